@@ -21,5 +21,6 @@ type AuthParams struct {
 	APIServer string  `json:"apiServer,omitempty"`
 	ProxyURL  *string `json:"proxyURL,omitempty"`
 
-	AwsConfig *AwsConfig `json:"awsConfig,omitempty"`
+	AwsConfig  *AwsConfig  `json:"awsConfig,omitempty"`
+	OIDCConfig *OIDCConfig `json:"oidcConfig,omitempty"`
 }
@@ -0,0 +1,24 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// OIDCConfig contains the OIDC token issued to the Liqo user by the identity provider trusted by the cluster API server.
+type OIDCConfig struct {
+	Token          string      `json:"token"`
+	IssueTime      metav1.Time `json:"issueTime"`
+	ExpirationTime metav1.Time `json:"expirationTime"`
+}
@@ -56,6 +56,23 @@ type ResourceSliceSpec struct {
 	Class ResourceSliceClass `json:"class,omitempty"`
 	// CSR is the Certificate Signing Request of the consumer cluster.
 	CSR []byte `json:"csr,omitempty"`
+	// Autoscaling enables the consumer cluster to automatically resize the ResourceSlice depending on the demand.
+	// +kubebuilder:validation:Optional
+	Autoscaling *ResourceSliceAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ResourceSliceAutoscaling defines the bounds within which the consumer cluster resizes the ResourceSlice.
+type ResourceSliceAutoscaling struct {
+	// MinResources is the minimum amount of resources requested through the ResourceSlice.
+	// +kubebuilder:validation:Optional
+	MinResources corev1.ResourceList `json:"minResources,omitempty"`
+	// MaxResources is the maximum amount of resources requested through the ResourceSlice.
+	// Only the resources listed here are resized.
+	MaxResources corev1.ResourceList `json:"maxResources"`
+	// ScaleDownDelay is the time that must elapse since the last resize, without pods waiting for resources,
+	// before shrinking the ResourceSlice.
+	// +kubebuilder:default="10m"
+	ScaleDownDelay metav1.Duration `json:"scaleDownDelay,omitempty"`
 }
 
 // ResourceSliceConditionType represents different types of conditions that a ResourceSlice could assume.
@@ -77,6 +94,8 @@ const (
 	ResourceSliceConditionAccepted ResourceSliceConditionStatus = "Accepted"
 	// ResourceSliceConditionDenied informs users that the resources are not available.
 	ResourceSliceConditionDenied ResourceSliceConditionStatus = "Denied"
+	// ResourceSliceConditionPending informs users that the resources are waiting for the approval of the provider cluster.
+	ResourceSliceConditionPending ResourceSliceConditionStatus = "Pending"
 )
 
 // ResourceSliceCondition contains details about the status of the provided ResourceSlice.
@@ -85,7 +104,7 @@ type ResourceSliceCondition struct {
 	// +kubebuilder:validation:Enum="Authentication";"Resources"
 	Type ResourceSliceConditionType `json:"type"`
 	// Status of the condition.
-	// +kubebuilder:validation:Enum="Accepted";"Denied"
+	// +kubebuilder:validation:Enum="Accepted";"Denied";"Pending"
 	Status ResourceSliceConditionStatus `json:"status"`
 	// LastTransitionTime -> timestamp for when the condition last transitioned from one status to another.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
@@ -109,10 +128,14 @@ type ResourceSliceStatus struct {
 	IngressClasses []liqov1beta1.IngressType `json:"ingressClasses,omitempty"`
 	// LoadBalancerClasses contains the list of the load balancer classes offered by the cluster.
 	LoadBalancerClasses []liqov1beta1.LoadBalancerType `json:"loadBalancerClasses,omitempty"`
+	// DeviceClasses contains the list of the device classes offered by the cluster, through Dynamic Resource Allocation.
+	DeviceClasses []liqov1beta1.DeviceClassType `json:"deviceClasses,omitempty"`
 	// NodeLabels contains the provider cluster labels.
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// NodeSelector contains the selector to be applied to offloaded pods.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations contains the tolerations to be applied to offloaded pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // +kubebuilder:object:root=true
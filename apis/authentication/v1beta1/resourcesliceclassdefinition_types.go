@@ -0,0 +1,78 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResourceSliceClassDefinitionResource is the name of the resourceSliceClassDefinition resources.
+var ResourceSliceClassDefinitionResource = "resourcesliceclassdefinitions"
+
+// ResourceSliceClassDefinitionKind specifies the kind of the resourceSliceClassDefinition.
+var ResourceSliceClassDefinitionKind = "ResourceSliceClassDefinition"
+
+// ResourceSliceClassDefinitionGroupResource is group resource used to register these objects.
+var ResourceSliceClassDefinitionGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: ResourceSliceClassDefinitionResource}
+
+// ResourceSliceClassDefinitionGroupVersionResource is groupResourceVersion used to register these objects.
+var ResourceSliceClassDefinitionGroupVersionResource = GroupVersion.WithResource(ResourceSliceClassDefinitionResource)
+
+// ResourceSliceClassDefinitionSpec defines the desired state of ResourceSliceClassDefinition.
+type ResourceSliceClassDefinitionSpec struct {
+	// NodeSelector selects the nodes of the provider cluster backing the class.
+	// It is also applied to the pods offloaded through the ResourceSlices of the class.
+	// If empty, all the nodes are selected.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are the tolerations allowing to select the tainted nodes of the provider cluster.
+	// They are also applied to the pods offloaded through the ResourceSlices of the class.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// OverprovisioningPercentage is the percentage of the allocatable resources of the selected nodes offered through
+	// the class (e.g., 150 to overprovision them by 50%, or 50 to offer only half of them).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=100
+	OverprovisioningPercentage int32 `json:"overprovisioningPercentage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=rsclass
+// +kubebuilder:printcolumn:name="Overprovisioning",type=integer,JSONPath=`.spec.overprovisioningPercentage`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ResourceSliceClassDefinition defines a ResourceSlice class offered by the provider cluster, named after the resource.
+// The resources granted to the ResourceSlices of the class are computed from the capacity of the selected nodes.
+type ResourceSliceClassDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResourceSliceClassDefinitionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceSliceClassDefinitionList contains a list of ResourceSliceClassDefinitions.
+type ResourceSliceClassDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceSliceClassDefinition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceSliceClassDefinition{}, &ResourceSliceClassDefinitionList{})
+}
@@ -0,0 +1,94 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// ResourceSlicePolicyResource is the name of the resourceSlicePolicy resources.
+var ResourceSlicePolicyResource = "resourceslicepolicies"
+
+// ResourceSlicePolicyKind specifies the kind of the resourceSlicePolicy.
+var ResourceSlicePolicyKind = "ResourceSlicePolicy"
+
+// ResourceSlicePolicyGroupResource is group resource used to register these objects.
+var ResourceSlicePolicyGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: ResourceSlicePolicyResource}
+
+// ResourceSlicePolicyGroupVersionResource is groupResourceVersion used to register these objects.
+var ResourceSlicePolicyGroupVersionResource = GroupVersion.WithResource(ResourceSlicePolicyResource)
+
+// ResourceSliceApprovalMode defines how the ResourceSlices subject to a policy are approved.
+type ResourceSliceApprovalMode string
+
+const (
+	// ResourceSliceApprovalAutomatic approves the ResourceSlices as soon as they satisfy the policy.
+	ResourceSliceApprovalAutomatic ResourceSliceApprovalMode = "Automatic"
+	// ResourceSliceApprovalManual requires each ResourceSlice (and each change to it) to be explicitly approved
+	// by the provider administrator (e.g., through liqoctl approve resourceslice).
+	ResourceSliceApprovalManual ResourceSliceApprovalMode = "Manual"
+)
+
+// ResourceSlicePolicySpec defines the desired state of ResourceSlicePolicy.
+type ResourceSlicePolicySpec struct {
+	// ConsumerClusterIDs is the list of consumer clusters the policy applies to.
+	// If empty, the policy applies to all the consumer clusters.
+	// +kubebuilder:validation:Optional
+	ConsumerClusterIDs []liqov1beta1.ClusterID `json:"consumerClusterIDs,omitempty"`
+	// MaxResources is the maximum amount of resources each consumer cluster can obtain, summing all its ResourceSlices.
+	// Resources not listed are not limited.
+	// +kubebuilder:validation:Optional
+	MaxResources corev1.ResourceList `json:"maxResources,omitempty"`
+	// AllowedClasses is the list of ResourceSlice classes the consumer clusters can request.
+	// If empty, all the classes are allowed.
+	// +kubebuilder:validation:Optional
+	AllowedClasses []ResourceSliceClass `json:"allowedClasses,omitempty"`
+	// ApprovalMode defines whether the ResourceSlices are approved automatically or require a manual approval.
+	// +kubebuilder:validation:Enum="Automatic";"Manual"
+	// +kubebuilder:default="Automatic"
+	ApprovalMode ResourceSliceApprovalMode `json:"approvalMode,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=rslicepolicy
+// +kubebuilder:printcolumn:name="Approval",type=string,JSONPath=`.spec.approvalMode`
+// +kubebuilder:printcolumn:name="Consumers",type=string,JSONPath=`.spec.consumerClusterIDs`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ResourceSlicePolicy defines the admission policy enforced by the provider cluster on the incoming ResourceSlices.
+// All the policies matching a consumer cluster are enforced.
+type ResourceSlicePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResourceSlicePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceSlicePolicyList contains a list of ResourceSlicePolicies.
+type ResourceSlicePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceSlicePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceSlicePolicy{}, &ResourceSlicePolicyList{})
+}
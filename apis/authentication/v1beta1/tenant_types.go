@@ -76,6 +76,22 @@ type TenantSpec struct {
 	// +kubebuilder:validation:Enum=Active;Cordoned;Drained
 	// +kubebuilder:default=Active
 	TenantCondition TenantCondition `json:"tenantCondition,omitempty"`
+	// RevokedIdentities is the list of identities granted to the tenant cluster that have been revoked.
+	// The credentials of a revoked identity are denied access to the local API server and are no longer renewed.
+	RevokedIdentities []RevokedIdentity `json:"revokedIdentities,omitempty"`
+}
+
+// RevokedIdentity identifies an identity granted to the tenant cluster that has been revoked.
+// +kubebuilder:validation:XValidation:rule="self.type == 'ResourceSlice' || !has(self.resourceSliceName)",message="resourceSliceName can be set only for ResourceSlice identities"
+type RevokedIdentity struct {
+	// Type is the type of the revoked identity.
+	// +kubebuilder:validation:Enum=ControlPlane;ResourceSlice
+	Type IdentityType `json:"type"`
+	// ResourceSliceName is the name of the ResourceSlice the revoked identity has been issued for.
+	// If empty, all the ResourceSlice identities of the tenant cluster are revoked.
+	ResourceSliceName string `json:"resourceSliceName,omitempty"`
+	// Reason is a human readable explanation of the revocation.
+	Reason string `json:"reason,omitempty"`
 }
 
 // TenantCondition contains the conditions of the tenant.
@@ -0,0 +1,85 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// TenantRBACTemplateResource is the name of the tenantRBACTemplate resources.
+var TenantRBACTemplateResource = "tenantrbactemplates"
+
+// TenantRBACTemplateKind specifies the kind of the tenantRBACTemplate.
+var TenantRBACTemplateKind = "TenantRBACTemplate"
+
+// TenantRBACTemplateGroupResource is group resource used to register these objects.
+var TenantRBACTemplateGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: TenantRBACTemplateResource}
+
+// TenantRBACTemplateGroupVersionResource is groupResourceVersion used to register these objects.
+var TenantRBACTemplateGroupVersionResource = GroupVersion.WithResource(TenantRBACTemplateResource)
+
+// TenantRBACTemplateSpec defines the desired state of TenantRBACTemplate.
+type TenantRBACTemplateSpec struct {
+	// ConsumerClusterIDs is the list of consumer clusters the template applies to.
+	// If empty, the template applies to all the consumer clusters.
+	// +kubebuilder:validation:Optional
+	ConsumerClusterIDs []liqov1beta1.ClusterID `json:"consumerClusterIDs,omitempty"`
+	// ControlPlaneRules are the permissions granted to the control plane identity of the consumer in its tenant namespace.
+	// If empty, the default permissions are granted.
+	// +kubebuilder:validation:Optional
+	ControlPlaneRules []rbacv1.PolicyRule `json:"controlPlaneRules,omitempty"`
+	// OffloadedNamespaceRules are the permissions granted to the identities of the consumer in the namespaces
+	// it offloads to the provider (e.g., to reflect services and secrets).
+	// If empty, the default permissions are granted.
+	// +kubebuilder:validation:Optional
+	OffloadedNamespaceRules []rbacv1.PolicyRule `json:"offloadedNamespaceRules,omitempty"`
+	// ForbiddenServiceTypes is the list of service types the consumer is not allowed to create in the offloaded namespaces
+	// (e.g., LoadBalancer), which cannot be expressed through RBAC rules.
+	// +kubebuilder:validation:Optional
+	ForbiddenServiceTypes []corev1.ServiceType `json:"forbiddenServiceTypes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=trbac
+// +kubebuilder:printcolumn:name="Consumers",type=string,JSONPath=`.spec.consumerClusterIDs`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TenantRBACTemplate defines the permissions granted by the provider cluster to the identities of the consumer clusters.
+// When multiple templates match a consumer cluster, the ones explicitly listing it take precedence over the others,
+// and ties are broken by name.
+type TenantRBACTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TenantRBACTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantRBACTemplateList contains a list of TenantRBACTemplates.
+type TenantRBACTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantRBACTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantRBACTemplate{}, &TenantRBACTemplateList{})
+}
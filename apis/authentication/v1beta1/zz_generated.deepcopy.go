@@ -21,6 +21,7 @@ package v1beta1
 import (
 	corev1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -47,6 +48,11 @@ func (in *AuthParams) DeepCopyInto(out *AuthParams) {
 		*out = new(AwsConfig)
 		**out = **in
 	}
+	if in.OIDCConfig != nil {
+		in, out := &in.OIDCConfig, &out.OIDCConfig
+		*out = new(OIDCConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthParams.
@@ -174,6 +180,23 @@ func (in *IdentityStatus) DeepCopy() *IdentityStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	in.IssueTime.DeepCopyInto(&out.IssueTime)
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Renew) DeepCopyInto(out *Renew) {
 	*out = *in
@@ -310,6 +333,123 @@ func (in *ResourceSlice) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSliceAutoscaling) DeepCopyInto(out *ResourceSliceAutoscaling) {
+	*out = *in
+	if in.MinResources != nil {
+		in, out := &in.MinResources, &out.MinResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxResources != nil {
+		in, out := &in.MaxResources, &out.MaxResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	out.ScaleDownDelay = in.ScaleDownDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceAutoscaling.
+func (in *ResourceSliceAutoscaling) DeepCopy() *ResourceSliceAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSliceAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSliceClassDefinition) DeepCopyInto(out *ResourceSliceClassDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceClassDefinition.
+func (in *ResourceSliceClassDefinition) DeepCopy() *ResourceSliceClassDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSliceClassDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSliceClassDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSliceClassDefinitionList) DeepCopyInto(out *ResourceSliceClassDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceSliceClassDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceClassDefinitionList.
+func (in *ResourceSliceClassDefinitionList) DeepCopy() *ResourceSliceClassDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSliceClassDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSliceClassDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSliceClassDefinitionSpec) DeepCopyInto(out *ResourceSliceClassDefinitionSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceClassDefinitionSpec.
+func (in *ResourceSliceClassDefinitionSpec) DeepCopy() *ResourceSliceClassDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSliceClassDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSliceCondition) DeepCopyInto(out *ResourceSliceCondition) {
 	*out = *in
@@ -358,6 +498,96 @@ func (in *ResourceSliceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSlicePolicy) DeepCopyInto(out *ResourceSlicePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSlicePolicy.
+func (in *ResourceSlicePolicy) DeepCopy() *ResourceSlicePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSlicePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSlicePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSlicePolicyList) DeepCopyInto(out *ResourceSlicePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceSlicePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSlicePolicyList.
+func (in *ResourceSlicePolicyList) DeepCopy() *ResourceSlicePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSlicePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSlicePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSlicePolicySpec) DeepCopyInto(out *ResourceSlicePolicySpec) {
+	*out = *in
+	if in.ConsumerClusterIDs != nil {
+		in, out := &in.ConsumerClusterIDs, &out.ConsumerClusterIDs
+		*out = make([]corev1beta1.ClusterID, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxResources != nil {
+		in, out := &in.MaxResources, &out.MaxResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AllowedClasses != nil {
+		in, out := &in.AllowedClasses, &out.AllowedClasses
+		*out = make([]ResourceSliceClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSlicePolicySpec.
+func (in *ResourceSlicePolicySpec) DeepCopy() *ResourceSlicePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSlicePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSliceSpec) DeepCopyInto(out *ResourceSliceSpec) {
 	*out = *in
@@ -383,6 +613,11 @@ func (in *ResourceSliceSpec) DeepCopyInto(out *ResourceSliceSpec) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ResourceSliceAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceSpec.
@@ -432,6 +667,13 @@ func (in *ResourceSliceStatus) DeepCopyInto(out *ResourceSliceStatus) {
 		*out = make([]corev1beta1.LoadBalancerType, len(*in))
 		copy(*out, *in)
 	}
+	if in.DeviceClasses != nil {
+		in, out := &in.DeviceClasses, &out.DeviceClasses
+		*out = make([]corev1beta1.DeviceClassType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NodeLabels != nil {
 		in, out := &in.NodeLabels, &out.NodeLabels
 		*out = make(map[string]string, len(*in))
@@ -446,6 +688,13 @@ func (in *ResourceSliceStatus) DeepCopyInto(out *ResourceSliceStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceStatus.
@@ -458,6 +707,21 @@ func (in *ResourceSliceStatus) DeepCopy() *ResourceSliceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevokedIdentity) DeepCopyInto(out *RevokedIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevokedIdentity.
+func (in *RevokedIdentity) DeepCopy() *RevokedIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(RevokedIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
@@ -517,6 +781,103 @@ func (in *TenantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRBACTemplate) DeepCopyInto(out *TenantRBACTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRBACTemplate.
+func (in *TenantRBACTemplate) DeepCopy() *TenantRBACTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRBACTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantRBACTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRBACTemplateList) DeepCopyInto(out *TenantRBACTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantRBACTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRBACTemplateList.
+func (in *TenantRBACTemplateList) DeepCopy() *TenantRBACTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRBACTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantRBACTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRBACTemplateSpec) DeepCopyInto(out *TenantRBACTemplateSpec) {
+	*out = *in
+	if in.ConsumerClusterIDs != nil {
+		in, out := &in.ConsumerClusterIDs, &out.ConsumerClusterIDs
+		*out = make([]corev1beta1.ClusterID, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneRules != nil {
+		in, out := &in.ControlPlaneRules, &out.ControlPlaneRules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OffloadedNamespaceRules != nil {
+		in, out := &in.OffloadedNamespaceRules, &out.OffloadedNamespaceRules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ForbiddenServiceTypes != nil {
+		in, out := &in.ForbiddenServiceTypes, &out.ForbiddenServiceTypes
+		*out = make([]v1.ServiceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRBACTemplateSpec.
+func (in *TenantRBACTemplateSpec) DeepCopy() *TenantRBACTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRBACTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
@@ -545,6 +906,11 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RevokedIdentities != nil {
+		in, out := &in.RevokedIdentities, &out.RevokedIdentities
+		*out = make([]RevokedIdentity, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSpec.
@@ -14,6 +14,10 @@
 
 package v1beta1
 
+import (
+	resourcev1 "k8s.io/api/resource/v1"
+)
+
 // StorageType defines the type of storage offered by a resource offer.
 type StorageType struct {
 	// StorageClassName indicates the name of the storage class.
@@ -37,3 +41,16 @@ type LoadBalancerType struct {
 	// Default indicates whether this load balancer class is the default load balancer class for Liqo.
 	Default bool `json:"default,omitempty"`
 }
+
+// DeviceClassType defines the type of device class offered by a resource offer, through Dynamic Resource Allocation.
+type DeviceClassType struct {
+	// DeviceClassName indicates the name of the device class.
+	DeviceClassName string `json:"deviceClassName"`
+	// Driver indicates the name of the DRA driver exposing the devices of the class.
+	Driver string `json:"driver"`
+	// Devices indicates the number of devices of the class offered by the cluster.
+	Devices int64 `json:"devices"`
+	// Attributes contains the attributes shared by all the devices of the class offered by the cluster.
+	// +optional
+	Attributes map[resourcev1.QualifiedName]resourcev1.DeviceAttribute `json:"attributes,omitempty"`
+}
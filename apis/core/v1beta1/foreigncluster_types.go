@@ -134,6 +134,8 @@ const (
 	ConditionStatusPending ConditionStatusType = "Pending"
 	// ConditionStatusEstablished indicates that the condition has been established.
 	ConditionStatusEstablished ConditionStatusType = "Established"
+	// ConditionStatusDegraded indicates that the condition has been established, but it does not meet the expected quality.
+	ConditionStatusDegraded ConditionStatusType = "Degraded"
 	// ConditionStatusError indicates that an error has occurred.
 	ConditionStatusError ConditionStatusType = "Error"
 	// ConditionStatusReady indicates that the condition is ready.
@@ -152,7 +154,7 @@ type Condition struct {
 	//nolint:lll // ignore long lines given by Kubebuilder marker annotations
 	Type ConditionType `json:"type"`
 	// Status of the condition.
-	// +kubebuilder:validation:Enum="None";"Pending";"Established";"Error";"Ready";"NotReady";"SomeNotReady"
+	// +kubebuilder:validation:Enum="None";"Pending";"Established";"Degraded";"Error";"Ready";"NotReady";"SomeNotReady"
 	// +kubebuilder:default="None"
 	Status ConditionStatusType `json:"status"`
 	// LastTransitionTime -> timestamp for when the condition last transitioned from one status to another.
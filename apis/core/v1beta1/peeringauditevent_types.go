@@ -0,0 +1,107 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PeeringAuditOperation is the operation performed by a consumer cluster on a resource of the provider.
+type PeeringAuditOperation string
+
+const (
+	// PeeringAuditOperationCreate indicates the creation of a resource.
+	PeeringAuditOperationCreate PeeringAuditOperation = "Create"
+	// PeeringAuditOperationUpdate indicates the update of a resource.
+	PeeringAuditOperationUpdate PeeringAuditOperation = "Update"
+	// PeeringAuditOperationDelete indicates the deletion of a resource.
+	PeeringAuditOperationDelete PeeringAuditOperation = "Delete"
+	// PeeringAuditOperationConnect indicates a connection to a resource (e.g., exec and attach to a pod).
+	PeeringAuditOperationConnect PeeringAuditOperation = "Connect"
+)
+
+// PeeringAuditEventSpec defines the desired state of PeeringAuditEvent.
+type PeeringAuditEventSpec struct {
+	// ClusterID is the ID of the consumer cluster which performed the operation.
+	ClusterID ClusterID `json:"clusterID"`
+	// User is the name of the identity of the consumer cluster which performed the operation.
+	User string `json:"user"`
+	// Operation is the operation performed on the resource.
+	// +kubebuilder:validation:Enum="Create";"Update";"Delete";"Connect"
+	Operation PeeringAuditOperation `json:"operation"`
+	// Resource is the resource the operation was performed on.
+	Resource PeeringAuditResource `json:"resource"`
+	// FirstTimestamp is the time the operation was first observed.
+	FirstTimestamp metav1.Time `json:"firstTimestamp"`
+	// LastTimestamp is the time the operation was most recently observed.
+	LastTimestamp metav1.Time `json:"lastTimestamp"`
+	// Count is the number of times the operation has been observed.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+	// AppliedBatches are the digests of the audit IDs of the most recently applied batches of occurrences,
+	// to avoid counting them twice when the same batch is sent again.
+	// +kubebuilder:validation:Optional
+	AppliedBatches []string `json:"appliedBatches,omitempty"`
+}
+
+// PeeringAuditResource identifies the resource target of an audited operation.
+type PeeringAuditResource struct {
+	// Group is the API group of the resource.
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+	// Resource is the name of the resource type (e.g., services).
+	Resource string `json:"resource"`
+	// Subresource is the name of the subresource, if any (e.g., exec).
+	// +kubebuilder:validation:Optional
+	Subresource string `json:"subresource,omitempty"`
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource. It may be empty for resources created with a generated name.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=pae
+// +kubebuilder:printcolumn:name="ClusterID",type=string,JSONPath=`.spec.clusterID`
+// +kubebuilder:printcolumn:name="User",type=string,priority=1,JSONPath=`.spec.user`
+// +kubebuilder:printcolumn:name="Operation",type=string,JSONPath=`.spec.operation`
+// +kubebuilder:printcolumn:name="Resource",type=string,JSONPath=`.spec.resource.resource`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.resource.namespace`
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.resource.name`
+// +kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.spec.count`
+// +kubebuilder:printcolumn:name="Last Seen",type=date,JSONPath=`.spec.lastTimestamp`
+
+// PeeringAuditEvent records an operation performed by a consumer cluster on the resources of the provider cluster.
+// Repeated operations on the same resource are aggregated in a single event.
+type PeeringAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PeeringAuditEventSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PeeringAuditEventList contains a list of PeeringAuditEvents.
+type PeeringAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PeeringAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PeeringAuditEvent{}, &PeeringAuditEventList{})
+}
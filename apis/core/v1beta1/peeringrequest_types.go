@@ -0,0 +1,141 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PeeringRequestSpec defines the desired state of PeeringRequest.
+type PeeringRequestSpec struct {
+	// InvitationSecretRef is the reference to the Secret, in the same namespace of the PeeringRequest,
+	// containing the invitation token generated by the provider cluster (under the "token" key).
+	InvitationSecretRef corev1.LocalObjectReference `json:"invitationSecretRef"`
+	// ProviderKeyFingerprint is the fingerprint (i.e., the hex-encoded SHA-256 digest) of the authentication public
+	// key of the provider cluster, which is used to verify the invitation token. It shall be obtained from the provider
+	// cluster administrator through a channel other than the one used to share the token.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{64}$`
+	ProviderKeyFingerprint string `json:"providerKeyFingerprint"`
+	// Networking contains the configuration of the inter-cluster network.
+	// +kubebuilder:validation:Optional
+	Networking PeeringRequestNetworking `json:"networking,omitempty"`
+	// ResourceSlice contains the configuration of the ResourceSlice to be created towards the provider cluster.
+	// If not set, no ResourceSlice is created and only networking and authentication are established.
+	// +kubebuilder:validation:Optional
+	ResourceSlice *PeeringRequestResourceSlice `json:"resourceSlice,omitempty"`
+}
+
+// PeeringRequestNetworking contains the configuration of the inter-cluster network of a PeeringRequest.
+type PeeringRequestNetworking struct {
+	// Disabled disables the configuration of the inter-cluster network.
+	// +kubebuilder:validation:Optional
+	Disabled bool `json:"disabled,omitempty"`
+	// GatewayServerLocation is the cluster hosting the gateway server.
+	// +kubebuilder:validation:Enum="Consumer";"Provider"
+	// +kubebuilder:default="Provider"
+	GatewayServerLocation RoleType `json:"gatewayServerLocation,omitempty"`
+	// ServiceType is the type of the Service exposing the gateway server.
+	// +kubebuilder:validation:Enum="LoadBalancer";"NodePort";"ClusterIP"
+	// +kubebuilder:default="LoadBalancer"
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+	// MTU is the MTU of the inter-cluster network interfaces. If not set, the default value is used.
+	// +kubebuilder:validation:Optional
+	MTU int `json:"mtu,omitempty"`
+}
+
+// PeeringRequestResourceSlice contains the configuration of the ResourceSlice of a PeeringRequest.
+type PeeringRequestResourceSlice struct {
+	// Class is the class of the ResourceSlice.
+	// +kubebuilder:validation:Optional
+	Class string `json:"class,omitempty"`
+	// Resources contains the resources requested to the provider cluster.
+	// If not set, the default resources are requested.
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+	// DisableVirtualNodeCreation disables the creation of the VirtualNode associated with the ResourceSlice.
+	// +kubebuilder:validation:Optional
+	DisableVirtualNodeCreation bool `json:"disableVirtualNodeCreation,omitempty"`
+}
+
+// PeeringRequestPhase is the phase of a PeeringRequest.
+type PeeringRequestPhase string
+
+const (
+	// PeeringRequestPhasePending indicates that the PeeringRequest has not been processed yet.
+	PeeringRequestPhasePending PeeringRequestPhase = "Pending"
+	// PeeringRequestPhaseNetworking indicates that the inter-cluster network is being established.
+	PeeringRequestPhaseNetworking PeeringRequestPhase = "Networking"
+	// PeeringRequestPhaseAuthenticating indicates that the authentication with the provider cluster is being established.
+	PeeringRequestPhaseAuthenticating PeeringRequestPhase = "Authenticating"
+	// PeeringRequestPhaseOffloading indicates that the ResourceSlice is being created.
+	PeeringRequestPhaseOffloading PeeringRequestPhase = "Offloading"
+	// PeeringRequestPhaseEstablished indicates that the peering has been established.
+	PeeringRequestPhaseEstablished PeeringRequestPhase = "Established"
+	// PeeringRequestPhaseFailed indicates that the peering could not be established.
+	PeeringRequestPhaseFailed PeeringRequestPhase = "Failed"
+)
+
+// PeeringRequestStatus defines the observed state of PeeringRequest.
+type PeeringRequestStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase is the current phase of the PeeringRequest.
+	// +kubebuilder:validation:Enum="Pending";"Networking";"Authenticating";"Offloading";"Established";"Failed"
+	// +kubebuilder:validation:Optional
+	Phase PeeringRequestPhase `json:"phase,omitempty"`
+	// RemoteClusterID is the cluster ID of the provider cluster, as reported by the invitation.
+	// +kubebuilder:validation:Optional
+	RemoteClusterID ClusterID `json:"remoteClusterID,omitempty"`
+	// ExpirationTime is the expiration time of the invitation.
+	// +kubebuilder:validation:Optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// Message contains a human readable message about the current phase.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=pr
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="RemoteClusterID",type=string,JSONPath=`.status.remoteClusterID`
+// +kubebuilder:printcolumn:name="Expiration",type=date,priority=1,JSONPath=`.status.expirationTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PeeringRequest is the Schema for the peeringrequests API.
+// It represents the declarative request to peer the local cluster (consumer) with the provider cluster
+// that issued the referenced invitation.
+type PeeringRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PeeringRequestSpec   `json:"spec,omitempty"`
+	Status PeeringRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PeeringRequestList contains a list of PeeringRequest.
+type PeeringRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PeeringRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PeeringRequest{}, &PeeringRequestList{})
+}
@@ -19,6 +19,8 @@
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -38,6 +40,28 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClassType) DeepCopyInto(out *DeviceClassType) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[resourcev1.QualifiedName]resourcev1.DeviceAttribute, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClassType.
+func (in *DeviceClassType) DeepCopy() *DeviceClassType {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceClassType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForeignCluster) DeepCopyInto(out *ForeignCluster) {
 	*out = *in
@@ -206,6 +230,239 @@ func (in *Modules) DeepCopy() *Modules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAuditEvent) DeepCopyInto(out *PeeringAuditEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAuditEvent.
+func (in *PeeringAuditEvent) DeepCopy() *PeeringAuditEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAuditEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringAuditEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAuditEventList) DeepCopyInto(out *PeeringAuditEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringAuditEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAuditEventList.
+func (in *PeeringAuditEventList) DeepCopy() *PeeringAuditEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAuditEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringAuditEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAuditEventSpec) DeepCopyInto(out *PeeringAuditEventSpec) {
+	*out = *in
+	out.Resource = in.Resource
+	in.FirstTimestamp.DeepCopyInto(&out.FirstTimestamp)
+	in.LastTimestamp.DeepCopyInto(&out.LastTimestamp)
+	if in.AppliedBatches != nil {
+		in, out := &in.AppliedBatches, &out.AppliedBatches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAuditEventSpec.
+func (in *PeeringAuditEventSpec) DeepCopy() *PeeringAuditEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAuditEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringAuditResource) DeepCopyInto(out *PeeringAuditResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringAuditResource.
+func (in *PeeringAuditResource) DeepCopy() *PeeringAuditResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringAuditResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequest) DeepCopyInto(out *PeeringRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequest.
+func (in *PeeringRequest) DeepCopy() *PeeringRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequestList) DeepCopyInto(out *PeeringRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequestList.
+func (in *PeeringRequestList) DeepCopy() *PeeringRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequestNetworking) DeepCopyInto(out *PeeringRequestNetworking) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequestNetworking.
+func (in *PeeringRequestNetworking) DeepCopy() *PeeringRequestNetworking {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequestNetworking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequestResourceSlice) DeepCopyInto(out *PeeringRequestResourceSlice) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequestResourceSlice.
+func (in *PeeringRequestResourceSlice) DeepCopy() *PeeringRequestResourceSlice {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequestResourceSlice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequestSpec) DeepCopyInto(out *PeeringRequestSpec) {
+	*out = *in
+	out.InvitationSecretRef = in.InvitationSecretRef
+	out.Networking = in.Networking
+	if in.ResourceSlice != nil {
+		in, out := &in.ResourceSlice, &out.ResourceSlice
+		*out = new(PeeringRequestResourceSlice)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequestSpec.
+func (in *PeeringRequestSpec) DeepCopy() *PeeringRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRequestStatus) DeepCopyInto(out *PeeringRequestStatus) {
+	*out = *in
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRequestStatus.
+func (in *PeeringRequestStatus) DeepCopy() *PeeringRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageType) DeepCopyInto(out *StorageType) {
 	*out = *in
@@ -14,6 +14,10 @@
 
 package v1beta1
 
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -32,3 +36,41 @@ type IP string
 func (i IP) String() string {
 	return string(i)
 }
+
+// QoS defines the traffic shaping and prioritization applied to the traffic sent through the tunnel.
+// +kubebuilder:validation:XValidation:rule="!has(self.classes) || size(self.classes) == 0 || has(self.bandwidth)",message="bandwidth must be set when classes are defined"
+//
+//nolint:lll // ignore long lines given by Kubebuilder marker annotations
+type QoS struct {
+	// Bandwidth specifies the maximum bandwidth (in bits per second, e.g., 100M) of the traffic sent through the tunnel.
+	// +optional
+	Bandwidth *resource.Quantity `json:"bandwidth,omitempty"`
+	// Classes specifies the priority classes sharing the bandwidth, selected according to the DSCP value of the packets.
+	// The packets not matching any class are assigned to a default class with the lowest priority.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Classes []QoSClass `json:"classes,omitempty"`
+}
+
+// QoSClass defines a priority class of the traffic sent through the tunnel.
+type QoSClass struct {
+	// Name is the name of the class.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Priority specifies the priority of the class. Lower values are served first.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	Priority int32 `json:"priority"`
+	// DSCP specifies the DSCP values of the packets belonging to the class.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=63
+	DSCP []int32 `json:"dscp"`
+	// Rate specifies the bandwidth (in bits per second) guaranteed to the class.
+	// The class can borrow the bandwidth left unused by the other ones, up to the overall limit.
+	// +optional
+	Rate *resource.Quantity `json:"rate,omitempty"`
+}
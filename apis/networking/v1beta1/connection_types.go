@@ -72,12 +72,47 @@ type ConnectionLatency struct {
 	Timestamp metav1.Time `json:"timestamp,omitempty"`
 }
 
+// ConnectionHealth represents the quality of the connection between two clusters, computed over the last pings.
+type ConnectionHealth struct {
+	// PacketLoss is the percentage of pings lost.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	PacketLoss int32 `json:"packetLoss"`
+	// MinRTT is the minimum round-trip time.
+	MinRTT metav1.Duration `json:"minRTT,omitempty"`
+	// AvgRTT is the average round-trip time.
+	AvgRTT metav1.Duration `json:"avgRTT,omitempty"`
+	// MaxRTT is the maximum round-trip time.
+	MaxRTT metav1.Duration `json:"maxRTT,omitempty"`
+	// Jitter is the average variation of the round-trip time between consecutive pings.
+	Jitter metav1.Duration `json:"jitter,omitempty"`
+	// Samples is the number of pings the health has been computed over.
+	Samples int32 `json:"samples,omitempty"`
+}
+
+// ConnectionPathMTU represents the path MTU discovered between two clusters.
+type ConnectionPathMTU struct {
+	// Value of the path MTU, i.e., the largest packet that can traverse the tunnel without being fragmented.
+	Value int `json:"value,omitempty"`
+	// Applied specifies whether the path MTU has been applied to the tunnel interfaces,
+	// and hence whether it should be used for the internal fabric as well.
+	Applied bool `json:"applied,omitempty"`
+	// Timestamp of the path MTU discovery.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
 // ConnectionStatus defines the observed state of Connection.
 type ConnectionStatus struct {
 	// Value of the connection.
 	Value ConnectionStatusValue `json:"value,omitempty"`
 	// Latency of the connection.
 	Latency ConnectionLatency `json:"latency,omitempty"`
+	// Health of the connection, if the ping check is enabled.
+	Health *ConnectionHealth `json:"health,omitempty"`
+	// LastTransitionTime is the last time the value of the connection changed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// PathMTU of the connection, if path MTU discovery is enabled.
+	PathMTU *ConnectionPathMTU `json:"pathMTU,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -87,6 +122,9 @@ type ConnectionStatus struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.value`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:printcolumn:name="Latency",type=string,JSONPath=`.status.latency.value`,priority=1
+// +kubebuilder:printcolumn:name="Loss",type=integer,JSONPath=`.status.health.packetLoss`,priority=1
+// +kubebuilder:printcolumn:name="Jitter",type=string,JSONPath=`.status.health.jitter`,priority=1
+// +kubebuilder:printcolumn:name="PathMTU",type=integer,JSONPath=`.status.pathMTU.value`,priority=1
 
 // Connection contains the status of a connection between two clusters (a client and a server).
 type Connection struct {
@@ -41,6 +41,9 @@ type GatewayClientSpec struct {
 	ClientTemplateRef corev1.ObjectReference `json:"clientTemplateRef,omitempty"`
 	// MTU specifies the MTU of the tunnel.
 	MTU int `json:"mtu,omitempty"`
+	// QoS specifies the bandwidth limit and the priority classes applied to the traffic sent through the tunnel.
+	// +optional
+	QoS *QoS `json:"qos,omitempty"`
 	// Endpoint specifies the endpoint of the tunnel.
 	Endpoint EndpointStatus `json:"endpoint,omitempty"`
 	// SecretRef specifies the reference to the secret containing configurations.
@@ -74,6 +74,9 @@ type GatewayServerSpec struct {
 	ServerTemplateRef corev1.ObjectReference `json:"serverTemplateRef,omitempty"`
 	// MTU specifies the MTU of the tunnel.
 	MTU int `json:"mtu,omitempty"`
+	// QoS specifies the bandwidth limit and the priority classes applied to the traffic sent through the tunnel.
+	// +optional
+	QoS *QoS `json:"qos,omitempty"`
 	// Endpoint specifies the endpoint of the tunnel.
 	Endpoint Endpoint `json:"endpoint,omitempty"`
 	// SecretRef specifies the reference to the secret containing configurations.
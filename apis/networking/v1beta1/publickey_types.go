@@ -40,18 +40,44 @@ type PublicKeySpec struct {
 	PublicKey []byte `json:"publicKey,omitempty"`
 }
 
+// PublicKeyPhase defines the phase of the PublicKey.
+// +kubebuilder:validation:Enum=Pending;Applied
+type PublicKeyPhase string
+
+const (
+	// PublicKeyPhasePending means that the public key has been configured alongside the previous one,
+	// and it is waiting for the remote gateway to start using it.
+	PublicKeyPhasePending PublicKeyPhase = "Pending"
+	// PublicKeyPhaseApplied means that the public key is the only one in use.
+	PublicKeyPhaseApplied PublicKeyPhase = "Applied"
+)
+
+// PublicKeyStatus defines the observed state of PublicKey.
+type PublicKeyStatus struct {
+	// Phase is the phase of the public key.
+	Phase PublicKeyPhase `json:"phase,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the gateway.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastUpdateTime is the last time the phase changed.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
 // publickeies is used for resource name pluralization because k8s api do not manage false friends.
 // Waiting for this fix https://github.com/kubernetes-sigs/kubebuilder/pull/3408
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=liqo,path=publickeies,shortName=pk;pkies;pkey
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PublicKey contains a public key data required by some interconnection technologies.
 type PublicKey struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec PublicKeySpec `json:"spec,omitempty"`
+	Spec   PublicKeySpec   `json:"spec,omitempty"`
+	Status PublicKeyStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
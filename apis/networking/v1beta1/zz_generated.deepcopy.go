@@ -191,6 +191,25 @@ func (in *Connection) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionHealth) DeepCopyInto(out *ConnectionHealth) {
+	*out = *in
+	out.MinRTT = in.MinRTT
+	out.AvgRTT = in.AvgRTT
+	out.MaxRTT = in.MaxRTT
+	out.Jitter = in.Jitter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionHealth.
+func (in *ConnectionHealth) DeepCopy() *ConnectionHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionLatency) DeepCopyInto(out *ConnectionLatency) {
 	*out = *in
@@ -239,6 +258,22 @@ func (in *ConnectionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionPathMTU) DeepCopyInto(out *ConnectionPathMTU) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionPathMTU.
+func (in *ConnectionPathMTU) DeepCopy() *ConnectionPathMTU {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionPathMTU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionSpec) DeepCopyInto(out *ConnectionSpec) {
 	*out = *in
@@ -259,6 +294,20 @@ func (in *ConnectionSpec) DeepCopy() *ConnectionSpec {
 func (in *ConnectionStatus) DeepCopyInto(out *ConnectionStatus) {
 	*out = *in
 	in.Latency.DeepCopyInto(&out.Latency)
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(ConnectionHealth)
+		**out = **in
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PathMTU != nil {
+		in, out := &in.PathMTU, &out.PathMTU
+		*out = new(ConnectionPathMTU)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStatus.
@@ -514,6 +563,11 @@ func (in *GatewayClientList) DeepCopyObject() runtime.Object {
 func (in *GatewayClientSpec) DeepCopyInto(out *GatewayClientSpec) {
 	*out = *in
 	out.ClientTemplateRef = in.ClientTemplateRef
+	if in.QoS != nil {
+		in, out := &in.QoS, &out.QoS
+		*out = new(QoS)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	out.SecretRef = in.SecretRef
 }
@@ -621,8 +675,27 @@ func (in *GatewayServerList) DeepCopyObject() runtime.Object {
 func (in *GatewayServerSpec) DeepCopyInto(out *GatewayServerSpec) {
 	*out = *in
 	out.ServerTemplateRef = in.ServerTemplateRef
+	if in.QoS != nil {
+		in, out := &in.QoS, &out.QoS
+		*out = new(QoS)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	out.SecretRef = in.SecretRef
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLabels != nil {
+		in, out := &in.ServiceLabels, &out.ServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServerSpec.
@@ -1098,6 +1171,7 @@ func (in *PublicKey) DeepCopyInto(out *PublicKey) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKey.
@@ -1170,6 +1244,77 @@ func (in *PublicKeySpec) DeepCopy() *PublicKeySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicKeyStatus) DeepCopyInto(out *PublicKeyStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyStatus.
+func (in *PublicKeyStatus) DeepCopy() *PublicKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoS) DeepCopyInto(out *QoS) {
+	*out = *in
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]QoSClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoS.
+func (in *QoS) DeepCopy() *QoS {
+	if in == nil {
+		return nil
+	}
+	out := new(QoS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSClass) DeepCopyInto(out *QoSClass) {
+	*out = *in
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSClass.
+func (in *QoSClass) DeepCopy() *QoSClass {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -0,0 +1,79 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+)
+
+// ExportedEndpointSlice contains the information about an EndpointSlice of an exported Service.
+type ExportedEndpointSlice struct {
+	// Name is the name of the EndpointSlice in the exporting cluster.
+	Name string `json:"name"`
+	// Template contains the endpoints and the ports of the EndpointSlice.
+	Template EndpointSliceTemplate `json:"template,omitempty"`
+}
+
+// ExportedServiceSpec defines the desired state of ExportedService.
+type ExportedServiceSpec struct {
+	// ServiceNamespace is the namespace of the exported Service.
+	// The corresponding ServiceImport is created in the namespace with the same name in the importing cluster.
+	ServiceNamespace string `json:"serviceNamespace"`
+	// ServiceName is the name of the exported Service.
+	ServiceName string `json:"serviceName"`
+	// Type is the type of the corresponding ServiceImport (i.e., ClusterSetIP or Headless).
+	// +kubebuilder:validation:Enum="ClusterSetIP";"Headless"
+	Type mcsv1alpha1.ServiceImportType `json:"type"`
+	// Ports are the ports exposed by the exported Service.
+	Ports []mcsv1alpha1.ServicePort `json:"ports,omitempty"`
+	// SessionAffinity is the session affinity of the exported Service.
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// SessionAffinityConfig contains the session affinity configuration of the exported Service.
+	SessionAffinityConfig *corev1.SessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
+	// EndpointSlices contains the EndpointSlices of the exported Service, whose addresses
+	// are remapped by the importing cluster according to the network configuration.
+	EndpointSlices []ExportedEndpointSlice `json:"endpointSlices,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=expsvc;expsvcs
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.serviceNamespace`
+// +kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.spec.serviceName`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ExportedService is the Schema for the ExportedServices API, which describes a Service exported
+// to a peer cluster through a ServiceExport of the Multi-Cluster Services API.
+type ExportedService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExportedServiceSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExportedServiceList contains a list of ExportedService.
+type ExportedServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExportedService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExportedService{}, &ExportedServiceList{})
+}
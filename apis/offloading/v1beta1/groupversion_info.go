@@ -83,6 +83,24 @@ var (
 	// ShadowEndpointSliceGroupVersionResource is groupResourceVersion used to register these objects.
 	ShadowEndpointSliceGroupVersionResource = SchemeGroupVersion.WithResource(ShadowEndpointSliceResource)
 
+	// ShadowWorkloadResource is the resource name used to register the ShadowWorkload CRD.
+	ShadowWorkloadResource = "shadowworkloads"
+
+	// ShadowWorkloadGroupResource is group resource used to register these objects.
+	ShadowWorkloadGroupResource = schema.GroupResource{Group: SchemeGroupVersion.Group, Resource: ShadowWorkloadResource}
+
+	// ShadowWorkloadGroupVersionResource is groupResourceVersion used to register these objects.
+	ShadowWorkloadGroupVersionResource = SchemeGroupVersion.WithResource(ShadowWorkloadResource)
+
+	// ExportedServiceResource is the resource name used to register the ExportedService CRD.
+	ExportedServiceResource = "exportedservices"
+
+	// ExportedServiceGroupResource is group resource used to register these objects.
+	ExportedServiceGroupResource = schema.GroupResource{Group: SchemeGroupVersion.Group, Resource: ExportedServiceResource}
+
+	// ExportedServiceGroupVersionResource is groupResourceVersion used to register these objects.
+	ExportedServiceGroupVersionResource = SchemeGroupVersion.WithResource(ExportedServiceResource)
+
 	// VkOptionsTemplateResource is the resource name used to register the VkOptionsTemplate CRD.
 	VkOptionsTemplateResource = "vkoptionstemplates"
 
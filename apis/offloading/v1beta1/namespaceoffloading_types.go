@@ -17,6 +17,8 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 )
 
 // OffloadingPhaseType represents different namespaces offloading status.
@@ -109,6 +111,16 @@ type NamespaceOffloadingSpec struct {
 	// This field is required if NamespaceMappingStrategy is set to "SelectedName". It is ignored otherwise.
 	RemoteNamespaceName string `json:"remoteNamespaceName,omitempty"`
 
+	// ClusterMappings allows users to override the NamespaceMappingStrategy (and the RemoteNamespaceName)
+	// for specific remote clusters, so that the namespace can be assigned different names on providers
+	// with different naming policies. Clusters not listed here fall back to the global configuration.
+	// Existing entries cannot be modified or removed, and new ones can be added only for the clusters
+	// the namespace is not yet offloaded to.
+	// +listType=map
+	// +listMapKey=clusterID
+	// +kubebuilder:validation:Optional
+	ClusterMappings []ClusterNamespaceMapping `json:"clusterMappings,omitempty"`
+
 	// PodOffloadingStrategy allows users to configure how pods in this namespace are offloaded, according to three
 	// different strategies: "Local" (i.e. no pod offloading is performed), "Remote" (i.e. all pods are offloaded
 	// in remote clusters), "LocalAndRemote" (i.e. no constraints are enforced besides the ones
@@ -123,12 +135,66 @@ type NamespaceOffloadingSpec struct {
 	// (https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#node-affinity).
 	// A cluster selector with no NodeSelectorTerms matches all clusters.
 	ClusterSelector corev1.NodeSelector `json:"clusterSelector,omitempty"`
+
+	// Reflection allows users to customize the reflection of the resources in this namespace, overriding
+	// the configuration of the virtual kubelets (i.e., the one specified in the VkOptionsTemplate).
+	// Modifications are applied without the need of restarting the virtual kubelets.
+	// +kubebuilder:validation:Optional
+	Reflection *NamespaceReflection `json:"reflection,omitempty"`
+}
+
+// ClusterNamespaceMapping contains the namespace mapping configuration for a specific remote cluster.
+type ClusterNamespaceMapping struct {
+	// ClusterID is the identifier of the remote cluster the mapping refers to.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// NamespaceMappingStrategy is the strategy to map the local and remote namespace names on the given cluster.
+	// +kubebuilder:validation:Enum="EnforceSameName";"DefaultName";"SelectedName"
+	NamespaceMappingStrategy NamespaceMappingStrategyType `json:"namespaceMappingStrategy"`
+	// RemoteNamespaceName is the name of the remote namespace on the given cluster.
+	// This field is required if NamespaceMappingStrategy is set to "SelectedName". It is ignored otherwise.
+	RemoteNamespaceName string `json:"remoteNamespaceName,omitempty"`
+}
+
+// NamespaceReflection contains the per-namespace customizations of the reflection of resources.
+type NamespaceReflection struct {
+	// Resources contains the overrides of the reflection configuration of specific resource types.
+	// +listType=map
+	// +listMapKey=resource
+	Resources []NamespaceReflectorOverride `json:"resources,omitempty"`
+	// LabelsNotReflected contains the keys of the labels not to be reflected to remote clusters,
+	// in addition to the ones configured for the virtual kubelets.
+	LabelsNotReflected []string `json:"labelsNotReflected,omitempty"`
+	// AnnotationsNotReflected contains the keys of the annotations not to be reflected to remote clusters,
+	// in addition to the ones configured for the virtual kubelets.
+	AnnotationsNotReflected []string `json:"annotationsNotReflected,omitempty"`
+}
+
+// NamespaceReflectorOverride contains the override of the reflection configuration of a given resource type.
+type NamespaceReflectorOverride struct {
+	// Resource is the type of the resource the override refers to.
+	// +kubebuilder:validation:Enum="service";"ingress";"configmap";"secret";"event";"poddisruptionbudget"
+	Resource string `json:"resource"`
+	// Disabled disables the reflection of the resources of the given type in this namespace.
+	// Already reflected resources are removed from the remote clusters.
+	// +kubebuilder:validation:Optional
+	Disabled bool `json:"disabled,omitempty"`
+	// Type overrides the type of reflection of the resources of the given type in this namespace.
+	// +kubebuilder:validation:Enum="AllowList";"DenyList"
+	// +kubebuilder:validation:Optional
+	Type ReflectionType `json:"type,omitempty"`
+	// Selector restricts the reflection to the resources of the given type matching the label selector.
+	// The resources not matching the selector are not reflected, independently of the reflection type.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // NamespaceOffloadingStatus defines the observed state of NamespaceOffloading.
 type NamespaceOffloadingStatus struct {
 	// RemoteNamespaceName is the remote namespace name chosen by means of the NamespaceMappingStrategy.
 	RemoteNamespaceName string `json:"remoteNamespaceName,omitempty"`
+	// RemoteNamespacesNames contains the name of the remote namespace on each selected cluster (indexed by cluster ID),
+	// which differs from RemoteNamespaceName if a cluster-specific mapping is configured.
+	RemoteNamespacesNames map[string]string `json:"remoteNamespacesNames,omitempty"`
 	// OffloadingPhase -> informs users about namespaces offloading status:
 	// "Ready" (i.e. remote Namespaces have been correctly created on previously selected clusters.)
 	// "NoClusterSelected" (i.e. no cluster matches user constraints.)
@@ -149,6 +215,7 @@ type NamespaceOffloadingStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=liqo,shortName=nso;nsof;nsoff;nsoffloading
 // +kubebuilder:subresource:status
+// +genclient
 // +kubebuilder:printcolumn:name="NamespaceMappingStrategy",type=string,JSONPath=`.spec.namespaceMappingStrategy`
 // +kubebuilder:printcolumn:name="PodOffloadingStrategy",type=string,JSONPath=`.spec.podOffloadingStrategy`
 // +kubebuilder:printcolumn:name="OffloadingPhase",type=string,JSONPath=`.status.offloadingPhase`
@@ -0,0 +1,156 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadKind is the kind of the native workload backing a ShadowWorkload.
+// +kubebuilder:validation:Enum=Deployment;StatefulSet;Job
+type WorkloadKind string
+
+const (
+	// DeploymentWorkloadKind identifies ShadowWorkloads backed by a Deployment.
+	DeploymentWorkloadKind WorkloadKind = "Deployment"
+	// StatefulSetWorkloadKind identifies ShadowWorkloads backed by a StatefulSet.
+	StatefulSetWorkloadKind WorkloadKind = "StatefulSet"
+	// JobWorkloadKind identifies ShadowWorkloads backed by a Job.
+	JobWorkloadKind WorkloadKind = "Job"
+)
+
+// ShadowDeploymentSpec contains the Deployment specific fields of a ShadowWorkload.
+type ShadowDeploymentSpec struct {
+	// Strategy is the deployment strategy used to replace existing pods with new ones.
+	Strategy appsv1.DeploymentStrategy `json:"strategy,omitempty"`
+}
+
+// ShadowStatefulSetSpec contains the StatefulSet specific fields of a ShadowWorkload.
+type ShadowStatefulSetSpec struct {
+	// ServiceName is the name of the service governing the StatefulSet.
+	ServiceName string `json:"serviceName,omitempty"`
+	// PodManagementPolicy controls how pods are created during initial scale up and scale down.
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// UpdateStrategy indicates the strategy used to update the pods of the StatefulSet.
+	UpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// ShadowJobSpec contains the Job specific fields of a ShadowWorkload.
+type ShadowJobSpec struct {
+	// Parallelism is the maximum desired number of pods the job should run at any given time.
+	Parallelism *int32 `json:"parallelism,omitempty"`
+	// Completions is the desired number of successfully finished pods the job should be run with.
+	Completions *int32 `json:"completions,omitempty"`
+	// BackoffLimit is the number of retries before marking the job as failed.
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds is the duration in seconds relative to the start time that the job may be active.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// CompletionMode specifies how pod completions are tracked.
+	CompletionMode *batchv1.CompletionMode `json:"completionMode,omitempty"`
+}
+
+// ShadowWorkloadSpec defines the desired state of ShadowWorkload.
+type ShadowWorkloadSpec struct {
+	// Kind is the kind of the native workload created in the provider cluster.
+	Kind WorkloadKind `json:"kind"`
+	// Replicas is the number of desired pods (Deployments and StatefulSets only).
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Selector is the label query over the pods managed by the workload (Deployments and StatefulSets only).
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// MinReadySeconds is the minimum number of seconds for which a newly created pod should be ready
+	// to be considered available (Deployments and StatefulSets only).
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// Template describes the pods that will be created.
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// Deployment contains the Deployment specific fields.
+	Deployment *ShadowDeploymentSpec `json:"deployment,omitempty"`
+	// StatefulSet contains the StatefulSet specific fields.
+	StatefulSet *ShadowStatefulSetSpec `json:"statefulSet,omitempty"`
+	// Job contains the Job specific fields.
+	Job *ShadowJobSpec `json:"job,omitempty"`
+}
+
+// ShadowWorkloadCondition mirrors a condition of the native workload.
+type ShadowWorkloadCondition struct {
+	// Type of the condition (e.g., Available, Progressing, Complete, Failed).
+	Type string `json:"type"`
+	// Status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is the (brief) reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is the human-readable message indicating details about last transition.
+	Message string `json:"message,omitempty"`
+}
+
+// ShadowWorkloadStatus defines the observed state of ShadowWorkload, aggregated from the native workload.
+type ShadowWorkloadStatus struct {
+	// ObservedGeneration is the generation of the ShadowWorkload last propagated to the native workload.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of pods targeted by the workload.
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of pods with a Ready condition.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// AvailableReplicas is the number of pods ready for at least minReadySeconds.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// UpdatedReplicas is the number of pods matching the current template.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// Active is the number of pending and running pods (Jobs only).
+	Active int32 `json:"active,omitempty"`
+	// Succeeded is the number of pods which reached the Succeeded phase (Jobs only).
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of pods which reached the Failed phase (Jobs only).
+	Failed int32 `json:"failed,omitempty"`
+	// Conditions mirrors the conditions of the native workload.
+	Conditions []ShadowWorkloadCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=shw;shwl
+// +kubebuilder:subresource:status
+// +genclient
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.spec.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ShadowWorkload is the Schema for the ShadowWorkloads API.
+// It represents a Deployment, StatefulSet or Job offloaded by a consumer cluster,
+// which is materialized as the corresponding native workload in the provider cluster.
+type ShadowWorkload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ShadowWorkloadSpec   `json:"spec,omitempty"`
+	Status ShadowWorkloadStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ShadowWorkloadList contains a list of ShadowWorkload.
+type ShadowWorkloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShadowWorkload `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShadowWorkload{}, &ShadowWorkloadList{})
+}
@@ -83,6 +83,8 @@ type VirtualNodeSpec struct {
 	IngressClasses []liqov1beta1.IngressType `json:"ingressClasses,omitempty"`
 	// LoadBalancerClasses contains the list of the load balancer classes offered by the cluster.
 	LoadBalancerClasses []liqov1beta1.LoadBalancerType `json:"loadBalancerClasses,omitempty"`
+	// DeviceClasses contains the list of the device classes offered by the cluster, through Dynamic Resource Allocation.
+	DeviceClasses []liqov1beta1.DeviceClassType `json:"deviceClasses,omitempty"`
 	// VkOptionsTemplateRef contains the namespaced reference to the VkOptionsTemplate.
 	// If not set, the default template installed with Liqo will be used.
 	// +optional
@@ -20,9 +20,12 @@ package v1beta1
 
 import (
 	corev1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apisv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -45,6 +48,21 @@ func (in *Affinity) DeepCopy() *Affinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNamespaceMapping) DeepCopyInto(out *ClusterNamespaceMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNamespaceMapping.
+func (in *ClusterNamespaceMapping) DeepCopy() *ClusterNamespaceMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNamespaceMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentTemplate) DeepCopyInto(out *DeploymentTemplate) {
 	*out = *in
@@ -91,6 +109,114 @@ func (in *EndpointSliceTemplate) DeepCopy() *EndpointSliceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedEndpointSlice) DeepCopyInto(out *ExportedEndpointSlice) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedEndpointSlice.
+func (in *ExportedEndpointSlice) DeepCopy() *ExportedEndpointSlice {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedEndpointSlice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedService) DeepCopyInto(out *ExportedService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedService.
+func (in *ExportedService) DeepCopy() *ExportedService {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExportedService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedServiceList) DeepCopyInto(out *ExportedServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExportedService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServiceList.
+func (in *ExportedServiceList) DeepCopy() *ExportedServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExportedServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedServiceSpec) DeepCopyInto(out *ExportedServiceSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]apisv1alpha1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SessionAffinityConfig != nil {
+		in, out := &in.SessionAffinityConfig, &out.SessionAffinityConfig
+		*out = new(v1.SessionAffinityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointSlices != nil {
+		in, out := &in.EndpointSlices, &out.EndpointSlices
+		*out = make([]ExportedEndpointSlice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedServiceSpec.
+func (in *ExportedServiceSpec) DeepCopy() *ExportedServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceMap) DeepCopyInto(out *NamespaceMap) {
 	*out = *in
@@ -256,7 +382,17 @@ func (in *NamespaceOffloadingList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceOffloadingSpec) DeepCopyInto(out *NamespaceOffloadingSpec) {
 	*out = *in
+	if in.ClusterMappings != nil {
+		in, out := &in.ClusterMappings, &out.ClusterMappings
+		*out = make([]ClusterNamespaceMapping, len(*in))
+		copy(*out, *in)
+	}
 	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.Reflection != nil {
+		in, out := &in.Reflection, &out.Reflection
+		*out = new(NamespaceReflection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceOffloadingSpec.
@@ -272,6 +408,13 @@ func (in *NamespaceOffloadingSpec) DeepCopy() *NamespaceOffloadingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceOffloadingStatus) DeepCopyInto(out *NamespaceOffloadingStatus) {
 	*out = *in
+	if in.RemoteNamespacesNames != nil {
+		in, out := &in.RemoteNamespacesNames, &out.RemoteNamespacesNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.RemoteNamespacesConditions != nil {
 		in, out := &in.RemoteNamespacesConditions, &out.RemoteNamespacesConditions
 		*out = make(map[string]RemoteNamespaceConditions, len(*in))
@@ -302,6 +445,58 @@ func (in *NamespaceOffloadingStatus) DeepCopy() *NamespaceOffloadingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReflection) DeepCopyInto(out *NamespaceReflection) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]NamespaceReflectorOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LabelsNotReflected != nil {
+		in, out := &in.LabelsNotReflected, &out.LabelsNotReflected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationsNotReflected != nil {
+		in, out := &in.AnnotationsNotReflected, &out.AnnotationsNotReflected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReflection.
+func (in *NamespaceReflection) DeepCopy() *NamespaceReflection {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReflection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReflectorOverride) DeepCopyInto(out *NamespaceReflectorOverride) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReflectorOverride.
+func (in *NamespaceReflectorOverride) DeepCopy() *NamespaceReflectorOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReflectorOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OffloadingPatch) DeepCopyInto(out *OffloadingPatch) {
 	*out = *in
@@ -503,6 +698,22 @@ func (in *RemoteNamespaceStatus) DeepCopy() *RemoteNamespaceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowDeploymentSpec) DeepCopyInto(out *ShadowDeploymentSpec) {
+	*out = *in
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowDeploymentSpec.
+func (in *ShadowDeploymentSpec) DeepCopy() *ShadowDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShadowEndpointSlice) DeepCopyInto(out *ShadowEndpointSlice) {
 	*out = *in
@@ -577,6 +788,46 @@ func (in *ShadowEndpointSliceSpec) DeepCopy() *ShadowEndpointSliceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowJobSpec) DeepCopyInto(out *ShadowJobSpec) {
+	*out = *in
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Completions != nil {
+		in, out := &in.Completions, &out.Completions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CompletionMode != nil {
+		in, out := &in.CompletionMode, &out.CompletionMode
+		*out = new(batchv1.CompletionMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowJobSpec.
+func (in *ShadowJobSpec) DeepCopy() *ShadowJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShadowPod) DeepCopyInto(out *ShadowPod) {
 	*out = *in
@@ -667,6 +918,160 @@ func (in *ShadowPodStatus) DeepCopy() *ShadowPodStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowStatefulSetSpec) DeepCopyInto(out *ShadowStatefulSetSpec) {
+	*out = *in
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowStatefulSetSpec.
+func (in *ShadowStatefulSetSpec) DeepCopy() *ShadowStatefulSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowStatefulSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowWorkload) DeepCopyInto(out *ShadowWorkload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowWorkload.
+func (in *ShadowWorkload) DeepCopy() *ShadowWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShadowWorkload) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowWorkloadCondition) DeepCopyInto(out *ShadowWorkloadCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowWorkloadCondition.
+func (in *ShadowWorkloadCondition) DeepCopy() *ShadowWorkloadCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowWorkloadCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowWorkloadList) DeepCopyInto(out *ShadowWorkloadList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShadowWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowWorkloadList.
+func (in *ShadowWorkloadList) DeepCopy() *ShadowWorkloadList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowWorkloadList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShadowWorkloadList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowWorkloadSpec) DeepCopyInto(out *ShadowWorkloadSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ShadowDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(ShadowStatefulSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(ShadowJobSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowWorkloadSpec.
+func (in *ShadowWorkloadSpec) DeepCopy() *ShadowWorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowWorkloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowWorkloadStatus) DeepCopyInto(out *ShadowWorkloadStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ShadowWorkloadCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowWorkloadStatus.
+func (in *ShadowWorkloadStatus) DeepCopy() *ShadowWorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowWorkloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualNode) DeepCopyInto(out *VirtualNode) {
 	*out = *in
@@ -814,6 +1219,13 @@ func (in *VirtualNodeSpec) DeepCopyInto(out *VirtualNodeSpec) {
 		*out = make([]corev1beta1.LoadBalancerType, len(*in))
 		copy(*out, *in)
 	}
+	if in.DeviceClasses != nil {
+		in, out := &in.DeviceClasses, &out.DeviceClasses
+		*out = make([]corev1beta1.DeviceClassType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.VkOptionsTemplateRef != nil {
 		in, out := &in.VkOptionsTemplateRef, &out.VkOptionsTemplateRef
 		*out = new(v1.ObjectReference)
@@ -975,6 +1387,13 @@ func (in *VkOptionsTemplateSpec) DeepCopyInto(out *VkOptionsTemplateSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VkOptionsTemplateSpec.
@@ -37,6 +37,7 @@ import (
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/fabric"
+	fabricebpf "github.com/liqotech/liqo/pkg/fabric/ebpf"
 	sourcedetector "github.com/liqotech/liqo/pkg/fabric/source-detector"
 	"github.com/liqotech/liqo/pkg/firewall"
 	"github.com/liqotech/liqo/pkg/gateway"
@@ -166,22 +167,52 @@ func run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to setup gateway reconciler: %w", err)
 	}
 
+	firewallLabelsSets := []labels.Set{
+		fabric.ForgeFirewallTargetLabels(),
+		remapping.ForgeFirewallTargetLabelsIPMappingFabric(),
+		fabric.ForgeFirewallTargetLabelsSingleNode(options.NodeName),
+	}
+
 	// Setup the firewall configuration controller.
 	fwcr, err := firewall.NewFirewallConfigurationReconcilerWithFinalizer(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		options.PodName,
 		mgr.GetEventRecorderFor("firewall-controller"),
-		[]labels.Set{
-			fabric.ForgeFirewallTargetLabels(),
-			remapping.ForgeFirewallTargetLabelsIPMappingFabric(),
-			fabric.ForgeFirewallTargetLabelsSingleNode(options.NodeName),
-		},
+		firewallLabelsSets,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create firewall configuration reconciler: %w", err)
 	}
 
+	// Setup the eBPF datapath, if enabled, falling back to nftables if not supported by the kernel.
+	if options.Datapath == fabric.DatapathEBPF {
+		if err := fabricebpf.CheckSupport(); err != nil {
+			klog.Warningf("eBPF datapath not supported, falling back to nftables: %v", err)
+			options.Datapath = fabric.DatapathNftables
+		}
+	}
+	switch options.Datapath {
+	case fabric.DatapathEBPF:
+		datapath, err := fabricebpf.NewDatapath(options)
+		if err != nil {
+			return fmt.Errorf("unable to create eBPF datapath: %w", err)
+		}
+		defer datapath.Close()
+		fwcr.Offloader = datapath
+
+		dr := fabricebpf.NewDatapathReconciler(mgr.GetClient(), datapath, options, firewallLabelsSets)
+		if err := dr.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup eBPF datapath reconciler: %w", err)
+		}
+	default:
+		// Remove the leftovers of a previous run with the eBPF datapath, if any. Failures are not fatal,
+		// as the nftables datapath does not depend on them.
+		if err := fabricebpf.Cleanup(); err != nil {
+			klog.Warningf("Unable to completely clean up the eBPF datapath: %v", err)
+		}
+	}
+
 	if err := fwcr.SetupWithManager(cmd.Context(), mgr,
 		options.EnableNftMonitor, options.ReconcileTimeout); err != nil {
 		return fmt.Errorf("unable to setup firewall configuration reconciler: %w", err)
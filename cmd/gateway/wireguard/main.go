@@ -147,6 +147,13 @@ func run(cmd *cobra.Command, _ []string) error {
 	if err := wireguard.LoadKeys(options); err != nil {
 		return fmt.Errorf("unable to load keys: %w", err)
 	}
+	go wireguard.StartKeysRoutine(cmd.Context(), dnsChan, options)
+	klog.Infof("Starting keys routine: checking the keys every %s", options.KeysCheckInterval.String())
+
+	// Setup the routine exchanging the next public keys with the remote gateway, to rotate the keys.
+	if err := mgr.Add(wireguard.NewKeysExchanger(mgr.GetClient(), options)); err != nil {
+		return fmt.Errorf("unable to add the keys exchanger: %w", err)
+	}
 
 	// Get interface list
 	ports, err := wireguard.GetWireguardPorts(options)
@@ -161,6 +168,12 @@ func run(cmd *cobra.Command, _ []string) error {
 	}
 	klog.Infof("Successfully setup %d WireGuard interfaces", len(ports))
 
+	// Setup the controller enforcing the QoS on the WireGuard interfaces.
+	qosr := wireguard.NewQoSReconciler(mgr.GetClient(), options)
+	if err := qosr.SetupWithManager(cmd.Context(), mgr); err != nil {
+		return fmt.Errorf("unable to setup QoS reconciler: %w", err)
+	}
+
 	// Create the Prometheus collector and register it inside the controller-runtime metrics server.
 	promcollect, err := wireguard.NewPrometheusCollector(mgr.GetClient(), &wireguard.MetricsOptions{
 		RemoteClusterID:  options.GwOptions.RemoteClusterID,
@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
@@ -51,8 +52,12 @@ import (
 	"github.com/liqotech/liqo/pkg/ipam"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
 	foreignclustercontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/core/foreigncluster-controller"
+	invitationcredentialscontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/core/invitationcredentials-controller"
+	peeringauditeventcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/core/peeringauditevent-controller"
+	peeringrequestcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/core/peeringrequest-controller"
 	ipmapping "github.com/liqotech/liqo/pkg/liqo-controller-manager/ipmapping"
 	quotacreatorcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/quotacreator-controller"
+	resourcesliceautoscalercontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/resourcesliceautoscaler-controller"
 	virtualnodecreatorcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/virtualnodecreator-controller"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	dynamicutils "github.com/liqotech/liqo/pkg/utils/dynamic"
@@ -76,6 +81,7 @@ func init() {
 	_ = apiextensionsv1.AddToScheme(scheme)
 
 	_ = monitoringv1.AddToScheme(scheme)
+	_ = mcsv1alpha1.AddToScheme(scheme)
 
 	_ = liqov1beta1.AddToScheme(scheme)
 	_ = offloadingv1beta1.AddToScheme(scheme)
@@ -200,12 +206,19 @@ func run(cmd *cobra.Command, _ []string) error {
 	// AUTHENTICATION MODULE
 	if opts.AuthenticationEnabled {
 		var idProvider identitymanager.IdentityProvider
-		if opts.AWSConfig.IsEmpty() {
-			idProvider = identitymanager.NewCertificateIdentityProvider(cmd.Context(),
-				mgr.GetClient(), clientset, config, clusterID, namespaceManager)
-		} else {
+		switch {
+		case !opts.AWSConfig.IsEmpty():
 			idProvider = identitymanager.NewIAMIdentityProvider(cmd.Context(),
 				mgr.GetClient(), clientset, clusterID, opts.AWSConfig, namespaceManager)
+		case !opts.OIDCConfig.IsEmpty():
+			idProvider = identitymanager.NewOIDCIdentityProvider(cmd.Context(),
+				mgr.GetClient(), clientset, config, clusterID, opts.OIDCConfig, namespaceManager)
+		default:
+			if opts.IdentityCertificateLifetime != 0 && opts.IdentityCertificateLifetime < 10*time.Minute {
+				return fmt.Errorf("the identity certificate lifetime must be at least 10m, got %s", opts.IdentityCertificateLifetime)
+			}
+			idProvider = identitymanager.NewCertificateIdentityProvider(cmd.Context(),
+				mgr.GetClient(), clientset, config, clusterID, namespaceManager, opts.IdentityCertificateLifetime)
 		}
 
 		authOpts := modules.NewAuthOption(idProvider, namespaceManager, clusterID, opts)
@@ -242,6 +255,15 @@ func run(cmd *cobra.Command, _ []string) error {
 		if err := quotaCreatorReconciler.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup the quotacreator reconciler: %w", err)
 		}
+
+		// Configure controller that resizes the resourceslices depending on the demand.
+		if opts.EnableResourceSliceAutoscaler {
+			autoscalerReconciler := resourcesliceautoscalercontroller.NewResourceSliceAutoscalerReconciler(
+				mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("resourcesliceautoscaler-controller"))
+			if err := autoscalerReconciler.SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup the resourcesliceautoscaler reconciler: %w", err)
+			}
+		}
 	}
 
 	// OFFLOADING MODULE & NETWORKING MODULE
@@ -305,12 +327,39 @@ func run(cmd *cobra.Command, _ []string) error {
 		AuthenticationEnabled: opts.AuthenticationEnabled,
 		OffloadingEnabled:     opts.OffloadingEnabled,
 
+		ConnectionThresholds: foreignclustercontroller.ConnectionThresholds{
+			PacketLoss: opts.ConnectionPacketLossThreshold,
+			Latency:    opts.ConnectionLatencyThreshold,
+			Jitter:     opts.ConnectionJitterThreshold,
+		},
+
 		APIServerCheckers: foreignclustercontroller.NewAPIServerCheckers(idManager, opts.ForeignClusterPingInterval, opts.ForeignClusterPingTimeout),
 	}
 	if err = foreignClusterReconciler.SetupWithManager(mgr, opts.ForeignClusterWorkers); err != nil {
 		return fmt.Errorf("unable to setup the foreigncluster reconciler: %w", err)
 	}
 
+	// Configure the peeringrequest controller, which establishes the peerings declared through invitations.
+	if opts.AuthenticationEnabled {
+		peeringRequestReconciler := peeringrequestcontroller.NewPeeringRequestReconciler(mgr.GetClient(), clientset, mgr.GetScheme(),
+			namespaceManager, clusterID, opts.LiqoNamespace)
+		if err = peeringRequestReconciler.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup the peeringrequest reconciler: %w", err)
+		}
+
+		// Configure the invitationcredentials controller, which revokes the credentials issued through invitations.
+		invitationCredentialsReconciler := invitationcredentialscontroller.NewInvitationCredentialsReconciler(mgr.GetClient())
+		if err = invitationCredentialsReconciler.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup the invitationcredentials reconciler: %w", err)
+		}
+	}
+
+	// Configure the peeringauditevent controller, which deletes the audit events older than the retention period.
+	peeringAuditEventReconciler := peeringauditeventcontroller.NewPeeringAuditEventReconciler(mgr.GetClient(), opts.PeeringAuditRetention)
+	if err = peeringAuditEventReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup the peeringauditevent reconciler: %w", err)
+	}
+
 	// Start the manager.
 	klog.Info("starting manager as controller manager")
 	if err := mgr.Start(cmd.Context()); err != nil {
@@ -71,6 +71,7 @@ func NewAuthOption(identityProvider identitymanager.IdentityProvider, namespaceM
 			ClusterLabels:                    opts.ClusterLabels.StringMap,
 			DefaultResourceQuantity:          opts.DefaultNodeResources.ToResourceList(),
 			DefaultResourceSliceClassEnabled: opts.DefaultResourceSliceClassEnabled,
+			EnableDynamicResourceAllocation:  opts.EnableDRA,
 		},
 	}
 }
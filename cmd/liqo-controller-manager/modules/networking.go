@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -67,6 +68,7 @@ type NetworkingOption struct {
 	FabricFullMasquerade           bool
 	GwmasqbypassEnabled            bool
 	GatewayTemplateWatchEnabled    bool
+	GatewayKeysRotationInterval    time.Duration
 
 	GenevePort                     uint16
 	RouteConfigurationRulePriority int
@@ -91,6 +93,7 @@ func NewNetworkingOption(factory *dynamicutils.RunnableFactory, dynClient dynami
 		FabricFullMasquerade:           opts.FabricFullMasqueradeEnabled,
 		GwmasqbypassEnabled:            opts.GwmasqbypassEnabled,
 		GatewayTemplateWatchEnabled:    opts.GatewayTemplateWatchEnabled,
+		GatewayKeysRotationInterval:    opts.GatewayKeysRotationInterval,
 
 		GenevePort:                     opts.GenevePort,
 		RouteConfigurationRulePriority: opts.RouteConfigurationRulePriority,
@@ -149,7 +152,7 @@ func SetupNetworkingModule(ctx context.Context, mgr manager.Manager, uncachedCli
 
 	wgServerRec := wggatewaycontrollers.NewWgGatewayServerReconciler(mgr.GetClient(), mgr.GetScheme(),
 		mgr.GetEventRecorderFor("wg-gateway-server-controller"),
-		opts.WgGatewayServerClusterRoleName)
+		opts.WgGatewayServerClusterRoleName, opts.GatewayKeysRotationInterval)
 	if err := wgServerRec.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Unable to start the wgGatewayServerReconciler: %v", err)
 		return err
@@ -157,7 +160,7 @@ func SetupNetworkingModule(ctx context.Context, mgr manager.Manager, uncachedCli
 
 	wgClientRec := wggatewaycontrollers.NewWgGatewayClientReconciler(mgr.GetClient(), mgr.GetScheme(),
 		mgr.GetEventRecorderFor("wg-gateway-client-controller"),
-		opts.WgGatewayClientClusterRoleName)
+		opts.WgGatewayClientClusterRoleName, opts.GatewayKeysRotationInterval)
 	if err := wgClientRec.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Unable to start the wgGatewayClientReconciler: %v", err)
 		return err
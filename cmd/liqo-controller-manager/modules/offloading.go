@@ -31,12 +31,16 @@ import (
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/clusterdns"
 	mapsctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespacemap-controller"
 	nsoffctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespaceoffloading-controller"
 	nodefailurectrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/nodefailure-controller"
 	podstatusctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/podstatus-controller"
+	serviceexportctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/serviceexport-controller"
+	serviceimportctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/serviceimport-controller"
 	shadowepsctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/shadowendpointslice-controller"
 	shadowpodctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/shadowpod-controller"
+	shadowworkloadctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/shadowworkload-controller"
 	liqostorageprovisioner "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/storageprovisioner"
 	virtualnodectrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/virtualnode-controller"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
@@ -55,7 +59,13 @@ type OffloadingOption struct {
 	EnableNodeFailureController bool
 	ShadowPodWorkers            int
 	ShadowEndpointSliceWorkers  int
+	EnableWorkloadOffloading    bool
+	ShadowWorkloadWorkers       int
 	DenyDirectConnections       bool
+	EnableMultiClusterServices  bool
+	EnableClusterDNS            bool
+	ClusterDNSZone              string
+	ClusterDNSAddress           string
 	ResyncPeriod                time.Duration
 }
 
@@ -73,7 +83,13 @@ func NewOffloadingOption(clientset *kubernetes.Clientset, localClusterID liqov1b
 		EnableNodeFailureController: opts.EnableNodeFailureController,
 		ShadowPodWorkers:            opts.ShadowPodWorkers,
 		ShadowEndpointSliceWorkers:  opts.ShadowEndpointSliceWorkers,
+		EnableWorkloadOffloading:    opts.EnableWorkloadOffloading,
+		ShadowWorkloadWorkers:       opts.ShadowWorkloadWorkers,
 		DenyDirectConnections:       opts.DenyDirectConnections,
+		EnableMultiClusterServices:  opts.EnableMultiClusterServices,
+		EnableClusterDNS:            opts.EnableClusterDNS,
+		ClusterDNSZone:              opts.ClusterDNSZone,
+		ClusterDNSAddress:           opts.ClusterDNSAddress,
 		ResyncPeriod:                opts.ResyncPeriod,
 	}
 }
@@ -135,6 +151,45 @@ func SetupOffloadingModule(ctx context.Context, mgr manager.Manager, opts *Offlo
 		return err
 	}
 
+	if opts.EnableWorkloadOffloading {
+		shadowWorkloadReconciler := &shadowworkloadctrl.Reconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("shadowworkload-controller"),
+		}
+		if err = shadowWorkloadReconciler.SetupWithManager(mgr, opts.ShadowWorkloadWorkers); err != nil {
+			klog.Errorf("Unable to setup the shadowworkload reconciler: %v", err)
+			return err
+		}
+	}
+
+	if opts.EnableMultiClusterServices {
+		serviceExportReconciler := &serviceexportctrl.Reconciler{
+			Client:         mgr.GetClient(),
+			LocalClusterID: opts.LocalClusterID,
+		}
+		if err = serviceExportReconciler.SetupWithManager(mgr); err != nil {
+			klog.Errorf("Unable to setup the serviceexport reconciler: %v", err)
+			return err
+		}
+
+		serviceImportReconciler := &serviceimportctrl.Reconciler{
+			Client: mgr.GetClient(),
+		}
+		if err = serviceImportReconciler.SetupWithManager(mgr); err != nil {
+			klog.Errorf("Unable to setup the serviceimport reconciler: %v", err)
+			return err
+		}
+	}
+
+	if opts.EnableClusterDNS {
+		clusterDNSServer := clusterdns.NewServer(mgr.GetClient(), opts.LocalClusterID, opts.ClusterDNSZone, opts.ClusterDNSAddress)
+		if err = mgr.Add(clusterDNSServer); err != nil {
+			klog.Errorf("Unable to add the cross-cluster DNS server to the manager: %v", err)
+			return err
+		}
+	}
+
 	if opts.EnableStorage {
 		liqoProvisioner, err := liqostorageprovisioner.NewLiqoLocalStorageProvisioner(ctx, mgr.GetClient(),
 			opts.VirtualStorageClassName, opts.StorageNamespace, opts.RealStorageClassName)
@@ -0,0 +1,82 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/liqotech/liqo/pkg/liqoctl/approve"
+	"github.com/liqotech/liqo/pkg/liqoctl/completion"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/utils"
+)
+
+const liqoctlApproveResourceSliceLongHelp = `Approve a ResourceSlice.
+
+This command allows to approve a ResourceSlice requested by a consumer cluster, when a ResourceSlicePolicy
+enforces the manual approval. The approval refers to the current version of the ResourceSlice, hence any
+subsequent change requested by the consumer needs to be approved again.
+
+Examples:
+  $ {{ .Executable }} approve resourceslice my-rs-name --remote-cluster-id my-consumer-id
+`
+
+// newApproveCommand represents the approve command.
+func newApproveCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "approve",
+		Short: "Approve a liqo resource",
+		Long:  "Approve a liqo resource",
+		Args:  cobra.NoArgs,
+	}
+
+	utils.AddCommand(cmd, newApproveResourceSliceCommand(ctx, f))
+
+	return cmd
+}
+
+// newApproveResourceSliceCommand represents the approve resourceslice command.
+func newApproveResourceSliceCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
+	options := approve.NewOptions(f)
+
+	var cmd = &cobra.Command{
+		Use:               "resourceslice",
+		Aliases:           []string{"resourceslices", "rs"},
+		Short:             "Approve a ResourceSlice",
+		Long:              liqoctlApproveResourceSliceLongHelp,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.ResourceSlices(ctx, f, 1),
+
+		Run: func(_ *cobra.Command, args []string) {
+			options.Name = args[0]
+			output.ExitOnErr(options.RunApproveResourceSlice(ctx))
+		},
+	}
+
+	options.Factory.AddFlags(cmd.PersistentFlags(), cmd.RegisterFlagCompletionFunc)
+
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", 120*time.Second, "Timeout for approve completion")
+	cmd.Flags().Var(&options.ClusterID, "remote-cluster-id", "ClusterID of the consumer cluster which requested the ResourceSlice")
+
+	runtime.Must(cmd.MarkFlagRequired("remote-cluster-id"))
+	runtime.Must(cmd.RegisterFlagCompletionFunc("remote-cluster-id", completion.ClusterIDs(ctx, f, completion.NoLimit)))
+
+	return cmd
+}
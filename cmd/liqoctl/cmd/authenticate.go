@@ -19,11 +19,13 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/runtime"
 
 	"github.com/liqotech/liqo/pkg/liqoctl/authenticate"
 	"github.com/liqotech/liqo/pkg/liqoctl/completion"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 )
 
 const liqoctlAuthenticateLongHelp = `Authenticate with a provider cluster.
@@ -33,14 +35,21 @@ to obtain slices of resources from. At the end of the process, the consumer clus
 be able to replicate ResourceSlices resources to the provider cluster, and to receive
 an associated Identity to consume the provided resources.
 
+With '--dry-run=plan' or '--export <dir>', no object is applied: the command outputs
+the objects it would apply on each cluster, signing the authentication nonce locally.
+The Identity is included only once the Tenant has been applied on the provider cluster,
+hence the plan should be generated again at that point.
+
 Examples:
   $ {{ .Executable }} authenticate --remote-kubeconfig <provider>
+  $ {{ .Executable }} authenticate --remote-kubeconfig <provider> --export ./manifests
 `
 
 // newAuthenticateCommand represents the authenticate command.
 func newAuthenticateCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 	options := authenticate.NewOptions(f)
 	options.RemoteFactory = factory.NewForRemote()
+	planFlags := plan.NewFlags()
 
 	var cmd = &cobra.Command{
 		Use:     "authenticate",
@@ -54,7 +63,9 @@ func newAuthenticateCommand(ctx context.Context, f *factory.Factory) *cobra.Comm
 		},
 
 		Run: func(_ *cobra.Command, _ []string) {
+			options.Plan = planFlags.NewPlan(options.LocalFactory.CRClient.Scheme())
 			output.ExitOnErr(options.RunAuthenticate(ctx))
+			output.ExitOnErr(planFlags.Output(options.Plan, options.LocalFactory.PrinterGlobal))
 		},
 	}
 
@@ -73,6 +84,8 @@ func newAuthenticateCommand(ctx context.Context, f *factory.Factory) *cobra.Comm
 
 	cmd.Flags().BoolVar(&options.InBand, "in-band", false, "Use in-band authentication. Use it only if required and if you know what you are doing")
 	cmd.Flags().StringVar(&options.ProxyURL, "proxy-url", "", "The URL of the proxy to use for the communication with the remote cluster")
+	planFlags.AddFlags(cmd.Flags())
+	runtime.Must(cmd.RegisterFlagCompletionFunc("dry-run", completion.Enumeration(planFlags.DryRun.Allowed)))
 
 	return cmd
 }
@@ -66,7 +66,9 @@ Additionally, via '--get', it allows to retrieve each single field of the report
 using a query in dot notation (e.g. '--get field.subfield')
 
 This command shows additional information about the peered clusters, the status
-of the modules and the amount of shared resources.
+of the modules and the amount of shared resources. With '--audit', it additionally
+summarizes the operations performed by the peered clusters on the local one, as
+recorded by the peering audit.
 
 Examples:
   $ {{ .Executable }} info peer
@@ -82,6 +84,8 @@ get a specific field
   $ {{ .Executable }} info peer cluster1 cluster2 --get cluster2.network.cidr
 when a single cluster is specified, the cluster ID at the beginning of the query can be omitted
   $ {{ .Executable }} info peer cluster1 --get network.cidr
+show the operations performed by a consumer cluster
+  $ {{ .Executable }} info peer cluster1 --audit
 `
 
 func infoPreRun(options *info.Options) {
@@ -98,6 +102,8 @@ func infoPreRun(options *info.Options) {
 }
 
 func newPeerInfoCommand(ctx context.Context, f *factory.Factory, options *info.Options) *cobra.Command {
+	var audit bool
+
 	cmd := &cobra.Command{
 		Use:               "peer",
 		Short:             "Show additional info about peered clusters",
@@ -115,11 +121,16 @@ func newPeerInfoCommand(ctx context.Context, f *factory.Factory, options *info.O
 				&peer.AuthChecker{},
 				&peer.OffloadingChecker{},
 			}
+			if audit {
+				checkers = append(checkers, &peer.AuditChecker{})
+			}
 
 			output.ExitOnErr(options.RunPeerInfo(ctx, checkers, clusterIds))
 		},
 	}
 
+	cmd.Flags().BoolVar(&audit, "audit", false, "Show a summary of the operations performed by the peered clusters on the local one")
+
 	return cmd
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/network"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 	"github.com/liqotech/liqo/pkg/liqoctl/utils"
 )
 
@@ -39,13 +40,29 @@ It disconnects the two clusters and remove network configurations generated with
 const liqoctlNetworConnectLongHelp = `Connect two clusters using liqo networking.
 
 This command creates the Gateways to connect the two clusters.
-Run this command after inizialiting the network using the *network init* command.`
+Run this command after inizialiting the network using the *network init* command.
+
+With '--dry-run=plan' or '--export <dir>', no object is applied: the command outputs
+the objects it would apply on each cluster. The PublicKeys are included only once
+the Gateways have been applied and have generated their keys, hence the plan should
+be generated again at that point.`
 
 const liqoctlNetworkDisconnectLongHelp = `Disconnect two clusters keeping the network configuration.
 
 It deletes the Gateways, but keeps the network configurations generated with the *network init* command.
 Useful when a user wants to disconnect the clusters keeping the same IP mapping.`
 
+const liqoctlNetworkRotateKeysLongHelp = `Rotate the WireGuard keys of the gateways connecting two clusters.
+
+This command generates new keys for the gateways on both clusters, and exchanges the new public keys
+through the PublicKey resources. Each gateway keeps accepting the old key of its peer until the latter
+switches to the new one, so that the tunnel is not interrupted during the rotation.
+The command returns once both gateways are using the new keys, which might take a few minutes as
+the gateways detect the new keys once the kubelet updates the mounted secret (consider increasing
+the timeout accordingly).
+
+To rotate the keys periodically, run this command on a schedule (e.g., through a CronJob).`
+
 func newNetworkCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 	options := network.NewOptions(f)
 	options.RemoteFactory = factory.NewForRemote()
@@ -86,6 +103,7 @@ func newNetworkCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 	utils.AddCommand(cmd, newNetworkResetCommand(ctx, options))
 	utils.AddCommand(cmd, newNetworkConnectCommand(ctx, options))
 	utils.AddCommand(cmd, newNetworkDisconnectCommand(ctx, options))
+	utils.AddCommand(cmd, newNetworkRotateKeysCommand(ctx, options))
 
 	return cmd
 }
@@ -110,6 +128,8 @@ func newNetworkResetCommand(ctx context.Context, options *network.Options) *cobr
 }
 
 func newNetworkConnectCommand(ctx context.Context, options *network.Options) *cobra.Command {
+	planFlags := plan.NewFlags()
+
 	cmd := &cobra.Command{
 		Use:   "connect",
 		Short: "Connect two clusters using liqo networking",
@@ -117,7 +137,9 @@ func newNetworkConnectCommand(ctx context.Context, options *network.Options) *co
 		Args:  cobra.NoArgs,
 
 		Run: func(_ *cobra.Command, _ []string) {
+			options.Plan = planFlags.NewPlan(options.LocalFactory.CRClient.Scheme())
 			output.ExitOnErr(options.RunConnect(ctx))
+			output.ExitOnErr(planFlags.Output(options.Plan, options.LocalFactory.PrinterGlobal))
 		},
 	}
 
@@ -159,8 +181,10 @@ func newNetworkConnectCommand(ctx context.Context, options *network.Options) *co
 	cmd.Flags().IntVar(&options.MTU, "mtu", forge.DefaultMTU,
 		fmt.Sprintf("MTU of the Gateway server and client. Default: %d", forge.DefaultMTU))
 	cmd.Flags().BoolVar(&options.DisableSharingKeys, "disable-sharing-keys", false, "Disable the sharing of public keys between the two clusters")
+	planFlags.AddFlags(cmd.Flags())
 
 	runtime.Must(cmd.RegisterFlagCompletionFunc("gw-server-service-type", completion.Enumeration(options.ServerServiceType.Allowed)))
+	runtime.Must(cmd.RegisterFlagCompletionFunc("dry-run", completion.Enumeration(planFlags.DryRun.Allowed)))
 
 	return cmd
 }
@@ -183,3 +207,18 @@ func newNetworkDisconnectCommand(ctx context.Context, options *network.Options)
 
 	return cmd
 }
+
+func newNetworkRotateKeysCommand(ctx context.Context, options *network.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-keys",
+		Short: "Rotate the WireGuard keys of the gateways connecting two clusters",
+		Long:  liqoctlNetworkRotateKeysLongHelp,
+		Args:  cobra.NoArgs,
+
+		Run: func(_ *cobra.Command, _ []string) {
+			output.ExitOnErr(options.RunRotateKeys(ctx))
+		},
+	}
+
+	return cmd
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
 	"github.com/liqotech/liqo/pkg/liqoctl/peer"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 )
 
 const liqoctlPeerLongHelp = `Enable a peering towards a remote provider cluster.
@@ -48,6 +49,20 @@ the following operations:
   accepted by the provider cluster
 - [optional] create VirtualNode in consumer cluster
 
+With '--dry-run=plan' or '--export <dir>', no object is applied: the command outputs
+the objects it would apply on each cluster, so that they can be reviewed and applied
+through external tools (e.g., GitOps). The objects depending on the status of the
+previous ones (e.g., the Identity and the gateway PublicKeys) are included only once
+the latter have been applied, hence the plan should be generated again at that point.
+
+With '--inventory <file>', the command reconciles the peerings among a fleet of
+clusters towards the ones described in the given inventory, which lists the clusters
+(through their kubeconfig contexts), their roles (consumer and/or provider), and the
+options of the peerings (e.g., requested resources and network configuration).
+The missing peerings are established in parallel, retrying the failed ones, and a
+summary of the outcome is printed at the end. With '--prune', the peerings among
+the listed clusters which are no longer described by the inventory are torn down.
+
 Examples:
   $ {{ .Executable }} peer --remote-kubeconfig <provider>
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --gw-server-service-type NodePort
@@ -55,11 +70,17 @@ Examples:
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --cpu 2 --memory 4Gi --pods 10 --resource nvidia.com/gpu=2
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --create-resource-slice false
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --create-virtual-node false
+  $ {{ .Executable }} peer --remote-kubeconfig <provider> --gw-client-address 203.0.113.10 --export ./manifests
+  $ {{ .Executable }} peer --inventory fleet.yaml --concurrency 8
+  $ {{ .Executable }} peer --inventory fleet.yaml --prune
 `
 
 func newPeerCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 	options := peer.NewOptions(f)
 	options.RemoteFactory = factory.NewForRemote()
+	planFlags := plan.NewFlags()
+	fleet := &peer.FleetOptions{RetryDelay: 10 * time.Second}
+	var inventory string
 
 	cmd := &cobra.Command{
 		Use:   "peer",
@@ -68,16 +89,49 @@ func newPeerCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 		Args:  cobra.NoArgs,
 
 		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			if inventory != "" {
+				// The clusters are accessed through the kubeconfig contexts listed in the inventory.
+				singleClusterPersistentPreRun(cmd, options.LocalFactory, factory.WithScopedPrinter)
+				return
+			}
 			twoClustersPersistentPreRun(cmd, options.LocalFactory, options.RemoteFactory, factory.WithScopedPrinter)
 		},
 
 		Run: func(_ *cobra.Command, _ []string) {
+			if inventory != "" {
+				if planFlags.Enabled() {
+					options.LocalFactory.Printer.CheckErr(fmt.Errorf("--inventory cannot be combined with --dry-run or --export"))
+				}
+				inv, err := peer.LoadInventory(inventory)
+				options.LocalFactory.Printer.CheckErr(err)
+
+				fleet.Printer = options.LocalFactory.PrinterGlobal
+				fleet.Inventory = inv
+				fleet.Timeout = options.Timeout
+				fleet.SkipValidation = options.SkipValidation
+				output.ExitOnErr(fleet.RunFleet(ctx))
+				return
+			}
+
+			options.Plan = planFlags.NewPlan(options.LocalFactory.CRClient.Scheme())
 			output.ExitOnErr(options.RunPeer(ctx))
+			output.ExitOnErr(planFlags.Output(options.Plan, options.LocalFactory.PrinterGlobal))
 		},
 	}
 
 	cmd.PersistentFlags().DurationVar(&options.Timeout, "timeout", 10*time.Minute, "Timeout for peering completion")
 	cmd.PersistentFlags().BoolVar(&options.SkipValidation, "skip-validation", false, "Skip the validation")
+	planFlags.AddFlags(cmd.Flags())
+	runtime.Must(cmd.RegisterFlagCompletionFunc("dry-run", completion.Enumeration(planFlags.DryRun.Allowed)))
+
+	// Inventory flags
+	cmd.Flags().StringVar(&inventory, "inventory", "",
+		"Reconcile the peerings among the clusters described in the given inventory file, instead of peering with the remote cluster")
+	cmd.Flags().IntVar(&fleet.Concurrency, "concurrency", 4, "The maximum number of peerings of the inventory established in parallel")
+	cmd.Flags().IntVar(&fleet.Retries, "retries", 2, "The number of times a failed peering of the inventory is retried")
+	cmd.Flags().BoolVar(&fleet.Prune, "prune", false,
+		"Tear down the peerings among the clusters of the inventory which are no longer described by the inventory")
+	runtime.Must(cmd.MarkFlagFilename("inventory", "yaml", "yml"))
 
 	options.LocalFactory.AddFlags(cmd.PersistentFlags(), cmd.RegisterFlagCompletionFunc)
 	options.RemoteFactory.AddFlags(cmd.PersistentFlags(), cmd.RegisterFlagCompletionFunc)
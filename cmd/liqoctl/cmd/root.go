@@ -32,6 +32,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/gatewayclient"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/gatewayserver"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/identity"
+	"github.com/liqotech/liqo/pkg/liqoctl/rest/invitation"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/kubeconfig"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/nonce"
 	peeringuser "github.com/liqotech/liqo/pkg/liqoctl/rest/peering-user"
@@ -54,6 +55,7 @@ var liqoResources = []rest.APIProvider{
 	tenant.Tenant,
 	nonce.Nonce,
 	peeringuser.PeeringUser,
+	invitation.Invitation,
 	identity.Identity,
 	resourceslice.ResourceSlice,
 	kubeconfig.Kubeconfig,
@@ -133,6 +135,7 @@ func NewRootCommand(ctx context.Context) *cobra.Command {
 	utils.AddCommand(cmd, newCordonCommand(ctx, f))
 	utils.AddCommand(cmd, newUncordonCommand(ctx, f))
 	utils.AddCommand(cmd, newDrainCommand(ctx, f))
+	utils.AddCommand(cmd, newApproveCommand(ctx, f))
 	utils.AddCommand(cmd, create.NewCreateCommand(ctx, liqoResources, f))
 	utils.AddCommand(cmd, generate.NewGenerateCommand(ctx, liqoResources, f))
 	utils.AddCommand(cmd, get.NewGetCommand(ctx, liqoResources, f))
@@ -33,8 +33,14 @@ In the consumer cluster, it deletes the control plane Identity.
 In the provider cluster, it deletes the Tenant.
 The execution is prevented if any ResourceSlice or VirtualNode associated with the provider cluster is found.
 
+The --revoke flag immediately revokes all the identities granted to the consumer cluster, even if
+their credentials are not yet expired, and skips the checks on the leftover resources.
+In this case, the Tenant is preserved to keep enforcing the revocation.
+
 Examples:
   $ {{ .Executable }} unauthenticate --remote-kubeconfig <provider>
+or
+  $ {{ .Executable }} unauthenticate --remote-kubeconfig <provider> --revoke
 `
 
 // newUnauthenticateCommand represents the unauthenticate command.
@@ -60,6 +66,8 @@ func newUnauthenticateCommand(ctx context.Context, f *factory.Factory) *cobra.Co
 
 	cmd.PersistentFlags().DurationVar(&options.Timeout, "timeout", 2*time.Minute, "Timeout for completion")
 	cmd.PersistentFlags().BoolVar(&options.Wait, "wait", true, "Wait for the unauthentication to complete")
+	cmd.PersistentFlags().BoolVar(&options.Revoke, "revoke", false,
+		"Immediately revoke the identities granted to the consumer cluster, preserving the Tenant to enforce the revocation")
 
 	options.LocalFactory.AddFlags(cmd.PersistentFlags(), cmd.RegisterFlagCompletionFunc)
 	options.RemoteFactory.AddFlags(cmd.PersistentFlags(), cmd.RegisterFlagCompletionFunc)
@@ -50,6 +50,13 @@ func InstallFlags(flags *pflag.FlagSet, o *Opts) {
 	setReflectorsWorkers(flags, o)
 	setReflectorsType(flags, o)
 
+	flags.Var(o.EndpointZoneMode, "endpointslice-zone-mode",
+		"The zone assigned to the reflected endpoints, among real (the zone and hints of the cluster hosting them, verbatim) "+
+			"and cluster (a synthetic per-cluster zone)")
+	flags.BoolVar(&o.PreferSameCluster, "endpointslice-prefer-same-cluster", false,
+		"Hint the reflected endpoints so that the remote kube-proxy prefers the endpoints running in the remote cluster, if any, "+
+			"and reflect the traffic distribution of the services")
+
 	flags.DurationVar(&o.NodeLeaseDuration, "node-lease-duration", o.NodeLeaseDuration, "The duration of the node leases")
 	flags.DurationVar(&o.NodePingInterval, "node-ping-interval", o.NodePingInterval,
 		"The interval the reachability of the remote API server is verified to assess node readiness, 0 to disable")
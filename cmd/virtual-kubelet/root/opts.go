@@ -26,6 +26,7 @@ import (
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	argsutils "github.com/liqotech/liqo/pkg/utils/args"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/networkconfig"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
 )
@@ -59,6 +60,10 @@ var DefaultReflectorsWorkers = map[resources.ResourceReflected]uint{
 	resources.ServiceAccount:        3,
 	resources.PersistentVolumeClaim: 3,
 	resources.Event:                 3,
+	resources.Workload:              3,
+	resources.PodDisruptionBudget:   3,
+	resources.ResourceClaim:         0,
+	resources.ResourceClaimTemplate: 0,
 }
 
 // DefaultReflectorsTypes contains the default type of reflection for each reflected resource.
@@ -71,6 +76,10 @@ var DefaultReflectorsTypes = map[resources.ResourceReflected]offloadingv1beta1.R
 	resources.ServiceAccount:        offloadingv1beta1.CustomLiqo,
 	resources.PersistentVolumeClaim: offloadingv1beta1.CustomLiqo,
 	resources.Event:                 offloadingv1beta1.DenyList,
+	resources.Workload:              offloadingv1beta1.CustomLiqo,
+	resources.PodDisruptionBudget:   offloadingv1beta1.DenyList,
+	resources.ResourceClaim:         offloadingv1beta1.CustomLiqo,
+	resources.ResourceClaimTemplate: offloadingv1beta1.CustomLiqo,
 }
 
 // Opts stores all the options for configuring the root virtual-kubelet command.
@@ -104,6 +113,10 @@ type Opts struct {
 	// Type of reflection to use for each reflected resource
 	ReflectorsType map[string]*string
 
+	// Topology information of the reflected endpoints
+	EndpointZoneMode  *argsutils.StringEnum
+	PreferSameCluster bool
+
 	NodeLeaseDuration time.Duration
 	NodePingInterval  time.Duration
 	NodePingTimeout   time.Duration
@@ -159,6 +172,9 @@ func NewOpts() *Opts {
 		ReflectorsWorkers: initReflectionWorkers(),
 		ReflectorsType:    initReflectionType(),
 
+		EndpointZoneMode: argsutils.NewEnum([]string{string(forge.EndpointZoneModeReal), string(forge.EndpointZoneModeCluster)},
+			string(forge.EndpointZoneModeReal)),
+
 		NodeLeaseDuration: node.DefaultLeaseDuration * time.Second,
 		NodePingInterval:  node.DefaultPingInterval,
 		NodePingTimeout:   DefaultNodePingTimeout,
@@ -45,6 +45,7 @@ import (
 	"github.com/liqotech/liqo/pkg/utils"
 	fcutils "github.com/liqotech/liqo/pkg/utils/foreigncluster"
 	"github.com/liqotech/liqo/pkg/utils/restcfg"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	nodeprovider "github.com/liqotech/liqo/pkg/virtualKubelet/liqoNodeProvider"
 	metrics "github.com/liqotech/liqo/pkg/virtualKubelet/metrics"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/networkconfig"
@@ -195,6 +196,9 @@ func runRootCommand(ctx context.Context, c *Opts) error {
 
 		ReflectorsConfigs: reflectorsConfigs,
 
+		EndpointZoneMode:  forge.EndpointZoneMode(c.EndpointZoneMode.Value),
+		PreferSameCluster: c.PreferSameCluster,
+
 		EnableAPIServerSupport:          c.EnableAPIServerSupport,
 		EnableStorage:                   c.EnableStorage,
 		VirtualStorageClassName:         c.VirtualStorageClassName,
@@ -363,7 +367,8 @@ func getVersion(config *rest.Config) string {
 }
 
 func isReflectionTypeNotCustomizable(resource resources.ResourceReflected) bool {
-	return resource == resources.Pod || resource == resources.ServiceAccount || resource == resources.PersistentVolumeClaim
+	return resource == resources.Pod || resource == resources.ServiceAccount || resource == resources.PersistentVolumeClaim ||
+		resource == resources.Workload || resource == resources.ResourceClaim || resource == resources.ResourceClaimTemplate
 }
 
 func getReflectorsConfigs(c *Opts) (map[resources.ResourceReflected]offloadingv1beta1.ReflectorConfig, error) {
@@ -49,11 +49,14 @@ import (
 	"github.com/liqotech/liqo/pkg/utils/restcfg"
 	fwcfgwh "github.com/liqotech/liqo/pkg/webhooks/firewallconfiguration"
 	fcwh "github.com/liqotech/liqo/pkg/webhooks/foreigncluster"
+	identityrevocationwh "github.com/liqotech/liqo/pkg/webhooks/identityrevocation"
 	nsoffwh "github.com/liqotech/liqo/pkg/webhooks/namespaceoffloading"
+	peeringauditwh "github.com/liqotech/liqo/pkg/webhooks/peeringaudit"
 	podwh "github.com/liqotech/liqo/pkg/webhooks/pod"
 	resourceslicewh "github.com/liqotech/liqo/pkg/webhooks/resourceslice"
 	routecfgwh "github.com/liqotech/liqo/pkg/webhooks/routeconfiguration"
 	"github.com/liqotech/liqo/pkg/webhooks/secretcontroller"
+	servicewh "github.com/liqotech/liqo/pkg/webhooks/service"
 	shadowpodswh "github.com/liqotech/liqo/pkg/webhooks/shadowpod"
 	tenantwh "github.com/liqotech/liqo/pkg/webhooks/tenant"
 	virtualnodewh "github.com/liqotech/liqo/pkg/webhooks/virtualnode"
@@ -93,6 +96,8 @@ func main() {
 		5*time.Minute, "The interval at which the resource validator cache is refreshed")
 	liqoRuntimeClassName := pflag.String("liqo-runtime-class", consts.LiqoRuntimeClassName,
 		"Define the Liqo runtime class forcing the pods to be scheduled on virtual nodes")
+	peeringAuditSinks := pflag.StringSlice("peering-audit-sinks", nil,
+		"The sinks the operations performed by the consumer clusters are recorded to (supported: crd, log). Empty to disable the audit")
 
 	flagsutils.InitKlogFlags(pflag.CommandLine)
 	restcfg.InitFlags(pflag.CommandLine)
@@ -190,6 +195,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Configure the sinks the operations performed by the consumer clusters are recorded to.
+	peeringAuditSinkList := make([]peeringauditwh.Sink, len(*peeringAuditSinks))
+	for i, name := range *peeringAuditSinks {
+		if peeringAuditSinkList[i], err = peeringauditwh.NewSink(name, mgr.GetClient()); err != nil {
+			klog.Error(err)
+			os.Exit(1)
+		}
+	}
+
 	// Register the webhooks.
 	mgr.GetWebhookServer().Register("/mutate/foreign-cluster", fcwh.NewMutator())
 	mgr.GetWebhookServer().Register("/validate/shadowpods", &webhook.Admission{Handler: spv})
@@ -203,7 +217,13 @@ func main() {
 	mgr.GetWebhookServer().Register("/mutate/firewallconfigurations", fwcfgwh.NewMutator())
 	mgr.GetWebhookServer().Register("/validate/routeconfigurations", routecfgwh.NewValidator(mgr.GetClient()))
 	mgr.GetWebhookServer().Register("/validate/tenants", tenantwh.NewValidator(mgr.GetClient()))
+	mgr.GetWebhookServer().Register("/validate/tenant-revocations", tenantwh.NewRevocationValidator(mgr.GetClient()))
+	mgr.GetWebhookServer().Register("/validate/services", servicewh.NewValidator(mgr.GetClient()))
+	if len(peeringAuditSinkList) > 0 {
+		mgr.GetWebhookServer().Register("/audit/peering", peeringauditwh.NewAuditor(mgr.GetClient(), peeringAuditSinkList...))
+	}
 	mgr.GetWebhookServer().Register("/mutate/tenants", tenantwh.NewMutator(mgr.GetClient()))
+	mgr.GetWebhookServer().Register("/authorize/identities", identityrevocationwh.NewAuthorizer(mgr.GetClient()))
 
 	// Register the secret controller
 	secretReconciler := secretcontroller.NewSecretReconciler(mgr.GetClient(), mgr.GetScheme(),
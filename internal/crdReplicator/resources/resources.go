@@ -46,5 +46,9 @@ func GetResourcesToReplicate() []Resource {
 			GroupVersionResource: authv1beta1.RenewGroupVersionResource,
 			Ownership:            consts.OwnershipShared,
 		},
+		{
+			GroupVersionResource: offloadingv1beta1.ExportedServiceGroupVersionResource,
+			Ownership:            consts.OwnershipLocal,
+		},
 	}
 }
@@ -31,6 +31,10 @@ func (c *FakeOffloadingV1beta1) NamespaceMaps(namespace string) v1beta1.Namespac
 	return newFakeNamespaceMaps(c, namespace)
 }
 
+func (c *FakeOffloadingV1beta1) NamespaceOffloadings(namespace string) v1beta1.NamespaceOffloadingInterface {
+	return newFakeNamespaceOffloadings(c, namespace)
+}
+
 func (c *FakeOffloadingV1beta1) ShadowEndpointSlices(namespace string) v1beta1.ShadowEndpointSliceInterface {
 	return newFakeShadowEndpointSlices(c, namespace)
 }
@@ -39,6 +43,10 @@ func (c *FakeOffloadingV1beta1) ShadowPods(namespace string) v1beta1.ShadowPodIn
 	return newFakeShadowPods(c, namespace)
 }
 
+func (c *FakeOffloadingV1beta1) ShadowWorkloads(namespace string) v1beta1.ShadowWorkloadInterface {
+	return newFakeShadowWorkloads(c, namespace)
+}
+
 func (c *FakeOffloadingV1beta1) VirtualNodes(namespace string) v1beta1.VirtualNodeInterface {
 	return newFakeVirtualNodes(c, namespace)
 }
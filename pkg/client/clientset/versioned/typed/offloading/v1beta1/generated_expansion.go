@@ -18,10 +18,14 @@ package v1beta1
 
 type NamespaceMapExpansion interface{}
 
+type NamespaceOffloadingExpansion interface{}
+
 type ShadowEndpointSliceExpansion interface{}
 
 type ShadowPodExpansion interface{}
 
+type ShadowWorkloadExpansion interface{}
+
 type VirtualNodeExpansion interface{}
 
 type VkOptionsTemplateExpansion interface{}
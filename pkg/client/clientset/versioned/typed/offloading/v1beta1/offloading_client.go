@@ -28,8 +28,10 @@ import (
 type OffloadingV1beta1Interface interface {
 	RESTClient() rest.Interface
 	NamespaceMapsGetter
+	NamespaceOffloadingsGetter
 	ShadowEndpointSlicesGetter
 	ShadowPodsGetter
+	ShadowWorkloadsGetter
 	VirtualNodesGetter
 	VkOptionsTemplatesGetter
 }
@@ -43,6 +45,10 @@ func (c *OffloadingV1beta1Client) NamespaceMaps(namespace string) NamespaceMapIn
 	return newNamespaceMaps(c, namespace)
 }
 
+func (c *OffloadingV1beta1Client) NamespaceOffloadings(namespace string) NamespaceOffloadingInterface {
+	return newNamespaceOffloadings(c, namespace)
+}
+
 func (c *OffloadingV1beta1Client) ShadowEndpointSlices(namespace string) ShadowEndpointSliceInterface {
 	return newShadowEndpointSlices(c, namespace)
 }
@@ -51,6 +57,10 @@ func (c *OffloadingV1beta1Client) ShadowPods(namespace string) ShadowPodInterfac
 	return newShadowPods(c, namespace)
 }
 
+func (c *OffloadingV1beta1Client) ShadowWorkloads(namespace string) ShadowWorkloadInterface {
+	return newShadowWorkloads(c, namespace)
+}
+
 func (c *OffloadingV1beta1Client) VirtualNodes(namespace string) VirtualNodeInterface {
 	return newVirtualNodes(c, namespace)
 }
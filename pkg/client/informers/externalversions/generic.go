@@ -59,10 +59,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=offloading, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("namespacemaps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().NamespaceMaps().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("namespaceoffloadings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().NamespaceOffloadings().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("shadowendpointslices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().ShadowEndpointSlices().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("shadowpods"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().ShadowPods().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("shadowworkloads"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().ShadowWorkloads().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("virtualnodes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().VirtualNodes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vkoptionstemplates"):
@@ -24,10 +24,14 @@ import (
 type Interface interface {
 	// NamespaceMaps returns a NamespaceMapInformer.
 	NamespaceMaps() NamespaceMapInformer
+	// NamespaceOffloadings returns a NamespaceOffloadingInformer.
+	NamespaceOffloadings() NamespaceOffloadingInformer
 	// ShadowEndpointSlices returns a ShadowEndpointSliceInformer.
 	ShadowEndpointSlices() ShadowEndpointSliceInformer
 	// ShadowPods returns a ShadowPodInformer.
 	ShadowPods() ShadowPodInformer
+	// ShadowWorkloads returns a ShadowWorkloadInformer.
+	ShadowWorkloads() ShadowWorkloadInformer
 	// VirtualNodes returns a VirtualNodeInformer.
 	VirtualNodes() VirtualNodeInformer
 	// VkOptionsTemplates returns a VkOptionsTemplateInformer.
@@ -50,6 +54,11 @@ func (v *version) NamespaceMaps() NamespaceMapInformer {
 	return &namespaceMapInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NamespaceOffloadings returns a NamespaceOffloadingInformer.
+func (v *version) NamespaceOffloadings() NamespaceOffloadingInformer {
+	return &namespaceOffloadingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ShadowEndpointSlices returns a ShadowEndpointSliceInformer.
 func (v *version) ShadowEndpointSlices() ShadowEndpointSliceInformer {
 	return &shadowEndpointSliceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -60,6 +69,11 @@ func (v *version) ShadowPods() ShadowPodInformer {
 	return &shadowPodInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ShadowWorkloads returns a ShadowWorkloadInformer.
+func (v *version) ShadowWorkloads() ShadowWorkloadInformer {
+	return &shadowWorkloadInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VirtualNodes returns a VirtualNodeInformer.
 func (v *version) VirtualNodes() VirtualNodeInformer {
 	return &virtualNodeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -24,6 +24,14 @@ type NamespaceMapListerExpansion interface{}
 // NamespaceMapNamespaceLister.
 type NamespaceMapNamespaceListerExpansion interface{}
 
+// NamespaceOffloadingListerExpansion allows custom methods to be added to
+// NamespaceOffloadingLister.
+type NamespaceOffloadingListerExpansion interface{}
+
+// NamespaceOffloadingNamespaceListerExpansion allows custom methods to be added to
+// NamespaceOffloadingNamespaceLister.
+type NamespaceOffloadingNamespaceListerExpansion interface{}
+
 // ShadowEndpointSliceListerExpansion allows custom methods to be added to
 // ShadowEndpointSliceLister.
 type ShadowEndpointSliceListerExpansion interface{}
@@ -40,6 +48,14 @@ type ShadowPodListerExpansion interface{}
 // ShadowPodNamespaceLister.
 type ShadowPodNamespaceListerExpansion interface{}
 
+// ShadowWorkloadListerExpansion allows custom methods to be added to
+// ShadowWorkloadLister.
+type ShadowWorkloadListerExpansion interface{}
+
+// ShadowWorkloadNamespaceListerExpansion allows custom methods to be added to
+// ShadowWorkloadNamespaceLister.
+type ShadowWorkloadNamespaceListerExpansion interface{}
+
 // VirtualNodeListerExpansion allows custom methods to be added to
 // VirtualNodeLister.
 type VirtualNodeListerExpansion interface{}
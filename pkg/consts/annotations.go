@@ -53,4 +53,8 @@ const (
 	// ForeignClusterPermanentlyUnreachableAnnotationKey is the annotation used to signal that the foreign cluster is not reachable and it will
 	// never come up.
 	ForeignClusterPermanentlyUnreachableAnnotationKey = "liqo.io/foreign-cluster-permanently-unreachable"
+
+	// ClusterDNSNameAnnotationKey is the annotation of a ForeignCluster overriding the name identifying the peer cluster
+	// in the cross-cluster DNS zone (the cluster ID is used by default).
+	ClusterDNSNameAnnotationKey = "liqo.io/dns-name"
 )
@@ -52,6 +52,12 @@ const (
 	// CordonTenantAnnotation is the value of the annotation that enables the cordon of a tenant.
 	CordonTenantAnnotation = "liqo.io/cordon-tenant"
 
+	// ApprovedGenerationAnnotation is the annotation storing the generation of a ResourceSlice manually approved by the provider.
+	ApprovedGenerationAnnotation = "liqo.io/approved-generation"
+
+	// LastAutoscaleTimeAnnotation is the annotation storing the last time a ResourceSlice has been resized by the autoscaler.
+	LastAutoscaleTimeAnnotation = "liqo.io/last-autoscale-time"
+
 	// PeeringUserNameLabelKey labels all the resources created to grant peering permissions to the user doing a pering toward this cluster.
 	PeeringUserNameLabelKey = "liqo.io/peering-user-name"
 )
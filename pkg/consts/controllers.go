@@ -25,36 +25,41 @@ package consts
 const (
 	// Core.
 	CtrlForeignCluster                   = "foreigncluster"
+	CtrlPeeringRequest                   = "peeringrequest"
+	CtrlPeeringAuditEvent                = "peeringauditevent"
+	CtrlInvitationCredentials            = "invitationcredentials"
 	CtrlSecretCRDReplicator              = "secret_crdreplicator" //nolint:gosec // not a credential
 	CtrlForeignClusterStateCRDReplicator = "foreignclusterstate_crdreplicator"
 	CtrlSecretWebhook                    = "secret_webhook"
 
 	// Networking.
-	CtrlConfigurationExternal  = "configuration_external"
-	CtrlConfigurationInternal  = "configuration_internal"
-	CtrlConfigurationRemapping = "configuration_remapping"
-	CtrlConfigurationRoute     = "configuration_route"
-	CtrlConnection             = "connection"
-	CtrlFirewallConfiguration  = "firewallconfiguration"
-	CtrlGatewayClientExternal  = "gatewayclient_external"
-	CtrlGatewayClientInternal  = "gatewayclient_internal"
-	CtrlGatewayServerExternal  = "gatewayserver_external"
-	CtrlGatewayServerInternal  = "gatewayserver_internal"
-	CtrlInternalFabricCM       = "internalfabric_cm"
-	CtrlInternalFabricFabric   = "internalfabric_fabric"
-	CtrlInternalNodeGeneve     = "internalnode_geneve"
-	CtrlInternalNodeRoute      = "internalnode_route"
-	CtrlIP                     = "ip"
-	CtrlIPRemapping            = "ip_remapping"
-	CtrlNetwork                = "network"
-	CtrlNode                   = "node"
-	CtrlPodGateway             = "pod_gateway"
-	CtrlPodGwMasq              = "pod_gw_masq"
-	CtrlPodInternalNet         = "pod_internalnet"
-	CtrlPublicKey              = "publickey"
-	CtrlRouteConfiguration     = "routeconfiguration"
-	CtrlWGGatewayClient        = "wggatewayclient"
-	CtrlWGGatewayServer        = "wggatewayserver"
+	CtrlConfigurationExternal     = "configuration_external"
+	CtrlConfigurationInternal     = "configuration_internal"
+	CtrlConfigurationRemapping    = "configuration_remapping"
+	CtrlConfigurationRoute        = "configuration_route"
+	CtrlConnection                = "connection"
+	CtrlFirewallConfiguration     = "firewallconfiguration"
+	CtrlFirewallConfigurationEBPF = "firewallconfiguration_ebpf"
+	CtrlGatewayQoS                = "gateway_qos"
+	CtrlGatewayClientExternal     = "gatewayclient_external"
+	CtrlGatewayClientInternal     = "gatewayclient_internal"
+	CtrlGatewayServerExternal     = "gatewayserver_external"
+	CtrlGatewayServerInternal     = "gatewayserver_internal"
+	CtrlInternalFabricCM          = "internalfabric_cm"
+	CtrlInternalFabricFabric      = "internalfabric_fabric"
+	CtrlInternalNodeGeneve        = "internalnode_geneve"
+	CtrlInternalNodeRoute         = "internalnode_route"
+	CtrlIP                        = "ip"
+	CtrlIPRemapping               = "ip_remapping"
+	CtrlNetwork                   = "network"
+	CtrlNode                      = "node"
+	CtrlPodGateway                = "pod_gateway"
+	CtrlPodGwMasq                 = "pod_gw_masq"
+	CtrlPodInternalNet            = "pod_internalnet"
+	CtrlPublicKey                 = "publickey"
+	CtrlRouteConfiguration        = "routeconfiguration"
+	CtrlWGGatewayClient           = "wggatewayclient"
+	CtrlWGGatewayServer           = "wggatewayserver"
 
 	// Authentication.
 	CtrlIdentity            = "identity"
@@ -72,13 +77,17 @@ const (
 	CtrlNamespaceOffloading = "namespaceoffloading"
 	CtrlNodeFailure         = "node_failure"
 	CtrlPodStatus           = "pod_status"
+	CtrlServiceExport       = "serviceexport"
+	CtrlServiceImport       = "serviceimport"
 	CtrlShadowEndpointSlice = "shadowendpointslice"
 	CtrlShadowPod           = "shadowpod"
+	CtrlShadowWorkload      = "shadowworkload"
 	CtrlVirtualNode         = "virtualnode"
 
 	// Cross modules.
 	CtrlResourceSliceQuotaCreator = "resourceslice_quotacreator"
 	CtrlResourceSliceVNCreator    = "resourceslice_vncreator"
+	CtrlResourceSliceAutoscaler   = "resourceslice_autoscaler"
 	CtrlPodIPMapping              = "pod_ipmapping"
 	CtrlConfigurationIPMapping    = "configuration_ipmapping"
 	CtrlEndpointSlice             = "endpointslice"
@@ -36,6 +36,25 @@ const (
 	PrivateKeyField = "privateKey"
 	// PublicKeyField is the data field of the secrets containing public keys.
 	PublicKeyField = "publicKey"
+	// NextPrivateKeyField is the data field of the secrets containing the private key that will replace the current one.
+	NextPrivateKeyField = "nextPrivateKey"
+	// NextPublicKeyField is the data field of the secrets containing the public key that will replace the current one.
+	NextPublicKeyField = "nextPublicKey"
+
+	// RotateKeysAnnotation is the annotation added to a gateway keys secret to rotate the keys it contains.
+	RotateKeysAnnotation = "networking.liqo.io/rotate-keys"
+	// RotateKeysPrepare is the value of the RotateKeysAnnotation to generate the next keys, keeping the current ones in use.
+	RotateKeysPrepare = "prepare"
+	// RotateKeysCommit is the value of the RotateKeysAnnotation to replace the current keys with the next ones.
+	RotateKeysCommit = "commit"
+	// RotateKeysAuto is the value of the RotateKeysAnnotation set when the keys are rotated automatically: the next keys are generated,
+	// and they replace the current ones once the remote gateway acknowledged them.
+	RotateKeysAuto = "auto"
+	// KeysRotationTimestampAnnotation is the annotation reporting when the keys contained in a gateway keys secret have been last rotated.
+	KeysRotationTimestampAnnotation = "networking.liqo.io/keys-rotation-timestamp"
+	// NextPublicKeyAcknowledgedAnnotation is the annotation added to a connection to report the next public key
+	// of the local gateway (base64 encoded) that the remote gateway accepted.
+	NextPublicKeyAcknowledgedAnnotation = "networking.liqo.io/next-public-key-acknowledged"
 
 	// ClusterRoleBindingFinalizer is the finalizer added ti the owner when a ClusterRoleBinding is created.
 	ClusterRoleBindingFinalizer = "networking.liqo.io/clusterrolebinding"
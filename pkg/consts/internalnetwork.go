@@ -21,6 +21,11 @@ const (
 	DefaultGenevePort uint16 = 6091
 	// DefaultGeneveCleanupInterval is the default interval used to cleanup the geneve tunnels.
 	DefaultGeneveCleanupInterval = time.Minute * 30
+	// DefaultEBPFBypassMark is the default firewall mark used by the eBPF fabric datapath to flag the packets
+	// that must not be masqueraded.
+	DefaultEBPFBypassMark uint32 = 0x10000000
+	// DefaultEBPFResyncInterval is the default interval used to resync the eBPF fabric datapath.
+	DefaultEBPFResyncInterval = time.Minute
 	// DefaultRouteTable is the name of the default table used for routes.
 	DefaultRouteTable = "liqo"
 	// InternalFabricName is the label used to identify the internal fabric name.
@@ -31,3 +36,7 @@ const (
 	// id is freed.
 	InternalFabricGeneveTunnelFinalizer = "networking.liqo.io/internal-fabric-geneve-tunnel-finalizer"
 )
+
+// DefaultEBPFInterfaces contains the default glob patterns matching the host side of the pod interfaces
+// created by the most common CNIs, where the eBPF fabric datapath is attached.
+var DefaultEBPFInterfaces = []string{"veth*", "cali*", "lxc*", "azv*", "eni*"}
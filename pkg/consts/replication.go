@@ -48,6 +48,10 @@ const (
 	ManagedByShadowPodValue = "shadowpod"
 	// ManagedByShadowEndpointSliceValue it the label value used to indicate that a given resource is managed by a ShadowEndpointSlice.
 	ManagedByShadowEndpointSliceValue = "shadowendpointslice"
+	// ManagedByShadowWorkloadValue it the label value used to indicate that a given resource is managed by a ShadowWorkload.
+	ManagedByShadowWorkloadValue = "shadowworkload"
+	// ManagedByServiceImportValue it the label value used to indicate that a given resource is managed by a ServiceImport.
+	ManagedByServiceImportValue = "serviceimport"
 
 	// LocalResourceOwnership label key added to a resource when it is owned by a local component.
 	// Ex. Local networkconfigs are owned by the component that creates them. If the resource is replicated in
@@ -103,7 +107,32 @@ const (
 	// actual pod IPs running on the other provider.
 	DirectConnectionDataAnnotationKey = "liqo.io/direct-connections-data"
 
+	// WorkloadOffloadingAnnotationKey is the annotation key set on the pod template of a Deployment, StatefulSet or Job
+	// to offload the whole workload (rather than the single pods) to the remote cluster whose ID is the annotation value.
+	WorkloadOffloadingAnnotationKey = "liqo.io/workload-offloading"
+	// WorkloadOffloadingSchedulingGate is the scheduling gate added to the local pods of an offloaded workload,
+	// which are kept as placeholders while the actual pods are managed by the provider cluster.
+	WorkloadOffloadingSchedulingGate = "liqo.io/workload-offloading"
+	// RemoteWorkloadStatusAnnotationKey is the annotation key used to report on a local workload the status
+	// aggregated from the corresponding workload running in the provider cluster.
+	RemoteWorkloadStatusAnnotationKey = "liqo.io/remote-workload-status"
+	// RemotePodDisruptionBudgetStatusAnnotationKey is the annotation key used to report on a local PodDisruptionBudget
+	// the status of the corresponding one enforced in the provider cluster.
+	RemotePodDisruptionBudgetStatusAnnotationKey = "liqo.io/remote-pdb-status"
+
 	// UseDirectConnectionAnnotationKey is the annotation key set on a Service in the consumer cluster to
 	// request the use of direct connections between provider clusters for the service endpoints.
 	UseDirectConnectionAnnotationKey = "liqo.io/use-direct-connections"
+
+	// ServiceExportClustersAnnotationKey is the annotation key set on a ServiceExport to restrict the peer clusters
+	// the Service is exported to. The value must be a comma-separated list of cluster IDs.
+	// If not set, the Service is exported to all the peer clusters the local cluster is a consumer of.
+	ServiceExportClustersAnnotationKey = "liqo.io/export-to-clusters"
+	// ExportedServiceNameLabelKey is the label key set on an ExportedService to identify the name of the exported Service.
+	ExportedServiceNameLabelKey = "liqo.io/exported-service-name"
+	// ExportedServiceNamespaceLabelKey is the label key set on an ExportedService to identify the namespace of the exported Service.
+	ExportedServiceNamespaceLabelKey = "liqo.io/exported-service-namespace"
+	// ServiceImportEnabledLabelKey is the label key set (with value "true") on a namespace to allow importing into it
+	// the Services exported by the peer clusters, as the target namespace is chosen by the exporting cluster.
+	ServiceImportEnabledLabelKey = "liqo.io/service-import-enabled"
 )
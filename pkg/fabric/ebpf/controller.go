@@ -0,0 +1,140 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/fabric"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	utilspredicates "github.com/liqotech/liqo/pkg/utils/predicates"
+)
+
+// DatapathReconciler feeds the eBPF datapath with the fabric configuration.
+// Since the maps contain the union of all the configurations, every event triggers a full resync.
+type DatapathReconciler struct {
+	client.Client
+	Datapath *Datapath
+	Options  *fabric.Options
+	// Labels used to filter the FirewallConfigurations.
+	LabelsSets []labels.Set
+}
+
+// NewDatapathReconciler returns a new DatapathReconciler.
+func NewDatapathReconciler(cl client.Client, datapath *Datapath,
+	opts *fabric.Options, labelsSets []labels.Set) *DatapathReconciler {
+	return &DatapathReconciler{
+		Client:     cl,
+		Datapath:   datapath,
+		Options:    opts,
+		LabelsSets: labelsSets,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=firewallconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=routeconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=internalnodes,verbs=get;list;watch
+
+// Reconcile enforces the current fabric configuration in the eBPF maps and attaches the programs.
+func (r *DatapathReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	fwcfgs, err := r.listFirewallConfigurations(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rcfgs, err := getters.ListRouteConfigurationsByLabel(ctx, r.Client,
+		labels.SelectorFromSet(fabric.ForgeRouteTargetLabels()))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing routeconfigurations: %w", err)
+	}
+	rcfgs.Items = activeRouteConfigurations(rcfgs.Items)
+
+	entries := ForgeEntries(fwcfgs, rcfgs.Items, r.Datapath.bypassEnabled())
+	if err := r.Datapath.Sync(entries); err != nil {
+		return ctrl.Result{}, fmt.Errorf("syncing the eBPF datapath: %w", err)
+	}
+
+	klog.V(4).Infof("Enforced eBPF datapath: %d dnat, %d bypass and %d steering entries",
+		len(entries.DNAT), len(entries.Bypass), len(entries.Steering))
+
+	// Periodically resync, to handle the interfaces created after the last event (e.g., new pods).
+	return ctrl.Result{RequeueAfter: r.Options.EBPFResyncInterval}, nil
+}
+
+// listFirewallConfigurations returns the FirewallConfigurations matching any of the labels sets.
+func (r *DatapathReconciler) listFirewallConfigurations(ctx context.Context) ([]networkingv1beta1.FirewallConfiguration, error) {
+	var fwcfgs []networkingv1beta1.FirewallConfiguration
+	seen := make(map[types.NamespacedName]struct{})
+	for i := range r.LabelsSets {
+		list, err := getters.ListFirewallConfigurationsByLabel(ctx, r.Client, labels.SelectorFromSet(r.LabelsSets[i]))
+		if err != nil {
+			return nil, fmt.Errorf("listing firewallconfigurations: %w", err)
+		}
+		for j := range list.Items {
+			key := client.ObjectKeyFromObject(&list.Items[j])
+			if _, found := seen[key]; found || !list.Items[j].DeletionTimestamp.IsZero() {
+				continue
+			}
+			seen[key] = struct{}{}
+			fwcfgs = append(fwcfgs, list.Items[j])
+		}
+	}
+	return fwcfgs, nil
+}
+
+func activeRouteConfigurations(rcfgs []networkingv1beta1.RouteConfiguration) []networkingv1beta1.RouteConfiguration {
+	active := rcfgs[:0]
+	for i := range rcfgs {
+		if rcfgs[i].DeletionTimestamp.IsZero() {
+			active = append(active, rcfgs[i])
+		}
+	}
+	return active
+}
+
+// SetupWithManager registers the DatapathReconciler to the manager.
+func (r *DatapathReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	internalNodePredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == r.Options.NodeName
+	})
+
+	// All the events are mapped to the same request, as the reconciliation is global.
+	enqueuer := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: r.Options.NodeName}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlFirewallConfigurationEBPF).
+		Watches(&networkingv1beta1.FirewallConfiguration{}, enqueuer,
+			builder.WithPredicates(utilspredicates.NewAnyLabelsSetPredicate(r.LabelsSets))).
+		Watches(&networkingv1beta1.RouteConfiguration{}, enqueuer,
+			builder.WithPredicates(utilspredicates.NewAnyLabelsSetPredicate([]labels.Set{fabric.ForgeRouteTargetLabels()}))).
+		// The geneve interfaces are recreated when the InternalNode changes.
+		Watches(&networkingv1beta1.InternalNode{}, enqueuer, builder.WithPredicates(internalNodePredicate)).
+		Complete(r)
+}
@@ -0,0 +1,334 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/features"
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/fabric"
+)
+
+const (
+	// filterPriority is the priority of the TC filters attached by the datapath.
+	filterPriority = 0x4c51
+	// filterHandle is the handle of the TC filters attached by the datapath.
+	filterHandle = 1
+
+	// nftTableName is the name of the nftables table containing the masquerade bypass rule.
+	nftTableName = "liqo-fabric-ebpf"
+	// nftChainName is the name of the nftables chain containing the masquerade bypass rule.
+	nftChainName = "bypass"
+)
+
+// Datapath enforces part of the fabric configuration through eBPF programs attached to TC hooks.
+type Datapath struct {
+	interfaces []string
+	bypassMark uint32
+
+	maps    *maps
+	ingress *ebpf.Program
+	egress  *ebpf.Program
+}
+
+// CheckSupport returns an error if the running kernel does not support the eBPF datapath.
+func CheckSupport() error {
+	if err := features.HaveProgramHelper(ebpf.SchedCLS, asm.FnRedirectNeigh); err != nil {
+		return fmt.Errorf("the kernel does not support the bpf_redirect_neigh helper: %w", err)
+	}
+	return nil
+}
+
+// NewDatapath loads the eBPF programs and maps, and enforces the nftables rule
+// that skips the masquerade for the packets flagged by the programs.
+func NewDatapath(opts *fabric.Options) (*Datapath, error) {
+	if opts.EBPFBypassMark == 0 {
+		return nil, fmt.Errorf("the bypass mark cannot be zero")
+	}
+
+	m, err := newMaps()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Datapath{
+		interfaces: opts.EBPFInterfaces,
+		bypassMark: opts.EBPFBypassMark,
+		maps:       m,
+	}
+
+	if d.ingress, err = newProgram(ingressProgramName, ingressInstructions(m, d.bypassMark)); err != nil {
+		d.Close()
+		return nil, err
+	}
+	if d.egress, err = newProgram(egressProgramName, egressInstructions(m)); err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	if err := ensureBypassTable(d.bypassMark); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Close releases the programs and maps. The attached programs keep running until they are detached.
+func (d *Datapath) Close() {
+	for _, prog := range []*ebpf.Program{d.ingress, d.egress} {
+		if prog != nil {
+			_ = prog.Close()
+		}
+	}
+	d.maps.close()
+}
+
+// IsNatRuleOffloaded returns whether the given NAT rule is enforced by the eBPF datapath.
+func (d *Datapath) IsNatRuleOffloaded(chain *firewallapi.Chain, rule *firewallapi.NatRule) bool {
+	return isNatRuleOffloadable(chain, rule, d.bypassEnabled())
+}
+
+// bypassEnabled returns whether the masquerade bypass is enforced by the eBPF datapath.
+func (d *Datapath) bypassEnabled() bool {
+	return len(d.interfaces) > 0
+}
+
+// Sync enforces the given entries in the maps, and attaches the programs to the pod interfaces
+// and to the interfaces towards the gateways. Both hooks are used on every interface, so that the replies
+// of the translated connections are translated back whatever the interface they leave the node from.
+func (d *Datapath) Sync(entries *Entries) error {
+	if err := d.maps.sync(entries.toDesiredMaps(ifindexByName)); err != nil {
+		return fmt.Errorf("syncing the maps: %w", err)
+	}
+
+	var errs []error
+	for _, dev := range entries.Devices() {
+		link, err := netlink.LinkByName(dev)
+		if err != nil {
+			// The interface may have not been created yet: it will be handled at the next resync.
+			klog.V(4).Infof("Skipping interface %s: %v", dev, err)
+			continue
+		}
+		errs = append(errs,
+			attachProgram(link, netlink.HANDLE_MIN_INGRESS, d.ingress, ingressProgramName),
+			attachProgram(link, netlink.HANDLE_MIN_EGRESS, d.egress, egressProgramName))
+	}
+
+	if d.bypassEnabled() {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return fmt.Errorf("listing interfaces: %w", err)
+		}
+		for _, link := range links {
+			if link.Attrs().EncapType == "ether" && matchesAny(link.Attrs().Name, d.interfaces) {
+				errs = append(errs,
+					attachProgram(link, netlink.HANDLE_MIN_INGRESS, d.ingress, ingressProgramName),
+					attachProgram(link, netlink.HANDLE_MIN_EGRESS, d.egress, egressProgramName))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cleanup detaches the programs attached by any datapath instance and removes the nftables table.
+// It is meant to be called when the eBPF datapath is disabled, to remove the leftovers of previous runs.
+// The cleanup is best-effort: it proceeds with the other interfaces in case of errors, which are returned.
+func Cleanup() error {
+	errs := []error{deleteBypassTable()}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("listing interfaces: %w", err))...)
+	}
+	for _, link := range links {
+		for _, parent := range []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS} {
+			errs = append(errs, detachPrograms(link, parent))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func ifindexByName(dev string) (int, error) {
+	link, err := netlink.LinkByName(dev)
+	if err != nil {
+		return 0, err
+	}
+	return link.Attrs().Index, nil
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// attachProgram attaches the given program to the hook identified by parent, unless already attached.
+func attachProgram(link netlink.Link, parent uint32, prog *ebpf.Program, name string) error {
+	info, err := prog.Info()
+	if err != nil {
+		return fmt.Errorf("getting info of program %q: %w", name, err)
+	}
+	id, _ := info.ID()
+
+	qdisc := &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+	if err := netlink.QdiscAdd(qdisc); err != nil && !errors.Is(err, unix.EEXIST) {
+		return fmt.Errorf("adding clsact qdisc to interface %s: %w", link.Attrs().Name, err)
+	}
+
+	filters, err := netlink.FilterList(link, parent)
+	if err != nil {
+		return fmt.Errorf("listing filters of interface %s: %w", link.Attrs().Name, err)
+	}
+	for _, filter := range filters {
+		if bpf, ok := filter.(*netlink.BpfFilter); ok && bpf.Name == name && bpf.Id == int(id) {
+			return nil
+		}
+	}
+
+	filter := &netlink.BpfFilter{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    parent,
+			Handle:    filterHandle,
+			Protocol:  unix.ETH_P_ALL,
+			Priority:  filterPriority,
+		},
+		Fd:           prog.FD(),
+		Name:         name,
+		DirectAction: true,
+	}
+	if err := netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("attaching program %q to interface %s: %w", name, link.Attrs().Name, err)
+	}
+	klog.Infof("Attached program %q to interface %s", name, link.Attrs().Name)
+	return nil
+}
+
+// detachPrograms removes the filters attached by the datapath to the hook identified by parent.
+func detachPrograms(link netlink.Link, parent uint32) error {
+	filters, err := netlink.FilterList(link, parent)
+	if err != nil {
+		if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOENT) {
+			// The interface has no clsact qdisc.
+			return nil
+		}
+		return fmt.Errorf("listing filters of interface %s: %w", link.Attrs().Name, err)
+	}
+
+	var errs []error
+	for _, filter := range filters {
+		bpf, ok := filter.(*netlink.BpfFilter)
+		if !ok || (bpf.Name != ingressProgramName && bpf.Name != egressProgramName) {
+			continue
+		}
+		if err := netlink.FilterDel(bpf); err != nil {
+			errs = append(errs, fmt.Errorf("detaching program %q from interface %s: %w", bpf.Name, link.Attrs().Name, err))
+			continue
+		}
+		klog.Infof("Detached program %q from interface %s", bpf.Name, link.Attrs().Name)
+	}
+	return errors.Join(errs...)
+}
+
+// ensureBypassTable enforces the nftables rule translating the source address of the packets
+// flagged with the bypass mark to itself, which prevents any subsequent masquerade.
+func ensureBypassTable(mark uint32) error {
+	conn, err := nftables.New()
+	if err != nil {
+		return fmt.Errorf("creating nftables connection: %w", err)
+	}
+
+	table := &nftables.Table{Name: nftTableName, Family: nftables.TableFamilyIPv4}
+	// Adding and deleting the table first guarantees that it is recreated from scratch.
+	conn.AddTable(table)
+	conn.DelTable(table)
+	table = conn.AddTable(table)
+
+	priority := nftables.ChainPriority(int32(firewallapi.ChainPriorityNATSource) - 2)
+	chain := conn.AddChain(&nftables.Chain{
+		Name:     nftChainName,
+		Table:    table,
+		Type:     nftables.ChainTypeNAT,
+		Hooknum:  nftables.ChainHookPostrouting,
+		Priority: &priority,
+	})
+
+	conn.AddRule(&nftables.Rule{
+		Table: table,
+		Chain: chain,
+		Exprs: []expr.Any{
+			&expr.Meta{Key: expr.MetaKeyMARK, Register: 1},
+			&expr.Bitwise{
+				SourceRegister: 1,
+				DestRegister:   1,
+				Len:            4,
+				Mask:           binaryutil.NativeEndian.PutUint32(mark),
+				Xor:            binaryutil.NativeEndian.PutUint32(0),
+			},
+			&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: binaryutil.NativeEndian.PutUint32(mark)},
+			&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 12, Len: 4},
+			&expr.NAT{Type: expr.NATTypeSourceNAT, Family: unix.NFPROTO_IPV4, RegAddrMin: 1, Specified: true},
+		},
+	})
+
+	if err := conn.Flush(); err != nil {
+		return fmt.Errorf("enforcing nftables table %q: %w", nftTableName, err)
+	}
+	return nil
+}
+
+// deleteBypassTable removes the nftables table containing the masquerade bypass rule, if present.
+func deleteBypassTable() error {
+	conn, err := nftables.New()
+	if err != nil {
+		return fmt.Errorf("creating nftables connection: %w", err)
+	}
+
+	tables, err := conn.ListTablesOfFamily(nftables.TableFamilyIPv4)
+	if err != nil {
+		return fmt.Errorf("listing nftables tables: %w", err)
+	}
+	for _, table := range tables {
+		if table.Name == nftTableName {
+			conn.DelTable(table)
+			if err := conn.Flush(); err != nil {
+				return fmt.Errorf("deleting nftables table %q: %w", nftTableName, err)
+			}
+		}
+	}
+	return nil
+}
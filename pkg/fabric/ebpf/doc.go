@@ -0,0 +1,20 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ebpf implements an alternative datapath for the network fabric based on eBPF programs attached
+// to TC hooks. The remapping NAT, the gateway steering and the masquerade bypass are enforced through BPF maps,
+// which are fed by the same FirewallConfiguration, RouteConfiguration and InternalNode resources consumed by the
+// nftables datapath. The configurations that cannot be expressed through the maps are left to nftables and
+// netlink, which act as fallback.
+package ebpf
@@ -0,0 +1,333 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+// Bypass identifies the traffic which must not be masqueraded when leaving the node.
+type Bypass struct {
+	// Src is the source prefix.
+	Src netip.Prefix
+	// Dst is the destination prefix.
+	Dst netip.Prefix
+	// Proto is the L4 protocol (0 matches any protocol).
+	Proto uint8
+	// Port is the destination port (0 matches any port).
+	Port uint16
+}
+
+// Nexthop is the next hop towards the gateway for a remote prefix.
+type Nexthop struct {
+	// Dev is the name of the interface towards the gateway.
+	Dev string
+	// Gateway is the address of the gateway.
+	Gateway netip.Addr
+}
+
+// Entries contains the configuration enforced through the eBPF maps.
+type Entries struct {
+	// DNAT maps the destination addresses to be translated to the new ones.
+	DNAT map[netip.Addr]netip.Addr
+	// Bypass contains the traffic which must not be masqueraded.
+	Bypass []Bypass
+	// Steering maps the remote prefixes to the next hop towards the gateway.
+	Steering map[netip.Prefix]Nexthop
+}
+
+// NewEntries returns a new empty Entries.
+func NewEntries() *Entries {
+	return &Entries{
+		DNAT:     make(map[netip.Addr]netip.Addr),
+		Steering: make(map[netip.Prefix]Nexthop),
+	}
+}
+
+// ForgeEntries builds the entries to be enforced through the eBPF maps from the given
+// firewall and route configurations. The masquerade bypass rules are considered only
+// if enableBypass is true, as they require the programs to be attached to the pod interfaces.
+func ForgeEntries(fwcfgs []networkingv1beta1.FirewallConfiguration,
+	rcfgs []networkingv1beta1.RouteConfiguration, enableBypass bool) *Entries {
+	entries := NewEntries()
+
+	for i := range fwcfgs {
+		chains := fwcfgs[i].Spec.Table.Chains
+		for j := range chains {
+			for k := range chains[j].Rules.NatRules {
+				rule := &chains[j].Rules.NatRules[k]
+				if from, to, ok := dnatFromNatRule(&chains[j], rule); ok {
+					if _, found := entries.DNAT[from]; !found {
+						entries.DNAT[from] = to
+					}
+				}
+				if enableBypass {
+					if bypass, ok := bypassFromNatRule(&chains[j], rule); ok {
+						entries.Bypass = append(entries.Bypass, *bypass)
+					}
+				}
+			}
+		}
+	}
+
+	for i := range rcfgs {
+		for prefix, nexthop := range steeringFromTable(&rcfgs[i].Spec.Table) {
+			if _, found := entries.Steering[prefix]; !found {
+				entries.Steering[prefix] = nexthop
+			}
+		}
+	}
+
+	return entries
+}
+
+// isNatRuleOffloadable returns whether the given NAT rule can be removed from nftables, as fully enforced through
+// the eBPF maps. The DNAT rules are never removed, since the programs translate only the packets traversing the
+// interfaces they are attached to: the packets already translated no longer match the nftables rule, while the
+// ones entering from the other interfaces are still translated by nftables.
+func isNatRuleOffloadable(chain *firewallapi.Chain, rule *firewallapi.NatRule, enableBypass bool) bool {
+	if !enableBypass {
+		return false
+	}
+	_, ok := bypassFromNatRule(chain, rule)
+	return ok
+}
+
+// dnatFromNatRule returns the address translation described by the given rule, provided that it is a
+// prerouting DNAT towards a single address, matching only a single destination address.
+func dnatFromNatRule(chain *firewallapi.Chain, rule *firewallapi.NatRule) (from, to netip.Addr, ok bool) {
+	if rule.NatType != firewallapi.NatTypeDestination || ptr.Deref(chain.Hook, "") != firewallapi.ChainHookPrerouting {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	if len(rule.Match) != 1 || !isIPOnlyMatch(&rule.Match[0], firewallapi.MatchPositionDst) {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+
+	from, err := parseAddr(rule.Match[0].IP.Value)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	to, err = parseAddr(ptr.Deref(rule.To, ""))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	return from, to, true
+}
+
+// bypassFromNatRule returns the masquerade bypass described by the given rule, provided that it is a
+// postrouting SNAT to the source address itself, matching only on addresses, L4 protocol and destination port.
+func bypassFromNatRule(chain *firewallapi.Chain, rule *firewallapi.NatRule) (*Bypass, bool) {
+	if rule.NatType != firewallapi.NatTypeSource || ptr.Deref(chain.Hook, "") != firewallapi.ChainHookPostrouting {
+		return nil, false
+	}
+
+	bypass := Bypass{Dst: netip.PrefixFrom(netip.IPv4Unspecified(), 0)}
+	var src string
+	for i := range rule.Match {
+		match := &rule.Match[i]
+		if match.Op != firewallapi.MatchOperationEq || match.Dev != nil {
+			return nil, false
+		}
+
+		if match.IP != nil {
+			prefix, err := parsePrefix(match.IP.Value)
+			if err != nil {
+				return nil, false
+			}
+			switch match.IP.Position {
+			case firewallapi.MatchPositionSrc:
+				src, bypass.Src = match.IP.Value, prefix
+			case firewallapi.MatchPositionDst:
+				bypass.Dst = prefix
+			default:
+				return nil, false
+			}
+		}
+
+		if match.Proto != nil {
+			switch match.Proto.Value {
+			case firewallapi.L4ProtoTCP:
+				bypass.Proto = 6
+			case firewallapi.L4ProtoUDP:
+				bypass.Proto = 17
+			default:
+				return nil, false
+			}
+		}
+
+		if match.Port != nil {
+			port, err := strconv.ParseUint(match.Port.Value, 10, 16)
+			if err != nil || match.Port.Position != firewallapi.MatchPositionDst {
+				return nil, false
+			}
+			bypass.Port = uint16(port)
+		}
+	}
+
+	// The source must be matched, and the rule must translate it to itself.
+	if src == "" || ptr.Deref(rule.To, "") != src {
+		return nil, false
+	}
+	// A port can be matched only together with the protocol.
+	if bypass.Port != 0 && bypass.Proto == 0 {
+		return nil, false
+	}
+	return &bypass, true
+}
+
+// steeringFromTable returns the remote prefixes reachable through a gateway connected via a link route.
+func steeringFromTable(table *networkingv1beta1.Table) map[netip.Prefix]Nexthop {
+	// Collect the gateways reachable through a device.
+	devices := make(map[netip.Addr]string)
+	for i := range table.Rules {
+		for j := range table.Rules[i].Routes {
+			route := &table.Rules[i].Routes[j]
+			if route.Dev == nil || route.Gw != nil || route.Dst == nil {
+				continue
+			}
+			prefix, err := parsePrefix(route.Dst.String())
+			if err != nil || !prefix.IsSingleIP() {
+				continue
+			}
+			devices[prefix.Addr()] = *route.Dev
+		}
+	}
+
+	steering := make(map[netip.Prefix]Nexthop)
+	for i := range table.Rules {
+		rule := &table.Rules[i]
+		if rule.Dst == nil || rule.Src != nil || rule.Iif != nil || rule.Oif != nil || rule.FwMark != nil || len(rule.Routes) != 1 {
+			continue
+		}
+		route := &rule.Routes[0]
+		if route.Gw == nil || route.Dev != nil || route.Src != nil || route.Dst == nil || *route.Dst != *rule.Dst {
+			continue
+		}
+		prefix, err := parsePrefix(rule.Dst.String())
+		if err != nil {
+			continue
+		}
+		gw, err := parseAddr(route.Gw.String())
+		if err != nil {
+			continue
+		}
+		if dev, found := devices[gw]; found {
+			steering[prefix] = Nexthop{Dev: dev, Gateway: gw}
+		}
+	}
+	return steering
+}
+
+// toDesiredMaps converts the entries to the content of the maps, resolving the interface indexes
+// through the given function. The steering entries whose interface cannot be resolved are skipped.
+func (e *Entries) toDesiredMaps(ifindex func(dev string) (int, error)) *desiredMaps {
+	desired := &desiredMaps{
+		dnat:      make(map[addr]addr, len(e.DNAT)),
+		bypassDst: make(map[lpmKey]uint32),
+		bypassSrc: make(map[bypassKey]bypassValue, len(e.Bypass)),
+		steer:     make(map[lpmKey]steerValue, len(e.Steering)),
+	}
+
+	for from, to := range e.DNAT {
+		desired.dnat[toAddr(from)] = toAddr(to)
+	}
+
+	// Assign the classes in a deterministic order, to avoid useless updates of the maps.
+	dsts := make([]netip.Prefix, 0, len(e.Bypass))
+	for i := range e.Bypass {
+		dsts = append(dsts, e.Bypass[i].Dst.Masked())
+	}
+	slices.SortFunc(dsts, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+	dsts = slices.Compact(dsts)
+	for i := range dsts {
+		desired.bypassDst[toLPMKey(dsts[i])] = uint32(i + 1)
+	}
+	for i := range e.Bypass {
+		bypass := &e.Bypass[i]
+		key := bypassKey{
+			PrefixLen: 32 + uint32(bypass.Src.Bits()),
+			Class:     desired.bypassDst[toLPMKey(bypass.Dst.Masked())],
+			Addr:      toAddr(bypass.Src.Masked().Addr()),
+		}
+		value := bypassValue{Proto: bypass.Proto}
+		binary.BigEndian.PutUint16(value.Port[:], bypass.Port)
+		desired.bypassSrc[key] = value
+	}
+
+	for prefix, nexthop := range e.Steering {
+		index, err := ifindex(nexthop.Dev)
+		if err != nil {
+			continue
+		}
+		desired.steer[toLPMKey(prefix)] = steerValue{Ifindex: uint32(index), Nexthop: toAddr(nexthop.Gateway)}
+	}
+	return desired
+}
+
+// Devices returns the interfaces towards the gateways referenced by the steering entries.
+func (e *Entries) Devices() []string {
+	var devs []string
+	for _, nexthop := range e.Steering {
+		devs = append(devs, nexthop.Dev)
+	}
+	slices.Sort(devs)
+	return slices.Compact(devs)
+}
+
+func isIPOnlyMatch(match *firewallapi.Match, position firewallapi.MatchPosition) bool {
+	return match.Op == firewallapi.MatchOperationEq && match.IP != nil && match.IP.Position == position &&
+		match.Port == nil && match.Proto == nil && match.Dev == nil
+}
+
+// parsePrefix parses an IPv4 prefix or address, the latter being converted to a single address prefix.
+func parsePrefix(value string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		if !prefix.Addr().Is4() {
+			return netip.Prefix{}, fmt.Errorf("%q is not an IPv4 prefix", value)
+		}
+		return prefix.Masked(), nil
+	}
+	address, err := netip.ParseAddr(value)
+	if err != nil || !address.Is4() {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IPv4 prefix nor address", value)
+	}
+	return netip.PrefixFrom(address, 32), nil
+}
+
+// parseAddr parses an IPv4 address, possibly expressed as a single address prefix.
+func parseAddr(value string) (netip.Addr, error) {
+	prefix, err := parsePrefix(value)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if !prefix.IsSingleIP() {
+		return netip.Addr{}, fmt.Errorf("%q is not a single IPv4 address", value)
+	}
+	return prefix.Addr(), nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"fmt"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+var _ = Describe("Entries", func() {
+	ipMatch := func(op firewallapi.MatchOperation, position firewallapi.MatchPosition, value string) firewallapi.Match {
+		return firewallapi.Match{Op: op, IP: &firewallapi.MatchIP{Position: position, Value: value}}
+	}
+
+	postrouting := &firewallapi.Chain{Hook: ptr.To(firewallapi.ChainHookPostrouting)}
+	prerouting := &firewallapi.Chain{Hook: ptr.To(firewallapi.ChainHookPrerouting)}
+
+	bypassRule := func(src, dst string) *firewallapi.NatRule {
+		return &firewallapi.NatRule{
+			Match: []firewallapi.Match{
+				ipMatch(firewallapi.MatchOperationEq, firewallapi.MatchPositionDst, dst),
+				ipMatch(firewallapi.MatchOperationEq, firewallapi.MatchPositionSrc, src),
+			},
+			NatType: firewallapi.NatTypeSource,
+			To:      ptr.To(src),
+		}
+	}
+
+	dnatRule := func(from, to string) *firewallapi.NatRule {
+		return &firewallapi.NatRule{
+			Match:   []firewallapi.Match{ipMatch(firewallapi.MatchOperationEq, firewallapi.MatchPositionDst, from)},
+			NatType: firewallapi.NatTypeDestination,
+			To:      ptr.To(to),
+		}
+	}
+
+	Describe("the classification of the NAT rules", func() {
+		It("should offload the masquerade bypass rules", func() {
+			bypass, ok := bypassFromNatRule(postrouting, bypassRule("10.244.0.0/16", "10.70.0.0/16"))
+			Expect(ok).To(BeTrue())
+			Expect(*bypass).To(Equal(Bypass{
+				Src: netip.MustParsePrefix("10.244.0.0/16"),
+				Dst: netip.MustParsePrefix("10.70.0.0/16"),
+			}))
+			Expect(isNatRuleOffloadable(postrouting, bypassRule("10.244.0.0/16", "10.70.0.0/16"), true)).To(BeTrue())
+		})
+
+		It("should offload the masquerade bypass rules restricted to a port", func() {
+			rule := bypassRule("10.244.0.9", "10.70.0.0/16")
+			rule.Match = append(rule.Match,
+				firewallapi.Match{Op: firewallapi.MatchOperationEq, Proto: &firewallapi.MatchProto{Value: firewallapi.L4ProtoUDP}},
+				firewallapi.Match{Op: firewallapi.MatchOperationEq, Port: &firewallapi.MatchPort{
+					Position: firewallapi.MatchPositionDst, Value: "6091"}})
+			bypass, ok := bypassFromNatRule(postrouting, rule)
+			Expect(ok).To(BeTrue())
+			Expect(*bypass).To(Equal(Bypass{
+				Src:   netip.MustParsePrefix("10.244.0.9/32"),
+				Dst:   netip.MustParsePrefix("10.70.0.0/16"),
+				Proto: 17,
+				Port:  6091,
+			}))
+		})
+
+		It("should not offload the masquerade bypass rules if disabled", func() {
+			Expect(isNatRuleOffloadable(postrouting, bypassRule("10.244.0.0/16", "10.70.0.0/16"), false)).To(BeFalse())
+		})
+
+		It("should not offload the SNAT rules translating to a different address", func() {
+			rule := bypassRule("10.244.0.0/16", "10.70.0.0/16")
+			rule.To = ptr.To("10.71.255.254")
+			Expect(isNatRuleOffloadable(postrouting, rule, true)).To(BeFalse())
+		})
+
+		It("should not offload the rules with non equality matches", func() {
+			rule := bypassRule("10.244.0.0/16", "10.70.0.0/16")
+			rule.Match = append(rule.Match, ipMatch(firewallapi.MatchOperationNeq, firewallapi.MatchPositionSrc, "10.245.0.0/16"))
+			Expect(isNatRuleOffloadable(postrouting, rule, true)).To(BeFalse())
+		})
+
+		It("should not offload the rules matching IP ranges", func() {
+			rule := bypassRule("10.244.0.1-10.244.0.9", "10.70.0.0/16")
+			Expect(isNatRuleOffloadable(postrouting, rule, true)).To(BeFalse())
+		})
+
+		It("should enforce the DNAT rules towards a single address, keeping them in nftables", func() {
+			from, to, ok := dnatFromNatRule(prerouting, dnatRule("10.71.0.5", "10.244.0.7"))
+			Expect(ok).To(BeTrue())
+			Expect(from).To(Equal(netip.MustParseAddr("10.71.0.5")))
+			Expect(to).To(Equal(netip.MustParseAddr("10.244.0.7")))
+			// The packets entering from the interfaces without programs must still be translated by nftables.
+			Expect(isNatRuleOffloadable(prerouting, dnatRule("10.71.0.5", "10.244.0.7"), true)).To(BeFalse())
+		})
+
+		It("should not enforce the DNAT rules towards a subnet", func() {
+			_, _, ok := dnatFromNatRule(prerouting, dnatRule("10.71.0.0/24", "10.244.0.0/24"))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not offload the rules in chains with a different hook", func() {
+			Expect(isNatRuleOffloadable(postrouting, dnatRule("10.71.0.5", "10.244.0.7"), true)).To(BeFalse())
+			Expect(isNatRuleOffloadable(prerouting, bypassRule("10.244.0.0/16", "10.70.0.0/16"), true)).To(BeFalse())
+		})
+	})
+
+	Describe("the forging of the entries", func() {
+		var (
+			fwcfgs []networkingv1beta1.FirewallConfiguration
+			rcfgs  []networkingv1beta1.RouteConfiguration
+		)
+
+		BeforeEach(func() {
+			postroutingChain := postrouting.DeepCopy()
+			postroutingChain.Rules.NatRules = []firewallapi.NatRule{
+				*bypassRule("10.244.0.0/16", "10.70.0.0/16"),
+				*bypassRule("10.244.0.0/16", "10.71.0.0/16"),
+			}
+			preroutingChain := prerouting.DeepCopy()
+			preroutingChain.Rules.NatRules = []firewallapi.NatRule{*dnatRule("10.71.0.5", "10.244.0.7")}
+
+			fwcfgs = []networkingv1beta1.FirewallConfiguration{{
+				Spec: networkingv1beta1.FirewallConfigurationSpec{
+					Table: firewallapi.Table{Chains: []firewallapi.Chain{*postroutingChain, *preroutingChain}},
+				},
+			}}
+
+			rcfgs = []networkingv1beta1.RouteConfiguration{{
+				Spec: networkingv1beta1.RouteConfigurationSpec{
+					Table: networkingv1beta1.Table{Rules: []networkingv1beta1.Rule{
+						{
+							Dst: ptr.To(networkingv1beta1.CIDR("10.80.0.1/32")),
+							Routes: []networkingv1beta1.Route{{
+								Dst:   ptr.To(networkingv1beta1.CIDR("10.80.0.1/32")),
+								Dev:   ptr.To("liqo-gw"),
+								Scope: ptr.To(networkingv1beta1.LinkScope),
+							}},
+						},
+						{
+							Dst: ptr.To(networkingv1beta1.CIDR("10.70.0.0/16")),
+							Routes: []networkingv1beta1.Route{{
+								Dst: ptr.To(networkingv1beta1.CIDR("10.70.0.0/16")),
+								Gw:  ptr.To(networkingv1beta1.IP("10.80.0.1")),
+							}},
+						},
+						{
+							Dst: ptr.To(networkingv1beta1.CIDR("10.90.0.0/16")),
+							Routes: []networkingv1beta1.Route{{
+								Dst: ptr.To(networkingv1beta1.CIDR("10.90.0.0/16")),
+								Gw:  ptr.To(networkingv1beta1.IP("10.80.0.2")),
+							}},
+						},
+					}},
+				},
+			}}
+		})
+
+		It("should forge the entries from the configurations", func() {
+			entries := ForgeEntries(fwcfgs, rcfgs, true)
+			Expect(entries.DNAT).To(Equal(map[netip.Addr]netip.Addr{
+				netip.MustParseAddr("10.71.0.5"): netip.MustParseAddr("10.244.0.7"),
+			}))
+			Expect(entries.Bypass).To(HaveLen(2))
+			// The gateway of the second prefix is not reachable through a link route.
+			Expect(entries.Steering).To(Equal(map[netip.Prefix]Nexthop{
+				netip.MustParsePrefix("10.70.0.0/16"): {Dev: "liqo-gw", Gateway: netip.MustParseAddr("10.80.0.1")},
+			}))
+			Expect(entries.Devices()).To(ConsistOf("liqo-gw"))
+		})
+
+		It("should skip the bypass entries if disabled", func() {
+			Expect(ForgeEntries(fwcfgs, rcfgs, false).Bypass).To(BeEmpty())
+		})
+
+		It("should convert the entries to the content of the maps", func() {
+			ifindex := func(dev string) (int, error) {
+				if dev == "liqo-gw" {
+					return 42, nil
+				}
+				return 0, fmt.Errorf("interface %s not found", dev)
+			}
+
+			desired := ForgeEntries(fwcfgs, rcfgs, true).toDesiredMaps(ifindex)
+			Expect(desired.dnat).To(Equal(map[addr]addr{{10, 71, 0, 5}: {10, 244, 0, 7}}))
+			Expect(desired.bypassDst).To(Equal(map[lpmKey]uint32{
+				{PrefixLen: 16, Addr: addr{10, 70, 0, 0}}: 1,
+				{PrefixLen: 16, Addr: addr{10, 71, 0, 0}}: 2,
+			}))
+			Expect(desired.bypassSrc).To(Equal(map[bypassKey]bypassValue{
+				{PrefixLen: 48, Class: 1, Addr: addr{10, 244, 0, 0}}: {},
+				{PrefixLen: 48, Class: 2, Addr: addr{10, 244, 0, 0}}: {},
+			}))
+			Expect(desired.steer).To(Equal(map[lpmKey]steerValue{
+				{PrefixLen: 16, Addr: addr{10, 70, 0, 0}}: {Ifindex: 42, Nexthop: addr{10, 80, 0, 1}},
+			}))
+		})
+	})
+})
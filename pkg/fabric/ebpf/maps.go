@@ -0,0 +1,209 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/cilium/ebpf"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// maxEntries is the maximum number of entries of each map.
+	maxEntries = 16384
+	// maxConnections is the maximum number of translated connections tracked at the same time.
+	maxConnections = 65536
+)
+
+// addr is an IPv4 address in network byte order, as stored in the maps.
+type addr [4]byte
+
+// lpmKey is the key of the LPM trie maps indexed by IPv4 prefix.
+type lpmKey struct {
+	PrefixLen uint32
+	Addr      addr
+}
+
+// bypassKey is the key of the map containing the sources allowed to bypass the masquerade.
+// The destination class is always fully matched, hence the prefix length is 32 plus the one of the source.
+type bypassKey struct {
+	PrefixLen uint32
+	Class     uint32
+	Addr      addr
+}
+
+// bypassValue restricts a masquerade bypass entry to a given L4 protocol and destination port.
+// A zero value matches any protocol or port.
+type bypassValue struct {
+	Proto uint8
+	_     uint8
+	Port  [2]byte
+}
+
+// ctKey identifies a connection whose destination address has been translated by the ingress program,
+// as seen in the original direction. The ports are zero for the protocols other than TCP and UDP.
+type ctKey struct {
+	Proto      uint8
+	_          [3]uint8
+	Client     addr
+	Server     addr
+	ClientPort [2]byte
+	ServerPort [2]byte
+}
+
+// steerValue is the next hop towards the gateway for a given destination prefix.
+type steerValue struct {
+	Ifindex uint32
+	Nexthop addr
+}
+
+// maps contains the maps shared by the eBPF programs.
+type maps struct {
+	// dnat translates the destination address of the packets entering the node.
+	dnat *ebpf.Map
+	// ct tracks the translated connections, mapping them to the original destination address,
+	// to translate back the source address of the replies.
+	ct *ebpf.Map
+	// bypassDst assigns a class to the destinations involved in the masquerade bypass.
+	bypassDst *ebpf.Map
+	// bypassSrc contains the sources allowed to bypass the masquerade for each destination class.
+	bypassSrc *ebpf.Map
+	// steer contains the next hop towards the gateway for each remote prefix.
+	steer *ebpf.Map
+}
+
+func newMaps() (*maps, error) {
+	var m maps
+	specs := []struct {
+		target **ebpf.Map
+		spec   *ebpf.MapSpec
+	}{
+		{&m.dnat, &ebpf.MapSpec{Name: "liqo_dnat", Type: ebpf.Hash, KeySize: 4, ValueSize: 4, MaxEntries: maxEntries}},
+		{&m.ct, &ebpf.MapSpec{Name: "liqo_ct", Type: ebpf.LRUHash, KeySize: 16, ValueSize: 4, MaxEntries: maxConnections}},
+		{&m.bypassDst, &ebpf.MapSpec{Name: "liqo_bypass_dst", Type: ebpf.LPMTrie, KeySize: 8, ValueSize: 4,
+			MaxEntries: maxEntries, Flags: unix.BPF_F_NO_PREALLOC}},
+		{&m.bypassSrc, &ebpf.MapSpec{Name: "liqo_bypass_src", Type: ebpf.LPMTrie, KeySize: 12, ValueSize: 4,
+			MaxEntries: maxEntries, Flags: unix.BPF_F_NO_PREALLOC}},
+		{&m.steer, &ebpf.MapSpec{Name: "liqo_steer", Type: ebpf.LPMTrie, KeySize: 8, ValueSize: 8,
+			MaxEntries: maxEntries, Flags: unix.BPF_F_NO_PREALLOC}},
+	}
+
+	for i := range specs {
+		mp, err := ebpf.NewMap(specs[i].spec)
+		if err != nil {
+			m.close()
+			return nil, fmt.Errorf("creating map %q: %w", specs[i].spec.Name, err)
+		}
+		*specs[i].target = mp
+	}
+	return &m, nil
+}
+
+func (m *maps) close() {
+	for _, mp := range []*ebpf.Map{m.dnat, m.ct, m.bypassDst, m.bypassSrc, m.steer} {
+		if mp != nil {
+			_ = mp.Close()
+		}
+	}
+}
+
+// sync enforces the given entries, removing the stale ones.
+func (m *maps) sync(desired *desiredMaps) error {
+	return errors.Join(
+		syncMap(m.dnat, desired.dnat),
+		pruneConnections(m.ct, desired.dnat),
+		syncMap(m.bypassDst, desired.bypassDst),
+		syncMap(m.bypassSrc, desired.bypassSrc),
+		syncMap(m.steer, desired.steer),
+	)
+}
+
+// syncMap makes the content of the given map equal to the desired one.
+func syncMap[K comparable, V any](m *ebpf.Map, desired map[K]V) error {
+	var (
+		key   K
+		value V
+		stale []K
+	)
+
+	iter := m.Iterate()
+	for iter.Next(&key, &value) {
+		if _, ok := desired[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("iterating map %q: %w", m.String(), err)
+	}
+
+	for i := range stale {
+		if err := m.Delete(&stale[i]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("deleting stale entry from map %q: %w", m.String(), err)
+		}
+	}
+
+	for k, v := range desired {
+		if err := m.Update(&k, &v, ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("updating entry of map %q: %w", m.String(), err)
+		}
+	}
+	return nil
+}
+
+// pruneConnections removes the tracked connections whose translation is no longer configured,
+// so that the replies are not translated according to stale entries.
+func pruneConnections(m *ebpf.Map, dnat map[addr]addr) error {
+	var (
+		key   ctKey
+		value addr
+		stale []ctKey
+	)
+
+	iter := m.Iterate()
+	for iter.Next(&key, &value) {
+		if to, ok := dnat[value]; !ok || to != key.Server {
+			stale = append(stale, key)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("iterating map %q: %w", m.String(), err)
+	}
+
+	for i := range stale {
+		if err := m.Delete(&stale[i]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("deleting stale entry from map %q: %w", m.String(), err)
+		}
+	}
+	return nil
+}
+
+// desiredMaps contains the desired content of the maps, as derived from the Entries.
+type desiredMaps struct {
+	dnat      map[addr]addr
+	bypassDst map[lpmKey]uint32
+	bypassSrc map[bypassKey]bypassValue
+	steer     map[lpmKey]steerValue
+}
+
+func toAddr(a netip.Addr) addr {
+	return addr(a.As4())
+}
+
+func toLPMKey(p netip.Prefix) lpmKey {
+	return lpmKey{PrefixLen: uint32(p.Bits()), Addr: toAddr(p.Masked().Addr())}
+}
@@ -0,0 +1,334 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ingressProgramName is the name of the program attached to the ingress hook.
+	ingressProgramName = "liqo_fabric_in"
+	// egressProgramName is the name of the program attached to the egress hook of the geneve interfaces.
+	egressProgramName = "liqo_fabric_eg"
+
+	// Offsets of the fields of the __sk_buff context.
+	skbMarkOff     = 8
+	skbProtocolOff = 16
+
+	// The programs are attached to Ethernet interfaces only.
+	ethHLen = 14
+	ipHLen  = 20
+
+	// Stack layout, relative to the frame pointer.
+	stackIPHdr     = -24             // Copy of the fixed part of the IPv4 header.
+	stackIPFrag    = stackIPHdr + 6  // Flags and fragment offset.
+	stackIPProto   = stackIPHdr + 9  // L4 protocol.
+	stackIPSrc     = stackIPHdr + 12 // Source address.
+	stackIPDst     = stackIPHdr + 16 // Destination address.
+	stackLPMKey    = -32             // lpmKey used for the destination lookups.
+	stackBypassKey = -48             // bypassKey used for the source lookups.
+	stackL4Port    = -52             // Destination port of the packet.
+	stackNeigh     = -72             // struct bpf_redir_neigh.
+	stackCTKey     = -88             // ctKey of the connection the packet belongs to.
+	stackL4Ports   = -92             // Source and destination ports of the packet.
+	stackCTValue   = -96             // Original destination address of the connection.
+	neighSize      = stackL4Port - stackNeigh
+
+	// tcActUnspec lets the packet continue through the other filters attached to the same hook, if any.
+	tcActUnspec = -1
+
+	// Flags of the bpf_l4_csum_replace helper.
+	bpfFPseudoHdr   = 1 << 4
+	bpfFMarkMangled = 1 << 5
+)
+
+// htons converts a 16 bit value to network byte order, as seen by the programs on the running host.
+func htons(v uint16) uint16 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	return binary.NativeEndian.Uint16(b[:])
+}
+
+// loadIPv4Header returns the instructions copying the IPv4 header of the packet to the stack.
+// Non IPv4 packets jump to the given label. At the end, R6 holds the context and R7 the IPv4 header length.
+func loadIPv4Header(skip string) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R1, asm.R6, skbProtocolOff, asm.Word),
+		asm.JNE.Imm(asm.R1, int32(htons(unix.ETH_P_IP)), skip),
+
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.Mov.Imm(asm.R2, ethHLen),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackIPHdr),
+		asm.Mov.Imm(asm.R4, ipHLen),
+		asm.FnSkbLoadBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, skip),
+
+		asm.LoadMem(asm.R7, asm.RFP, stackIPHdr, asm.Byte),
+		asm.And.Imm(asm.R7, 0x0f),
+		asm.LSh.Imm(asm.R7, 2),
+	}
+}
+
+// skipFragments returns the instructions jumping to the given label for non-first fragments,
+// which do not carry the L4 header.
+func skipFragments(skip string) asm.Instructions {
+	return asm.Instructions{
+		asm.LoadMem(asm.R1, asm.RFP, stackIPFrag, asm.Byte),
+		asm.And.Imm(asm.R1, 0x1f),
+		asm.LoadMem(asm.R2, asm.RFP, stackIPFrag+1, asm.Byte),
+		asm.Or.Reg(asm.R1, asm.R2),
+		asm.JNE.Imm(asm.R1, 0, skip),
+	}
+}
+
+// rewriteAddress returns the instructions translating the address stored in the given header field
+// according to the value associated with the given stack key in the map, and fixing the L3 and L4 checksums
+// accordingly. The first instruction is labeled with the given prefix. The execution continues at the given
+// label, which must identify the instruction following the returned ones. On a match, R9 holds the original address.
+func rewriteAddress(m *ebpf.Map, key, field int16, prefix, next string) asm.Instructions {
+	store := prefix + "_store"
+	tcp, udp, l4 := prefix+"_tcp", prefix+"_udp", prefix+"_l4"
+
+	insns := asm.Instructions{
+		asm.LoadMapPtr(asm.R1, m.FD()).WithSymbol(prefix),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, int32(key)),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, next),
+
+		// R8 holds the translated address, R9 the original one.
+		asm.LoadMem(asm.R8, asm.R0, 0, asm.Word),
+		asm.LoadMem(asm.R9, asm.RFP, field, asm.Word),
+
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.Mov.Imm(asm.R2, ethHLen+10),
+		asm.Mov.Reg(asm.R3, asm.R9),
+		asm.Mov.Reg(asm.R4, asm.R8),
+		asm.Mov.Imm(asm.R5, 4),
+		asm.FnL3CsumReplace.Call(),
+	}
+
+	insns = append(insns, skipFragments(store)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R1, asm.RFP, stackIPProto, asm.Byte),
+		asm.JEq.Imm(asm.R1, unix.IPPROTO_TCP, tcp),
+		asm.JEq.Imm(asm.R1, unix.IPPROTO_UDP, udp),
+		asm.Ja.Label(store),
+
+		asm.Mov.Reg(asm.R2, asm.R7).WithSymbol(tcp),
+		asm.Add.Imm(asm.R2, ethHLen+16),
+		asm.Mov.Imm(asm.R5, bpfFPseudoHdr|4),
+		asm.Ja.Label(l4),
+
+		// A zero UDP checksum means that the checksum is not computed, and must be preserved.
+		asm.Mov.Reg(asm.R2, asm.R7).WithSymbol(udp),
+		asm.Add.Imm(asm.R2, ethHLen+6),
+		asm.Mov.Imm(asm.R5, bpfFPseudoHdr|bpfFMarkMangled|4),
+
+		asm.Mov.Reg(asm.R1, asm.R6).WithSymbol(l4),
+		asm.Mov.Reg(asm.R3, asm.R9),
+		asm.Mov.Reg(asm.R4, asm.R8),
+		asm.FnL4CsumReplace.Call(),
+
+		asm.StoreMem(asm.RFP, field, asm.R8, asm.Word).WithSymbol(store),
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.Mov.Imm(asm.R2, ethHLen+int32(field-stackIPHdr)),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, int32(field)),
+		asm.Mov.Imm(asm.R4, 4),
+		asm.Mov.Imm(asm.R5, 0),
+		asm.FnSkbStoreBytes.Call(),
+	)
+	return insns
+}
+
+// forgeCTKey returns the instructions storing on the stack the ctKey the packet belongs to. If reply is true,
+// the packet is considered to flow in the reply direction, hence source and destination are swapped. The ports
+// are set only for the TCP and UDP packets which are not a non-first fragment. The execution continues at the
+// given label, which must identify the instruction following the returned ones.
+func forgeCTKey(reply bool, prefix, next string) asm.Instructions {
+	client, server := int16(stackIPSrc), int16(stackIPDst)
+	clientPort, serverPort := asm.R1, asm.R2
+	if reply {
+		client, server = server, client
+		clientPort, serverPort = serverPort, clientPort
+	}
+	ports := prefix + "_ports"
+
+	insns := asm.Instructions{
+		asm.Mov.Imm(asm.R1, 0),
+		asm.StoreMem(asm.RFP, stackCTKey, asm.R1, asm.DWord),
+		asm.StoreMem(asm.RFP, stackCTKey+8, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.RFP, stackIPProto, asm.Byte),
+		asm.StoreMem(asm.RFP, stackCTKey, asm.R1, asm.Byte),
+		asm.LoadMem(asm.R1, asm.RFP, client, asm.Word),
+		asm.StoreMem(asm.RFP, stackCTKey+4, asm.R1, asm.Word),
+		asm.LoadMem(asm.R1, asm.RFP, server, asm.Word),
+		asm.StoreMem(asm.RFP, stackCTKey+8, asm.R1, asm.Word),
+	}
+
+	insns = append(insns, skipFragments(next)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R1, asm.RFP, stackIPProto, asm.Byte),
+		asm.JEq.Imm(asm.R1, unix.IPPROTO_TCP, ports),
+		asm.JNE.Imm(asm.R1, unix.IPPROTO_UDP, next),
+
+		asm.Mov.Reg(asm.R1, asm.R6).WithSymbol(ports),
+		asm.Mov.Reg(asm.R2, asm.R7),
+		asm.Add.Imm(asm.R2, ethHLen),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackL4Ports),
+		asm.Mov.Imm(asm.R4, 4),
+		asm.FnSkbLoadBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, next),
+
+		asm.LoadMem(asm.R1, asm.RFP, stackL4Ports, asm.Half),
+		asm.LoadMem(asm.R2, asm.RFP, stackL4Ports+2, asm.Half),
+		asm.StoreMem(asm.RFP, stackCTKey+12, clientPort, asm.Half),
+		asm.StoreMem(asm.RFP, stackCTKey+14, serverPort, asm.Half),
+	)
+	return insns
+}
+
+// ingressInstructions returns the program attached to the ingress hook. It translates the destination address
+// according to the dnat map (tracking the connection, so that the replies can be translated back), flags with
+// the bypass mark the packets which must not be masqueraded, and redirects them towards the corresponding gateway
+// if directed to a remote prefix. The other packets are never redirected, as they must traverse netfilter to be
+// masqueraded (e.g., when the full masquerade is enabled, and no bypass is configured).
+func ingressInstructions(m *maps, bypassMark uint32) asm.Instructions {
+	insns := loadIPv4Header("pass")
+	insns = append(insns, rewriteAddress(m.dnat, stackIPDst, stackIPDst, "dnat", "bypass")...)
+
+	// Track the translated connection, R9 holding the original destination address.
+	insns = append(insns, asm.StoreMem(asm.RFP, stackCTValue, asm.R9, asm.Word))
+	insns = append(insns, forgeCTKey(false, "fkey", "track")...)
+	insns = append(insns,
+		asm.LoadMapPtr(asm.R1, m.ct.FD()).WithSymbol("track"),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackCTKey),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackCTValue),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnMapUpdateElem.Call(),
+	)
+
+	insns = append(insns,
+		asm.StoreImm(asm.RFP, stackLPMKey, 32, asm.Word).WithSymbol("bypass"),
+		asm.LoadMem(asm.R1, asm.RFP, stackIPDst, asm.Word),
+		asm.StoreMem(asm.RFP, stackLPMKey+4, asm.R1, asm.Word),
+		asm.LoadMapPtr(asm.R1, m.bypassDst.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackLPMKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "pass"),
+
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.Word),
+		asm.StoreImm(asm.RFP, stackBypassKey, 64, asm.Word),
+		asm.StoreMem(asm.RFP, stackBypassKey+4, asm.R1, asm.Word),
+		asm.LoadMem(asm.R1, asm.RFP, stackIPSrc, asm.Word),
+		asm.StoreMem(asm.RFP, stackBypassKey+8, asm.R1, asm.Word),
+		asm.LoadMapPtr(asm.R1, m.bypassSrc.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackBypassKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "pass"),
+
+		// Check the L4 protocol and destination port, if the entry is restricted.
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.Byte),
+		asm.JEq.Imm(asm.R1, 0, "mark"),
+		asm.LoadMem(asm.R2, asm.RFP, stackIPProto, asm.Byte),
+		asm.JNE.Reg(asm.R1, asm.R2, "pass"),
+		asm.LoadMem(asm.R8, asm.R0, 2, asm.Half),
+		asm.JEq.Imm(asm.R8, 0, "mark"),
+	)
+	insns = append(insns, skipFragments("pass")...)
+	insns = append(insns,
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.Mov.Reg(asm.R2, asm.R7),
+		asm.Add.Imm(asm.R2, ethHLen+2),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackL4Port),
+		asm.Mov.Imm(asm.R4, 2),
+		asm.FnSkbLoadBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, "pass"),
+		asm.LoadMem(asm.R1, asm.RFP, stackL4Port, asm.Half),
+		asm.JNE.Reg(asm.R1, asm.R8, "pass"),
+
+		asm.LoadMem(asm.R1, asm.R6, skbMarkOff, asm.Word).WithSymbol("mark"),
+		asm.Or.Imm32(asm.R1, int32(bypassMark)),
+		asm.StoreMem(asm.R6, skbMarkOff, asm.R1, asm.Word),
+
+		// The LPM key of the destination has already been initialized.
+		asm.LoadMapPtr(asm.R1, m.steer.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackLPMKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "pass"),
+
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.Word),
+		asm.LoadMem(asm.R2, asm.R0, 4, asm.Word),
+		asm.StoreImm(asm.RFP, stackNeigh, unix.AF_INET, asm.Word),
+		asm.StoreMem(asm.RFP, stackNeigh+4, asm.R2, asm.Word),
+		asm.StoreImm(asm.RFP, stackNeigh+8, 0, asm.Word),
+		asm.StoreImm(asm.RFP, stackNeigh+12, 0, asm.Word),
+		asm.StoreImm(asm.RFP, stackNeigh+16, 0, asm.Word),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackNeigh),
+		asm.Mov.Imm(asm.R3, neighSize),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnRedirectNeigh.Call(),
+		asm.Return(),
+
+		asm.Mov.Imm(asm.R0, tcActUnspec).WithSymbol("pass"),
+		asm.Return(),
+	)
+	return insns
+}
+
+// egressInstructions returns the program attached to the egress hook of the geneve and pod interfaces.
+// It translates back the source address of the replies of the connections translated by the ingress program.
+// The translation happens at egress, after netfilter, so that conntrack sees the same addresses in both directions.
+func egressInstructions(m *maps) asm.Instructions {
+	insns := loadIPv4Header("pass")
+	insns = append(insns, forgeCTKey(true, "key", "rev")...)
+	insns = append(insns, rewriteAddress(m.ct, stackCTKey, stackIPSrc, "rev", "pass")...)
+	insns = append(insns,
+		asm.Mov.Imm(asm.R0, tcActUnspec).WithSymbol("pass"),
+		asm.Return(),
+	)
+	return insns
+}
+
+// newProgram loads the given instructions as a TC classifier.
+func newProgram(name string, insns asm.Instructions) (*ebpf.Program, error) {
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name:         name,
+		Type:         ebpf.SchedCLS,
+		Instructions: insns,
+		License:      "Apache-2.0",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading program %q: %w", name, err)
+	}
+	return prog, nil
+}
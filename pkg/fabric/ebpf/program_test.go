@@ -0,0 +1,132 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebpf
+
+import (
+	"encoding/binary"
+	"net/netip"
+
+	"github.com/cilium/ebpf"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+)
+
+var _ = Describe("Programs", func() {
+	var (
+		m               *maps
+		ingress, egress *ebpf.Program
+
+		from, to, client = addr{10, 71, 0, 5}, addr{10, 244, 0, 7}, addr{10, 70, 0, 9}
+	)
+
+	// udpPacket returns an Ethernet frame carrying an UDP datagram with the given addresses and ports.
+	udpPacket := func(src, dst addr, sport, dport uint16) []byte {
+		frame := make([]byte, ethHLen+ipHLen+8)
+		binary.BigEndian.PutUint16(frame[12:], 0x0800)
+		ip := frame[ethHLen:]
+		ip[0], ip[8], ip[9] = 0x45, 64, 17
+		binary.BigEndian.PutUint16(ip[2:], ipHLen+8)
+		copy(ip[12:], src[:])
+		copy(ip[16:], dst[:])
+		binary.BigEndian.PutUint16(ip[20:], sport)
+		binary.BigEndian.PutUint16(ip[22:], dport)
+		binary.BigEndian.PutUint16(ip[24:], 8)
+		return frame
+	}
+
+	// run executes the given program on the given frame, and returns the resulting source and destination addresses.
+	run := func(prog *ebpf.Program, frame []byte) (src, dst addr) {
+		out := make([]byte, len(frame))
+		_, err := prog.Run(&ebpf.RunOptions{Data: frame, DataOut: out})
+		Expect(err).ToNot(HaveOccurred())
+		copy(src[:], out[ethHLen+12:])
+		copy(dst[:], out[ethHLen+16:])
+		return src, dst
+	}
+
+	BeforeEach(func() {
+		var err error
+		if m, err = newMaps(); err != nil {
+			Skip("the eBPF maps cannot be created: " + err.Error())
+		}
+		DeferCleanup(m.close)
+
+		ingress, err = newProgram(ingressProgramName, ingressInstructions(m, 0x10))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(ingress.Close)
+		egress, err = newProgram(egressProgramName, egressInstructions(m))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(egress.Close)
+
+		Expect(m.dnat.Update(&from, &to, ebpf.UpdateAny)).To(Succeed())
+	})
+
+	It("should translate back the replies of the translated connections only", func() {
+		_, dst := run(ingress, udpPacket(client, from, 1111, 53))
+		Expect(dst).To(Equal(to))
+
+		src, _ := run(egress, udpPacket(to, client, 53, 1111))
+		Expect(src).To(Equal(from))
+
+		// A packet from the same address, but not belonging to the translated connection, is left untouched.
+		src, _ = run(egress, udpPacket(to, client, 54, 1111))
+		Expect(src).To(Equal(to))
+	})
+
+	It("should stop translating the replies once the translation is removed", func() {
+		run(ingress, udpPacket(client, from, 1111, 53))
+		Expect(pruneConnections(m.ct, map[addr]addr{})).To(Succeed())
+
+		src, _ := run(egress, udpPacket(to, client, 53, 1111))
+		Expect(src).To(Equal(to))
+	})
+
+	Context("steering the packets towards the gateways", func() {
+		pod, remote := addr{10, 244, 1, 3}, addr{10, 70, 0, 9}
+
+		// sync enforces the given masquerade bypasses, together with the steering of the remote prefix.
+		sync := func(bypass ...Bypass) {
+			entries := NewEntries()
+			entries.DNAT[netip.AddrFrom4(from)] = netip.AddrFrom4(to)
+			entries.Steering[netip.MustParsePrefix("10.70.0.0/16")] = Nexthop{Dev: "gateway", Gateway: netip.MustParseAddr("10.80.0.1")}
+			entries.Bypass = bypass
+			Expect(m.sync(entries.toDesiredMaps(func(string) (int, error) { return 1, nil }))).To(Succeed())
+		}
+
+		// verdict executes the ingress program on a packet from the pod to the remote address, and returns its verdict.
+		verdict := func() int32 {
+			ret, err := ingress.Run(&ebpf.RunOptions{Data: udpPacket(pod, remote, 1111, 80)})
+			Expect(err).ToNot(HaveOccurred())
+			return int32(ret)
+		}
+
+		It("should redirect the packets bypassing the masquerade", func() {
+			sync(Bypass{Src: netip.MustParsePrefix("10.244.0.0/16"), Dst: netip.MustParsePrefix("10.70.0.0/16")})
+			Expect(verdict()).To(BeEquivalentTo(netlink.TC_ACT_REDIRECT))
+		})
+
+		It("should not redirect the packets which do not bypass the masquerade", func() {
+			sync(Bypass{Src: netip.MustParsePrefix("10.245.0.0/16"), Dst: netip.MustParsePrefix("10.70.0.0/16")})
+			Expect(verdict()).To(BeEquivalentTo(tcActUnspec))
+		})
+
+		It("should not redirect any packet when the full masquerade is enabled", func() {
+			// With the full masquerade, no bypass is configured, and all packets must traverse netfilter to be masqueraded.
+			sync()
+			Expect(verdict()).To(BeEquivalentTo(tcActUnspec))
+		})
+	})
+})
@@ -15,6 +15,7 @@
 package fabric
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -58,6 +59,15 @@ const (
 	FlagNameGenevePort FlagName = "geneve-port"
 	// FlagNameGeneveCleanupInterval is the flag to set the Geneve cleanup interval.
 	FlagNameGeneveCleanupInterval FlagName = "geneve-cleanup-interval"
+
+	// FlagNameDatapath is the flag to select the datapath implementation.
+	FlagNameDatapath FlagName = "datapath"
+	// FlagNameEBPFInterfaces is the flag to set the interfaces where the eBPF programs are attached.
+	FlagNameEBPFInterfaces FlagName = "ebpf-interfaces"
+	// FlagNameEBPFBypassMark is the flag to set the firewall mark used by the eBPF datapath to bypass the masquerade.
+	FlagNameEBPFBypassMark FlagName = "ebpf-bypass-mark"
+	// FlagNameEBPFResyncInterval is the flag to set the interval used to resync the eBPF maps and attachments.
+	FlagNameEBPFResyncInterval FlagName = "ebpf-resync-interval"
 )
 
 // RequiredFlags contains the list of the mandatory flags.
@@ -85,6 +95,15 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.Uint16Var(&opts.GenevePort, FlagNameGenevePort.String(), consts.DefaultGenevePort, "Geneve port")
 	flagset.DurationVar(&opts.GeneveCleanupInterval, FlagNameGeneveCleanupInterval.String(),
 		consts.DefaultGeneveCleanupInterval, "Geneve cleanup interval")
+
+	flagset.Var(&opts.Datapath, FlagNameDatapath.String(),
+		fmt.Sprintf("Datapath used to enforce the fabric configuration (%s or %s)", DatapathNftables, DatapathEBPF))
+	flagset.StringSliceVar(&opts.EBPFInterfaces, FlagNameEBPFInterfaces.String(), consts.DefaultEBPFInterfaces,
+		"Glob patterns matching the host interfaces where the pod traffic enters the node, used by the eBPF datapath")
+	flagset.Uint32Var(&opts.EBPFBypassMark, FlagNameEBPFBypassMark.String(), consts.DefaultEBPFBypassMark,
+		"Firewall mark set by the eBPF datapath on the packets that must not be masqueraded")
+	flagset.DurationVar(&opts.EBPFResyncInterval, FlagNameEBPFResyncInterval.String(), consts.DefaultEBPFResyncInterval,
+		"Interval used to resync the eBPF maps and to attach the eBPF programs to new interfaces")
 }
 
 // MarkFlagsRequired marks the flags as required.
@@ -15,6 +15,7 @@
 package fabric
 
 import (
+	"fmt"
 	"time"
 
 	kernelversion "github.com/liqotech/liqo/pkg/utils/kernel/version"
@@ -39,11 +40,49 @@ type Options struct {
 	DisableARP            bool
 	GenevePort            uint16
 	GeneveCleanupInterval time.Duration
+
+	Datapath           Datapath
+	EBPFInterfaces     []string
+	EBPFBypassMark     uint32
+	EBPFResyncInterval time.Duration
 }
 
 // NewOptions returns a new Options struct.
 func NewOptions() *Options {
 	return &Options{
 		MinimumKernelVersion: kernelversion.MinimumKernelVersion,
+		Datapath:             DatapathNftables,
 	}
 }
+
+// Datapath is the implementation used to enforce the fabric configuration.
+type Datapath string
+
+const (
+	// DatapathNftables enforces the fabric configuration through nftables and netlink policy routing.
+	DatapathNftables Datapath = "nftables"
+	// DatapathEBPF enforces the fabric configuration, where possible, through eBPF programs attached to TC hooks.
+	// The configurations that cannot be expressed through the eBPF maps are still enforced through nftables.
+	DatapathEBPF Datapath = "ebpf"
+)
+
+// String returns the string representation of the datapath.
+func (d Datapath) String() string {
+	return string(d)
+}
+
+// Set sets the value of the datapath.
+func (d *Datapath) Set(value string) error {
+	switch Datapath(value) {
+	case DatapathNftables, DatapathEBPF:
+		*d = Datapath(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid datapath %q, must be one of %q or %q", value, DatapathNftables, DatapathEBPF)
+	}
+}
+
+// Type returns the type of the datapath.
+func (d *Datapath) Type() string {
+	return "string"
+}
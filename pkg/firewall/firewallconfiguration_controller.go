@@ -57,6 +57,8 @@ type FirewallConfigurationReconciler struct {
 	EnableFinalizer bool
 	// ConntrackClient is the client used to flush conntrack entries when notrack rules are applied.
 	ConntrackClient conntrackClient
+	// Offloader, if set, is the alternative datapath enforcing part of the NAT rules in place of nftables.
+	Offloader NatRuleOffloader
 }
 
 // newFirewallConfigurationReconciler returns a new FirewallConfigurationReconciler.
@@ -144,9 +146,12 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, nil
 	}
 
+	// The rules handled by the offloader (if any) are not enforced through nftables.
+	enforced := enforcedTable(&fwcfg.Spec.Table, r.Offloader)
+
 	// If table exists, it delete chains and rules which are not contained anymore in firewallconfiguration resource.
 	// It also deletes chains and rules which has been updated and need to be recreated.
-	if err = cleanTable(r.NftConnection, &fwcfg.Spec.Table); err != nil {
+	if err = cleanTable(r.NftConnection, enforced); err != nil {
 		return ctrl.Result{}, fmt.Errorf("cleaning table %s: %w", ptr.Deref(fwcfg.Spec.Table.Name, ""), err)
 	}
 
@@ -158,9 +163,9 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 	klog.V(4).Infof("Applying firewallconfiguration %s", req.String())
 
 	// Enforce table existence.
-	table := addTable(r.NftConnection, &fwcfg.Spec.Table)
+	table := addTable(r.NftConnection, enforced)
 
-	notrackApplied, err := addChains(r.NftConnection, fwcfg.Spec.Table.Chains, table)
+	notrackApplied, err := addChains(r.NftConnection, enforced.Chains, table)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("adding chains to table %s: %w", ptr.Deref(fwcfg.Spec.Table.Name, ""), err)
 	}
@@ -0,0 +1,51 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewall
+
+import (
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+// NatRuleOffloader is implemented by alternative datapaths which enforce a subset
+// of the NAT rules in place of nftables.
+type NatRuleOffloader interface {
+	// IsNatRuleOffloaded returns whether the given NAT rule, belonging to the given chain,
+	// is enforced by the alternative datapath.
+	IsNatRuleOffloaded(chain *firewallapi.Chain, rule *firewallapi.NatRule) bool
+}
+
+// enforcedTable returns the table to be enforced through nftables, stripping the rules
+// handled by the given offloader. The input table is never modified.
+func enforcedTable(table *firewallapi.Table, offloader NatRuleOffloader) *firewallapi.Table {
+	if offloader == nil {
+		return table
+	}
+
+	enforced := table.DeepCopy()
+	for i := range enforced.Chains {
+		rules := enforced.Chains[i].Rules.NatRules
+		if len(rules) == 0 {
+			continue
+		}
+		filtered := make([]firewallapi.NatRule, 0, len(rules))
+		for j := range rules {
+			if !offloader.IsNatRuleOffloaded(&enforced.Chains[i], &rules[j]) {
+				filtered = append(filtered, rules[j])
+			}
+		}
+		enforced.Chains[i].Rules.NatRules = filtered
+	}
+	return enforced
+}
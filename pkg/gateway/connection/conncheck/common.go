@@ -24,6 +24,10 @@ type Msg struct {
 	ClusterID string    `json:"clusterID"`
 	MsgType   MsgTypes  `json:"msgType"`
 	TimeStamp time.Time `json:"timeStamp"`
+	// Size is the size of the IP packet carrying a PROBE message, echoed back in the PROBEACK.
+	Size int `json:"size,omitempty"`
+	// Padding is used to inflate a PROBE message up to the desired size.
+	Padding string `json:"padding,omitempty"`
 }
 
 func (msg Msg) String() string {
@@ -41,7 +45,12 @@ const (
 	PING MsgTypes = "PING"
 	// PONG is the type of a pong message.
 	PONG MsgTypes = "PONG"
+	// PROBE is the type of a path MTU probe message.
+	PROBE MsgTypes = "PROBE"
+	// PROBEACK is the type of a path MTU probe acknowledgement message.
+	PROBEACK MsgTypes = "PROBEACK"
 )
 
 // UpdateFunc is a function called when a Receiver gets a PONG or when a connection is declared failed.
-type UpdateFunc func(connected bool, latency time.Duration, time time.Time) error
+// The health is nil if not available.
+type UpdateFunc func(connected bool, latency time.Duration, time time.Time, health *Health) error
@@ -47,6 +47,11 @@ func NewConnChecker(opts *Options) (*ConnChecker, error) {
 		return nil, fmt.Errorf("failed to listen on UDP socket %s : %w", addr, err)
 	}
 	klog.V(4).Infof("conncheck socket: listening on %s", addr)
+	if opts.PathMTUDiscoveryEnabled {
+		if err := setDontFragment(conn); err != nil {
+			return nil, fmt.Errorf("failed to set the Don't Fragment bit on UDP socket %s : %w", addr, err)
+		}
+	}
 	connChecker := ConnChecker{
 		opts:           opts,
 		receiver:       NewReceiver(conn, opts),
@@ -108,10 +113,12 @@ func (c *ConnChecker) RunSender(clusterID string) {
 	klog.Infof("conncheck sender %q starting against %q", clusterID, sender.raddr.IP.String())
 
 	if err := wait.PollUntilContextCancel(sender.Ctx, c.opts.PingInterval, false, func(_ context.Context) (done bool, err error) {
-		err = c.senders[clusterID].SendPing()
+		ts, err := c.senders[clusterID].SendPing()
 		if err != nil {
 			klog.Warningf("failed to send ping: %s", err)
+			return false, nil
 		}
+		c.receiver.RecordPing(clusterID, ts)
 		return false, nil
 	}); err != nil {
 		klog.Errorf("conncheck sender %s stopped for an error: %s", clusterID, err)
@@ -147,6 +154,16 @@ func (c *ConnChecker) GetLatency(clusterID string) (time.Duration, error) {
 	return 0, fmt.Errorf("sender %s not found", clusterID)
 }
 
+// GetHealth returns the health of the connection with clusterID.
+func (c *ConnChecker) GetHealth(clusterID string) (Health, error) {
+	c.receiver.m.RLock()
+	defer c.receiver.m.RUnlock()
+	if peer, ok := c.receiver.peers[clusterID]; ok {
+		return peer.window.health(time.Now(), c.opts.PingInterval), nil
+	}
+	return Health{}, fmt.Errorf("sender %s not found", clusterID)
+}
+
 // GetConnected returns the connection status with clusterID.
 func (c *ConnChecker) GetConnected(clusterID string) (bool, error) {
 	c.receiver.m.RLock()
@@ -0,0 +1,123 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"time"
+)
+
+// Health summarizes the quality of the connection with a peer, computed over the last pings.
+type Health struct {
+	// PacketLoss is the percentage of pings lost.
+	PacketLoss float64
+	// MinRTT is the minimum round-trip time.
+	MinRTT time.Duration
+	// AvgRTT is the average round-trip time.
+	AvgRTT time.Duration
+	// MaxRTT is the maximum round-trip time.
+	MaxRTT time.Duration
+	// Jitter is the average variation of the round-trip time between consecutive pings.
+	Jitter time.Duration
+	// Samples is the number of pings the health has been computed over.
+	Samples int
+}
+
+// pingSample is the outcome of a ping.
+type pingSample struct {
+	sent     time.Time
+	rtt      time.Duration
+	received bool
+}
+
+// healthWindow keeps track of the outcome of the last pings sent to a peer.
+type healthWindow struct {
+	samples []pingSample
+	size    int
+}
+
+// newHealthWindow returns a new healthWindow, tracking the given number of pings.
+func newHealthWindow(size int) *healthWindow {
+	return &healthWindow{
+		samples: make([]pingSample, 0, size),
+		size:    size,
+	}
+}
+
+// addSent records a ping sent at the given time.
+func (w *healthWindow) addSent(ts time.Time) {
+	if w.size == 0 {
+		return
+	}
+	if len(w.samples) == w.size {
+		w.samples = append(w.samples[:0], w.samples[1:]...)
+	}
+	w.samples = append(w.samples, pingSample{sent: ts})
+}
+
+// addReceived records the reply to the ping sent at the given time.
+func (w *healthWindow) addReceived(ts time.Time, rtt time.Duration) {
+	for i := len(w.samples) - 1; i >= 0; i-- {
+		if w.samples[i].sent.Equal(ts) {
+			w.samples[i].rtt = rtt
+			w.samples[i].received = true
+			return
+		}
+	}
+}
+
+// health computes the health over the tracked pings. The pings sent less than timeout ago and not replied yet are ignored.
+func (w *healthWindow) health(now time.Time, timeout time.Duration) Health {
+	var h Health
+	var lost int
+	var sum, variation time.Duration
+	var prev *pingSample
+	var received int
+
+	for i := range w.samples {
+		sample := &w.samples[i]
+		if !sample.received {
+			if now.Sub(sample.sent) >= timeout {
+				lost++
+				h.Samples++
+			}
+			continue
+		}
+
+		h.Samples++
+		received++
+		sum += sample.rtt
+		if received == 1 || sample.rtt < h.MinRTT {
+			h.MinRTT = sample.rtt
+		}
+		if sample.rtt > h.MaxRTT {
+			h.MaxRTT = sample.rtt
+		}
+		if prev != nil {
+			variation += (sample.rtt - prev.rtt).Abs()
+		}
+		prev = sample
+	}
+
+	if h.Samples > 0 {
+		h.PacketLoss = float64(lost) * 100 / float64(h.Samples)
+	}
+	if received > 0 {
+		h.AvgRTT = sum / time.Duration(received)
+	}
+	if received > 1 {
+		h.Jitter = variation / time.Duration(received-1)
+	}
+	return h
+}
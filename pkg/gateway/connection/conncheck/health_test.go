@@ -0,0 +1,78 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		window *healthWindow
+		start  time.Time
+	)
+
+	BeforeEach(func() {
+		window = newHealthWindow(4)
+		start = time.Now()
+	})
+
+	It("should report no samples if no ping has been sent", func() {
+		Expect(window.health(start, time.Second)).To(Equal(Health{}))
+	})
+
+	It("should compute the round-trip time statistics and the packet loss", func() {
+		rtts := []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 0, 20 * time.Millisecond}
+		for i, rtt := range rtts {
+			ts := start.Add(time.Duration(i) * time.Second)
+			window.addSent(ts)
+			if rtt > 0 {
+				window.addReceived(ts, rtt)
+			}
+		}
+
+		health := window.health(start.Add(10*time.Second), time.Second)
+		Expect(health.Samples).To(Equal(4))
+		Expect(health.PacketLoss).To(BeNumerically("==", 25))
+		Expect(health.MinRTT).To(Equal(10 * time.Millisecond))
+		Expect(health.AvgRTT).To(Equal(20 * time.Millisecond))
+		Expect(health.MaxRTT).To(Equal(30 * time.Millisecond))
+		Expect(health.Jitter).To(Equal(15 * time.Millisecond))
+	})
+
+	It("should ignore the pings still in flight", func() {
+		window.addSent(start)
+		window.addReceived(start, time.Millisecond)
+		window.addSent(start.Add(time.Second))
+
+		health := window.health(start.Add(1500*time.Millisecond), time.Second)
+		Expect(health.Samples).To(Equal(1))
+		Expect(health.PacketLoss).To(BeZero())
+	})
+
+	It("should only track the last pings", func() {
+		for i := range 6 {
+			window.addSent(start.Add(time.Duration(i) * time.Second))
+		}
+		window.addReceived(start, time.Millisecond)
+
+		health := window.health(start.Add(10*time.Second), time.Second)
+		Expect(health.Samples).To(Equal(4))
+		Expect(health.PacketLoss).To(BeNumerically("==", 100))
+	})
+})
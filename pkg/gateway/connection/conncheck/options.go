@@ -26,6 +26,14 @@ type Options struct {
 	PingLossThreshold uint
 	// PingInterval is the interval at which the ping is sent.
 	PingInterval time.Duration
+	// PingHealthWindow is the number of pings over which the health of the connection is computed.
+	PingHealthWindow uint
+	// PathMTUDiscoveryEnabled enables the path MTU discovery.
+	PathMTUDiscoveryEnabled bool
+	// PathMTUMax is the upper bound of the path MTU discovery.
+	PathMTUMax int
+	// PathMTUProbeTimeout is the time waited for the acknowledgement of a path MTU probe.
+	PathMTUProbeTimeout time.Duration
 }
 
 // NewOptions returns a new Options struct.
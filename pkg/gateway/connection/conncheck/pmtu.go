@@ -0,0 +1,171 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PathMTUMin is the lower bound of the path MTU discovery.
+	PathMTUMin = 576
+	// probeHeadersSize is the size of the IPv4 and UDP headers preceding the probe payload.
+	probeHeadersSize = 28
+	// probeAttempts is the number of probes sent for each size before declaring it unsupported.
+	probeAttempts = 3
+	// probeAcksBufferSize is the size of the buffer holding the acknowledged probes.
+	probeAcksBufferSize = 8
+)
+
+// ForgeProbeMsg forges a PROBE message whose encoding fills an IP packet of the given size.
+func ForgeProbeMsg(clusterID string, size int) ([]byte, error) {
+	msg := Msg{ClusterID: clusterID, MsgType: PROBE, TimeStamp: time.Now(), Size: size, Padding: "x"}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal msg: %w", err)
+	}
+	padding := size - probeHeadersSize - len(b) + 1
+	if padding < 1 {
+		return nil, fmt.Errorf("probe size %d too small", size)
+	}
+	msg.Padding = strings.Repeat("x", padding)
+	return json.Marshal(msg)
+}
+
+// SendProbe sends a PROBE message of the given size, with the Don't Fragment bit set.
+// It returns false if the probe exceeds the MTU of the local interface.
+func (s *Sender) SendProbe(size int) (bool, error) {
+	b, err := ForgeProbeMsg(s.clusterID, size)
+	if err != nil {
+		return false, fmt.Errorf("conncheck sender: %w", err)
+	}
+	if _, err = s.conn.WriteToUDP(b, &s.raddr); err != nil {
+		if errors.Is(err, syscall.EMSGSIZE) {
+			return false, nil
+		}
+		return false, fmt.Errorf("conncheck sender: failed to write to %s: %w", s.raddr.String(), err)
+	}
+	klog.V(8).Infof("conncheck sender: sent a PROBE of %d bytes to %s", size, s.raddr.String())
+	return true, nil
+}
+
+// DiscoverPathMTU discovers the path MTU towards the given cluster, between PathMTUMin and the lowest
+// between the given and the configured upper bounds. The probes are sent with the Don't Fragment bit set,
+// and cannot exceed the MTU of the tunnel interfaces, which is never changed to carry them.
+func (c *ConnChecker) DiscoverPathMTU(clusterID string, high int) (int, error) {
+	c.sm.RLock()
+	sender, ok := c.senders[clusterID]
+	c.sm.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("sender %s not found", clusterID)
+	}
+
+	c.receiver.m.RLock()
+	peer, ok := c.receiver.peers[clusterID]
+	c.receiver.m.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("peer %s not found", clusterID)
+	}
+
+	probe := func(size int) bool {
+		for range probeAttempts {
+			// Discard the acknowledgements of the previous probes.
+			for len(peer.probeAcks) > 0 {
+				<-peer.probeAcks
+			}
+			sent, err := sender.SendProbe(size)
+			if err != nil {
+				klog.Warningf("failed to send path MTU probe: %s", err)
+				continue
+			}
+			if !sent {
+				return false
+			}
+			if waitProbeAck(sender, peer, size, c.opts.PathMTUProbeTimeout) {
+				return true
+			}
+		}
+		return false
+	}
+
+	mtu, ok := SearchPathMTU(PathMTUMin, min(high, c.opts.PathMTUMax), probe)
+	if !ok {
+		return 0, fmt.Errorf("no path MTU probe acknowledged by %s", clusterID)
+	}
+	return mtu, nil
+}
+
+// waitProbeAck waits for the acknowledgement of a probe of the given size.
+func waitProbeAck(sender *Sender, peer *Peer, size int, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case acked := <-peer.probeAcks:
+			if acked == size {
+				return true
+			}
+		case <-timer.C:
+			return false
+		case <-sender.Ctx.Done():
+			return false
+		}
+	}
+}
+
+// SearchPathMTU returns the largest size in [low, high] accepted by the probe function, performing a binary search.
+// It returns false if not even the lower bound is accepted.
+func SearchPathMTU(low, high int, probe func(size int) bool) (int, bool) {
+	if high < low || !probe(low) {
+		return 0, false
+	}
+	if probe(high) {
+		return high, true
+	}
+	// Invariant: low is accepted, high is not.
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		if probe(mid) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low, true
+}
+
+// setDontFragment configures the socket to set the Don't Fragment bit, regardless of the cached path MTU.
+func setDontFragment(conn *net.UDPConn) error {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	if err := rc.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_PROBE)
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
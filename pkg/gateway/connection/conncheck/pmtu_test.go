@@ -0,0 +1,76 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Path MTU discovery", func() {
+	Context("searching the path MTU", func() {
+		var probed []int
+
+		forgeProbe := func(pathMTU int) func(size int) bool {
+			return func(size int) bool {
+				probed = append(probed, size)
+				return size <= pathMTU
+			}
+		}
+
+		BeforeEach(func() { probed = nil })
+
+		It("should return the upper bound if accepted", func() {
+			mtu, ok := SearchPathMTU(PathMTUMin, 1340, forgeProbe(1500))
+			Expect(ok).To(BeTrue())
+			Expect(mtu).To(Equal(1340))
+			Expect(probed).To(Equal([]int{PathMTUMin, 1340}))
+		})
+
+		It("should find the largest accepted size", func() {
+			mtu, ok := SearchPathMTU(PathMTUMin, 1340, forgeProbe(1234))
+			Expect(ok).To(BeTrue())
+			Expect(mtu).To(Equal(1234))
+			Expect(len(probed)).To(BeNumerically("<=", 12))
+		})
+
+		It("should fail if the lower bound is not accepted", func() {
+			_, ok := SearchPathMTU(PathMTUMin, 1340, forgeProbe(0))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("forging the probes", func() {
+		It("should fill an IP packet of the given size", func() {
+			for _, size := range []int{PathMTUMin, 1234, 1500} {
+				b, err := ForgeProbeMsg("cluster", size)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(b) + probeHeadersSize).To(Equal(size))
+
+				msg := &Msg{}
+				Expect(json.Unmarshal(b, msg)).To(Succeed())
+				Expect(msg.MsgType).To(Equal(PROBE))
+				Expect(msg.Size).To(Equal(size))
+			}
+		})
+
+		It("should fail if the size is too small", func() {
+			_, err := ForgeProbeMsg("cluster", probeHeadersSize)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -33,6 +33,10 @@ type Peer struct {
 	// lastReceivedTimestamp is the timestamp when the last received PING has been sent.
 	lastReceivedTimestamp time.Time
 	updateCallback        UpdateFunc
+	// window tracks the outcome of the last pings, to compute the health of the connection.
+	window *healthWindow
+	// probeAcks receives the sizes of the acknowledged path MTU probes.
+	probeAcks chan int
 }
 
 // Receiver is a receiver for conncheck messages.
@@ -46,9 +50,14 @@ type Receiver struct {
 
 // NewReceiver creates a new conncheck receiver.
 func NewReceiver(conn *net.UDPConn, opts *Options) *Receiver {
+	buffSize := opts.PingBufferSize
+	if opts.PathMTUDiscoveryEnabled && uint(opts.PathMTUMax) > buffSize {
+		// The buffer must be large enough to receive the largest path MTU probe.
+		buffSize = uint(opts.PathMTUMax)
+	}
 	return &Receiver{
 		peers: make(map[string]*Peer),
-		buff:  make([]byte, opts.PingBufferSize),
+		buff:  make([]byte, buffSize),
 		conn:  conn,
 		opts:  opts,
 	}
@@ -74,16 +83,18 @@ func (r *Receiver) ReceivePong(msg *Msg) error {
 	r.m.Lock()
 	defer r.m.Unlock()
 	if peer, ok := r.peers[msg.ClusterID]; ok {
+		now := time.Now()
+		peer.window.addReceived(msg.TimeStamp, now.Sub(msg.TimeStamp))
 		if msg.TimeStamp.Before(peer.lastReceivedTimestamp) {
 			klog.V(8).Infof("dropped a PONG message from %s because out-of-order", msg.ClusterID)
 			return nil
 		}
-		now := time.Now()
 		peer.lastReceivedTimestamp = msg.TimeStamp
 		peer.latency = now.Sub(msg.TimeStamp)
 		peer.connected = true
 
-		err := peer.updateCallback(true, peer.latency, now)
+		health := peer.window.health(now, r.opts.PingInterval)
+		err := peer.updateCallback(true, peer.latency, now, &health)
 		if err != nil {
 			return fmt.Errorf("failed to update peer %s: %w", msg.ClusterID, err)
 		}
@@ -92,6 +103,47 @@ func (r *Receiver) ReceivePong(msg *Msg) error {
 	return fmt.Errorf("%s sender has not been initialized", msg.ClusterID)
 }
 
+// SendProbeAck sends a PROBEACK message to the given address.
+func (r *Receiver) SendProbeAck(raddr *net.UDPAddr, msg *Msg) error {
+	msg.MsgType = PROBEACK
+	msg.Padding = ""
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal msg: %w", err)
+	}
+	_, err = r.conn.WriteToUDP(b, raddr)
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", raddr.String(), err)
+	}
+	klog.V(8).Infof("conncheck receiver: sent a PROBEACK -> %s", msg)
+	return nil
+}
+
+// ReceiveProbeAck receives a PROBEACK message.
+func (r *Receiver) ReceiveProbeAck(msg *Msg) error {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	peer, ok := r.peers[msg.ClusterID]
+	if !ok {
+		return fmt.Errorf("%s sender has not been initialized", msg.ClusterID)
+	}
+	select {
+	case peer.probeAcks <- msg.Size:
+	default:
+		klog.V(8).Infof("dropped a PROBEACK message from %s because no probe is pending", msg.ClusterID)
+	}
+	return nil
+}
+
+// RecordPing records a PING message sent to the given peer at the given time.
+func (r *Receiver) RecordPing(clusterID string, ts time.Time) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if peer, ok := r.peers[clusterID]; ok {
+		peer.window.addSent(ts)
+	}
+}
+
 // InitPeer initializes a peer.
 func (r *Receiver) InitPeer(clusterID string, updateCallback UpdateFunc) error {
 	r.m.Lock()
@@ -101,6 +153,8 @@ func (r *Receiver) InitPeer(clusterID string, updateCallback UpdateFunc) error {
 		latency:               0,
 		lastReceivedTimestamp: time.Now(),
 		updateCallback:        updateCallback,
+		window:                newHealthWindow(int(r.opts.PingHealthWindow)),
+		probeAcks:             make(chan int, probeAcksBufferSize),
 	}
 	return nil
 }
@@ -128,6 +182,12 @@ func (r *Receiver) Run(ctx context.Context) {
 		case PONG:
 			klog.V(8).Infof("conncheck receiver: received a PONG from %s  -> %s", raddr, msgr)
 			err = r.ReceivePong(msgr)
+		case PROBE:
+			klog.V(8).Infof("conncheck receiver: received a PROBE from %s -> %s", raddr, msgr)
+			err = r.SendProbeAck(raddr, msgr)
+		case PROBEACK:
+			klog.V(8).Infof("conncheck receiver: received a PROBEACK from %s -> %s", raddr, msgr)
+			err = r.ReceiveProbeAck(msgr)
 		}
 		if err != nil {
 			klog.Errorf("conncheck receiver: %v", err)
@@ -154,7 +214,8 @@ func (r *Receiver) RunDisconnectObserver(ctx context.Context) {
 				klog.V(8).Infof("conncheck receiver: %s unreachable", id)
 				peer.connected = false
 				peer.latency = 0
-				err := peer.updateCallback(false, 0, time.Time{})
+				health := peer.window.health(time.Now(), r.opts.PingInterval)
+				err := peer.updateCallback(false, 0, time.Time{}, &health)
 				if err != nil {
 					klog.Errorf("conncheck receiver: failed to update peer %s: %s", peer.lastReceivedTimestamp, err)
 				}
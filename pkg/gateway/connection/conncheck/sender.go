@@ -48,17 +48,17 @@ func NewSender(ctx context.Context, opts *Options, clusterID string, cancel func
 	}, nil
 }
 
-// SendPing sends a PING message to the given address.
-func (s *Sender) SendPing() error {
+// SendPing sends a PING message to the given address, and returns its timestamp.
+func (s *Sender) SendPing() (time.Time, error) {
 	msgOut := Msg{ClusterID: s.clusterID, MsgType: PING, TimeStamp: time.Now()}
 	b, err := json.Marshal(msgOut)
 	if err != nil {
-		return fmt.Errorf("conncheck sender: failed to marshal msg: %w", err)
+		return time.Time{}, fmt.Errorf("conncheck sender: failed to marshal msg: %w", err)
 	}
 	_, err = s.conn.WriteToUDP(b, &s.raddr)
 	if err != nil {
-		return fmt.Errorf("conncheck sender: failed to write to %s: %w", s.raddr.String(), err)
+		return time.Time{}, fmt.Errorf("conncheck sender: failed to write to %s: %w", s.raddr.String(), err)
 	}
 	klog.V(8).Infof("conncheck sender: sent a PING -> %s", msgOut)
-	return nil
+	return msgOut.TimeStamp, nil
 }
@@ -98,8 +98,12 @@ func (r *ConnectionsReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		go r.ConnChecker.RunSender(r.Options.GwOptions.RemoteClusterID)
+
+		if r.Options.ConnCheckOptions.PathMTUDiscoveryEnabled {
+			go r.RunPathMTUDiscovery(ctx, req)
+		}
 	case false:
-		if err := updateConnection(true, 0, time.Time{}); err != nil {
+		if err := updateConnection(true, 0, time.Time{}, nil); err != nil {
 			return ctrl.Result{}, fmt.Errorf("unable to update the connection status: %w", err)
 		}
 	}
@@ -124,7 +128,7 @@ func (r *ConnectionsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // ForgeUpdateConnectionCallback forges the UpdateConnectionStatus function.
 func ForgeUpdateConnectionCallback(ctx context.Context, cl client.Client, opts *Options, req ctrl.Request) conncheck.UpdateFunc {
-	return func(connected bool, latency time.Duration, timestamp time.Time) error {
+	return func(connected bool, latency time.Duration, timestamp time.Time, health *conncheck.Health) error {
 		connection := &networkingv1beta1.Connection{}
 		if err := cl.Get(ctx, req.NamespacedName, connection); err != nil {
 			return err
@@ -136,6 +140,6 @@ func ForgeUpdateConnectionCallback(ctx context.Context, cl client.Client, opts *
 		case false:
 			connStatusValue = networkingv1beta1.ConnectionError
 		}
-		return UpdateConnectionStatus(ctx, cl, opts, connection, connStatusValue, latency, timestamp)
+		return UpdateConnectionStatus(ctx, cl, opts, connection, connStatusValue, latency, timestamp, health)
 	}
 }
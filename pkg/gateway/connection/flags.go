@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
 )
 
 // FlagName is the type for the name of the flags.
@@ -40,8 +42,20 @@ const (
 	PingLossThresholdFlag FlagName = "ping-loss-threshold"
 	// PingIntervalFlag is the name of the flag used to set the ping interval.
 	PingIntervalFlag FlagName = "ping-interval"
+	// PingHealthWindowFlag is the name of the flag used to set the number of pings over which the health is computed.
+	PingHealthWindowFlag FlagName = "ping-health-window"
 	// PingUpdateStatusIntervalFlag is the name of the flag used to set the ping update status interval.
 	PingUpdateStatusIntervalFlag FlagName = "ping-update-status-interval"
+	// PathMTUDiscoveryEnabledFlag is the name of the flag used to enable the path MTU discovery.
+	PathMTUDiscoveryEnabledFlag FlagName = "pmtu-discovery-enabled"
+	// PathMTUDiscoveryIntervalFlag is the name of the flag used to set the path MTU discovery interval.
+	PathMTUDiscoveryIntervalFlag FlagName = "pmtu-discovery-interval"
+	// PathMTUMaxFlag is the name of the flag used to set the upper bound of the path MTU discovery.
+	PathMTUMaxFlag FlagName = "pmtu-max"
+	// PathMTUProbeTimeoutFlag is the name of the flag used to set the path MTU probe timeout.
+	PathMTUProbeTimeoutFlag FlagName = "pmtu-probe-timeout"
+	// PathMTUUpdateMTUFlag is the name of the flag used to apply the discovered path MTU to the tunnel interfaces.
+	PathMTUUpdateMTUFlag FlagName = "pmtu-update-mtu"
 )
 
 // InitFlags initializes the flags for the wireguard tunnel.
@@ -58,6 +72,18 @@ func InitFlags(flagset *pflag.FlagSet, options *Options) {
 		"ping-loss-threshold is the number of lost packets after which the connection check is considered as failed.")
 	flagset.DurationVar(&options.ConnCheckOptions.PingInterval, PingIntervalFlag.String(), 2*time.Second,
 		"ping-interval is the interval between two connection checks")
+	flagset.UintVar(&options.ConnCheckOptions.PingHealthWindow, PingHealthWindowFlag.String(), 30,
+		"ping-health-window is the number of pings over which the health of the connection (packet loss, round-trip time and jitter) is computed")
 	flagset.DurationVar(&options.PingUpdateStatusInterval, PingUpdateStatusIntervalFlag.String(), 10*time.Second,
 		"ping-update-status-interval is the interval at which the status is updated")
+	flagset.BoolVar(&options.ConnCheckOptions.PathMTUDiscoveryEnabled, PathMTUDiscoveryEnabledFlag.String(), false,
+		"pmtu-discovery-enabled enables the discovery of the path MTU through probes with the Don't Fragment bit set. It requires the ping check.")
+	flagset.DurationVar(&options.PathMTUDiscoveryInterval, PathMTUDiscoveryIntervalFlag.String(), 5*time.Minute,
+		"pmtu-discovery-interval is the interval between two path MTU discoveries")
+	flagset.IntVar(&options.ConnCheckOptions.PathMTUMax, PathMTUMaxFlag.String(), forge.DefaultMTU,
+		"pmtu-max is the upper bound of the path MTU discovery, usually the MTU configured for the tunnel")
+	flagset.DurationVar(&options.ConnCheckOptions.PathMTUProbeTimeout, PathMTUProbeTimeoutFlag.String(), time.Second,
+		"pmtu-probe-timeout is the time waited for the acknowledgement of a path MTU probe")
+	flagset.BoolVar(&options.PathMTUUpdateMTU, PathMTUUpdateMTUFlag.String(), false,
+		"pmtu-update-mtu applies the discovered path MTU to the tunnel interfaces and to the internal fabric")
 }
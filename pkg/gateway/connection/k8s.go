@@ -17,29 +17,35 @@ package connection
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway/connection/conncheck"
 	timeutils "github.com/liqotech/liqo/pkg/utils/time"
 )
 
 // UpdateConnectionStatus updates the status of a connection.
 func UpdateConnectionStatus(ctx context.Context, cl client.Client, opts *Options, connection *networkingv1beta1.Connection,
-	value networkingv1beta1.ConnectionStatusValue, latency time.Duration, timestamp time.Time) error {
+	value networkingv1beta1.ConnectionStatusValue, latency time.Duration, timestamp time.Time, health *conncheck.Health) error {
 	if connection.Status.Value != value ||
 		timestamp.Sub(connection.Status.Latency.Timestamp.Time) > opts.PingUpdateStatusInterval {
 		if connection.Status.Value != value {
 			klog.Infof("changing connection %q status to %q",
 				client.ObjectKeyFromObject(connection).String(), value)
+			connection.Status.LastTransitionTime = ptr.To(metav1.Now())
 		}
 		connection.Status.Latency = networkingv1beta1.ConnectionLatency{
 			Value:     timeutils.FormatLatency(latency),
 			Timestamp: metav1.NewTime(timestamp),
 		}
+		connection.Status.Health = ForgeConnectionHealth(health)
 		connection.Status.Value = value
 		if err := cl.Status().Update(ctx, connection); err != nil {
 			return fmt.Errorf("unable to update connection %q: %w",
@@ -48,3 +54,39 @@ func UpdateConnectionStatus(ctx context.Context, cl client.Client, opts *Options
 	}
 	return nil
 }
+
+// ForgeConnectionHealth forges the health of a connection from the one computed by the connection checker.
+func ForgeConnectionHealth(health *conncheck.Health) *networkingv1beta1.ConnectionHealth {
+	if health == nil || health.Samples == 0 {
+		return nil
+	}
+	return &networkingv1beta1.ConnectionHealth{
+		PacketLoss: int32(math.Round(health.PacketLoss)),
+		MinRTT:     metav1.Duration{Duration: health.MinRTT.Round(time.Microsecond)},
+		AvgRTT:     metav1.Duration{Duration: health.AvgRTT.Round(time.Microsecond)},
+		MaxRTT:     metav1.Duration{Duration: health.MaxRTT.Round(time.Microsecond)},
+		Jitter:     metav1.Duration{Duration: health.Jitter.Round(time.Microsecond)},
+		Samples:    int32(health.Samples), //nolint:gosec // the number of samples is bounded by the window size
+	}
+}
+
+// UpdateConnectionPathMTU updates the path MTU of a connection.
+func UpdateConnectionPathMTU(ctx context.Context, cl client.Client, key types.NamespacedName, mtu int, applied bool) error {
+	connection := &networkingv1beta1.Connection{}
+	if err := cl.Get(ctx, key, connection); err != nil {
+		return fmt.Errorf("unable to get connection %q: %w", key.String(), err)
+	}
+	if connection.Status.PathMTU != nil && connection.Status.PathMTU.Value == mtu && connection.Status.PathMTU.Applied == applied {
+		return nil
+	}
+	klog.Infof("changing connection %q path MTU to %d", key.String(), mtu)
+	connection.Status.PathMTU = &networkingv1beta1.ConnectionPathMTU{
+		Value:     mtu,
+		Applied:   applied,
+		Timestamp: metav1.Now(),
+	}
+	if err := cl.Status().Update(ctx, connection); err != nil {
+		return fmt.Errorf("unable to update connection %q: %w", key.String(), err)
+	}
+	return nil
+}
@@ -33,6 +33,10 @@ type Options struct {
 	PingEnabled bool
 	// PingUpdateStatusInterval is the interval at which the status is updated.
 	PingUpdateStatusInterval time.Duration
+	// PathMTUDiscoveryInterval is the interval between two path MTU discoveries.
+	PathMTUDiscoveryInterval time.Duration
+	// PathMTUUpdateMTU applies the discovered path MTU to the tunnel interfaces.
+	PathMTUUpdateMTU bool
 }
 
 // NewOptions returns a new Options struct.
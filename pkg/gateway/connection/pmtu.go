@@ -0,0 +1,71 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+// RunPathMTUDiscovery periodically discovers the path MTU towards the remote cluster and publishes it in the connection status.
+// If enabled, the discovered value is also applied to the tunnel interfaces, if lower than their current MTU.
+// The MTU is never raised, as a larger value cannot be confirmed without exceeding the current one, which
+// would black-hole the traffic if not supported by the path. It is restored when the tunnel is recreated.
+func (r *ConnectionsReconciler) RunPathMTUDiscovery(ctx context.Context, req ctrl.Request) {
+	remoteClusterID := r.Options.GwOptions.RemoteClusterID
+	klog.Infof("path MTU discovery towards %q started", remoteClusterID)
+
+	// Wait for the tunnel to be up before probing it.
+	// Ignore errors because only caused by context cancellation.
+	_ = wait.PollUntilContextCancel(ctx, r.Options.ConnCheckOptions.PingInterval, true, func(context.Context) (done bool, err error) {
+		connected, err := r.ConnChecker.GetConnected(remoteClusterID)
+		return connected && err == nil, nil
+	})
+
+	_ = wait.PollUntilContextCancel(ctx, r.Options.PathMTUDiscoveryInterval, true, func(ctx context.Context) (done bool, err error) {
+		current, err := tunnel.GetTunnelsMTU()
+		if err != nil || current == 0 {
+			klog.Warningf("unable to retrieve the MTU of the tunnel interfaces: %v", err)
+			return false, nil
+		}
+
+		mtu, err := r.ConnChecker.DiscoverPathMTU(remoteClusterID, current)
+		if err != nil {
+			klog.Warningf("unable to discover the path MTU towards %q: %s", remoteClusterID, err)
+			return false, nil
+		}
+		klog.V(4).Infof("discovered path MTU towards %q: %d", remoteClusterID, mtu)
+
+		if r.Options.PathMTUUpdateMTU && mtu < current {
+			if err := tunnel.SetTunnelsMTU(mtu); err != nil {
+				klog.Errorf("unable to apply the path MTU to the tunnel interfaces: %s", err)
+				return false, nil
+			}
+			klog.Infof("lowered the MTU of the tunnel interfaces towards %q from %d to %d", remoteClusterID, current, mtu)
+		}
+
+		if err := UpdateConnectionPathMTU(ctx, r.Client, req.NamespacedName, mtu, r.Options.PathMTUUpdateMTU); err != nil {
+			klog.Errorf("unable to update the connection path MTU: %s", err)
+		}
+		return false, nil
+	})
+
+	klog.Infof("path MTU discovery towards %q stopped", remoteClusterID)
+}
@@ -34,6 +34,16 @@ var (
 	MetricsPeerLatencyHistogram *prometheus.HistogramVec
 	// MetricsPeerIsConnected is the metric that outputs the connection status.
 	MetricsPeerIsConnected *prometheus.Desc
+	// MetricsPeerPacketLoss is the metric that exposes the packet loss towards a given peer.
+	MetricsPeerPacketLoss *prometheus.Desc
+	// MetricsPeerJitter is the metric that exposes the jitter towards a given peer.
+	MetricsPeerJitter *prometheus.Desc
+	// MetricsPeerMinLatency is the metric that exposes the minimum latency towards a given peer.
+	MetricsPeerMinLatency *prometheus.Desc
+	// MetricsPeerAvgLatency is the metric that exposes the average latency towards a given peer.
+	MetricsPeerAvgLatency *prometheus.Desc
+	// MetricsPeerMaxLatency is the metric that exposes the maximum latency towards a given peer.
+	MetricsPeerMaxLatency *prometheus.Desc
 	// MetricsLabels is the labels that are used for the metrics.
 	MetricsLabels []string
 )
@@ -75,6 +85,41 @@ func init() {
 		MetricsLabels,
 		nil,
 	)
+
+	MetricsPeerPacketLoss = prometheus.NewDesc(
+		"liqo_peer_packet_loss_percent",
+		"Percentage of pings lost towards a given peer, computed over the last pings.",
+		MetricsLabels,
+		nil,
+	)
+
+	MetricsPeerJitter = prometheus.NewDesc(
+		"liqo_peer_jitter_us",
+		"Average variation of the round-trip latency of a given peer in microseconds, computed over the last pings.",
+		MetricsLabels,
+		nil,
+	)
+
+	MetricsPeerMinLatency = prometheus.NewDesc(
+		"liqo_peer_latency_min_us",
+		"Minimum round-trip latency of a given peer in microseconds, computed over the last pings.",
+		MetricsLabels,
+		nil,
+	)
+
+	MetricsPeerAvgLatency = prometheus.NewDesc(
+		"liqo_peer_latency_avg_us",
+		"Average round-trip latency of a given peer in microseconds, computed over the last pings.",
+		MetricsLabels,
+		nil,
+	)
+
+	MetricsPeerMaxLatency = prometheus.NewDesc(
+		"liqo_peer_latency_max_us",
+		"Maximum round-trip latency of a given peer in microseconds, computed over the last pings.",
+		MetricsLabels,
+		nil,
+	)
 }
 
 // Describe implements prometheus.Collector.
@@ -83,6 +128,11 @@ func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
 	ch <- MetricsPeerTransmittedBytes
 	ch <- MetricsPeerLatency
 	ch <- MetricsPeerIsConnected
+	ch <- MetricsPeerPacketLoss
+	ch <- MetricsPeerJitter
+	ch <- MetricsPeerMinLatency
+	ch <- MetricsPeerAvgLatency
+	ch <- MetricsPeerMaxLatency
 	MetricsPeerLatencyHistogram.Describe(ch)
 }
 
@@ -92,6 +142,11 @@ func (m *PrometheusMetrics) MetricsErrorHandler(err error, ch chan<- prometheus.
 	ch <- prometheus.NewInvalidMetric(MetricsPeerTransmittedBytes, err)
 	ch <- prometheus.NewInvalidMetric(MetricsPeerLatency, err)
 	ch <- prometheus.NewInvalidMetric(MetricsPeerIsConnected, err)
+	ch <- prometheus.NewInvalidMetric(MetricsPeerPacketLoss, err)
+	ch <- prometheus.NewInvalidMetric(MetricsPeerJitter, err)
+	ch <- prometheus.NewInvalidMetric(MetricsPeerMinLatency, err)
+	ch <- prometheus.NewInvalidMetric(MetricsPeerAvgLatency, err)
+	ch <- prometheus.NewInvalidMetric(MetricsPeerMaxLatency, err)
 }
 
 // GenerateFocusBuckets builds a Prometheus-style histogram bucket layout that
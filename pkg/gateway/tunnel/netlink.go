@@ -15,6 +15,7 @@
 package tunnel
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/vishvananda/netlink"
@@ -84,6 +85,53 @@ func GetTunnelName(idx int) string {
 	return fmt.Sprintf("%s%d", TunnelInterfaceName, idx)
 }
 
+// GetTunnelMTU returns the MTU of the first Wireguard interface.
+func GetTunnelMTU() (int, error) {
+	link, err := GetLink(GetTunnelName(0))
+	if err != nil {
+		return 0, err
+	}
+	return link.Attrs().MTU, nil
+}
+
+// SetTunnelsMTU sets the MTU of all the Wireguard interfaces.
+func SetTunnelsMTU(mtu int) error {
+	for idx := range MaxWireguardInterfaces {
+		link, err := GetLink(GetTunnelName(idx))
+		if err != nil {
+			if errors.As(err, &netlink.LinkNotFoundError{}) {
+				return nil
+			}
+			return err
+		}
+		if link.Attrs().MTU == mtu {
+			continue
+		}
+		if err := netlink.LinkSetMTU(link, mtu); err != nil {
+			return fmt.Errorf("unable to set the MTU of interface %s: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+// GetTunnelsMTU returns the lowest MTU of the tunnel interfaces, or zero if there is no tunnel interface.
+func GetTunnelsMTU() (int, error) {
+	var mtu int
+	for idx := range MaxWireguardInterfaces {
+		link, err := GetLink(GetTunnelName(idx))
+		if err != nil {
+			if errors.As(err, &netlink.LinkNotFoundError{}) {
+				break
+			}
+			return 0, err
+		}
+		if mtu == 0 || link.Attrs().MTU < mtu {
+			mtu = link.Attrs().MTU
+		}
+	}
+	return mtu, nil
+}
+
 // GetRemoteInterfaceIP returns the IP address of the remote Wireguard interface.
 func GetRemoteInterfaceIP(mode gateway.Mode) (string, error) {
 	switch mode {
@@ -0,0 +1,216 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
+const (
+	// qosRootMajor is the major number of the root HTB qdisc enforcing the QoS.
+	qosRootMajor = 1
+	// qosParentMinor is the minor number of the class enforcing the overall bandwidth limit.
+	qosParentMinor = 1
+	// qosDefaultMinor is the minor number of the class of the packets not matching any priority class.
+	qosDefaultMinor = 2
+	// qosClassesMinorOffset is the minor number of the first priority class.
+	qosClassesMinorOffset = 0x10
+	// qosDefaultPriority is the HTB priority of the default class (the lowest one allowed by the API).
+	qosDefaultPriority = 7
+	// qosMinRate is the minimum rate (in bits per second) guaranteed to each class.
+	qosMinRate = 8000
+)
+
+// qosClass is a leaf HTB class enforcing a priority class.
+type qosClass struct {
+	minor    uint16
+	priority uint32
+	rate     uint64
+	ceil     uint64
+	dscp     []int32
+}
+
+// EnsureQoS enforces the given QoS on the tunnel interface with the given name, through an HTB qdisc
+// shaping the egress traffic and u32 filters selecting the class according to the DSCP value of the packets.
+// The bandwidth is evenly split among the given number of tunnels, as the traffic is balanced among them.
+// If qos is nil or does not specify any bandwidth, the QoS configuration is removed.
+func EnsureQoS(name string, qos *networkingv1beta1.QoS, tunnels int) error {
+	link, err := GetLink(name)
+	if err != nil {
+		return fmt.Errorf("getting interface %q: %w", name, err)
+	}
+
+	// The configuration is recreated from scratch, as it changes only when the QoS is updated.
+	if err := deleteQoS(link); err != nil {
+		return err
+	}
+
+	if qos == nil || qos.Bandwidth == nil {
+		return nil
+	}
+
+	bandwidth, classes, err := forgeQoSClasses(qos, tunnels)
+	if err != nil {
+		return err
+	}
+
+	htb := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(qosRootMajor, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	})
+	htb.Defcls = qosDefaultMinor
+	if err := netlink.QdiscAdd(htb); err != nil {
+		return fmt.Errorf("adding htb qdisc to interface %q: %w", name, err)
+	}
+
+	if err := addHtbClass(link, netlink.MakeHandle(qosRootMajor, 0), qosParentMinor,
+		netlink.HtbClassAttrs{Rate: bandwidth, Ceil: bandwidth}); err != nil {
+		return err
+	}
+
+	for i := range classes {
+		if err := addQoSClass(link, &classes[i]); err != nil {
+			return err
+		}
+	}
+
+	klog.Infof("Enforced QoS on interface %q: bandwidth %d bit/s, %d priority classes", name, bandwidth, len(classes)-1)
+	return nil
+}
+
+// forgeQoSClasses returns the bandwidth limit of a single tunnel and the leaf classes (the default one included).
+// The bandwidth not explicitly guaranteed is evenly shared among the classes without a rate.
+func forgeQoSClasses(qos *networkingv1beta1.QoS, tunnels int) (bandwidth uint64, classes []qosClass, err error) {
+	if tunnels <= 0 {
+		tunnels = 1
+	}
+	if qos.Bandwidth.Sign() <= 0 {
+		return 0, nil, fmt.Errorf("invalid bandwidth %s", qos.Bandwidth.String())
+	}
+	bandwidth = max(uint64(qos.Bandwidth.Value())/uint64(tunnels), qosMinRate)
+
+	var guaranteed uint64
+	unspecified := 1 // The default class.
+	classes = make([]qosClass, 0, len(qos.Classes)+1)
+	for i := range qos.Classes {
+		class := &qos.Classes[i]
+		var rate uint64
+		if class.Rate != nil {
+			if class.Rate.Sign() <= 0 {
+				return 0, nil, fmt.Errorf("invalid rate %s for class %q", class.Rate.String(), class.Name)
+			}
+			rate = max(uint64(class.Rate.Value())/uint64(tunnels), qosMinRate)
+			guaranteed += rate
+		} else {
+			unspecified++
+		}
+		classes = append(classes, qosClass{
+			minor:    uint16(qosClassesMinorOffset + i),
+			priority: uint32(class.Priority),
+			rate:     rate,
+			ceil:     bandwidth,
+			dscp:     class.DSCP,
+		})
+	}
+	if guaranteed > bandwidth {
+		return 0, nil, fmt.Errorf("the rates guaranteed to the classes exceed the bandwidth (%d > %d bit/s)", guaranteed, bandwidth)
+	}
+	classes = append(classes, qosClass{minor: qosDefaultMinor, priority: qosDefaultPriority, ceil: bandwidth})
+
+	share := max((bandwidth-guaranteed)/uint64(unspecified), qosMinRate)
+	for i := range classes {
+		if classes[i].rate == 0 {
+			classes[i].rate = share
+		}
+	}
+	return bandwidth, classes, nil
+}
+
+// addQoSClass adds the given leaf class, together with its fq_codel qdisc and the filters selecting it.
+func addQoSClass(link netlink.Link, class *qosClass) error {
+	if err := addHtbClass(link, netlink.MakeHandle(qosRootMajor, qosParentMinor), class.minor,
+		netlink.HtbClassAttrs{Rate: class.rate, Ceil: class.ceil, Prio: class.priority}); err != nil {
+		return err
+	}
+
+	leaf := netlink.NewFqCodel(netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(qosClassesMinorOffset+class.minor, 0),
+		Parent:    netlink.MakeHandle(qosRootMajor, class.minor),
+	})
+	if err := netlink.QdiscAdd(leaf); err != nil {
+		// The class keeps working with the default pfifo qdisc, although more prone to bufferbloat.
+		klog.Warningf("Unable to add fq_codel qdisc to class %d of interface %q: %v", class.minor, link.Attrs().Name, err)
+	}
+
+	for _, dscp := range class.dscp {
+		filter := &netlink.U32{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: link.Attrs().Index,
+				Parent:    netlink.MakeHandle(qosRootMajor, 0),
+				Priority:  1,
+				Protocol:  unix.ETH_P_IP,
+			},
+			ClassId: netlink.MakeHandle(qosRootMajor, class.minor),
+			// Match the DSCP field, i.e., the 6 most significant bits of the TOS byte of the IPv4 header.
+			Sel: &netlink.TcU32Sel{
+				Flags: netlink.TC_U32_TERMINAL,
+				Keys:  []netlink.TcU32Key{{Mask: 0x00fc0000, Val: uint32(dscp) << 18}},
+			},
+		}
+		if err := netlink.FilterAdd(filter); err != nil {
+			return fmt.Errorf("adding filter for DSCP %d to interface %q: %w", dscp, link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+func addHtbClass(link netlink.Link, parent uint32, minor uint16, attrs netlink.HtbClassAttrs) error {
+	class := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: link.Attrs().Index,
+		Parent:    parent,
+		Handle:    netlink.MakeHandle(qosRootMajor, minor),
+	}, attrs)
+	if err := netlink.ClassAdd(class); err != nil {
+		return fmt.Errorf("adding htb class %d to interface %q: %w", minor, link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// deleteQoS removes the root qdisc enforcing the QoS, if present, restoring the default one.
+func deleteQoS(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs of interface %q: %w", link.Attrs().Name, err)
+	}
+	for _, qdisc := range qdiscs {
+		attrs := qdisc.Attrs()
+		if attrs.Parent != netlink.HANDLE_ROOT || attrs.Handle != netlink.MakeHandle(qosRootMajor, 0) || qdisc.Type() != "htb" {
+			continue
+		}
+		if err := netlink.QdiscDel(qdisc); err != nil && !errors.Is(err, unix.ENOENT) {
+			return fmt.Errorf("deleting htb qdisc of interface %q: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
+var _ = Describe("QoS", func() {
+	var qos *networkingv1beta1.QoS
+
+	BeforeEach(func() {
+		qos = &networkingv1beta1.QoS{
+			Bandwidth: ptr.To(resource.MustParse("100M")),
+			Classes: []networkingv1beta1.QoSClass{
+				{Name: "realtime", Priority: 0, DSCP: []int32{46}, Rate: ptr.To(resource.MustParse("40M"))},
+				{Name: "bulk", Priority: 5, DSCP: []int32{8, 10}},
+			},
+		}
+	})
+
+	It("should forge the classes, sharing the bandwidth not guaranteed", func() {
+		bandwidth, classes, err := forgeQoSClasses(qos, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bandwidth).To(BeEquivalentTo(100_000_000))
+		Expect(classes).To(Equal([]qosClass{
+			{minor: qosClassesMinorOffset, priority: 0, rate: 40_000_000, ceil: 100_000_000, dscp: []int32{46}},
+			{minor: qosClassesMinorOffset + 1, priority: 5, rate: 30_000_000, ceil: 100_000_000, dscp: []int32{8, 10}},
+			{minor: qosDefaultMinor, priority: qosDefaultPriority, rate: 30_000_000, ceil: 100_000_000},
+		}))
+	})
+
+	It("should split the bandwidth among the tunnels", func() {
+		bandwidth, classes, err := forgeQoSClasses(qos, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bandwidth).To(BeEquivalentTo(25_000_000))
+		Expect(classes[0].rate).To(BeEquivalentTo(10_000_000))
+		Expect(classes[0].ceil).To(BeEquivalentTo(25_000_000))
+	})
+
+	It("should forge only the default class if no classes are defined", func() {
+		qos.Classes = nil
+		_, classes, err := forgeQoSClasses(qos, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(classes).To(Equal([]qosClass{
+			{minor: qosDefaultMinor, priority: qosDefaultPriority, rate: 100_000_000, ceil: 100_000_000},
+		}))
+	})
+
+	It("should fail if the guaranteed rates exceed the bandwidth", func() {
+		qos.Classes[1].Rate = ptr.To(resource.MustParse("70M"))
+		_, _, err := forgeQoSClasses(qos, 1)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -30,20 +31,40 @@ import (
 
 var errWgEndpointPeerNotFound = errors.New("wg endpoint peer not found")
 
+// peerSessionTimeout is the time after which a WireGuard session is rejected if not renewed with a new handshake.
+const peerSessionTimeout = 3 * time.Minute
+
 func configureDevice(wgcl *wgctrl.Client, options *Options, peerPubKey wgtypes.Key, idx int) error {
 	confdev := wgtypes.Config{
 		PrivateKey: &options.PrivateKey,
 		ListenPort: nil,
 		Peers: []wgtypes.PeerConfig{
 			{
-				PublicKey:  peerPubKey,
-				AllowedIPs: []net.IPNet{{IP: net.IP{0, 0, 0, 0}, Mask: net.CIDRMask(0, 32)}},
+				PublicKey:         peerPubKey,
+				ReplaceAllowedIPs: true,
+				AllowedIPs:        []net.IPNet{{IP: net.IP{0, 0, 0, 0}, Mask: net.CIDRMask(0, 32)}},
 			},
 		},
-		ReplacePeers: true,
 	}
 	name := tunnel.GetTunnelName(idx)
 
+	// The other peers are explicitly removed once the given one is in place, rather than replacing all of them,
+	// to preserve the session with the current peer (e.g., when the local key is rotated).
+	var stale []wgtypes.PeerConfig
+	dev, err := getExistingDevice(wgcl, name)
+	switch {
+	case err == nil:
+		for i := range dev.Peers {
+			if dev.Peers[i].PublicKey != peerPubKey {
+				stale = append(stale, wgtypes.PeerConfig{PublicKey: dev.Peers[i].PublicKey, Remove: true})
+			}
+		}
+	case errors.Is(err, errWgEndpointPeerNotFound):
+		confdev.ReplacePeers = true
+	default:
+		return fmt.Errorf("getting existing device: %w", err)
+	}
+
 	switch options.GwOptions.Mode {
 	case gateway.ModeServer:
 		confdev.ListenPort = &options.ListenPorts[idx]
@@ -69,11 +90,56 @@ func configureDevice(wgcl *wgctrl.Client, options *Options, peerPubKey wgtypes.K
 	if err := wgcl.ConfigureDevice(name, confdev); err != nil {
 		return fmt.Errorf("configuring the device %q: %w", name, err)
 	}
+	if len(stale) > 0 {
+		// The allowed IPs have already been moved to the given peer, hence the traffic is never left without a peer.
+		if err := wgcl.ConfigureDevice(name, wgtypes.Config{Peers: stale}); err != nil {
+			return fmt.Errorf("removing the stale peers from the device %q: %w", name, err)
+		}
+	}
 	klog.Infof("Device %s configured", name)
 
 	return nil
 }
 
+// addPendingPeer configures the given peer alongside the current one, without allowed IPs.
+// This way, the remote gateway can complete a handshake using its new key, while the traffic still flows through the current peer.
+func addPendingPeer(wgcl *wgctrl.Client, options *Options, peerPubKey wgtypes.Key, idx int) error {
+	peer := wgtypes.PeerConfig{PublicKey: peerPubKey}
+	if options.GwOptions.Mode == gateway.ModeClient {
+		peer.Endpoint = &net.UDPAddr{
+			IP:   options.EndpointIP,
+			Port: options.EndpointPorts[idx],
+		}
+	}
+
+	name := tunnel.GetTunnelName(idx)
+	if err := wgcl.ConfigureDevice(name, wgtypes.Config{PrivateKey: &options.PrivateKey, Peers: []wgtypes.PeerConfig{peer}}); err != nil {
+		return fmt.Errorf("configuring the device %q: %w", name, err)
+	}
+	klog.Infof("Device %s configured with pending peer %s", name, peerPubKey.String())
+
+	return nil
+}
+
+// isKeyRotationPending returns whether the given peer replaces a different one, which is still in use,
+// and the remote gateway did not complete a handshake with its new key yet.
+func isKeyRotationPending(dev *wgtypes.Device, peerPubKey wgtypes.Key, now time.Time) bool {
+	var current, next *wgtypes.Peer
+	for i := range dev.Peers {
+		switch {
+		case dev.Peers[i].PublicKey == peerPubKey:
+			next = &dev.Peers[i]
+		case len(dev.Peers[i].AllowedIPs) > 0:
+			current = &dev.Peers[i]
+		}
+	}
+
+	if current == nil || now.Sub(current.LastHandshakeTime) > peerSessionTimeout {
+		return false
+	}
+	return next == nil || next.LastHandshakeTime.IsZero()
+}
+
 func getExistingPeerEndpoint(wgcl *wgctrl.Client, peerPubKey wgtypes.Key, name string) (*net.UDPAddr, error) {
 	peer, err := getExistingPeer(wgcl, peerPubKey, name)
 	if err != nil {
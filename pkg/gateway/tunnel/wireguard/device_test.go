@@ -0,0 +1,70 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var _ = Describe("Key rotation", func() {
+	var (
+		now          time.Time
+		current, key wgtypes.Key
+		dev          *wgtypes.Device
+		allowedIPs   = []net.IPNet{{IP: net.IP{0, 0, 0, 0}, Mask: net.CIDRMask(0, 32)}}
+	)
+
+	generateKey := func() wgtypes.Key {
+		k, err := wgtypes.GenerateKey()
+		Expect(err).ToNot(HaveOccurred())
+		return k
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		current, key = generateKey(), generateKey()
+		dev = &wgtypes.Device{Peers: []wgtypes.Peer{
+			{PublicKey: current, AllowedIPs: allowedIPs, LastHandshakeTime: now.Add(-time.Minute)},
+		}}
+	})
+
+	It("should not be pending if the key is already in use", func() {
+		Expect(isKeyRotationPending(dev, current, now)).To(BeFalse())
+	})
+
+	It("should not be pending if the device has no peers", func() {
+		Expect(isKeyRotationPending(&wgtypes.Device{}, key, now)).To(BeFalse())
+	})
+
+	It("should not be pending if the current peer has no active session", func() {
+		dev.Peers[0].LastHandshakeTime = now.Add(-10 * time.Minute)
+		Expect(isKeyRotationPending(dev, key, now)).To(BeFalse())
+	})
+
+	It("should be pending until the new peer completes a handshake", func() {
+		Expect(isKeyRotationPending(dev, key, now)).To(BeTrue())
+
+		dev.Peers = append(dev.Peers, wgtypes.Peer{PublicKey: key})
+		Expect(isKeyRotationPending(dev, key, now)).To(BeTrue())
+
+		dev.Peers[1].LastHandshakeTime = now
+		Expect(isKeyRotationPending(dev, key, now)).To(BeFalse())
+	})
+})
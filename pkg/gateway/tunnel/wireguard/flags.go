@@ -44,6 +44,10 @@ const (
 	FlagNameEndpointPorts FlagName = "endpoint-ports"
 	// FlagNameKeysDir is the directory where the keys are stored.
 	FlagNameKeysDir FlagName = "keys-dir"
+	// FlagNameKeysCheckInterval is the interval between two checks of the keys, to detect their rotation.
+	FlagNameKeysCheckInterval FlagName = "keys-check-interval"
+	// FlagNameKeysExchangePort is the port used to exchange the next public keys through the tunnel.
+	FlagNameKeysExchangePort FlagName = "keys-exchange-port"
 
 	// FlagNameDNSCheckInterval is the interval between two DNS checks.
 	FlagNameDNSCheckInterval FlagName = "dns-check-interval"
@@ -66,6 +70,10 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.StringVar(&opts.EndpointAddress, FlagNameEndpointAddress.String(), "", "Endpoint address (client only)")
 	flagset.IntSliceVar(&opts.EndpointPorts, FlagNameEndpointPorts.String(), []int{forge.DefaultGwServerPort}, "List of endpoint ports (client only)")
 	flagset.StringVar(&opts.KeysDir, FlagNameKeysDir.String(), forge.DefaultKeysDir, "Directory where the keys are stored")
+	flagset.DurationVar(&opts.KeysCheckInterval, FlagNameKeysCheckInterval.String(), 10*time.Second,
+		"Interval between two checks of the keys, to detect their rotation")
+	flagset.IntVar(&opts.KeysExchangePort, FlagNameKeysExchangePort.String(), forge.DefaultKeysExchangePort,
+		"Port used to exchange the next public keys with the remote gateway through the tunnel")
 
 	flagset.DurationVar(&opts.DNSCheckInterval, FlagNameDNSCheckInterval.String(), 5*time.Minute, "Interval between two DNS checks")
 
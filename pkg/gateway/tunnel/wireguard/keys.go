@@ -15,6 +15,7 @@
 package wireguard
 
 import (
+	"context"
 	"encoding/base64"
 	"io"
 	"os"
@@ -22,32 +23,67 @@ import (
 	"path/filepath"
 
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 // LoadKeys loads the keys from the specified directory.
 func LoadKeys(options *Options) error {
+	key, err := readPrivateKey(options.KeysDir)
+	if err != nil {
+		return err
+	}
+
+	options.PrivateKey = key
+	return nil
+}
+
+// StartKeysRoutine run a routine which periodically checks whether the private key stored in the keys directory changed.
+// The keys directory is a mounted secret, which is eventually updated by the kubelet when the keys are rotated.
+// If the private key changed a new publickkeys-controller reconcile is triggered through a generic event.
+func StartKeysRoutine(ctx context.Context, ch chan event.GenericEvent, opts *Options) {
+	current := opts.PrivateKey
+	err := wait.PollUntilContextCancel(ctx, opts.KeysCheckInterval, false, func(_ context.Context) (done bool, err error) {
+		key, err := readPrivateKey(opts.KeysDir)
+		if err != nil {
+			klog.Warningf("Unable to read the private key: %v", err)
+			return false, nil
+		}
+		if key == current {
+			return false, nil
+		}
+
+		klog.Infof("Private key changed: reconfiguring the WireGuard interfaces")
+		current = key
+
+		// Triggers a new reconcile
+		ch <- event.GenericEvent{}
+
+		return false, nil
+	})
+	if err != nil && ctx.Err() == nil {
+		klog.Error(err)
+	}
+}
+
+func readPrivateKey(keysDir string) (wgtypes.Key, error) {
 	// Load the keys
-	privKeyPath := path.Join(options.KeysDir, "privateKey")
+	privKeyPath := path.Join(keysDir, "privateKey")
 
 	// read the private key from the file
 	privKeyFile, err := os.Open(filepath.Clean(privKeyPath))
 	if err != nil {
-		return err
+		return wgtypes.Key{}, err
 	}
 	defer privKeyFile.Close()
 
 	// base64 encoded private key
 	privKey, err := io.ReadAll(privKeyFile)
 	if err != nil {
-		return err
+		return wgtypes.Key{}, err
 	}
 
 	base64PrivKey := base64.StdEncoding.EncodeToString(privKey)
-	wgtypesKey, err := wgtypes.ParseKey(base64PrivKey)
-	if err != nil {
-		return err
-	}
-
-	options.PrivateKey = wgtypesKey
-	return nil
+	return wgtypes.ParseKey(base64PrivKey)
 }
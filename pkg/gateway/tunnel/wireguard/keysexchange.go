@@ -0,0 +1,310 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway/forge"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+const (
+	// keysExchangeNextKey is the type of the message announcing the next public key of the sender.
+	keysExchangeNextKey = "NEXTKEY"
+	// keysExchangeNextKeyAck is the type of the message confirming that the next public key of the receiver is accepted.
+	keysExchangeNextKeyAck = "NEXTKEYACK"
+	// keysExchangeBufferSize is the size of the buffer used to receive the messages.
+	keysExchangeBufferSize = 1024
+)
+
+// keysExchangeMsg is a message exchanged by the gateways to agree on the next public keys.
+// The sender is not part of the message, as it is identified by the tunnel the message is received from.
+type keysExchangeMsg struct {
+	Type      string `json:"type"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+// KeysExchanger announces the next public key of the local gateway to the remote one, and accepts the next public key
+// announced by the remote gateway. The messages are exchanged through the tunnel, which authenticates them with the
+// current keys: this way, the keys can be rotated (e.g., periodically) without any other channel between the clusters.
+type KeysExchanger struct {
+	Client  client.Client
+	Options *Options
+}
+
+// NewKeysExchanger returns a new KeysExchanger.
+func NewKeysExchanger(cl client.Client, options *Options) *KeysExchanger {
+	return &KeysExchanger{
+		Client:  cl,
+		Options: options,
+	}
+}
+
+// Start runs the KeysExchanger until the given context is canceled.
+func (k *KeysExchanger) Start(ctx context.Context) error {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero, Port: k.Options.KeysExchangePort})
+	if err != nil {
+		return fmt.Errorf("unable to listen on port %d for the keys exchange: %w", k.Options.KeysExchangePort, err)
+	}
+	if err := enablePacketInfo(conn); err != nil {
+		return fmt.Errorf("unable to configure the keys exchange socket: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	klog.Infof("Keys exchange started on port %d", k.Options.KeysExchangePort)
+	go k.announce(ctx, conn)
+	k.receive(ctx, conn)
+	return nil
+}
+
+// announce periodically announces the next public key of the local gateway, if any, until it is accepted.
+func (k *KeysExchanger) announce(ctx context.Context, conn *net.UDPConn) {
+	remoteIP, err := tunnel.GetRemoteInterfaceIP(k.Options.GwOptions.Mode)
+	if err != nil {
+		klog.Errorf("Unable to retrieve the remote tunnel address: %v", err)
+		return
+	}
+	raddr := &net.UDPAddr{IP: net.ParseIP(remoteIP), Port: k.Options.KeysExchangePort}
+
+	// Ignore errors because only caused by context cancellation.
+	_ = wait.PollUntilContextCancel(ctx, k.Options.KeysCheckInterval, false, func(ctx context.Context) (done bool, err error) {
+		next, err := readNextPublicKey(k.Options.KeysDir)
+		if err != nil || next == nil {
+			return false, nil
+		}
+
+		acknowledged, err := k.getAcknowledgedKey(ctx)
+		if err != nil {
+			klog.Warningf("Unable to retrieve the acknowledged next public key: %v", err)
+			return false, nil
+		}
+		if bytes.Equal(acknowledged, next) {
+			return false, nil
+		}
+
+		if err := k.send(conn, raddr, keysExchangeNextKey, next); err != nil {
+			klog.Warningf("Unable to announce the next public key: %v", err)
+		}
+		return false, nil
+	})
+}
+
+// receive handles the messages received from the remote gateway through the tunnel.
+func (k *KeysExchanger) receive(ctx context.Context, conn *net.UDPConn) {
+	buff := make([]byte, keysExchangeBufferSize)
+	oob := make([]byte, unix.CmsgSpace(unix.SizeofInet4Pktinfo))
+	for ctx.Err() == nil {
+		n, oobn, _, raddr, err := conn.ReadMsgUDP(buff, oob)
+		if err != nil {
+			if ctx.Err() == nil {
+				klog.Errorf("Unable to receive keys exchange message: %v", err)
+			}
+			continue
+		}
+
+		// Only the messages received through the tunnel are authenticated by the remote gateway keys.
+		if !receivedFromTunnel(oob[:oobn]) {
+			klog.Warningf("Discarded keys exchange message from %s, not received through the tunnel", raddr)
+			continue
+		}
+
+		var msg keysExchangeMsg
+		if err := json.Unmarshal(buff[:n], &msg); err != nil {
+			klog.Warningf("Unable to unmarshal keys exchange message from %s: %v", raddr, err)
+			continue
+		}
+		if len(msg.PublicKey) != wgtypes.KeyLen {
+			klog.Warningf("Discarded invalid keys exchange message from %s", raddr)
+			continue
+		}
+
+		switch msg.Type {
+		case keysExchangeNextKey:
+			err = k.handleNextKey(ctx, conn, raddr, msg.PublicKey)
+		case keysExchangeNextKeyAck:
+			err = k.handleNextKeyAck(ctx, msg.PublicKey)
+		}
+		if err != nil {
+			klog.Errorf("Unable to handle keys exchange message %s from %s: %v", msg.Type, raddr, err)
+		}
+	}
+}
+
+// handleNextKey configures the next public key of the remote gateway in the PublicKey resource,
+// and acknowledges it once the local gateway accepts it alongside the current one.
+func (k *KeysExchanger) handleNextKey(ctx context.Context, conn *net.UDPConn, raddr *net.UDPAddr, key []byte) error {
+	pk, err := getters.GetPublicKeyByClusterID(ctx, k.Client,
+		liqov1beta1.ClusterID(k.Options.GwOptions.RemoteClusterID), k.Options.GwOptions.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(pk.Spec.PublicKey, key) {
+		pk.Spec.PublicKey = key
+		if err := k.Client.Update(ctx, pk); err != nil {
+			return fmt.Errorf("unable to update the publicKey %q: %w", client.ObjectKeyFromObject(pk), err)
+		}
+		klog.Infof("PublicKey %q updated with the next key announced by the remote gateway", client.ObjectKeyFromObject(pk))
+		// The acknowledgement is sent once the key has been accepted, at the next announcement.
+		return nil
+	}
+
+	if pk.Status.ObservedGeneration != pk.Generation || (pk.Status.Phase != networkingv1beta1.PublicKeyPhasePending &&
+		pk.Status.Phase != networkingv1beta1.PublicKeyPhaseApplied) {
+		return nil
+	}
+	return k.send(conn, raddr, keysExchangeNextKeyAck, key)
+}
+
+// handleNextKeyAck records in the Connection resource that the remote gateway accepted the next public key,
+// so that the keys can be switched without interrupting the tunnel.
+func (k *KeysExchanger) handleNextKeyAck(ctx context.Context, key []byte) error {
+	next, err := readNextPublicKey(k.Options.KeysDir)
+	if err != nil || !bytes.Equal(next, key) {
+		// The acknowledgement refers to a stale key.
+		return err
+	}
+
+	var conn networkingv1beta1.Connection
+	if err := k.Client.Get(ctx, k.connectionKey(), &conn); err != nil {
+		return fmt.Errorf("unable to get the connection: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+	if conn.Annotations[consts.NextPublicKeyAcknowledgedAnnotation] == encoded {
+		return nil
+	}
+
+	if conn.Annotations == nil {
+		conn.Annotations = make(map[string]string)
+	}
+	conn.Annotations[consts.NextPublicKeyAcknowledgedAnnotation] = encoded
+	if err := k.Client.Update(ctx, &conn); err != nil {
+		return fmt.Errorf("unable to update the connection: %w", err)
+	}
+	klog.Infof("The remote gateway accepted the next public key %s", encoded)
+	return nil
+}
+
+// getAcknowledgedKey returns the next public key the remote gateway accepted, if any.
+func (k *KeysExchanger) getAcknowledgedKey(ctx context.Context) ([]byte, error) {
+	var conn networkingv1beta1.Connection
+	if err := k.Client.Get(ctx, k.connectionKey(), &conn); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	encoded, ok := conn.Annotations[consts.NextPublicKeyAcknowledgedAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+func (k *KeysExchanger) connectionKey() types.NamespacedName {
+	return types.NamespacedName{Name: forge.GatewayResourceName(k.Options.GwOptions.Name), Namespace: k.Options.GwOptions.Namespace}
+}
+
+func (k *KeysExchanger) send(conn *net.UDPConn, raddr *net.UDPAddr, msgType string, key []byte) error {
+	b, err := json.Marshal(keysExchangeMsg{Type: msgType, PublicKey: key})
+	if err != nil {
+		return err
+	}
+	if _, err := conn.WriteToUDP(b, raddr); err != nil {
+		return fmt.Errorf("unable to send keys exchange message to %s: %w", raddr, err)
+	}
+	klog.V(4).Infof("Sent keys exchange message %s to %s", msgType, raddr)
+	return nil
+}
+
+// readNextPublicKey returns the next public key stored in the keys directory, or nil if there is no rotation in progress.
+func readNextPublicKey(keysDir string) ([]byte, error) {
+	key, err := os.ReadFile(filepath.Clean(path.Join(keysDir, consts.NextPublicKeyField)))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case len(key) != wgtypes.KeyLen:
+		return nil, fmt.Errorf("invalid next public key length %d", len(key))
+	default:
+		return key, nil
+	}
+}
+
+// enablePacketInfo configures the socket to report the interface the packets are received from.
+func enablePacketInfo(conn *net.UDPConn) error {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	if err := rc.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_PKTINFO, 1)
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// receivedFromTunnel returns whether the given control messages report that the packet has been received
+// through one of the tunnel interfaces.
+func receivedFromTunnel(oob []byte) bool {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return false
+	}
+	for i := range msgs {
+		if msgs[i].Header.Level != unix.IPPROTO_IP || msgs[i].Header.Type != unix.IP_PKTINFO ||
+			len(msgs[i].Data) < unix.SizeofInet4Pktinfo {
+			continue
+		}
+		// The index of the interface is the first field of the in_pktinfo structure.
+		ifindex := int(int32(binary.NativeEndian.Uint32(msgs[i].Data)))
+		for idx := range tunnel.MaxWireguardInterfaces {
+			link, err := tunnel.GetLink(tunnel.GetTunnelName(idx))
+			if err != nil {
+				break
+			}
+			if link.Attrs().Index == ifindex {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -158,6 +158,24 @@ func (pc *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
 		}).Observe(float64(latency.Microseconds()))
 	}
 
+	if health := conn.Status.Health; health != nil {
+		ch <- prometheus.MustNewConstMetric(
+			tunnel.MetricsPeerPacketLoss,
+			prometheus.GaugeValue,
+			float64(health.PacketLoss),
+			labels...,
+		)
+
+		for desc, value := range map[*prometheus.Desc]time.Duration{
+			tunnel.MetricsPeerJitter:     health.Jitter.Duration,
+			tunnel.MetricsPeerMinLatency: health.MinRTT.Duration,
+			tunnel.MetricsPeerAvgLatency: health.AvgRTT.Duration,
+			tunnel.MetricsPeerMaxLatency: health.MaxRTT.Duration,
+		} {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value.Microseconds()), labels...)
+		}
+	}
+
 	tunnel.MetricsPeerLatencyHistogram.Collect(ch)
 }
 
@@ -74,6 +74,10 @@ type Options struct {
 	EndpointPorts []int
 
 	KeysDir string
+	// KeysCheckInterval is the interval between two checks of the keys stored in KeysDir, to detect their rotation.
+	KeysCheckInterval time.Duration
+	// KeysExchangePort is the port used to exchange the next public keys with the remote gateway through the tunnel.
+	KeysExchangePort int
 
 	EndpointIP      net.IP
 	EndpointIPMutex *sync.Mutex
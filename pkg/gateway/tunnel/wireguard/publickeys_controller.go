@@ -16,15 +16,18 @@ package wireguard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +37,7 @@ import (
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
 )
 
 const (
@@ -41,10 +45,13 @@ const (
 	maxNAPIAttempts = 3
 	// napiBackoffBase is the baseline duration to wait between retry attempts.
 	napiBackoffBase = 200 * time.Millisecond
+	// keyRotationCheckInterval is the interval between two checks of a pending key rotation.
+	keyRotationCheckInterval = 500 * time.Millisecond
 )
 
 // cluster-role
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=publickeies,verbs=get;list;create;delete;update;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=publickeies/status,verbs=get;update;patch
 
 // PublicKeysReconciler updates the PublicKey resource used to establish the Wireguard connection.
 type PublicKeysReconciler struct {
@@ -86,13 +93,32 @@ func (r *PublicKeysReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		klog.Warning("EndpointIP is not set yet. Maybe the DNS resolution is still in progress")
 		return ctrl.Result{}, nil
 	}
+	// Reload the private key, as it might have been rotated.
+	if err := LoadKeys(r.Options); err != nil {
+		return ctrl.Result{}, fmt.Errorf("loading keys: %w", err)
+	}
+
 	// Get interface list
 	ports, err := GetWireguardPorts(r.Options)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("parsing wireguard ports: %w", err)
 	}
+	peerPubKey := wgtypes.Key(publicKey.Spec.PublicKey)
+	phase := networkingv1beta1.PublicKeyPhaseApplied
 	for i := range ports {
-		if err := configureDevice(r.Wgcl, r.Options, wgtypes.Key(publicKey.Spec.PublicKey), i); err != nil {
+		pending, err := r.isKeyRotationPending(peerPubKey, i)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking key rotation on interface %d: %w", i, err)
+		}
+		if pending {
+			// Keep the current peer until the remote gateway switches to the new key, to avoid interrupting the tunnel.
+			phase = networkingv1beta1.PublicKeyPhasePending
+			if err := addPendingPeer(r.Wgcl, r.Options, peerPubKey, i); err != nil {
+				return ctrl.Result{}, fmt.Errorf("configuring pending peer on interface %d: %w", i, err)
+			}
+			continue
+		}
+		if err := configureDevice(r.Wgcl, r.Options, peerPubKey, i); err != nil {
 			return ctrl.Result{}, fmt.Errorf("configuring interface %d: %w", i, err)
 		}
 	}
@@ -103,7 +129,46 @@ func (r *PublicKeysReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			klog.Warningf("Skipped threaded NAPI setup: %v", err)
 		}
 	}
-	return ctrl.Result{}, EnsureConnection(ctx, r.Client, r.Scheme, r.Options)
+
+	if err := r.updateStatus(ctx, publicKey, phase); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := EnsureConnection(ctx, r.Client, r.Scheme, r.Options); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if phase == networkingv1beta1.PublicKeyPhasePending {
+		return ctrl.Result{RequeueAfter: keyRotationCheckInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *PublicKeysReconciler) isKeyRotationPending(peerPubKey wgtypes.Key, idx int) (bool, error) {
+	dev, err := getExistingDevice(r.Wgcl, tunnel.GetTunnelName(idx))
+	switch {
+	case errors.Is(err, errWgEndpointPeerNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return isKeyRotationPending(dev, peerPubKey, time.Now()), nil
+	}
+}
+
+func (r *PublicKeysReconciler) updateStatus(ctx context.Context, publicKey *networkingv1beta1.PublicKey,
+	phase networkingv1beta1.PublicKeyPhase) error {
+	if publicKey.Status.Phase == phase && publicKey.Status.ObservedGeneration == publicKey.Generation {
+		return nil
+	}
+
+	publicKey.Status.Phase = phase
+	publicKey.Status.ObservedGeneration = publicKey.Generation
+	publicKey.Status.LastUpdateTime = ptr.To(metav1.Now())
+	if err := r.Client.Status().Update(ctx, publicKey); err != nil {
+		return fmt.Errorf("unable to update the status of the publicKey %q: %w", client.ObjectKeyFromObject(publicKey), err)
+	}
+	klog.Infof("PublicKey %q is %s", client.ObjectKeyFromObject(publicKey), phase)
+	return nil
 }
 
 // SetupWithManager register the ConfigurationReconciler to the manager.
@@ -0,0 +1,164 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+	"github.com/liqotech/liqo/pkg/utils/network/netmonitor"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients,verbs=get;list;watch
+
+// QoSReconciler enforces the QoS specified by the GatewayServer or GatewayClient owning the gateway on the tunnel interfaces.
+type QoSReconciler struct {
+	Client  client.Client
+	Options *Options
+
+	// enforced contains the QoS enforced on each tunnel interface. It is accessed by a single worker.
+	enforced map[string]enforcedQoS
+}
+
+// enforcedQoS is the QoS enforced on a given instance of a tunnel interface.
+type enforcedQoS struct {
+	ifindex int
+	qos     *networkingv1beta1.QoS
+}
+
+// NewQoSReconciler returns a new QoSReconciler.
+func NewQoSReconciler(cl client.Client, options *Options) *QoSReconciler {
+	return &QoSReconciler{
+		Client:   cl,
+		Options:  options,
+		enforced: make(map[string]enforcedQoS),
+	}
+}
+
+// Reconcile enforces the QoS on the tunnel interfaces.
+func (r *QoSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	qos, err := r.getQoS(ctx, req)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(6).Infof("There is no gateway %s", req.String())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the gateway %q: %w", req.NamespacedName, err)
+	}
+
+	ports, err := GetWireguardPorts(r.Options)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("parsing wireguard ports: %w", err)
+	}
+	for i := range ports {
+		if err := r.ensureQoS(tunnel.GetTunnelName(i), qos, len(ports)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("enforcing QoS on interface %d: %w", i, err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// ensureQoS enforces the given QoS on the tunnel interface with the given name, unless already enforced
+// on the same instance of the interface. Indeed, the configuration is lost when the interface is recreated.
+func (r *QoSReconciler) ensureQoS(name string, qos *networkingv1beta1.QoS, tunnels int) error {
+	link, err := tunnel.GetLink(name)
+	if err != nil {
+		return fmt.Errorf("getting interface %q: %w", name, err)
+	}
+
+	if current, found := r.enforced[name]; found && current.ifindex == link.Attrs().Index &&
+		equality.Semantic.DeepEqual(current.qos, qos) {
+		return nil
+	}
+
+	delete(r.enforced, name)
+	if err := tunnel.EnsureQoS(name, qos, tunnels); err != nil {
+		return err
+	}
+	r.enforced[name] = enforcedQoS{ifindex: link.Attrs().Index, qos: qos.DeepCopy()}
+	return nil
+}
+
+// getQoS returns the QoS specified by the GatewayServer or GatewayClient, according to the gateway mode.
+func (r *QoSReconciler) getQoS(ctx context.Context, req ctrl.Request) (*networkingv1beta1.QoS, error) {
+	switch r.Options.GwOptions.Mode {
+	case gateway.ModeServer:
+		var gwServer networkingv1beta1.GatewayServer
+		if err := r.Client.Get(ctx, req.NamespacedName, &gwServer); err != nil {
+			return nil, err
+		}
+		return gwServer.Spec.QoS, nil
+	case gateway.ModeClient:
+		var gwClient networkingv1beta1.GatewayClient
+		if err := r.Client.Get(ctx, req.NamespacedName, &gwClient); err != nil {
+			return nil, err
+		}
+		return gwClient.Spec.QoS, nil
+	default:
+		return nil, fmt.Errorf("invalid mode %v", r.Options.GwOptions.Mode)
+	}
+}
+
+// SetupWithManager register the QoSReconciler to the manager.
+func (r *QoSReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	var obj client.Object
+	switch r.Options.GwOptions.Mode {
+	case gateway.ModeServer:
+		obj = &networkingv1beta1.GatewayServer{}
+	case gateway.ModeClient:
+		obj = &networkingv1beta1.GatewayClient{}
+	default:
+		return fmt.Errorf("invalid mode %v", r.Options.GwOptions.Mode)
+	}
+
+	// The QoS configuration is lost when a tunnel interface is recreated, hence the creation of the interfaces
+	// triggers a reconciliation. Reconciliations not changing the QoS nor the interfaces are no-ops.
+	src := make(chan event.GenericEvent)
+	go func() {
+		utilruntime.Must(netmonitor.InterfacesMonitoring(ctx, src, &netmonitor.Options{Link: &netmonitor.OptionsLink{Create: true}}))
+	}()
+
+	gw := types.NamespacedName{Name: r.Options.GwOptions.Name, Namespace: r.Options.GwOptions.Namespace}
+	enqueuer := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: gw}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlGatewayQoS).
+		For(obj, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == gw.Name && object.GetNamespace() == gw.Namespace
+		}))).
+		WatchesRawSource(source.Channel(src, enqueuer)).
+		Complete(r)
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
@@ -52,6 +53,9 @@ type certificateIdentityProvider struct {
 	cl               client.Client
 	cnf              *rest.Config
 	csrWatcher       certificateSigningRequest.Watcher
+
+	// certificateLifetime is the requested validity of the issued certificates (zero means the signer default).
+	certificateLifetime time.Duration
 }
 
 // GetRemoteCertificate retrieves a certificate issued in the past,
@@ -125,6 +129,10 @@ func (identityProvider *certificateIdentityProvider) ApproveSigningRequest(ctx c
 		},
 	}
 
+	if identityProvider.certificateLifetime > 0 {
+		cert.Spec.ExpirationSeconds = ptr.To(int32(identityProvider.certificateLifetime.Seconds()))
+	}
+
 	cert, err = identityProvider.k8sClient.CertificatesV1().CertificateSigningRequests().Create(ctx, cert, metav1.CreateOptions{})
 	if err != nil {
 		klog.Error(err)
@@ -58,4 +58,11 @@ const (
 	AwsEKSClusterIDSecretKey = "awsEksClusterID" //nolint:gosec // not a credential
 	// AwsIAMUserArnSecretKey is the key used for the AWS IAM user ARN inside the secret.
 	AwsIAMUserArnSecretKey = "awsIamUserArn" //nolint:gosec // not a credential
+
+	// OIDCTokenSecretKey is the key used for the OIDC token inside the secret.
+	OIDCTokenSecretKey = "oidcToken" //nolint:gosec // not a credential
+	// OIDCIssueTimeSecretKey is the key used for the issue time of the OIDC token inside the secret.
+	OIDCIssueTimeSecretKey = "oidcIssueTime"
+	// OIDCExpirationTimeSecretKey is the key used for the expiration time of the OIDC token inside the secret.
+	OIDCExpirationTimeSecretKey = "oidcExpirationTime"
 )
@@ -16,6 +16,7 @@ package identitymanager
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -29,6 +30,7 @@ import (
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils/csr"
+	"github.com/liqotech/liqo/pkg/utils/oidc"
 )
 
 var _ IdentityManager = &identityManager{}
@@ -65,9 +67,11 @@ func NewCertificateIdentityManager(ctx context.Context,
 }
 
 // NewCertificateIdentityProvider gets a new certificate identity approver.
+// A non-zero certificateLifetime overrides the validity of the issued certificates.
 func NewCertificateIdentityProvider(ctx context.Context, cl client.Client, k8sClient kubernetes.Interface,
 	cnf *rest.Config,
-	localCluster liqov1beta1.ClusterID, namespaceManager tenantnamespace.Manager) IdentityProvider {
+	localCluster liqov1beta1.ClusterID, namespaceManager tenantnamespace.Manager,
+	certificateLifetime time.Duration) IdentityProvider {
 	req, err := labels.NewRequirement(remoteTenantCSRLabel, selection.Exists, []string{})
 	utilruntime.Must(err)
 
@@ -79,6 +83,8 @@ func NewCertificateIdentityProvider(ctx context.Context, cl client.Client, k8sCl
 		cl:               cl,
 		cnf:              cnf,
 		csrWatcher:       csrWatcher,
+
+		certificateLifetime: certificateLifetime,
 	}
 
 	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
@@ -99,6 +105,21 @@ func NewIAMIdentityProvider(ctx context.Context, cl client.Client, k8sClient kub
 	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
 }
 
+// NewOIDCIdentityProvider gets a new identity approver to handle identities backed by OIDC tokens.
+func NewOIDCIdentityProvider(ctx context.Context, cl client.Client, k8sClient kubernetes.Interface,
+	cnf *rest.Config, localCluster liqov1beta1.ClusterID, localOIDCConfig *LocalOIDCConfig,
+	namespaceManager tenantnamespace.Manager) IdentityProvider {
+	idProvider := &oidcIdentityProvider{
+		localOIDCConfig: localOIDCConfig,
+		oidcClient: oidc.NewClient(localOIDCConfig.IssuerURL, localOIDCConfig.ClientID,
+			localOIDCConfig.ClientSecret, localOIDCConfig.TokenEndpoint, nil),
+		cl:  cl,
+		cnf: cnf,
+	}
+
+	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
+}
+
 func newIdentityManager(ctx context.Context,
 	cl client.Client, k8sClient kubernetes.Interface,
 	localCluster liqov1beta1.ClusterID,
@@ -94,7 +94,7 @@ var _ = BeforeSuite(func() {
 
 	namespaceManager = tenantnamespace.NewManager(k8sClient, cl.Scheme())
 	identityMan = NewCertificateIdentityManager(ctx, cl, cluster.GetClient(), cluster.GetCfg(), localCluster, namespaceManager)
-	identityProvider = NewCertificateIdentityProvider(ctx, cl, cluster.GetClient(), cluster.GetCfg(), localCluster, namespaceManager)
+	identityProvider = NewCertificateIdentityProvider(ctx, cl, cluster.GetClient(), cluster.GetCfg(), localCluster, namespaceManager, 0)
 
 	namespace, err = namespaceManager.CreateNamespace(ctx, remoteCluster)
 	Expect(err).ToNot(HaveOccurred())
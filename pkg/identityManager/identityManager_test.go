@@ -15,12 +15,20 @@
 package identitymanager
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	idManTest "github.com/liqotech/liqo/pkg/identityManager/testUtils"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/utils/csr"
+	"github.com/liqotech/liqo/pkg/utils/oidc"
+	oidcfake "github.com/liqotech/liqo/pkg/utils/oidc/fake"
 )
 
 var _ = Describe("IdentityManager", func() {
@@ -77,7 +85,7 @@ var _ = Describe("IdentityManager", func() {
 		It("Certificate Identity Provider", func() {
 			idProvider := NewCertificateIdentityProvider(ctx,
 				mgr.GetClient(), cluster.GetClient(), cluster.GetCfg(),
-				localCluster, namespaceManager)
+				localCluster, namespaceManager, 0)
 
 			certIDManager, ok := idProvider.(*identityManager)
 			Expect(ok).To(BeTrue())
@@ -88,4 +96,96 @@ var _ = Describe("IdentityManager", func() {
 
 	})
 
+	Context("OIDC Identity Provider", Ordered, func() {
+
+		var (
+			csrBytes   []byte
+			err        error
+			server     *oidcfake.Server
+			cl         client.Client
+			idProvider *oidcIdentityProvider
+			opts       *SigningRequestOptions
+		)
+
+		BeforeAll(func() {
+			_, csrBytes, err = csr.NewKeyAndRequest("foobar")
+			Expect(err).To(BeNil())
+
+			server = oidcfake.NewServer("liqo", "secret", time.Hour)
+			cl = fake.NewClientBuilder().Build()
+			config := &LocalOIDCConfig{
+				IssuerURL:    server.URL,
+				ClientID:     "liqo",
+				ClientSecret: "secret",
+				Audience:     "kubernetes",
+			}
+			idProvider = &oidcIdentityProvider{
+				localOIDCConfig: config,
+				oidcClient:      oidc.NewClient(config.IssuerURL, config.ClientID, config.ClientSecret, "", nil),
+				cl:              cl,
+			}
+			opts = &SigningRequestOptions{
+				Cluster:         remoteCluster,
+				SigningRequest:  csrBytes,
+				IdentityType:    authv1beta1.ControlPlaneIdentityType,
+				TenantNamespace: "tenant-namespace",
+			}
+		})
+
+		AfterAll(func() {
+			server.Close()
+		})
+
+		It("Approve Signing Request", func() {
+			resp, err := idProvider.ApproveSigningRequest(ctx, opts)
+			Expect(err).To(BeNil())
+			Expect(resp.OIDCIdentityResponse.Token).ToNot(BeEmpty())
+
+			issued := server.Issued()
+			Expect(issued).To(HaveLen(1))
+			Expect(issued[0].Subject).To(Equal(authentication.CommonNameControlPlaneCSR(remoteCluster)))
+			Expect(issued[0].Groups).To(ConsistOf(authentication.OrganizationControlPlaneCSR()))
+			Expect(issued[0].Audience).To(Equal("kubernetes"))
+			Expect(resp.OIDCIdentityResponse.Token).To(Equal(issued[0].Token))
+		})
+
+		It("Retrieve Remote Token", func() {
+			resp, err := idProvider.GetRemoteCertificate(ctx, opts)
+			Expect(err).To(BeNil())
+			Expect(resp.OIDCIdentityResponse.Token).To(Equal(server.Issued()[0].Token))
+			Expect(resp.OIDCIdentityResponse.ExpirationTime).To(BeTemporally(">", time.Now()))
+		})
+
+		It("Reject a different CSR", func() {
+			_, otherCSR, err := csr.NewKeyAndRequest("other")
+			Expect(err).To(BeNil())
+			otherOpts := *opts
+			otherOpts.SigningRequest = otherCSR
+			_, err = idProvider.GetRemoteCertificate(ctx, &otherOpts)
+			Expect(err).To(MatchError(NotMatchingCSRError))
+		})
+
+		It("Issue a new token when the stored one is expired", func() {
+			var secret corev1.Secret
+			Expect(cl.Get(ctx, client.ObjectKey{Namespace: opts.TenantNamespace, Name: remoteCertificateSecretName(opts)}, &secret)).To(Succeed())
+			secret.Data[OIDCExpirationTimeSecretKey] = []byte(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))
+			Expect(cl.Update(ctx, &secret)).To(Succeed())
+
+			authParams, err := idProvider.ForgeAuthParams(ctx, &SigningRequestOptions{
+				Cluster:                  opts.Cluster,
+				SigningRequest:           opts.SigningRequest,
+				IdentityType:             opts.IdentityType,
+				TenantNamespace:          opts.TenantNamespace,
+				APIServerAddressOverride: "https://example.com:6443",
+				CAOverride:               []byte("ca"),
+			})
+			Expect(err).To(BeNil())
+			Expect(server.Issued()).To(HaveLen(2))
+			Expect(authParams.SignedCRT).To(BeEmpty())
+			Expect(authParams.OIDCConfig).ToNot(BeNil())
+			Expect(authParams.OIDCConfig.Token).To(Equal(server.Issued()[1].Token))
+		})
+
+	})
+
 })
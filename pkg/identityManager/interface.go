@@ -75,3 +75,4 @@ type IdentityProvider interface {
 
 var _ IdentityProvider = &certificateIdentityProvider{}
 var _ IdentityProvider = &iamIdentityProvider{}
+var _ IdentityProvider = &oidcIdentityProvider{}
@@ -0,0 +1,220 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identitymanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	responsetypes "github.com/liqotech/liqo/pkg/identityManager/responseTypes"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/utils/apiserver"
+	"github.com/liqotech/liqo/pkg/utils/oidc"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+type oidcIdentityProvider struct {
+	localOIDCConfig *LocalOIDCConfig
+	oidcClient      *oidc.Client
+	cl              client.Client
+	cnf             *rest.Config
+}
+
+// GetRemoteCertificate retrieves a token issued in the past, given the clusterid and the signingRequest.
+// A NotFound error is returned if the stored token is expired, so that a new one is issued.
+func (identityProvider *oidcIdentityProvider) GetRemoteCertificate(ctx context.Context,
+	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
+	response = &responsetypes.SigningRequestResponse{
+		ResponseType: responsetypes.SigningRequestResponseOIDC,
+	}
+
+	secretName := remoteCertificateSecretName(options)
+	var secret corev1.Secret
+	if err := identityProvider.cl.Get(ctx, types.NamespacedName{
+		Namespace: options.TenantNamespace,
+		Name:      secretName,
+	}, &secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			klog.V(4).Info(err)
+		} else {
+			klog.Error(err)
+		}
+		return response, err
+	}
+
+	notFound := kerrors.NewNotFound(schema.GroupResource{
+		Group:    "v1",
+		Resource: "secrets",
+	}, secretName)
+
+	if !bytes.Equal(secret.Data[csrSecretKey], options.SigningRequest) {
+		klog.Errorf("the stored and the provided CSR for cluster %s does not match", options.Cluster)
+		return response, NotMatchingCSRError
+	}
+
+	token, ok := secret.Data[OIDCTokenSecretKey]
+	if !ok || len(token) == 0 {
+		klog.Errorf("no %v key in secret %v/%v", OIDCTokenSecretKey, secret.Namespace, secret.Name)
+		return response, notFound
+	}
+
+	issueTime, err := time.Parse(time.RFC3339, string(secret.Data[OIDCIssueTimeSecretKey]))
+	if err != nil {
+		klog.Errorf("invalid %v key in secret %v/%v: %v", OIDCIssueTimeSecretKey, secret.Namespace, secret.Name, err)
+		return response, notFound
+	}
+	expirationTime, err := time.Parse(time.RFC3339, string(secret.Data[OIDCExpirationTimeSecretKey]))
+	if err != nil {
+		klog.Errorf("invalid %v key in secret %v/%v: %v", OIDCExpirationTimeSecretKey, secret.Namespace, secret.Name, err)
+		return response, notFound
+	}
+	if !time.Now().Before(expirationTime) {
+		klog.V(4).Infof("the OIDC token stored in secret %v/%v is expired", secret.Namespace, secret.Name)
+		return response, notFound
+	}
+
+	response.OIDCIdentityResponse = responsetypes.OIDCIdentityResponse{
+		Token:          string(token),
+		IssueTime:      issueTime,
+		ExpirationTime: expirationTime,
+	}
+	return response, nil
+}
+
+// ApproveSigningRequest requests a new token to the OpenID Connect provider, for the user
+// and the group that would have been encoded in the certificate of the remote cluster.
+func (identityProvider *oidcIdentityProvider) ApproveSigningRequest(ctx context.Context,
+	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
+	var username string
+	var organization string
+
+	switch options.IdentityType {
+	case authv1beta1.ControlPlaneIdentityType:
+		username = authentication.CommonNameControlPlaneCSR(options.Cluster)
+		organization = authentication.OrganizationControlPlaneCSR()
+	case authv1beta1.ResourceSliceIdentityType:
+		if options.ResourceSlice == nil {
+			klog.Error("resource slice is nil")
+			return response, fmt.Errorf("resource slice is nil")
+		}
+
+		username = authentication.CommonNameResourceSliceCSR(options.ResourceSlice)
+		organization = authentication.OrganizationResourceSliceCSR(options.ResourceSlice)
+	default:
+		klog.Errorf("identity type %v not supported", options.IdentityType)
+		return response, fmt.Errorf("identity type %v not supported", options.IdentityType)
+	}
+
+	token, err := identityProvider.oidcClient.IssueToken(ctx, &oidc.TokenRequest{
+		Subject:  username,
+		Groups:   []string{organization},
+		Audience: identityProvider.localOIDCConfig.Audience,
+	})
+	if err != nil {
+		klog.Error(err)
+		return response, err
+	}
+	klog.V(4).Infof("OIDC token issued for user %s, expiring at %s", username, token.ExpirationTime.Format(time.RFC3339))
+
+	if _, err = identityProvider.storeRemoteToken(ctx, token, options); err != nil {
+		klog.Error(err)
+		return response, err
+	}
+
+	return &responsetypes.SigningRequestResponse{
+		ResponseType: responsetypes.SigningRequestResponseOIDC,
+		OIDCIdentityResponse: responsetypes.OIDCIdentityResponse{
+			Token:          token.Value,
+			IssueTime:      token.IssueTime,
+			ExpirationTime: token.ExpirationTime,
+		},
+	}, nil
+}
+
+func (identityProvider *oidcIdentityProvider) ForgeAuthParams(ctx context.Context,
+	options *SigningRequestOptions) (*authv1beta1.AuthParams, error) {
+	resp, err := EnsureCertificate(ctx, identityProvider, options)
+	if err != nil {
+		return nil, err
+	}
+
+	apiServer, err := apiserver.GetURL(ctx, identityProvider.cl, options.APIServerAddressOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := apiserver.RetrieveAPIServerCA(identityProvider.cnf,
+		options.CAOverride, options.TrustedCA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authv1beta1.AuthParams{
+		CA:        ca,
+		APIServer: apiServer,
+		ProxyURL:  options.ProxyURL,
+		OIDCConfig: &authv1beta1.OIDCConfig{
+			Token:          resp.OIDCIdentityResponse.Token,
+			IssueTime:      metav1.NewTime(resp.OIDCIdentityResponse.IssueTime),
+			ExpirationTime: metav1.NewTime(resp.OIDCIdentityResponse.ExpirationTime),
+		},
+	}, nil
+}
+
+// storeRemoteToken stores the issued token in a Secret in the TenantNamespace.
+func (identityProvider *oidcIdentityProvider) storeRemoteToken(ctx context.Context,
+	token *oidc.Token, options *SigningRequestOptions) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      remoteCertificateSecretName(options),
+			Namespace: options.TenantNamespace,
+		},
+	}
+
+	_, err := resource.CreateOrUpdate(ctx, identityProvider.cl, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[consts.RemoteClusterID] = string(options.Cluster)
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[csrSecretKey] = options.SigningRequest
+		secret.Data[OIDCTokenSecretKey] = []byte(token.Value)
+		secret.Data[OIDCIssueTimeSecretKey] = []byte(token.IssueTime.UTC().Format(time.RFC3339))
+		secret.Data[OIDCExpirationTimeSecretKey] = []byte(token.ExpirationTime.UTC().Format(time.RFC3339))
+
+		return nil
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	return secret, nil
+}
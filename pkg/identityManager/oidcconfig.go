@@ -0,0 +1,32 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identitymanager
+
+// LocalOIDCConfig contains the configuration of the OpenID Connect provider trusted by the local API server,
+// and the credentials of the client used by Liqo to request tokens on behalf of the remote clusters.
+type LocalOIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// Audience is the audience requested for the issued tokens, if required by the provider.
+	Audience string
+	// TokenEndpoint overrides the token endpoint discovered from the issuer metadata.
+	TokenEndpoint string
+}
+
+// IsEmpty indicates that some of the required values is not set.
+func (oc *LocalOIDCConfig) IsEmpty() bool {
+	return oc == nil || oc.IssuerURL == "" || oc.ClientID == "" || oc.ClientSecret == ""
+}
@@ -14,6 +14,8 @@
 
 package responsetypes
 
+import "time"
+
 // SigningRequestResponseType indicates the type for a signign request response.
 type SigningRequestResponseType string
 
@@ -23,6 +25,8 @@ const (
 	SigningRequestResponseCertificate SigningRequestResponseType = "Certificate"
 	// SigningRequestResponseIAM indicates that the identity has been validated by the Amazon IAM service.
 	SigningRequestResponseIAM SigningRequestResponseType = "IAM"
+	// SigningRequestResponseOIDC indicates that the identity is a token issued by an OpenID Connect provider.
+	SigningRequestResponseOIDC SigningRequestResponseType = "OIDC"
 )
 
 // AwsIdentityResponse contains the information about the created IAM user and the EKS cluster.
@@ -36,6 +40,13 @@ type AwsIdentityResponse struct {
 	Region                             string
 }
 
+// OIDCIdentityResponse contains the token issued by the OpenID Connect provider.
+type OIDCIdentityResponse struct {
+	Token          string
+	IssueTime      time.Time
+	ExpirationTime time.Time
+}
+
 // SigningRequestResponse contains the response from an Indentity Provider.
 type SigningRequestResponse struct {
 	ResponseType SigningRequestResponseType
@@ -43,4 +54,6 @@ type SigningRequestResponse struct {
 	Certificate []byte
 
 	AwsIdentityResponse AwsIdentityResponse
+
+	OIDCIdentityResponse OIDCIdentityResponse
 }
@@ -55,11 +55,17 @@ func CommonNameResourceSliceCSR(resourceSlice *authv1beta1.ResourceSlice) string
 		panic("resource slice without consumer cluster ID")
 	}
 
+	return CommonNameResourceSlice(resourceSlice.Name, *clusterID)
+}
+
+// CommonNameResourceSlice returns the common name for the identity of the ResourceSlice
+// with the given name, issued to the given consumer cluster.
+func CommonNameResourceSlice(name string, clusterID liqov1beta1.ClusterID) string {
 	hash := sha256.New()
-	hash.Write([]byte(*clusterID))
+	hash.Write([]byte(clusterID))
 	h := hash.Sum(nil)
 
-	return fmt.Sprintf("%s-%x", resourceSlice.Name, h[:6])
+	return fmt.Sprintf("%s-%x", name, h[:6])
 }
 
 // OrganizationResourceSliceCSR returns the organization for a resource slice CSR.
@@ -92,6 +98,11 @@ func commonNamePeerUser(clusterID liqov1beta1.ClusterID) (string, error) {
 	return fmt.Sprintf("liqo-peer-user-%s-%x", clusterID, randSuffix), nil
 }
 
+// PeeringUserName returns the name identifying the resources which grant permissions to the peering user of the given cluster.
+func PeeringUserName(clusterID liqov1beta1.ClusterID) string {
+	return fmt.Sprintf("liqo-peer-user-%s", clusterID)
+}
+
 // CommonNameControlPlaneCSR returns the common name for a control plane CSR.
 func CommonNameControlPlaneCSR(clusterID liqov1beta1.ClusterID) string {
 	return string(clusterID)
@@ -29,6 +29,8 @@ import (
 type ResourceSliceOptions struct {
 	Class     authv1beta1.ResourceSliceClass
 	Resources map[corev1.ResourceName]string
+	// MaxResources enables the autoscaling of the listed resources, between the requested and the maximum quantities.
+	MaxResources map[corev1.ResourceName]string
 }
 
 // ResourceSlice forges a ResourceSlice resource.
@@ -67,11 +69,29 @@ func MutateResourceSlice(resourceSlice *authv1beta1.ResourceSlice, remoteCluster
 		return err
 	}
 
+	maxRl, err := resourceList(opts.MaxResources)
+	if err != nil {
+		return err
+	}
+
 	resourceSlice.Spec = authv1beta1.ResourceSliceSpec{
 		Class:             opts.Class,
 		ProviderClusterID: ptr.To(remoteClusterID),
 		Resources:         rl,
 	}
+
+	if len(maxRl) > 0 {
+		minRl := corev1.ResourceList{}
+		for name := range maxRl {
+			if quantity, ok := rl[name]; ok {
+				minRl[name] = quantity
+			}
+		}
+		resourceSlice.Spec.Autoscaling = &authv1beta1.ResourceSliceAutoscaling{
+			MinResources: minRl,
+			MaxResources: maxRl,
+		}
+	}
 	return nil
 }
 
@@ -62,11 +62,22 @@ func MutateTenant(tenant *authv1beta1.Tenant, remoteClusterID liqov1beta1.Cluste
 		proxyURLPtr = proxyURL
 	}
 
-	tenant.Spec = authv1beta1.TenantSpec{
+	MutateTenantSpec(tenant, &authv1beta1.TenantSpec{
 		ClusterID: remoteClusterID,
 		PublicKey: publicKey,
 		CSR:       csr,
 		Signature: signature,
 		ProxyURL:  proxyURLPtr,
-	}
+	})
+}
+
+// MutateTenantSpec sets the spec of a Tenant to the one generated by the tenant cluster, preserving the fields
+// managed by the administrator of the cluster hosting the Tenant (i.e., the authorization policy, the tenant
+// condition and the revoked identities), which would otherwise be reset whenever the Tenant is applied again.
+func MutateTenantSpec(tenant *authv1beta1.Tenant, spec *authv1beta1.TenantSpec) {
+	current := tenant.Spec
+	tenant.Spec = *spec.DeepCopy()
+	tenant.Spec.AuthzPolicy = current.AuthzPolicy
+	tenant.Spec.TenantCondition = current.TenantCondition
+	tenant.Spec.RevokedIdentities = current.RevokedIdentities
 }
@@ -0,0 +1,74 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+const (
+	// CredentialsLabelKey labels the Secrets recording the credentials issued through an invitation.
+	CredentialsLabelKey = "liqo.io/invitation-credentials"
+	// CredentialsExpirationAnnotationKey is the annotation storing the expiration time of the credentials
+	// recorded by a Secret, after which the credentials are revoked.
+	CredentialsExpirationAnnotationKey = "liqo.io/invitation-credentials-expiration"
+	// CredentialsFinalizer ensures that the credentials recorded by a Secret are revoked when the Secret is deleted.
+	CredentialsFinalizer = "invitation.liqo.io/credentials"
+)
+
+// CredentialsSecretName returns the name of the Secret recording the credentials issued to the given consumer cluster.
+func CredentialsSecretName(consumerClusterID liqov1beta1.ClusterID) string {
+	return fmt.Sprintf("liqo-invitation-credentials-%s", consumerClusterID)
+}
+
+// CredentialsSecret forges the Secret recording the credentials issued to the given consumer cluster through an invitation.
+// The credentials are revoked once expired, or when the Secret is deleted (e.g., by the consumer cluster, as the
+// corresponding PeeringRequest is deleted).
+func CredentialsSecret(consumerClusterID liqov1beta1.ClusterID, tenantNamespace string, expiration metav1.Time) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialsSecretName(consumerClusterID),
+			Namespace: tenantNamespace,
+			Labels: map[string]string{
+				CredentialsLabelKey:    "true",
+				consts.RemoteClusterID: string(consumerClusterID),
+			},
+			Annotations: map[string]string{
+				CredentialsExpirationAnnotationKey: expiration.UTC().Format(time.RFC3339),
+			},
+			Finalizers: []string{CredentialsFinalizer},
+		},
+	}
+}
+
+// CredentialsExpiration returns the expiration time of the credentials recorded by the given Secret.
+func CredentialsExpiration(secret *corev1.Secret) (time.Time, error) {
+	value, found := secret.Annotations[CredentialsExpirationAnnotationKey]
+	if !found {
+		return time.Time{}, fmt.Errorf("missing the %q annotation", CredentialsExpirationAnnotationKey)
+	}
+	expiration, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %q annotation: %w", CredentialsExpirationAnnotationKey, err)
+	}
+	return expiration, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// TokenKey is the key of the Secret field containing the invitation token.
+const TokenKey = "token"
+
+// tokenSeparator separates the payload and the signature of the token.
+const tokenSeparator = "."
+
+var (
+	// ErrExpired is returned when the invitation is expired.
+	ErrExpired = errors.New("the invitation is expired")
+	// ErrCredentialsExpired is returned when the credentials carried by the invitation are expired.
+	ErrCredentialsExpired = errors.New("the invitation credentials are expired")
+)
+
+// Invitation contains the information issued by a provider cluster to let a given consumer cluster peer with it.
+type Invitation struct {
+	// ProviderClusterID is the cluster ID of the cluster that issued the invitation.
+	ProviderClusterID liqov1beta1.ClusterID `json:"providerClusterID"`
+	// ConsumerClusterID is the cluster ID of the cluster allowed to use the invitation.
+	ConsumerClusterID liqov1beta1.ClusterID `json:"consumerClusterID"`
+	// LiqoNamespace is the namespace where Liqo is installed in the provider cluster.
+	LiqoNamespace string `json:"liqoNamespace"`
+	// TenantNamespace is the namespace of the provider cluster dedicated to the consumer cluster,
+	// hosting the Secret which records the credentials carried by the invitation.
+	TenantNamespace string `json:"tenantNamespace"`
+	// Kubeconfig is the kubeconfig of the peering user, granting the minimum permissions to peer with the provider cluster.
+	Kubeconfig []byte `json:"kubeconfig"`
+	// PublicKey is the PKIX-encoded authentication public key of the provider cluster, used to verify the token signature.
+	// It is trusted only if it matches the fingerprint the consumer cluster obtained from the provider out of band.
+	PublicKey []byte `json:"publicKey"`
+	// ExpirationTime is the time after which the invitation can no longer be used to establish a new peering.
+	ExpirationTime metav1.Time `json:"expirationTime"`
+	// CredentialsExpirationTime is the time after which the credentials carried by the invitation are no longer valid,
+	// hence the peering can no longer be reconfigured. It is never before the expiration time of the invitation.
+	CredentialsExpirationTime metav1.Time `json:"credentialsExpirationTime"`
+}
+
+// Sign encodes the invitation and signs it with the given private key, returning the resulting token.
+func Sign(invitation *Invitation, privateKey crypto.PrivateKey) (string, error) {
+	payload, err := json.Marshal(invitation)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the invitation: %w", err)
+	}
+
+	signature, err := authentication.SignNonce(privateKey, payload)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign the invitation: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + tokenSeparator + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Fingerprint returns the fingerprint (i.e., the hex-encoded SHA-256 digest) of the given PKIX-encoded public key.
+func Fingerprint(publicKey []byte) string {
+	digest := sha256.Sum256(publicKey)
+	return hex.EncodeToString(digest[:])
+}
+
+// Parse decodes the given token and verifies its signature against the public key it carries, which must match
+// the given fingerprint. The fingerprint is the trust anchor of the invitation: since anyone can sign a token with
+// a key of their own, it must be obtained from the provider cluster through a channel other than the token itself.
+// It does not check the expiration time and the consumer cluster ID, which are checked by Validate.
+func Parse(token, fingerprint string) (*Invitation, error) {
+	if fingerprint == "" {
+		return nil, fmt.Errorf("the fingerprint of the provider public key is required to verify the invitation")
+	}
+
+	encodedPayload, encodedSignature, found := strings.Cut(strings.TrimSpace(token), tokenSeparator)
+	if !found {
+		return nil, fmt.Errorf("malformed invitation token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the invitation payload: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the invitation signature: %w", err)
+	}
+
+	var invitation Invitation
+	if err := json.Unmarshal(payload, &invitation); err != nil {
+		return nil, fmt.Errorf("unable to decode the invitation: %w", err)
+	}
+
+	expected, actual := strings.ToLower(strings.TrimSpace(fingerprint)), Fingerprint(invitation.PublicKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) != 1 {
+		return nil, fmt.Errorf("the invitation public key does not match the trusted fingerprint")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(invitation.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the invitation public key: %w", err)
+	}
+
+	valid, err := authentication.VerifyNonce(publicKey, payload, signature)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the invitation signature: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid invitation signature")
+	}
+
+	return &invitation, nil
+}
+
+// Validate checks that the invitation has been issued for the given consumer cluster, and that neither its credentials
+// (ErrCredentialsExpired) nor the invitation itself (ErrExpired) are expired.
+func (i *Invitation) Validate(consumerClusterID liqov1beta1.ClusterID, now time.Time) error {
+	if i.ConsumerClusterID != consumerClusterID {
+		return fmt.Errorf("the invitation has been issued for cluster %q, not for %q", i.ConsumerClusterID, consumerClusterID)
+	}
+	if !now.Before(i.CredentialsExpirationTime.Time) {
+		return fmt.Errorf("%w (expired at %s)", ErrCredentialsExpired, i.CredentialsExpirationTime.Format(time.RFC3339))
+	}
+	if !now.Before(i.ExpirationTime.Time) {
+		return fmt.Errorf("%w (expired at %s)", ErrExpired, i.ExpirationTime.Format(time.RFC3339))
+	}
+	return nil
+}
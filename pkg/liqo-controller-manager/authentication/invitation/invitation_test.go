@@ -0,0 +1,155 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Invitation tokens", func() {
+	var (
+		now        time.Time
+		privateKey crypto.PrivateKey
+		invitation *Invitation
+	)
+
+	forgeInvitation := func(public crypto.PublicKey) *Invitation {
+		publicKeyDER, err := x509.MarshalPKIXPublicKey(public)
+		Expect(err).ToNot(HaveOccurred())
+		return &Invitation{
+			ProviderClusterID: "provider",
+			ConsumerClusterID: "consumer",
+			LiqoNamespace:     "liqo",
+			Kubeconfig:        []byte("kubeconfig"),
+			PublicKey:         publicKeyDER,
+			ExpirationTime:    metav1.NewTime(now.Add(time.Hour)),
+
+			CredentialsExpirationTime: metav1.NewTime(now.Add(24 * time.Hour)),
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		privateKey = private
+		invitation = forgeInvitation(public)
+	})
+
+	When("the token is signed with an Ed25519 key", func() {
+		It("should be parsed back to the same invitation", func() {
+			token, err := Sign(invitation, privateKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			parsed, err := Parse(token, Fingerprint(invitation.PublicKey))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.ProviderClusterID).To(BeEquivalentTo("provider"))
+			Expect(parsed.LiqoNamespace).To(Equal("liqo"))
+			Expect(parsed.Kubeconfig).To(Equal([]byte("kubeconfig")))
+			Expect(parsed.Validate("consumer", now)).To(Succeed())
+		})
+	})
+
+	When("the token is signed with an RSA key", func() {
+		It("should be parsed back to the same invitation", func() {
+			rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			invitation = forgeInvitation(&rsaKey.PublicKey)
+
+			token, err := Sign(invitation, rsaKey)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = Parse(token, Fingerprint(invitation.PublicKey))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("the token payload is tampered with", func() {
+		It("should fail the signature verification", func() {
+			token, err := Sign(invitation, privateKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			invitation.ConsumerClusterID = "attacker"
+			tampered, err := Sign(invitation, privateKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload, _, _ := strings.Cut(tampered, tokenSeparator)
+			_, signature, _ := strings.Cut(token, tokenSeparator)
+			_, err = Parse(payload+tokenSeparator+signature, Fingerprint(invitation.PublicKey))
+			Expect(err).To(MatchError(ContainSubstring("invalid invitation signature")))
+		})
+	})
+
+	When("the token is malformed", func() {
+		It("should return an error", func() {
+			_, err := Parse("not-a-token", Fingerprint(invitation.PublicKey))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the token is signed with a key other than the trusted one", func() {
+		It("should fail the fingerprint verification", func() {
+			trusted := Fingerprint(invitation.PublicKey)
+
+			public, private, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			token, err := Sign(forgeInvitation(public), private)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = Parse(token, trusted)
+			Expect(err).To(MatchError(ContainSubstring("does not match the trusted fingerprint")))
+		})
+	})
+
+	When("no fingerprint is provided", func() {
+		It("should refuse to parse the token", func() {
+			token, err := Sign(invitation, privateKey)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = Parse(token, "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the invitation is expired", func() {
+		It("should fail the validation", func() {
+			Expect(invitation.Validate("consumer", now.Add(2*time.Hour))).To(MatchError(ErrExpired))
+		})
+
+		It("should not report the credentials as expired, as they outlive the invitation", func() {
+			Expect(invitation.Validate("consumer", now.Add(2*time.Hour))).ToNot(MatchError(ErrCredentialsExpired))
+		})
+	})
+
+	When("the invitation credentials are expired", func() {
+		It("should fail the validation", func() {
+			Expect(invitation.Validate("consumer", now.Add(48*time.Hour))).To(MatchError(ErrCredentialsExpired))
+		})
+	})
+
+	When("the invitation has been issued for another cluster", func() {
+		It("should fail the validation", func() {
+			Expect(invitation.Validate("other", now)).To(HaveOccurred())
+		})
+	})
+})
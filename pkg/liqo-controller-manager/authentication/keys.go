@@ -23,6 +23,7 @@ import (
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 
@@ -82,6 +83,16 @@ func GenerateRSAKeys() (privateKey, publicKey []byte, err error) {
 	return privateKeyPEM, publicKeyPEM, nil
 }
 
+// GenerateNonce generates a random nonce for the authentication challenge of a consumer cluster.
+func GenerateNonce() (string, error) {
+	nonce := make([]byte, 64)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(nonce), nil
+}
+
 // SignNonce signs a nonce using the provided private key. The private key can be
 // ed25519.PrivateKey, *rsa.PrivateKey, or *ecdsa.PrivateKey. For RSA/ECDSA the nonce
 // is hashed with SHA-256 before signing.
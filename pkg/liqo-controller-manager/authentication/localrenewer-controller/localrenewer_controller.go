@@ -15,7 +15,6 @@
 package localrenewercontroller
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
@@ -32,6 +31,7 @@ import (
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
 	"github.com/liqotech/liqo/pkg/utils/events"
 )
 
@@ -158,8 +158,8 @@ func (r *LocalRenewerReconciler) handleRenewedAuthParams(ctx context.Context, id
 		return nil
 	}
 
-	// If the certificate is already up to date, skip.
-	if bytes.Equal(renew.Status.AuthParams.SignedCRT, identity.Spec.AuthParams.SignedCRT) {
+	// If the credentials are already up to date, skip.
+	if utils.SameCredentials(renew.Status.AuthParams, &identity.Spec.AuthParams) {
 		return nil
 	}
 
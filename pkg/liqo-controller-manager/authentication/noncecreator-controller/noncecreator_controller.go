@@ -16,8 +16,6 @@ package noncecreatorcontroller
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base64"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils"
 )
@@ -104,7 +103,7 @@ func (r *NonceCreatorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	nonce, err := generateNonce()
+	nonce, err := authentication.GenerateNonce()
 	if err != nil {
 		klog.Errorf("Unable to generate nonce: %s", err)
 		r.EventRecorder.Event(secret, "Warning", "NonceGenerationFailed", "Unable to generate nonce")
@@ -127,15 +126,6 @@ func (r *NonceCreatorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
-func generateNonce() (string, error) {
-	nonce := make([]byte, 64)
-	if _, err := rand.Read(nonce); err != nil {
-		return "", err
-	}
-
-	return base64.StdEncoding.EncodeToString(nonce), nil
-}
-
 // SetupWithManager register the NonceReconciler with the manager.
 func (r *NonceCreatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	req1, err := labels.NewRequirement(consts.NonceSecretLabelKey, selection.Exists, nil)
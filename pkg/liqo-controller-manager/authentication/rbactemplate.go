@@ -0,0 +1,109 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/component-helpers/auth/rbac/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// TenantRBACComponentValue is the value of the component label assigned to the ClusterRoles rendered
+// from the TenantRBACTemplates.
+const TenantRBACComponentValue = "tenant-rbac"
+
+// GetTenantRBACTemplate returns the TenantRBACTemplate applying to the given consumer cluster, or nil if none matches.
+// The templates explicitly listing the consumer take precedence over the catch-all ones, and ties are broken by name.
+func GetTenantRBACTemplate(ctx context.Context, cl client.Client,
+	consumerClusterID liqov1beta1.ClusterID) (*authv1beta1.TenantRBACTemplate, error) {
+	var templates authv1beta1.TenantRBACTemplateList
+	if err := cl.List(ctx, &templates); err != nil {
+		return nil, fmt.Errorf("unable to list the TenantRBACTemplates: %w", err)
+	}
+
+	sort.Slice(templates.Items, func(i, j int) bool {
+		return templates.Items[i].Name < templates.Items[j].Name
+	})
+
+	var fallback *authv1beta1.TenantRBACTemplate
+	for i := range templates.Items {
+		ids := templates.Items[i].Spec.ConsumerClusterIDs
+		switch {
+		case slices.Contains(ids, consumerClusterID):
+			return &templates.Items[i], nil
+		case len(ids) == 0 && fallback == nil:
+			fallback = &templates.Items[i]
+		}
+	}
+	return fallback, nil
+}
+
+// TenantControlPlaneClusterRoleName returns the name of the ClusterRole rendered for the control plane identity
+// of the given consumer cluster.
+func TenantControlPlaneClusterRoleName(consumerClusterID liqov1beta1.ClusterID) string {
+	return "liqo-tenant-controlplane-" + string(consumerClusterID)
+}
+
+// TenantOffloadedNamespaceClusterRoleName returns the name of the ClusterRole rendered for the identities
+// of the given consumer cluster in the offloaded namespaces.
+func TenantOffloadedNamespaceClusterRoleName(consumerClusterID liqov1beta1.ClusterID) string {
+	return "liqo-tenant-offloading-" + string(consumerClusterID)
+}
+
+// CheckTenantRBACRules returns an error if the given rules, rendered from a TenantRBACTemplate, grant any permission
+// not granted by the ceiling ClusterRoles, that is the default ones approved by the administrator at installation time.
+// Hence, the templates can only restrict the default permissions.
+func CheckTenantRBACRules(rules []rbacv1.PolicyRule, ceiling ...*rbacv1.ClusterRole) error {
+	var allowed []rbacv1.PolicyRule
+	for _, clusterRole := range ceiling {
+		allowed = append(allowed, clusterRole.Rules...)
+	}
+
+	if covered, uncovered := validation.Covers(allowed, rules); !covered {
+		return fmt.Errorf("the TenantRBACTemplate grants permissions exceeding the default ones: %v", uncovered)
+	}
+	return nil
+}
+
+// ForgeTenantClusterRole forges a ClusterRole rendered from a TenantRBACTemplate for the given consumer cluster.
+func ForgeTenantClusterRole(name string, consumerClusterID liqov1beta1.ClusterID, rules []rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				consts.K8sAppManagedByKey: consts.LiqoAppLabelValue,
+				consts.K8sAppComponentKey: TenantRBACComponentValue,
+				consts.RemoteClusterID:    string(consumerClusterID),
+			},
+		},
+		Rules: rules,
+	}
+
+	resource.AddGlobalLabels(clusterRole)
+	resource.AddGlobalAnnotations(clusterRole)
+
+	return clusterRole
+}
@@ -15,7 +15,6 @@
 package remoterenwercontroller
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
@@ -33,6 +32,7 @@ import (
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils/events"
 	"github.com/liqotech/liqo/pkg/utils/getters"
@@ -105,14 +105,14 @@ func (r *RemoteRenewerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	// If the Tenant's AuthParams are not yet populated, nothing to sync.
-	if tenant.Status.AuthParams == nil || len(tenant.Status.AuthParams.SignedCRT) == 0 {
+	if !utils.HasCredentials(tenant.Status.AuthParams) {
 		klog.V(4).Infof("Tenant %q AuthParams not yet available for Renew %q", tenant.Name, req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
 	// If the Renew's AuthParams are already in sync with the Tenant's, skip.
 	if renew.Status.AuthParams != nil &&
-		bytes.Equal(renew.Status.AuthParams.SignedCRT, tenant.Status.AuthParams.SignedCRT) {
+		utils.SameCredentials(renew.Status.AuthParams, tenant.Status.AuthParams) {
 		return ctrl.Result{}, nil
 	}
 
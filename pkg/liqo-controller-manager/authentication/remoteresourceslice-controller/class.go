@@ -0,0 +1,251 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	resourcehelper "k8s.io/component-helpers/resource"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+)
+
+// effectiveClass returns the class of the given ResourceSlice, mapping the empty one to the default class.
+func effectiveClass(resourceSlice *authv1beta1.ResourceSlice) authv1beta1.ResourceSliceClass {
+	if resourceSlice.Spec.Class == authv1beta1.ResourceSliceClassUnknown {
+		return authv1beta1.ResourceSliceClassDefault
+	}
+	return resourceSlice.Spec.Class
+}
+
+// getClassDefinition returns the ResourceSliceClassDefinition of the class of the given ResourceSlice,
+// or nil if the class has not been defined on the provider.
+func getClassDefinition(ctx context.Context, cl client.Client,
+	resourceSlice *authv1beta1.ResourceSlice) (*authv1beta1.ResourceSliceClassDefinition, error) {
+	class := effectiveClass(resourceSlice)
+	var classDefinition authv1beta1.ResourceSliceClassDefinition
+	if err := cl.Get(ctx, types.NamespacedName{Name: string(class)}, &classDefinition); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get the ResourceSliceClassDefinition %q: %w", class, err)
+	}
+	return &classDefinition, nil
+}
+
+// getClassCapacity returns the resources offered through the given class, i.e., the allocatable resources of the
+// ready and schedulable nodes selected by the class, scaled by the overprovisioning percentage, net of the resources
+// requested by the local pods already running on those nodes. The pods offloaded by the consumers are not subtracted,
+// as they are already accounted for by the resources granted through the ResourceSlices.
+func getClassCapacity(ctx context.Context, cl client.Client,
+	classDefinition *authv1beta1.ResourceSliceClassDefinition) (corev1.ResourceList, error) {
+	var nodes corev1.NodeList
+	if err := cl.List(ctx, &nodes, client.MatchingLabels(classDefinition.Spec.NodeSelector)); err != nil {
+		return nil, fmt.Errorf("unable to list the nodes of the ResourceSliceClassDefinition %q: %w", classDefinition.Name, err)
+	}
+
+	capacity, used := corev1.ResourceList{}, corev1.ResourceList{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !isNodeSelectedByClass(node, classDefinition) {
+			continue
+		}
+		addResources(capacity, node.Status.Allocatable)
+
+		nodeUsed, err := getLocalPodsRequests(ctx, cl, node)
+		if err != nil {
+			return nil, err
+		}
+		addResources(used, nodeUsed)
+	}
+
+	percentage := int64(classDefinition.Spec.OverprovisioningPercentage)
+	if percentage <= 0 {
+		percentage = 100
+	}
+	for name, quantity := range capacity {
+		scaled := resource.NewMilliQuantity(quantity.MilliValue()*percentage/100, quantity.Format)
+		scaled.Sub(used[name])
+		if scaled.Sign() < 0 {
+			scaled = resource.NewQuantity(0, quantity.Format)
+		}
+		capacity[name] = *scaled
+	}
+	return capacity, nil
+}
+
+// getLocalPodsRequests returns the resources requested by the local pods running on the given node,
+// i.e., excluding the terminated pods and the ones offloaded by the consumers through ShadowPods.
+func getLocalPodsRequests(ctx context.Context, cl client.Client, node *corev1.Node) (corev1.ResourceList, error) {
+	var pods corev1.PodList
+	if err := cl.List(ctx, &pods, client.MatchingFields{indexer.FieldNodeNameFromPod: node.Name}); err != nil {
+		return nil, fmt.Errorf("unable to list the pods running on node %q: %w", node.Name, err)
+	}
+
+	used := corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed ||
+			pod.Labels[consts.ManagedByLabelKey] == consts.ManagedByShadowPodValue {
+			continue
+		}
+		addResources(used, resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}))
+		addResources(used, corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(1, resource.DecimalSI)})
+	}
+	return used, nil
+}
+
+// addResources adds the given resources to the given list.
+func addResources(list, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		total := list[name].DeepCopy()
+		total.Add(quantity)
+		list[name] = total
+	}
+}
+
+// isNodeSelectedByClass returns whether the given node backs the given class: it must be a ready and schedulable
+// physical node, matching the node selector and whose taints are tolerated by the class.
+func isNodeSelectedByClass(node *corev1.Node, classDefinition *authv1beta1.ResourceSliceClassDefinition) bool {
+	if utils.IsVirtualNode(node) || node.Spec.Unschedulable || !utils.IsNodeReady(node) {
+		return false
+	}
+	if !labels.SelectorFromSet(classDefinition.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range classDefinition.Spec.Tolerations {
+			if classDefinition.Spec.Tolerations[j].ToleratesTaint(klog.Background(), taint, false) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// getClassGrantedResources returns the resources already granted through the other accepted ResourceSlices of the
+// same class, across all the consumers.
+func getClassGrantedResources(ctx context.Context, cl client.Client, resourceSlice *authv1beta1.ResourceSlice) (corev1.ResourceList, error) {
+	resourceSlices, err := getters.ListResourceSlicesByLabel(ctx, cl, corev1.NamespaceAll, liqolabels.RemoteLabelSelector())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the ResourceSlices: %w", err)
+	}
+
+	granted := corev1.ResourceList{}
+	for i := range resourceSlices {
+		rs := &resourceSlices[i]
+		if rs.UID == resourceSlice.UID || effectiveClass(rs) != effectiveClass(resourceSlice) {
+			continue
+		}
+		cond := authentication.GetCondition(rs, authv1beta1.ResourceSliceConditionTypeResources)
+		if cond == nil || cond.Status != authv1beta1.ResourceSliceConditionAccepted {
+			continue
+		}
+		addResources(granted, rs.Status.Resources)
+	}
+	return granted, nil
+}
+
+// checkClassCapacity returns the resources to be granted to a ResourceSlice of a defined class, given the capacity of
+// the class and the resources already granted through it. The resources not explicitly requested are set to the
+// minimum between the default quantity and the available one. A denial verdict is returned if no node backs the
+// class, or if the requested resources exceed the available ones. The requests of the autoscaled ResourceSlices,
+// instead, are capped to the available resources, since they are resized depending on the demand anyway.
+func checkClassCapacity(resourceSlice *authv1beta1.ResourceSlice, capacity, granted,
+	defaults corev1.ResourceList) (corev1.ResourceList, *policyVerdict) {
+	if len(capacity) == 0 {
+		return nil, &policyVerdict{
+			status:  authv1beta1.ResourceSliceConditionDenied,
+			reason:  "ResourceSliceClassCapacityExceeded",
+			message: fmt.Sprintf("no ready node backs the ResourceSlice class %q", effectiveClass(resourceSlice)),
+		}
+	}
+
+	available := corev1.ResourceList{}
+	for name, quantity := range capacity {
+		free := quantity.DeepCopy()
+		free.Sub(granted[name])
+		if free.Sign() < 0 {
+			free = *resource.NewQuantity(0, quantity.Format)
+		}
+		available[name] = free
+	}
+
+	requested := corev1.ResourceList{}
+	for name, quantity := range defaults {
+		free, ok := available[name]
+		if !ok {
+			continue
+		}
+		if free.Cmp(quantity) < 0 {
+			quantity = free
+		}
+		requested[name] = quantity
+	}
+	for name, quantity := range resourceSlice.Spec.Resources {
+		requested[name] = quantity
+	}
+
+	var exceeded []string
+	for name, quantity := range requested {
+		if quantity.IsZero() {
+			continue
+		}
+		free := available[name]
+		if quantity.Cmp(free) > 0 {
+			if resourceSlice.Spec.Autoscaling != nil {
+				requested[name] = free
+				continue
+			}
+			exceeded = append(exceeded, fmt.Sprintf("%s (requested %s, available %s)", name, quantity.String(), free.String()))
+		}
+	}
+	if len(exceeded) > 0 {
+		sort.Strings(exceeded)
+		return nil, &policyVerdict{
+			status: authv1beta1.ResourceSliceConditionDenied,
+			reason: "ResourceSliceClassCapacityExceeded",
+			message: fmt.Sprintf("the nodes of the ResourceSlice class %q do not provide enough resources: %s",
+				effectiveClass(resourceSlice), strings.Join(exceeded, ", ")),
+		}
+	}
+	return requested, nil
+}
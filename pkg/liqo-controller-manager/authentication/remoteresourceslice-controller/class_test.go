@@ -0,0 +1,268 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+)
+
+var _ = Describe("ResourceSliceClassDefinitions", func() {
+	var (
+		ctx             context.Context
+		scheme          *runtime.Scheme
+		classDefinition *authv1beta1.ResourceSliceClassDefinition
+	)
+
+	forgeNode := func(name string, nodeLabels map[string]string, cpu, memory string, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		classDefinition = &authv1beta1.ResourceSliceClassDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpu-nodes"},
+			Spec: authv1beta1.ResourceSliceClassDefinitionSpec{
+				NodeSelector:               map[string]string{"gpu": "true"},
+				OverprovisioningPercentage: 100,
+			},
+		}
+	})
+
+	Describe("computing the capacity of a class", func() {
+		var (
+			nodes []*corev1.Node
+			pods  []*corev1.Pod
+		)
+
+		forgePod := func(name, nodeName, cpu string, podLabels map[string]string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					}}},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+		}
+
+		BeforeEach(func() {
+			notReady := forgeNode("not-ready", map[string]string{"gpu": "true"}, "8", "8Gi")
+			notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+			cordoned := forgeNode("cordoned", map[string]string{"gpu": "true"}, "8", "8Gi")
+			cordoned.Spec.Unschedulable = true
+
+			nodes = []*corev1.Node{
+				forgeNode("gpu-1", map[string]string{"gpu": "true"}, "4", "8Gi"),
+				forgeNode("gpu-2", map[string]string{"gpu": "true"}, "2", "4Gi"),
+				forgeNode("tainted", map[string]string{"gpu": "true"}, "8", "8Gi",
+					corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}),
+				forgeNode("virtual", map[string]string{"gpu": "true", consts.TypeLabel: consts.TypeNode}, "100", "100Gi"),
+				forgeNode("cpu", map[string]string{"gpu": "false"}, "16", "32Gi"),
+				notReady, cordoned,
+			}
+			pods = nil
+		})
+
+		capacity := func() corev1.ResourceList {
+			builder := fake.NewClientBuilder().WithScheme(scheme).
+				WithIndex(&corev1.Pod{}, indexer.FieldNodeNameFromPod, indexer.ExtractNodeName)
+			for _, node := range nodes {
+				builder = builder.WithObjects(node)
+			}
+			for _, pod := range pods {
+				builder = builder.WithObjects(pod)
+			}
+			capacity, err := getClassCapacity(ctx, builder.Build(), classDefinition)
+			Expect(err).ToNot(HaveOccurred())
+			return capacity
+		}
+
+		It("should sum the allocatable resources of the selected nodes only", func() {
+			c := capacity()
+			Expect(c.Cpu().Cmp(resource.MustParse("6"))).To(BeZero())
+			Expect(c.Memory().Cmp(resource.MustParse("12Gi"))).To(BeZero())
+		})
+
+		It("should include the tainted nodes tolerated by the class", func() {
+			classDefinition.Spec.Tolerations = []corev1.Toleration{{
+				Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule,
+			}}
+			c := capacity()
+			Expect(c.Cpu().Cmp(resource.MustParse("14"))).To(BeZero())
+		})
+
+		It("should apply the overprovisioning percentage", func() {
+			classDefinition.Spec.OverprovisioningPercentage = 150
+			c := capacity()
+			Expect(c.Cpu().Cmp(resource.MustParse("9"))).To(BeZero())
+			Expect(c.Memory().Cmp(resource.MustParse("18Gi"))).To(BeZero())
+		})
+
+		It("should subtract the resources requested by the local pods running on the selected nodes", func() {
+			completed := forgePod("completed", "gpu-1", "1", nil)
+			completed.Status.Phase = corev1.PodSucceeded
+			pods = []*corev1.Pod{
+				forgePod("local", "gpu-1", "1500m", nil),
+				forgePod("other-node", "cpu", "4", nil),
+				forgePod("offloaded", "gpu-2", "1", map[string]string{consts.ManagedByLabelKey: consts.ManagedByShadowPodValue}),
+				completed,
+			}
+			c := capacity()
+			Expect(c.Cpu().Cmp(resource.MustParse("4500m"))).To(BeZero())
+			Expect(c.Memory().Cmp(resource.MustParse("12Gi"))).To(BeZero())
+		})
+	})
+
+	Describe("checking the capacity of a class", func() {
+		var resourceSlice *authv1beta1.ResourceSlice
+		capacity := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("16Gi")}
+		granted := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}
+		defaults := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}
+
+		BeforeEach(func() {
+			resourceSlice = &authv1beta1.ResourceSlice{Spec: authv1beta1.ResourceSliceSpec{Class: "gpu-nodes"}}
+		})
+
+		It("should cap the default quantities to the available resources", func() {
+			requested, verdict := checkClassCapacity(resourceSlice, capacity, granted, defaults)
+			Expect(verdict).To(BeNil())
+			Expect(requested.Cpu().Cmp(resource.MustParse("4"))).To(BeZero())
+			Expect(requested.Memory().Cmp(resource.MustParse("4Gi"))).To(BeZero())
+			Expect(requested).ToNot(HaveKey(corev1.ResourcePods))
+		})
+
+		It("should grant the requested resources when available", func() {
+			resourceSlice.Spec.Resources = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}
+			requested, verdict := checkClassCapacity(resourceSlice, capacity, granted, defaults)
+			Expect(verdict).To(BeNil())
+			Expect(requested.Cpu().Cmp(resource.MustParse("3"))).To(BeZero())
+		})
+
+		It("should deny the requests exceeding the available resources", func() {
+			resourceSlice.Spec.Resources = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5")}
+			_, verdict := checkClassCapacity(resourceSlice, capacity, granted, defaults)
+			Expect(verdict).ToNot(BeNil())
+			Expect(verdict.reason).To(Equal("ResourceSliceClassCapacityExceeded"))
+			Expect(verdict.message).To(ContainSubstring("available 4"))
+		})
+
+		It("should cap the requests of the autoscaled slices exceeding the available resources", func() {
+			resourceSlice.Spec.Resources = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5")}
+			resourceSlice.Spec.Autoscaling = &authv1beta1.ResourceSliceAutoscaling{
+				MaxResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16")},
+			}
+			requested, verdict := checkClassCapacity(resourceSlice, capacity, granted, defaults)
+			Expect(verdict).To(BeNil())
+			Expect(requested.Cpu().Cmp(resource.MustParse("4"))).To(BeZero())
+		})
+
+		It("should deny the requests when no node backs the class", func() {
+			_, verdict := checkClassCapacity(resourceSlice, corev1.ResourceList{}, granted, defaults)
+			Expect(verdict).ToNot(BeNil())
+			Expect(verdict.status).To(Equal(authv1beta1.ResourceSliceConditionDenied))
+		})
+	})
+	Describe("enqueuing the ResourceSlices as a node changes", func() {
+		var (
+			reconciler *RemoteResourceSliceReconciler
+			queue      workqueue.TypedRateLimitingInterface[reconcile.Request]
+			node       *corev1.Node
+		)
+
+		forgeResourceSlice := func(name string, class authv1beta1.ResourceSliceClass) *authv1beta1.ResourceSlice {
+			return &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "liqo-tenant-consumer",
+					Labels: map[string]string{consts.ReplicationStatusLabel: "true"}},
+				Spec: authv1beta1.ResourceSliceSpec{Class: class},
+			}
+		}
+
+		enqueued := func() []string {
+			var names []string
+			for queue.Len() > 0 {
+				req, _ := queue.Get()
+				names = append(names, req.Name)
+				queue.Done(req)
+			}
+			return names
+		}
+
+		BeforeEach(func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(classDefinition,
+				forgeResourceSlice("gpu", "gpu-nodes"), forgeResourceSlice("default", authv1beta1.ResourceSliceClassUnknown)).Build()
+			reconciler = &RemoteResourceSliceReconciler{Client: cl}
+			queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			node = forgeNode("node", map[string]string{"gpu": "true"}, "4", "8Gi")
+		})
+
+		AfterEach(func() { queue.ShutDown() })
+
+		It("should enqueue the slices of the classes selecting the node", func() {
+			reconciler.nodeResourceSlicesHandler().Create(ctx, event.TypedCreateEvent[client.Object]{Object: node}, queue)
+			Expect(enqueued()).To(ConsistOf("gpu"))
+		})
+
+		It("should enqueue the slices of the classes no longer selecting the node", func() {
+			updated := node.DeepCopy()
+			delete(updated.Labels, "gpu")
+			reconciler.nodeResourceSlicesHandler().Update(ctx, event.TypedUpdateEvent[client.Object]{ObjectOld: node, ObjectNew: updated}, queue)
+			Expect(enqueued()).To(ConsistOf("gpu"))
+		})
+
+		It("should not enqueue the slices of the classes never selecting the node", func() {
+			node.Labels = map[string]string{"gpu": "false"}
+			updated := node.DeepCopy()
+			updated.Labels["zone"] = "a"
+			reconciler.nodeResourceSlicesHandler().Update(ctx, event.TypedUpdateEvent[client.Object]{ObjectOld: node, ObjectNew: updated}, queue)
+			Expect(enqueued()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	resourcev1 "k8s.io/api/resource/v1"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/library"
+	"k8s.io/klog/v2"
+)
+
+// deviceVar is the name of the CEL variable representing the device evaluated by the selectors of a DeviceClass.
+const deviceVar = "device"
+
+// deviceEnv returns the CEL environment to evaluate the selectors of the DeviceClasses, exposing the device
+// (i.e., its driver, attributes and capacity) through the same variable available to the Kubernetes scheduler.
+var deviceEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(deviceVar, cel.MapType(cel.StringType, cel.DynType)),
+		library.Quantity(),
+		library.SemverLib(),
+	)
+})
+
+// deviceSelector selects the devices matching all the CEL selectors of a DeviceClass.
+type deviceSelector struct {
+	programs []cel.Program
+}
+
+// newDeviceSelector compiles the CEL selectors of the given DeviceClass.
+func newDeviceSelector(class *resourcev1.DeviceClass) (*deviceSelector, error) {
+	env, err := deviceEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CEL environment: %w", err)
+	}
+
+	selector := &deviceSelector{}
+	for i := range class.Spec.Selectors {
+		if class.Spec.Selectors[i].CEL == nil {
+			continue
+		}
+		ast, issues := env.Compile(class.Spec.Selectors[i].CEL.Expression)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("failed to compile the selector of DeviceClass %q: %w", class.GetName(), issues.Err())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the selector of DeviceClass %q: %w", class.GetName(), err)
+		}
+		selector.programs = append(selector.programs, program)
+	}
+	return selector, nil
+}
+
+// Matches returns whether the given device, exposed by the given driver, matches all the selectors.
+// The devices whose evaluation fails (e.g., as missing the attributes referenced by a selector) do not match.
+func (s *deviceSelector) Matches(driver string, device *resourcev1.Device) bool {
+	input := map[string]any{deviceVar: deviceObject(driver, device)}
+	for _, program := range s.programs {
+		result, _, err := program.Eval(input)
+		if err != nil {
+			klog.V(4).Infof("Failed to evaluate the selector for device %q of driver %q: %v", device.Name, driver, err)
+			return false
+		}
+		if result != types.True {
+			return false
+		}
+	}
+	return true
+}
+
+// deviceObject returns the representation of the given device evaluated by the selectors, where the attributes
+// and the capacities are grouped by domain, and the unqualified names belong to the domain of the driver.
+func deviceObject(driver string, device *resourcev1.Device) map[string]any {
+	attributes := map[string]map[string]any{}
+	for name, attribute := range device.Attributes {
+		domain, id := qualifiedName(driver, name)
+		if attributes[domain] == nil {
+			attributes[domain] = map[string]any{}
+		}
+		attributes[domain][id] = attributeValue(&attribute)
+	}
+
+	capacity := map[string]map[string]any{}
+	for name := range device.Capacity {
+		domain, id := qualifiedName(driver, name)
+		if capacity[domain] == nil {
+			capacity[domain] = map[string]any{}
+		}
+		value := device.Capacity[name].Value.DeepCopy()
+		capacity[domain][id] = apiservercel.Quantity{Quantity: &value}
+	}
+
+	return map[string]any{
+		"driver":     driver,
+		"attributes": attributes,
+		"capacity":   capacity,
+	}
+}
+
+// qualifiedName returns the domain and the identifier of the given attribute or capacity name.
+func qualifiedName(driver string, name resourcev1.QualifiedName) (domain, id string) {
+	if domain, id, found := strings.Cut(string(name), "/"); found {
+		return domain, id
+	}
+	return driver, string(name)
+}
+
+// attributeValue returns the value of the given attribute, as evaluated by the selectors.
+func attributeValue(attribute *resourcev1.DeviceAttribute) any {
+	switch {
+	case attribute.IntValue != nil:
+		return *attribute.IntValue
+	case attribute.BoolValue != nil:
+		return *attribute.BoolValue
+	case attribute.StringValue != nil:
+		return *attribute.StringValue
+	case attribute.VersionValue != nil:
+		if version, err := semver.Parse(*attribute.VersionValue); err == nil {
+			return apiservercel.Semver{Version: version}
+		}
+		return *attribute.VersionValue
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("Device classes", func() {
+	const driver = "gpu.example.com"
+
+	var (
+		ctx           context.Context
+		scheme        *runtime.Scheme
+		objects       []client.Object
+		resourceSlice *authv1beta1.ResourceSlice
+		deviceClasses []liqov1beta1.DeviceClassType
+		err           error
+	)
+
+	forgeDeviceClass := func(name, expression string) *resourcev1.DeviceClass {
+		return &resourcev1.DeviceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: resourcev1.DeviceClassSpec{Selectors: []resourcev1.DeviceSelector{{
+				CEL: &resourcev1.CELDeviceSelector{Expression: expression},
+			}}},
+		}
+	}
+
+	forgeDevices := func(count int, model string, memory string) []resourcev1.Device {
+		devices := make([]resourcev1.Device, count)
+		for i := range devices {
+			devices[i] = resourcev1.Device{
+				Name: fmt.Sprintf("%s-%d", model, i),
+				Attributes: map[resourcev1.QualifiedName]resourcev1.DeviceAttribute{
+					"model": {StringValue: ptr.To(model)},
+					"index": {IntValue: ptr.To(int64(i))},
+				},
+				Capacity: map[resourcev1.QualifiedName]resourcev1.DeviceCapacity{
+					"memory": {Value: resource.MustParse(memory)},
+				},
+			}
+		}
+		return devices
+	}
+
+	forgeSlice := func(name, node string, devices []resourcev1.Device) *resourcev1.ResourceSlice {
+		return &resourcev1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: resourcev1.ResourceSliceSpec{
+				Driver:   driver,
+				NodeName: ptr.To(node),
+				Pool:     resourcev1.ResourcePool{Name: node, ResourceSliceCount: 1},
+				Devices:  devices,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(resourcev1.AddToScheme(scheme)).To(Succeed())
+
+		resourceSlice = &authv1beta1.ResourceSlice{ObjectMeta: metav1.ObjectMeta{Name: "slice", Namespace: "tenant", UID: "slice"}}
+		objects = []client.Object{
+			forgeDeviceClass("gpu", fmt.Sprintf("device.driver == %q", driver)),
+			forgeDeviceClass("gpu-large", fmt.Sprintf("device.driver == %q && device.capacity[%q].memory.compareTo(quantity(\"40Gi\")) >= 0",
+				driver, driver)),
+			forgeDeviceClass("fpga", `device.driver == "fpga.example.com"`),
+			forgeSlice("node-a", "node-a", forgeDevices(2, "small", "16Gi")),
+			forgeSlice("node-b", "node-b", forgeDevices(2, "large", "80Gi")),
+		}
+	})
+
+	JustBeforeEach(func() {
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		deviceClasses, err = getDeviceClasses(ctx, cl, resourceSlice, &SliceStatusOptions{EnableDynamicResourceAllocation: true})
+	})
+
+	It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+	It("should count the devices matching the selectors of each class", func() {
+		Expect(deviceClasses).To(HaveLen(2))
+		Expect(deviceClasses[0].DeviceClassName).To(Equal("gpu"))
+		Expect(deviceClasses[0].Driver).To(Equal(driver))
+		Expect(deviceClasses[0].Devices).To(BeNumerically("==", 4))
+		Expect(deviceClasses[1].DeviceClassName).To(Equal("gpu-large"))
+		Expect(deviceClasses[1].Devices).To(BeNumerically("==", 2))
+	})
+
+	It("should advertise the attributes shared by the devices of each class", func() {
+		Expect(deviceClasses[0].Attributes).To(BeEmpty())
+		Expect(deviceClasses[1].Attributes).To(HaveLen(1))
+		Expect(deviceClasses[1].Attributes).To(HaveKeyWithValue(resourcev1.QualifiedName("model"),
+			resourcev1.DeviceAttribute{StringValue: ptr.To("large")}))
+	})
+
+	When("the devices are advertised through a virtual node", func() {
+		BeforeEach(func() {
+			virtual := forgeSlice("virtual-node", "virtual-node", forgeDevices(4, "large", "80Gi"))
+			virtual.Labels = map[string]string{consts.VirtualNodeLabel: "virtual-node"}
+			objects = append(objects, virtual)
+		})
+
+		It("should not count them", func() {
+			Expect(deviceClasses).To(HaveLen(2))
+			Expect(deviceClasses[0].Devices).To(BeNumerically("==", 4))
+			Expect(deviceClasses[1].Devices).To(BeNumerically("==", 2))
+		})
+	})
+
+	When("some devices are allocated", func() {
+		BeforeEach(func() {
+			objects = append(objects, &resourcev1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "default"},
+				Status: resourcev1.ResourceClaimStatus{Allocation: &resourcev1.AllocationResult{
+					Devices: resourcev1.DeviceAllocationResult{Results: []resourcev1.DeviceRequestAllocationResult{
+						{Request: "gpu", Driver: driver, Pool: "node-b", Device: "large-0"},
+					}},
+				}},
+			})
+		})
+
+		It("should count only the unallocated devices", func() {
+			Expect(deviceClasses).To(HaveLen(2))
+			Expect(deviceClasses[0].Devices).To(BeNumerically("==", 3))
+			Expect(deviceClasses[1].Devices).To(BeNumerically("==", 1))
+		})
+	})
+
+	When("some devices are already offered through other ResourceSlices", func() {
+		BeforeEach(func() {
+			objects = append(objects, &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-tenant", UID: "other"},
+				Status: authv1beta1.ResourceSliceStatus{
+					Conditions: []authv1beta1.ResourceSliceCondition{{
+						Type:   authv1beta1.ResourceSliceConditionTypeResources,
+						Status: authv1beta1.ResourceSliceConditionAccepted,
+					}},
+					DeviceClasses: []liqov1beta1.DeviceClassType{{DeviceClassName: "gpu-large", Driver: driver, Devices: 2}},
+				},
+			})
+		})
+
+		It("should offer only the remaining devices", func() {
+			Expect(deviceClasses).To(HaveLen(1))
+			Expect(deviceClasses[0].DeviceClassName).To(Equal("gpu"))
+			Expect(deviceClasses[0].Devices).To(BeNumerically("==", 4))
+		})
+	})
+
+	When("the dynamic resource allocation is disabled", func() {
+		JustBeforeEach(func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			deviceClasses, err = getDeviceClasses(ctx, cl, resourceSlice, &SliceStatusOptions{})
+		})
+
+		It("should not advertise any device class", func() { Expect(deviceClasses).To(BeEmpty()) })
+	})
+})
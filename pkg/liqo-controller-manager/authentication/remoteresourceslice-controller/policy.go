@@ -0,0 +1,157 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// policyVerdict is the outcome of the evaluation of the ResourceSlicePolicies for a ResourceSlice.
+type policyVerdict struct {
+	status  authv1beta1.ResourceSliceConditionStatus
+	reason  string
+	message string
+}
+
+// getMatchingPolicies returns the ResourceSlicePolicies applying to the given consumer cluster.
+func getMatchingPolicies(ctx context.Context, cl client.Client,
+	consumerClusterID liqov1beta1.ClusterID) ([]authv1beta1.ResourceSlicePolicy, error) {
+	var policies authv1beta1.ResourceSlicePolicyList
+	if err := cl.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("unable to list the ResourceSlicePolicies: %w", err)
+	}
+
+	var matching []authv1beta1.ResourceSlicePolicy
+	for i := range policies.Items {
+		ids := policies.Items[i].Spec.ConsumerClusterIDs
+		if len(ids) == 0 || slices.Contains(ids, consumerClusterID) {
+			matching = append(matching, policies.Items[i])
+		}
+	}
+	return matching, nil
+}
+
+// checkAllowedClass returns a denial verdict if the class of the ResourceSlice is not allowed by any of the policies.
+func checkAllowedClass(policies []authv1beta1.ResourceSlicePolicy, resourceSlice *authv1beta1.ResourceSlice) *policyVerdict {
+	class := effectiveClass(resourceSlice)
+	for i := range policies {
+		allowed := policies[i].Spec.AllowedClasses
+		if len(allowed) > 0 && !slices.Contains(allowed, class) {
+			return &policyVerdict{
+				status: authv1beta1.ResourceSliceConditionDenied,
+				reason: "ResourceSliceClassNotAllowed",
+				message: fmt.Sprintf("the ResourceSlice class %q is not allowed by the ResourceSlicePolicy %q",
+					class, policies[i].Name),
+			}
+		}
+	}
+	return nil
+}
+
+// checkQuotaAndApproval checks that the requested resources, summed to the ones already granted to the consumer through
+// other ResourceSlices, do not exceed the maximum set by the policies, and that the ResourceSlice has been approved
+// when required. It returns nil if the ResourceSlice can be accepted.
+func checkQuotaAndApproval(policies []authv1beta1.ResourceSlicePolicy, resourceSlice *authv1beta1.ResourceSlice,
+	requested, granted corev1.ResourceList) *policyVerdict {
+	for i := range policies {
+		var exceeded []string
+		for name, limit := range policies[i].Spec.MaxResources {
+			total := requested[name].DeepCopy()
+			total.Add(granted[name])
+			if total.Cmp(limit) > 0 {
+				exceeded = append(exceeded, fmt.Sprintf("%s (requested %s, maximum %s)", name, total.String(), limit.String()))
+			}
+		}
+		if len(exceeded) > 0 {
+			sort.Strings(exceeded)
+			return &policyVerdict{
+				status: authv1beta1.ResourceSliceConditionDenied,
+				reason: "ResourceSlicePolicyQuotaExceeded",
+				message: fmt.Sprintf("the ResourceSlicePolicy %q limits the resources of the consumer: %s",
+					policies[i].Name, strings.Join(exceeded, ", ")),
+			}
+		}
+	}
+
+	for i := range policies {
+		if policies[i].Spec.ApprovalMode == authv1beta1.ResourceSliceApprovalManual && !isApproved(resourceSlice) {
+			return &policyVerdict{
+				status: authv1beta1.ResourceSliceConditionPending,
+				reason: "ResourceSliceApprovalPending",
+				message: fmt.Sprintf("the ResourceSlicePolicy %q requires a manual approval (liqoctl approve resourceslice %s)",
+					policies[i].Name, resourceSlice.Name),
+			}
+		}
+	}
+
+	return nil
+}
+
+// isApproved returns whether the current generation of the ResourceSlice has been manually approved.
+func isApproved(resourceSlice *authv1beta1.ResourceSlice) bool {
+	return resourceSlice.Annotations[consts.ApprovedGenerationAnnotation] == strconv.FormatInt(resourceSlice.Generation, 10)
+}
+
+// getGrantedResources returns the resources already granted to the consumer through the other accepted ResourceSlices
+// in the same tenant namespace.
+func getGrantedResources(ctx context.Context, cl client.Client, resourceSlice *authv1beta1.ResourceSlice) (corev1.ResourceList, error) {
+	var resourceSlices authv1beta1.ResourceSliceList
+	if err := cl.List(ctx, &resourceSlices, client.InNamespace(resourceSlice.Namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list the ResourceSlices in namespace %q: %w", resourceSlice.Namespace, err)
+	}
+
+	granted := corev1.ResourceList{}
+	for i := range resourceSlices.Items {
+		rs := &resourceSlices.Items[i]
+		if rs.UID == resourceSlice.UID {
+			continue
+		}
+		cond := authentication.GetCondition(rs, authv1beta1.ResourceSliceConditionTypeResources)
+		if cond == nil || cond.Status != authv1beta1.ResourceSliceConditionAccepted {
+			continue
+		}
+		for name, quantity := range rs.Status.Resources {
+			total := granted[name].DeepCopy()
+			total.Add(quantity)
+			granted[name] = total
+		}
+	}
+	return granted, nil
+}
+
+// getRequestedResources returns the resources requested by the ResourceSlice, with the default values for the ones not specified.
+func getRequestedResources(resourceSlice *authv1beta1.ResourceSlice, defaults corev1.ResourceList) corev1.ResourceList {
+	requested := corev1.ResourceList{}
+	for name, quantity := range defaults {
+		requested[name] = quantity
+	}
+	for name, quantity := range resourceSlice.Spec.Resources {
+		requested[name] = quantity
+	}
+	return requested
+}
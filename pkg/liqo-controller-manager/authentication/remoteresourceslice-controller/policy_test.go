@@ -0,0 +1,181 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("ResourceSlicePolicies", func() {
+	var (
+		ctx           context.Context
+		scheme        *runtime.Scheme
+		resourceSlice *authv1beta1.ResourceSlice
+	)
+
+	forgePolicy := func(name string, spec authv1beta1.ResourceSlicePolicySpec) *authv1beta1.ResourceSlicePolicy {
+		return &authv1beta1.ResourceSlicePolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+
+	forgeAcceptedSlice := func(name string, resources corev1.ResourceList) *authv1beta1.ResourceSlice {
+		return &authv1beta1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant", UID: types.UID(name)},
+			Status: authv1beta1.ResourceSliceStatus{
+				Conditions: []authv1beta1.ResourceSliceCondition{{
+					Type:   authv1beta1.ResourceSliceConditionTypeResources,
+					Status: authv1beta1.ResourceSliceConditionAccepted,
+				}},
+				Resources: resources,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		consumer := liqov1beta1.ClusterID("consumer")
+		resourceSlice = &authv1beta1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "slice", Namespace: "tenant", UID: "slice", Generation: 2},
+			Spec: authv1beta1.ResourceSliceSpec{
+				ConsumerClusterID: &consumer,
+				Resources:         corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+		}
+	})
+
+	Describe("selecting the policies", func() {
+		It("should return the global policies and the ones listing the consumer", func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				forgePolicy("global", authv1beta1.ResourceSlicePolicySpec{}),
+				forgePolicy("consumer", authv1beta1.ResourceSlicePolicySpec{ConsumerClusterIDs: []liqov1beta1.ClusterID{"consumer"}}),
+				forgePolicy("other", authv1beta1.ResourceSlicePolicySpec{ConsumerClusterIDs: []liqov1beta1.ClusterID{"other"}}),
+			).Build()
+
+			policies, err := getMatchingPolicies(ctx, cl, "consumer")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(2))
+			Expect([]string{policies[0].Name, policies[1].Name}).To(ConsistOf("global", "consumer"))
+		})
+	})
+
+	Describe("checking the allowed classes", func() {
+		policies := []authv1beta1.ResourceSlicePolicy{
+			*forgePolicy("classes", authv1beta1.ResourceSlicePolicySpec{AllowedClasses: []authv1beta1.ResourceSliceClass{"default"}}),
+		}
+
+		It("should treat the empty class as the default one", func() {
+			Expect(checkAllowedClass(policies, resourceSlice)).To(BeNil())
+		})
+
+		It("should deny a class not in the list", func() {
+			resourceSlice.Spec.Class = "gpu"
+			verdict := checkAllowedClass(policies, resourceSlice)
+			Expect(verdict).ToNot(BeNil())
+			Expect(verdict.status).To(Equal(authv1beta1.ResourceSliceConditionDenied))
+			Expect(verdict.reason).To(Equal("ResourceSliceClassNotAllowed"))
+		})
+	})
+
+	Describe("checking the quota and the approval", func() {
+		var granted corev1.ResourceList
+
+		BeforeEach(func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				resourceSlice,
+				forgeAcceptedSlice("accepted", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5")}),
+				forgeAcceptedSlice("other", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+			).Build()
+
+			var err error
+			granted, err = getGrantedResources(ctx, cl, resourceSlice)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should sum the resources granted through the other slices", func() {
+			Expect(granted.Cpu().String()).To(Equal("6"))
+		})
+
+		It("should accept a request within the maximum", func() {
+			policies := []authv1beta1.ResourceSlicePolicy{*forgePolicy("quota", authv1beta1.ResourceSlicePolicySpec{
+				MaxResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			})}
+			Expect(checkQuotaAndApproval(policies, resourceSlice, resourceSlice.Spec.Resources, granted)).To(BeNil())
+		})
+
+		It("should deny a request exceeding the maximum", func() {
+			policies := []authv1beta1.ResourceSlicePolicy{*forgePolicy("quota", authv1beta1.ResourceSlicePolicySpec{
+				MaxResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7")},
+			})}
+			verdict := checkQuotaAndApproval(policies, resourceSlice, resourceSlice.Spec.Resources, granted)
+			Expect(verdict).ToNot(BeNil())
+			Expect(verdict.status).To(Equal(authv1beta1.ResourceSliceConditionDenied))
+			Expect(verdict.message).To(ContainSubstring("cpu (requested 8, maximum 7)"))
+		})
+
+		It("should keep the slice pending until the current generation is approved", func() {
+			policies := []authv1beta1.ResourceSlicePolicy{*forgePolicy("manual", authv1beta1.ResourceSlicePolicySpec{
+				ApprovalMode: authv1beta1.ResourceSliceApprovalManual,
+			})}
+
+			verdict := checkQuotaAndApproval(policies, resourceSlice, resourceSlice.Spec.Resources, granted)
+			Expect(verdict).ToNot(BeNil())
+			Expect(verdict.status).To(Equal(authv1beta1.ResourceSliceConditionPending))
+
+			resourceSlice.Annotations = map[string]string{consts.ApprovedGenerationAnnotation: "1"}
+			Expect(checkQuotaAndApproval(policies, resourceSlice, resourceSlice.Spec.Resources, granted)).ToNot(BeNil())
+
+			resourceSlice.Annotations[consts.ApprovedGenerationAnnotation] = "2"
+			Expect(checkQuotaAndApproval(policies, resourceSlice, resourceSlice.Spec.Resources, granted)).To(BeNil())
+		})
+	})
+
+	Describe("applying the verdict", func() {
+		verdict := &policyVerdict{status: authv1beta1.ResourceSliceConditionDenied, reason: "Reason", message: "message"}
+
+		It("should deny a slice not yet accepted", func() {
+			applyPolicyVerdict(resourceSlice, fakeRecorder(), verdict)
+			Expect(resourceSlice.Status.Conditions).To(ConsistOf(HaveField("Status", authv1beta1.ResourceSliceConditionDenied)))
+		})
+
+		It("should retain the acceptance of a slice already accepted", func() {
+			resourceSlice.Status = forgeAcceptedSlice("slice", nil).Status
+			applyPolicyVerdict(resourceSlice, fakeRecorder(), verdict)
+			Expect(resourceSlice.Status.Conditions).To(ConsistOf(And(
+				HaveField("Status", authv1beta1.ResourceSliceConditionAccepted),
+				HaveField("Reason", "Reason"),
+			)))
+		})
+	})
+})
+
+func fakeRecorder() record.EventRecorder {
+	return record.NewFakeRecorder(10)
+}
@@ -17,21 +17,26 @@ package remoteresourceslicecontroller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	klog "k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -43,6 +48,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	liqoutils "github.com/liqotech/liqo/pkg/utils"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
 )
@@ -98,8 +104,13 @@ type RemoteResourceSliceReconciler struct {
 // cluster-role
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslices;resourceslices/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslicepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourcesliceclassdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=deviceclasses;resourceclaims;resourceslices,verbs=get;list;watch
 
 // Reconcile replicated ResourceSlice resources.
 func (r *RemoteResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -192,11 +203,22 @@ func (r *RemoteResourceSliceReconciler) handleAuthenticationStatus(ctx context.C
 		return requeueIn, nil
 	}
 
+	// The credentials of a revoked identity are neither issued nor renewed.
+	if authentication.IsResourceSliceIdentityRevoked(tenant, resourceSlice.Name) {
+		if resourceSlice.Status.AuthParams != nil {
+			klog.Infof("Identity of the ResourceSlice %q revoked", client.ObjectKeyFromObject(resourceSlice))
+			r.eventRecorder.Event(resourceSlice, corev1.EventTypeNormal, "IdentityRevoked", "ResourceSlice identity revoked")
+			resourceSlice.Status.AuthParams = nil
+		}
+		denyAuthentication(resourceSlice, r.eventRecorder)
+		return requeueIn, nil
+	}
+
 	shouldRenew := false
-	if resourceSlice.Status.AuthParams != nil && len(resourceSlice.Status.AuthParams.SignedCRT) > 0 {
+	if utils.HasCredentials(resourceSlice.Status.AuthParams) {
 		var err error
-		// Check if the certificate needs to be renewed and if so, generate a new CSR.
-		shouldRenew, requeueIn, err = utils.ShouldRenewCertificate(resourceSlice.Status.AuthParams.SignedCRT)
+		// Check if the credentials need to be renewed and if so, generate a new CSR.
+		shouldRenew, requeueIn, err = utils.ShouldRenewAuthParams(resourceSlice.Status.AuthParams)
 		if err != nil {
 			klog.Errorf("unable to check if certificate should be renewed for ResourceSlice %q: %v", client.ObjectKeyFromObject(resourceSlice), err)
 			r.eventRecorder.Event(resourceSlice, corev1.EventTypeWarning, "FailedCheckCertificateRenewal", err.Error())
@@ -244,21 +266,36 @@ func (r *RemoteResourceSliceReconciler) handleAuthenticationStatus(ctx context.C
 
 func (r *RemoteResourceSliceReconciler) handleResourcesStatus(ctx context.Context,
 	resourceSlice *authv1beta1.ResourceSlice, tenant *authv1beta1.Tenant) error {
-	var err error
-
 	switch tenant.Spec.TenantCondition {
 	case authv1beta1.TenantConditionActive:
-		// If the ResourceSlice is not of the default class, the resource status is leaved as it is and the update is
-		// demanded to external controllers/plugins.
-		if !isInResourceClasses(resourceSlice, r.reconciledClasses...) {
+		policies, err := getMatchingPolicies(ctx, r.Client, *resourceSlice.Spec.ConsumerClusterID)
+		if err != nil {
+			return err
+		}
+
+		// The allowed classes are enforced regardless of the controller in charge of the ResourceSlice class.
+		if verdict := checkAllowedClass(policies, resourceSlice); verdict != nil {
+			applyPolicyVerdict(resourceSlice, r.eventRecorder, verdict)
+			return nil
+		}
+
+		classDefinition, err := getClassDefinition(ctx, r.Client, resourceSlice)
+		if err != nil {
+			return err
+		}
+
+		// If the ResourceSlice is neither of the default class nor of a class defined through a ResourceSliceClassDefinition,
+		// the resource status is leaved as it is and the update is demanded to external controllers/plugins.
+		if classDefinition == nil && !isInResourceClasses(resourceSlice, r.reconciledClasses...) {
 			klog.V(6).Infof("ResourceSlice %q is not of the default class, the resource status is leaved as it is",
 				client.ObjectKeyFromObject(resourceSlice))
 			return nil
 		}
 
 		// If the built-in default ResourceSlice class has been administratively disabled on the provider,
-		// deny slices of the default (or empty) class instead of accepting them.
-		if !r.sliceStatusOptions.DefaultResourceSliceClassEnabled {
+		// deny slices of the default (or empty) class instead of accepting them, even if a
+		// ResourceSliceClassDefinition named after the default class exists.
+		if effectiveClass(resourceSlice) == authv1beta1.ResourceSliceClassDefault && !r.sliceStatusOptions.DefaultResourceSliceClassEnabled {
 			klog.V(4).Infof("ResourceSlice %q denied: the built-in default ResourceSlice class is administratively disabled",
 				client.ObjectKeyFromObject(resourceSlice))
 			denyResourcesWithReason(resourceSlice, r.eventRecorder,
@@ -267,23 +304,43 @@ func (r *RemoteResourceSliceReconciler) handleResourcesStatus(ctx context.Contex
 			return nil
 		}
 
-		// Default class: accept requested resources and set the default values for the resources not specified.
-		findOrDefault := func(resource corev1.ResourceName, val resource.Quantity) resource.Quantity {
-			v, ok := resourceSlice.Spec.Resources[resource]
-			if ok {
-				return v
+		var requested corev1.ResourceList
+		if classDefinition != nil {
+			// Defined class: grant the requested resources, as long as they are available on the nodes backing the class.
+			capacity, err := getClassCapacity(ctx, r.Client, classDefinition)
+			if err != nil {
+				return err
+			}
+			classGranted, err := getClassGrantedResources(ctx, r.Client, resourceSlice)
+			if err != nil {
+				return err
 			}
-			return val
+			var verdict *policyVerdict
+			if requested, verdict = checkClassCapacity(resourceSlice, capacity, classGranted,
+				r.sliceStatusOptions.DefaultResourceQuantity); verdict != nil {
+				applyPolicyVerdict(resourceSlice, r.eventRecorder, verdict)
+				return nil
+			}
+		} else {
+			// Default class: accept requested resources and set the default values for the resources not specified.
+			requested = getRequestedResources(resourceSlice, r.sliceStatusOptions.DefaultResourceQuantity)
 		}
 
-		if resourceSlice.Status.Resources == nil {
-			resourceSlice.Status.Resources = corev1.ResourceList{}
+		if len(policies) > 0 {
+			granted, err := getGrantedResources(ctx, r.Client, resourceSlice)
+			if err != nil {
+				return err
+			}
+			if verdict := checkQuotaAndApproval(policies, resourceSlice, requested, granted); verdict != nil {
+				applyPolicyVerdict(resourceSlice, r.eventRecorder, verdict)
+				return nil
+			}
 		}
 
-		for k, v := range r.sliceStatusOptions.DefaultResourceQuantity {
-			resourceSlice.Status.Resources[k] = findOrDefault(k, v)
+		if resourceSlice.Status.Resources == nil {
+			resourceSlice.Status.Resources = corev1.ResourceList{}
 		}
-		for k, v := range resourceSlice.Spec.Resources {
+		for k, v := range requested {
 			resourceSlice.Status.Resources[k] = v
 		}
 
@@ -294,9 +351,22 @@ func (r *RemoteResourceSliceReconciler) handleResourcesStatus(ctx context.Contex
 			return err
 		}
 
+		resourceSlice.Status.DeviceClasses, err = getDeviceClasses(ctx, r.Client, resourceSlice, r.sliceStatusOptions)
+		if err != nil {
+			klog.Errorf("Unable to get the DeviceClasses for the ResourceSlice %q: %s", client.ObjectKeyFromObject(resourceSlice), err)
+			r.eventRecorder.Event(resourceSlice, corev1.EventTypeWarning, "DeviceClassesFailed", err.Error())
+			return err
+		}
+
 		resourceSlice.Status.IngressClasses = getIngressClasses(r.sliceStatusOptions)
 		resourceSlice.Status.LoadBalancerClasses = getLoadBalancerClasses(r.sliceStatusOptions)
 		resourceSlice.Status.NodeLabels = getNodeLabels(r.sliceStatusOptions)
+		if classDefinition != nil {
+			// The virtual nodes of a defined class represent the subset of the provider nodes selected by the class.
+			resourceSlice.Status.NodeLabels = labels.Merge(resourceSlice.Status.NodeLabels, classDefinition.Spec.NodeSelector)
+			resourceSlice.Status.NodeSelector = classDefinition.Spec.NodeSelector
+			resourceSlice.Status.Tolerations = classDefinition.Spec.Tolerations
+		}
 
 		acceptResources(resourceSlice, r.eventRecorder)
 	case authv1beta1.TenantConditionCordoned:
@@ -324,10 +394,17 @@ func (r *RemoteResourceSliceReconciler) SetupWithManager(mgr ctrl.Manager) error
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlResourceSliceRemote).
 		For(
 			&authv1beta1.ResourceSlice{},
-			// With GenerationChangedPredicate we prevent to reconcile multiple times when the status of the resource changes
-			builder.WithPredicates(predicate.And(remoteResSliceFilter, withCSR(), predicate.GenerationChangedPredicate{})),
+			// With GenerationChangedPredicate we prevent to reconcile multiple times when the status of the resource changes,
+			// while AnnotationChangedPredicate triggers the reconciliation when a ResourceSlice is manually approved.
+			builder.WithPredicates(predicate.And(remoteResSliceFilter, withCSR(),
+				predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))),
 		).
 		Watches(&authv1beta1.Tenant{}, handler.EnqueueRequestsFromMapFunc(r.resourceSlicesEnquer())).
+		Watches(&authv1beta1.ResourceSlicePolicy{}, handler.EnqueueRequestsFromMapFunc(r.policyResourceSlicesEnquer())).
+		Watches(&authv1beta1.ResourceSliceClassDefinition{}, handler.EnqueueRequestsFromMapFunc(r.classResourceSlicesEnquer())).
+		// The capacity of the defined classes depends on the nodes they select.
+		Watches(&corev1.Node{}, r.nodeResourceSlicesHandler(),
+			builder.WithPredicates(nodeCapacityChangedPredicate())).
 		Complete(r)
 }
 
@@ -362,6 +439,155 @@ func (r *RemoteResourceSliceReconciler) resourceSlicesEnquer() func(ctx context.
 	}
 }
 
+func (r *RemoteResourceSliceReconciler) policyResourceSlicesEnquer() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policy, ok := obj.(*authv1beta1.ResourceSlicePolicy)
+		if !ok {
+			klog.Infof("Object %q is not a ResourceSlicePolicy", obj.GetName())
+			return nil
+		}
+
+		resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll, liqolabels.RemoteLabelSelector())
+		if err != nil {
+			klog.Errorf("Failed to retrieve ResourceSlices for ResourceSlicePolicy %q: %v", policy.Name, err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range resSlices {
+			consumer := resSlices[i].Spec.ConsumerClusterID
+			if consumer == nil {
+				continue
+			}
+			if len(policy.Spec.ConsumerClusterIDs) > 0 && !slices.Contains(policy.Spec.ConsumerClusterIDs, *consumer) {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      resSlices[i].Name,
+				Namespace: resSlices[i].Namespace,
+			}})
+		}
+
+		return reqs
+	}
+}
+
+func (r *RemoteResourceSliceReconciler) classResourceSlicesEnquer() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		classDefinition, ok := obj.(*authv1beta1.ResourceSliceClassDefinition)
+		if !ok {
+			klog.Infof("Object %q is not a ResourceSliceClassDefinition", obj.GetName())
+			return nil
+		}
+
+		resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll, liqolabels.RemoteLabelSelector())
+		if err != nil {
+			klog.Errorf("Failed to retrieve ResourceSlices for ResourceSliceClassDefinition %q: %v", classDefinition.Name, err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range resSlices {
+			if string(effectiveClass(&resSlices[i])) != classDefinition.Name {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      resSlices[i].Name,
+				Namespace: resSlices[i].Namespace,
+			}})
+		}
+
+		return reqs
+	}
+}
+
+// nodeResourceSlicesHandler enqueues the ResourceSlices of the classes selecting the node, either before or after the event.
+func (r *RemoteResourceSliceReconciler) nodeResourceSlicesHandler() handler.EventHandler {
+	enqueue := func(ctx context.Context, trli workqueue.TypedRateLimitingInterface[reconcile.Request], nodes ...client.Object) {
+		for _, req := range r.nodeResourceSlicesRequests(ctx, nodes...) {
+			trli.Add(req)
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, ce event.TypedCreateEvent[client.Object], trli workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, trli, ce.Object)
+		},
+		UpdateFunc: func(ctx context.Context, ue event.TypedUpdateEvent[client.Object], trli workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			// The node may have just stopped backing a class (e.g., as a label is removed), hence the old labels are checked too.
+			enqueue(ctx, trli, ue.ObjectOld, ue.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, de event.TypedDeleteEvent[client.Object], trli workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, trli, de.Object)
+		},
+	}
+}
+
+// nodeResourceSlicesRequests returns the requests for the ResourceSlices of the classes selecting any of the given nodes.
+func (r *RemoteResourceSliceReconciler) nodeResourceSlicesRequests(ctx context.Context, nodes ...client.Object) []reconcile.Request {
+	if len(nodes) == 0 {
+		return nil
+	}
+	nodeName := nodes[len(nodes)-1].GetName()
+
+	var classDefinitions authv1beta1.ResourceSliceClassDefinitionList
+	if err := r.List(ctx, &classDefinitions); err != nil {
+		klog.Errorf("Failed to retrieve ResourceSliceClassDefinitions for Node %q: %v", nodeName, err)
+		return nil
+	}
+	classes := sets.New[authv1beta1.ResourceSliceClass]()
+	for i := range classDefinitions.Items {
+		// The node may have just stopped backing the class, hence only the node selector is checked.
+		selector := labels.SelectorFromSet(classDefinitions.Items[i].Spec.NodeSelector)
+		for _, node := range nodes {
+			if selector.Matches(labels.Set(node.GetLabels())) {
+				classes.Insert(authv1beta1.ResourceSliceClass(classDefinitions.Items[i].Name))
+				break
+			}
+		}
+	}
+	if classes.Len() == 0 {
+		return nil
+	}
+
+	resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll, liqolabels.RemoteLabelSelector())
+	if err != nil {
+		klog.Errorf("Failed to retrieve ResourceSlices for Node %q: %v", nodeName, err)
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i := range resSlices {
+		if !classes.Has(effectiveClass(&resSlices[i])) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      resSlices[i].Name,
+			Namespace: resSlices[i].Namespace,
+		}})
+	}
+
+	return reqs
+}
+
+// nodeCapacityChangedPredicate filters the node events which may change the capacity of the classes selecting the node.
+func nodeCapacityChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				!equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+				oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+				!equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable) ||
+				liqoutils.IsNodeReady(oldNode) != liqoutils.IsNodeReady(newNode)
+		},
+	}
+}
+
 func withCSR() predicate.Funcs {
 	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
 		rs, ok := obj.(*authv1beta1.ResourceSlice)
@@ -454,6 +680,29 @@ func denyResourcesWithReason(resourceSlice *authv1beta1.ResourceSlice, er record
 	}
 }
 
+// applyPolicyVerdict reflects the verdict of the ResourceSlicePolicies in the ResourceSlice resources condition.
+// If the resources have already been accepted, the previously granted ones are retained, and the condition only
+// explains why the update has not been applied.
+func applyPolicyVerdict(resourceSlice *authv1beta1.ResourceSlice, er record.EventRecorder, verdict *policyVerdict) {
+	klog.V(4).Infof("ResourceSlice %q not accepted by the ResourceSlicePolicies: %s",
+		client.ObjectKeyFromObject(resourceSlice), verdict.message)
+
+	status, message := verdict.status, verdict.message
+	resCond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+	if resCond != nil && resCond.Status == authv1beta1.ResourceSliceConditionAccepted {
+		status = authv1beta1.ResourceSliceConditionAccepted
+		message = fmt.Sprintf("the previously accepted resources are retained: %s", verdict.message)
+	}
+
+	switch authentication.EnsureCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources,
+		status, verdict.reason, message) {
+	case controllerutil.OperationResultUpdated, controllerutil.OperationResultCreated:
+		er.Event(resourceSlice, corev1.EventTypeWarning, verdict.reason, message)
+	default:
+		return
+	}
+}
+
 func isInResourceClasses(resourceSlice *authv1beta1.ResourceSlice, classes ...authv1beta1.ResourceSliceClass) bool {
 	for _, class := range classes {
 		if resourceSlice.Spec.Class == class {
@@ -19,21 +19,30 @@ import (
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	argutils "github.com/liqotech/liqo/pkg/utils/args"
 )
 
 // SliceStatusOptions contains the options to configure the status of a remote resource slice.
 type SliceStatusOptions struct {
-	EnableStorage             bool
-	LocalRealStorageClassName string
-	IngressClasses            argutils.ClassNameList
-	LoadBalancerClasses       argutils.ClassNameList
-	ClusterLabels             map[string]string
-	DefaultResourceQuantity   corev1.ResourceList
+	EnableStorage bool
+	// EnableDynamicResourceAllocation enables the advertisement of the device classes offered through Dynamic Resource Allocation.
+	EnableDynamicResourceAllocation bool
+	LocalRealStorageClassName       string
+	IngressClasses                  argutils.ClassNameList
+	LoadBalancerClasses             argutils.ClassNameList
+	ClusterLabels                   map[string]string
+	DefaultResourceQuantity         corev1.ResourceList
 	// DefaultResourceSliceClassEnabled enables the built-in default ResourceSlice class.
 	// When false, ResourceSlices of the default (or empty) class are denied instead of accepted.
 	DefaultResourceSliceClassEnabled bool
@@ -101,6 +110,189 @@ func getStorageClasses(ctx context.Context, cl client.Client, opts *SliceStatusO
 	return storageTypes, nil
 }
 
+// deviceKey returns the key identifying a device published by a DRA driver.
+func deviceKey(driver, pool, device string) string {
+	return driver + "/" + pool + "/" + device
+}
+
+// getAllocatedDevices returns the keys of the devices allocated to the ResourceClaims of the cluster.
+func getAllocatedDevices(ctx context.Context, cl client.Client) (sets.Set[string], error) {
+	var claims resourcev1.ResourceClaimList
+	if err := cl.List(ctx, &claims); err != nil {
+		return nil, err
+	}
+
+	allocated := sets.New[string]()
+	for i := range claims.Items {
+		if claims.Items[i].Status.Allocation == nil {
+			continue
+		}
+		for _, result := range claims.Items[i].Status.Allocation.Devices.Results {
+			allocated.Insert(deviceKey(result.Driver, result.Pool, result.Device))
+		}
+	}
+	return allocated, nil
+}
+
+// getOfferedDevices returns the number of devices of each device class and driver already offered through
+// the accepted ResourceSlices other than the given one, so that the same devices are not offered twice.
+func getOfferedDevices(ctx context.Context, cl client.Client, resourceSlice *authv1beta1.ResourceSlice) (map[string]int64, error) {
+	var resourceSlices authv1beta1.ResourceSliceList
+	if err := cl.List(ctx, &resourceSlices); err != nil {
+		return nil, err
+	}
+
+	offered := map[string]int64{}
+	for i := range resourceSlices.Items {
+		rs := &resourceSlices.Items[i]
+		if rs.UID == resourceSlice.UID {
+			continue
+		}
+		cond := authentication.GetCondition(rs, authv1beta1.ResourceSliceConditionTypeResources)
+		if cond == nil || cond.Status != authv1beta1.ResourceSliceConditionAccepted {
+			continue
+		}
+		for j := range rs.Status.DeviceClasses {
+			class := &rs.Status.DeviceClasses[j]
+			offered[class.DeviceClassName+"/"+class.Driver] += class.Devices
+		}
+	}
+	return offered, nil
+}
+
+// getDeviceResourceSlices returns the resource.k8s.io ResourceSlices publishing the devices of the local cluster,
+// excluding the ones advertising the devices of the remote clusters through the virtual nodes, as well as the
+// outdated ones belonging to an older generation of the corresponding pool.
+func getDeviceResourceSlices(ctx context.Context, cl client.Client) ([]*resourcev1.ResourceSlice, error) {
+	var resourceSliceList resourcev1.ResourceSliceList
+	if err := cl.List(ctx, &resourceSliceList); err != nil {
+		return nil, err
+	}
+
+	generations := map[string]int64{}
+	for i := range resourceSliceList.Items {
+		slice := &resourceSliceList.Items[i]
+		pool := slice.Spec.Driver + "/" + slice.Spec.Pool.Name
+		generations[pool] = max(generations[pool], slice.Spec.Pool.Generation)
+	}
+
+	var slices []*resourcev1.ResourceSlice
+	for i := range resourceSliceList.Items {
+		slice := &resourceSliceList.Items[i]
+		if _, virtual := slice.Labels[consts.VirtualNodeLabel]; virtual {
+			continue
+		}
+		if slice.Spec.Pool.Generation < generations[slice.Spec.Driver+"/"+slice.Spec.Pool.Name] {
+			continue
+		}
+		slices = append(slices, slice)
+	}
+	return slices, nil
+}
+
+// commonAttributes returns the attributes shared, with the same value, by the given devices.
+func commonAttributes(devices []*resourcev1.Device) map[resourcev1.QualifiedName]resourcev1.DeviceAttribute {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	attributes := map[resourcev1.QualifiedName]resourcev1.DeviceAttribute{}
+	for name, attribute := range devices[0].Attributes {
+		attributes[name] = attribute
+	}
+	for _, device := range devices[1:] {
+		for name, attribute := range attributes {
+			if other, found := device.Attributes[name]; !found || !equality.Semantic.DeepEqual(attribute, other) {
+				delete(attributes, name)
+			}
+		}
+	}
+
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+// getDeviceClasses returns the device classes offered through the given ResourceSlice, along with the number of devices
+// of each driver which match the selectors of the class, are not allocated to any ResourceClaim, and are not already
+// offered through other ResourceSlices. The attributes shared by all the devices are advertised as well, so that they
+// can be selected by the device classes of the consumer cluster.
+func getDeviceClasses(ctx context.Context, cl client.Client, resourceSlice *authv1beta1.ResourceSlice,
+	opts *SliceStatusOptions) ([]liqov1beta1.DeviceClassType, error) {
+	if opts == nil || !opts.EnableDynamicResourceAllocation {
+		return []liqov1beta1.DeviceClassType{}, nil
+	}
+
+	var deviceClassList resourcev1.DeviceClassList
+	if err := cl.List(ctx, &deviceClassList); err != nil {
+		return nil, err
+	}
+
+	slices, err := getDeviceResourceSlices(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated, err := getAllocatedDevices(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := getOfferedDevices(ctx, cl, resourceSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceClasses := []liqov1beta1.DeviceClassType{}
+	for i := range deviceClassList.Items {
+		class := &deviceClassList.Items[i]
+		selector, err := newDeviceSelector(class)
+		if err != nil {
+			// the device classes whose selectors cannot be evaluated are not advertised
+			klog.Warningf("Skipping the advertisement of DeviceClass %q: %v", class.GetName(), err)
+			continue
+		}
+
+		// collect the available devices of the class, for each driver
+		available := map[string][]*resourcev1.Device{}
+		for _, slice := range slices {
+			for j := range slice.Spec.Devices {
+				device := &slice.Spec.Devices[j]
+				if allocated.Has(deviceKey(slice.Spec.Driver, slice.Spec.Pool.Name, device.Name)) ||
+					!selector.Matches(slice.Spec.Driver, device) {
+					continue
+				}
+				available[slice.Spec.Driver] = append(available[slice.Spec.Driver], device)
+			}
+		}
+
+		for driver, devices := range available {
+			count := int64(len(devices)) - offered[class.GetName()+"/"+driver]
+			if count <= 0 {
+				continue
+			}
+
+			deviceClasses = append(deviceClasses, liqov1beta1.DeviceClassType{
+				DeviceClassName: class.GetName(),
+				Driver:          driver,
+				Devices:         count,
+				Attributes:      commonAttributes(devices),
+			})
+		}
+	}
+
+	// sort the device classes by name (and driver) to have a deterministic order
+	sort.Slice(deviceClasses, func(i, j int) bool {
+		if deviceClasses[i].DeviceClassName != deviceClasses[j].DeviceClassName {
+			return deviceClasses[i].DeviceClassName < deviceClasses[j].DeviceClassName
+		}
+		return deviceClasses[i].Driver < deviceClasses[j].Driver
+	})
+
+	return deviceClasses, nil
+}
+
 func getNodeLabels(opts *SliceStatusOptions) map[string]string {
 	if opts == nil {
 		return map[string]string{}
@@ -0,0 +1,64 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+)
+
+// IsControlPlaneIdentityRevoked checks whether the control plane identity of the given tenant has been revoked.
+func IsControlPlaneIdentityRevoked(tenant *authv1beta1.Tenant) bool {
+	for i := range tenant.Spec.RevokedIdentities {
+		if tenant.Spec.RevokedIdentities[i].Type == authv1beta1.ControlPlaneIdentityType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsResourceSliceIdentityRevoked checks whether the identity of the given ResourceSlice has been revoked.
+// An empty name checks whether all the ResourceSlice identities of the tenant have been revoked.
+func IsResourceSliceIdentityRevoked(tenant *authv1beta1.Tenant, resourceSliceName string) bool {
+	for i := range tenant.Spec.RevokedIdentities {
+		revoked := &tenant.Spec.RevokedIdentities[i]
+		if revoked.Type != authv1beta1.ResourceSliceIdentityType {
+			continue
+		}
+		if revoked.ResourceSliceName == "" || revoked.ResourceSliceName == resourceSliceName {
+			return true
+		}
+	}
+	return false
+}
+
+// RevokedUsersAndGroups returns the deny-list of users and groups corresponding to the identities
+// revoked for the given tenant. The users match the common names of the issued certificates, while
+// revoking all the ResourceSlice identities denies the whole group of the tenant cluster.
+func RevokedUsersAndGroups(tenant *authv1beta1.Tenant) (users, groups []string) {
+	for i := range tenant.Spec.RevokedIdentities {
+		revoked := &tenant.Spec.RevokedIdentities[i]
+		switch revoked.Type {
+		case authv1beta1.ControlPlaneIdentityType:
+			users = append(users, CommonNameControlPlaneCSR(tenant.Spec.ClusterID))
+		case authv1beta1.ResourceSliceIdentityType:
+			if revoked.ResourceSliceName == "" {
+				groups = append(groups, string(tenant.Spec.ClusterID))
+				continue
+			}
+			users = append(users, CommonNameResourceSlice(revoked.ResourceSliceName, tenant.Spec.ClusterID))
+		}
+	}
+	return users, groups
+}
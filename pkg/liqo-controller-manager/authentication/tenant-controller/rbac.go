@@ -0,0 +1,123 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenantcontroller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// ensureControlPlaneClusterRoles renders the ClusterRole of the control plane identity from the given TenantRBACTemplate,
+// and returns the ClusterRoles to be bound in the tenant namespace. The bindings of the ClusterRoles no longer in use are removed.
+func (r *TenantReconciler) ensureControlPlaneClusterRoles(ctx context.Context, tenant *authv1beta1.Tenant,
+	template *authv1beta1.TenantRBACTemplate) ([]*rbacv1.ClusterRole, error) {
+	name := authentication.TenantControlPlaneClusterRoleName(tenant.Spec.ClusterID)
+	rendered := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+	if template == nil || len(template.Spec.ControlPlaneRules) == 0 {
+		if err := r.NamespaceManager.UnbindClusterRoles(ctx, tenant.Spec.ClusterID, rendered); err != nil {
+			return nil, fmt.Errorf("unable to unbind the ClusterRole %q: %w", name, err)
+		}
+		if err := r.deleteRenderedClusterRole(ctx, name); err != nil {
+			return nil, err
+		}
+		return r.tenantClusterRoles, nil
+	}
+
+	if err := authentication.CheckTenantRBACRules(template.Spec.ControlPlaneRules, r.tenantClusterRoles...); err != nil {
+		return nil, fmt.Errorf("invalid control plane rules of TenantRBACTemplate %q: %w", template.Name, err)
+	}
+	if err := r.enforceRenderedClusterRole(ctx, tenant.Spec.ClusterID, name, template.Spec.ControlPlaneRules); err != nil {
+		return nil, err
+	}
+	if err := r.NamespaceManager.UnbindClusterRoles(ctx, tenant.Spec.ClusterID, r.tenantClusterRoles...); err != nil {
+		return nil, fmt.Errorf("unable to unbind the default ClusterRoles: %w", err)
+	}
+	return []*rbacv1.ClusterRole{rendered}, nil
+}
+
+// ensureOffloadedNamespaceClusterRole renders the ClusterRole bound to the identities of the consumer in the offloaded namespaces
+// from the given TenantRBACTemplate. The binding is enforced by the NamespaceMap controller, which falls back to the default
+// ClusterRole when it does not exist.
+func (r *TenantReconciler) ensureOffloadedNamespaceClusterRole(ctx context.Context, tenant *authv1beta1.Tenant,
+	template *authv1beta1.TenantRBACTemplate) error {
+	name := authentication.TenantOffloadedNamespaceClusterRoleName(tenant.Spec.ClusterID)
+	if template == nil || len(template.Spec.OffloadedNamespaceRules) == 0 {
+		return r.deleteRenderedClusterRole(ctx, name)
+	}
+
+	var ceiling rbacv1.ClusterRole
+	if err := r.Get(ctx, types.NamespacedName{Name: consts.RemoteNamespaceClusterRoleName}, &ceiling); err != nil {
+		return fmt.Errorf("unable to get the ClusterRole %q: %w", consts.RemoteNamespaceClusterRoleName, err)
+	}
+	if err := authentication.CheckTenantRBACRules(template.Spec.OffloadedNamespaceRules, &ceiling); err != nil {
+		return fmt.Errorf("invalid offloaded namespace rules of TenantRBACTemplate %q: %w", template.Name, err)
+	}
+	return r.enforceRenderedClusterRole(ctx, tenant.Spec.ClusterID, name, template.Spec.OffloadedNamespaceRules)
+}
+
+// unbindControlPlaneClusterRoles removes the bindings of both the default and the rendered ClusterRoles of the control plane identity.
+func (r *TenantReconciler) unbindControlPlaneClusterRoles(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	rendered := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+		Name: authentication.TenantControlPlaneClusterRoleName(tenant.Spec.ClusterID)}}
+	return r.NamespaceManager.UnbindClusterRoles(ctx, tenant.Spec.ClusterID, append(slices.Clone(r.tenantClusterRoles), rendered)...)
+}
+
+// deleteRenderedClusterRoles deletes the ClusterRoles rendered for the given tenant.
+func (r *TenantReconciler) deleteRenderedClusterRoles(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	if err := r.deleteRenderedClusterRole(ctx, authentication.TenantControlPlaneClusterRoleName(tenant.Spec.ClusterID)); err != nil {
+		return err
+	}
+	return r.deleteRenderedClusterRole(ctx, authentication.TenantOffloadedNamespaceClusterRoleName(tenant.Spec.ClusterID))
+}
+
+func (r *TenantReconciler) enforceRenderedClusterRole(ctx context.Context, clusterID liqov1beta1.ClusterID,
+	name string, rules []rbacv1.PolicyRule) error {
+	forged := authentication.ForgeTenantClusterRole(name, clusterID, rules)
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	result, err := resource.CreateOrUpdate(ctx, r.Client, clusterRole, func() error {
+		clusterRole.Labels = forged.Labels
+		clusterRole.Annotations = forged.Annotations
+		clusterRole.Rules = forged.Rules
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to enforce the ClusterRole %q: %w", name, err)
+	}
+	klog.V(utils.FromResult(result)).Infof("ClusterRole %q rendered for cluster %q (with %v operation)", name, clusterID, result)
+	return nil
+}
+
+func (r *TenantReconciler) deleteRenderedClusterRole(ctx context.Context, name string) error {
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, clusterRole)); err != nil {
+		return fmt.Errorf("unable to delete the ClusterRole %q: %w", name, err)
+	}
+	return nil
+}
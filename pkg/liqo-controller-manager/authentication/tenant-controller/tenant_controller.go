@@ -29,6 +29,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
@@ -104,6 +106,7 @@ func NewTenantReconciler(cl client.Client, scheme *runtime.Scheme, config *rest.
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings/finalizers,verbs=update
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles/finalizers,verbs=update
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenantrbactemplates,verbs=get;list;watch
 
 // Reconcile manages the lifecycle of a Tenant.
 func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -129,6 +132,10 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			klog.Errorf("Unable to unbind the ClusterRolesClusterWide for the Tenant %q before deletion: %s", req.Name, err)
 			return ctrl.Result{}, err
 		}
+		if err := r.deleteRenderedClusterRoles(ctx, tenant); err != nil {
+			klog.Errorf("Unable to delete the ClusterRoles rendered for the Tenant %q before deletion: %s", req.Name, err)
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, r.enforceTenantFinalizerAbsence(ctx, tenant)
 	}
 
@@ -180,6 +187,20 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 
 	tenant.Status.TenantNamespace = tenantNamespace.Name
 
+	// render the permissions from the TenantRBACTemplate matching the tenant, if any
+
+	template, err := authentication.GetTenantRBACTemplate(ctx, r.Client, tenant.Spec.ClusterID)
+	if err != nil {
+		klog.Errorf("Unable to get the TenantRBACTemplate for the Tenant %q: %s", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureOffloadedNamespaceClusterRole(ctx, tenant, template); err != nil {
+		klog.Errorf("Unable to render the offloaded namespace ClusterRole for the Tenant %q: %s", req.Name, err)
+		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRoleRenderingFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
 	shouldRenew := false
 	defer func() {
 		errDef := r.Client.Status().Update(ctx, tenant)
@@ -199,10 +220,17 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 
 	requeueIn := time.Duration(0)
 	// If no handshake is performed, then the user is charge of creating the authentication params and bind the right permissions.
-	if authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake {
+	switch {
+	case authentication.IsControlPlaneIdentityRevoked(tenant):
+		// The credentials of a revoked identity are neither issued nor renewed, and its permissions are removed.
+		if err = r.handleControlPlaneIdentityRevoked(ctx, tenant); err != nil {
+			klog.Errorf("Unable to revoke the control plane identity of the Tenant %q: %s", req.Name, err)
+			return ctrl.Result{}, err
+		}
+	case authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake:
 		// create the CSR and forge the AuthParams
-		if tenant.Status.AuthParams != nil && len(tenant.Status.AuthParams.SignedCRT) > 0 {
-			shouldRenew, requeueIn, err = utils.ShouldRenewCertificate(tenant.Status.AuthParams.SignedCRT)
+		if utils.HasCredentials(tenant.Status.AuthParams) {
+			shouldRenew, requeueIn, err = utils.ShouldRenewAuthParams(tenant.Status.AuthParams)
 			if err != nil {
 				klog.Errorf("Unable to check if the certificate should be renewed for the Tenant %q: %s", req.Name, err)
 				r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "CertificateRenewalCheckFailed", err.Error())
@@ -237,7 +265,14 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 
 		// bind permissions
 
-		_, err = r.NamespaceManager.BindClusterRoles(ctx, tenant.Spec.ClusterID, tenant, r.tenantClusterRoles...)
+		clusterRoles, err := r.ensureControlPlaneClusterRoles(ctx, tenant, template)
+		if err != nil {
+			klog.Errorf("Unable to render the control plane ClusterRole for the Tenant %q: %s", req.Name, err)
+			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRoleRenderingFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+
+		_, err = r.NamespaceManager.BindClusterRoles(ctx, tenant.Spec.ClusterID, tenant, clusterRoles...)
 		if err != nil {
 			klog.Errorf("Unable to bind the ClusterRoles for the Tenant %q: %s", req.Name, err)
 			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesBindingFailed", err.Error())
@@ -245,6 +280,17 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		}
 	}
 
+	// The cluster-wide roles are tied to the tenant group shared by all the ResourceSlice identities,
+	// hence they are removed only when all of them are revoked.
+	if authentication.IsResourceSliceIdentityRevoked(tenant, "") {
+		if err = r.NamespaceManager.UnbindClusterRolesClusterWide(ctx, tenant.Spec.ClusterID, r.tenantClusterRolesClusterWide...); err != nil {
+			klog.Errorf("Unable to unbind the ClusterRolesClusterWide for the revoked Tenant %q: %s", req.Name, err)
+			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesClusterWideUnbindingFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueIn}, nil
+	}
+
 	// Bind cluster roles with cluster-wide scope to the tenant group.
 	// We do this even with TolerateNoHandshake since these clusterrole are tied to the tenant Group and
 	// will be used by the virtual kubelet to access cluster-wide resources (e.g., scraping remote metrics server)
@@ -354,9 +400,42 @@ func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlTenant).
 		For(&authv1beta1.Tenant{}).
 		Owns(&corev1.Namespace{}).
+		Watches(&authv1beta1.TenantRBACTemplate{}, handler.EnqueueRequestsFromMapFunc(r.tenantEnqueuer)).
 		Complete(r)
 }
 
+// tenantEnqueuer enqueues all the Tenants, since a change to a TenantRBACTemplate may affect
+// also the consumers it does not explicitly list.
+func (r *TenantReconciler) tenantEnqueuer(ctx context.Context, _ client.Object) []reconcile.Request {
+	var tenants authv1beta1.TenantList
+	if err := r.List(ctx, &tenants); err != nil {
+		klog.Errorf("Unable to list the Tenants: %s", err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(tenants.Items))
+	for i := range tenants.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&tenants.Items[i])}
+	}
+	return requests
+}
+
+// handleControlPlaneIdentityRevoked removes the permissions granted to the control plane identity of the tenant,
+// and drops its credentials from the status, so that they are no longer propagated to the consumer.
+func (r *TenantReconciler) handleControlPlaneIdentityRevoked(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	if err := r.unbindControlPlaneClusterRoles(ctx, tenant); err != nil {
+		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesUnbindingFailed", err.Error())
+		return err
+	}
+
+	if tenant.Status.AuthParams != nil {
+		klog.Infof("Control plane identity of the Tenant %q revoked", tenant.Name)
+		r.EventRecorder.Event(tenant, corev1.EventTypeNormal, "IdentityRevoked", "Control plane identity revoked")
+		tenant.Status.AuthParams = nil
+	}
+	return nil
+}
+
 func (r *TenantReconciler) handleTenantCordoned(ctx context.Context, tenant *authv1beta1.Tenant) error {
 	// Cordon all the resourceslices related to the tenant
 	resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll,
@@ -421,7 +500,7 @@ func (r *TenantReconciler) handleTenantDrained(ctx context.Context, tenant *auth
 	}
 
 	// Delete binding of cluster roles
-	if err := r.NamespaceManager.UnbindClusterRoles(ctx, tenant.Spec.ClusterID, r.tenantClusterRoles...); err != nil {
+	if err := r.unbindControlPlaneClusterRoles(ctx, tenant); err != nil {
 		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesUnbindingFailed", err.Error())
 		return err
 	}
@@ -459,5 +538,22 @@ func (r *TenantReconciler) handleTenantDrained(ctx context.Context, tenant *auth
 	}
 	r.EventRecorder.Event(tenant, corev1.EventTypeNormal, "NamespaceMapsDeleted", "NamespaceMaps deleted")
 
+	// Delete all the exportedservices related to the tenant
+	exportedServices, err := getters.ListExportedServicesByLabel(ctx, r.Client, corev1.NamespaceAll,
+		liqolabels.RemoteLabelSelectorForCluster(string(tenant.Spec.ClusterID)))
+	if err != nil {
+		klog.Errorf("Failed to retrieve ExportedServices for Tenant %q: %v", tenant.Name, err)
+		return err
+	}
+
+	for i := range exportedServices {
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, &exportedServices[i])); err != nil {
+			klog.Errorf("Failed to delete ExportedService %q for Tenant %q: %v",
+				client.ObjectKeyFromObject(&exportedServices[i]), tenant.Name, err)
+			return err
+		}
+	}
+	r.EventRecorder.Event(tenant, corev1.EventTypeNormal, "ExportedServicesDeleted", "ExportedServices deleted")
+
 	return nil
 }
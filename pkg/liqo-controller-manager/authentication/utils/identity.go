@@ -15,6 +15,7 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/pem"
@@ -71,18 +72,56 @@ func ShouldRenewCertificate(pemCert []byte) (bool, time.Duration, error) {
 		return false, requeueIn, fmt.Errorf("failed to parse certificate: %w", err)
 	}
 
+	return shouldRenew(cert.NotBefore, cert.NotAfter)
+}
+
+// ShouldRenewAuthParams determines if the credentials stored in the AuthParams have to be renewed,
+// following the same 2/3 life rule of ShouldRenewCertificate for both certificates and OIDC tokens.
+func ShouldRenewAuthParams(authParams *authv1beta1.AuthParams) (bool, time.Duration, error) {
+	if authParams.OIDCConfig != nil {
+		return shouldRenew(authParams.OIDCConfig.IssueTime.Time, authParams.OIDCConfig.ExpirationTime.Time)
+	}
+	return ShouldRenewCertificate(authParams.SignedCRT)
+}
+
+// HasCredentials checks whether the AuthParams carry renewable credentials, either a certificate or an OIDC token.
+func HasCredentials(authParams *authv1beta1.AuthParams) bool {
+	if authParams == nil {
+		return false
+	}
+	return len(authParams.SignedCRT) > 0 || (authParams.OIDCConfig != nil && authParams.OIDCConfig.Token != "")
+}
+
+// SameCredentials checks whether the two AuthParams carry the same certificate and OIDC token.
+func SameCredentials(a, b *authv1beta1.AuthParams) bool {
+	return bytes.Equal(a.SignedCRT, b.SignedCRT) && oidcToken(a) == oidcToken(b)
+}
+
+func oidcToken(authParams *authv1beta1.AuthParams) string {
+	if authParams.OIDCConfig == nil {
+		return ""
+	}
+	return authParams.OIDCConfig.Token
+}
+
+func shouldRenew(notBefore, notAfter time.Time) (bool, time.Duration, error) {
+	requeueIn := time.Duration(0)
+	if !notAfter.After(notBefore) {
+		return false, requeueIn, fmt.Errorf("invalid validity period [%s, %s]", notBefore, notAfter)
+	}
+
 	// Calculate if we need to renew based on 2/3 life rule
-	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	lifetime := notAfter.Sub(notBefore)
 	// The date of expiration minus 1/3 of the lifetime is the point where the certificate
 	// reached 2/3 of its validity, so we need to renew it.
-	twoThirdsPoint := cert.NotAfter.Add(-lifetime / 3)
+	twoThirdsPoint := notAfter.Add(-lifetime / 3)
 
 	if time.Now().Before(twoThirdsPoint) {
 		// Calculate requeue time as the remaining time until the 2/3 point of the certificate expiration time + 10%
 		timeUntilTwoThirds := time.Until(twoThirdsPoint)
 		requeueIn = timeUntilTwoThirds * 11 / 10
 
-		klog.V(4).Infof("Certificate not ready for renewal, will check again in %v", requeueIn)
+		klog.V(4).Infof("Credentials not ready for renewal, will check again in %v", requeueIn)
 		return false, requeueIn, nil
 	}
 
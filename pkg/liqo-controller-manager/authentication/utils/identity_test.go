@@ -25,6 +25,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 )
 
 // generateCertificate creates a self-signed PEM certificate with the given NotBefore and NotAfter.
@@ -140,3 +143,50 @@ var _ = Describe("ShouldRenewCertificate", func() {
 		})
 	})
 })
+
+var _ = Describe("ShouldRenewAuthParams", func() {
+	oidcAuthParams := func(issueTime, expirationTime time.Time) *authv1beta1.AuthParams {
+		return &authv1beta1.AuthParams{OIDCConfig: &authv1beta1.OIDCConfig{
+			Token:          "token",
+			IssueTime:      metav1.NewTime(issueTime),
+			ExpirationTime: metav1.NewTime(expirationTime),
+		}}
+	}
+
+	It("should not require renewal for a fresh OIDC token", func() {
+		shouldRenew, requeueIn, err := ShouldRenewAuthParams(oidcAuthParams(time.Now(), time.Now().Add(3*time.Hour)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(shouldRenew).To(BeFalse())
+		Expect(requeueIn).To(BeNumerically("~", 2*time.Hour+12*time.Minute, 5*time.Second))
+	})
+
+	It("should require renewal when the OIDC token is past 2/3 lifetime", func() {
+		shouldRenew, _, err := ShouldRenewAuthParams(oidcAuthParams(time.Now().Add(-50*time.Minute), time.Now().Add(10*time.Minute)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(shouldRenew).To(BeTrue())
+	})
+
+	It("should return an error for an invalid OIDC validity period", func() {
+		_, _, err := ShouldRenewAuthParams(oidcAuthParams(time.Now(), time.Now().Add(-time.Hour)))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fall back to the certificate", func() {
+		cert := generateCertificate(time.Now().Add(-50*time.Minute), time.Now().Add(10*time.Minute))
+		shouldRenew, _, err := ShouldRenewAuthParams(&authv1beta1.AuthParams{SignedCRT: cert})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(shouldRenew).To(BeTrue())
+	})
+
+	It("should compare the credentials of the AuthParams", func() {
+		now := time.Now()
+		Expect(HasCredentials(nil)).To(BeFalse())
+		Expect(HasCredentials(&authv1beta1.AuthParams{APIServer: "https://example.com"})).To(BeFalse())
+		Expect(HasCredentials(oidcAuthParams(now, now.Add(time.Hour)))).To(BeTrue())
+
+		Expect(SameCredentials(oidcAuthParams(now, now.Add(time.Hour)), oidcAuthParams(now, now.Add(time.Hour)))).To(BeTrue())
+		renewed := oidcAuthParams(now, now.Add(time.Hour))
+		renewed.OIDCConfig.Token = "renewed"
+		Expect(SameCredentials(oidcAuthParams(now, now.Add(time.Hour)), renewed)).To(BeFalse())
+	})
+})
@@ -18,6 +18,9 @@ const (
 	connectionEstablishedReason  = "ConnectionEstablished"
 	connectionEstablishedMessage = "The network connection with the foreign cluster is established"
 
+	connectionDegradedReason        = "ConnectionDegraded"
+	connectionDegradedMessageFormat = "The network connection with the foreign cluster is degraded: %s"
+
 	connectionPendingReason  = "ConnectionPending"
 	connectionPendingMessage = "The network connection with the foreign cluster is connecting"
 
@@ -0,0 +1,51 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package foreignclustercontroller
+
+import (
+	"time"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
+// ConnectionThresholds defines the thresholds on the health of the network connection,
+// above which the connection is considered degraded. A zero value disables the corresponding check.
+type ConnectionThresholds struct {
+	// PacketLoss is the maximum percentage of pings lost.
+	PacketLoss int32
+	// Latency is the maximum average round-trip time.
+	Latency time.Duration
+	// Jitter is the maximum average variation of the round-trip time.
+	Jitter time.Duration
+}
+
+// Check returns the list of thresholds exceeded by the given connection health.
+func (t *ConnectionThresholds) Check(health *networkingv1beta1.ConnectionHealth) []string {
+	if health == nil {
+		return nil
+	}
+
+	var violations []string
+	if t.PacketLoss > 0 && health.PacketLoss > t.PacketLoss {
+		violations = append(violations, "packet loss above threshold")
+	}
+	if t.Latency > 0 && health.AvgRTT.Duration > t.Latency {
+		violations = append(violations, "latency above threshold")
+	}
+	if t.Jitter > 0 && health.Jitter.Duration > t.Jitter {
+		violations = append(violations, "jitter above threshold")
+	}
+	return violations
+}
@@ -54,6 +54,9 @@ type ForeignClusterReconciler struct {
 	AuthenticationEnabled bool
 	OffloadingEnabled     bool
 
+	// ConnectionThresholds defines when the network connection is considered degraded.
+	ConnectionThresholds ConnectionThresholds
+
 	// Handle concurrent access to the map containing the cancel context functions of the API server checkers.
 	APIServerCheckers
 }
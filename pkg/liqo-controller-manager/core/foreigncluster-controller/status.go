@@ -16,6 +16,8 @@ package foreignclustercontroller
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -83,6 +85,12 @@ func (r *ForeignClusterReconciler) handleConnectionStatus(ctx context.Context,
 		fcutils.EnableModuleNetworking(fc)
 		switch connection.Status.Value {
 		case networkingv1beta1.Connected:
+			if violations := r.ConnectionThresholds.Check(connection.Status.Health); len(violations) > 0 {
+				fcutils.EnsureModuleCondition(&fc.Status.Modules.Networking,
+					liqov1beta1.NetworkConnectionStatusCondition, liqov1beta1.ConditionStatusDegraded,
+					connectionDegradedReason, fmt.Sprintf(connectionDegradedMessageFormat, strings.Join(violations, ", ")))
+				break
+			}
 			fcutils.EnsureModuleCondition(&fc.Status.Modules.Networking,
 				liqov1beta1.NetworkConnectionStatusCondition, liqov1beta1.ConditionStatusEstablished,
 				connectionEstablishedReason, connectionEstablishedMessage)
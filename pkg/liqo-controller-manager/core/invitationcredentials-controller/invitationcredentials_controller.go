@@ -0,0 +1,150 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitationcredentialscontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	certv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
+)
+
+// InvitationCredentialsReconciler revokes the credentials issued through the invitations, once expired
+// or as soon as the Secret recording them is deleted (e.g., by the consumer cluster, when no longer used).
+type InvitationCredentialsReconciler struct {
+	client.Client
+}
+
+// NewInvitationCredentialsReconciler returns a new InvitationCredentialsReconciler.
+func NewInvitationCredentialsReconciler(cl client.Client) *InvitationCredentialsReconciler {
+	return &InvitationCredentialsReconciler{Client: cl}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch;delete
+
+// Reconcile deletes the Secret recording the credentials issued through an invitation once they are expired,
+// and revokes the credentials before the Secret is actually removed.
+func (r *InvitationCredentialsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, req.NamespacedName, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("Invitation credentials secret %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the invitation credentials secret %q: %w", req.NamespacedName, err)
+	}
+
+	if secret.DeletionTimestamp.IsZero() {
+		expiration, err := invitation.CredentialsExpiration(&secret)
+		if err != nil {
+			// The secret cannot be fixed without changing it, which triggers a new reconciliation.
+			klog.Warningf("Invalid invitation credentials secret %q: %v", req.NamespacedName, err)
+			return ctrl.Result{}, nil
+		}
+		if remaining := time.Until(expiration); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+
+		// The credentials are revoked as the secret is being deleted.
+		if err := client.IgnoreNotFound(r.Delete(ctx, &secret)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to delete the expired invitation credentials secret %q: %w", req.NamespacedName, err)
+		}
+		klog.Infof("Expired invitation credentials secret %q deleted", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&secret, invitation.CredentialsFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// The peering user is identified through the tenant namespace, rather than the secret itself,
+	// since the consumer cluster is allowed to create secrets in that namespace.
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: secret.Namespace}, &namespace); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to get the namespace of the invitation credentials secret %q: %w", req.NamespacedName, err)
+	}
+	if _, tenant := namespace.Labels[consts.TenantNamespaceLabel]; tenant && namespace.Labels[consts.RemoteClusterID] != "" {
+		userName := authentication.PeeringUserName(liqov1beta1.ClusterID(namespace.Labels[consts.RemoteClusterID]))
+		if err := r.revoke(ctx, userName); err != nil {
+			return ctrl.Result{}, err
+		}
+		klog.Infof("Credentials of peering user %q issued through invitation %q revoked", userName, req.NamespacedName)
+	}
+
+	controllerutil.RemoveFinalizer(&secret, invitation.CredentialsFinalizer)
+	if err := r.Update(ctx, &secret); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to remove the finalizer from the invitation credentials secret %q: %w", req.NamespacedName, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// revoke deletes the RoleBindings granting permissions to the given peering user, and the CertificateSigningRequest
+// of its certificate. Although the certificate is still valid until expiration, it no longer grants any permission.
+func (r *InvitationCredentialsReconciler) revoke(ctx context.Context, userName string) error {
+	selector := client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(labels.Set{consts.PeeringUserNameLabelKey: userName})}
+
+	var bindings rbacv1.RoleBindingList
+	if err := r.List(ctx, &bindings, selector); err != nil {
+		return fmt.Errorf("unable to list the RoleBindings of peering user %q: %w", userName, err)
+	}
+	for i := range bindings.Items {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &bindings.Items[i])); err != nil {
+			return fmt.Errorf("unable to delete RoleBinding %q: %w", client.ObjectKeyFromObject(&bindings.Items[i]), err)
+		}
+	}
+
+	var csrs certv1.CertificateSigningRequestList
+	if err := r.List(ctx, &csrs, selector); err != nil {
+		return fmt.Errorf("unable to list the CertificateSigningRequests of peering user %q: %w", userName, err)
+	}
+	for i := range csrs.Items {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &csrs.Items[i])); err != nil {
+			return fmt.Errorf("unable to delete CertificateSigningRequest %q: %w", csrs.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager registers a new controller for the Secrets recording the credentials issued through the invitations.
+func (r *InvitationCredentialsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	filter, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{MatchLabels: map[string]string{invitation.CredentialsLabelKey: "true"}})
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlInvitationCredentials).
+		For(&corev1.Secret{}, builder.WithPredicates(filter)).
+		Complete(r)
+}
@@ -0,0 +1,144 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitationcredentialscontroller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	certv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
+)
+
+var _ = Describe("InvitationCredentials controller", func() {
+	const tenantNamespace = "liqo-tenant-consumer"
+
+	var (
+		ctx        context.Context
+		expiration time.Time
+		secret     *corev1.Secret
+		reconciler *InvitationCredentialsReconciler
+		result     ctrl.Result
+		err        error
+	)
+
+	forgeRoleBinding := func(name, namespace string, clusterID liqov1beta1.ClusterID) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: namespace,
+			Labels: map[string]string{consts.PeeringUserNameLabelKey: authentication.PeeringUserName(clusterID)},
+		}}
+	}
+
+	exists := func(obj client.Object) bool {
+		err := reconciler.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	reconcile := func() {
+		result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		expiration = time.Now().Add(time.Hour)
+	})
+
+	JustBeforeEach(func() {
+		secret = invitation.CredentialsSecret("consumer", tenantNamespace, metav1.NewTime(expiration))
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tenantNamespace, Labels: map[string]string{
+				consts.TenantNamespaceLabel: "true",
+				consts.RemoteClusterID:      "consumer",
+			}}},
+			secret.DeepCopy(),
+			forgeRoleBinding("liqo-ns-reader", "liqo", "consumer"),
+			forgeRoleBinding("tenant-ns-writer", tenantNamespace, "consumer"),
+			forgeRoleBinding("other-tenant-ns-writer", "liqo-tenant-other", "other"),
+			&certv1.CertificateSigningRequest{ObjectMeta: metav1.ObjectMeta{
+				Name:   authentication.PeeringUserName("consumer"),
+				Labels: map[string]string{consts.PeeringUserNameLabelKey: authentication.PeeringUserName("consumer")},
+			}},
+		).Build()
+		reconciler = NewInvitationCredentialsReconciler(cl)
+		reconcile()
+	})
+
+	When("the credentials are not expired", func() {
+		It("should keep them and requeue the secret at their expiration", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(secret)).To(BeTrue())
+			Expect(exists(forgeRoleBinding("tenant-ns-writer", tenantNamespace, "consumer"))).To(BeTrue())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		})
+	})
+
+	When("the credentials are expired", func() {
+		BeforeEach(func() { expiration = time.Now().Add(-time.Minute) })
+
+		It("should revoke the credentials and delete the secret", func() {
+			Expect(err).ToNot(HaveOccurred())
+			// The first reconciliation deletes the secret, while the second one processes the finalizer.
+			reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(exists(secret)).To(BeFalse())
+			Expect(exists(forgeRoleBinding("liqo-ns-reader", "liqo", "consumer"))).To(BeFalse())
+			Expect(exists(forgeRoleBinding("tenant-ns-writer", tenantNamespace, "consumer"))).To(BeFalse())
+			Expect(exists(&certv1.CertificateSigningRequest{ObjectMeta: metav1.ObjectMeta{
+				Name: authentication.PeeringUserName("consumer")}})).To(BeFalse())
+		})
+
+		It("should not revoke the credentials of other peering users", func() {
+			reconcile()
+			Expect(exists(forgeRoleBinding("other-tenant-ns-writer", "liqo-tenant-other", "other"))).To(BeTrue())
+		})
+	})
+
+	When("the secret is deleted by the consumer cluster", func() {
+		JustBeforeEach(func() {
+			Expect(reconciler.Delete(ctx, secret)).To(Succeed())
+			reconcile()
+		})
+
+		It("should revoke the credentials before the secret is removed", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(secret)).To(BeFalse())
+			Expect(exists(forgeRoleBinding("tenant-ns-writer", tenantNamespace, "consumer"))).To(BeFalse())
+			Expect(exists(forgeRoleBinding("other-tenant-ns-writer", "liqo-tenant-other", "other"))).To(BeTrue())
+		})
+	})
+})
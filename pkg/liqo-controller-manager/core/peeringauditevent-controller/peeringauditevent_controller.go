@@ -0,0 +1,82 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringauditeventcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// PeeringAuditEventReconciler deletes the PeeringAuditEvents whose last occurrence is older than the retention period.
+type PeeringAuditEventReconciler struct {
+	client.Client
+
+	Retention time.Duration
+}
+
+// NewPeeringAuditEventReconciler returns a new PeeringAuditEventReconciler.
+func NewPeeringAuditEventReconciler(cl client.Client, retention time.Duration) *PeeringAuditEventReconciler {
+	return &PeeringAuditEventReconciler{
+		Client:    cl,
+		Retention: retention,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=core.liqo.io,resources=peeringauditevents,verbs=get;list;watch;delete
+
+// Reconcile deletes a PeeringAuditEvent once its retention period has elapsed.
+// A zero retention disables the garbage collection of the events.
+func (r *PeeringAuditEventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.Retention <= 0 {
+		return ctrl.Result{}, nil
+	}
+
+	var event liqov1beta1.PeeringAuditEvent
+	if err := r.Get(ctx, req.NamespacedName, &event); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("PeeringAuditEvent %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the PeeringAuditEvent %q: %w", req.NamespacedName, err)
+	}
+
+	expiration := event.Spec.LastTimestamp.Add(r.Retention)
+	if remaining := time.Until(expiration); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if err := client.IgnoreNotFound(r.Delete(ctx, &event)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to delete the expired PeeringAuditEvent %q: %w", req.NamespacedName, err)
+	}
+	klog.V(4).Infof("Expired PeeringAuditEvent %q deleted", req.NamespacedName)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers a new controller for PeeringAuditEvents.
+func (r *PeeringAuditEventReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlPeeringAuditEvent).
+		For(&liqov1beta1.PeeringAuditEvent{}).
+		Complete(r)
+}
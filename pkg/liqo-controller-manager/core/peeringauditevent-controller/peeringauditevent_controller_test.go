@@ -0,0 +1,77 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringauditeventcontroller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+var _ = Describe("PeeringAuditEvent controller", func() {
+	var (
+		ctx        context.Context
+		event      *liqov1beta1.PeeringAuditEvent
+		reconciler *PeeringAuditEventReconciler
+		result     ctrl.Result
+		err        error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		event = &liqov1beta1.PeeringAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "liqo-tenant-consumer"},
+			Spec:       liqov1beta1.PeeringAuditEventSpec{ClusterID: "consumer", Count: 1},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(liqov1beta1.AddToScheme(scheme)).To(Succeed())
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(event).Build()
+		reconciler = NewPeeringAuditEventReconciler(cl, time.Hour)
+		result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(event)})
+	})
+
+	When("the retention period has not elapsed", func() {
+		BeforeEach(func() { event.Spec.LastTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Minute)) })
+
+		It("should keep the event and requeue it at its expiration", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(event), &liqov1beta1.PeeringAuditEvent{})).To(Succeed())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 50*time.Minute, time.Minute))
+		})
+	})
+
+	When("the retention period has elapsed", func() {
+		BeforeEach(func() { event.Spec.LastTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour)) })
+
+		It("should delete the event", func() {
+			Expect(err).ToNot(HaveOccurred())
+			err := reconciler.Get(ctx, client.ObjectKeyFromObject(event), &liqov1beta1.PeeringAuditEvent{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
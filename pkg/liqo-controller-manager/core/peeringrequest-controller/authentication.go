@@ -0,0 +1,157 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringrequestcontroller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	authforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/forge"
+	authgetters "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/getters"
+	authutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// defaultResourceSliceClass is the class of the ResourceSlice created when not specified.
+const defaultResourceSliceClass = "default"
+
+// ensureNonce ensures the provider cluster generates the nonce for the authentication challenge of the consumer cluster.
+func (p *peering) ensureNonce(ctx context.Context) (bool, error) {
+	if err := authutils.EnsureNonceSecret(ctx, p.provider.client, p.consumer.clusterID, p.provider.tenantNamespace); err != nil {
+		return false, fmt.Errorf("unable to ensure the nonce secret in the provider cluster: %w", err)
+	}
+
+	secret, err := getters.GetNonceSecretByClusterID(ctx, p.provider.client, p.consumer.clusterID, p.provider.tenantNamespace)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	nonce, err := authgetters.GetNonceFromSecret(secret)
+	if err != nil {
+		// The nonce has not been generated yet.
+		return false, nil
+	}
+	p.nonce = nonce
+	return true, nil
+}
+
+// ensureSignedNonce ensures the consumer cluster signs the nonce generated by the provider cluster.
+func (p *peering) ensureSignedNonce(ctx context.Context) (bool, error) {
+	if err := authutils.EnsureSignedNonceSecret(ctx, p.consumer.client, p.provider.clusterID,
+		p.consumer.tenantNamespace, ptr.To(string(p.nonce))); err != nil {
+		return false, fmt.Errorf("unable to ensure the signed nonce secret in the consumer cluster: %w", err)
+	}
+
+	secret, err := getters.GetSignedNonceSecretByClusterID(ctx, p.consumer.client, p.provider.clusterID, p.consumer.tenantNamespace)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	signedNonce, err := authgetters.GetSignedNonceFromSecret(secret)
+	if err != nil {
+		// The nonce has not been signed yet.
+		return false, nil
+	}
+	p.signedNonce = signedNonce
+	return true, nil
+}
+
+// ensureTenant creates the Tenant of the consumer cluster in the provider cluster, and waits for it to be accepted.
+func (p *peering) ensureTenant(ctx context.Context) (bool, error) {
+	tenant, err := authutils.GenerateTenant(ctx, p.consumer.client, p.consumer.clusterID, p.consumer.liqoNamespace,
+		p.provider.tenantNamespace, p.signedNonce, ptr.To(""))
+	if err != nil {
+		return false, fmt.Errorf("unable to generate the tenant: %w", err)
+	}
+
+	desired := tenant.DeepCopy()
+	if _, err := resource.CreateOrUpdate(ctx, p.provider.client, tenant, func() error {
+		tenant.Labels = desired.Labels
+		tenant.Annotations = desired.Annotations
+		authforge.MutateTenantSpec(tenant, &desired.Spec)
+		return nil
+	}); err != nil {
+		return false, fmt.Errorf("unable to apply the tenant in the provider cluster: %w", err)
+	}
+
+	current, err := getters.GetTenantByClusterID(ctx, p.provider.client, p.consumer.clusterID, p.provider.tenantNamespace)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return current.Status.AuthParams != nil && current.Status.TenantNamespace != "", nil
+}
+
+// ensureIdentity creates the Identity granted by the provider cluster in the consumer cluster, and waits for it to be ready.
+func (p *peering) ensureIdentity(ctx context.Context) (bool, error) {
+	identity, err := authutils.GenerateIdentityControlPlane(ctx, p.provider.client, p.consumer.clusterID,
+		p.consumer.tenantNamespace, p.provider.clusterID, &p.provider.tenantNamespace)
+	if err != nil {
+		return false, fmt.Errorf("unable to generate the identity: %w", err)
+	}
+
+	desired := identity.DeepCopy()
+	if _, err := resource.CreateOrUpdate(ctx, p.consumer.client, identity, func() error {
+		identity.Labels = desired.Labels
+		identity.Annotations = desired.Annotations
+		identity.Spec = desired.Spec
+		return nil
+	}); err != nil {
+		return false, fmt.Errorf("unable to apply the identity in the consumer cluster: %w", err)
+	}
+
+	current, err := getters.GetControlPlaneIdentityByClusterID(ctx, p.consumer.client, p.provider.clusterID)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return current.Status.KubeconfigSecretRef != nil && current.Status.KubeconfigSecretRef.Name != "", nil
+}
+
+// ensureResourceSlice creates the ResourceSlice towards the provider cluster, and waits for it to be authenticated.
+func (p *peering) ensureResourceSlice(ctx context.Context) (bool, error) {
+	spec := p.spec.ResourceSlice
+	rs := authforge.ResourceSlice(string(p.provider.clusterID), p.consumer.tenantNamespace)
+	if _, err := resource.CreateOrUpdate(ctx, p.consumer.client, rs, func() error {
+		return authforge.MutateResourceSlice(rs, p.provider.clusterID, resourceSliceOptions(spec), !spec.DisableVirtualNodeCreation)
+	}); err != nil {
+		return false, fmt.Errorf("unable to apply the ResourceSlice: %w", err)
+	}
+
+	condition := authentication.GetCondition(rs, authv1beta1.ResourceSliceConditionTypeAuthentication)
+	return condition != nil && condition.Status == authv1beta1.ResourceSliceConditionAccepted, nil
+}
+
+// resourceSliceOptions returns the options to forge the ResourceSlice, according to the PeeringRequest spec.
+func resourceSliceOptions(spec *liqov1beta1.PeeringRequestResourceSlice) *authforge.ResourceSliceOptions {
+	class := defaultResourceSliceClass
+	if spec.Class != "" {
+		class = spec.Class
+	}
+
+	resources := make(map[corev1.ResourceName]string, len(spec.Resources))
+	for name, quantity := range spec.Resources {
+		resources[name] = quantity.String()
+	}
+
+	return &authforge.ResourceSliceOptions{
+		Class:     authv1beta1.ResourceSliceClass(class),
+		Resources: resources,
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringrequestcontroller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	gwforge "github.com/liqotech/liqo/pkg/gateway/forge"
+	nwforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+	nwgetters "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/getters"
+	networkingutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/utils"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// gatewayClusters returns the clusters hosting the gateway server and the gateway client, respectively.
+func (p *peering) gatewayClusters() (server, cl *peeringCluster) {
+	if p.spec.Networking.GatewayServerLocation == liqov1beta1.ConsumerRole {
+		return p.consumer, p.provider
+	}
+	return p.provider, p.consumer
+}
+
+// ensureConfigurations exchanges the network configurations between the clusters,
+// and waits for the remapped CIDRs to be set in both clusters.
+func (p *peering) ensureConfigurations(ctx context.Context) (bool, error) {
+	done := true
+	for _, pair := range [][2]*peeringCluster{{p.consumer, p.provider}, {p.provider, p.consumer}} {
+		local, remote := pair[0], pair[1]
+
+		conf, err := nwforge.ConfigurationForRemoteCluster(ctx, remote.client, remote.tenantNamespace, remote.liqoNamespace)
+		if err != nil {
+			return false, fmt.Errorf("unable to forge the network configuration of cluster %q: %w", remote.clusterID, err)
+		}
+		conf.Namespace = local.tenantNamespace
+		desired := conf.DeepCopy()
+		if _, err := resource.CreateOrUpdate(ctx, local.client, conf, func() error {
+			if conf.Labels == nil {
+				conf.Labels = make(map[string]string)
+			}
+			conf.Labels[consts.RemoteClusterID] = string(remote.clusterID)
+			conf.Spec.Remote = desired.Spec.Remote
+			return nil
+		}); err != nil {
+			return false, fmt.Errorf("unable to apply the network configuration in cluster %q: %w", local.clusterID, err)
+		}
+
+		current, err := getters.GetConfigurationByClusterID(ctx, local.client, remote.clusterID, local.tenantNamespace)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		done = done && networkingutils.AreConfigurationNetworkCIDRsConfigured(current)
+	}
+	return done, nil
+}
+
+// ensureGatewayServer creates the GatewayServer, and waits for it to be ready and exposed.
+// If the inter-cluster network is already established with the gateways in the opposite location,
+// the existing gateways are left untouched.
+func (p *peering) ensureGatewayServer(ctx context.Context) (bool, error) {
+	server, cl := p.gatewayClusters()
+
+	reverse, err := p.isReverseNetworkingEstablished(ctx)
+	if err != nil || reverse {
+		p.reverseNetworking = reverse
+		return reverse, err
+	}
+
+	var name *string
+	existing, err := getters.GetGatewayServerByClusterID(ctx, server.client, cl.clusterID, server.tenantNamespace)
+	switch {
+	case err == nil:
+		name = &existing.Name
+	case !apierrors.IsNotFound(err):
+		return false, fmt.Errorf("unable to get the gateway server in cluster %q: %w", server.clusterID, err)
+	}
+
+	opts := gatewayServerOptions(&p.spec.Networking, server, cl)
+	gwServer, err := nwforge.GatewayServer(server.tenantNamespace, name, opts)
+	if err != nil {
+		return false, fmt.Errorf("unable to forge the gateway server: %w", err)
+	}
+	if _, err := resource.CreateOrUpdate(ctx, server.client, gwServer, func() error {
+		return nwforge.MutateGatewayServer(gwServer, opts)
+	}); err != nil {
+		return false, fmt.Errorf("unable to apply the gateway server in cluster %q: %w", server.clusterID, err)
+	}
+	p.gwServer = gwServer
+
+	ready, err := isGatewayReady(ctx, server.client, gwServer)
+	if err != nil || !ready {
+		return false, err
+	}
+	return gwServer.Status.Endpoint != nil && len(gwServer.Status.Endpoint.Addresses) > 0, nil
+}
+
+// ensureGatewayClient creates the GatewayClient connecting to the endpoint of the GatewayServer,
+// and waits for it to be ready.
+func (p *peering) ensureGatewayClient(ctx context.Context) (bool, error) {
+	if p.reverseNetworking {
+		return true, nil
+	}
+	server, cl := p.gatewayClusters()
+
+	var name *string
+	existing, err := getters.GetGatewayClientByClusterID(ctx, cl.client, server.clusterID, cl.tenantNamespace)
+	switch {
+	case err == nil:
+		name = &existing.Name
+	case !apierrors.IsNotFound(err):
+		return false, fmt.Errorf("unable to get the gateway client in cluster %q: %w", cl.clusterID, err)
+	}
+
+	opts := gatewayClientOptions(&p.spec.Networking, cl, server, p.gwServer.Status.Endpoint)
+	gwClient, err := nwforge.GatewayClient(cl.tenantNamespace, name, opts)
+	if err != nil {
+		return false, fmt.Errorf("unable to forge the gateway client: %w", err)
+	}
+	if _, err := resource.CreateOrUpdate(ctx, cl.client, gwClient, func() error {
+		return nwforge.MutateGatewayClient(gwClient, opts)
+	}); err != nil {
+		return false, fmt.Errorf("unable to apply the gateway client in cluster %q: %w", cl.clusterID, err)
+	}
+	p.gwClient = gwClient
+
+	return isGatewayReady(ctx, cl.client, gwClient)
+}
+
+// ensurePublicKeys exchanges the public keys of the gateways, as soon as they have been generated.
+func (p *peering) ensurePublicKeys(ctx context.Context) (bool, error) {
+	if p.reverseNetworking {
+		return true, nil
+	}
+	server, cl := p.gatewayClusters()
+
+	if p.gwServer.Status.SecretRef == nil || p.gwClient.Status.SecretRef == nil {
+		return false, nil
+	}
+
+	serverKey, err := nwgetters.ExtractKeyFromSecretRef(ctx, server.client, p.gwServer.Status.SecretRef)
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve the public key of the gateway server: %w", err)
+	}
+	if err := ensurePublicKey(ctx, cl, server.clusterID, serverKey, p.gwClient); err != nil {
+		return false, err
+	}
+
+	clientKey, err := nwgetters.ExtractKeyFromSecretRef(ctx, cl.client, p.gwClient.Status.SecretRef)
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve the public key of the gateway client: %w", err)
+	}
+	if err := ensurePublicKey(ctx, server, cl.clusterID, clientKey, p.gwServer); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkConnections waits for the Connections in both clusters to be established.
+func (p *peering) checkConnections(ctx context.Context) (bool, error) {
+	for _, pair := range [][2]*peeringCluster{{p.consumer, p.provider}, {p.provider, p.consumer}} {
+		local, remote := pair[0], pair[1]
+
+		selector := labels.Set{consts.RemoteClusterID: string(remote.clusterID)}.AsSelector()
+		connections, err := getters.ListConnectionsByLabel(ctx, local.client, local.tenantNamespace, selector)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		switch {
+		case len(connections.Items) > 1:
+			return false, fmt.Errorf("more than one Connection found in cluster %q for remote cluster %q",
+				local.clusterID, remote.clusterID)
+		case len(connections.Items) == 0 || connections.Items[0].Status.Value != networkingv1beta1.Connected:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isReverseNetworkingEstablished returns whether the gateways already exist in the opposite location.
+func (p *peering) isReverseNetworkingEstablished(ctx context.Context) (bool, error) {
+	server, cl := p.gatewayClusters()
+
+	_, err := getters.GetGatewayClientByClusterID(ctx, server.client, cl.clusterID, server.tenantNamespace)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("unable to get the gateway client in cluster %q: %w", server.clusterID, err)
+	}
+	if err == nil {
+		return true, nil
+	}
+
+	_, err = getters.GetGatewayServerByClusterID(ctx, cl.client, server.clusterID, cl.tenantNamespace)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("unable to get the gateway server in cluster %q: %w", cl.clusterID, err)
+	}
+	return err == nil, nil
+}
+
+// ensurePublicKey creates the PublicKey of the remote gateway in the given cluster, owned by the local gateway.
+func ensurePublicKey(ctx context.Context, local *peeringCluster, remoteClusterID liqov1beta1.ClusterID,
+	key []byte, owner client.Object) error {
+	var name *string
+	existing, err := getters.GetPublicKeyByClusterID(ctx, local.client, remoteClusterID, local.tenantNamespace)
+	switch {
+	case err == nil:
+		name = &existing.Name
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("unable to get the public key in cluster %q: %w", local.clusterID, err)
+	}
+
+	pubKey, err := nwforge.PublicKey(local.tenantNamespace, name, remoteClusterID, key)
+	if err != nil {
+		return fmt.Errorf("unable to forge the public key: %w", err)
+	}
+	if _, err := resource.CreateOrUpdate(ctx, local.client, pubKey, func() error {
+		if err := nwforge.MutatePublicKey(pubKey, remoteClusterID, key); err != nil {
+			return err
+		}
+		return controllerutil.SetOwnerReference(owner, pubKey, local.client.Scheme())
+	}); err != nil {
+		return fmt.Errorf("unable to apply the public key in cluster %q: %w", local.clusterID, err)
+	}
+	return nil
+}
+
+// isGatewayReady returns whether the pod of the given gateway is ready.
+func isGatewayReady(ctx context.Context, cl client.Client, gateway client.Object) (bool, error) {
+	var deployment appsv1.Deployment
+	key := types.NamespacedName{Namespace: gateway.GetNamespace(), Name: gwforge.GatewayResourceName(gateway.GetName())}
+	if err := cl.Get(ctx, key, &deployment); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return deployment.Status.ReadyReplicas > 0, nil
+}
+
+// gatewayServerOptions returns the options to forge the GatewayServer, according to the PeeringRequest spec.
+func gatewayServerOptions(spec *liqov1beta1.PeeringRequestNetworking, server, cl *peeringCluster) *nwforge.GwServerOptions {
+	serviceType := nwforge.DefaultGwServerServiceType
+	if spec.ServiceType != "" {
+		serviceType = spec.ServiceType
+	}
+
+	return &nwforge.GwServerOptions{
+		KubeClient:        server.kubeClient,
+		RemoteClusterID:   cl.clusterID,
+		GatewayType:       nwforge.DefaultGwServerType,
+		TemplateName:      nwforge.DefaultGwServerTemplateName,
+		TemplateNamespace: server.liqoNamespace,
+		ServiceType:       serviceType,
+		MTU:               mtu(spec),
+		Port:              nwforge.DefaultGwServerPort,
+		NodePort:          ptr.To[int32](0),
+		LoadBalancerIP:    ptr.To(""),
+	}
+}
+
+// gatewayClientOptions returns the options to forge the GatewayClient, connecting to the given endpoint.
+func gatewayClientOptions(spec *liqov1beta1.PeeringRequestNetworking, cl, server *peeringCluster,
+	endpoint *networkingv1beta1.EndpointStatus) *nwforge.GwClientOptions {
+	protocol := nwforge.DefaultProtocol
+	if endpoint.Protocol != nil {
+		protocol = string(*endpoint.Protocol)
+	}
+
+	return &nwforge.GwClientOptions{
+		KubeClient:        cl.kubeClient,
+		RemoteClusterID:   server.clusterID,
+		GatewayType:       nwforge.DefaultGwClientType,
+		TemplateName:      nwforge.DefaultGwClientTemplateName,
+		TemplateNamespace: cl.liqoNamespace,
+		MTU:               mtu(spec),
+		Addresses:         endpoint.Addresses,
+		Port:              endpoint.Port,
+		Protocol:          protocol,
+	}
+}
+
+func mtu(spec *liqov1beta1.PeeringRequestNetworking) int {
+	if spec.MTU != 0 {
+		return spec.MTU
+	}
+	return nwforge.DefaultMTU
+}
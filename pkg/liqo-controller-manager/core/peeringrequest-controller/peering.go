@@ -0,0 +1,123 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringrequestcontroller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+)
+
+// peeringCluster groups the clients and the identifiers of one of the two clusters involved in a peering.
+type peeringCluster struct {
+	client           client.Client
+	kubeClient       kubernetes.Interface
+	namespaceManager tenantnamespace.Manager
+
+	clusterID       liqov1beta1.ClusterID
+	liqoNamespace   string
+	tenantNamespace string
+}
+
+// peering holds the state of the peering established by a PeeringRequest during a single reconciliation.
+type peering struct {
+	spec     *liqov1beta1.PeeringRequestSpec
+	consumer *peeringCluster
+	provider *peeringCluster
+
+	// reverseNetworking is set when the inter-cluster network is already established with the gateways
+	// in the opposite location, hence it must be left untouched.
+	reverseNetworking bool
+	gwServer          *networkingv1beta1.GatewayServer
+	gwClient          *networkingv1beta1.GatewayClient
+
+	nonce       []byte
+	signedNonce []byte
+}
+
+// step is a step of the peering. It is idempotent, and returns whether the resources it manages are ready,
+// so that the following steps can be performed.
+type step struct {
+	phase   liqov1beta1.PeeringRequestPhase
+	message string
+	run     func(ctx context.Context) (done bool, err error)
+}
+
+// steps returns the steps to establish the peering declared by the PeeringRequest, in order.
+func (p *peering) steps() []step {
+	firstPhase := liqov1beta1.PeeringRequestPhaseNetworking
+	if p.spec.Networking.Disabled {
+		firstPhase = liqov1beta1.PeeringRequestPhaseAuthenticating
+	}
+	steps := []step{
+		{firstPhase, "Creating the tenant namespaces", p.ensureTenantNamespaces},
+	}
+
+	if !p.spec.Networking.Disabled {
+		phase := liqov1beta1.PeeringRequestPhaseNetworking
+		steps = append(steps,
+			step{phase, "Waiting for the network configurations to be ready", p.ensureConfigurations},
+			step{phase, "Waiting for the gateway server to be ready", p.ensureGatewayServer},
+			step{phase, "Waiting for the gateway client to be ready", p.ensureGatewayClient},
+			step{phase, "Waiting for the gateway public keys to be exchanged", p.ensurePublicKeys},
+			step{phase, "Waiting for the inter-cluster connection to be established", p.checkConnections},
+		)
+	}
+
+	phase := liqov1beta1.PeeringRequestPhaseAuthenticating
+	steps = append(steps,
+		step{phase, "Waiting for the provider cluster to generate the authentication nonce", p.ensureNonce},
+		step{phase, "Waiting for the authentication nonce to be signed", p.ensureSignedNonce},
+		step{phase, "Waiting for the Tenant to be accepted by the provider cluster", p.ensureTenant},
+		step{phase, "Waiting for the Identity of the provider cluster to be ready", p.ensureIdentity},
+	)
+
+	if p.spec.ResourceSlice != nil {
+		steps = append(steps,
+			step{liqov1beta1.PeeringRequestPhaseOffloading, "Waiting for the ResourceSlice to be accepted", p.ensureResourceSlice})
+	}
+
+	return steps
+}
+
+// ensureTenantNamespaces ensures the tenant namespaces exist in both clusters.
+func (p *peering) ensureTenantNamespaces(ctx context.Context) (bool, error) {
+	ns, err := p.consumer.namespaceManager.CreateNamespace(ctx, p.provider.clusterID)
+	if err != nil {
+		return false, fmt.Errorf("unable to create the tenant namespace in the consumer cluster: %w", err)
+	}
+	p.consumer.tenantNamespace = ns.Name
+
+	ns, err = p.provider.namespaceManager.CreateNamespace(ctx, p.consumer.clusterID)
+	if apierrors.IsForbidden(err) {
+		// The invitation user may not be allowed to create namespaces, if the tenant namespace was created in advance.
+		klog.V(4).Infof("Not allowed to create the tenant namespace in cluster %q, retrieving the existing one", p.provider.clusterID)
+		ns, err = p.provider.namespaceManager.GetNamespace(ctx, p.consumer.clusterID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to ensure the tenant namespace in the provider cluster: %w", err)
+	}
+	p.provider.tenantNamespace = ns.Name
+
+	return true, nil
+}
@@ -0,0 +1,351 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringrequestcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	liqoutils "github.com/liqotech/liqo/pkg/utils"
+)
+
+const (
+	// stepRequeueInterval is the interval after which a PeeringRequest is reconciled again,
+	// while waiting for the resources created by the current step to be ready.
+	stepRequeueInterval = 5 * time.Second
+
+	// peeringRequestControllerFinalizer ensures that the credentials issued through the invitation
+	// are released once the PeeringRequest is deleted.
+	peeringRequestControllerFinalizer = "peeringrequest-controller.liqo.io/finalizer"
+)
+
+// PeeringRequestReconciler establishes the peering with the provider clusters referenced by the PeeringRequests,
+// leveraging the credentials carried by the invitation tokens.
+// The peering is established step by step: each reconciliation performs the steps whose preconditions are met,
+// and requeues the PeeringRequest until the resources created by the current step are ready.
+type PeeringRequestReconciler struct {
+	client.Client
+	KubeClient       kubernetes.Interface
+	Scheme           *runtime.Scheme
+	NamespaceManager tenantnamespace.Manager
+	// NewRemoteClient returns the client towards the provider cluster, given the configuration carried by the invitation.
+	NewRemoteClient func(config *rest.Config) (client.Client, error)
+
+	LocalClusterID liqov1beta1.ClusterID
+	LiqoNamespace  string
+}
+
+// NewPeeringRequestReconciler returns a new PeeringRequestReconciler.
+func NewPeeringRequestReconciler(cl client.Client, kubeClient kubernetes.Interface, scheme *runtime.Scheme,
+	namespaceManager tenantnamespace.Manager, localClusterID liqov1beta1.ClusterID, liqoNamespace string) *PeeringRequestReconciler {
+	return &PeeringRequestReconciler{
+		Client:           cl,
+		KubeClient:       kubeClient,
+		Scheme:           scheme,
+		NamespaceManager: namespaceManager,
+		NewRemoteClient: func(config *rest.Config) (client.Client, error) {
+			return client.New(config, client.Options{Scheme: scheme})
+		},
+
+		LocalClusterID: localClusterID,
+		LiqoNamespace:  liqoNamespace,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=core.liqo.io,resources=peeringrequests,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core.liqo.io,resources=peeringrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile establishes the peering declared by a PeeringRequest.
+func (r *PeeringRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pr liqov1beta1.PeeringRequest
+	if err := r.Get(ctx, req.NamespacedName, &pr); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("PeeringRequest %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the PeeringRequest %q: %w", req.NamespacedName, err)
+	}
+
+	if !pr.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.handleDeletion(ctx, &pr)
+	}
+
+	if controllerutil.AddFinalizer(&pr, peeringRequestControllerFinalizer) {
+		if err := r.Update(ctx, &pr); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to add the finalizer to PeeringRequest %q: %w", req.NamespacedName, err)
+		}
+	}
+
+	// The peering is already established for the current spec.
+	if pr.Status.Phase == liqov1beta1.PeeringRequestPhaseEstablished && pr.Status.ObservedGeneration == pr.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	inv, err := r.getInvitation(ctx, &pr)
+	if err != nil {
+		// The invitation cannot be fixed without changing the PeeringRequest or the referenced Secret,
+		// which triggers a new reconciliation.
+		klog.Warningf("Invalid invitation for PeeringRequest %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, r.setPhase(ctx, &pr, liqov1beta1.PeeringRequestPhaseFailed, err.Error())
+	}
+
+	pr.Status.RemoteClusterID = inv.ProviderClusterID
+	pr.Status.ExpirationTime = inv.ExpirationTime.DeepCopy()
+
+	p, err := r.newPeering(ctx, &pr, inv)
+	if err != nil {
+		return ctrl.Result{}, r.stepFailed(ctx, &pr, pr.Status.Phase, err)
+	}
+
+	for _, s := range p.steps() {
+		done, err := s.run(ctx)
+		if err != nil {
+			return ctrl.Result{}, r.stepFailed(ctx, &pr, s.phase, err)
+		}
+		if !done {
+			klog.V(4).Infof("PeeringRequest %q: %s", req.NamespacedName, s.message)
+			return ctrl.Result{RequeueAfter: stepRequeueInterval}, r.setPhase(ctx, &pr, s.phase, s.message)
+		}
+	}
+
+	pr.Status.ObservedGeneration = pr.Generation
+	if err := r.setPhase(ctx, &pr, liqov1beta1.PeeringRequestPhaseEstablished,
+		fmt.Sprintf("Peering with cluster %q established", inv.ProviderClusterID)); err != nil {
+		return ctrl.Result{}, err
+	}
+	klog.Infof("Peering with cluster %q established for PeeringRequest %q", inv.ProviderClusterID, req.NamespacedName)
+
+	return ctrl.Result{}, nil
+}
+
+// getInvitation retrieves, verifies and validates the invitation referenced by the PeeringRequest.
+func (r *PeeringRequestReconciler) getInvitation(ctx context.Context, pr *liqov1beta1.PeeringRequest) (*invitation.Invitation, error) {
+	inv, err := r.parseInvitation(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expiration is only relevant before the peering is established for the first time,
+	// while the credentials are required to reconfigure it afterwards.
+	if err := inv.Validate(r.LocalClusterID, time.Now()); err != nil {
+		if !errors.Is(err, invitation.ErrExpired) || pr.Status.ObservedGeneration == 0 {
+			return nil, err
+		}
+	}
+
+	return inv, nil
+}
+
+// parseInvitation retrieves and verifies the invitation referenced by the PeeringRequest, without validating it.
+func (r *PeeringRequestReconciler) parseInvitation(ctx context.Context, pr *liqov1beta1.PeeringRequest) (*invitation.Invitation, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: pr.Namespace, Name: pr.Spec.InvitationSecretRef.Name}
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the invitation secret %q: %w", key, err)
+	}
+
+	token, found := secret.Data[invitation.TokenKey]
+	if !found {
+		return nil, fmt.Errorf("invitation secret %q does not contain the %q key", key, invitation.TokenKey)
+	}
+
+	return invitation.Parse(string(token), pr.Spec.ProviderKeyFingerprint)
+}
+
+// newPeering initializes the clients towards the provider cluster, using the credentials carried by the invitation.
+func (r *PeeringRequestReconciler) newPeering(ctx context.Context, pr *liqov1beta1.PeeringRequest,
+	inv *invitation.Invitation) (*peering, error) {
+	remoteConfig, err := clientcmd.RESTConfigFromKubeConfig(inv.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the invitation kubeconfig: %w", err)
+	}
+	remoteClient, err := r.NewRemoteClient(remoteConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the provider cluster client: %w", err)
+	}
+	remoteKubeClient, err := kubernetes.NewForConfig(remoteConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the provider cluster client: %w", err)
+	}
+
+	// Make sure the invitation kubeconfig grants access to the cluster that issued the invitation.
+	remoteClusterID, err := liqoutils.GetClusterIDWithControllerClient(ctx, remoteClient, inv.LiqoNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the provider cluster ID: %w", err)
+	}
+	if remoteClusterID != inv.ProviderClusterID {
+		return nil, fmt.Errorf("the invitation has been issued by cluster %q, but its credentials grant access to cluster %q",
+			inv.ProviderClusterID, remoteClusterID)
+	}
+
+	return &peering{
+		spec: &pr.Spec,
+		consumer: &peeringCluster{
+			client:           r.Client,
+			kubeClient:       r.KubeClient,
+			namespaceManager: r.NamespaceManager,
+			clusterID:        r.LocalClusterID,
+			liqoNamespace:    r.LiqoNamespace,
+		},
+		provider: &peeringCluster{
+			client:           remoteClient,
+			kubeClient:       remoteKubeClient,
+			namespaceManager: tenantnamespace.NewManager(remoteKubeClient, r.Scheme),
+			clusterID:        remoteClusterID,
+			liqoNamespace:    inv.LiqoNamespace,
+		},
+	}, nil
+}
+
+// handleDeletion releases the credentials issued through the invitation, deleting the Secret recording them
+// in the provider cluster (which, in turn, revokes them), and removes the finalizer from the PeeringRequest.
+// The credentials are left untouched if they can no longer be used (e.g., the invitation is no longer available,
+// or its credentials are expired or already revoked), as the provider cluster revokes them upon expiration anyway.
+func (r *PeeringRequestReconciler) handleDeletion(ctx context.Context, pr *liqov1beta1.PeeringRequest) error {
+	if !controllerutil.ContainsFinalizer(pr, peeringRequestControllerFinalizer) {
+		return nil
+	}
+
+	if err := r.releaseCredentials(ctx, pr); err != nil {
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(pr, peeringRequestControllerFinalizer)
+	if err := r.Update(ctx, pr); err != nil {
+		return fmt.Errorf("unable to remove the finalizer from PeeringRequest %q: %w", client.ObjectKeyFromObject(pr), err)
+	}
+	return nil
+}
+
+// releaseCredentials deletes the Secret recording the credentials issued through the invitation in the provider cluster.
+func (r *PeeringRequestReconciler) releaseCredentials(ctx context.Context, pr *liqov1beta1.PeeringRequest) error {
+	key := client.ObjectKeyFromObject(pr)
+	inv, err := r.parseInvitation(ctx, pr)
+	if err != nil {
+		klog.Warningf("Unable to release the invitation credentials of PeeringRequest %q: %v", key, err)
+		return nil
+	}
+	if err := inv.Validate(r.LocalClusterID, time.Now()); err != nil && !errors.Is(err, invitation.ErrExpired) {
+		klog.Warningf("Unable to release the invitation credentials of PeeringRequest %q: %v", key, err)
+		return nil
+	}
+
+	remoteConfig, err := clientcmd.RESTConfigFromKubeConfig(inv.Kubeconfig)
+	if err != nil {
+		klog.Warningf("Unable to release the invitation credentials of PeeringRequest %q: %v", key, err)
+		return nil
+	}
+	remoteClient, err := r.NewRemoteClient(remoteConfig)
+	if err != nil {
+		return fmt.Errorf("unable to initialize the provider cluster client: %w", err)
+	}
+
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: invitation.CredentialsSecretName(r.LocalClusterID), Namespace: inv.TenantNamespace}}
+	switch err := remoteClient.Delete(ctx, &secret); {
+	case err == nil:
+		klog.Infof("Invitation credentials of PeeringRequest %q released", key)
+	case apierrors.IsNotFound(err), apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		// The credentials have already been released or revoked.
+		klog.V(4).Infof("Invitation credentials of PeeringRequest %q already released: %v", key, err)
+	default:
+		return fmt.Errorf("unable to release the invitation credentials in the provider cluster: %w", err)
+	}
+	return nil
+}
+
+// stepFailed reports in the PeeringRequest status that the given phase cannot proceed, and returns the error
+// so that the PeeringRequest is reconciled again with backoff.
+func (r *PeeringRequestReconciler) stepFailed(ctx context.Context, pr *liqov1beta1.PeeringRequest,
+	phase liqov1beta1.PeeringRequestPhase, err error) error {
+	klog.Errorf("Unable to establish the peering for PeeringRequest %q: %v", client.ObjectKeyFromObject(pr), err)
+	if phase == "" {
+		phase = liqov1beta1.PeeringRequestPhasePending
+	}
+	if uerr := r.setPhase(ctx, pr, phase, err.Error()); uerr != nil {
+		return uerr
+	}
+	return err
+}
+
+// setPhase updates the phase and the message of the PeeringRequest status, if changed.
+func (r *PeeringRequestReconciler) setPhase(ctx context.Context, pr *liqov1beta1.PeeringRequest,
+	phase liqov1beta1.PeeringRequestPhase, message string) error {
+	pr.Status.Phase = phase
+	pr.Status.Message = message
+
+	// Skip the update when nothing changed, as the PeeringRequest is requeued periodically while waiting.
+	var current liqov1beta1.PeeringRequest
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pr), &current); err == nil &&
+		equality.Semantic.DeepEqual(current.Status, pr.Status) {
+		return nil
+	}
+
+	if err := r.Status().Update(ctx, pr); err != nil {
+		return fmt.Errorf("unable to update the status of PeeringRequest %q: %w", client.ObjectKeyFromObject(pr), err)
+	}
+	return nil
+}
+
+// SetupWithManager registers a new controller for PeeringRequest resources.
+func (r *PeeringRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlPeeringRequest).
+		For(&liqov1beta1.PeeringRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.secretEnqueuer)).
+		Complete(r)
+}
+
+// secretEnqueuer enqueues the PeeringRequests referencing the given Secret.
+func (r *PeeringRequestReconciler) secretEnqueuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	var prs liqov1beta1.PeeringRequestList
+	if err := r.List(ctx, &prs, client.InNamespace(obj.GetNamespace())); err != nil {
+		klog.Errorf("Unable to list PeeringRequests in namespace %q: %v", obj.GetNamespace(), err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range prs.Items {
+		if prs.Items[i].Spec.InvitationSecretRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&prs.Items[i])})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,251 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringrequestcontroller
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
+	nwforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+)
+
+var _ = Describe("Establishing the peering declared by a PeeringRequest", func() {
+	var (
+		spec               liqov1beta1.PeeringRequestSpec
+		p                  *peering
+		consumer, provider *peeringCluster
+	)
+
+	BeforeEach(func() {
+		spec = liqov1beta1.PeeringRequestSpec{}
+		consumer = &peeringCluster{clusterID: "consumer", liqoNamespace: "liqo-consumer"}
+		provider = &peeringCluster{clusterID: "provider", liqoNamespace: "liqo-provider"}
+		p = &peering{spec: &spec, consumer: consumer, provider: provider}
+	})
+
+	phases := func() []liqov1beta1.PeeringRequestPhase {
+		var phases []liqov1beta1.PeeringRequestPhase
+		for _, s := range p.steps() {
+			phases = append(phases, s.phase)
+		}
+		return phases
+	}
+
+	When("the spec is empty", func() {
+		It("should establish networking and authentication, without any ResourceSlice", func() {
+			Expect(phases()).To(ContainElement(liqov1beta1.PeeringRequestPhaseNetworking))
+			Expect(phases()).To(ContainElement(liqov1beta1.PeeringRequestPhaseAuthenticating))
+			Expect(phases()).ToNot(ContainElement(liqov1beta1.PeeringRequestPhaseOffloading))
+		})
+
+		It("should host the gateway server in the provider cluster, with the default configuration", func() {
+			server, cl := p.gatewayClusters()
+			Expect(server).To(BeIdenticalTo(provider))
+			Expect(cl).To(BeIdenticalTo(consumer))
+
+			opts := gatewayServerOptions(&spec.Networking, server, cl)
+			Expect(opts.RemoteClusterID).To(BeEquivalentTo("consumer"))
+			Expect(opts.TemplateNamespace).To(Equal("liqo-provider"))
+			Expect(opts.ServiceType).To(Equal(nwforge.DefaultGwServerServiceType))
+			Expect(opts.Port).To(BeEquivalentTo(nwforge.DefaultGwServerPort))
+			Expect(opts.MTU).To(Equal(nwforge.DefaultMTU))
+		})
+	})
+
+	When("the networking is disabled", func() {
+		It("should only establish authentication", func() {
+			spec.Networking.Disabled = true
+			Expect(phases()).ToNot(ContainElement(liqov1beta1.PeeringRequestPhaseNetworking))
+			Expect(phases()[0]).To(Equal(liqov1beta1.PeeringRequestPhaseAuthenticating))
+		})
+	})
+
+	When("the spec customizes the networking", func() {
+		BeforeEach(func() {
+			spec.Networking = liqov1beta1.PeeringRequestNetworking{
+				GatewayServerLocation: liqov1beta1.ConsumerRole,
+				ServiceType:           corev1.ServiceTypeNodePort,
+				MTU:                   1400,
+			}
+		})
+
+		It("should host the gateway server in the consumer cluster", func() {
+			server, cl := p.gatewayClusters()
+			Expect(server).To(BeIdenticalTo(consumer))
+			Expect(cl).To(BeIdenticalTo(provider))
+
+			opts := gatewayServerOptions(&spec.Networking, server, cl)
+			Expect(opts.ServiceType).To(Equal(corev1.ServiceTypeNodePort))
+			Expect(opts.MTU).To(Equal(1400))
+		})
+
+		It("should connect the gateway client to the endpoint of the gateway server", func() {
+			server, cl := p.gatewayClusters()
+			endpoint := &networkingv1beta1.EndpointStatus{
+				Addresses: []string{"10.0.0.1"},
+				Port:      30000,
+				Protocol:  ptr.To(corev1.ProtocolUDP),
+			}
+
+			opts := gatewayClientOptions(&spec.Networking, cl, server, endpoint)
+			Expect(opts.RemoteClusterID).To(BeEquivalentTo("consumer"))
+			Expect(opts.TemplateNamespace).To(Equal("liqo-provider"))
+			Expect(opts.Addresses).To(ConsistOf("10.0.0.1"))
+			Expect(opts.Port).To(BeEquivalentTo(30000))
+			Expect(opts.Protocol).To(Equal(string(corev1.ProtocolUDP)))
+			Expect(opts.MTU).To(Equal(1400))
+		})
+	})
+
+	When("the spec requests a ResourceSlice", func() {
+		BeforeEach(func() {
+			spec.ResourceSlice = &liqov1beta1.PeeringRequestResourceSlice{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+					"nvidia.com/gpu":      resource.MustParse("1"),
+				},
+			}
+		})
+
+		It("should create it as the last step", func() {
+			Expect(phases()[len(phases())-1]).To(Equal(liqov1beta1.PeeringRequestPhaseOffloading))
+		})
+
+		It("should forward the requested resources, with the default class", func() {
+			opts := resourceSliceOptions(spec.ResourceSlice)
+			Expect(opts.Class).To(Equal(authv1beta1.ResourceSliceClass(defaultResourceSliceClass)))
+			Expect(opts.Resources).To(HaveKeyWithValue(corev1.ResourceCPU, "4"))
+			Expect(opts.Resources).To(HaveKeyWithValue(corev1.ResourceMemory, "8Gi"))
+			Expect(opts.Resources).To(HaveKeyWithValue(corev1.ResourceName("nvidia.com/gpu"), "1"))
+		})
+	})
+})
+
+var _ = Describe("Deleting a PeeringRequest", func() {
+	const kubeconfig = `apiVersion: v1
+kind: Config
+clusters: [{name: provider, cluster: {server: "https://provider.example.com:6443"}}]
+users: [{name: consumer, user: {token: token}}]
+contexts: [{name: provider, context: {cluster: provider, user: consumer}}]
+current-context: provider`
+
+	var (
+		ctx                          context.Context
+		credentialsExpiration        time.Time
+		pr                           *liqov1beta1.PeeringRequest
+		localClient, remoteClient    client.Client
+		credentials, invitationToken *corev1.Secret
+		err                          error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		credentialsExpiration = time.Now().Add(time.Hour)
+	})
+
+	JustBeforeEach(func() {
+		public, private, kerr := ed25519.GenerateKey(rand.Reader)
+		Expect(kerr).ToNot(HaveOccurred())
+		publicKey, kerr := x509.MarshalPKIXPublicKey(public)
+		Expect(kerr).ToNot(HaveOccurred())
+
+		token, serr := invitation.Sign(&invitation.Invitation{
+			ProviderClusterID: "provider",
+			ConsumerClusterID: "consumer",
+			LiqoNamespace:     "liqo",
+			TenantNamespace:   "liqo-tenant-consumer",
+			Kubeconfig:        []byte(kubeconfig),
+			PublicKey:         publicKey,
+			// The invitation is expired, while its credentials are still valid.
+			ExpirationTime:            metav1.NewTime(time.Now().Add(-time.Minute)),
+			CredentialsExpirationTime: metav1.NewTime(credentialsExpiration),
+		}, private)
+		Expect(serr).ToNot(HaveOccurred())
+
+		invitationToken = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "invitation", Namespace: "default"},
+			Data:       map[string][]byte{invitation.TokenKey: []byte(token)},
+		}
+		pr = &liqov1beta1.PeeringRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "provider", Namespace: "default",
+				Finalizers:        []string{peeringRequestControllerFinalizer},
+				DeletionTimestamp: ptr.To(metav1.Now()),
+			},
+			Spec: liqov1beta1.PeeringRequestSpec{
+				InvitationSecretRef:    corev1.LocalObjectReference{Name: invitationToken.Name},
+				ProviderKeyFingerprint: invitation.Fingerprint(publicKey),
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(liqov1beta1.AddToScheme(scheme)).To(Succeed())
+		localClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pr, invitationToken).Build()
+		credentials = invitation.CredentialsSecret("consumer", "liqo-tenant-consumer", metav1.NewTime(credentialsExpiration))
+		credentials.Finalizers = nil
+		remoteClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials).Build()
+
+		reconciler := NewPeeringRequestReconciler(localClient, nil, scheme, nil, "consumer", "liqo")
+		reconciler.NewRemoteClient = func(*rest.Config) (client.Client, error) { return remoteClient, nil }
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pr)})
+	})
+
+	isNotFound := func(cl client.Client, obj client.Object) bool {
+		return apierrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+	}
+
+	It("should release the invitation credentials in the provider cluster", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isNotFound(remoteClient, credentials)).To(BeTrue())
+	})
+
+	It("should remove the finalizer from the PeeringRequest", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isNotFound(localClient, pr)).To(BeTrue())
+	})
+
+	When("the invitation credentials are expired", func() {
+		BeforeEach(func() { credentialsExpiration = time.Now().Add(-time.Second) })
+
+		It("should leave them to the provider cluster, and remove the finalizer", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isNotFound(remoteClient, credentials)).To(BeFalse())
+			Expect(isNotFound(localClient, pr)).To(BeTrue())
+		})
+	})
+})
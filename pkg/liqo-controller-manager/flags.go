@@ -57,6 +57,8 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 		"The timeout of the ForeignCluster API server readiness check")
 	flagset.StringVar(&opts.DefaultLimitsEnforcement, "default-limits-enforcement", "none",
 		"Defines how strict is the enforcement of the quota offered by the remote cluster. Possible values are: none, soft, hard")
+	flagset.DurationVar(&opts.PeeringAuditRetention, "peering-audit-retention", 7*24*time.Hour,
+		"The period after which the PeeringAuditEvents that did not occur again are deleted (0 to keep them forever)")
 
 	// Networking module
 	flagset.StringVar(&opts.IPAMServer, "ipam-server", "", "The address of the IPAM server (set to empty string to disable IPAM)")
@@ -74,6 +76,8 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 		"Enable the gateway masquerade bypass")
 	flagset.BoolVar(&opts.GatewayTemplateWatchEnabled, "gateway-template-watch-enabled", true,
 		"Enable watching of custom GatewayTemplate CRDs")
+	flagset.DurationVar(&opts.GatewayKeysRotationInterval, "gateway-keys-rotation-interval", 0,
+		"The interval between two automatic rotations of the keys of the wireguard gateways (0 to disable)")
 	flagset.IntVar(&opts.NetworkWorkers, "network-ctrl-workers", 1,
 		"The number of workers used to reconcile Network resources.")
 	flagset.IntVar(&opts.IPWorkers, "ip-ctrl-workers", 1,
@@ -81,6 +85,12 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.Uint16Var(&opts.GenevePort, "geneve-port", 6081, "The port used by the Geneve tunnel")
 	flagset.IntVar(&opts.RouteConfigurationRulePriority, "fabric-route-rule-priority", 0,
 		"The priority of the ip rules created by the controller-manager for node/fabric routing")
+	flagset.Int32Var(&opts.ConnectionPacketLossThreshold, "connection-packet-loss-threshold", 0,
+		"The percentage of lost pings above which the network connection with a foreign cluster is considered degraded (0 to disable)")
+	flagset.DurationVar(&opts.ConnectionLatencyThreshold, "connection-latency-threshold", 0,
+		"The average round-trip time above which the network connection with a foreign cluster is considered degraded (0 to disable)")
+	flagset.DurationVar(&opts.ConnectionJitterThreshold, "connection-jitter-threshold", 0,
+		"The jitter above which the network connection with a foreign cluster is considered degraded (0 to disable)")
 
 	// Authentication module
 	flagset.StringVar(&opts.APIServerAddressOverride, "api-server-address-override", "",
@@ -92,10 +102,19 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.BoolVar(&opts.DefaultResourceSliceClassEnabled, "default-resource-slice-class-enabled", true,
 		"Enable the built-in default ResourceSlice class. When disabled, the provider denies ResourceSlices "+
 			"that use the \"default\" class, so consumers must use an explicit provider-approved class")
+	flagset.DurationVar(&opts.IdentityCertificateLifetime, "identity-certificate-lifetime", 0,
+		"The validity of the certificates issued to the remote clusters (0 to use the default of the Kubernetes signer, minimum 10m)")
 	flagset.StringVar(&opts.AWSConfig.AwsAccessKeyID, "aws-access-key-id", "", "AWS IAM AccessKeyID for the Liqo User")
 	flagset.StringVar(&opts.AWSConfig.AwsSecretAccessKey, "aws-secret-access-key", "", "AWS IAM SecretAccessKey for the Liqo User")
 	flagset.StringVar(&opts.AWSConfig.AwsRegion, "aws-region", "", "AWS region where the local cluster is running")
 	flagset.StringVar(&opts.AWSConfig.AwsClusterName, "aws-cluster-name", "", "Name of the local EKS cluster")
+	flagset.StringVar(&opts.OIDCConfig.IssuerURL, "oidc-issuer-url", "",
+		"URL of the OIDC provider trusted by the local API server, used to issue tokens to the remote clusters")
+	flagset.StringVar(&opts.OIDCConfig.ClientID, "oidc-client-id", "", "Client ID used by Liqo to request tokens to the OIDC provider")
+	flagset.StringVar(&opts.OIDCConfig.ClientSecret, "oidc-client-secret", "", "Client secret used by Liqo to request tokens to the OIDC provider")
+	flagset.StringVar(&opts.OIDCConfig.Audience, "oidc-audience", "", "Audience requested for the OIDC tokens, if required by the provider")
+	flagset.StringVar(&opts.OIDCConfig.TokenEndpoint, "oidc-token-endpoint", "",
+		"Override the token endpoint of the OIDC provider, instead of discovering it from the issuer")
 	flagset.Var(&opts.ClusterLabels, consts.ClusterLabelsParameter,
 		"The set of labels which characterizes the local cluster when exposed remotely as a virtual node")
 	flagset.Var(&opts.IngressClasses, "ingress-classes", "List of ingress classes offered by the cluster. Example: \"nginx;default,traefik\"")
@@ -110,9 +129,22 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.StringVar(&opts.RealStorageClassName, "real-storage-class-name", "", "Name of the real storage class to use for the actual volumes")
 	flagset.StringVar(&opts.StorageNamespace, "storage-namespace", "liqo-storage", "Namespace where the liqo storage-related resources are stored")
 	flagset.BoolVar(&opts.EnableNodeFailureController, "enable-node-failure-controller", false, "Enable the node failure controller")
+	flagset.BoolVar(&opts.EnableResourceSliceAutoscaler, "enable-resource-slice-autoscaler", false,
+		"Enable the controller resizing the ResourceSlices with autoscaling enabled, depending on the demand")
 	flagset.IntVar(&opts.ShadowPodWorkers, "shadow-pod-ctrl-workers", 10, "The number of workers used to reconcile ShadowPod resources.")
 	flagset.IntVar(&opts.ShadowEndpointSliceWorkers, "shadow-endpointslice-ctrl-workers", 10,
 		"The number of workers used to reconcile ShadowEndpointSlice resources.")
+	flagset.BoolVar(&opts.EnableWorkloadOffloading, "enable-workload-offloading", false,
+		"Enable the controller creating the Deployments, StatefulSets and Jobs offloaded by consumer clusters through ShadowWorkloads")
+	flagset.IntVar(&opts.ShadowWorkloadWorkers, "shadow-workload-ctrl-workers", 3, "The number of workers used to reconcile ShadowWorkload resources.")
+	flagset.BoolVar(&opts.EnableDRA, "enable-dynamic-resource-allocation", false,
+		"Enable the advertisement to consumer clusters of the device classes offered through Dynamic Resource Allocation")
+	flagset.BoolVar(&opts.EnableMultiClusterServices, "enable-multicluster-services", false,
+		"Enable the controllers exporting and importing Services to/from peer clusters through the Multi-Cluster Services API")
+	flagset.BoolVar(&opts.EnableClusterDNS, "enable-cluster-dns", false,
+		"Enable the DNS server resolving the services reflected and imported from the peer clusters")
+	flagset.StringVar(&opts.ClusterDNSZone, "cluster-dns-zone", "liqo", "The DNS zone served by the cross-cluster DNS server")
+	flagset.StringVar(&opts.ClusterDNSAddress, "cluster-dns-address", ":5353", "The address the cross-cluster DNS server listens on (both UDP and TCP)")
 	flagset.BoolVar(&opts.DenyDirectConnections, "deny-direct-connections", false,
 		"Prevents the usage of direct connections between provider clusters.")
 
@@ -0,0 +1,172 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// keysExchangeCheckInterval is the interval between two checks of whether the remote gateway acknowledged the next public key.
+const keysExchangeCheckInterval = 10 * time.Second
+
+// handleKeysRotation rotates the keys contained in the given secret, according to the RotateKeysAnnotation and to the
+// automatic rotation interval (if greater than zero). It returns after how long the secret has to be checked again, if needed.
+func handleKeysRotation(ctx context.Context, cl client.Client, secret *corev1.Secret, interval time.Duration) (time.Duration, error) {
+	var acknowledged []byte
+	if secret.Annotations[consts.RotateKeysAnnotation] == consts.RotateKeysAuto {
+		var err error
+		if acknowledged, err = getAcknowledgedPublicKey(ctx, cl, secret); err != nil {
+			return 0, err
+		}
+	}
+
+	updated, requeue, err := rotateKeys(secret, time.Now(), interval, acknowledged)
+	if err != nil {
+		return 0, fmt.Errorf("unable to rotate keys in secret %q: %w", client.ObjectKeyFromObject(secret), err)
+	}
+	if !updated {
+		return requeue, nil
+	}
+
+	step := secret.Annotations[consts.RotateKeysAnnotation]
+	if err := cl.Update(ctx, secret); err != nil {
+		return 0, fmt.Errorf("unable to update keys secret %q: %w", client.ObjectKeyFromObject(secret), err)
+	}
+	klog.Infof("Keys rotation step %q completed for secret %q", step, client.ObjectKeyFromObject(secret))
+	return requeue, nil
+}
+
+// rotateKeys mutates the given secret according to the requested keys rotation step, and returns whether it has been modified
+// and after how long it has to be checked again, if needed.
+// In the prepare step, the next keys are generated and stored alongside the current ones, which are still used by the gateway.
+// In the commit step, the next keys replace the current ones, and the annotation is removed to mark the rotation as completed.
+// If the interval is greater than zero, the auto step is started once the interval elapsed since the last rotation: the next keys
+// are generated, and they are committed once the remote gateway acknowledged the next public key.
+func rotateKeys(secret *corev1.Secret, now time.Time, interval time.Duration, acknowledged []byte) (updated bool, requeue time.Duration, err error) {
+	step, ok := secret.Annotations[consts.RotateKeysAnnotation]
+	if !ok {
+		if interval <= 0 {
+			return false, 0, nil
+		}
+		if next := lastKeysRotation(secret).Add(interval); now.Before(next) {
+			return false, next.Sub(now), nil
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[consts.RotateKeysAnnotation] = consts.RotateKeysAuto
+		step = consts.RotateKeysAuto
+		updated = true
+	}
+
+	switch step {
+	case consts.RotateKeysPrepare:
+		generated, err := generateNextKeys(secret)
+		return generated, 0, err
+	case consts.RotateKeysAuto:
+		generated, err := generateNextKeys(secret)
+		if err != nil {
+			return false, 0, err
+		}
+		if generated || !bytes.Equal(acknowledged, secret.Data[consts.NextPublicKeyField]) {
+			// Wait for the remote gateway to acknowledge the next public key, exchanged through the tunnel.
+			return updated || generated, keysExchangeCheckInterval, nil
+		}
+		commitNextKeys(secret, now)
+		return true, interval, nil
+	case consts.RotateKeysCommit:
+		commitNextKeys(secret, now)
+		return true, 0, nil
+	default:
+		return false, 0, fmt.Errorf("invalid value %q for annotation %q (allowed values are: %s,%s,%s)",
+			step, consts.RotateKeysAnnotation, consts.RotateKeysPrepare, consts.RotateKeysCommit, consts.RotateKeysAuto)
+	}
+}
+
+// generateNextKeys generates the next keys, if not already present, and returns whether the secret has been modified.
+func generateNextKeys(secret *corev1.Secret) (bool, error) {
+	if _, ok := secret.Data[consts.NextPrivateKeyField]; ok {
+		return false, nil
+	}
+	pri, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return false, fmt.Errorf("unable to generate private key: %w", err)
+	}
+	pub := pri.PublicKey()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[consts.NextPrivateKeyField] = pri[:]
+	secret.Data[consts.NextPublicKeyField] = pub[:]
+	return true, nil
+}
+
+// commitNextKeys replaces the current keys with the next ones, and marks the rotation as completed.
+func commitNextKeys(secret *corev1.Secret, now time.Time) {
+	pri, okPri := secret.Data[consts.NextPrivateKeyField]
+	pub, okPub := secret.Data[consts.NextPublicKeyField]
+	if okPri && okPub {
+		secret.Data[consts.PrivateKeyField] = pri
+		secret.Data[consts.PublicKeyField] = pub
+		secret.Annotations[consts.KeysRotationTimestampAnnotation] = now.UTC().Format(time.RFC3339)
+	}
+	delete(secret.Data, consts.NextPrivateKeyField)
+	delete(secret.Data, consts.NextPublicKeyField)
+	delete(secret.Annotations, consts.RotateKeysAnnotation)
+}
+
+// lastKeysRotation returns when the keys contained in the given secret have been last rotated, or generated.
+func lastKeysRotation(secret *corev1.Secret) time.Time {
+	if ts, err := time.Parse(time.RFC3339, secret.Annotations[consts.KeysRotationTimestampAnnotation]); err == nil {
+		return ts
+	}
+	return secret.CreationTimestamp.Time
+}
+
+// getAcknowledgedPublicKey returns the next public key of the local gateway that the remote gateway acknowledged, if any.
+// The acknowledgement is reported by the local gateway in the Connection resource.
+func getAcknowledgedPublicKey(ctx context.Context, cl client.Client, secret *corev1.Secret) ([]byte, error) {
+	conn, err := getters.GetConnectionByClusterIDInNamespace(ctx, cl, secret.Labels[consts.RemoteClusterID], secret.Namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to get the connection associated with secret %q: %w", client.ObjectKeyFromObject(secret), err)
+	}
+
+	encoded, ok := conn.Annotations[consts.NextPublicKeyAcknowledgedAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %q in connection %q: %w",
+			consts.NextPublicKeyAcknowledgedAnnotation, client.ObjectKeyFromObject(conn), err)
+	}
+	return key, nil
+}
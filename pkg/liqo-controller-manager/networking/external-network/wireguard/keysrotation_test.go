@@ -0,0 +1,121 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("Keys rotation", func() {
+	var (
+		secret *corev1.Secret
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw-foo", Namespace: "bar", Annotations: map[string]string{}},
+			Data: map[string][]byte{
+				consts.PrivateKeyField: []byte("private"),
+				consts.PublicKeyField:  []byte("public"),
+			},
+		}
+	})
+
+	It("should not modify the secret if no rotation is requested", func() {
+		Expect(rotateKeys(secret, now, 0, nil)).To(BeFalse())
+		Expect(secret.Data).To(HaveLen(2))
+	})
+
+	It("should return an error if the rotation step is invalid", func() {
+		secret.Annotations[consts.RotateKeysAnnotation] = "invalid"
+		_, _, err := rotateKeys(secret, now, 0, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should generate the next keys once and then promote them", func() {
+		secret.Annotations[consts.RotateKeysAnnotation] = consts.RotateKeysPrepare
+		Expect(rotateKeys(secret, now, 0, nil)).To(BeTrue())
+		Expect(secret.Data[consts.PrivateKeyField]).To(Equal([]byte("private")))
+		Expect(secret.Data[consts.NextPrivateKeyField]).To(HaveLen(32))
+		Expect(secret.Data[consts.NextPublicKeyField]).To(HaveLen(32))
+		next := secret.Data[consts.NextPublicKeyField]
+
+		By("preparing the rotation again")
+		Expect(rotateKeys(secret, now, 0, nil)).To(BeFalse())
+		Expect(secret.Data[consts.NextPublicKeyField]).To(Equal(next))
+
+		By("committing the rotation")
+		secret.Annotations[consts.RotateKeysAnnotation] = consts.RotateKeysCommit
+		Expect(rotateKeys(secret, now, 0, nil)).To(BeTrue())
+		Expect(secret.Data).To(HaveLen(2))
+		Expect(secret.Data[consts.PublicKeyField]).To(Equal(next))
+		Expect(secret.Annotations).ToNot(HaveKey(consts.RotateKeysAnnotation))
+		Expect(secret.Annotations).To(HaveKeyWithValue(consts.KeysRotationTimestampAnnotation, now.UTC().Format(time.RFC3339)))
+	})
+
+	When("the automatic rotation is enabled", func() {
+		const interval = time.Hour
+
+		BeforeEach(func() {
+			secret.CreationTimestamp = metav1.NewTime(now.Add(-2 * interval))
+			secret.Annotations[consts.KeysRotationTimestampAnnotation] = now.Add(-interval / 2).UTC().Format(time.RFC3339)
+		})
+
+		It("should wait for the interval to elapse since the last rotation", func() {
+			updated, requeue, err := rotateKeys(secret, now, interval, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeFalse())
+			Expect(requeue).To(BeNumerically("~", interval/2, time.Second))
+			Expect(secret.Data).To(HaveLen(2))
+		})
+
+		It("should commit the next keys only once acknowledged by the remote gateway", func() {
+			later := now.Add(interval)
+			updated, requeue, err := rotateKeys(secret, later, interval, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+			Expect(requeue).To(Equal(keysExchangeCheckInterval))
+			Expect(secret.Annotations).To(HaveKeyWithValue(consts.RotateKeysAnnotation, consts.RotateKeysAuto))
+			Expect(secret.Data[consts.PrivateKeyField]).To(Equal([]byte("private")))
+			next := secret.Data[consts.NextPublicKeyField]
+			Expect(next).To(HaveLen(32))
+
+			By("checking the rotation before the acknowledgement")
+			updated, requeue, err = rotateKeys(secret, later, interval, []byte("stale"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeFalse())
+			Expect(requeue).To(Equal(keysExchangeCheckInterval))
+
+			By("checking the rotation after the acknowledgement")
+			updated, requeue, err = rotateKeys(secret, later, interval, next)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+			Expect(requeue).To(Equal(interval))
+			Expect(secret.Data).To(HaveLen(2))
+			Expect(secret.Data[consts.PublicKeyField]).To(Equal(next))
+			Expect(secret.Annotations).ToNot(HaveKey(consts.RotateKeysAnnotation))
+			Expect(secret.Annotations).To(HaveKeyWithValue(consts.KeysRotationTimestampAnnotation, later.UTC().Format(time.RFC3339)))
+		})
+	})
+})
@@ -17,6 +17,7 @@ package wireguard
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	corev1 "k8s.io/api/core/v1"
@@ -59,11 +60,20 @@ func wireGuardSecretEnquerer(_ context.Context, obj client.Object) []ctrl.Reques
 		return nil
 	}
 
+	// The WireGuard gateway is named after the gateway owning the secret, if any.
+	name := forge.GatewayResourceName(secret.Name)
+	for _, ref := range secret.GetOwnerReferences() {
+		if ref.Kind == networkingv1beta1.GatewayServerKind || ref.Kind == networkingv1beta1.GatewayClientKind {
+			name = ref.Name
+			break
+		}
+	}
+
 	return []ctrl.Request{
 		{
 			NamespacedName: types.NamespacedName{
 				Namespace: secret.Namespace,
-				Name:      forge.GatewayResourceName(secret.Name),
+				Name:      name,
 			},
 		},
 	}
@@ -126,7 +136,9 @@ func podEnquerer(_ context.Context, obj client.Object) []ctrl.Request {
 }
 
 // ensureKeysSecret ensure the presence of the private and public keys for the Wireguard interface and save them inside a Secret resource and Options.
-func ensureKeysSecret(ctx context.Context, cl client.Client, wgObj metav1.Object, mode gateway.Mode) error {
+// It returns after how long the secret has to be checked again to rotate the keys, if needed.
+func ensureKeysSecret(ctx context.Context, cl client.Client, wgObj metav1.Object, mode gateway.Mode,
+	rotationInterval time.Duration) (time.Duration, error) {
 	var controllerRef metav1.OwnerReference
 	for _, ref := range wgObj.GetOwnerReferences() {
 		if ref.Controller != nil && *ref.Controller {
@@ -148,54 +160,55 @@ func ensureKeysSecret(ctx context.Context, cl client.Client, wgObj metav1.Object
 		Mode:            mode,
 	}
 
-	_, err := getWireGuardSecret(ctx, cl, wgObj)
+	secret, err := getWireGuardSecret(ctx, cl, wgObj)
 	switch {
 	case kerrors.IsNotFound(err):
 		pri, err := wgtypes.GeneratePrivateKey()
 		if err != nil {
 			klog.Error(err)
-			return err
+			return 0, err
 		}
 		pub := pri.PublicKey()
 		if err := wireguard.CreateKeysSecret(ctx, cl, opts, pri, pub); err != nil {
 			klog.Error(err)
-			return err
+			return 0, err
 		}
 		klog.Infof("Keys secret for WireGuard gateway %q correctly enforced", wgObj.GetName())
-		return nil
+		return 0, nil
 	case err != nil:
 		klog.Error(err)
-		return err
+		return 0, err
 	default:
-		return nil
+		return handleKeysRotation(ctx, cl, secret, rotationInterval)
 	}
 }
 
-func checkExistingKeysSecret(ctx context.Context, cl client.Client, secretName, namespace string, wgObj metav1.Object) error {
+func checkExistingKeysSecret(ctx context.Context, cl client.Client, secretName, namespace string, wgObj metav1.Object,
+	rotationInterval time.Duration) (time.Duration, error) {
 	var s corev1.Secret
 	if err := cl.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, &s); err != nil {
-		return err
+		return 0, err
 	}
 
 	// Check needed data fields are present
 	if s.Data == nil {
-		return fmt.Errorf("mandatory data %q and %q are missing in secret %q", consts.PrivateKeyField, consts.PublicKeyField, secretName)
+		return 0, fmt.Errorf("mandatory data %q and %q are missing in secret %q", consts.PrivateKeyField, consts.PublicKeyField, secretName)
 	}
 	if _, ok := s.Data[consts.PrivateKeyField]; !ok {
-		return fmt.Errorf("missing %q data in secret %q", consts.PrivateKeyField, secretName)
+		return 0, fmt.Errorf("missing %q data in secret %q", consts.PrivateKeyField, secretName)
 	}
 	if _, ok := s.Data[consts.PublicKeyField]; !ok {
-		return fmt.Errorf("missing %q data in secret %q", consts.PublicKeyField, secretName)
+		return 0, fmt.Errorf("missing %q data in secret %q", consts.PublicKeyField, secretName)
 	}
 
 	// Check remote cluster ID label match the parent wireguard object
 	remoteClusterID, exists := wgObj.GetLabels()[consts.RemoteClusterID]
 	if !exists || remoteClusterID == "" {
-		return fmt.Errorf("missing %q label in WireGuard gateway %q", consts.RemoteClusterID, wgObj.GetName())
+		return 0, fmt.Errorf("missing %q label in WireGuard gateway %q", consts.RemoteClusterID, wgObj.GetName())
 	}
 	if s.Labels != nil {
 		if v, ok := s.Labels[consts.RemoteClusterID]; ok && v != remoteClusterID {
-			return fmt.Errorf("label %q in secret %q does not match the one in WireGuard gateway %q", consts.RemoteClusterID, secretName, wgObj.GetName())
+			return 0, fmt.Errorf("label %q in secret %q does not match the one in WireGuard gateway %q", consts.RemoteClusterID, secretName, wgObj.GetName())
 		}
 	}
 
@@ -206,12 +219,12 @@ func checkExistingKeysSecret(ctx context.Context, cl client.Client, secretName,
 			consts.GatewayResourceLabel: consts.GatewayResourceLabelValue,
 		}))
 		if err := cl.Update(ctx, &s); err != nil {
-			return fmt.Errorf("unable to update labels in secret %q: %w", secretName, err)
+			return 0, fmt.Errorf("unable to update labels in secret %q: %w", secretName, err)
 		}
 		klog.Infof("Enforced correct gateway labels in secret %q", secretName)
 	}
 
-	return nil
+	return handleKeysRotation(ctx, cl, &s, rotationInterval)
 }
 
 func getWireGuardSecret(ctx context.Context, cl client.Client, wgObj metav1.Object) (*corev1.Secret, error) {
@@ -17,6 +17,7 @@ package wireguard
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -49,6 +50,8 @@ type WgGatewayClientReconciler struct {
 	client.Client
 	Scheme          *runtime.Scheme
 	clusterRoleName string
+	// keysRotationInterval is the interval between two automatic rotations of the keys (0 to disable).
+	keysRotationInterval time.Duration
 
 	eventRecorder record.EventRecorder
 }
@@ -56,11 +59,12 @@ type WgGatewayClientReconciler struct {
 // NewWgGatewayClientReconciler returns a new WgGatewayClientReconciler.
 func NewWgGatewayClientReconciler(cl client.Client, s *runtime.Scheme,
 	recorder record.EventRecorder,
-	clusterRoleName string) *WgGatewayClientReconciler {
+	clusterRoleName string, keysRotationInterval time.Duration) *WgGatewayClientReconciler {
 	return &WgGatewayClientReconciler{
-		Client:          cl,
-		Scheme:          s,
-		clusterRoleName: clusterRoleName,
+		Client:               cl,
+		Scheme:               s,
+		clusterRoleName:      clusterRoleName,
+		keysRotationInterval: keysRotationInterval,
 
 		eventRecorder: recorder,
 	}
@@ -161,17 +165,19 @@ func (r *WgGatewayClientReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	var requeue time.Duration
 	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the Wireguard keys.
 	if wgClient.Spec.SecretRef.Name == "" {
 		// Ensure WireGuard keys secret (create or update)
-		if err = ensureKeysSecret(ctx, r.Client, wgClient, gateway.ModeClient); err != nil {
+		if requeue, err = ensureKeysSecret(ctx, r.Client, wgClient, gateway.ModeClient, r.keysRotationInterval); err != nil {
 			r.eventRecorder.Event(wgClient, corev1.EventTypeWarning, "KeysSecretEnforcedFailed", "Failed to enforce keys secret")
 			return ctrl.Result{}, err
 		}
 		r.eventRecorder.Event(wgClient, corev1.EventTypeNormal, "KeysSecretEnforced", "Enforced keys secret")
 	} else {
 		// Check that the secret exists and ensure is correctly labeled
-		if err = checkExistingKeysSecret(ctx, r.Client, wgClient.Spec.SecretRef.Name, wgClient.Namespace, wgClient.GetObjectMeta()); err != nil {
+		if requeue, err = checkExistingKeysSecret(ctx, r.Client, wgClient.Spec.SecretRef.Name, wgClient.Namespace, wgClient.GetObjectMeta(),
+			r.keysRotationInterval); err != nil {
 			r.eventRecorder.Event(wgClient, corev1.EventTypeWarning, "KeysSecretCheckFailed", fmt.Sprintf("Failed to check keys secret: %s", err))
 			return ctrl.Result{}, err
 		}
@@ -201,7 +207,7 @@ func (r *WgGatewayClientReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 	r.eventRecorder.Event(wgClient, corev1.EventTypeNormal, "MetricsEnforced", "Enforced metrics")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
 // SetupWithManager register the WgGatewayClientReconciler to the manager.
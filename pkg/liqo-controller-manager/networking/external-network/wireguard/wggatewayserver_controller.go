@@ -17,6 +17,7 @@ package wireguard
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +52,8 @@ type WgGatewayServerReconciler struct {
 	client.Client
 	Scheme          *runtime.Scheme
 	clusterRoleName string
+	// keysRotationInterval is the interval between two automatic rotations of the keys (0 to disable).
+	keysRotationInterval time.Duration
 
 	eventRecorder record.EventRecorder
 }
@@ -58,11 +61,12 @@ type WgGatewayServerReconciler struct {
 // NewWgGatewayServerReconciler returns a new WgGatewayServerReconciler.
 func NewWgGatewayServerReconciler(cl client.Client, s *runtime.Scheme,
 	recorder record.EventRecorder,
-	clusterRoleName string) *WgGatewayServerReconciler {
+	clusterRoleName string, keysRotationInterval time.Duration) *WgGatewayServerReconciler {
 	return &WgGatewayServerReconciler{
-		Client:          cl,
-		Scheme:          s,
-		clusterRoleName: clusterRoleName,
+		Client:               cl,
+		Scheme:               s,
+		clusterRoleName:      clusterRoleName,
+		keysRotationInterval: keysRotationInterval,
 
 		eventRecorder: recorder,
 	}
@@ -171,17 +175,19 @@ func (r *WgGatewayServerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	var requeue time.Duration
 	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the Wireguard keys.
 	if wgServer.Spec.SecretRef.Name == "" {
 		// Ensure WireGuard keys secret (create or update)
-		if err = ensureKeysSecret(ctx, r.Client, wgServer, gateway.ModeServer); err != nil {
+		if requeue, err = ensureKeysSecret(ctx, r.Client, wgServer, gateway.ModeServer, r.keysRotationInterval); err != nil {
 			r.eventRecorder.Event(wgServer, corev1.EventTypeWarning, "KeysSecretEnforcedFailed", "Failed to enforce keys secret")
 			return ctrl.Result{}, err
 		}
 		r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "KeysSecretEnforced", "Enforced keys secret")
 	} else {
 		// Check that the secret exists and ensure is correctly labeled
-		if err = checkExistingKeysSecret(ctx, r.Client, wgServer.Spec.SecretRef.Name, wgServer.Namespace, wgServer.GetObjectMeta()); err != nil {
+		if requeue, err = checkExistingKeysSecret(ctx, r.Client, wgServer.Spec.SecretRef.Name, wgServer.Namespace, wgServer.GetObjectMeta(),
+			r.keysRotationInterval); err != nil {
 			r.eventRecorder.Event(wgServer, corev1.EventTypeWarning, "KeysSecretCheckFailed", fmt.Sprintf("Failed to check keys secret: %s", err))
 			return ctrl.Result{}, err
 		}
@@ -218,7 +224,7 @@ func (r *WgGatewayServerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 	r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "MetricsEnforced", "Enforced metrics")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
 // SetupWithManager register the WgGatewayServerReconciler to the manager.
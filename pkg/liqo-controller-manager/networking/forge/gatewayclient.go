@@ -16,6 +16,7 @@ package forge
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
@@ -45,6 +46,7 @@ type GwClientOptions struct {
 	TemplateName      string
 	TemplateNamespace string
 	MTU               int
+	Bandwidth         *resource.Quantity
 	Addresses         []string
 	Port              int32
 	Protocol          string
@@ -86,6 +88,14 @@ func MutateGatewayClient(gwClient *networkingv1beta1.GatewayClient, o *GwClientO
 	// MTU
 	gwClient.Spec.MTU = o.MTU
 
+	// QoS (the priority classes, if any, are preserved)
+	if o.Bandwidth != nil {
+		if gwClient.Spec.QoS == nil {
+			gwClient.Spec.QoS = &networkingv1beta1.QoS{}
+		}
+		gwClient.Spec.QoS.Bandwidth = o.Bandwidth
+	}
+
 	// Server Endpoint
 	gwClient.Spec.Endpoint = networkingv1beta1.EndpointStatus{
 		Addresses: o.Addresses,
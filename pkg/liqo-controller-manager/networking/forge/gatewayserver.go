@@ -16,6 +16,7 @@ package forge
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
@@ -34,6 +35,7 @@ const (
 	DefaultGwServerServiceType  = corev1.ServiceTypeLoadBalancer
 	DefaultGwServerPort         = 51840
 	DefaultKeysDir              = "/etc/wireguard/keys"
+	DefaultKeysExchangePort     = 51830
 )
 
 // defaultGatewayServerName returns the default name for a GatewayServer.
@@ -50,6 +52,7 @@ type GwServerOptions struct {
 	TemplateNamespace string
 	ServiceType       corev1.ServiceType
 	MTU               int
+	Bandwidth         *resource.Quantity
 	Port              int32
 	NodePort          *int32
 	LoadBalancerIP    *string
@@ -91,6 +94,14 @@ func MutateGatewayServer(gwServer *networkingv1beta1.GatewayServer, o *GwServerO
 	// MTU
 	gwServer.Spec.MTU = o.MTU
 
+	// QoS (the priority classes, if any, are preserved)
+	if o.Bandwidth != nil {
+		if gwServer.Spec.QoS == nil {
+			gwServer.Spec.QoS = &networkingv1beta1.QoS{}
+		}
+		gwServer.Spec.QoS.Bandwidth = o.Bandwidth
+	}
+
 	// Server Endpoint
 	gwServer.Spec.Endpoint = networkingv1beta1.Endpoint{
 		Port:        o.Port,
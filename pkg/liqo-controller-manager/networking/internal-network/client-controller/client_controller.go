@@ -60,6 +60,7 @@ func NewClientReconciler(cl client.Client, s *runtime.Scheme) *ClientReconciler
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch;delete;create;update;patch
 
 // Reconcile manage GatewayClient lifecycle.
@@ -128,7 +129,10 @@ func (r *ClientReconciler) ensureInternalFabric(ctx context.Context, gwClient *n
 		}
 		internalFabric.Labels[consts.RemoteClusterID] = string(remoteClusterID)
 
-		internalFabric.Spec.MTU = gwClient.Spec.MTU
+		if internalFabric.Spec.MTU, err = internalnetwork.ForgeInternalFabricMTU(
+			ctx, r.Client, string(remoteClusterID), gwClient.Namespace, gwClient.Spec.MTU); err != nil {
+			return err
+		}
 
 		internalFabric.Spec.GatewayIP = *gwClient.Status.InternalEndpoint.IP
 
@@ -168,6 +172,11 @@ func (r *ClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.gatewayClientEnqueuerByRemoteID()),
 			builder.WithPredicates(netutils.AreConfigurationNetworkCIDRsConfiguredPredicate()),
 		).
+		Watches(
+			&networkingv1beta1.Connection{},
+			handler.EnqueueRequestsFromMapFunc(r.gatewayClientEnqueuerByRemoteID()),
+			builder.WithPredicates(internalnetwork.PathMTUChangedPredicate()),
+		).
 		Complete(r)
 }
 
@@ -175,7 +184,7 @@ func (r *ClientReconciler) gatewayClientEnqueuerByRemoteID() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		remoteClusterID, ok := utils.GetClusterIDFromLabels(obj.GetLabels())
 		if !ok {
-			klog.Errorf("unable to get the remote cluster ID from the labels of configuration %s", obj.GetName())
+			klog.Errorf("unable to get the remote cluster ID from the labels of %s", obj.GetName())
 			return nil
 		}
 
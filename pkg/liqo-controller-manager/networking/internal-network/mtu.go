@@ -0,0 +1,70 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internalnetwork
+
+import (
+	"context"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// ForgeInternalFabricMTU returns the MTU of the internal fabric towards the given remote cluster.
+// It is the MTU configured for the gateway, lowered to the path MTU if the latter has been applied to the tunnel.
+func ForgeInternalFabricMTU(ctx context.Context, cl client.Client, remoteClusterID, namespace string, mtu int) (int, error) {
+	connection, err := getters.GetConnectionByClusterIDInNamespace(ctx, cl, remoteClusterID, namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return mtu, nil
+	case err != nil:
+		return 0, err
+	}
+
+	pathMTU := connection.Status.PathMTU
+	if pathMTU != nil && pathMTU.Applied && pathMTU.Value > 0 && pathMTU.Value < mtu {
+		return pathMTU.Value, nil
+	}
+	return mtu, nil
+}
+
+// PathMTUChangedPredicate returns a predicate that filters the connections whose path MTU changed.
+func PathMTUChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConn, okOld := e.ObjectOld.(*networkingv1beta1.Connection)
+			newConn, okNew := e.ObjectNew.(*networkingv1beta1.Connection)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(forgeComparablePathMTU(oldConn), forgeComparablePathMTU(newConn))
+		},
+	}
+}
+
+func forgeComparablePathMTU(connection *networkingv1beta1.Connection) networkingv1beta1.ConnectionPathMTU {
+	if connection.Status.PathMTU == nil {
+		return networkingv1beta1.ConnectionPathMTU{}
+	}
+	return networkingv1beta1.ConnectionPathMTU{
+		Value:   connection.Status.PathMTU.Value,
+		Applied: connection.Status.PathMTU.Applied,
+	}
+}
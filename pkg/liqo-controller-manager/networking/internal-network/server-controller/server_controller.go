@@ -60,6 +60,7 @@ func NewServerReconciler(cl client.Client, s *runtime.Scheme) *ServerReconciler
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch;delete;create;update;patch
 
 // Reconcile manage GatewayServer lifecycle.
@@ -128,7 +129,10 @@ func (r *ServerReconciler) ensureInternalFabric(ctx context.Context, gwServer *n
 		}
 		internalFabric.Labels[consts.RemoteClusterID] = string(remoteClusterID)
 
-		internalFabric.Spec.MTU = gwServer.Spec.MTU
+		if internalFabric.Spec.MTU, err = internalnetwork.ForgeInternalFabricMTU(
+			ctx, r.Client, string(remoteClusterID), gwServer.Namespace, gwServer.Spec.MTU); err != nil {
+			return err
+		}
 
 		internalFabric.Spec.GatewayIP = *gwServer.Status.InternalEndpoint.IP
 
@@ -165,6 +169,11 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.gatewayServerEnqueuerByRemoteID()),
 			builder.WithPredicates(netutils.AreConfigurationNetworkCIDRsConfiguredPredicate()),
 		).
+		Watches(
+			&networkingv1beta1.Connection{},
+			handler.EnqueueRequestsFromMapFunc(r.gatewayServerEnqueuerByRemoteID()),
+			builder.WithPredicates(internalnetwork.PathMTUChangedPredicate()),
+		).
 		Complete(r)
 }
 
@@ -172,7 +181,7 @@ func (r *ServerReconciler) gatewayServerEnqueuerByRemoteID() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		remoteClusterID, ok := utils.GetClusterIDFromLabels(obj.GetLabels())
 		if !ok {
-			klog.Errorf("unable to get the remote cluster ID from the labels of configuration %s", obj.GetName())
+			klog.Errorf("unable to get the remote cluster ID from the labels of %s", obj.GetName())
 			return nil
 		}
 
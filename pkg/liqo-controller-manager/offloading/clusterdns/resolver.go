@@ -0,0 +1,252 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// ErrNameNotFound is returned when the queried name does not exist in the zone.
+var ErrNameNotFound = errors.New("name not found")
+
+// Resolve returns the addresses associated with the given name, which is expected to belong to the served zone:
+//   - <service>.<namespace>.<zone> resolves to the ClusterIP of the local (possibly reflected) service, to the IPs of the
+//     corresponding ServiceImport, or to the addresses of all the ready endpoints in case of headless services;
+//   - <service>.<namespace>.<cluster>.<zone> resolves to the addresses of the ready endpoints hosted by the given cluster,
+//     identified either by its cluster ID or by the name configured through the liqo.io/dns-name ForeignCluster annotation.
+func (s *Server) Resolve(ctx context.Context, name string) ([]net.IP, error) {
+	name = dns.CanonicalName(name)
+	if !dns.IsSubDomain(s.Zone, name) {
+		return nil, ErrNameNotFound
+	}
+
+	var labels []string
+	if relative := strings.TrimSuffix(strings.TrimSuffix(name, s.Zone), "."); relative != "" {
+		labels = strings.Split(relative, ".")
+	}
+
+	switch len(labels) {
+	case 0:
+		// The zone apex exists, although it has no addresses.
+		return nil, nil
+	case 2:
+		return s.resolveService(ctx, labels[1], labels[0])
+	case 3:
+		clusterID, found, err := s.clusterID(ctx, labels[2])
+		if err != nil || !found {
+			return nil, notFoundIfNoError(err)
+		}
+		return s.resolveClusterService(ctx, labels[1], labels[0], clusterID)
+	default:
+		return nil, ErrNameNotFound
+	}
+}
+
+// resolveService returns the addresses associated with the aggregated name of the given service.
+func (s *Server) resolveService(ctx context.Context, namespace, name string) ([]net.IP, error) {
+	var svc corev1.Service
+	svcFound, err := s.get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &svc)
+	if err != nil {
+		return nil, err
+	}
+	if svcFound && svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		return parseIPs(svc.Spec.ClusterIPs), nil
+	}
+
+	var si mcsv1alpha1.ServiceImport
+	siFound, err := s.get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &si)
+	if err != nil {
+		return nil, err
+	}
+	if siFound && len(si.Spec.IPs) > 0 {
+		return parseIPs(si.Spec.IPs), nil
+	}
+
+	addresses, err := s.endpoints(ctx, namespace, name, func(liqov1beta1.ClusterID) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	if !svcFound && !siFound && len(addresses) == 0 {
+		return nil, ErrNameNotFound
+	}
+	return addresses, nil
+}
+
+// resolveClusterService returns the addresses of the endpoints of the given service hosted by the given cluster.
+func (s *Server) resolveClusterService(ctx context.Context, namespace, name string, clusterID liqov1beta1.ClusterID) ([]net.IP, error) {
+	addresses, err := s.endpoints(ctx, namespace, name, func(id liqov1beta1.ClusterID) bool { return id == clusterID })
+	if err != nil || len(addresses) > 0 {
+		return addresses, err
+	}
+
+	// The name exists (with no addresses) as long as the service is known locally.
+	svcFound, err := s.get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &corev1.Service{})
+	if err != nil || svcFound {
+		return nil, err
+	}
+	siFound, err := s.get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &mcsv1alpha1.ServiceImport{})
+	if err != nil || siFound {
+		return nil, err
+	}
+	return nil, ErrNameNotFound
+}
+
+// clusterID returns the ID of the cluster identified by the given DNS label.
+func (s *Server) clusterID(ctx context.Context, label string) (liqov1beta1.ClusterID, bool, error) {
+	if label == strings.ToLower(string(s.LocalClusterID)) {
+		return s.LocalClusterID, true, nil
+	}
+
+	var foreignClusters liqov1beta1.ForeignClusterList
+	if err := s.List(ctx, &foreignClusters); err != nil {
+		return "", false, fmt.Errorf("failed to list foreign clusters: %w", err)
+	}
+
+	// The names configured through the annotation take precedence over the cluster IDs.
+	for i := range foreignClusters.Items {
+		fc := &foreignClusters.Items[i]
+		if alias, ok := fc.Annotations[consts.ClusterDNSNameAnnotationKey]; ok && strings.ToLower(alias) == label {
+			return fc.Spec.ClusterID, true, nil
+		}
+	}
+	for i := range foreignClusters.Items {
+		if clusterID := foreignClusters.Items[i].Spec.ClusterID; strings.ToLower(string(clusterID)) == label {
+			return clusterID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// endpoints returns the addresses of the ready endpoints of the given service, hosted by the clusters matching the filter.
+// Both the EndpointSlices of the service itself and the ones imported through the Multi-Cluster Services API are considered.
+func (s *Server) endpoints(ctx context.Context, namespace, name string, filter func(liqov1beta1.ClusterID) bool) ([]net.IP, error) {
+	slices := map[string]*discoveryv1.EndpointSlice{}
+	for _, label := range []string{discoveryv1.LabelServiceName, mcsv1alpha1.LabelServiceName} {
+		var list discoveryv1.EndpointSliceList
+		if err := s.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{label: name}); err != nil {
+			return nil, fmt.Errorf("failed to list the endpointslices of service %s/%s: %w", namespace, name, err)
+		}
+		for i := range list.Items {
+			slices[list.Items[i].Name] = &list.Items[i]
+		}
+	}
+
+	if len(slices) == 0 {
+		return nil, nil
+	}
+
+	virtualNodes, err := s.virtualNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []net.IP
+	seen := sets.New[string]()
+	for _, slice := range slices {
+		for i := range slice.Endpoints {
+			endpoint := &slice.Endpoints[i]
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			if !filter(s.endpointClusterID(slice, endpoint, virtualNodes)) {
+				continue
+			}
+			for _, address := range parseIPs(endpoint.Addresses) {
+				if !seen.Has(address.String()) {
+					seen.Insert(address.String())
+					addresses = append(addresses, address)
+				}
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// endpointClusterID returns the ID of the cluster hosting the given endpoint.
+func (s *Server) endpointClusterID(slice *discoveryv1.EndpointSlice, endpoint *discoveryv1.Endpoint,
+	virtualNodes map[string]liqov1beta1.ClusterID) liqov1beta1.ClusterID {
+	// EndpointSlices reflected (or imported) from a peer cluster are labeled with the corresponding cluster ID.
+	if origin, ok := slice.Labels[vkforge.LiqoOriginClusterIDKey]; ok {
+		return liqov1beta1.ClusterID(origin)
+	}
+	// Endpoints of offloaded pods refer to the virtual node representing the provider cluster.
+	if endpoint.NodeName != nil {
+		if clusterID, ok := virtualNodes[*endpoint.NodeName]; ok {
+			return clusterID
+		}
+	}
+	return s.LocalClusterID
+}
+
+// virtualNodes returns the map associating the name of each virtual node with the ID of the corresponding cluster.
+func (s *Server) virtualNodes(ctx context.Context) (map[string]liqov1beta1.ClusterID, error) {
+	var nodes corev1.NodeList
+	if err := s.List(ctx, &nodes, client.HasLabels{consts.RemoteClusterID}); err != nil {
+		return nil, fmt.Errorf("failed to list virtual nodes: %w", err)
+	}
+
+	virtualNodes := make(map[string]liqov1beta1.ClusterID, len(nodes.Items))
+	for i := range nodes.Items {
+		virtualNodes[nodes.Items[i].Name] = liqov1beta1.ClusterID(nodes.Items[i].Labels[consts.RemoteClusterID])
+	}
+	return virtualNodes, nil
+}
+
+// get retrieves the given object, returning whether it exists. Missing kinds (e.g., when the Multi-Cluster
+// Services API is not installed) are treated as not found.
+func (s *Server) get(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
+	switch err := s.Get(ctx, key, obj); {
+	case err == nil:
+		return true, nil
+	case kerrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to retrieve %T %s: %w", obj, key, err)
+	}
+}
+
+func parseIPs(addresses []string) []net.IP {
+	ips := make([]net.IP, 0, len(addresses))
+	for _, address := range addresses {
+		if ip := net.ParseIP(address); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+func notFoundIfNoError(err error) error {
+	if err != nil {
+		return err
+	}
+	return ErrNameNotFound
+}
@@ -0,0 +1,164 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+const (
+	// ttl is the time to live of the returned records, kept short since endpoints may change frequently.
+	ttl = 5
+	// queryTimeout is the maximum time allowed to resolve a query.
+	queryTimeout = 5 * time.Second
+	// shutdownTimeout is the maximum time allowed to gracefully shutdown the servers.
+	shutdownTimeout = 5 * time.Second
+)
+
+// Server is a DNS server authoritative for the cross-cluster zone, which resolves the names of the services
+// reflected and imported from the peer clusters to the addresses reachable from the local cluster.
+type Server struct {
+	client.Reader
+
+	LocalClusterID liqov1beta1.ClusterID
+	Zone           string
+	Address        string
+}
+
+// NewServer returns a new Server, serving the given zone on the given address (both UDP and TCP).
+func NewServer(cl client.Reader, localClusterID liqov1beta1.ClusterID, zone, address string) *Server {
+	return &Server{
+		Reader:         cl,
+		LocalClusterID: localClusterID,
+		Zone:           dns.CanonicalName(zone),
+		Address:        address,
+	}
+}
+
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
+
+// Start starts the UDP and TCP servers, until the context is canceled.
+func (s *Server) Start(ctx context.Context) error {
+	servers := []*dns.Server{
+		{Addr: s.Address, Net: "udp", Handler: s},
+		{Addr: s.Address, Net: "tcp", Handler: s},
+	}
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *dns.Server) {
+			errs <- server.ListenAndServe()
+		}(server)
+	}
+
+	klog.Infof("Serving the cross-cluster DNS zone %q on %s", s.Zone, s.Address)
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+		err = fmt.Errorf("failed to serve the cross-cluster DNS zone: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, server := range servers {
+		if shutdownErr := server.ShutdownContext(shutdownCtx); shutdownErr != nil {
+			klog.V(4).Infof("Failed to shutdown the %s DNS server: %v", server.Net, shutdownErr)
+		}
+	}
+
+	return err
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, as every replica
+// needs to answer the queries it receives.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeDNS implements the dns.Handler interface.
+func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	msg := new(dns.Msg)
+	msg.SetReply(r)
+
+	if len(r.Question) != 1 {
+		msg.Rcode = dns.RcodeFormatError
+		s.write(w, msg)
+		return
+	}
+
+	question := r.Question[0]
+	if question.Qclass != dns.ClassINET || !dns.IsSubDomain(s.Zone, dns.CanonicalName(question.Name)) {
+		msg.Rcode = dns.RcodeRefused
+		s.write(w, msg)
+		return
+	}
+
+	msg.Authoritative = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	addresses, err := s.Resolve(ctx, question.Name)
+	switch {
+	case errors.Is(err, ErrNameNotFound):
+		msg.Rcode = dns.RcodeNameError
+	case err != nil:
+		klog.Errorf("Failed to resolve %q: %v", question.Name, err)
+		msg.Rcode = dns.RcodeServerFailure
+	default:
+		msg.Answer = answers(&question, addresses)
+	}
+
+	s.write(w, msg)
+}
+
+func (s *Server) write(w dns.ResponseWriter, msg *dns.Msg) {
+	if err := w.WriteMsg(msg); err != nil {
+		klog.Errorf("Failed to write the DNS response: %v", err)
+	}
+}
+
+// answers returns the resource records of the given addresses matching the type of the given question.
+func answers(question *dns.Question, addresses []net.IP) []dns.RR {
+	var rrs []dns.RR
+	for _, address := range addresses {
+		header := dns.RR_Header{Name: question.Name, Class: dns.ClassINET, Ttl: ttl}
+		switch ipv4 := address.To4(); {
+		case ipv4 != nil && (question.Qtype == dns.TypeA || question.Qtype == dns.TypeANY):
+			header.Rrtype = dns.TypeA
+			rrs = append(rrs, &dns.A{Hdr: header, A: ipv4})
+		case ipv4 == nil && (question.Qtype == dns.TypeAAAA || question.Qtype == dns.TypeANY):
+			header.Rrtype = dns.TypeAAAA
+			rrs = append(rrs, &dns.AAAA{Hdr: header, AAAA: address})
+		}
+	}
+	return rrs
+}
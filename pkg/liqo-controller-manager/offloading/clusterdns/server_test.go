@@ -0,0 +1,216 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterdns_test
+
+import (
+	"context"
+	"net"
+
+	"github.com/miekg/dns"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/clusterdns"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// responseRecorder is a dns.ResponseWriter storing the written message.
+type responseRecorder struct {
+	dns.ResponseWriter
+	msg *dns.Msg
+}
+
+func (r *responseRecorder) WriteMsg(msg *dns.Msg) error {
+	r.msg = msg
+	return nil
+}
+
+var _ = Describe("Cross-cluster DNS server", func() {
+	const (
+		localClusterID = liqov1beta1.ClusterID("local")
+		namespace      = "foo"
+	)
+
+	var (
+		ctx     context.Context
+		server  *clusterdns.Server
+		objects []client.Object
+	)
+
+	ips := func(addresses ...string) []net.IP {
+		var result []net.IP
+		for _, address := range addresses {
+			result = append(result, net.ParseIP(address))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = []client.Object{
+			&liqov1beta1.ForeignCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider", Annotations: map[string]string{consts.ClusterDNSNameAnnotationKey: "Milan"}},
+				Spec:       liqov1beta1.ForeignClusterSpec{ClusterID: "provider-id"},
+			},
+			&liqov1beta1.ForeignCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "consumer"},
+				Spec:       liqov1beta1.ForeignClusterSpec{ClusterID: "consumer-id"},
+			},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "virtual-node",
+				Labels: map[string]string{consts.RemoteClusterID: "provider-id"}}},
+
+			// A local service, with endpoints hosted both locally and by the provider cluster.
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: namespace},
+				Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1", ClusterIPs: []string{"10.0.0.1"}},
+			},
+			&discoveryv1.EndpointSlice{
+				ObjectMeta:  metav1.ObjectMeta{Name: "svc-abcde", Namespace: namespace, Labels: map[string]string{discoveryv1.LabelServiceName: "svc"}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.1.0.1"}, NodeName: ptr.To("node")},
+					{Addresses: []string{"10.2.0.1"}, NodeName: ptr.To("virtual-node")},
+					{Addresses: []string{"10.2.0.2"}, NodeName: ptr.To("virtual-node"), Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+				},
+			},
+
+			// A headless service, with endpoints reflected from the consumer cluster.
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "headless", Namespace: namespace},
+				Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+			},
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "headless-abcde", Namespace: namespace, Labels: map[string]string{
+					discoveryv1.LabelServiceName: "headless", vkforge.LiqoOriginClusterIDKey: "consumer-id"}},
+				AddressType: discoveryv1.AddressTypeIPv6,
+				Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"fd00::1"}}},
+			},
+
+			// A service imported through the Multi-Cluster Services API.
+			&mcsv1alpha1.ServiceImport{
+				ObjectMeta: metav1.ObjectMeta{Name: "imported", Namespace: namespace},
+				Spec:       mcsv1alpha1.ServiceImportSpec{Type: mcsv1alpha1.ClusterSetIP, IPs: []string{"10.0.0.2"}},
+			},
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "imported-abcde-consumer-id", Namespace: namespace, Labels: map[string]string{
+					discoveryv1.LabelServiceName: "derived-imported", mcsv1alpha1.LabelServiceName: "imported",
+					vkforge.LiqoOriginClusterIDKey: "consumer-id"}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.3.0.1"}}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cl := fake.NewClientBuilder().WithObjects(objects...).Build()
+		server = clusterdns.NewServer(cl, localClusterID, "Liqo", ":5353")
+	})
+
+	DescribeTable("Resolve",
+		func(name string, expected []net.IP) {
+			addresses, err := server.Resolve(ctx, name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(addresses).To(ConsistOf(expected))
+		},
+		Entry("the ClusterIP of a local service", "svc.foo.liqo.", ips("10.0.0.1")),
+		Entry("the endpoints of a headless service", "headless.foo.liqo.", ips("fd00::1")),
+		Entry("the IPs of an imported service", "imported.foo.liqo.", ips("10.0.0.2")),
+		Entry("the endpoints hosted by the local cluster", "svc.foo.local.liqo.", ips("10.1.0.1")),
+		Entry("the ready endpoints hosted by a provider, by name", "svc.foo.milan.liqo.", ips("10.2.0.1")),
+		Entry("the ready endpoints hosted by a provider, by cluster ID", "SVC.foo.provider-id.liqo", ips("10.2.0.1")),
+		Entry("the endpoints reflected from a consumer", "headless.foo.consumer-id.liqo.", ips("fd00::1")),
+		Entry("the endpoints imported from a peer", "imported.foo.consumer-id.liqo.", ips("10.3.0.1")),
+		Entry("no endpoints for an existing service", "svc.foo.consumer-id.liqo.", nil),
+		Entry("the zone apex", "liqo.", nil),
+	)
+
+	DescribeTable("Resolve non existing names",
+		func(name string) {
+			_, err := server.Resolve(ctx, name)
+			Expect(err).To(MatchError(clusterdns.ErrNameNotFound))
+		},
+		Entry("a non existing service", "missing.foo.liqo."),
+		Entry("a non existing cluster", "svc.foo.missing.liqo."),
+		Entry("a name with too many labels", "a.svc.foo.local.liqo."),
+		Entry("a name outside the zone", "svc.foo.svc.cluster.local."),
+	)
+
+	Describe("ServeDNS", func() {
+		var (
+			recorder *responseRecorder
+			query    *dns.Msg
+		)
+
+		BeforeEach(func() { query = new(dns.Msg) })
+
+		JustBeforeEach(func() {
+			recorder = &responseRecorder{}
+			server.ServeDNS(recorder, query)
+		})
+
+		When("querying the A records of an existing service", func() {
+			BeforeEach(func() { query.SetQuestion("svc.foo.milan.liqo.", dns.TypeA) })
+
+			It("should return the addresses", func() {
+				Expect(recorder.msg.Rcode).To(Equal(dns.RcodeSuccess))
+				Expect(recorder.msg.Authoritative).To(BeTrue())
+				Expect(recorder.msg.Answer).To(HaveLen(1))
+				Expect(recorder.msg.Answer[0].(*dns.A).A.String()).To(Equal("10.2.0.1"))
+			})
+		})
+
+		When("querying the A records of a service with IPv6 endpoints only", func() {
+			BeforeEach(func() { query.SetQuestion("headless.foo.liqo.", dns.TypeA) })
+
+			It("should return no records", func() {
+				Expect(recorder.msg.Rcode).To(Equal(dns.RcodeSuccess))
+				Expect(recorder.msg.Answer).To(BeEmpty())
+			})
+		})
+
+		When("querying the AAAA records of a service with IPv6 endpoints", func() {
+			BeforeEach(func() { query.SetQuestion("headless.foo.liqo.", dns.TypeAAAA) })
+
+			It("should return the addresses", func() {
+				Expect(recorder.msg.Answer).To(HaveLen(1))
+				Expect(recorder.msg.Answer[0].(*dns.AAAA).AAAA.String()).To(Equal("fd00::1"))
+			})
+		})
+
+		When("querying a non existing name", func() {
+			BeforeEach(func() { query.SetQuestion("missing.foo.liqo.", dns.TypeA) })
+
+			It("should return NXDOMAIN", func() { Expect(recorder.msg.Rcode).To(Equal(dns.RcodeNameError)) })
+		})
+
+		When("querying a name outside the zone", func() {
+			BeforeEach(func() { query.SetQuestion("svc.foo.svc.cluster.local.", dns.TypeA) })
+
+			It("should refuse the query", func() {
+				Expect(recorder.msg.Rcode).To(Equal(dns.RcodeRefused))
+				Expect(recorder.msg.Authoritative).To(BeFalse())
+			})
+		})
+	})
+})
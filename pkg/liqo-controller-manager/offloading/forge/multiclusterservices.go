@@ -0,0 +1,252 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+const (
+	// ServiceImportEndpointSliceManagedBy is the manager associated with the EndpointSlices of imported Services.
+	ServiceImportEndpointSliceManagedBy = "serviceimport.liqo.io"
+
+	derivedServicePrefix = "derived-"
+)
+
+// ExportedServiceName returns the name of the ExportedService associated with the given Service.
+// Namespace and Service names cannot contain dots, hence the resulting name is unique.
+func ExportedServiceName(namespace, name string) string {
+	return fmt.Sprintf("%s.%s", name, namespace)
+}
+
+// ExportedServiceLabels returns the labels identifying the ExportedServices associated with the given Service.
+func ExportedServiceLabels(namespace, name string) labels.Set {
+	return labels.Set{
+		consts.ExportedServiceNamespaceLabelKey: namespace,
+		consts.ExportedServiceNameLabelKey:      name,
+	}
+}
+
+// ExportedServiceReplicationLabels returns the labels requesting the replication of an ExportedService to the given cluster.
+func ExportedServiceReplicationLabels(destination liqov1beta1.ClusterID) labels.Set {
+	return labels.Set{
+		consts.ReplicationRequestedLabel:   consts.ReplicationRequestedLabelValue,
+		consts.ReplicationDestinationLabel: string(destination),
+	}
+}
+
+// ServiceImportType returns the type of the ServiceImport corresponding to the given Service.
+func ServiceImportType(svc *corev1.Service) mcsv1alpha1.ServiceImportType {
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return mcsv1alpha1.Headless
+	}
+	return mcsv1alpha1.ClusterSetIP
+}
+
+// ExportedServiceSpec forges the spec of the ExportedService describing the given Service and EndpointSlices.
+// The endpoints not satisfying the given filter (e.g., the ones hosted by virtual nodes) are not exported.
+func ExportedServiceSpec(svc *corev1.Service, slices []discoveryv1.EndpointSlice, localClusterID liqov1beta1.ClusterID,
+	filter func(*discoveryv1.Endpoint) bool) offloadingv1beta1.ExportedServiceSpec {
+	spec := offloadingv1beta1.ExportedServiceSpec{
+		ServiceNamespace:      svc.GetNamespace(),
+		ServiceName:           svc.GetName(),
+		Type:                  ServiceImportType(svc),
+		SessionAffinity:       svc.Spec.SessionAffinity,
+		SessionAffinityConfig: svc.Spec.SessionAffinityConfig.DeepCopy(),
+	}
+
+	for i := range svc.Spec.Ports {
+		port := &svc.Spec.Ports[i]
+		spec.Ports = append(spec.Ports, mcsv1alpha1.ServicePort{
+			Name: port.Name, Protocol: port.Protocol, AppProtocol: port.AppProtocol, Port: port.Port,
+		})
+	}
+
+	for i := range slices {
+		slice := &slices[i]
+		exported := offloadingv1beta1.ExportedEndpointSlice{
+			Name: slice.GetName(),
+			Template: offloadingv1beta1.EndpointSliceTemplate{
+				AddressType: slice.AddressType,
+				Ports:       vkforge.RemoteEndpointSlicePorts(slice.Ports),
+			},
+		}
+
+		for j := range slice.Endpoints {
+			if !filter(&slice.Endpoints[j]) {
+				continue
+			}
+
+			local := slice.Endpoints[j].DeepCopy()
+			exported.Template.Endpoints = append(exported.Template.Endpoints, discoveryv1.Endpoint{
+				Addresses:  local.Addresses,
+				Conditions: discoveryv1.EndpointConditions{Ready: local.Conditions.Ready},
+				Hostname:   local.Hostname,
+				TargetRef:  vkforge.RemoteEndpointTargetRef(local.TargetRef),
+				NodeName:   ptr.To(string(localClusterID)),
+				Zone:       local.Zone,
+				Hints:      local.Hints,
+			})
+		}
+
+		spec.EndpointSlices = append(spec.EndpointSlices, exported)
+	}
+
+	// Sort the EndpointSlices, to prevent spurious updates due to the ordering of the cache.
+	sort.Slice(spec.EndpointSlices, func(i, j int) bool {
+		return spec.EndpointSlices[i].Name < spec.EndpointSlices[j].Name
+	})
+
+	return spec
+}
+
+// DerivedServiceName returns the name of the Service backing the ServiceImport with the given name.
+// The name is truncated and suffixed with a hash in case it would exceed the maximum length of a Service name.
+func DerivedServiceName(name string) string {
+	derived := derivedServicePrefix + name
+	if len(derived) <= validation.DNS1035LabelMaxLength {
+		return derived
+	}
+
+	hasher := fnv.New32a()
+	// The hash.Hash interface guarantees that Write never returns an error.
+	_, _ = hasher.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", hasher.Sum32())
+	return derived[:validation.DNS1035LabelMaxLength-len(suffix)] + suffix
+}
+
+// ServiceImportLabels returns the labels assigned to the resources backing the ServiceImport with the given name.
+func ServiceImportLabels(name string) labels.Set {
+	return labels.Set{
+		consts.ManagedByLabelKey:     consts.ManagedByServiceImportValue,
+		mcsv1alpha1.LabelServiceName: name,
+	}
+}
+
+// MergeServiceImportPorts returns the union of the ports of the given ExportedServices.
+// Ports with the same name and protocol are considered the same port, and the first occurrence wins.
+func MergeServiceImportPorts(exports []offloadingv1beta1.ExportedService) []mcsv1alpha1.ServicePort {
+	type key struct {
+		name     string
+		protocol corev1.Protocol
+	}
+
+	var ports []mcsv1alpha1.ServicePort
+	seen := map[key]struct{}{}
+	for i := range exports {
+		for _, port := range exports[i].Spec.Ports {
+			k := key{name: port.Name, protocol: port.Protocol}
+			if _, found := seen[k]; found {
+				continue
+			}
+			seen[k] = struct{}{}
+			ports = append(ports, *port.DeepCopy())
+		}
+	}
+	return ports
+}
+
+// MutateDerivedService mutates the Service backing the ServiceImport with the given name.
+// The spec is the one of the ExportedService taking precedence, while the ports are the merged ones.
+func MutateDerivedService(svc *corev1.Service, name string, spec *offloadingv1beta1.ExportedServiceSpec, ports []mcsv1alpha1.ServicePort) {
+	svc.SetLabels(labels.Merge(svc.GetLabels(), ServiceImportLabels(name)))
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	// The derived Service has no selector, as its EndpointSlices are managed by Liqo.
+	svc.Spec.Selector = nil
+	if spec.Type == mcsv1alpha1.Headless {
+		// The cluster IP is immutable, hence it is only set when the Service is created.
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+	svc.Spec.SessionAffinity = spec.SessionAffinity
+	svc.Spec.SessionAffinityConfig = spec.SessionAffinityConfig.DeepCopy()
+
+	svc.Spec.Ports = nil
+	for _, port := range ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:        port.Name,
+			Protocol:    port.Protocol,
+			AppProtocol: port.AppProtocol,
+			Port:        port.Port,
+			TargetPort:  intstr.FromInt32(port.Port),
+		})
+	}
+}
+
+// MutateServiceImport mutates the ServiceImport with the given name, given the backing Service and the current exporters.
+func MutateServiceImport(si *mcsv1alpha1.ServiceImport, derived *corev1.Service,
+	spec *offloadingv1beta1.ExportedServiceSpec, ports []mcsv1alpha1.ServicePort) {
+	si.SetLabels(labels.Merge(si.GetLabels(), ServiceImportLabels(si.GetName())))
+	si.Spec.Type = spec.Type
+	si.Spec.Ports = ports
+	si.Spec.SessionAffinity = spec.SessionAffinity
+	si.Spec.SessionAffinityConfig = spec.SessionAffinityConfig.DeepCopy()
+	si.Spec.IPFamilies = derived.Spec.IPFamilies
+
+	si.Spec.IPs = nil
+	if spec.Type == mcsv1alpha1.ClusterSetIP && derived.Spec.ClusterIP != corev1.ClusterIPNone {
+		si.Spec.IPs = derived.Spec.ClusterIPs
+	}
+}
+
+// ServiceImportClusters returns the status of the ServiceImport clusters, given the origin of the current exporters.
+func ServiceImportClusters(origins []liqov1beta1.ClusterID) []mcsv1alpha1.ClusterStatus {
+	clusters := make([]mcsv1alpha1.ClusterStatus, 0, len(origins))
+	for _, origin := range origins {
+		clusters = append(clusters, mcsv1alpha1.ClusterStatus{Cluster: string(origin)})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Cluster < clusters[j].Cluster })
+	return clusters
+}
+
+// ImportedShadowEndpointSliceName returns the name of the ShadowEndpointSlice corresponding
+// to the given EndpointSlice exported by the given cluster.
+func ImportedShadowEndpointSliceName(sliceName string, origin liqov1beta1.ClusterID) string {
+	return fmt.Sprintf("%s-%s", sliceName, origin)
+}
+
+// ImportedShadowEndpointSliceLabels returns the labels identifying the ShadowEndpointSlices
+// corresponding to the Service with the given name exported by the given cluster.
+func ImportedShadowEndpointSliceLabels(name string, origin liqov1beta1.ClusterID) labels.Set {
+	return labels.Merge(ServiceImportLabels(name), labels.Set{
+		mcsv1alpha1.LabelSourceCluster: string(origin),
+	})
+}
+
+// MutateImportedShadowEndpointSlice mutates the ShadowEndpointSlice corresponding to the given exported EndpointSlice.
+// The addresses are the native ones of the origin cluster, and they are remapped by the ShadowEndpointSlice controller.
+func MutateImportedShadowEndpointSlice(shadow *offloadingv1beta1.ShadowEndpointSlice, name string,
+	origin liqov1beta1.ClusterID, exported *offloadingv1beta1.ExportedEndpointSlice) {
+	shadow.SetLabels(labels.Merge(shadow.GetLabels(), labels.Merge(ImportedShadowEndpointSliceLabels(name, origin), labels.Set{
+		vkforge.LiqoOriginClusterIDKey: string(origin),
+		discoveryv1.LabelServiceName:   DerivedServiceName(name),
+		discoveryv1.LabelManagedBy:     ServiceImportEndpointSliceManagedBy,
+	})))
+	shadow.Spec.Template = *exported.Template.DeepCopy()
+}
@@ -38,8 +38,10 @@ type VirtualNodeOptions struct {
 	StorageClasses      []liqov1beta1.StorageType      `json:"storageClasses,omitempty"`
 	IngressClasses      []liqov1beta1.IngressType      `json:"ingressClasses,omitempty"`
 	LoadBalancerClasses []liqov1beta1.LoadBalancerType `json:"loadBalancerClasses,omitempty"`
+	DeviceClasses       []liqov1beta1.DeviceClassType  `json:"deviceClasses,omitempty"`
 	NodeLabels          map[string]string              `json:"nodeLabels,omitempty"`
 	NodeSelector        map[string]string              `json:"nodeSelector,omitempty"`
+	Tolerations         []corev1.Toleration            `json:"tolerations,omitempty"`
 }
 
 // VirtualNode forges a VirtualNode resource.
@@ -88,6 +90,7 @@ func MutateVirtualNode(ctx context.Context, cl client.Client, virtualNode *offlo
 	virtualNode.Spec.StorageClasses = opts.StorageClasses
 	virtualNode.Spec.IngressClasses = opts.IngressClasses
 	virtualNode.Spec.LoadBalancerClasses = opts.LoadBalancerClasses
+	virtualNode.Spec.DeviceClasses = opts.DeviceClasses
 
 	if runtimeClassName != nil && *runtimeClassName != "" {
 		if virtualNode.Spec.OffloadingPatch == nil {
@@ -104,6 +107,14 @@ func MutateVirtualNode(ctx context.Context, cl client.Client, virtualNode *offlo
 		virtualNode.Spec.OffloadingPatch.NodeSelector = opts.NodeSelector
 	}
 
+	if len(opts.Tolerations) > 0 {
+		if virtualNode.Spec.OffloadingPatch == nil {
+			virtualNode.Spec.OffloadingPatch = &offloadingv1beta1.OffloadingPatch{}
+		}
+
+		virtualNode.Spec.OffloadingPatch.Tolerations = opts.Tolerations
+	}
+
 	vkOptionsTemplate := offloadingv1beta1.VkOptionsTemplate{}
 	if virtualNode.Spec.VkOptionsTemplateRef != nil {
 		if err := cl.Get(ctx, types.NamespacedName{
@@ -132,7 +143,9 @@ func VirtualNodeOptionsFromResourceSlice(resourceSlice *authv1beta1.ResourceSlic
 		StorageClasses:      resourceSlice.Status.StorageClasses,
 		IngressClasses:      resourceSlice.Status.IngressClasses,
 		LoadBalancerClasses: resourceSlice.Status.LoadBalancerClasses,
+		DeviceClasses:       resourceSlice.Status.DeviceClasses,
 		NodeLabels:          resourceSlice.Status.NodeLabels,
 		NodeSelector:        resourceSlice.Status.NodeSelector,
+		Tolerations:         resourceSlice.Status.Tolerations,
 	}
 }
@@ -0,0 +1,149 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// NativeWorkload forges the native workload (i.e., Deployment, StatefulSet or Job) backing the given ShadowWorkload.
+// The returned object is meant to be server-side applied, hence it includes the type information.
+func NativeWorkload(shadow *offloadingv1beta1.ShadowWorkload) (client.Object, error) {
+	meta := metav1.ObjectMeta{
+		Name:        shadow.GetName(),
+		Namespace:   shadow.GetNamespace(),
+		Labels:      labels.Merge(shadow.GetLabels(), labels.Set{consts.ManagedByLabelKey: consts.ManagedByShadowWorkloadValue}),
+		Annotations: shadow.GetAnnotations(),
+	}
+	spec := shadow.Spec.DeepCopy()
+
+	switch spec.Kind {
+	case offloadingv1beta1.DeploymentWorkloadKind:
+		deploy := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: meta,
+			Spec: appsv1.DeploymentSpec{
+				Replicas:        spec.Replicas,
+				Selector:        spec.Selector,
+				Template:        spec.Template,
+				MinReadySeconds: spec.MinReadySeconds,
+			},
+		}
+		if spec.Deployment != nil {
+			deploy.Spec.Strategy = spec.Deployment.Strategy
+		}
+		return deploy, nil
+
+	case offloadingv1beta1.StatefulSetWorkloadKind:
+		sts := &appsv1.StatefulSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "StatefulSet"},
+			ObjectMeta: meta,
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:        spec.Replicas,
+				Selector:        spec.Selector,
+				Template:        spec.Template,
+				MinReadySeconds: spec.MinReadySeconds,
+			},
+		}
+		if spec.StatefulSet != nil {
+			sts.Spec.ServiceName = spec.StatefulSet.ServiceName
+			sts.Spec.PodManagementPolicy = spec.StatefulSet.PodManagementPolicy
+			sts.Spec.UpdateStrategy = spec.StatefulSet.UpdateStrategy
+		}
+		return sts, nil
+
+	case offloadingv1beta1.JobWorkloadKind:
+		// The selector is not set, as automatically generated by the API server.
+		job := &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+			ObjectMeta: meta,
+			Spec:       batchv1.JobSpec{Template: spec.Template},
+		}
+		if spec.Job != nil {
+			job.Spec.Parallelism = spec.Job.Parallelism
+			job.Spec.Completions = spec.Job.Completions
+			job.Spec.BackoffLimit = spec.Job.BackoffLimit
+			job.Spec.ActiveDeadlineSeconds = spec.Job.ActiveDeadlineSeconds
+			job.Spec.CompletionMode = spec.Job.CompletionMode
+		}
+		return job, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %q", spec.Kind)
+	}
+}
+
+// ShadowWorkloadStatus forges the status of a ShadowWorkload, aggregating the one of the given native workload.
+func ShadowWorkloadStatus(workload client.Object, observedGeneration int64) offloadingv1beta1.ShadowWorkloadStatus {
+	status := offloadingv1beta1.ShadowWorkloadStatus{ObservedGeneration: observedGeneration}
+
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		status.Replicas = w.Status.Replicas
+		status.ReadyReplicas = w.Status.ReadyReplicas
+		status.AvailableReplicas = w.Status.AvailableReplicas
+		status.UpdatedReplicas = w.Status.UpdatedReplicas
+		for i := range w.Status.Conditions {
+			cond := &w.Status.Conditions[i]
+			status.Conditions = append(status.Conditions,
+				shadowWorkloadCondition(string(cond.Type), cond.Status, cond.LastTransitionTime, cond.Reason, cond.Message))
+		}
+	case *appsv1.StatefulSet:
+		status.Replicas = w.Status.Replicas
+		status.ReadyReplicas = w.Status.ReadyReplicas
+		status.AvailableReplicas = w.Status.AvailableReplicas
+		status.UpdatedReplicas = w.Status.UpdatedReplicas
+		for i := range w.Status.Conditions {
+			cond := &w.Status.Conditions[i]
+			status.Conditions = append(status.Conditions,
+				shadowWorkloadCondition(string(cond.Type), cond.Status, cond.LastTransitionTime, cond.Reason, cond.Message))
+		}
+	case *batchv1.Job:
+		status.Active = w.Status.Active
+		status.Succeeded = w.Status.Succeeded
+		status.Failed = w.Status.Failed
+		if w.Status.Ready != nil {
+			status.ReadyReplicas = *w.Status.Ready
+		}
+		for i := range w.Status.Conditions {
+			cond := &w.Status.Conditions[i]
+			status.Conditions = append(status.Conditions,
+				shadowWorkloadCondition(string(cond.Type), cond.Status, cond.LastTransitionTime, cond.Reason, cond.Message))
+		}
+	}
+
+	return status
+}
+
+func shadowWorkloadCondition(condType string, status corev1.ConditionStatus, lastTransitionTime metav1.Time,
+	reason, message string) offloadingv1beta1.ShadowWorkloadCondition {
+	return offloadingv1beta1.ShadowWorkloadCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: lastTransitionTime,
+		Reason:             reason,
+		Message:            message,
+	}
+}
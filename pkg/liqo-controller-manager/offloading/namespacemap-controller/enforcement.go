@@ -29,8 +29,10 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/utils"
 	liqoerrors "github.com/liqotech/liqo/pkg/utils/errors"
 	"github.com/liqotech/liqo/pkg/utils/resource"
@@ -82,7 +84,16 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 	// Make sure the appropriate role binding is present in the namespace for virtual kubelet operations.
 	// The rolebinding is named after the tenant namespace name, since that is guaranteed to be unique.
 	// This will simplify the support for remote namespaces associated with multiple origins.
+	clusterRoleName, err := r.offloadedNamespaceClusterRoleName(ctx, origin)
+	if err != nil {
+		return true, err
+	}
+
 	binding := rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: name, Name: nm.GetNamespace()}}
+	if err := r.ensureRoleRefUnchanged(ctx, &binding, clusterRoleName); err != nil {
+		return true, err
+	}
+
 	result, err := resource.CreateOrUpdate(ctx, r.Client, &binding, func() error {
 		binding.Annotations = labels.Merge(binding.GetAnnotations(), map[string]string{
 			consts.RemoteNamespaceManagedByAnnotationKey: nmID,
@@ -94,7 +105,7 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 
 		if binding.CreationTimestamp.IsZero() {
 			binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: origin}}
-			binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: consts.RemoteNamespaceClusterRoleName}
+			binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRoleName}
 		}
 
 		return nil
@@ -107,12 +118,76 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 	return true, nil
 }
 
+// offloadedNamespaceClusterRoleName returns the name of the ClusterRole to be bound to the identities of the given origin cluster
+// in the offloaded namespaces, that is the one rendered from a TenantRBACTemplate if present, and the default one otherwise.
+func (r *NamespaceMapReconciler) offloadedNamespaceClusterRoleName(ctx context.Context, origin string) (string, error) {
+	name := authentication.TenantOffloadedNamespaceClusterRoleName(liqov1beta1.ClusterID(origin))
+	var clusterRole rbacv1.ClusterRole
+	err := r.Get(ctx, types.NamespacedName{Name: name}, &clusterRole)
+	switch {
+	case apierrors.IsNotFound(err):
+		return consts.RemoteNamespaceClusterRoleName, nil
+	case err != nil:
+		return "", fmt.Errorf("failed to retrieve ClusterRole %q: %w", name, err)
+	default:
+		return name, nil
+	}
+}
+
+// ensureRoleRefUnchanged deletes the given role binding if it refers to a ClusterRole different from the expected one,
+// since the role reference is immutable and the binding needs to be recreated.
+func (r *NamespaceMapReconciler) ensureRoleRefUnchanged(ctx context.Context, binding *rbacv1.RoleBinding, clusterRoleName string) error {
+	var existing rbacv1.RoleBinding
+	if err := r.Get(ctx, client.ObjectKeyFromObject(binding), &existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if existing.RoleRef.Name == clusterRoleName {
+		return nil
+	}
+
+	if err := client.IgnoreNotFound(r.Delete(ctx, &existing)); err != nil {
+		return fmt.Errorf("failed to delete role binding %q: %w", klog.KObj(&existing), err)
+	}
+	klog.Infof("RoleBinding %q deleted, as it refers to ClusterRole %q instead of %q", klog.KObj(&existing), existing.RoleRef.Name, clusterRoleName)
+	return nil
+}
+
 // For every entry of DesiredMapping create remote Namespace if it has not already being created.
 // ensureNamespacesExistence tries to create all the remote namespaces requested in DesiredMapping (NamespaceMap->Spec->DesiredMapping).
 func (r *NamespaceMapReconciler) ensureNamespacesExistence(ctx context.Context, nm *offloadingv1beta1.NamespaceMap) error {
-	var err error
+	nmID, err := cache.MetaNamespaceKeyFunc(nm)
+	utilruntime.Must(err)
 
 	for originName, destinationName := range nm.Spec.DesiredMapping {
+		// The remote name of an accepted namespace is never changed (e.g., due to a cluster-specific mapping override),
+		// as this would require deleting the previous namespace together with all the resources it contains. Otherwise,
+		// the previous namespace needs to be removed (if managed by the NamespaceMap) before creating the new one.
+		if previous, found := nm.Status.CurrentMapping[originName]; found && previous.RemoteNamespace != destinationName {
+			if previous.Phase == offloadingv1beta1.MappingAccepted {
+				klog.Warningf("Namespace %q is already mapped to %q: ignoring the remapping to %q", originName, previous.RemoteNamespace, destinationName)
+				continue
+			}
+
+			existing, deletionError := r.deleteNamespace(ctx, previous.RemoteNamespace, nmID)
+			if deletionError != nil {
+				klog.Errorf("Namespace enforcement failure: %v", deletionError)
+				err = deletionError
+				continue
+			}
+
+			if existing {
+				nm.Status.CurrentMapping[originName] = offloadingv1beta1.RemoteNamespaceStatus{
+					RemoteNamespace: previous.RemoteNamespace,
+					Phase:           offloadingv1beta1.MappingTerminating,
+				}
+				continue
+			}
+
+			klog.Infof("Namespace %q remapped from %q to %q", originName, previous.RemoteNamespace, destinationName)
+			delete(nm.Status.CurrentMapping, originName)
+		}
+
 		phase := offloadingv1beta1.MappingAccepted
 		if ignorable, creationError := r.createNamespace(ctx, destinationName, originName, nm); creationError != nil {
 			// Do not overwrite the phase in case the mapping was already present, and this is marked as a temporary error.
@@ -173,8 +248,8 @@ func (r *NamespaceMapReconciler) ensureNamespacesDeletion(ctx context.Context, n
 		}
 
 		existing, deletionError := r.deleteNamespace(ctx, destinationStatus.RemoteNamespace, nmID)
-		if err != nil {
-			klog.Errorf("Namespace enforcement failure: %v", err)
+		if deletionError != nil {
+			klog.Errorf("Namespace enforcement failure: %v", deletionError)
 			err = deletionError
 			continue
 		}
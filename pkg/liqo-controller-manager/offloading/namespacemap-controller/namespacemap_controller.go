@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -34,6 +35,7 @@ import (
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 )
 
 // NamespaceMapReconciler creates remote namespaces and updates NamespaceMaps Status.
@@ -44,6 +46,7 @@ type NamespaceMapReconciler struct {
 // cluster-role
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps,verbs=get;watch;list;update;patch;create;delete
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps/finalizers,verbs=get;update;patch
@@ -93,6 +96,10 @@ func (r *NamespaceMapReconciler) Reconcile(ctx context.Context, req ctrl.Request
 func (r *NamespaceMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	filter, err := predicate.LabelSelectorPredicate(reflection.ReplicatedResourcesLabelSelector())
 	utilruntime.Must(err)
+	clusterRoleFilter, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{consts.K8sAppComponentKey: authentication.TenantRBACComponentValue},
+	})
+	utilruntime.Must(err)
 
 	enqueuer := func(_ context.Context, obj client.Object) []reconcile.Request {
 		nm, found := obj.GetAnnotations()[consts.RemoteNamespaceManagedByAnnotationKey]
@@ -116,5 +123,28 @@ func (r *NamespaceMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// https://kubernetes.io/docs/concepts/overview/working-with-objects/owners-dependents/.
 		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(enqueuer)).
 		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(enqueuer)).
+		Watches(&rbacv1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(r.clusterRoleEnqueuer),
+			builder.WithPredicates(clusterRoleFilter)).
 		Complete(r)
 }
+
+// clusterRoleEnqueuer enqueues the NamespaceMaps of the cluster a ClusterRole rendered from a TenantRBACTemplate refers to,
+// so that the role bindings in the offloaded namespaces are updated accordingly.
+func (r *NamespaceMapReconciler) clusterRoleEnqueuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	origin, found := obj.GetLabels()[consts.RemoteClusterID]
+	if !found {
+		return nil
+	}
+
+	var nms offloadingv1beta1.NamespaceMapList
+	if err := r.List(ctx, &nms, client.MatchingLabels{consts.ReplicationOriginLabel: origin}); err != nil {
+		klog.Errorf("Failed to list the NamespaceMaps of cluster %q: %v", origin, err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(nms.Items))
+	for i := range nms.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&nms.Items[i])}
+	}
+	return requests
+}
@@ -31,6 +31,7 @@ import (
 
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	namespacemapctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespacemap-controller"
 	. "github.com/liqotech/liqo/pkg/utils/testutil"
 )
@@ -120,6 +121,41 @@ var _ = Describe("Enforcement logic", func() {
 				Describe("perform checks", func() { SuccessWhenBody() })
 			})
 
+			When("a ClusterRole has been rendered from a TenantRBACTemplate for the origin cluster", func() {
+				var rendered string
+
+				BeforeEach(func() {
+					rendered = authentication.TenantOffloadedNamespaceClusterRoleName("origin")
+					clientBuilder.WithObjects(authentication.ForgeTenantClusterRole(rendered, "origin", nil))
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should bind the rendered ClusterRole", func() {
+					var binding rbacv1.RoleBinding
+					Expect(reconciler.Get(ctx, types.NamespacedName{Namespace: "namespace-remote", Name: "tenant-namespace"}, &binding)).To(Succeed())
+					Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: rendered}))
+				})
+
+				When("the rolebinding already refers to the default ClusterRole", func() {
+					BeforeEach(func() {
+						binding := rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-remote", Name: "tenant-namespace"},
+							RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole",
+								Name: liqoconst.RemoteNamespaceClusterRoleName},
+						}
+						clientBuilder.WithObjects(&binding)
+					})
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("should recreate the rolebinding referring to the rendered ClusterRole", func() {
+						var binding rbacv1.RoleBinding
+						Expect(reconciler.Get(ctx, types.NamespacedName{Namespace: "namespace-remote", Name: "tenant-namespace"}, &binding)).To(Succeed())
+						Expect(binding.RoleRef.Name).To(Equal(rendered))
+						Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "origin"}))
+					})
+				})
+			})
+
 			When("the namespace already exists but it is not managed by the NamespaceMap", func() {
 				BeforeEach(func() {
 					namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote"}}
@@ -245,6 +281,69 @@ var _ = Describe("Enforcement logic", func() {
 				})
 			})
 		})
+
+		Context("remapping", func() {
+			BeforeEach(func() {
+				nm.Spec.DesiredMapping = map[string]string{"namespace": "namespace-renamed"}
+				nm.Status.CurrentMapping = map[string]offloadingv1beta1.RemoteNamespaceStatus{
+					"namespace": {RemoteNamespace: "namespace-remote", Phase: offloadingv1beta1.MappingAccepted},
+				}
+			})
+
+			When("the previous namespace has been accepted", func() {
+				BeforeEach(func() {
+					namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote",
+						Annotations: map[string]string{
+							liqoconst.RemoteNamespaceManagedByAnnotationKey:    "tenant-namespace/name",
+							liqoconst.RemoteNamespaceOriginalNameAnnotationKey: "namespace",
+						},
+						Labels: map[string]string{liqoconst.RemoteClusterID: "origin"},
+					}}
+					clientBuilder.WithObjects(&namespace)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not delete the previous namespace", func() {
+					var namespace corev1.Namespace
+					Expect(reconciler.Get(ctx, types.NamespacedName{Name: "namespace-remote"}, &namespace)).To(Succeed())
+				})
+				It("should not create the new namespace", func() {
+					var namespace corev1.Namespace
+					Expect(reconciler.Get(ctx, types.NamespacedName{Name: "namespace-renamed"}, &namespace)).To(BeNotFound())
+				})
+				It("should preserve the previous mapping in the NamespaceMap status", func() {
+					var updated offloadingv1beta1.NamespaceMap
+					Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(&nm), &updated)).To(Succeed())
+					Expect(updated.Status.CurrentMapping).To(HaveKeyWithValue("namespace",
+						offloadingv1beta1.RemoteNamespaceStatus{RemoteNamespace: "namespace-remote", Phase: offloadingv1beta1.MappingAccepted}))
+				})
+			})
+
+			When("the previous namespace could not be created", func() {
+				BeforeEach(func() {
+					nm.Status.CurrentMapping["namespace"] = offloadingv1beta1.RemoteNamespaceStatus{
+						RemoteNamespace: "namespace-remote", Phase: offloadingv1beta1.MappingCreationLoopBackOff}
+					namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote"}}
+					clientBuilder.WithObjects(&namespace)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not delete the conflicting namespace", func() {
+					var namespace corev1.Namespace
+					Expect(reconciler.Get(ctx, types.NamespacedName{Name: "namespace-remote"}, &namespace)).To(Succeed())
+				})
+				It("should correctly ensure the new namespace is present", func() {
+					var namespace corev1.Namespace
+					Expect(reconciler.Get(ctx, types.NamespacedName{Name: "namespace-renamed"}, &namespace)).To(Succeed())
+				})
+				It("should correctly update the NamespaceMap status", func() {
+					var updated offloadingv1beta1.NamespaceMap
+					Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(&nm), &updated)).To(Succeed())
+					Expect(updated.Status.CurrentMapping).To(HaveKeyWithValue("namespace",
+						offloadingv1beta1.RemoteNamespaceStatus{RemoteNamespace: "namespace-renamed", Phase: offloadingv1beta1.MappingAccepted}))
+				})
+			})
+		})
 	})
 
 	Context("the NamespaceMap is being terminated", func() {
@@ -59,10 +59,12 @@ func (r *NamespaceOffloadingReconciler) enforceClusterSelector(ctx context.Conte
 			return fmt.Errorf("invalid ClusterSelector: %w", err)
 		}
 
-		nm := clusterIDToNsMap[string(virtualNodes.Items[i].Spec.ClusterID)]
+		clusterID := virtualNodes.Items[i].Spec.ClusterID
+		nm := clusterIDToNsMap[string(clusterID)]
 
 		if match {
-			if err = addDesiredMapping(ctx, r.Client, nsoff.Namespace, r.remoteNamespaceName(nsoff), nm); err != nil {
+			remoteName := r.remoteNamespaceNameForCluster(nsoff, clusterID)
+			if err = addDesiredMapping(ctx, r.Client, nsoff.Namespace, remoteName, nm); err != nil {
 				errs = append(errs, fmt.Errorf("adding namespace %q to NamespaceMap %s: %w", nsoff.Namespace, nm.Name, err))
 			}
 		} else {
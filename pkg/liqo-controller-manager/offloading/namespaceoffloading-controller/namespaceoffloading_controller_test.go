@@ -365,6 +365,43 @@ var _ = Describe("Namespace controller", func() {
 
 	})
 
+	It("Create a NamespaceOffloading resource with cluster-specific mappings", func() {
+		var nm offloadingv1beta1.NamespaceMap
+		defaultName := fmt.Sprintf("%s-%s", namespaceName, foreignclusterutils.UniqueName(localCluster))
+		nsoff = &offloadingv1beta1.NamespaceOffloading{
+			ObjectMeta: metav1.ObjectMeta{Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: namespaceName},
+			Spec: offloadingv1beta1.NamespaceOffloadingSpec{
+				NamespaceMappingStrategy: offloadingv1beta1.DefaultNameMappingStrategyType,
+				PodOffloadingStrategy:    offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType,
+				ClusterMappings: []offloadingv1beta1.ClusterNamespaceMapping{
+					{ClusterID: remoteCluster1, NamespaceMappingStrategy: offloadingv1beta1.EnforceSameNameMappingStrategyType},
+					{ClusterID: remoteCluster2, NamespaceMappingStrategy: offloadingv1beta1.SelectedNameMappingStrategyType,
+						RemoteNamespaceName: "selected"},
+				},
+			},
+		}
+
+		By(fmt.Sprintf("Create NamespaceOffloading resource in Namespace %q", namespaceName))
+		Expect(cl.Create(ctx, nsoff)).To(Succeed())
+
+		By("Check the NamespaceMaps of virtual nodes")
+		for obj, expected := range map[*offloadingv1beta1.NamespaceMap]string{nm1: namespaceName, nm2: "selected", nm3: defaultName} {
+			Eventually(func() map[string]string {
+				Expect(cl.Get(ctx, client.ObjectKeyFromObject(obj), &nm)).To(Succeed())
+				return nm.Spec.DesiredMapping
+			}).Should(HaveKeyWithValue(namespaceName, expected))
+		}
+
+		By("Check the remote namespace names in the status")
+		Eventually(func() map[string]string {
+			Expect(cl.Get(ctx, client.ObjectKeyFromObject(nsoff), nsoff)).To(Succeed())
+			return nsoff.Status.RemoteNamespacesNames
+		}).Should(Equal(map[string]string{
+			string(remoteCluster1): namespaceName, string(remoteCluster2): "selected", string(remoteCluster3): defaultName,
+		}))
+		Expect(nsoff.Status.RemoteNamespaceName).To(Equal(defaultName))
+	})
+
 	It("Create a NamespaceOffloading resource with a wrong clusterSelector", func() {
 		var nm offloadingv1beta1.NamespaceMap
 		nsoff = &offloadingv1beta1.NamespaceOffloading{
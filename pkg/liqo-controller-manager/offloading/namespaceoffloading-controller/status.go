@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	foreignclusterutils "github.com/liqotech/liqo/pkg/utils/foreigncluster"
 )
@@ -34,6 +35,7 @@ func (r *NamespaceOffloadingReconciler) enforceStatus(ctx context.Context, nsoff
 	orig := nsoff.Status.DeepCopy()
 
 	nsoff.Status.RemoteNamespaceName = r.remoteNamespaceName(nsoff)
+	nsoff.Status.RemoteNamespacesNames = remoteNamespacesNames(nsoff, nsmaps)
 
 	// Update the observed generation.
 	nsoff.Status.ObservedGeneration = nsoff.Generation
@@ -62,22 +64,55 @@ func (r *NamespaceOffloadingReconciler) enforceStatus(ctx context.Context, nsoff
 	return nil
 }
 
-// remoteNamespaceName returns the remapped name corresponding to a given namespace.
+// remoteNamespaceName returns the remapped name corresponding to a given namespace, according to the global mapping strategy.
 func (r *NamespaceOffloadingReconciler) remoteNamespaceName(nsoff *offloadingv1beta1.NamespaceOffloading) string {
-	switch nsoff.Spec.NamespaceMappingStrategy {
+	return r.remapNamespaceName(nsoff, nsoff.Spec.NamespaceMappingStrategy, nsoff.Spec.RemoteNamespaceName)
+}
+
+// remoteNamespaceNameForCluster returns the remapped name corresponding to a given namespace on the given remote cluster,
+// taking into account the cluster-specific mapping overrides (if any).
+func (r *NamespaceOffloadingReconciler) remoteNamespaceNameForCluster(nsoff *offloadingv1beta1.NamespaceOffloading,
+	clusterID liqov1beta1.ClusterID) string {
+	for i := range nsoff.Spec.ClusterMappings {
+		if mapping := &nsoff.Spec.ClusterMappings[i]; mapping.ClusterID == clusterID {
+			return r.remapNamespaceName(nsoff, mapping.NamespaceMappingStrategy, mapping.RemoteNamespaceName)
+		}
+	}
+	return r.remoteNamespaceName(nsoff)
+}
+
+// remapNamespaceName returns the remapped name corresponding to a given namespace, according to the given strategy.
+func (r *NamespaceOffloadingReconciler) remapNamespaceName(nsoff *offloadingv1beta1.NamespaceOffloading,
+	strategy offloadingv1beta1.NamespaceMappingStrategyType, selectedName string) string {
+	switch strategy {
 	case offloadingv1beta1.EnforceSameNameMappingStrategyType:
 		return nsoff.Namespace
 	case offloadingv1beta1.DefaultNameMappingStrategyType:
 		return nsoff.Namespace + "-" + foreignclusterutils.UniqueName(r.LocalCluster)
 	case offloadingv1beta1.SelectedNameMappingStrategyType:
-		return nsoff.Spec.RemoteNamespaceName
+		return selectedName
 	default:
 		klog.Errorf("NamespaceOffloading %q: unknown NamespaceMappingStrategy %q, falling back to %q",
-			klog.KObj(nsoff), nsoff.Spec.NamespaceMappingStrategy, offloadingv1beta1.DefaultNameMappingStrategyType)
+			klog.KObj(nsoff), strategy, offloadingv1beta1.DefaultNameMappingStrategyType)
 		return nsoff.Namespace + "-" + foreignclusterutils.UniqueName(r.LocalCluster)
 	}
 }
 
+// remoteNamespacesNames returns the name of the remote namespace on each cluster the namespace is offloaded to, indexed by cluster ID.
+func remoteNamespacesNames(nsoff *offloadingv1beta1.NamespaceOffloading, nsmaps map[string]*offloadingv1beta1.NamespaceMap) map[string]string {
+	names := map[string]string{}
+	for clusterID, nsmap := range nsmaps {
+		if name, requested := nsmap.Spec.DesiredMapping[nsoff.Namespace]; requested {
+			names[clusterID] = name
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
 // ensureRemoteConditionsConsistence checks for every remote condition of the NamespaceOffloading resource that the
 // corresponding NamespaceMap is still there. If the peering is deleted also the corresponding remote condition
 // must be deleted.
@@ -0,0 +1,405 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceexportctrl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/forge"
+	"github.com/liqotech/liqo/pkg/utils"
+	foreignclusterutils "github.com/liqotech/liqo/pkg/utils/foreigncluster"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+const (
+	serviceExportControllerFinalizer = "serviceexport-controller.liqo.io/finalizer"
+
+	// ServiceExportReasonNotProvider is the reason set on the ServiceExports selecting peer clusters
+	// the local one is not a consumer of, as the Services cannot be exported to them.
+	ServiceExportReasonNotProvider mcsv1alpha1.ServiceExportConditionReason = "NotProvider"
+)
+
+// Reconciler reconciles a ServiceExport object.
+type Reconciler struct {
+	client.Client
+	LocalClusterID liqov1beta1.ClusterID
+}
+
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=exportedservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
+
+// Reconcile ServiceExports objects, replicating the exported Services to the target peer clusters.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	nsName := req.NamespacedName
+	klog.V(4).Infof("reconcile serviceexport %q", nsName)
+
+	var export mcsv1alpha1.ServiceExport
+	if err := r.Get(ctx, nsName, &export); err != nil {
+		err = client.IgnoreNotFound(err)
+		if err == nil {
+			klog.V(4).Infof("skip: serviceexport %q not found", nsName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !export.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&export, serviceExportControllerFinalizer) {
+			if err := r.deleteStaleExportedServices(ctx, &export, nil); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			controllerutil.RemoveFinalizer(&export, serviceExportControllerFinalizer)
+			if err := r.Update(ctx, &export); err != nil {
+				klog.Errorf("Failed to remove finalizer from serviceexport %q: %v", nsName, err)
+				return ctrl.Result{}, err
+			}
+			klog.Infof("ServiceExport %q correctly removed", nsName)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&export, serviceExportControllerFinalizer) {
+		controllerutil.AddFinalizer(&export, serviceExportControllerFinalizer)
+		if err := r.Update(ctx, &export); err != nil {
+			klog.Errorf("Failed to add finalizer to serviceexport %q: %v", nsName, err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	var svc corev1.Service
+	err := r.Get(ctx, nsName, &svc)
+	switch {
+	case kerrors.IsNotFound(err):
+		return ctrl.Result{}, r.invalidate(ctx, &export, mcsv1alpha1.ServiceExportReasonNoService,
+			fmt.Sprintf("Service %q not found", nsName))
+	case err != nil:
+		klog.Errorf("Failed to retrieve service %q: %v", nsName, err)
+		return ctrl.Result{}, err
+	case svc.Spec.Type == corev1.ServiceTypeExternalName:
+		return ctrl.Result{}, r.invalidate(ctx, &export, mcsv1alpha1.ServiceExportReasonInvalidServiceType,
+			fmt.Sprintf("Services of type %q cannot be exported", corev1.ServiceTypeExternalName))
+	}
+
+	clusters, rejected, err := r.targetClusters(ctx, &export)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(rejected) > 0 {
+		return ctrl.Result{}, r.invalidate(ctx, &export, ServiceExportReasonNotProvider,
+			fmt.Sprintf("Services cannot be exported to clusters %s, as the local cluster is not a consumer of them",
+				strings.Join(rejected, ", ")))
+	}
+
+	slices, err := r.exportableEndpointSlices(ctx, &svc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	spec := forge.ExportedServiceSpec(&svc, slices, r.LocalClusterID, r.endpointToBeExported(ctx))
+	for clusterID, tenantNamespace := range clusters {
+		if err := r.enforceExportedService(ctx, &export, clusterID, tenantNamespace, &spec); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.deleteStaleExportedServices(ctx, &export, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ready := metav1.Condition{
+		Type:    string(mcsv1alpha1.ServiceExportConditionReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(mcsv1alpha1.ServiceExportReasonExported),
+		Message: fmt.Sprintf("Service exported to %d peer clusters", len(clusters)),
+	}
+	if len(clusters) == 0 {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = string(mcsv1alpha1.ServiceExportReasonPending)
+		ready.Message = "No provider cluster to export the Service to"
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, &export, metav1.Condition{
+		Type:    string(mcsv1alpha1.ServiceExportConditionValid),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(mcsv1alpha1.ServiceExportReasonValid),
+		Message: "Service is valid for export",
+	}, ready)
+}
+
+// invalidate withdraws the Service from all the peer clusters, and marks the ServiceExport as not valid.
+func (r *Reconciler) invalidate(ctx context.Context, export *mcsv1alpha1.ServiceExport,
+	reason mcsv1alpha1.ServiceExportConditionReason, message string) error {
+	if err := r.deleteStaleExportedServices(ctx, export, nil); err != nil {
+		return err
+	}
+
+	return r.updateStatus(ctx, export, metav1.Condition{
+		Type:    string(mcsv1alpha1.ServiceExportConditionValid),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: message,
+	}, metav1.Condition{
+		Type:    string(mcsv1alpha1.ServiceExportConditionReady),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(mcsv1alpha1.ServiceExportReasonFailed),
+		Message: message,
+	})
+}
+
+// updateStatus sets the given conditions on the ServiceExport, updating it only if necessary.
+func (r *Reconciler) updateStatus(ctx context.Context, export *mcsv1alpha1.ServiceExport, conditions ...metav1.Condition) error {
+	original := export.Status.DeepCopy()
+	for i := range conditions {
+		conditions[i].ObservedGeneration = export.Generation
+		meta.SetStatusCondition(&export.Status.Conditions, conditions[i])
+	}
+
+	if equality.Semantic.DeepEqual(original, &export.Status) {
+		return nil
+	}
+
+	if err := r.Status().Update(ctx, export); err != nil {
+		klog.Errorf("Failed to update the status of serviceexport %q: %v", klog.KObj(export), err)
+		return err
+	}
+	return nil
+}
+
+// targetClusters returns the peer clusters (and the corresponding tenant namespaces) the Service shall be exported to,
+// along with the selected peer clusters it cannot be exported to. Services can be exported only to the providers,
+// as the replication requires a control plane identity towards them: exporting a Service from a provider to its
+// consumers is not supported, unless the peering is bidirectional.
+func (r *Reconciler) targetClusters(ctx context.Context, export *mcsv1alpha1.ServiceExport) (
+	clusters map[liqov1beta1.ClusterID]string, rejected []string, err error) {
+	var selected sets.Set[string]
+	if value, found := export.GetAnnotations()[consts.ServiceExportClustersAnnotationKey]; found {
+		selected = sets.New[string]()
+		for _, clusterID := range strings.Split(value, ",") {
+			if clusterID = strings.TrimSpace(clusterID); clusterID != "" {
+				selected.Insert(clusterID)
+			}
+		}
+	}
+
+	var fcs liqov1beta1.ForeignClusterList
+	if err := r.List(ctx, &fcs); err != nil {
+		klog.Errorf("Failed to list foreignclusters: %v", err)
+		return nil, nil, err
+	}
+
+	clusters = map[liqov1beta1.ClusterID]string{}
+	for i := range fcs.Items {
+		fc := &fcs.Items[i]
+		if selected != nil && !selected.Has(string(fc.Spec.ClusterID)) {
+			continue
+		}
+		if !foreignclusterutils.IsProvider(fc.Status.Role) {
+			// The clusters explicitly selected are rejected, while the other ones are silently skipped.
+			if selected != nil {
+				rejected = append(rejected, string(fc.Spec.ClusterID))
+			}
+			continue
+		}
+		if fc.Status.TenantNamespace.Local == "" {
+			continue
+		}
+		clusters[fc.Spec.ClusterID] = fc.Status.TenantNamespace.Local
+	}
+
+	sort.Strings(rejected)
+	return clusters, rejected, nil
+}
+
+// exportableEndpointSlices returns the EndpointSlices of the given Service, excluding the ones managed by Liqo.
+func (r *Reconciler) exportableEndpointSlices(ctx context.Context, svc *corev1.Service) ([]discoveryv1.EndpointSlice, error) {
+	var slices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &slices, client.InNamespace(svc.GetNamespace()),
+		client.MatchingLabels{discoveryv1.LabelServiceName: svc.GetName()}); err != nil {
+		klog.Errorf("Failed to list endpointslices of service %q: %v", klog.KObj(svc), err)
+		return nil, err
+	}
+
+	var exportable []discoveryv1.EndpointSlice
+	for i := range slices.Items {
+		slice := &slices.Items[i]
+		// The EndpointSlices reflected or imported by Liqo refer to endpoints hosted by other clusters.
+		if vkforge.IsEndpointSliceManagedByReflection(slice) {
+			continue
+		}
+		if _, found := slice.GetLabels()[consts.ManagedByLabelKey]; found {
+			continue
+		}
+		exportable = append(exportable, *slice)
+	}
+	return exportable, nil
+}
+
+// endpointToBeExported returns a filter excluding the endpoints hosted by virtual nodes,
+// since their addresses belong to the pod CIDR of the corresponding provider.
+func (r *Reconciler) endpointToBeExported(ctx context.Context) func(*discoveryv1.Endpoint) bool {
+	return func(endpoint *discoveryv1.Endpoint) bool {
+		if endpoint.NodeName == nil {
+			// The endpoint is probably external to the cluster, hence it is exported as is.
+			return true
+		}
+
+		var node corev1.Node
+		if err := r.Get(ctx, types.NamespacedName{Name: *endpoint.NodeName}, &node); err != nil {
+			klog.Errorf("Unable to retrieve node %q: %v", *endpoint.NodeName, err)
+			return false
+		}
+
+		remoteClusterID, err := getters.RetrieveRemoteClusterIDFromNode(&node)
+		if err != nil {
+			klog.Errorf("Unable to retrieve remote cluster ID from node %q: %v", node.GetName(), err)
+			return false
+		}
+		return remoteClusterID == ""
+	}
+}
+
+// enforceExportedService ensures the presence of the ExportedService replicated to the given cluster.
+func (r *Reconciler) enforceExportedService(ctx context.Context, export *mcsv1alpha1.ServiceExport,
+	clusterID liqov1beta1.ClusterID, tenantNamespace string, spec *offloadingv1beta1.ExportedServiceSpec) error {
+	exported := &offloadingv1beta1.ExportedService{ObjectMeta: metav1.ObjectMeta{
+		Name:      forge.ExportedServiceName(export.GetNamespace(), export.GetName()),
+		Namespace: tenantNamespace,
+	}}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, exported, func() error {
+		exported.SetLabels(labels.Merge(exported.GetLabels(), labels.Merge(
+			forge.ExportedServiceLabels(export.GetNamespace(), export.GetName()),
+			forge.ExportedServiceReplicationLabels(clusterID))))
+		exported.Spec = *spec.DeepCopy()
+		return nil
+	})
+	if err != nil {
+		klog.Errorf("Failed to enforce exportedservice %q for cluster %q: %v", klog.KObj(exported), clusterID, err)
+		return err
+	}
+
+	klog.V(utils.FromResult(result)).Infof("ExportedService %q for cluster %q successfully enforced (with %v operation)",
+		klog.KObj(exported), clusterID, result)
+	return nil
+}
+
+// deleteStaleExportedServices deletes the ExportedServices associated with the ServiceExport
+// and replicated to clusters not included in the given set.
+func (r *Reconciler) deleteStaleExportedServices(ctx context.Context, export *mcsv1alpha1.ServiceExport,
+	clusters map[liqov1beta1.ClusterID]string) error {
+	exported, err := getters.ListExportedServicesByLabel(ctx, r.Client, corev1.NamespaceAll,
+		forge.ExportedServiceLabels(export.GetNamespace(), export.GetName()).AsSelector())
+	if err != nil {
+		klog.Errorf("Failed to list exportedservices for serviceexport %q: %v", klog.KObj(export), err)
+		return err
+	}
+
+	for i := range exported {
+		destination := liqov1beta1.ClusterID(exported[i].GetLabels()[consts.ReplicationDestinationLabel])
+		if namespace, found := clusters[destination]; found && namespace == exported[i].GetNamespace() {
+			continue
+		}
+
+		if err := client.IgnoreNotFound(r.Delete(ctx, &exported[i])); err != nil {
+			klog.Errorf("Failed to delete exportedservice %q: %v", klog.KObj(&exported[i]), err)
+			return err
+		}
+		klog.Infof("ExportedService %q for cluster %q correctly deleted", klog.KObj(&exported[i]), destination)
+	}
+	return nil
+}
+
+// serviceExportEnqueuer enqueues the ServiceExport with the same name and namespace of the given object.
+func serviceExportEnqueuer(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(obj)}}
+}
+
+// endpointSliceEnqueuer enqueues the ServiceExport associated with the Service the given EndpointSlice belongs to.
+func endpointSliceEnqueuer(_ context.Context, obj client.Object) []reconcile.Request {
+	name, found := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if !found {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// foreignClusterEnqueuer enqueues all the ServiceExports, as the set of target clusters may have changed.
+func (r *Reconciler) foreignClusterEnqueuer(ctx context.Context, _ client.Object) []reconcile.Request {
+	var exports mcsv1alpha1.ServiceExportList
+	if err := r.List(ctx, &exports); err != nil {
+		klog.Errorf("Failed to list serviceexports: %v", err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(exports.Items))
+	for i := range exports.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&exports.Items[i])})
+	}
+	return requests
+}
+
+// SetupWithManager monitors ServiceExports, as well as the Services and EndpointSlices they refer to.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Trigger a reconciliation only if the role or the tenant namespace of the ForeignCluster changed.
+	fcPredicates := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldFc, oldOk := e.ObjectOld.(*liqov1beta1.ForeignCluster)
+			newFc, newOk := e.ObjectNew.(*liqov1beta1.ForeignCluster)
+			return !oldOk || !newOk || oldFc.Status.Role != newFc.Status.Role ||
+				oldFc.Status.TenantNamespace.Local != newFc.Status.TenantNamespace.Local
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlServiceExport).
+		For(&mcsv1alpha1.ServiceExport{}).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(serviceExportEnqueuer)).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(endpointSliceEnqueuer)).
+		Watches(&liqov1beta1.ForeignCluster{}, handler.EnqueueRequestsFromMapFunc(r.foreignClusterEnqueuer),
+			builder.WithPredicates(fcPredicates)).
+		Complete(r)
+}
@@ -0,0 +1,256 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceexportctrl_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	serviceexportctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/serviceexport-controller"
+)
+
+var _ = Describe("ServiceExport controller", func() {
+	const (
+		localClusterID  = liqov1beta1.ClusterID("local-cluster")
+		providerID      = liqov1beta1.ClusterID("provider")
+		consumerID      = liqov1beta1.ClusterID("consumer")
+		providerTenant  = "liqo-tenant-provider"
+		consumerTenant  = "liqo-tenant-consumer"
+		namespace       = "foo"
+		name            = "svc"
+		exportedSvcName = "svc.foo"
+	)
+
+	var (
+		ctx        context.Context
+		cl         client.Client
+		reconciler serviceexportctrl.Reconciler
+		objects    []client.Object
+
+		export *mcsv1alpha1.ServiceExport
+		err    error
+	)
+
+	foreignCluster := func(clusterID liqov1beta1.ClusterID, role liqov1beta1.RoleType, tenantNamespace string) *liqov1beta1.ForeignCluster {
+		return &liqov1beta1.ForeignCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: string(clusterID)},
+			Spec:       liqov1beta1.ForeignClusterSpec{ClusterID: clusterID},
+			Status: liqov1beta1.ForeignClusterStatus{
+				Role:            role,
+				TenantNamespace: liqov1beta1.TenantNamespaceType{Local: tenantNamespace},
+			},
+		}
+	}
+
+	exportedService := func(tenantNamespace string) *offloadingv1beta1.ExportedService {
+		var exported offloadingv1beta1.ExportedService
+		Expect(cl.Get(ctx, client.ObjectKey{Namespace: tenantNamespace, Name: exportedSvcName}, &exported)).To(Succeed())
+		return &exported
+	}
+
+	condition := func(conditionType mcsv1alpha1.ServiceExportConditionType) *metav1.Condition {
+		var current mcsv1alpha1.ServiceExport
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(export), &current)).To(Succeed())
+		return meta.FindStatusCondition(current.Status.Conditions, string(conditionType))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		export = &mcsv1alpha1.ServiceExport{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		objects = []client.Object{
+			foreignCluster(providerID, liqov1beta1.ProviderRole, providerTenant),
+			foreignCluster(consumerID, liqov1beta1.ConsumerRole, consumerTenant),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: map[string]string{"foo": "bar"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "virtual-node",
+				Labels: map[string]string{consts.RemoteClusterID: string(providerID)}}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeClusterIP,
+					ClusterIP: "10.0.0.1",
+					Ports:     []corev1.ServicePort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80}},
+				},
+			},
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc-abcde", Namespace: namespace,
+					Labels: map[string]string{discoveryv1.LabelServiceName: name}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.1.0.1"}, NodeName: ptr.To("node"), Zone: ptr.To("zone-a")},
+					{Addresses: []string{"10.2.0.1"}, NodeName: ptr.To("virtual-node")},
+				},
+				Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](8080)}},
+			},
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc-imported", Namespace: namespace,
+					Labels: map[string]string{discoveryv1.LabelServiceName: name,
+						consts.ManagedByLabelKey: consts.ManagedByShadowEndpointSliceValue}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.3.0.1"}}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cl = fake.NewClientBuilder().WithObjects(append(objects, export)...).
+			WithStatusSubresource(&mcsv1alpha1.ServiceExport{}).Build()
+		reconciler = serviceexportctrl.Reconciler{Client: cl, LocalClusterID: localClusterID}
+		_, err = reconciler.Reconcile(ctx, controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(export)})
+	})
+
+	When("the exported service exists", func() {
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should replicate the ExportedService to the provider clusters only", func() {
+			exported := exportedService(providerTenant)
+			Expect(exported.Labels).To(HaveKeyWithValue(consts.ReplicationRequestedLabel, consts.ReplicationRequestedLabelValue))
+			Expect(exported.Labels).To(HaveKeyWithValue(consts.ReplicationDestinationLabel, string(providerID)))
+			Expect(exported.Labels).To(HaveKeyWithValue(consts.ExportedServiceNamespaceLabelKey, namespace))
+			Expect(exported.Labels).To(HaveKeyWithValue(consts.ExportedServiceNameLabelKey, name))
+
+			var exportedList offloadingv1beta1.ExportedServiceList
+			Expect(cl.List(ctx, &exportedList, client.InNamespace(consumerTenant))).To(Succeed())
+			Expect(exportedList.Items).To(BeEmpty())
+		})
+
+		It("should describe the exported service", func() {
+			spec := exportedService(providerTenant).Spec
+			Expect(spec.ServiceNamespace).To(Equal(namespace))
+			Expect(spec.ServiceName).To(Equal(name))
+			Expect(spec.Type).To(Equal(mcsv1alpha1.ClusterSetIP))
+			Expect(spec.Ports).To(ConsistOf(mcsv1alpha1.ServicePort{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80}))
+		})
+
+		It("should export only the endpoints hosted by the local cluster", func() {
+			slices := exportedService(providerTenant).Spec.EndpointSlices
+			Expect(slices).To(HaveLen(1))
+			Expect(slices[0].Name).To(Equal("svc-abcde"))
+			Expect(slices[0].Template.Endpoints).To(HaveLen(1))
+			Expect(slices[0].Template.Endpoints[0].Addresses).To(ConsistOf("10.1.0.1"))
+			Expect(slices[0].Template.Endpoints[0].NodeName).To(PointTo(Equal(string(localClusterID))))
+			Expect(slices[0].Template.Endpoints[0].Zone).To(PointTo(Equal("zone-a")))
+			Expect(slices[0].Template.Ports).To(HaveLen(1))
+		})
+
+		It("should mark the ServiceExport as valid and ready", func() {
+			Expect(condition(mcsv1alpha1.ServiceExportConditionValid)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(metav1.ConditionTrue), "Reason": Equal(string(mcsv1alpha1.ServiceExportReasonValid)),
+			})))
+			Expect(condition(mcsv1alpha1.ServiceExportConditionReady)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(metav1.ConditionTrue), "Reason": Equal(string(mcsv1alpha1.ServiceExportReasonExported)),
+			})))
+		})
+	})
+
+	When("the export is restricted to a subset of peer clusters", func() {
+		BeforeEach(func() {
+			export.Annotations = map[string]string{consts.ServiceExportClustersAnnotationKey: "other, "}
+			objects = append(objects, &offloadingv1beta1.ExportedService{ObjectMeta: metav1.ObjectMeta{
+				Name: exportedSvcName, Namespace: providerTenant,
+				Labels: map[string]string{
+					consts.ExportedServiceNamespaceLabelKey: namespace,
+					consts.ExportedServiceNameLabelKey:      name,
+					consts.ReplicationDestinationLabel:      string(providerID),
+				},
+			}})
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should withdraw the service from the non selected clusters", func() {
+			var exportedList offloadingv1beta1.ExportedServiceList
+			Expect(cl.List(ctx, &exportedList)).To(Succeed())
+			Expect(exportedList.Items).To(BeEmpty())
+		})
+
+		It("should mark the ServiceExport as pending", func() {
+			Expect(condition(mcsv1alpha1.ServiceExportConditionReady)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(metav1.ConditionFalse), "Reason": Equal(string(mcsv1alpha1.ServiceExportReasonPending)),
+			})))
+		})
+	})
+
+	When("the export selects a cluster the local one is not a consumer of", func() {
+		BeforeEach(func() {
+			export.Annotations = map[string]string{consts.ServiceExportClustersAnnotationKey: string(providerID) + "," + string(consumerID)}
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should not replicate the ExportedService", func() {
+			var exportedList offloadingv1beta1.ExportedServiceList
+			Expect(cl.List(ctx, &exportedList)).To(Succeed())
+			Expect(exportedList.Items).To(BeEmpty())
+		})
+
+		It("should mark the ServiceExport as not valid", func() {
+			Expect(condition(mcsv1alpha1.ServiceExportConditionValid)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal(string(serviceexportctrl.ServiceExportReasonNotProvider)),
+				"Message": ContainSubstring(string(consumerID)),
+			})))
+		})
+	})
+
+	When("the exported service does not exist", func() {
+		BeforeEach(func() { export.Name = "missing" })
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should mark the ServiceExport as not valid", func() {
+			Expect(condition(mcsv1alpha1.ServiceExportConditionValid)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(metav1.ConditionFalse), "Reason": Equal(string(mcsv1alpha1.ServiceExportReasonNoService)),
+			})))
+		})
+	})
+
+	When("the ServiceExport is being deleted", func() {
+		BeforeEach(func() {
+			export.Finalizers = []string{"serviceexport-controller.liqo.io/finalizer"}
+			export.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+			objects = append(objects, &offloadingv1beta1.ExportedService{ObjectMeta: metav1.ObjectMeta{
+				Name: exportedSvcName, Namespace: providerTenant,
+				Labels: map[string]string{
+					consts.ExportedServiceNamespaceLabelKey: namespace,
+					consts.ExportedServiceNameLabelKey:      name,
+					consts.ReplicationDestinationLabel:      string(providerID),
+				},
+			}})
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should withdraw the service from all the peer clusters", func() {
+			var exportedList offloadingv1beta1.ExportedServiceList
+			Expect(cl.List(ctx, &exportedList)).To(Succeed())
+			Expect(exportedList.Items).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,405 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceimportctrl
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/forge"
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+)
+
+const (
+	serviceImportControllerFinalizer = "serviceimport-controller.liqo.io/finalizer"
+)
+
+// Reconciler reconciles the ExportedServices replicated by the peer clusters,
+// enforcing the corresponding ServiceImports, derived Services and ShadowEndpointSlices.
+type Reconciler struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=exportedservices,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=exportedservices/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowendpointslices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile ExportedServices objects, importing the corresponding Services exported by the peer clusters.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	nsName := req.NamespacedName
+	klog.V(4).Infof("reconcile exportedservice %q", nsName)
+
+	var exported offloadingv1beta1.ExportedService
+	if err := r.Get(ctx, nsName, &exported); err != nil {
+		err = client.IgnoreNotFound(err)
+		if err == nil {
+			klog.V(4).Infof("skip: exportedservice %q not found", nsName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	origin, ok := utils.GetClusterIDFromLabelsWithKey(exported.Labels, consts.ReplicationOriginLabel)
+	if !ok {
+		klog.Errorf("exportedservice %q has no label %q", nsName, consts.ReplicationOriginLabel)
+		return ctrl.Result{}, nil
+	}
+	namespace, name := exported.Spec.ServiceNamespace, exported.Spec.ServiceName
+
+	if !exported.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&exported, serviceImportControllerFinalizer) {
+			if err := r.enforceShadowEndpointSlices(ctx, namespace, name, origin, nil); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.enforceServiceImport(ctx, namespace, name); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			controllerutil.RemoveFinalizer(&exported, serviceImportControllerFinalizer)
+			if err := r.Update(ctx, &exported); err != nil {
+				klog.Errorf("Failed to remove finalizer from exportedservice %q: %v", nsName, err)
+				return ctrl.Result{}, err
+			}
+			klog.Infof("Service %q exported by cluster %q correctly withdrawn", klog.KRef(namespace, name), origin)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&exported, serviceImportControllerFinalizer) {
+		controllerutil.AddFinalizer(&exported, serviceImportControllerFinalizer)
+		if err := r.Update(ctx, &exported); err != nil {
+			klog.Errorf("Failed to add finalizer to exportedservice %q: %v", nsName, err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The Service is imported in the namespace with the same name, according to the namespace sameness principle.
+	// The reconciliation is triggered again once the namespace is created, or enabled for the import.
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if kerrors.IsNotFound(err) {
+			klog.Infof("Namespace %q not found, the import of service %q from cluster %q is pending", namespace, name, origin)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("Failed to retrieve namespace %q: %v", namespace, err)
+		return ctrl.Result{}, err
+	}
+
+	// The target namespace is chosen by the exporting cluster, hence the import shall be explicitly enabled by the local one.
+	if !isImportEnabled(&ns) {
+		klog.Infof("Namespace %q not enabled for the import, the import of service %q from cluster %q is pending",
+			namespace, name, origin)
+		if err := r.enforceShadowEndpointSlices(ctx, namespace, name, origin, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.withdrawServiceImport(ctx, namespace, name)
+	}
+
+	if err := r.enforceServiceImport(ctx, namespace, name); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.enforceShadowEndpointSlices(ctx, namespace, name, origin, exported.Spec.EndpointSlices); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// activeExportedServices returns the replicated ExportedServices (not being deleted) corresponding to the given Service,
+// sorted by creation timestamp. In case of conflicting properties, the oldest export takes precedence.
+func (r *Reconciler) activeExportedServices(ctx context.Context, namespace, name string) ([]offloadingv1beta1.ExportedService, error) {
+	exported, err := getters.ListExportedServicesByLabel(ctx, r.Client, corev1.NamespaceAll,
+		replicatedExportedServicesSelector(forge.ExportedServiceLabels(namespace, name)))
+	if err != nil {
+		klog.Errorf("Failed to list exportedservices for service %q: %v", klog.KRef(namespace, name), err)
+		return nil, err
+	}
+
+	var active []offloadingv1beta1.ExportedService
+	for i := range exported {
+		if !exported[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		active = append(active, exported[i])
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].CreationTimestamp.Equal(&active[j].CreationTimestamp) {
+			return active[i].GetNamespace() < active[j].GetNamespace()
+		}
+		return active[i].CreationTimestamp.Before(&active[j].CreationTimestamp)
+	})
+	return active, nil
+}
+
+// enforceServiceImport ensures the ServiceImport and the derived Service corresponding to the given Service
+// reflect the current set of exporters, deleting them in case no peer cluster exports the Service anymore.
+func (r *Reconciler) enforceServiceImport(ctx context.Context, namespace, name string) error {
+	exported, err := r.activeExportedServices(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if len(exported) == 0 {
+		return r.withdrawServiceImport(ctx, namespace, name)
+	}
+
+	si := &mcsv1alpha1.ServiceImport{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	derived := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: forge.DerivedServiceName(name), Namespace: namespace}}
+
+	spec := &exported[0].Spec
+	ports := forge.MergeServiceImportPorts(exported)
+
+	if err := r.enforceDerivedService(ctx, derived, name, spec, ports); err != nil {
+		return err
+	}
+
+	if managed, err := r.isManaged(ctx, si); err != nil || !managed {
+		return err
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, si, func() error {
+		forge.MutateServiceImport(si, derived, spec, ports)
+		return nil
+	})
+	if err != nil {
+		klog.Errorf("Failed to enforce serviceimport %q: %v", klog.KObj(si), err)
+		return err
+	}
+	klog.V(utils.FromResult(result)).Infof("ServiceImport %q successfully enforced (with %v operation)", klog.KObj(si), result)
+
+	origins := make([]liqov1beta1.ClusterID, 0, len(exported))
+	for i := range exported {
+		origins = append(origins, liqov1beta1.ClusterID(exported[i].GetLabels()[consts.ReplicationOriginLabel]))
+	}
+
+	clusters := forge.ServiceImportClusters(origins)
+	if equality.Semantic.DeepEqual(si.Status.Clusters, clusters) {
+		return nil
+	}
+
+	si.Status.Clusters = clusters
+	if err := r.Status().Update(ctx, si); err != nil {
+		klog.Errorf("Failed to update the status of serviceimport %q: %v", klog.KObj(si), err)
+		return err
+	}
+	return nil
+}
+
+// withdrawServiceImport deletes the ServiceImport and the derived Service corresponding to the given Service, if managed by Liqo.
+func (r *Reconciler) withdrawServiceImport(ctx context.Context, namespace, name string) error {
+	si := &mcsv1alpha1.ServiceImport{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	derived := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: forge.DerivedServiceName(name), Namespace: namespace}}
+	for _, obj := range []client.Object{si, derived} {
+		if err := r.deleteIfManaged(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isImportEnabled returns whether the Services exported by the peer clusters can be imported into the given namespace.
+func isImportEnabled(ns *corev1.Namespace) bool {
+	return ns.GetLabels()[consts.ServiceImportEnabledLabelKey] == "true"
+}
+
+// enforceDerivedService ensures the presence of the Service backing the ServiceImport.
+func (r *Reconciler) enforceDerivedService(ctx context.Context, derived *corev1.Service, name string,
+	spec *offloadingv1beta1.ExportedServiceSpec, ports []mcsv1alpha1.ServicePort) error {
+	if managed, err := r.isManaged(ctx, derived); err != nil || !managed {
+		return err
+	}
+
+	// The cluster IP is immutable, hence the Service is recreated in case the type of the ServiceImport changed.
+	if !derived.CreationTimestamp.IsZero() && (derived.Spec.ClusterIP == corev1.ClusterIPNone) != (spec.Type == mcsv1alpha1.Headless) {
+		if err := client.IgnoreNotFound(r.Delete(ctx, derived)); err != nil {
+			klog.Errorf("Failed to delete service %q to change its type: %v", klog.KObj(derived), err)
+			return err
+		}
+		*derived = corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: derived.GetName(), Namespace: derived.GetNamespace()}}
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, derived, func() error {
+		forge.MutateDerivedService(derived, name, spec, ports)
+		return nil
+	})
+	if err != nil {
+		klog.Errorf("Failed to enforce derived service %q: %v", klog.KObj(derived), err)
+		return err
+	}
+
+	klog.V(utils.FromResult(result)).Infof("Derived service %q successfully enforced (with %v operation)", klog.KObj(derived), result)
+	return nil
+}
+
+// enforceShadowEndpointSlices ensures the ShadowEndpointSlices corresponding to the given Service exported by the origin cluster
+// match the given EndpointSlices, deleting the stale ones.
+func (r *Reconciler) enforceShadowEndpointSlices(ctx context.Context, namespace, name string,
+	origin liqov1beta1.ClusterID, slices []offloadingv1beta1.ExportedEndpointSlice) error {
+	desired := sets.New[string]()
+	for i := range slices {
+		shadow := &offloadingv1beta1.ShadowEndpointSlice{ObjectMeta: metav1.ObjectMeta{
+			Name:      forge.ImportedShadowEndpointSliceName(slices[i].Name, origin),
+			Namespace: namespace,
+		}}
+		desired.Insert(shadow.GetName())
+
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, shadow, func() error {
+			forge.MutateImportedShadowEndpointSlice(shadow, name, origin, &slices[i])
+			return nil
+		})
+		if err != nil {
+			klog.Errorf("Failed to enforce shadowendpointslice %q: %v", klog.KObj(shadow), err)
+			return err
+		}
+		klog.V(utils.FromResult(result)).Infof("ShadowEndpointSlice %q successfully enforced (with %v operation)", klog.KObj(shadow), result)
+	}
+
+	var shadows offloadingv1beta1.ShadowEndpointSliceList
+	if err := r.List(ctx, &shadows, client.InNamespace(namespace),
+		client.MatchingLabels(forge.ImportedShadowEndpointSliceLabels(name, origin))); err != nil {
+		klog.Errorf("Failed to list shadowendpointslices for service %q: %v", klog.KRef(namespace, name), err)
+		return err
+	}
+
+	for i := range shadows.Items {
+		if desired.Has(shadows.Items[i].GetName()) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, &shadows.Items[i])); err != nil {
+			klog.Errorf("Failed to delete shadowendpointslice %q: %v", klog.KObj(&shadows.Items[i]), err)
+			return err
+		}
+		klog.Infof("ShadowEndpointSlice %q correctly deleted", klog.KObj(&shadows.Items[i]))
+	}
+	return nil
+}
+
+// isManaged retrieves the given object, and returns whether it does not exist or it is managed by this controller.
+// Objects with the same name created by third parties are never modified.
+func (r *Reconciler) isManaged(ctx context.Context, obj client.Object) (bool, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		klog.Errorf("Failed to retrieve %q: %v", klog.KObj(obj), err)
+		return false, err
+	}
+
+	if obj.GetLabels()[consts.ManagedByLabelKey] != consts.ManagedByServiceImportValue {
+		klog.Warningf("Object %q already exists and it is not managed by Liqo, skipping", klog.KObj(obj))
+		return false, nil
+	}
+	return true, nil
+}
+
+// deleteIfManaged deletes the given object, in case it is managed by this controller.
+func (r *Reconciler) deleteIfManaged(ctx context.Context, obj client.Object) error {
+	managed, err := r.isManaged(ctx, obj)
+	if err != nil || !managed || obj.GetResourceVersion() == "" {
+		return err
+	}
+
+	if err := client.IgnoreNotFound(r.Delete(ctx, obj)); err != nil {
+		klog.Errorf("Failed to delete %q: %v", klog.KObj(obj), err)
+		return err
+	}
+	klog.Infof("%q correctly deleted, as no peer cluster exports the corresponding service", klog.KObj(obj))
+	return nil
+}
+
+// replicatedExportedServicesSelector returns a selector matching the replicated ExportedServices with the given labels.
+func replicatedExportedServicesSelector(set labels.Set) labels.Selector {
+	requirements, _ := set.AsSelector().Requirements()
+	return liqolabels.RemoteLabelSelector().Add(requirements...)
+}
+
+// exportedServicesEnqueuer enqueues the replicated ExportedServices matching the given labels.
+func (r *Reconciler) exportedServicesEnqueuer(ctx context.Context, set labels.Set) []reconcile.Request {
+	exported, err := getters.ListExportedServicesByLabel(ctx, r.Client, corev1.NamespaceAll, replicatedExportedServicesSelector(set))
+	if err != nil {
+		klog.Errorf("Failed to list exportedservices with labels %q: %v", set, err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(exported))
+	for i := range exported {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&exported[i])})
+	}
+	return requests
+}
+
+// SetupWithManager monitors the replicated ExportedServices, as well as the resources enforced for them.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	filter, err := predicate.LabelSelectorPredicate(reflection.ReplicatedResourcesLabelSelector())
+	utilruntime.Must(err)
+	managedFilter, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{consts.ManagedByLabelKey: consts.ManagedByServiceImportValue},
+	})
+	utilruntime.Must(err)
+
+	// Trigger a reconciliation only when a namespace is created or enabled (disabled) for the import, as the import may be pending.
+	namespaceFilter := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isImportEnabled(e.ObjectOld.(*corev1.Namespace)) != isImportEnabled(e.ObjectNew.(*corev1.Namespace))
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool { return false },
+	}
+
+	managedEnqueuer := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return r.exportedServicesEnqueuer(ctx, forge.ExportedServiceLabels(obj.GetNamespace(), obj.GetLabels()[mcsv1alpha1.LabelServiceName]))
+	}
+	namespaceEnqueuer := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return r.exportedServicesEnqueuer(ctx, labels.Set{consts.ExportedServiceNamespaceLabelKey: obj.GetName()})
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlServiceImport).
+		For(&offloadingv1beta1.ExportedService{}, builder.WithPredicates(filter)).
+		Watches(&mcsv1alpha1.ServiceImport{}, handler.EnqueueRequestsFromMapFunc(managedEnqueuer), builder.WithPredicates(managedFilter)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(managedEnqueuer), builder.WithPredicates(managedFilter)).
+		Watches(&offloadingv1beta1.ShadowEndpointSlice{}, handler.EnqueueRequestsFromMapFunc(managedEnqueuer),
+			builder.WithPredicates(managedFilter)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(namespaceEnqueuer), builder.WithPredicates(namespaceFilter)).
+		Complete(r)
+}
@@ -0,0 +1,237 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceimportctrl_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	serviceimportctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/serviceimport-controller"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("ServiceImport controller", func() {
+	const (
+		namespace = "foo"
+		name      = "svc"
+	)
+
+	var (
+		ctx        context.Context
+		cl         client.Client
+		reconciler serviceimportctrl.Reconciler
+		objects    []client.Object
+
+		exported *offloadingv1beta1.ExportedService
+		err      error
+	)
+
+	exportedService := func(origin liqov1beta1.ClusterID, ports ...mcsv1alpha1.ServicePort) *offloadingv1beta1.ExportedService {
+		return &offloadingv1beta1.ExportedService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "svc.foo", Namespace: "liqo-tenant-" + string(origin),
+				Labels: map[string]string{
+					consts.ReplicationOriginLabel:           string(origin),
+					consts.ReplicationStatusLabel:           "true",
+					consts.ExportedServiceNamespaceLabelKey: namespace,
+					consts.ExportedServiceNameLabelKey:      name,
+				},
+			},
+			Spec: offloadingv1beta1.ExportedServiceSpec{
+				ServiceNamespace: namespace,
+				ServiceName:      name,
+				Type:             mcsv1alpha1.ClusterSetIP,
+				Ports:            ports,
+				EndpointSlices: []offloadingv1beta1.ExportedEndpointSlice{{
+					Name: "svc-abcde",
+					Template: offloadingv1beta1.EndpointSliceTemplate{
+						AddressType: discoveryv1.AddressTypeIPv4,
+						Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.1.0.1"}}},
+					},
+				}},
+			},
+		}
+	}
+
+	http := mcsv1alpha1.ServicePort{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80}
+	https := mcsv1alpha1.ServicePort{Name: "https", Protocol: corev1.ProtocolTCP, Port: 443}
+
+	getServiceImport := func() (*mcsv1alpha1.ServiceImport, error) {
+		var si mcsv1alpha1.ServiceImport
+		return &si, cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &si)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		exported = exportedService("origin", http)
+		objects = []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace,
+			Labels: map[string]string{consts.ServiceImportEnabledLabelKey: "true"}}}}
+	})
+
+	JustBeforeEach(func() {
+		cl = fake.NewClientBuilder().WithObjects(append(objects, exported)...).
+			WithStatusSubresource(&mcsv1alpha1.ServiceImport{}).Build()
+		reconciler = serviceimportctrl.Reconciler{Client: cl}
+		_, err = reconciler.Reconcile(ctx, controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(exported)})
+	})
+
+	When("a peer cluster exports a service", func() {
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should create the ServiceImport", func() {
+			si, err := getServiceImport()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(si.Spec.Type).To(Equal(mcsv1alpha1.ClusterSetIP))
+			Expect(si.Spec.Ports).To(ConsistOf(http))
+			Expect(si.Status.Clusters).To(ConsistOf(mcsv1alpha1.ClusterStatus{Cluster: "origin"}))
+		})
+
+		It("should create the derived service", func() {
+			var svc corev1.Service
+			Expect(cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "derived-svc"}, &svc)).To(Succeed())
+			Expect(svc.Spec.Selector).To(BeEmpty())
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Port).To(BeNumerically("==", 80))
+		})
+
+		It("should create the ShadowEndpointSlices", func() {
+			var shadow offloadingv1beta1.ShadowEndpointSlice
+			Expect(cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "svc-abcde-origin"}, &shadow)).To(Succeed())
+			Expect(shadow.Labels).To(HaveKeyWithValue(vkforge.LiqoOriginClusterIDKey, "origin"))
+			Expect(shadow.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, "derived-svc"))
+			Expect(shadow.Labels).To(HaveKeyWithValue(mcsv1alpha1.LabelServiceName, name))
+			Expect(shadow.Labels).To(HaveKeyWithValue(mcsv1alpha1.LabelSourceCluster, "origin"))
+			Expect(shadow.Spec.Template.Endpoints).To(HaveLen(1))
+		})
+	})
+
+	When("multiple peer clusters export the same service", func() {
+		BeforeEach(func() {
+			older := exportedService("older", http, https)
+			older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			objects = append(objects, older)
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should aggregate the exporters in the ServiceImport", func() {
+			si, err := getServiceImport()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(si.Spec.Ports).To(ConsistOf(http, https))
+			Expect(si.Status.Clusters).To(ConsistOf(
+				mcsv1alpha1.ClusterStatus{Cluster: "older"}, mcsv1alpha1.ClusterStatus{Cluster: "origin"}))
+		})
+	})
+
+	When("the target namespace does not exist", func() {
+		BeforeEach(func() { objects = nil })
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should not create the ServiceImport", func() {
+			_, err := getServiceImport()
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the target namespace is not enabled for the import", func() {
+		BeforeEach(func() {
+			labels := map[string]string{consts.ManagedByLabelKey: consts.ManagedByServiceImportValue, mcsv1alpha1.LabelServiceName: name}
+			objects = []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+				&mcsv1alpha1.ServiceImport{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}},
+			}
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should withdraw the ServiceImport", func() {
+			_, err := getServiceImport()
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not create the ShadowEndpointSlices", func() {
+			var shadow offloadingv1beta1.ShadowEndpointSlice
+			err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "svc-abcde-origin"}, &shadow)
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("a ServiceImport not managed by Liqo already exists", func() {
+		BeforeEach(func() {
+			objects = append(objects, &mcsv1alpha1.ServiceImport{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       mcsv1alpha1.ServiceImportSpec{Type: mcsv1alpha1.Headless},
+			})
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should not modify it", func() {
+			si, err := getServiceImport()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(si.Spec.Type).To(Equal(mcsv1alpha1.Headless))
+			Expect(si.Spec.Ports).To(BeEmpty())
+		})
+	})
+
+	When("the only exporter withdraws the service", func() {
+		BeforeEach(func() {
+			exported.Finalizers = []string{"serviceimport-controller.liqo.io/finalizer"}
+			exported.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+			labels := map[string]string{consts.ManagedByLabelKey: consts.ManagedByServiceImportValue, mcsv1alpha1.LabelServiceName: name}
+			objects = append(objects,
+				&mcsv1alpha1.ServiceImport{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "derived-svc", Namespace: namespace, Labels: labels}},
+				&offloadingv1beta1.ShadowEndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "svc-abcde-origin", Namespace: namespace,
+					Labels: map[string]string{
+						consts.ManagedByLabelKey:       consts.ManagedByServiceImportValue,
+						mcsv1alpha1.LabelServiceName:   name,
+						mcsv1alpha1.LabelSourceCluster: "origin",
+						vkforge.LiqoOriginClusterIDKey: "origin",
+					}}},
+			)
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should delete the imported resources", func() {
+			_, err := getServiceImport()
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+			err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "derived-svc"}, &corev1.Service{})
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+			var shadows offloadingv1beta1.ShadowEndpointSliceList
+			Expect(cl.List(ctx, &shadows, client.InNamespace(namespace))).To(Succeed())
+			Expect(shadows.Items).To(BeEmpty())
+		})
+	})
+})
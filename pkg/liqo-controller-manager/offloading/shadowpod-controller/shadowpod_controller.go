@@ -62,6 +62,8 @@ const (
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowpods,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowpods/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/ephemeralcontainers,verbs=update;patch
+// +kubebuilder:rbac:groups="",resources=pods/resize,verbs=update;patch
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowpods/status,verbs=get;update;patch
 
 // Reconcile ShadowPods objects.
@@ -107,6 +109,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			return ctrl.Result{}, err
 		}
 
+		if err := r.enforceEphemeralContainers(ctx, &shadowPod, &existingPod); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.enforceResources(ctx, &shadowPod, &existingPod); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// Update ShadowPod status same as Pod status
 		shadowPod.Status.Phase = existingPod.Status.DeepCopy().Phase
 		if newErr := r.Client.Status().Update(ctx, &shadowPod); newErr != nil {
@@ -133,9 +143,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			Labels:      shadowPod.Labels,
 			Annotations: shadowPod.Annotations,
 		},
-		Spec: shadowPod.Spec.Pod,
+		Spec: *shadowPod.Spec.Pod.DeepCopy(),
 	}
 
+	// Ephemeral containers cannot be specified at creation time, and they are added once the pod has been created.
+	newPod.Spec.EphemeralContainers = nil
+
 	// Mutate PodSpec
 	if err := r.mutatePodSpec(ctx, &newPod.Spec, remoteClusterID); err != nil {
 		klog.Errorf("unable to mutate pod spec for shadowpod %q: %v", klog.KObj(&shadowPod), err)
@@ -155,9 +168,104 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		"Successfully created pod from ShadowPod")
 	klog.Infof("created pod %q for shadowpod %q", klog.KObj(&newPod), klog.KObj(&shadowPod))
 
+	if err := r.enforceEphemeralContainers(ctx, &shadowPod, &newPod); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// enforceEphemeralContainers adds to the given pod the ephemeral containers specified by the shadowpod and not yet present
+// (e.g., added by kubectl debug to the origin pod), through the corresponding subresource.
+func (r *Reconciler) enforceEphemeralContainers(ctx context.Context, shadowPod *offloadingv1beta1.ShadowPod, pod *corev1.Pod) error {
+	desired := forge.RemoteEphemeralContainers(shadowPod.Spec.Pod.EphemeralContainers, pod.Spec.EphemeralContainers)
+	if len(desired) == len(pod.Spec.EphemeralContainers) {
+		return nil
+	}
+
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = desired
+	if err := r.SubResource("ephemeralcontainers").Update(ctx, updated); err != nil {
+		r.Recorder.Eventf(shadowPod, corev1.EventTypeWarning, EventReasonFailedUpdatePod,
+			"Failed to update pod ephemeral containers: %v", err)
+		klog.Errorf("unable to update the ephemeral containers of pod %q: %v", klog.KObj(pod), err)
+		return err
+	}
+
+	klog.Infof("updated the ephemeral containers of pod %q with success", klog.KObj(pod))
+	return nil
+}
+
+// enforceResources propagates to the given pod the in-place resize of the containers specified by the shadowpod,
+// through the corresponding subresource. Only the resources explicitly set in the shadowpod are compared, to
+// tolerate the defaults possibly configured by the LimitRanges of the remote namespace.
+func (r *Reconciler) enforceResources(ctx context.Context, shadowPod *offloadingv1beta1.ShadowPod, pod *corev1.Pod) error {
+	if !containersResourcesDiffer(shadowPod.Spec.Pod.Containers, pod.Spec.Containers) {
+		return nil
+	}
+
+	updated := pod.DeepCopy()
+	for i := range shadowPod.Spec.Pod.Containers {
+		for j := range updated.Spec.Containers {
+			if shadowPod.Spec.Pod.Containers[i].Name == updated.Spec.Containers[j].Name {
+				desired := &shadowPod.Spec.Pod.Containers[i].Resources
+				actual := &updated.Spec.Containers[j].Resources
+				actual.Requests = mergeResourceList(actual.Requests, desired.Requests)
+				actual.Limits = mergeResourceList(actual.Limits, desired.Limits)
+			}
+		}
+	}
+	if err := r.SubResource("resize").Update(ctx, updated); err != nil {
+		r.Recorder.Eventf(shadowPod, corev1.EventTypeWarning, EventReasonFailedUpdatePod,
+			"Failed to resize pod: %v", err)
+		klog.Errorf("unable to resize pod %q: %v", klog.KObj(pod), err)
+		return err
+	}
+
+	klog.Infof("resized pod %q with success", klog.KObj(pod))
+	return nil
+}
+
+// containersResourcesDiffer returns whether any of the resources set in the desired containers differs from the actual ones.
+func containersResourcesDiffer(desired, actual []corev1.Container) bool {
+	for i := range desired {
+		for j := range actual {
+			if desired[i].Name != actual[j].Name {
+				continue
+			}
+			if resourceListDiffers(desired[i].Resources.Requests, actual[j].Resources.Requests) ||
+				resourceListDiffers(desired[i].Resources.Limits, actual[j].Resources.Limits) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourceListDiffers returns whether any of the quantities set in the desired list differs from the actual one.
+func resourceListDiffers(desired, actual corev1.ResourceList) bool {
+	for name, quantity := range desired {
+		if current, found := actual[name]; !found || !quantity.Equal(current) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeResourceList overrides the quantities of the actual list with those set in the desired one.
+func mergeResourceList(actual, desired corev1.ResourceList) corev1.ResourceList {
+	if len(desired) == 0 {
+		return actual
+	}
+	if actual == nil {
+		actual = corev1.ResourceList{}
+	}
+	for name, quantity := range desired {
+		actual[name] = quantity.DeepCopy()
+	}
+	return actual
+}
+
 // SetupWithManager monitors only updates on ShadowPods.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
 	// Trigger a reconciliation only for Delete and Update Events.
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -229,6 +230,64 @@ var _ = Describe("Reconcile", func() {
 		})
 	})
 
+	When("ephemeral containers have been added to the shadowpod", func() {
+		debugger := corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"},
+			TargetContainerName:      "nginx",
+		}
+
+		BeforeEach(func() {
+			testShadowPod.Spec.Pod.EphemeralContainers = []corev1.EphemeralContainer{debugger}
+			Expect(k8sClient.Create(ctx, &testShadowPod)).To(Succeed())
+		})
+
+		When("the pod already exists", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(ctx, &testPod)).To(Succeed())
+			})
+
+			It("should add the ephemeral containers to the pod", func() {
+				pod := corev1.Pod{}
+				Expect(k8sClient.Get(ctx, req.NamespacedName, &pod)).To(Succeed())
+				Expect(pod.Spec.EphemeralContainers).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"TargetContainerName": Equal("nginx")})))
+			})
+		})
+
+		When("the pod does not exist", func() {
+			It("should create the pod, and then add the ephemeral containers", func() {
+				pod := corev1.Pod{}
+				Expect(k8sClient.Get(ctx, req.NamespacedName, &pod)).To(Succeed())
+				Expect(pod.Spec.EphemeralContainers).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"TargetContainerName": Equal("nginx")})))
+			})
+		})
+	})
+
+	When("the containers of the shadowpod have been resized", func() {
+		Resources := func(cpu string) corev1.ResourceRequirements {
+			return corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}
+		}
+
+		BeforeEach(func() {
+			testPod.Spec.Containers = []corev1.Container{{Name: "nginx", Image: "nginx", Resources: Resources("100m")}}
+			testShadowPod.Spec.Pod.Containers = []corev1.Container{{Name: "nginx", Image: "nginx", Resources: Resources("200m")}}
+			Expect(k8sClient.Create(ctx, &testShadowPod)).To(Succeed())
+			Expect(k8sClient.Create(ctx, &testPod)).To(Succeed())
+		})
+
+		It("should resize the containers of the pod", func() {
+			pod := corev1.Pod{}
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &pod)).To(Succeed())
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].Resources.Requests.Cpu().MilliValue()).To(BeNumerically("==", 200))
+			Expect(pod.Spec.Containers[0].Resources.Limits.Cpu().MilliValue()).To(BeNumerically("==", 200))
+		})
+	})
+
 	When("pod is already completed or failed, shouldn't recreate pod", func() {
 		BeforeEach(func() {
 			Expect(k8sClient.Create(ctx, &testShadowPodSuccess)).To(Succeed())
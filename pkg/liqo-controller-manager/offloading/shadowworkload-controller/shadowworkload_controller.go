@@ -0,0 +1,128 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowworkloadctrl
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/forge"
+	"github.com/liqotech/liqo/pkg/utils"
+	clientutils "github.com/liqotech/liqo/pkg/utils/clients"
+	vkforge "github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// Reconciler reconciles a ShadowWorkload object.
+type Reconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+const (
+	// EventReasonFailedEnforceWorkload is the event reason when failing to enforce the native workload.
+	EventReasonFailedEnforceWorkload = "FailedEnforceWorkload"
+
+	fieldOwner = "shadow-workload"
+)
+
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowworkloads,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowworkloads/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowworkloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile ShadowWorkloads objects, enforcing the corresponding native workload and aggregating its status.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	nsName := req.NamespacedName
+	klog.V(4).Infof("reconcile shadowworkload %s", nsName)
+	shadow := offloadingv1beta1.ShadowWorkload{}
+	if err := r.Get(ctx, nsName, &shadow); err != nil {
+		err = client.IgnoreNotFound(err)
+		if err == nil {
+			klog.V(4).Infof("skip: shadowworkload %s not found", nsName)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !shadow.DeletionTimestamp.IsZero() {
+		klog.V(4).Infof("skip: shadowworkload %s is being deleted", nsName)
+		return ctrl.Result{}, nil
+	}
+
+	if _, ok := utils.GetClusterIDFromLabelsWithKey(shadow.Labels, vkforge.LiqoOriginClusterIDKey); !ok {
+		klog.Errorf("unable to get remote cluster ID from shadowworkload %q", klog.KObj(&shadow))
+		return ctrl.Result{}, nil
+	}
+
+	workload, err := forge.NativeWorkload(&shadow)
+	if err != nil {
+		klog.Errorf("unable to forge the workload for shadowworkload %q: %v", klog.KObj(&shadow), err)
+		r.Recorder.Eventf(&shadow, corev1.EventTypeWarning, EventReasonFailedEnforceWorkload, "Failed to forge workload: %v", err)
+		return ctrl.Result{}, nil
+	}
+	utilruntime.Must(ctrl.SetControllerReference(&shadow, workload, r.Scheme))
+
+	// The native workload is server-side applied, so that the fields defaulted by the API server, as well as the ones
+	// managed by the native controllers (e.g., the labels added to the Job templates), are preserved.
+	if err := r.Patch(ctx, workload, clientutils.Patch(workload), client.ForceOwnership, client.FieldOwner(fieldOwner)); err != nil {
+		klog.Errorf("unable to enforce %s %q for shadowworkload %q: %v", shadow.Spec.Kind, klog.KObj(workload), klog.KObj(&shadow), err)
+		r.Recorder.Eventf(&shadow, corev1.EventTypeWarning, EventReasonFailedEnforceWorkload, "Failed to enforce %s: %v", shadow.Spec.Kind, err)
+		if kerrors.IsInvalid(err) {
+			// Retrying would not help (e.g., an immutable field has been modified), until the ShadowWorkload is updated.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := forge.ShadowWorkloadStatus(workload, shadow.Generation)
+	if equality.Semantic.DeepEqual(shadow.Status, status) {
+		return ctrl.Result{}, nil
+	}
+
+	shadow.Status = status
+	if err := r.Status().Update(ctx, &shadow); err != nil {
+		klog.Errorf("unable to update the status of shadowworkload %q: %v", klog.KObj(&shadow), err)
+		return ctrl.Result{}, err
+	}
+
+	klog.V(4).Infof("updated the status of shadowworkload %q", klog.KObj(&shadow))
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager monitors ShadowWorkloads and the native workloads they own.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, workers int) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlShadowWorkload).
+		For(&offloadingv1beta1.ShadowWorkload{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers}).
+		Complete(r)
+}
@@ -42,6 +42,7 @@ type Options struct {
 	ForeignClusterPingInterval time.Duration
 	ForeignClusterPingTimeout  time.Duration
 	DefaultLimitsEnforcement   string
+	PeeringAuditRetention      time.Duration
 
 	// Networking module
 	IPAMServer                     string
@@ -52,10 +53,14 @@ type Options struct {
 	FabricFullMasqueradeEnabled    bool
 	GwmasqbypassEnabled            bool
 	GatewayTemplateWatchEnabled    bool
+	GatewayKeysRotationInterval    time.Duration
 	NetworkWorkers                 int
 	IPWorkers                      int
 	GenevePort                     uint16
 	RouteConfigurationRulePriority int
+	ConnectionPacketLossThreshold  int32
+	ConnectionLatencyThreshold     time.Duration
+	ConnectionJitterThreshold      time.Duration
 
 	// Authentication module
 	APIServerAddressOverride         string
@@ -63,7 +68,9 @@ type Options struct {
 	TrustedCA                        bool
 	TLSCompatibilityMode             bool
 	DefaultResourceSliceClassEnabled bool
+	IdentityCertificateLifetime      time.Duration
 	AWSConfig                        *identitymanager.LocalAwsConfig
+	OIDCConfig                       *identitymanager.LocalOIDCConfig
 	ClusterLabels                    args.StringMap
 	IngressClasses                   args.ClassNameList
 	LoadBalancerClasses              args.ClassNameList
@@ -72,14 +79,22 @@ type Options struct {
 	GlobalAnnotations                args.StringMap
 
 	// Offloading module
-	EnableStorage               bool
-	VirtualStorageClassName     string
-	RealStorageClassName        string
-	StorageNamespace            string
-	EnableNodeFailureController bool
-	ShadowPodWorkers            int
-	ShadowEndpointSliceWorkers  int
-	DenyDirectConnections       bool
+	EnableStorage                 bool
+	VirtualStorageClassName       string
+	RealStorageClassName          string
+	StorageNamespace              string
+	EnableNodeFailureController   bool
+	EnableResourceSliceAutoscaler bool
+	ShadowPodWorkers              int
+	ShadowEndpointSliceWorkers    int
+	EnableWorkloadOffloading      bool
+	ShadowWorkloadWorkers         int
+	DenyDirectConnections         bool
+	EnableDRA                     bool
+	EnableMultiClusterServices    bool
+	EnableClusterDNS              bool
+	ClusterDNSZone                string
+	ClusterDNSAddress             string
 
 	// Cross module
 	EnableAPIServerProxyIPRemapping bool
@@ -89,6 +104,7 @@ type Options struct {
 // NewOptions creates a new Options struct with default values.
 func NewOptions() *Options {
 	return &Options{
-		AWSConfig: &identitymanager.LocalAwsConfig{},
+		AWSConfig:  &identitymanager.LocalAwsConfig{},
+		OIDCConfig: &identitymanager.LocalOIDCConfig{},
 	}
 }
@@ -0,0 +1,236 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	resourcehelper "k8s.io/component-helpers/resource"
+	k8shelper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+)
+
+// candidate is the virtual node of an autoscaled ResourceSlice, which the pending pods can be charged to.
+type candidate struct {
+	node *corev1.Node
+	// canGrow is whether the ResourceSlice has not reached its maximum resources yet.
+	canGrow bool
+}
+
+// getResourceSliceNode returns the virtual node associated with the given ResourceSlice, resolving it through the
+// VirtualNode created for the ResourceSlice. It returns a NotFound error if the node does not exist yet.
+func getResourceSliceNode(ctx context.Context, cl client.Client, resourceSlice *authv1beta1.ResourceSlice) (*corev1.Node, error) {
+	var virtualNodes offloadingv1beta1.VirtualNodeList
+	if err := cl.List(ctx, &virtualNodes, client.InNamespace(resourceSlice.Namespace),
+		client.MatchingLabels{consts.ResourceSliceNameLabelKey: resourceSlice.Name}); err != nil {
+		return nil, fmt.Errorf("unable to list the VirtualNodes of ResourceSlice %q: %w", client.ObjectKeyFromObject(resourceSlice), err)
+	}
+	if len(virtualNodes.Items) == 0 {
+		return nil, kerrors.NewNotFound(offloadingv1beta1.VirtualNodeGroupResource, resourceSlice.Name)
+	}
+	return getters.GetNodeFromVirtualNode(ctx, cl, &virtualNodes.Items[0])
+}
+
+// listCandidates returns the virtual nodes of the local autoscaled ResourceSlices, sorted by name.
+// The ResourceSlices whose virtual node does not exist yet are skipped.
+func listCandidates(ctx context.Context, cl client.Client) ([]candidate, error) {
+	resSlices, err := getters.ListResourceSlicesByLabel(ctx, cl, corev1.NamespaceAll, liqolabels.LocalLabelSelector())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the local ResourceSlices: %w", err)
+	}
+
+	var candidates []candidate
+	for i := range resSlices {
+		resourceSlice := &resSlices[i]
+		if resourceSlice.DeletionTimestamp != nil || resourceSlice.Spec.Autoscaling == nil {
+			continue
+		}
+		node, err := getResourceSliceNode(ctx, cl, resourceSlice)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate{node: node, canGrow: canGrow(resourceSlice)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].node.Name < candidates[j].node.Name })
+	return candidates, nil
+}
+
+// canGrow returns whether any of the resources requested by the ResourceSlice is below its maximum.
+func canGrow(resourceSlice *authv1beta1.ResourceSlice) bool {
+	for name, maximum := range resourceSlice.Spec.Autoscaling.MaxResources {
+		if requested, ok := resourceSlice.Spec.Resources[name]; !ok || requested.Cmp(maximum) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// computeUsedResources returns the resources requested by the pods running on the given node.
+func computeUsedResources(ctx context.Context, cl client.Client, node *corev1.Node) (corev1.ResourceList, error) {
+	var pods corev1.PodList
+	if err := cl.List(ctx, &pods, client.MatchingFields{indexer.FieldNodeNameFromPod: node.Name}); err != nil {
+		return nil, fmt.Errorf("unable to list the pods running on node %q: %w", node.Name, err)
+	}
+
+	used := corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		addPodRequests(used, pod)
+	}
+	return used, nil
+}
+
+// computePendingResources returns the resources requested by the pods which are waiting to be scheduled because of
+// insufficient resources, and that are assigned to the given node among the candidate ones.
+func computePendingResources(ctx context.Context, cl client.Client, node *corev1.Node,
+	candidates []candidate) (corev1.ResourceList, error) {
+	var pods corev1.PodList
+	if err := cl.List(ctx, &pods, client.MatchingFields{indexer.FieldNodeNameFromPod: ""}); err != nil {
+		return nil, fmt.Errorf("unable to list the pending pods: %w", err)
+	}
+
+	pending := corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isUnschedulable(pod) || assignNode(pod, candidates) != node.Name {
+			continue
+		}
+		addPodRequests(pending, pod)
+	}
+	return pending, nil
+}
+
+// assignNode returns the name of the candidate node the demand of the given pending pod is charged to, so that
+// each pod grows a single ResourceSlice. The pod is assigned to the first node it could be scheduled on whose
+// ResourceSlice can still grow, falling back to the first node it could be scheduled on otherwise.
+// It returns an empty string if the pod cannot be scheduled on any candidate node.
+func assignNode(pod *corev1.Pod, candidates []candidate) string {
+	fallback := ""
+	for i := range candidates {
+		if !canBeScheduledOn(pod, candidates[i].node) {
+			continue
+		}
+		if candidates[i].canGrow {
+			return candidates[i].node.Name
+		}
+		if fallback == "" {
+			fallback = candidates[i].node.Name
+		}
+	}
+	return fallback
+}
+
+// isUnschedulable returns whether the given pod has been marked as unschedulable by the scheduler.
+func isUnschedulable(pod *corev1.Pod) bool {
+	if pod.Spec.NodeName != "" || pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type == corev1.PodScheduled {
+			return cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable
+		}
+	}
+	return false
+}
+
+// canBeScheduledOn returns whether the given pod tolerates the taints of the node and matches its node affinity.
+func canBeScheduledOn(pod *corev1.Pod, node *corev1.Node) bool {
+	if _, untolerated := k8shelper.FindMatchingUntoleratedTaint(klog.Background(), node.Spec.Taints, pod.Spec.Tolerations,
+		func(t *corev1.Taint) bool {
+			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+		}, false); untolerated {
+		return false
+	}
+
+	match, err := nodeaffinity.GetRequiredNodeAffinity(pod).Match(node)
+	return err == nil && match
+}
+
+// addPodRequests adds the resources requested by the given pod (including the pod itself) to the given list.
+func addPodRequests(list corev1.ResourceList, pod *corev1.Pod) {
+	for name, quantity := range resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}) {
+		total := list[name].DeepCopy()
+		total.Add(quantity)
+		list[name] = total
+	}
+	pods := list[corev1.ResourcePods].DeepCopy()
+	pods.Add(*resource.NewQuantity(1, resource.DecimalSI))
+	list[corev1.ResourcePods] = pods
+}
+
+// computeDesiredResources returns the resources to be requested through the ResourceSlice, given the ones currently
+// granted, the ones used by the running pods and the ones requested by the pending pods. The ResourceSlice is grown
+// to fit the pending pods, and shrunk to the used resources when no pod is pending and scaling down is allowed.
+// The resulting quantities are bounded by the autoscaling configuration.
+func computeDesiredResources(autoscaling *authv1beta1.ResourceSliceAutoscaling, requested, granted,
+	used, pending corev1.ResourceList, canScaleDown bool) corev1.ResourceList {
+	desired := requested.DeepCopy()
+	if desired == nil {
+		desired = corev1.ResourceList{}
+	}
+
+	for name, maximum := range autoscaling.MaxResources {
+		current, ok := granted[name]
+		if !ok {
+			current = requested[name]
+		}
+
+		target := used[name].DeepCopy()
+		switch {
+		case len(pending) > 0:
+			target.Add(pending[name])
+			if target.Cmp(current) <= 0 {
+				continue
+			}
+		case canScaleDown:
+			if target.Cmp(current) >= 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if minimum, ok := autoscaling.MinResources[name]; ok && target.Cmp(minimum) < 0 {
+			target = minimum.DeepCopy()
+		}
+		if target.Cmp(maximum) > 0 {
+			target = maximum.DeepCopy()
+		}
+		desired[name] = target
+	}
+	return desired
+}
@@ -0,0 +1,247 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+)
+
+var _ = Describe("ResourceSlice autoscaling", func() {
+	var (
+		autoscaling *authv1beta1.ResourceSliceAutoscaling
+		node        *corev1.Node
+	)
+
+	cpu := func(quantity string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(quantity)}
+	}
+
+	forgePod := func(name, nodeName, cpuRequest string, unschedulable bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name:      "app",
+					Resources: corev1.ResourceRequirements{Requests: cpu(cpuRequest)},
+				}},
+				Tolerations: []corev1.Toleration{{Key: consts.VirtualNodeTolerationKey, Operator: corev1.TolerationOpExists}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if unschedulable {
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.Conditions = []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable,
+			}}
+		}
+		return pod
+	}
+
+	BeforeEach(func() {
+		autoscaling = &authv1beta1.ResourceSliceAutoscaling{
+			MinResources: cpu("2"),
+			MaxResources: cpu("8"),
+		}
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "slice", Labels: map[string]string{consts.TypeLabel: consts.TypeNode}},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{{
+				Key: consts.VirtualNodeTolerationKey, Value: "true", Effect: corev1.TaintEffectNoExecute,
+			}}},
+		}
+	})
+
+	Describe("computing the desired resources", func() {
+		It("should grow the slice to fit the pending pods", func() {
+			desired := computeDesiredResources(autoscaling, cpu("4"), cpu("4"), cpu("3"), cpu("2"), false)
+			Expect(desired.Cpu().Cmp(resource.MustParse("5"))).To(BeZero())
+		})
+
+		It("should not grow the slice beyond the maximum", func() {
+			desired := computeDesiredResources(autoscaling, cpu("4"), cpu("4"), cpu("4"), cpu("10"), false)
+			Expect(desired.Cpu().Cmp(resource.MustParse("8"))).To(BeZero())
+		})
+
+		It("should not grow the slice if the pending pods already fit", func() {
+			desired := computeDesiredResources(autoscaling, cpu("4"), cpu("4"), cpu("1"), cpu("2"), false)
+			Expect(desired.Cpu().Cmp(resource.MustParse("4"))).To(BeZero())
+		})
+
+		It("should shrink the slice to the used resources only when allowed", func() {
+			desired := computeDesiredResources(autoscaling, cpu("6"), cpu("6"), cpu("3"), corev1.ResourceList{}, false)
+			Expect(desired.Cpu().Cmp(resource.MustParse("6"))).To(BeZero())
+
+			desired = computeDesiredResources(autoscaling, cpu("6"), cpu("6"), cpu("3"), corev1.ResourceList{}, true)
+			Expect(desired.Cpu().Cmp(resource.MustParse("3"))).To(BeZero())
+		})
+
+		It("should not shrink the slice below the minimum", func() {
+			desired := computeDesiredResources(autoscaling, cpu("6"), cpu("6"), corev1.ResourceList{}, corev1.ResourceList{}, true)
+			Expect(desired.Cpu().Cmp(resource.MustParse("2"))).To(BeZero())
+		})
+
+		It("should leave the resources not listed in the maximum untouched", func() {
+			requested := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("8Gi")}
+			desired := computeDesiredResources(autoscaling, requested, requested, cpu("4"), cpu("2"), false)
+			Expect(desired.Memory().Cmp(resource.MustParse("8Gi"))).To(BeZero())
+			Expect(desired.Cpu().Cmp(resource.MustParse("6"))).To(BeZero())
+		})
+	})
+
+	Describe("selecting the pending pods", func() {
+		It("should consider only the unschedulable pods", func() {
+			Expect(isUnschedulable(forgePod("pod", "", "1", true))).To(BeTrue())
+			Expect(isUnschedulable(forgePod("pod", "node", "1", false))).To(BeFalse())
+		})
+
+		It("should consider only the pods which could be scheduled on the virtual node", func() {
+			pod := forgePod("pod", "", "1", true)
+			Expect(canBeScheduledOn(pod, node)).To(BeTrue())
+
+			pod.Spec.NodeSelector = map[string]string{"zone": "eu"}
+			Expect(canBeScheduledOn(pod, node)).To(BeFalse())
+
+			pod = forgePod("pod", "", "1", true)
+			pod.Spec.Tolerations = nil
+			Expect(canBeScheduledOn(pod, node)).To(BeFalse())
+		})
+	})
+
+	Describe("checking the scale down delay", func() {
+		It("should allow scaling down only after the delay since the last resize", func() {
+			now := time.Now()
+			resourceSlice := &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+					Annotations:       map[string]string{consts.LastAutoscaleTimeAnnotation: now.Add(-5 * time.Minute).Format(time.RFC3339)},
+				},
+				Spec: authv1beta1.ResourceSliceSpec{Autoscaling: autoscaling},
+			}
+			Expect(canScaleDown(resourceSlice, now)).To(BeFalse())
+
+			resourceSlice.Spec.Autoscaling.ScaleDownDelay = metav1.Duration{Duration: time.Minute}
+			Expect(canScaleDown(resourceSlice, now)).To(BeTrue())
+		})
+	})
+
+	Describe("assigning the pending pods", func() {
+		It("should charge each pod to a single node, preferring the ones which can still grow", func() {
+			other := node.DeepCopy()
+			other.Name = "other"
+			pod := forgePod("pod", "", "1", true)
+
+			Expect(assignNode(pod, []candidate{{node: node, canGrow: true}, {node: other, canGrow: true}})).To(Equal("slice"))
+			Expect(assignNode(pod, []candidate{{node: node, canGrow: false}, {node: other, canGrow: true}})).To(Equal("other"))
+			Expect(assignNode(pod, []candidate{{node: node, canGrow: false}, {node: other, canGrow: false}})).To(Equal("slice"))
+
+			pod.Spec.Tolerations = nil
+			Expect(assignNode(pod, []candidate{{node: node, canGrow: true}})).To(BeEmpty())
+		})
+	})
+
+	Describe("reconciling a ResourceSlice", func() {
+		var (
+			ctx    context.Context
+			scheme *runtime.Scheme
+		)
+
+		forgeResourceSlice := func(name string) *authv1beta1.ResourceSlice {
+			return &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant", Labels: map[string]string{
+					consts.ReplicationRequestedLabel: "true",
+				}},
+				Spec:   authv1beta1.ResourceSliceSpec{Resources: cpu("2"), Autoscaling: autoscaling.DeepCopy()},
+				Status: authv1beta1.ResourceSliceStatus{Resources: cpu("2")},
+			}
+		}
+
+		// forgeVirtualNode returns the VirtualNode of the given ResourceSlice, and the corresponding node.
+		forgeVirtualNode := func(name, resourceSlice string) (*offloadingv1beta1.VirtualNode, *corev1.Node) {
+			virtualNode := &offloadingv1beta1.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant", Labels: map[string]string{
+					consts.ResourceSliceNameLabelKey: resourceSlice,
+				}},
+				Spec: offloadingv1beta1.VirtualNodeSpec{ClusterID: "remote"},
+			}
+			n := node.DeepCopy()
+			n.Name = name
+			n.Labels[consts.RemoteClusterID] = "remote"
+			return virtualNode, n
+		}
+
+		reconcile := func(cl client.Client, resourceSlice *authv1beta1.ResourceSlice) {
+			r := NewResourceSliceAutoscalerReconciler(cl, scheme, record.NewFakeRecorder(10))
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(resourceSlice)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cl.Get(ctx, client.ObjectKeyFromObject(resourceSlice), resourceSlice)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme = runtime.NewScheme()
+			Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(offloadingv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		})
+
+		It("should request the resources needed by the pending pods", func() {
+			resourceSlice := forgeResourceSlice("slice")
+			virtualNode, virtualNodeNode := forgeVirtualNode("virtual-slice", "slice")
+			cl := fake.NewClientBuilder().WithScheme(scheme).
+				WithIndex(&corev1.Pod{}, indexer.FieldNodeNameFromPod, indexer.ExtractNodeName).
+				WithObjects(resourceSlice, virtualNode, virtualNodeNode,
+					forgePod("running", "virtual-slice", "2", false),
+					forgePod("pending", "", "3", true),
+				).Build()
+
+			reconcile(cl, resourceSlice)
+			Expect(resourceSlice.Spec.Resources.Cpu().Cmp(resource.MustParse("5"))).To(BeZero())
+			Expect(resourceSlice.Annotations).To(HaveKey(consts.LastAutoscaleTimeAnnotation))
+		})
+
+		It("should charge each pending pod to a single ResourceSlice", func() {
+			first, second := forgeResourceSlice("first"), forgeResourceSlice("second")
+			firstVirtualNode, firstNode := forgeVirtualNode("first", "first")
+			secondVirtualNode, secondNode := forgeVirtualNode("second", "second")
+			cl := fake.NewClientBuilder().WithScheme(scheme).
+				WithIndex(&corev1.Pod{}, indexer.FieldNodeNameFromPod, indexer.ExtractNodeName).
+				WithObjects(first, second, firstVirtualNode, firstNode, secondVirtualNode, secondNode,
+					forgePod("pending", "", "3", true),
+				).Build()
+
+			reconcile(cl, first)
+			reconcile(cl, second)
+			Expect(first.Spec.Resources.Cpu().Cmp(resource.MustParse("3"))).To(BeZero())
+			Expect(second.Spec.Resources.Cpu().Cmp(resource.MustParse("2"))).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,209 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+)
+
+const (
+	// resyncPeriod is the period after which the demand of each autoscaled ResourceSlice is evaluated again.
+	resyncPeriod = 30 * time.Second
+	// defaultScaleDownDelay is the scale down delay used when not specified in the ResourceSlice.
+	defaultScaleDownDelay = 10 * time.Minute
+)
+
+// ResourceSliceAutoscalerReconciler resizes the local ResourceSlices depending on the demand of the consumer cluster.
+type ResourceSliceAutoscalerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	EventRecorder record.EventRecorder
+}
+
+// NewResourceSliceAutoscalerReconciler returns a new ResourceSliceAutoscalerReconciler.
+func NewResourceSliceAutoscalerReconciler(cl client.Client, s *runtime.Scheme,
+	recorder record.EventRecorder) *ResourceSliceAutoscalerReconciler {
+	return &ResourceSliceAutoscalerReconciler{
+		Client: cl,
+		Scheme: s,
+
+		EventRecorder: recorder,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslices,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=virtualnodes,verbs=get;list;watch
+
+// Reconcile resizes the ResourceSlices with autoscaling enabled, depending on the pods running on, and waiting for,
+// the associated virtual nodes.
+func (r *ResourceSliceAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var resourceSlice authv1beta1.ResourceSlice
+	if err := r.Get(ctx, req.NamespacedName, &resourceSlice); err != nil {
+		if kerrors.IsNotFound(err) {
+			klog.V(4).Infof("resourceSlice %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("unable to get ResourceSlice %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	if resourceSlice.DeletionTimestamp != nil || resourceSlice.Spec.Autoscaling == nil {
+		return ctrl.Result{}, nil
+	}
+
+	node, err := getResourceSliceNode(ctx, r.Client, &resourceSlice)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			klog.V(4).Infof("Node of ResourceSlice %q not found yet", req.NamespacedName)
+			return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the node of ResourceSlice %q: %w", req.NamespacedName, err)
+	}
+
+	used, err := computeUsedResources(ctx, r.Client, node)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Each pending pod is charged to a single ResourceSlice, to avoid growing all the ones it could be scheduled on.
+	candidates, err := listCandidates(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	pending, err := computePendingResources(ctx, r.Client, node, candidates)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	desired := computeDesiredResources(resourceSlice.Spec.Autoscaling, resourceSlice.Spec.Resources,
+		resourceSlice.Status.Resources, used, pending, canScaleDown(&resourceSlice, time.Now()))
+	if equality.Semantic.DeepEqual(desired, resourceSlice.Spec.Resources) {
+		return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	}
+
+	original := resourceSlice.DeepCopy()
+	resourceSlice.Spec.Resources = desired
+	if resourceSlice.Annotations == nil {
+		resourceSlice.Annotations = map[string]string{}
+	}
+	resourceSlice.Annotations[consts.LastAutoscaleTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, &resourceSlice, client.MergeFrom(original)); err != nil {
+		klog.Errorf("Unable to resize the ResourceSlice %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	klog.Infof("ResourceSlice %q resized to %s", req.NamespacedName, formatResources(desired))
+	r.EventRecorder.Event(&resourceSlice, corev1.EventTypeNormal, "ResourceSliceResized",
+		fmt.Sprintf("ResourceSlice resized to %s", formatResources(desired)))
+	return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+// canScaleDown returns whether the scale down delay has elapsed since the last resize of the ResourceSlice.
+func canScaleDown(resourceSlice *authv1beta1.ResourceSlice, now time.Time) bool {
+	delay := resourceSlice.Spec.Autoscaling.ScaleDownDelay.Duration
+	if delay == 0 {
+		delay = defaultScaleDownDelay
+	}
+
+	last := resourceSlice.CreationTimestamp.Time
+	if value, ok := resourceSlice.Annotations[consts.LastAutoscaleTimeAnnotation]; ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			last = parsed
+		}
+	}
+	return now.Sub(last) >= delay
+}
+
+func formatResources(resources corev1.ResourceList) string {
+	entries := make([]string, 0, len(resources))
+	for name, quantity := range resources {
+		entries = append(entries, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// SetupWithManager register the ResourceSliceAutoscalerReconciler with the manager.
+func (r *ResourceSliceAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// generate the predicate to filter just the ResourceSlices created by the local cluster checking crdReplicator labels
+	localResSliceFilter, err := predicate.LabelSelectorPredicate(reflection.LocalResourcesLabelSelector())
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlResourceSliceAutoscaler).
+		For(&authv1beta1.ResourceSlice{}, builder.WithPredicates(predicate.And(localResSliceFilter, withAutoscaling()))).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.resourceSlicesEnquer()),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				pod, ok := obj.(*corev1.Pod)
+				return ok && isUnschedulable(pod)
+			}))).
+		Complete(r)
+}
+
+// resourceSlicesEnquer enqueues all the autoscaled ResourceSlices when a pod becomes unschedulable,
+// to promptly grow the ones it could be scheduled on.
+func (r *ResourceSliceAutoscalerReconciler) resourceSlicesEnquer() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll, liqolabels.LocalLabelSelector())
+		if err != nil {
+			klog.Errorf("Failed to retrieve the local ResourceSlices: %v", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range resSlices {
+			if resSlices[i].Spec.Autoscaling == nil {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&resSlices[i])})
+		}
+		return reqs
+	}
+}
+
+func withAutoscaling() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		rs, ok := obj.(*authv1beta1.ResourceSlice)
+		return ok && rs.Spec.Autoscaling != nil
+	})
+}
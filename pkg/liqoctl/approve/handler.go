@@ -0,0 +1,83 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	argsutils "github.com/liqotech/liqo/pkg/utils/args"
+)
+
+// Options encapsulates the arguments of the approve command.
+type Options struct {
+	*factory.Factory
+
+	Name      string
+	ClusterID argsutils.ClusterIDFlags
+
+	Timeout time.Duration
+}
+
+// NewOptions returns a new Options struct.
+func NewOptions(f *factory.Factory) *Options {
+	return &Options{
+		Factory: f,
+	}
+}
+
+// RunApproveResourceSlice approves the current generation of a ResourceSlice requested by a consumer cluster.
+func (o *Options) RunApproveResourceSlice(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	namespaceManager := tenantnamespace.NewManager(o.Factory.KubeClient, o.Factory.CRClient.Scheme())
+
+	ns, err := namespaceManager.GetNamespace(ctx, o.ClusterID.GetClusterID())
+	if err != nil {
+		o.Printer.CheckErr(fmt.Errorf("unable to get tenant namespace: %v", output.PrettyErr(err)))
+		return err
+	}
+
+	var rs authv1beta1.ResourceSlice
+	if err := o.CRClient.Get(ctx, client.ObjectKey{Name: o.Name, Namespace: ns.Name}, &rs); err != nil {
+		o.Printer.CheckErr(fmt.Errorf("unable to get ResourceSlice: %v", output.PrettyErr(err)))
+		return err
+	}
+
+	if rs.Annotations == nil {
+		rs.Annotations = make(map[string]string)
+	}
+	// The approval refers to the current generation, hence any subsequent change requires a new approval.
+	rs.Annotations[consts.ApprovedGenerationAnnotation] = strconv.FormatInt(rs.Generation, 10)
+
+	if err := o.CRClient.Update(ctx, &rs); err != nil {
+		o.Printer.CheckErr(fmt.Errorf("unable to update ResourceSlice: %v", output.PrettyErr(err)))
+		return err
+	}
+
+	o.Printer.Success.Printfln("ResourceSlice %q approved", o.Name)
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
 	"github.com/liqotech/liqo/pkg/consts"
+	authforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/forge"
 	authutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
@@ -179,7 +180,7 @@ func (c *Cluster) EnsureTenant(ctx context.Context, tenant *authv1beta1.Tenant)
 	if _, err := resource.CreateOrUpdate(ctx, c.local.CRClient, tenant, func() error {
 		tenant.Labels = newTenant.Labels
 		tenant.Annotations = newTenant.Annotations
-		tenant.Spec = newTenant.Spec
+		authforge.MutateTenantSpec(tenant, &newTenant.Spec)
 		return nil
 	}); err != nil {
 		s.Fail(fmt.Sprintf("Unable to apply tenant on provider cluster: %v", output.PrettyErr(err)))
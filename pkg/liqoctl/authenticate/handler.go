@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 )
 
 // Options encapsulates the arguments of the authenticate command.
@@ -29,6 +30,9 @@ type Options struct {
 
 	InBand   bool
 	ProxyURL string
+
+	// Plan, if set, collects the objects to be applied on each cluster, instead of applying them.
+	Plan *plan.Plan
 }
 
 // NewOptions returns a new Options struct.
@@ -55,6 +59,10 @@ func (o *Options) RunAuthenticate(ctx context.Context) error {
 		return err
 	}
 
+	if o.Plan != nil {
+		return o.planAuthenticate(ctx, consumer, provider)
+	}
+
 	// Ensure that the tenant namespace exists in the consumer cluster.
 	if err := consumer.EnsureTenantNamespace(ctx, provider.LocalClusterID); err != nil {
 		return err
@@ -77,19 +85,8 @@ func (o *Options) RunAuthenticate(ctx context.Context) error {
 		return err
 	}
 
-	if o.InBand && o.ProxyURL == "" {
-		// In-band authentication: forge the proxy URL.
-		providerAPIServerProxyIP, err := provider.GetAPIServerProxyRemappedIP(ctx)
-		if err != nil {
-			return err
-		}
-
-		remappedIP, err := consumer.RemapIPExternalCIDR(ctx, providerAPIServerProxyIP)
-		if err != nil {
-			return err
-		}
-
-		o.ProxyURL = "http://" + remappedIP + ":8118"
+	if err := o.setInBandProxyURL(ctx, consumer, provider); err != nil {
+		return err
 	}
 
 	// In the consumer cluster, forge a tenant resource to be applied on the provider cluster
@@ -116,3 +113,24 @@ func (o *Options) RunAuthenticate(ctx context.Context) error {
 
 	return nil
 }
+
+// setInBandProxyURL forges the proxy URL used to reach the provider through the inter-cluster network,
+// in case of in-band authentication.
+func (o *Options) setInBandProxyURL(ctx context.Context, consumer, provider *Cluster) error {
+	if !o.InBand || o.ProxyURL != "" {
+		return nil
+	}
+
+	providerAPIServerProxyIP, err := provider.GetAPIServerProxyRemappedIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	remappedIP, err := consumer.RemapIPExternalCIDR(ctx, providerAPIServerProxyIP)
+	if err != nil {
+		return err
+	}
+
+	o.ProxyURL = "http://" + remappedIP + ":8118"
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticate
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/forge"
+	authgetters "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/getters"
+	authutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/utils"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// planAuthenticate collects the objects to be applied on the consumer and provider clusters to authenticate them, without applying them.
+// Differently from the actual command, the nonce is generated and signed by liqoctl, as the controllers are not involved.
+func (o *Options) planAuthenticate(ctx context.Context, consumer, provider *Cluster) error {
+	if err := consumer.PlanTenantNamespace(ctx, o.Plan, provider.LocalClusterID); err != nil {
+		return err
+	}
+	if err := provider.PlanTenantNamespace(ctx, o.Plan, consumer.LocalClusterID); err != nil {
+		return err
+	}
+
+	nonce, err := provider.PlanNonce(ctx, o.Plan)
+	if err != nil {
+		return err
+	}
+
+	signedNonce, err := consumer.PlanSignedNonce(ctx, o.Plan, nonce)
+	if err != nil {
+		return err
+	}
+
+	if err := o.setInBandProxyURL(ctx, consumer, provider); err != nil {
+		return err
+	}
+
+	tenant, err := consumer.GenerateTenant(ctx, signedNonce, provider.TenantNamespace, &o.ProxyURL)
+	if err != nil {
+		return err
+	}
+	if err := o.Plan.Add(provider.LocalClusterID, tenant); err != nil {
+		return err
+	}
+
+	return provider.PlanIdentity(ctx, o.Plan, consumer.TenantNamespace)
+}
+
+// PlanTenantNamespace sets the tenant namespace for the given remote cluster, adding it to the plan if it does not exist yet.
+func (c *Cluster) PlanTenantNamespace(ctx context.Context, p *plan.Plan, remoteClusterID liqov1beta1.ClusterID) error {
+	c.RemoteClusterID = remoteClusterID
+
+	tenantNs, err := p.TenantNamespace(ctx, c.localNamespaceManager, c.LocalClusterID, remoteClusterID)
+	if err != nil {
+		return fmt.Errorf("unable to get the tenant namespace: %w", err)
+	}
+	c.TenantNamespace = tenantNs
+	return nil
+}
+
+// PlanNonce returns the nonce for the authentication challenge of the remote cluster.
+// If no nonce exists yet, it generates a new one and adds the secret containing it to the plan.
+func (c *Cluster) PlanNonce(ctx context.Context, p *plan.Plan) ([]byte, error) {
+	nonce, err := authutils.RetrieveNonce(ctx, c.local.CRClient, c.RemoteClusterID, c.TenantNamespace)
+	switch {
+	case err == nil:
+		return nonce, nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	generated, err := authentication.GenerateNonce()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate nonce: %w", err)
+	}
+
+	secret := forge.Nonce(c.TenantNamespace)
+	if err := forge.MutateNonce(secret, c.RemoteClusterID); err != nil {
+		return nil, err
+	}
+	secret.StringData = map[string]string{consts.NonceSecretField: generated}
+	if err := p.Add(c.LocalClusterID, secret); err != nil {
+		return nil, err
+	}
+
+	return []byte(generated), nil
+}
+
+// PlanSignedNonce signs the nonce with the keys of the local cluster, adding the secret containing the nonce to the plan.
+func (c *Cluster) PlanSignedNonce(ctx context.Context, p *plan.Plan, nonce []byte) ([]byte, error) {
+	secret, err := getters.GetSignedNonceSecretByClusterID(ctx, c.local.CRClient, c.RemoteClusterID, c.TenantNamespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := p.Add(c.LocalClusterID, forge.SignedNonce(c.RemoteClusterID, c.TenantNamespace, string(nonce))); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("unable to get signed nonce secret: %w", err)
+	default:
+		existingNonce, err := authgetters.GetNonceFromSecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract nonce data from secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		if string(existingNonce) != string(nonce) {
+			return nil, fmt.Errorf("nonce secret already exists with a different nonce: %s", existingNonce)
+		}
+	}
+
+	privateKey, _, err := authentication.GetClusterKeys(ctx, c.local.CRClient, c.local.LiqoNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster keys: %w", err)
+	}
+	return authentication.SignNonce(privateKey, nonce)
+}
+
+// PlanIdentity adds to the plan the identity to be applied on the consumer cluster, if the provider has already issued
+// the credentials for it. Otherwise, it records that the plan must be generated again once the Tenant has been applied.
+func (c *Cluster) PlanIdentity(ctx context.Context, p *plan.Plan, remoteTenantNamespace string) error {
+	tenant, err := getters.GetTenantByClusterID(ctx, c.local.CRClient, c.RemoteClusterID, c.TenantNamespace)
+	switch {
+	case apierrors.IsNotFound(err) || (err == nil && tenant.Status.AuthParams == nil):
+		p.AddNote("The Identity for cluster %q is forged from the credentials issued by cluster %q once the Tenant is applied: "+
+			"generate the plan again after applying the objects of cluster %q", c.RemoteClusterID, c.LocalClusterID, c.LocalClusterID)
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to get the Tenant: %w", err)
+	}
+
+	identity, err := c.GenerateIdentity(ctx, remoteTenantNamespace)
+	if err != nil {
+		return err
+	}
+	return p.Add(c.RemoteClusterID, identity)
+}
@@ -15,11 +15,13 @@
 package factory
 
 import (
+	"io"
 	"strings"
 
 	helm "github.com/mittwald/go-helm-client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
@@ -112,6 +114,36 @@ func NewForRemote() *Factory {
 	return factory
 }
 
+// NewForRESTConfig returns a new Factory already initialized from the given REST config, to run the liqoctl logic
+// outside of the CLI (e.g., within a controller). All messages are output to the given writer.
+func NewForRESTConfig(cfg *rest.Config, scheme *runtime.Scheme, liqoNamespace string, remote bool, writer io.Writer) (*Factory, error) {
+	f := &Factory{
+		remote:        remote,
+		ScopedPrinter: remote,
+		LiqoNamespace: liqoNamespace,
+		RESTConfig:    cfg,
+	}
+
+	scope := "local"
+	if remote {
+		scope = "remote"
+	}
+	f.Printer = output.NewWriterPrinter(scope, writer)
+	f.PrinterGlobal = output.NewWriterPrinter("global", writer)
+
+	var err error
+	if f.KubeClient, err = kubernetes.NewForConfig(cfg); err != nil {
+		return nil, err
+	}
+	if f.DynClient, err = dynamic.NewForConfig(cfg); err != nil {
+		return nil, err
+	}
+	if f.CRClient, err = client.New(cfg, client.Options{Scheme: scheme}); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 // HelmClient returns an Helm client, initializing it if necessary. In case of error, it outputs
 // the error (through the spinner if provided, or leveraging the printer) and exits.
 func (f *Factory) HelmClient() helm.Client {
@@ -0,0 +1,178 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqoctl/info"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// maxRecentAuditEvents is the maximum number of recent events shown for each cluster.
+const maxRecentAuditEvents = 10
+
+// AuditOperationSummary contains the number of times an operation was performed on a type of resource.
+type AuditOperationSummary struct {
+	Resource  string                            `json:"resource"`
+	Operation liqov1beta1.PeeringAuditOperation `json:"operation"`
+	Count     int32                             `json:"count"`
+}
+
+// AuditEvent contains some info about an operation performed by the remote cluster.
+type AuditEvent struct {
+	User          string                            `json:"user"`
+	Operation     liqov1beta1.PeeringAuditOperation `json:"operation"`
+	Resource      string                            `json:"resource"`
+	Count         int32                             `json:"count"`
+	LastTimestamp time.Time                         `json:"lastTimestamp"`
+}
+
+// Audit contains a summary of the operations performed by a remote cluster on the local one.
+type Audit struct {
+	Total        int32                   `json:"total"`
+	Operations   []AuditOperationSummary `json:"operations"`
+	RecentEvents []AuditEvent            `json:"recentEvents"`
+}
+
+// AuditChecker collects a summary of the operations performed by the remote clusters, as recorded by the peering audit.
+type AuditChecker struct {
+	info.CheckerCommon
+
+	// In this case data is a mapping between ClusterID and the audit summary
+	data map[liqov1beta1.ClusterID]Audit
+}
+
+// Collect the PeeringAuditEvents of the remote clusters.
+func (ac *AuditChecker) Collect(ctx context.Context, options info.Options) {
+	ac.data = map[liqov1beta1.ClusterID]Audit{}
+	for clusterID := range options.ClustersInfo {
+		events, err := getters.ListPeeringAuditEventsByClusterID(ctx, options.CRClient, clusterID)
+		if err != nil {
+			ac.AddCollectionError(fmt.Errorf("unable to get PeeringAuditEvents of cluster %q: %w", clusterID, err))
+			continue
+		}
+		ac.data[clusterID] = summarizeAuditEvents(events)
+	}
+}
+
+// FormatForClusterID returns the collected data for the specified clusterID using a user friendly output.
+func (ac *AuditChecker) FormatForClusterID(clusterID liqov1beta1.ClusterID, options info.Options) string {
+	if data, ok := ac.data[clusterID]; ok {
+		main := output.NewRootSection()
+		if data.Total == 0 {
+			main.AddEntry("Operations", "No operations recorded")
+			return main.SprintForBox(options.Printer)
+		}
+
+		main.AddEntry("Total operations", fmt.Sprint(data.Total))
+		operationsSection := main.AddSection("Operations by resource")
+		for _, op := range data.Operations {
+			operationsSection.AddEntry(fmt.Sprintf("%s %s", op.Operation, op.Resource), fmt.Sprint(op.Count))
+		}
+
+		recentSection := main.AddSection("Recent operations")
+		for i := range data.RecentEvents {
+			event := &data.RecentEvents[i]
+			recentSection.AddEntry(event.LastTimestamp.Format(time.RFC3339),
+				fmt.Sprintf("%s %s by %s (%d times)", event.Operation, event.Resource, event.User, event.Count))
+		}
+
+		return main.SprintForBox(options.Printer)
+	}
+	return ""
+}
+
+// GetData returns the data collected by the checker.
+func (ac *AuditChecker) GetData() interface{} {
+	return ac.data
+}
+
+// GetDataByClusterID returns the data collected by the checker for the cluster with the give ClusterID.
+func (ac *AuditChecker) GetDataByClusterID(clusterID liqov1beta1.ClusterID) (interface{}, error) {
+	if res, ok := ac.data[clusterID]; ok {
+		return res, nil
+	}
+	return nil, fmt.Errorf("no data collected for cluster %q", clusterID)
+}
+
+// GetID returns the id of the section collected by the checker.
+func (ac *AuditChecker) GetID() string {
+	return "audit"
+}
+
+// GetTitle returns the title of the section collected by the checker.
+func (ac *AuditChecker) GetTitle() string {
+	return "Audit"
+}
+
+// summarizeAuditEvents aggregates the given events by resource type and operation, and selects the most recent ones.
+func summarizeAuditEvents(events []liqov1beta1.PeeringAuditEvent) Audit {
+	audit := Audit{Operations: []AuditOperationSummary{}, RecentEvents: []AuditEvent{}}
+	operations := map[AuditOperationSummary]int32{}
+
+	for i := range events {
+		spec := &events[i].Spec
+		resource := formatAuditResource(&spec.Resource)
+		key := AuditOperationSummary{Resource: resourceType(&spec.Resource), Operation: spec.Operation}
+		operations[key] += spec.Count
+		audit.Total += spec.Count
+
+		audit.RecentEvents = append(audit.RecentEvents, AuditEvent{
+			User:          spec.User,
+			Operation:     spec.Operation,
+			Resource:      resource,
+			Count:         spec.Count,
+			LastTimestamp: spec.LastTimestamp.Time,
+		})
+	}
+
+	for key, count := range operations {
+		key.Count = count
+		audit.Operations = append(audit.Operations, key)
+	}
+	slices.SortFunc(audit.Operations, func(a, b AuditOperationSummary) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Resource, b.Resource), cmp.Compare(a.Operation, b.Operation))
+	})
+
+	slices.SortStableFunc(audit.RecentEvents, func(a, b AuditEvent) int {
+		return cmp.Or(b.LastTimestamp.Compare(a.LastTimestamp), cmp.Compare(a.Resource, b.Resource))
+	})
+	if len(audit.RecentEvents) > maxRecentAuditEvents {
+		audit.RecentEvents = audit.RecentEvents[:maxRecentAuditEvents]
+	}
+
+	return audit
+}
+
+// resourceType returns the type of the given resource, including the subresource if any (e.g., pods/exec).
+func resourceType(resource *liqov1beta1.PeeringAuditResource) string {
+	if resource.Subresource != "" {
+		return resource.Resource + "/" + resource.Subresource
+	}
+	return resource.Resource
+}
+
+// formatAuditResource returns a human readable reference to the given resource.
+func formatAuditResource(resource *liqov1beta1.PeeringAuditResource) string {
+	return fmt.Sprintf("%s %s", resourceType(resource), path.Join(resource.Namespace, resource.Name))
+}
@@ -0,0 +1,113 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/info"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+var _ = Describe("AuditChecker tests", func() {
+	remoteClusterID := liqov1beta1.ClusterID("fake-remote")
+	now := time.Now().Truncate(time.Second)
+
+	var (
+		ac      *AuditChecker
+		ctx     context.Context
+		options info.Options
+	)
+
+	fakeAuditEvent := func(name, clusterID string, operation liqov1beta1.PeeringAuditOperation,
+		resource liqov1beta1.PeeringAuditResource, count int32, last time.Time) *liqov1beta1.PeeringAuditEvent {
+		return &liqov1beta1.PeeringAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "liqo-tenant-" + clusterID,
+				Labels: map[string]string{consts.RemoteClusterID: clusterID},
+			},
+			Spec: liqov1beta1.PeeringAuditEventSpec{
+				ClusterID: liqov1beta1.ClusterID(clusterID), User: clusterID, Operation: operation, Resource: resource,
+				FirstTimestamp: metav1.NewTime(last), LastTimestamp: metav1.NewTime(last), Count: count,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		options = info.Options{Factory: factory.NewForLocal()}
+		options.Printer = output.NewFakePrinter(GinkgoWriter)
+		options.ClustersInfo = map[liqov1beta1.ClusterID]*liqov1beta1.ForeignCluster{
+			remoteClusterID: testutil.FakeForeignCluster(remoteClusterID, &liqov1beta1.Modules{}),
+		}
+	})
+
+	It("should summarize the operations of the remote cluster", func() {
+		secret := liqov1beta1.PeeringAuditResource{Resource: "secrets", Namespace: "foo", Name: "bar"}
+		exec := liqov1beta1.PeeringAuditResource{Resource: "pods", Subresource: "exec", Namespace: "foo", Name: "baz"}
+		options.CRClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			fakeAuditEvent("e1", string(remoteClusterID), liqov1beta1.PeeringAuditOperationUpdate, secret, 3, now.Add(-time.Hour)),
+			fakeAuditEvent("e2", string(remoteClusterID), liqov1beta1.PeeringAuditOperationConnect, exec, 1, now),
+			fakeAuditEvent("e3", "other", liqov1beta1.PeeringAuditOperationDelete, secret, 5, now),
+		).Build()
+
+		ac = &AuditChecker{}
+		ac.Collect(ctx, options)
+		Expect(ac.GetCollectionErrors()).To(BeEmpty())
+
+		rawData, err := ac.GetDataByClusterID(remoteClusterID)
+		Expect(err).ToNot(HaveOccurred())
+		data := rawData.(Audit)
+
+		Expect(data.Total).To(BeEquivalentTo(4))
+		Expect(data.Operations).To(Equal([]AuditOperationSummary{
+			{Resource: "secrets", Operation: liqov1beta1.PeeringAuditOperationUpdate, Count: 3},
+			{Resource: "pods/exec", Operation: liqov1beta1.PeeringAuditOperationConnect, Count: 1},
+		}))
+		Expect(data.RecentEvents).To(HaveLen(2))
+		Expect(data.RecentEvents[0].Resource).To(Equal("pods/exec foo/baz"))
+		Expect(data.RecentEvents[1].Resource).To(Equal("secrets foo/bar"))
+
+		text := pterm.RemoveColorFromString(ac.FormatForClusterID(remoteClusterID, options))
+		text = strings.TrimSpace(testutil.SqueezeWhitespaces(text))
+		Expect(text).To(ContainSubstring("Total operations: 4"))
+		Expect(text).To(ContainSubstring("Update secrets: 3"))
+		Expect(text).To(ContainSubstring("Connect pods/exec foo/baz by fake-remote (1 times)"))
+	})
+
+	It("should report when no operations have been recorded", func() {
+		options.CRClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+		ac = &AuditChecker{}
+		ac.Collect(ctx, options)
+		Expect(ac.GetCollectionErrors()).To(BeEmpty())
+
+		text := pterm.RemoveColorFromString(ac.FormatForClusterID(remoteClusterID, options))
+		Expect(strings.TrimSpace(testutil.SqueezeWhitespaces(text))).To(Equal("Operations: No operations recorded"))
+	})
+})
@@ -35,6 +35,7 @@ import (
 	networkingutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/utils"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 	"github.com/liqotech/liqo/pkg/liqoctl/wait"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	liqoutils "github.com/liqotech/liqo/pkg/utils"
@@ -59,14 +60,18 @@ type Cluster struct {
 	remoteClusterID liqov1beta1.ClusterID
 
 	networkConfiguration *networkingv1beta1.Configuration
+
+	plan *plan.Plan
 }
 
 // NewCluster returns a new Cluster struct.
-func NewCluster(ctx context.Context, local, remote *factory.Factory, createTenantNs bool) (*Cluster, error) {
+// If p is not nil, the missing tenant namespaces are added to the plan instead of being created.
+func NewCluster(ctx context.Context, local, remote *factory.Factory, createTenantNs bool, p *plan.Plan) (*Cluster, error) {
 	cluster := Cluster{
 		local:  local,
 		remote: remote,
 		waiter: wait.NewWaiterFromFactory(local),
+		plan:   p,
 
 		localNamespaceManager:  tenantnamespace.NewManager(local.KubeClient, local.CRClient.Scheme()),
 		remoteNamespaceManager: tenantnamespace.NewManager(remote.KubeClient, remote.CRClient.Scheme()),
@@ -122,6 +127,15 @@ func (c *Cluster) SetNamespaces(ctx context.Context, createTenantNs bool) error
 		// set it as the local network namespace.
 		var localTenantNs *corev1.Namespace
 
+		if c.plan != nil {
+			c.localNetworkNamespace, err = c.plan.TenantNamespace(ctx, c.localNamespaceManager, c.localClusterID, c.remoteClusterID)
+			if err != nil {
+				c.local.Printer.CheckErr(fmt.Errorf("an error occurred while retrieving local tenant namespace: %v", output.PrettyErr(err)))
+				return err
+			}
+			break
+		}
+
 		if createTenantNs {
 			localTenantNs, err = c.localNamespaceManager.CreateNamespace(ctx, c.remoteClusterID)
 			if err != nil {
@@ -147,6 +161,16 @@ func (c *Cluster) SetNamespaces(ctx context.Context, createTenantNs bool) error
 		// set it as the remote network namespace.
 		var remoteTenantNs *corev1.Namespace
 		creationError := false
+
+		if c.plan != nil {
+			c.remoteNetworkNamespace, err = c.plan.TenantNamespace(ctx, c.remoteNamespaceManager, c.remoteClusterID, c.localClusterID)
+			if err != nil {
+				c.remote.Printer.CheckErr(fmt.Errorf("an error occurred while retrieving remote tenant namespace: %v", output.PrettyErr(err)))
+				return err
+			}
+			break
+		}
+
 		if createTenantNs {
 			remoteTenantNs, err = c.remoteNamespaceManager.CreateNamespace(ctx, c.localClusterID)
 			switch {
@@ -625,3 +649,77 @@ func (c *Cluster) CheckAlreadyEstablishedForGwClient(ctx context.Context) (bool,
 		return true, nil
 	}
 }
+
+// GetGatewayKeysSecret returns the secret containing the keys of the local gateway (either server or client) connected to the remote cluster.
+func (c *Cluster) GetGatewayKeysSecret(ctx context.Context) (*corev1.Secret, error) {
+	s := c.local.Printer.StartSpinner("Retrieving gateway keys")
+
+	var secretRef *corev1.ObjectReference
+	gwServer, err := getters.GetGatewayServerByClusterID(ctx, c.local.CRClient, c.remoteClusterID, c.localNetworkNamespace)
+	switch {
+	case err == nil:
+		secretRef = gwServer.Status.SecretRef
+	case apierrors.IsNotFound(err):
+		gwClient, err := getters.GetGatewayClientByClusterID(ctx, c.local.CRClient, c.remoteClusterID, c.localNetworkNamespace)
+		if err != nil {
+			s.Fail(fmt.Sprintf("An error occurred while retrieving gateway: %v", output.PrettyErr(err)))
+			return nil, err
+		}
+		secretRef = gwClient.Status.SecretRef
+	default:
+		s.Fail(fmt.Sprintf("An error occurred while retrieving gateway: %v", output.PrettyErr(err)))
+		return nil, err
+	}
+
+	if secretRef == nil {
+		err := fmt.Errorf("the gateway connected to cluster %q has no keys secret", c.remoteClusterID)
+		s.Fail(err.Error())
+		return nil, err
+	}
+
+	var secret corev1.Secret
+	if err := c.local.CRClient.Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: secretRef.Namespace}, &secret); err != nil {
+		s.Fail(fmt.Sprintf("An error occurred while retrieving gateway keys secret: %v", output.PrettyErr(err)))
+		return nil, err
+	}
+
+	s.Success("Gateway keys correctly retrieved")
+	return &secret, nil
+}
+
+// RunKeysRotationStep annotates the gateway keys secret to perform the given keys rotation step, and waits for its completion.
+func (c *Cluster) RunKeysRotationStep(ctx context.Context, secret *corev1.Secret, step string) error {
+	s := c.local.Printer.StartSpinner(fmt.Sprintf("Requesting keys rotation step %q", step))
+	if _, err := resource.CreateOrUpdate(ctx, c.local.CRClient, secret, func() error {
+		secret.SetAnnotations(maps.Merge(secret.GetAnnotations(), map[string]string{consts.RotateKeysAnnotation: step}))
+		return nil
+	}); err != nil {
+		s.Fail(fmt.Sprintf("An error occurred while requesting keys rotation step %q: %v", step, output.PrettyErr(err)))
+		return err
+	}
+	s.Success(fmt.Sprintf("Keys rotation step %q correctly requested", step))
+
+	return c.waiter.ForKeysRotationStep(ctx, secret, step)
+}
+
+// UpdatePublicKey updates the PublicKey of the given remote cluster with the provided key.
+func (c *Cluster) UpdatePublicKey(ctx context.Context, remoteClusterID liqov1beta1.ClusterID,
+	key []byte) (*networkingv1beta1.PublicKey, error) {
+	s := c.local.Printer.StartSpinner("Updating public key")
+
+	pk, err := getters.GetPublicKeyByClusterID(ctx, c.local.CRClient, remoteClusterID, c.localNetworkNamespace)
+	if err != nil {
+		s.Fail(fmt.Sprintf("An error occurred while retrieving public key: %v", output.PrettyErr(err)))
+		return nil, err
+	}
+
+	if _, err = resource.CreateOrUpdate(ctx, c.local.CRClient, pk, func() error {
+		return forge.MutatePublicKey(pk, remoteClusterID, key)
+	}); err != nil {
+		s.Fail(fmt.Sprintf("An error occurred while updating public key: %v", output.PrettyErr(err)))
+		return nil, err
+	}
+
+	s.Success("Public key correctly updated")
+	return pk, nil
+}
@@ -24,9 +24,11 @@ import (
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/getters"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 	argsutils "github.com/liqotech/liqo/pkg/utils/args"
 )
 
@@ -59,6 +61,9 @@ type Options struct {
 
 	MTU                int
 	DisableSharingKeys bool
+
+	// Plan, if set, collects the objects to be applied on each cluster, instead of applying them.
+	Plan *plan.Plan
 }
 
 // NewOptions returns a new Options struct.
@@ -78,13 +83,13 @@ func (o *Options) RunReset(ctx context.Context) error {
 	defer cancel()
 
 	// Create and initialize cluster 1.
-	cluster1, err := NewCluster(ctx, o.LocalFactory, o.RemoteFactory, false)
+	cluster1, err := NewCluster(ctx, o.LocalFactory, o.RemoteFactory, false, nil)
 	if err != nil {
 		return err
 	}
 
 	// Create and initialize cluster 2.
-	cluster2, err := NewCluster(ctx, o.RemoteFactory, o.LocalFactory, false)
+	cluster2, err := NewCluster(ctx, o.RemoteFactory, o.LocalFactory, false, nil)
 	if err != nil {
 		return err
 	}
@@ -117,16 +122,21 @@ func (o *Options) RunConnect(ctx context.Context) error {
 	}
 
 	// Create and initialize cluster 1.
-	cluster1, err := NewCluster(ctx, o.LocalFactory, o.RemoteFactory, true)
+	cluster1, err := NewCluster(ctx, o.LocalFactory, o.RemoteFactory, true, o.Plan)
 	if err != nil {
 		return err
 	}
 
 	// Create and initialize cluster 2.
-	cluster2, err := NewCluster(ctx, o.RemoteFactory, o.LocalFactory, true)
+	cluster2, err := NewCluster(ctx, o.RemoteFactory, o.LocalFactory, true, o.Plan)
 	if err != nil {
 		return err
 	}
+
+	if o.Plan != nil {
+		return o.planConnect(ctx, cluster1, cluster2)
+	}
+
 	// Exchange network configurations between the clusters
 	if err := o.initNetworkConfigs(ctx, cluster1, cluster2); err != nil {
 		return err
@@ -277,7 +287,7 @@ func (o *Options) RunDisconnect(ctx context.Context, cluster1, cluster2 *Cluster
 
 	if cluster1 == nil {
 		// Create and initialize cluster 1.
-		cluster1, err = NewCluster(ctx, o.LocalFactory, o.RemoteFactory, false)
+		cluster1, err = NewCluster(ctx, o.LocalFactory, o.RemoteFactory, false, nil)
 		if err != nil {
 			return err
 		}
@@ -285,7 +295,7 @@ func (o *Options) RunDisconnect(ctx context.Context, cluster1, cluster2 *Cluster
 
 	if cluster2 == nil {
 		// Create and initialize cluster 2.
-		cluster2, err = NewCluster(ctx, o.RemoteFactory, o.LocalFactory, false)
+		cluster2, err = NewCluster(ctx, o.RemoteFactory, o.LocalFactory, false, nil)
 		if err != nil {
 			return err
 		}
@@ -310,6 +320,61 @@ func (o *Options) RunDisconnect(ctx context.Context, cluster1, cluster2 *Cluster
 	return cluster2.DeleteGatewayServer(ctx, cluster1.localClusterID)
 }
 
+// RunRotateKeys rotates the keys of the gateways connecting two clusters, without interrupting the tunnel.
+func (o *Options) RunRotateKeys(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	// Create and initialize cluster 1.
+	cluster1, err := NewCluster(ctx, o.LocalFactory, o.RemoteFactory, false, nil)
+	if err != nil {
+		return err
+	}
+
+	// Create and initialize cluster 2.
+	cluster2, err := NewCluster(ctx, o.RemoteFactory, o.LocalFactory, false, nil)
+	if err != nil {
+		return err
+	}
+
+	// Rotate the keys of the gateway on cluster 1
+	if err := rotateKeys(ctx, cluster1, cluster2); err != nil {
+		return err
+	}
+
+	// Rotate the keys of the gateway on cluster 2
+	return rotateKeys(ctx, cluster2, cluster1)
+}
+
+// rotateKeys rotates the keys of the gateway on the local cluster, publishing the new public key on the remote cluster.
+func rotateKeys(ctx context.Context, local, remote *Cluster) error {
+	secret, err := local.GetGatewayKeysSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Generate the next keys, while the gateway keeps using the current ones.
+	if err := local.RunKeysRotationStep(ctx, secret, consts.RotateKeysPrepare); err != nil {
+		return err
+	}
+
+	// Publish the next public key: the remote gateway accepts it alongside the current one.
+	pk, err := remote.UpdatePublicKey(ctx, local.localClusterID, secret.Data[consts.NextPublicKeyField])
+	if err != nil {
+		return err
+	}
+	if err := remote.waiter.ForPublicKeyPhase(ctx, pk,
+		networkingv1beta1.PublicKeyPhasePending, networkingv1beta1.PublicKeyPhaseApplied); err != nil {
+		return err
+	}
+
+	// Switch to the next keys: the remote gateway drops the old public key once a handshake with the new one is completed.
+	if err := local.RunKeysRotationStep(ctx, secret, consts.RotateKeysCommit); err != nil {
+		return err
+	}
+	return remote.waiter.ForPublicKeyPhase(ctx, pk, networkingv1beta1.PublicKeyPhaseApplied)
+}
+
 func (o *Options) initNetworkConfigs(ctx context.Context, cluster1, cluster2 *Cluster) error {
 	// Forges the local Configuration of cluster 1 to be applied on remote clusters.
 	if err := cluster1.SetLocalConfiguration(ctx); err != nil {
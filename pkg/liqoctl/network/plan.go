@@ -0,0 +1,209 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/getters"
+	liqogetters "github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// planConnect collects the objects to be applied on the two clusters to connect them, without applying them.
+// The objects depending on the status of the gateways (i.e., the PublicKeys, and the GatewayClient if the endpoint of the
+// server is not provided) can be planned only once the gateways are running, hence they are recorded as notes otherwise.
+func (o *Options) planConnect(ctx context.Context, cluster1, cluster2 *Cluster) error {
+	// Forge the Configurations to be exchanged between the clusters.
+	if err := cluster1.SetLocalConfiguration(ctx); err != nil {
+		return err
+	}
+	if err := cluster2.SetLocalConfiguration(ctx); err != nil {
+		return err
+	}
+	if err := cluster1.PlanConfiguration(cluster2.networkConfiguration); err != nil {
+		return err
+	}
+	if err := cluster2.PlanConfiguration(cluster1.networkConfiguration); err != nil {
+		return err
+	}
+
+	if !o.SkipValidation {
+		if err := cluster2.CheckTemplateGwServer(ctx, o); err != nil {
+			return err
+		}
+		if err := cluster1.CheckTemplateGwClient(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	// Check if the reverse Networking is already established.
+	if established, err := cluster1.CheckAlreadyEstablishedForGwServer(ctx); err != nil || established {
+		return err
+	}
+	if established, err := cluster2.CheckAlreadyEstablishedForGwClient(ctx); err != nil || established {
+		return err
+	}
+
+	gwServer, err := cluster2.PlanGatewayServer(ctx, o.newGatewayServerForgeOptions(o.RemoteFactory.KubeClient, cluster1.localClusterID))
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := o.planServerEndpoint(gwServer)
+	if err != nil {
+		return err
+	}
+	gwClient, err := cluster1.PlanGatewayClient(ctx, o.newGatewayClientForgeOptions(o.LocalFactory.KubeClient, cluster2.localClusterID, endpoint))
+	if err != nil {
+		return err
+	}
+
+	if o.DisableSharingKeys {
+		return nil
+	}
+
+	if gwServer.Status.SecretRef == nil || gwClient.Status.SecretRef == nil {
+		o.Plan.AddNote("The PublicKeys are forged from the keys generated by the gateways once they are running: " +
+			"generate the plan again after applying the gateways on both clusters")
+		return nil
+	}
+
+	keyServer, err := getters.ExtractKeyFromSecretRef(ctx, cluster2.local.CRClient, gwServer.Status.SecretRef)
+	if err != nil {
+		return err
+	}
+	if err := cluster1.PlanPublicKey(ctx, cluster2.localClusterID, keyServer); err != nil {
+		return err
+	}
+
+	keyClient, err := getters.ExtractKeyFromSecretRef(ctx, cluster1.local.CRClient, gwClient.Status.SecretRef)
+	if err != nil {
+		return err
+	}
+	return cluster2.PlanPublicKey(ctx, cluster1.localClusterID, keyClient)
+}
+
+// planServerEndpoint returns the endpoint the gateway client connects to. Unless explicitly provided, it is taken from
+// the status of the existing gateway server, if any, and from the port of the service exposing it otherwise.
+func (o *Options) planServerEndpoint(gwServer *networkingv1beta1.GatewayServer) (*networkingv1beta1.EndpointStatus, error) {
+	endpoint := &networkingv1beta1.EndpointStatus{Protocol: ptr.To(corev1.Protocol(forge.DefaultProtocol))}
+	if gwServer.Status.Endpoint != nil {
+		endpoint = gwServer.Status.Endpoint.DeepCopy()
+	} else {
+		endpoint.Port = o.ServerServicePort
+		if corev1.ServiceType(o.ServerServiceType.Value) == corev1.ServiceTypeNodePort && o.ServerServiceNodePort != 0 {
+			endpoint.Port = o.ServerServiceNodePort
+		}
+	}
+
+	if o.ClientConnectAddress != "" {
+		endpoint.Addresses = []string{o.ClientConnectAddress}
+	}
+	if o.ClientConnectPort != 0 {
+		endpoint.Port = o.ClientConnectPort
+	}
+
+	if len(endpoint.Addresses) == 0 {
+		return nil, fmt.Errorf("the address of the gateway server is known only once it is exposed: " +
+			"specify the address the gateway client connects to through the --gw-client-address flag")
+	}
+	if endpoint.Protocol == nil {
+		endpoint.Protocol = ptr.To(corev1.Protocol(forge.DefaultProtocol))
+	}
+	return endpoint, nil
+}
+
+// PlanConfiguration adds to the plan the Configuration of the remote cluster.
+func (c *Cluster) PlanConfiguration(conf *networkingv1beta1.Configuration) error {
+	conf = conf.DeepCopy()
+	conf.Namespace = c.localNetworkNamespace
+	return c.plan.Add(c.localClusterID, conf)
+}
+
+// PlanGatewayServer adds to the plan the GatewayServer connecting to the remote cluster.
+// The returned GatewayServer carries the status of the existing one, if any, so that it can be leveraged.
+func (c *Cluster) PlanGatewayServer(ctx context.Context, opts *forge.GwServerOptions) (*networkingv1beta1.GatewayServer, error) {
+	var name *string
+	var status networkingv1beta1.GatewayServerStatus
+	existing, err := liqogetters.GetGatewayServerByClusterID(ctx, c.local.CRClient, c.remoteClusterID, c.localNetworkNamespace)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return nil, err
+	case err == nil:
+		name, status = &existing.Name, existing.Status
+	}
+
+	gwServer, err := forge.GatewayServer(c.localNetworkNamespace, name, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to forge the gateway server: %w", err)
+	}
+	if err := c.plan.Add(c.localClusterID, gwServer); err != nil {
+		return nil, err
+	}
+
+	gwServer.Status = status
+	return gwServer, nil
+}
+
+// PlanGatewayClient adds to the plan the GatewayClient connecting to the remote cluster.
+// The returned GatewayClient carries the status of the existing one, if any, so that it can be leveraged.
+func (c *Cluster) PlanGatewayClient(ctx context.Context, opts *forge.GwClientOptions) (*networkingv1beta1.GatewayClient, error) {
+	var name *string
+	var status networkingv1beta1.GatewayClientStatus
+	existing, err := liqogetters.GetGatewayClientByClusterID(ctx, c.local.CRClient, c.remoteClusterID, c.localNetworkNamespace)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return nil, err
+	case err == nil:
+		name, status = &existing.Name, existing.Status
+	}
+
+	gwClient, err := forge.GatewayClient(c.localNetworkNamespace, name, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to forge the gateway client: %w", err)
+	}
+	if err := c.plan.Add(c.localClusterID, gwClient); err != nil {
+		return nil, err
+	}
+
+	gwClient.Status = status
+	return gwClient, nil
+}
+
+// PlanPublicKey adds to the plan the PublicKey of the gateway of the remote cluster.
+func (c *Cluster) PlanPublicKey(ctx context.Context, remoteClusterID liqov1beta1.ClusterID, key []byte) error {
+	var name *string
+	pk, err := liqogetters.GetPublicKeyByClusterID(ctx, c.local.CRClient, remoteClusterID, c.localNetworkNamespace)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return err
+	case err == nil:
+		name = &pk.Name
+	}
+
+	pubKey, err := forge.PublicKey(c.localNetworkNamespace, name, remoteClusterID, key)
+	if err != nil {
+		return fmt.Errorf("unable to forge the public key: %w", err)
+	}
+	return c.plan.Add(c.localClusterID, pubKey)
+}
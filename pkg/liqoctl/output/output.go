@@ -100,6 +100,8 @@ type Printer struct {
 	Logger     *pterm.Logger
 	Table      *pterm.TablePrinter
 	verbose    bool
+	// embedded is true when the printer is used outside of the CLI, hence errors must not terminate the process.
+	embedded bool
 }
 
 // SpinnerRunningWarning prints a warning message while a spinner is running.
@@ -204,6 +206,11 @@ func (p *Printer) CheckErr(err error) {
 	case err == nil:
 		return
 
+	// Only print the error in case the printer is embedded, to let the caller handle it.
+	case p != nil && p.embedded:
+		p.Error.Println(err)
+		return
+
 	// Print the error through the spinner, if specified.
 	case p != nil && p.spinner.IsActive:
 		util.BehaviorOnFatal(func(msg string, code int) {
@@ -336,6 +343,23 @@ func newPrinter(scope string, color pterm.Color, scoped, verbose bool) *Printer
 	return printer
 }
 
+// NewWriterPrinter returns a new printer referring to the given scope, which outputs all messages to the given writer.
+// It is meant to run the liqoctl logic outside of a terminal (e.g., within a controller), hence CheckErr does not exit.
+func NewWriterPrinter(scope string, writer io.Writer) *Printer {
+	printer := newPrinter(scope, pterm.FgDefault, true, false)
+	printer.Info.Writer = writer
+	printer.Success.Writer = writer
+	printer.Warning.Writer = writer
+	printer.Error.Writer = writer
+	printer.BulletList.Writer = writer
+	printer.Section.Writer = writer
+	printer.box.Writer = writer
+	printer.spinner.Writer = writer
+	printer.Logger = printer.Logger.WithWriter(writer)
+	printer.embedded = true
+	return printer
+}
+
 // NewFakePrinter returns a new printer to be used in tests.
 func NewFakePrinter(writer io.Writer) *Printer {
 	printer := newPrinter("fake", pterm.FgBlack, true, true)
@@ -0,0 +1,682 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/network"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/unpeer"
+	liqoutils "github.com/liqotech/liqo/pkg/utils"
+	fcutils "github.com/liqotech/liqo/pkg/utils/foreigncluster"
+	"github.com/liqotech/liqo/pkg/utils/restcfg"
+)
+
+// FleetOperation is the operation to be performed on a peering of the fleet.
+type FleetOperation string
+
+const (
+	// FleetOperationPeer means the peering must be established.
+	FleetOperationPeer FleetOperation = "peer"
+	// FleetOperationUnpeer means the peering must be torn down, as no longer listed in the inventory.
+	FleetOperationUnpeer FleetOperation = "unpeer"
+	// FleetOperationUpdate means the peering is established, but its configuration differs from the inventory.
+	FleetOperationUpdate FleetOperation = "update"
+	// FleetOperationNone means the peering is already established, as described by the inventory.
+	FleetOperationNone FleetOperation = "none"
+	// FleetOperationSkip means the peering is no longer listed in the inventory, but pruning is disabled.
+	FleetOperationSkip FleetOperation = "skip"
+)
+
+// FleetAction is an operation to be performed on a peering of the fleet.
+type FleetAction struct {
+	Peering   InventoryPeering
+	Operation FleetOperation
+	// Changes describes how the peering differs from the inventory, for the update operations.
+	Changes string
+	// ResetNetwork means the inter-cluster network must be torn down before updating the peering,
+	// as the gateways are to be moved or removed.
+	ResetNetwork bool
+}
+
+// PeeringDrift describes how an established peering differs from the inventory.
+type PeeringDrift struct {
+	Changes      []string
+	ResetNetwork bool
+}
+
+// PeeringState is the current configuration of an established peering, as observed on the two clusters.
+type PeeringState struct {
+	// ResourceSlice is the ResourceSlice of the consumer towards the provider, if any.
+	ResourceSlice *authv1beta1.ResourceSlice
+	// ConsumerGatewayServer and ConsumerGatewayClient are the gateways of the consumer towards the provider, if any.
+	ConsumerGatewayServer *networkingv1beta1.GatewayServer
+	ConsumerGatewayClient *networkingv1beta1.GatewayClient
+	// ProviderGatewayServer and ProviderGatewayClient are the gateways of the provider towards the consumer, if any.
+	ProviderGatewayServer *networkingv1beta1.GatewayServer
+	ProviderGatewayClient *networkingv1beta1.GatewayClient
+	// SharedNetwork means the network is also required by the reverse peering, hence it is neither moved nor removed.
+	SharedNetwork bool
+}
+
+// FleetOptions encapsulates the arguments to reconcile the peerings of a fleet of clusters towards an inventory.
+type FleetOptions struct {
+	Printer   *output.Printer
+	Inventory *Inventory
+
+	Timeout        time.Duration
+	SkipValidation bool
+	Concurrency    int
+	Retries        int
+	RetryDelay     time.Duration
+	Prune          bool
+}
+
+// fleetCluster is a cluster of the inventory, along with its current state.
+type fleetCluster struct {
+	name          string
+	config        *rest.Config
+	liqoNamespace string
+	clusterID     liqov1beta1.ClusterID
+	// providers are the clusters the cluster is currently consuming resources from.
+	providers []liqov1beta1.ClusterID
+
+	// resourceSlices, gatewayServers and gatewayClients are the ones towards each remote cluster.
+	resourceSlices map[liqov1beta1.ClusterID]*authv1beta1.ResourceSlice
+	gatewayServers map[liqov1beta1.ClusterID]*networkingv1beta1.GatewayServer
+	gatewayClients map[liqov1beta1.ClusterID]*networkingv1beta1.GatewayClient
+}
+
+// fleetResult is the outcome of a fleet action.
+type fleetResult struct {
+	action   FleetAction
+	attempts int
+	err      error
+}
+
+// RunFleet reconciles the peerings among the clusters of the inventory, establishing the missing ones and,
+// if pruning is enabled, tearing down the ones no longer listed. It prints a summary of the performed operations.
+func (o *FleetOptions) RunFleet(ctx context.Context) error {
+	clusters, err := o.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, unmanaged := existingPeerings(o.Inventory, clusters)
+	for _, peering := range unmanaged {
+		o.Printer.Warning.Printfln("Cluster %q is peered with cluster %q, which is not listed in the inventory: skipping it",
+			peering.Consumer, peering.Provider)
+	}
+
+	desired := o.Inventory.DesiredPeerings()
+	drifts, err := o.drifts(clusters, desired, existing)
+	if err != nil {
+		return err
+	}
+
+	actions := ComputeFleetActions(desired, existing, drifts, o.Prune)
+	results := o.run(ctx, clusters, actions)
+	return o.summarize(results)
+}
+
+// connect initializes the access to the clusters of the inventory, and retrieves their current peerings.
+func (o *FleetOptions) connect(ctx context.Context) (map[string]*fleetCluster, error) {
+	s := o.Printer.StartSpinner(fmt.Sprintf("Retrieving the status of %d clusters", len(o.Inventory.Clusters)))
+
+	clusters := make(map[string]*fleetCluster, len(o.Inventory.Clusters))
+	names := map[liqov1beta1.ClusterID]string{}
+	for i := range o.Inventory.Clusters {
+		cluster, err := newFleetCluster(ctx, &o.Inventory.Clusters[i])
+		if err != nil {
+			s.Fail(fmt.Sprintf("Unable to access cluster %q: %v", o.Inventory.Clusters[i].Name, output.PrettyErr(err)))
+			return nil, err
+		}
+
+		if other, found := names[cluster.clusterID]; found {
+			err := fmt.Errorf("clusters %q and %q refer to the same cluster (ID %q)", other, cluster.name, cluster.clusterID)
+			s.Fail(err.Error())
+			return nil, err
+		}
+		names[cluster.clusterID] = cluster.name
+		clusters[cluster.name] = cluster
+	}
+
+	s.Success(fmt.Sprintf("Status of %d clusters retrieved", len(clusters)))
+	return clusters, nil
+}
+
+func newFleetCluster(ctx context.Context, cluster *InventoryCluster) (*fleetCluster, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = cluster.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: cluster.Context}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the kubeconfig: %w", err)
+	}
+	restcfg.SetRateLimiter(config)
+
+	fc := &fleetCluster{name: cluster.Name, config: config, liqoNamespace: cluster.LiqoNamespace}
+	if fc.liqoNamespace == "" {
+		fc.liqoNamespace = consts.DefaultLiqoNamespace
+	}
+
+	f, err := fc.factory(false, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	if fc.clusterID, err = liqoutils.GetClusterIDWithControllerClient(ctx, f.CRClient, fc.liqoNamespace); err != nil {
+		return nil, fmt.Errorf("unable to retrieve the cluster ID: %w", err)
+	}
+
+	var foreignClusters liqov1beta1.ForeignClusterList
+	if err := f.CRClient.List(ctx, &foreignClusters); err != nil {
+		return nil, fmt.Errorf("unable to list the foreign clusters: %w", err)
+	}
+	for i := range foreignClusters.Items {
+		if fcutils.IsProvider(foreignClusters.Items[i].Status.Role) {
+			fc.providers = append(fc.providers, foreignClusters.Items[i].Spec.ClusterID)
+		}
+	}
+
+	if err := fc.retrievePeeringResources(ctx, f.CRClient); err != nil {
+		return nil, err
+	}
+	return fc, nil
+}
+
+// retrievePeeringResources retrieves the resources configuring the peerings with the other clusters,
+// to compare them with the ones described by the inventory.
+func (fc *fleetCluster) retrievePeeringResources(ctx context.Context, cl client.Client) error {
+	fc.resourceSlices = map[liqov1beta1.ClusterID]*authv1beta1.ResourceSlice{}
+	fc.gatewayServers = map[liqov1beta1.ClusterID]*networkingv1beta1.GatewayServer{}
+	fc.gatewayClients = map[liqov1beta1.ClusterID]*networkingv1beta1.GatewayClient{}
+
+	// The ResourceSlices created by the peer command are named after the provider cluster.
+	var resourceSlices authv1beta1.ResourceSliceList
+	if err := cl.List(ctx, &resourceSlices, client.MatchingLabels{
+		consts.ReplicationRequestedLabel: consts.ReplicationRequestedLabelValue}); err != nil {
+		return fmt.Errorf("unable to list the resource slices: %w", err)
+	}
+	for i := range resourceSlices.Items {
+		rs := &resourceSlices.Items[i]
+		if rs.Name == rs.Labels[consts.RemoteClusterID] {
+			fc.resourceSlices[liqov1beta1.ClusterID(rs.Name)] = rs
+		}
+	}
+
+	var gatewayServers networkingv1beta1.GatewayServerList
+	if err := cl.List(ctx, &gatewayServers, client.HasLabels{consts.RemoteClusterID}); err != nil {
+		return fmt.Errorf("unable to list the gateway servers: %w", err)
+	}
+	for i := range gatewayServers.Items {
+		fc.gatewayServers[liqov1beta1.ClusterID(gatewayServers.Items[i].Labels[consts.RemoteClusterID])] = &gatewayServers.Items[i]
+	}
+
+	var gatewayClients networkingv1beta1.GatewayClientList
+	if err := cl.List(ctx, &gatewayClients, client.HasLabels{consts.RemoteClusterID}); err != nil {
+		return fmt.Errorf("unable to list the gateway clients: %w", err)
+	}
+	for i := range gatewayClients.Items {
+		fc.gatewayClients[liqov1beta1.ClusterID(gatewayClients.Items[i].Labels[consts.RemoteClusterID])] = &gatewayClients.Items[i]
+	}
+	return nil
+}
+
+// factory returns a new factory to interact with the cluster. A new factory is created for each operation,
+// since the liqoctl logic is not meant to be run concurrently on the same factory.
+func (fc *fleetCluster) factory(remote bool, writer io.Writer) (*factory.Factory, error) {
+	f, err := factory.NewForRESTConfig(fc.config, scheme.Scheme, fc.liqoNamespace, remote, writer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the clients for cluster %q: %w", fc.name, err)
+	}
+	return f, nil
+}
+
+// existingPeerings returns the peerings currently established among the clusters of the inventory,
+// as well as the ones towards clusters which are not listed in the inventory.
+func existingPeerings(inventory *Inventory, clusters map[string]*fleetCluster) (existing sets.Set[InventoryPeering], unmanaged []InventoryPeering) {
+	names := make(map[liqov1beta1.ClusterID]string, len(clusters))
+	for _, cluster := range clusters {
+		names[cluster.clusterID] = cluster.name
+	}
+
+	existing = sets.New[InventoryPeering]()
+	for i := range inventory.Clusters {
+		cluster := clusters[inventory.Clusters[i].Name]
+		for _, provider := range cluster.providers {
+			name, found := names[provider]
+			if !found {
+				unmanaged = append(unmanaged, InventoryPeering{Consumer: cluster.name, Provider: string(provider)})
+				continue
+			}
+			existing.Insert(InventoryPeering{Consumer: cluster.name, Provider: name})
+		}
+	}
+	return existing, unmanaged
+}
+
+// drifts compares the established peerings which are still desired with the inventory.
+func (o *FleetOptions) drifts(clusters map[string]*fleetCluster, desired []InventoryPeering,
+	existing sets.Set[InventoryPeering]) (map[InventoryPeering]PeeringDrift, error) {
+	desiredSet := sets.New(desired...)
+	drifts := map[InventoryPeering]PeeringDrift{}
+	for _, peering := range desired {
+		if !existing.Has(peering) {
+			continue
+		}
+
+		consumer, provider := clusters[peering.Consumer], clusters[peering.Provider]
+		reverse := InventoryPeering{Consumer: peering.Provider, Provider: peering.Consumer}
+		state := &PeeringState{
+			ResourceSlice:         consumer.resourceSlices[provider.clusterID],
+			ConsumerGatewayServer: consumer.gatewayServers[provider.clusterID],
+			ConsumerGatewayClient: consumer.gatewayClients[provider.clusterID],
+			ProviderGatewayServer: provider.gatewayServers[consumer.clusterID],
+			ProviderGatewayClient: provider.gatewayClients[consumer.clusterID],
+			SharedNetwork:         desiredSet.Has(reverse) && !ptr.Deref(o.Inventory.PeeringOptions(reverse.Provider).NetworkingDisabled, false),
+		}
+
+		drift, err := ComputePeeringDrift(o.Inventory.PeeringOptions(peering.Provider), state)
+		if err != nil {
+			return nil, fmt.Errorf("peering %s: %w", peering, err)
+		}
+		drifts[peering] = drift
+	}
+	return drifts, nil
+}
+
+// ComputePeeringDrift returns the differences between the current state of an established peering and the desired options.
+// Only the differences which can be reconciled by the peer command are reported.
+func ComputePeeringDrift(desired *InventoryPeeringOptions, state *PeeringState) (PeeringDrift, error) {
+	var drift PeeringDrift
+	opts := NewOptions(nil)
+	if err := desired.apply(opts); err != nil {
+		return drift, err
+	}
+
+	hasGateways := state.ConsumerGatewayServer != nil || state.ConsumerGatewayClient != nil ||
+		state.ProviderGatewayServer != nil || state.ProviderGatewayClient != nil
+	switch {
+	case opts.NetworkingDisabled:
+		if hasGateways && !state.SharedNetwork {
+			drift.Changes = append(drift.Changes, "networking disabled")
+			drift.ResetNetwork = true
+		}
+	case !hasGateways:
+		drift.Changes = append(drift.Changes, "networking missing")
+	default:
+		drift.networkChanges(opts, state)
+	}
+
+	if opts.CreateResourceSlice {
+		drift.resourceSliceChanges(opts, state.ResourceSlice)
+	}
+	return drift, nil
+}
+
+// networkChanges compares the gateways connecting the two clusters with the desired options.
+func (d *PeeringDrift) networkChanges(opts *Options, state *PeeringState) {
+	server, wrongServer := state.ProviderGatewayServer, state.ConsumerGatewayServer
+	if opts.ServerServiceLocation.Value == string(liqov1beta1.ConsumerRole) {
+		server, wrongServer = state.ConsumerGatewayServer, state.ProviderGatewayServer
+	}
+
+	// The network shared with the reverse peering is kept where it has been established.
+	if wrongServer != nil && !state.SharedNetwork {
+		d.Changes = append(d.Changes, fmt.Sprintf("gateway server location (%s)", opts.ServerServiceLocation.Value))
+		d.ResetNetwork = true
+		return
+	}
+	if server == nil {
+		server = wrongServer
+	}
+
+	if server != nil {
+		if server.Spec.Endpoint.ServiceType != corev1.ServiceType(opts.ServerServiceType.Value) {
+			d.Changes = append(d.Changes, fmt.Sprintf("gateway server service type (%s)", opts.ServerServiceType.Value))
+		}
+		if server.Spec.Endpoint.Port != opts.ServerServicePort {
+			d.Changes = append(d.Changes, fmt.Sprintf("gateway server port (%d)", opts.ServerServicePort))
+		}
+	}
+
+	mtuChanged := server != nil && server.Spec.MTU != opts.MTU
+	for _, gwClient := range []*networkingv1beta1.GatewayClient{state.ConsumerGatewayClient, state.ProviderGatewayClient} {
+		mtuChanged = mtuChanged || (gwClient != nil && gwClient.Spec.MTU != opts.MTU)
+	}
+	if mtuChanged {
+		d.Changes = append(d.Changes, fmt.Sprintf("MTU (%d)", opts.MTU))
+	}
+}
+
+// resourceSliceChanges compares the ResourceSlice of the consumer with the desired options.
+func (d *PeeringDrift) resourceSliceChanges(opts *Options, rs *authv1beta1.ResourceSlice) {
+	if rs == nil {
+		d.Changes = append(d.Changes, "resource slice missing")
+		return
+	}
+
+	if rs.Spec.Class != authv1beta1.ResourceSliceClass(opts.ResourceSliceClass) {
+		d.Changes = append(d.Changes, fmt.Sprintf("resource slice class (%s)", opts.ResourceSliceClass))
+	}
+
+	desired := map[corev1.ResourceName]string{
+		corev1.ResourceCPU: opts.CPU, corev1.ResourceMemory: opts.Memory, corev1.ResourcePods: opts.Pods}
+	for name, quantity := range opts.OtherResources {
+		desired[corev1.ResourceName(name)] = quantity
+	}
+	var changed []string
+	for name := range sets.KeySet(desired).Union(sets.KeySet(rs.Spec.Resources)) {
+		current, found := rs.Spec.Resources[name]
+		if desired[name] == "" {
+			if found {
+				changed = append(changed, string(name))
+			}
+			continue
+		}
+		// The quantities have already been validated when parsing the inventory.
+		if !found || !current.Equal(resource.MustParse(desired[name])) {
+			changed = append(changed, string(name))
+		}
+	}
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		d.Changes = append(d.Changes, fmt.Sprintf("resources (%s)", strings.Join(changed, ", ")))
+	}
+
+	if opts.CreateVirtualNode && rs.Annotations[consts.CreateVirtualNodeAnnotation] != "true" {
+		d.Changes = append(d.Changes, "virtual node creation")
+	}
+}
+
+// ComputeFleetActions returns the operations required to converge from the existing to the desired peerings.
+// The established peerings differing from the inventory (according to drifts) are updated,
+// while the peerings no longer desired are torn down only if prune is set.
+func ComputeFleetActions(desired []InventoryPeering, existing sets.Set[InventoryPeering],
+	drifts map[InventoryPeering]PeeringDrift, prune bool) []FleetAction {
+	actions := make([]FleetAction, 0, len(desired))
+	for _, peering := range desired {
+		action := FleetAction{Peering: peering, Operation: FleetOperationPeer}
+		if existing.Has(peering) {
+			action.Operation = FleetOperationNone
+			if drift, found := drifts[peering]; found && len(drift.Changes) > 0 {
+				action.Operation = FleetOperationUpdate
+				action.Changes = strings.Join(drift.Changes, ", ")
+				action.ResetNetwork = drift.ResetNetwork
+			}
+		}
+		actions = append(actions, action)
+	}
+
+	stale := existing.Difference(sets.New(desired...)).UnsortedList()
+	sort.Slice(stale, func(i, j int) bool { return stale[i].String() < stale[j].String() })
+	for _, peering := range stale {
+		operation := FleetOperationSkip
+		if prune {
+			operation = FleetOperationUnpeer
+		}
+		actions = append(actions, FleetAction{Peering: peering, Operation: operation})
+	}
+	return actions
+}
+
+// GroupFleetActions groups the actions to be performed by pair of clusters. The actions concerning the same pair
+// must be performed sequentially, since the two peerings share the same network and tenant namespaces.
+// Within each group, the unpeerings precede the peerings.
+func GroupFleetActions(actions []FleetAction) [][]FleetAction {
+	var groups [][]FleetAction
+	indexes := map[[2]string]int{}
+	for _, action := range actions {
+		if action.Operation != FleetOperationPeer && action.Operation != FleetOperationUnpeer && action.Operation != FleetOperationUpdate {
+			continue
+		}
+
+		key := [2]string{action.Peering.Consumer, action.Peering.Provider}
+		if key[0] > key[1] {
+			key[0], key[1] = key[1], key[0]
+		}
+		index, found := indexes[key]
+		if !found {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], action)
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Operation == FleetOperationUnpeer && group[j].Operation != FleetOperationUnpeer
+		})
+	}
+	return groups
+}
+
+// run performs the given actions, with bounded concurrency. The groups of actions concerning the same cluster
+// are performed sequentially, since a cluster can appear in several pairs and liqoctl does not support
+// concurrent operations on the same cluster.
+func (o *FleetOptions) run(ctx context.Context, clusters map[string]*fleetCluster, actions []FleetAction) []fleetResult {
+	results := make([]fleetResult, len(actions))
+	positions := make(map[FleetAction]int, len(actions))
+	for i := range actions {
+		results[i] = fleetResult{action: actions[i]}
+		positions[actions[i]] = i
+	}
+
+	groups := GroupFleetActions(actions)
+	if len(groups) == 0 {
+		return results
+	}
+
+	total := 0
+	for _, group := range groups {
+		total += len(group)
+	}
+
+	var (
+		mutex     sync.Mutex
+		completed int
+		wg        sync.WaitGroup
+	)
+	s := o.Printer.StartSpinner(fmt.Sprintf("Reconciling the peerings (0/%d completed)", total))
+	semaphore := make(chan struct{}, max(o.Concurrency, 1))
+	locks := make(map[string]*sync.Mutex, len(clusters))
+	for name := range clusters {
+		locks[name] = &sync.Mutex{}
+	}
+
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group []FleetAction) {
+			defer wg.Done()
+
+			// The locks are always acquired in the same order, to prevent deadlocks.
+			names := []string{group[0].Peering.Consumer, group[0].Peering.Provider}
+			sort.Strings(names)
+			for _, name := range names {
+				locks[name].Lock()
+			}
+			defer func() {
+				for _, name := range names {
+					locks[name].Unlock()
+				}
+			}()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			for _, action := range group {
+				attempts, err := o.execute(ctx, action, clusters[action.Peering.Consumer], clusters[action.Peering.Provider])
+
+				mutex.Lock()
+				results[positions[action]].attempts = attempts
+				results[positions[action]].err = err
+				completed++
+				s.UpdateText(fmt.Sprintf("Reconciling the peerings (%d/%d completed)", completed, total))
+				mutex.Unlock()
+			}
+		}(group)
+	}
+	wg.Wait()
+
+	s.Success(fmt.Sprintf("%d peering operations completed", total))
+	return results
+}
+
+// execute performs the given action, retrying it in case of failure.
+func (o *FleetOptions) execute(ctx context.Context, action FleetAction, consumer, provider *fleetCluster) (attempts int, err error) {
+	for attempts = 1; ; attempts++ {
+		if err = o.executeOnce(ctx, action, consumer, provider); err == nil || attempts > o.Retries {
+			return attempts, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(o.RetryDelay):
+		}
+	}
+}
+
+func (o *FleetOptions) executeOnce(ctx context.Context, action FleetAction, consumer, provider *fleetCluster) error {
+	// The output of the single operations is discarded, as the outcome is reported in the summary.
+	localFactory, err := consumer.factory(false, io.Discard)
+	if err != nil {
+		return err
+	}
+	remoteFactory, err := provider.factory(true, io.Discard)
+	if err != nil {
+		return err
+	}
+
+	switch action.Operation {
+	case FleetOperationPeer:
+		return o.peer(ctx, localFactory, remoteFactory, provider)
+	case FleetOperationUpdate:
+		if action.ResetNetwork {
+			opts := network.NewOptions(localFactory)
+			opts.RemoteFactory = remoteFactory
+			opts.Timeout = o.Timeout
+			opts.Wait = true
+			if err := opts.RunReset(ctx); err != nil {
+				return fmt.Errorf("unable to reset the network: %w", err)
+			}
+		}
+		// The peer command creates or updates the resources, converging them to the inventory.
+		return o.peer(ctx, localFactory, remoteFactory, provider)
+	case FleetOperationUnpeer:
+		opts := unpeer.NewOptions(localFactory)
+		opts.RemoteFactory = remoteFactory
+		opts.Timeout = o.Timeout
+		opts.Wait = true
+		return opts.RunUnpeer(ctx)
+	default:
+		return fmt.Errorf("unsupported operation %q", action.Operation)
+	}
+}
+
+// peer establishes the peering with the given provider, according to the options of the inventory.
+func (o *FleetOptions) peer(ctx context.Context, localFactory, remoteFactory *factory.Factory, provider *fleetCluster) error {
+	opts := NewOptions(localFactory)
+	opts.RemoteFactory = remoteFactory
+	opts.Timeout = o.Timeout
+	opts.SkipValidation = o.SkipValidation
+	if err := o.Inventory.PeeringOptions(provider.name).apply(opts); err != nil {
+		return err
+	}
+	return opts.RunPeer(ctx)
+}
+
+// summarize prints the outcome of the fleet actions, and returns an error if any of them failed.
+func (o *FleetOptions) summarize(results []fleetResult) error {
+	data := pterm.TableData{{"Consumer", "Provider", "Operation", "Result", "Attempts"}}
+	failed := 0
+	for i := range results {
+		res := &results[i]
+		attempts := "-"
+		if res.attempts > 0 {
+			attempts = strconv.Itoa(res.attempts)
+		}
+		data = append(data, []string{res.action.Peering.Consumer, res.action.Peering.Provider,
+			string(res.action.Operation), res.outcome(), attempts})
+		if res.err != nil {
+			failed++
+		}
+	}
+
+	if len(results) == 0 {
+		o.Printer.Info.Println("No peerings listed in the inventory")
+		return nil
+	}
+	if err := o.Printer.Table.WithData(data).Render(); err != nil {
+		return err
+	}
+
+	for i := range results {
+		if results[i].action.Operation == FleetOperationUpdate {
+			o.Printer.Info.Printfln("Peering %s differed from the inventory: %s", results[i].action.Peering, results[i].action.Changes)
+		}
+	}
+	for i := range results {
+		if results[i].err != nil {
+			o.Printer.Error.Printfln("Peering %s: %v", results[i].action.Peering, output.PrettyErr(results[i].err))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d out of %d peering operations failed", failed, len(results))
+	}
+	return nil
+}
+
+// outcome returns a human-readable description of the result of the action.
+func (r *fleetResult) outcome() string {
+	switch {
+	case r.err != nil:
+		return "Failed"
+	case r.action.Operation == FleetOperationPeer:
+		return "Peered"
+	case r.action.Operation == FleetOperationUnpeer:
+		return "Unpeered"
+	case r.action.Operation == FleetOperationUpdate:
+		return "Updated"
+	case r.action.Operation == FleetOperationSkip:
+		return "Not listed (pruning disabled)"
+	default:
+		return "Up-to-date"
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	authforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/forge"
+	nwforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+)
+
+var _ = Describe("Fleet", func() {
+	var (
+		ab = InventoryPeering{Consumer: "a", Provider: "b"}
+		ba = InventoryPeering{Consumer: "b", Provider: "a"}
+		ac = InventoryPeering{Consumer: "a", Provider: "c"}
+		cb = InventoryPeering{Consumer: "c", Provider: "b"}
+	)
+
+	Describe("computing the actions", func() {
+		It("should peer the missing peerings and skip the established ones", func() {
+			actions := ComputeFleetActions([]InventoryPeering{ab, ac}, sets.New(ab), nil, false)
+			Expect(actions).To(Equal([]FleetAction{
+				{Peering: ab, Operation: FleetOperationNone},
+				{Peering: ac, Operation: FleetOperationPeer},
+			}))
+		})
+
+		It("should update the established peerings differing from the inventory", func() {
+			drifts := map[InventoryPeering]PeeringDrift{
+				ab: {Changes: []string{"resources (cpu)", "MTU (1340)"}},
+				ac: {},
+			}
+			actions := ComputeFleetActions([]InventoryPeering{ab, ac}, sets.New(ab, ac), drifts, false)
+			Expect(actions).To(Equal([]FleetAction{
+				{Peering: ab, Operation: FleetOperationUpdate, Changes: "resources (cpu), MTU (1340)"},
+				{Peering: ac, Operation: FleetOperationNone},
+			}))
+		})
+
+		It("should not tear down the peerings no longer desired if pruning is disabled", func() {
+			actions := ComputeFleetActions([]InventoryPeering{ab}, sets.New(ab, cb), nil, false)
+			Expect(actions).To(ContainElement(FleetAction{Peering: cb, Operation: FleetOperationSkip}))
+		})
+
+		It("should tear down the peerings no longer desired if pruning is enabled", func() {
+			actions := ComputeFleetActions([]InventoryPeering{ab}, sets.New(ab, cb), nil, true)
+			Expect(actions).To(ContainElement(FleetAction{Peering: cb, Operation: FleetOperationUnpeer}))
+		})
+	})
+
+	Describe("computing the drift of an established peering", func() {
+		var (
+			state   *PeeringState
+			desired *InventoryPeeringOptions
+		)
+
+		BeforeEach(func() {
+			desired = &InventoryPeeringOptions{Resources: map[corev1.ResourceName]string{corev1.ResourceCPU: "4"}}
+			rs := authforge.ResourceSlice("id-b", "tenant")
+			Expect(authforge.MutateResourceSlice(rs, "id-b", &authforge.ResourceSliceOptions{
+				Class: defaultResourceSliceClass, Resources: map[corev1.ResourceName]string{corev1.ResourceCPU: "4000m"}}, true)).To(Succeed())
+
+			state = &PeeringState{
+				ResourceSlice: rs,
+				ProviderGatewayServer: &networkingv1beta1.GatewayServer{Spec: networkingv1beta1.GatewayServerSpec{
+					MTU: nwforge.DefaultMTU,
+					Endpoint: networkingv1beta1.Endpoint{
+						ServiceType: nwforge.DefaultGwServerServiceType, Port: nwforge.DefaultGwServerPort},
+				}},
+				ConsumerGatewayClient: &networkingv1beta1.GatewayClient{Spec: networkingv1beta1.GatewayClientSpec{MTU: nwforge.DefaultMTU}},
+			}
+		})
+
+		It("should report no changes if the peering matches the inventory", func() {
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift).To(Equal(PeeringDrift{}))
+		})
+
+		It("should report the changed resources", func() {
+			desired.Resources = map[corev1.ResourceName]string{corev1.ResourceCPU: "8", corev1.ResourceMemory: "16Gi"}
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift.Changes).To(ConsistOf("resources (cpu, memory)"))
+			Expect(drift.ResetNetwork).To(BeFalse())
+		})
+
+		It("should report the changed network options", func() {
+			desired.MTU = 1400
+			desired.GatewayServerServiceType = string(corev1.ServiceTypeNodePort)
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift.Changes).To(ConsistOf("gateway server service type (NodePort)", "MTU (1400)"))
+			Expect(drift.ResetNetwork).To(BeFalse())
+		})
+
+		It("should reset the network to move the gateway server", func() {
+			desired.GatewayServerLocation = string(liqov1beta1.ConsumerRole)
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift.Changes).To(ConsistOf(ContainSubstring("gateway server location")))
+			Expect(drift.ResetNetwork).To(BeTrue())
+		})
+
+		It("should keep the network shared with the reverse peering where it has been established", func() {
+			desired.GatewayServerLocation = string(liqov1beta1.ConsumerRole)
+			state.SharedNetwork = true
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift).To(Equal(PeeringDrift{}))
+		})
+
+		It("should reset the network if networking is disabled", func() {
+			desired.NetworkingDisabled = ptr.To(true)
+			drift, err := ComputePeeringDrift(desired, state)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift).To(Equal(PeeringDrift{Changes: []string{"networking disabled"}, ResetNetwork: true}))
+		})
+	})
+
+	Describe("grouping the actions", func() {
+		It("should group the actions concerning the same pair of clusters, unpeering first", func() {
+			groups := GroupFleetActions([]FleetAction{
+				{Peering: ab, Operation: FleetOperationPeer},
+				{Peering: ac, Operation: FleetOperationNone},
+				{Peering: cb, Operation: FleetOperationPeer},
+				{Peering: ba, Operation: FleetOperationUnpeer},
+				{Peering: ac, Operation: FleetOperationUpdate},
+			})
+			Expect(groups).To(Equal([][]FleetAction{
+				{{Peering: ba, Operation: FleetOperationUnpeer}, {Peering: ab, Operation: FleetOperationPeer}},
+				{{Peering: cb, Operation: FleetOperationPeer}},
+				{{Peering: ac, Operation: FleetOperationUpdate}},
+			}))
+		})
+	})
+
+	Describe("retrieving the existing peerings", func() {
+		It("should distinguish the peerings among the clusters of the inventory from the other ones", func() {
+			inventory := &Inventory{Clusters: []InventoryCluster{{Name: "a"}, {Name: "b"}}}
+			clusters := map[string]*fleetCluster{
+				"a": {name: "a", clusterID: "id-a", providers: []liqov1beta1.ClusterID{"id-b", "id-x"}},
+				"b": {name: "b", clusterID: "id-b"},
+			}
+
+			existing, unmanaged := existingPeerings(inventory, clusters)
+			Expect(existing.UnsortedList()).To(ConsistOf(ab))
+			Expect(unmanaged).To(ConsistOf(InventoryPeering{Consumer: "a", Provider: "id-x"}))
+		})
+	})
+})
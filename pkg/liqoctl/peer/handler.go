@@ -25,6 +25,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqoctl/authenticate"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/network"
+	"github.com/liqotech/liqo/pkg/liqoctl/plan"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/resourceslice"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
@@ -62,6 +63,9 @@ type Options struct {
 	Memory            string
 	Pods              string
 	OtherResources    map[string]string
+
+	// Plan, if set, collects the objects to be applied on each cluster, instead of applying them.
+	Plan *plan.Plan
 }
 
 // NewOptions returns a new Options struct.
@@ -91,21 +95,21 @@ func (o *Options) RunPeer(ctx context.Context) error {
 
 	// Ensure networking
 	if !o.NetworkingDisabled {
-		if err := ensureNetworking(ctx, o); err != nil {
+		if err := EnsureNetworking(ctx, o); err != nil {
 			o.LocalFactory.PrinterGlobal.Error.Printfln("Unable to ensure networking: %v", err)
 			return err
 		}
 	}
 
 	// Ensure authentication
-	if err := ensureAuthentication(ctx, o); err != nil {
+	if err := EnsureAuthentication(ctx, o); err != nil {
 		o.LocalFactory.PrinterGlobal.Error.Printfln("Unable to ensure authentication: %v", err)
 		return err
 	}
 
 	// Ensure offloading
 	if o.CreateResourceSlice {
-		if err := ensureOffloading(ctx, o); err != nil {
+		if err := EnsureOffloading(ctx, o); err != nil {
 			o.LocalFactory.PrinterGlobal.Error.Printfln("Unable to ensure offloading: %v", err)
 			return err
 		}
@@ -114,7 +118,8 @@ func (o *Options) RunPeer(ctx context.Context) error {
 	return nil
 }
 
-func ensureNetworking(ctx context.Context, o *Options) error {
+// EnsureNetworking establishes the inter-cluster network between the local and the remote cluster.
+func EnsureNetworking(ctx context.Context, o *Options) error {
 	localFactory := o.LocalFactory
 	remoteFactory := o.RemoteFactory
 
@@ -148,6 +153,8 @@ func ensureNetworking(ctx context.Context, o *Options) error {
 
 		MTU:                o.MTU,
 		DisableSharingKeys: false,
+
+		Plan: o.Plan,
 	}
 
 	if err := networkOptions.RunConnect(ctx); err != nil {
@@ -157,7 +164,8 @@ func ensureNetworking(ctx context.Context, o *Options) error {
 	return nil
 }
 
-func ensureAuthentication(ctx context.Context, o *Options) error {
+// EnsureAuthentication establishes the authentication between the local (consumer) and the remote (provider) cluster.
+func EnsureAuthentication(ctx context.Context, o *Options) error {
 	authOptions := authenticate.Options{
 		LocalFactory:  o.LocalFactory,
 		RemoteFactory: o.RemoteFactory,
@@ -165,6 +173,8 @@ func ensureAuthentication(ctx context.Context, o *Options) error {
 
 		InBand:   o.InBand,
 		ProxyURL: o.ProxyURL,
+
+		Plan: o.Plan,
 	}
 
 	if err := authOptions.RunAuthenticate(ctx); err != nil {
@@ -174,7 +184,8 @@ func ensureAuthentication(ctx context.Context, o *Options) error {
 	return nil
 }
 
-func ensureOffloading(ctx context.Context, o *Options) error {
+// EnsureOffloading creates a ResourceSlice towards the remote (provider) cluster.
+func EnsureOffloading(ctx context.Context, o *Options) error {
 	providerClusterID, err := liqoutils.GetClusterIDWithControllerClient(ctx, o.RemoteFactory.CRClient, o.RemoteFactory.LiqoNamespace)
 	if err != nil {
 		return err
@@ -202,9 +213,40 @@ func ensureOffloading(ctx context.Context, o *Options) error {
 		OtherResources: o.OtherResources,
 	}
 
+	if o.Plan != nil {
+		return planOffloading(ctx, o, &rsOptions)
+	}
+
 	if err := rsOptions.HandleCreate(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// planOffloading adds to the plan the ResourceSlice towards the remote (provider) cluster.
+func planOffloading(ctx context.Context, o *Options, rsOptions *resourceslice.Options) error {
+	consumerClusterID, err := liqoutils.GetClusterIDWithControllerClient(ctx, o.LocalFactory.CRClient, o.LocalFactory.LiqoNamespace)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := o.Plan.TenantNamespace(ctx, rsOptions.NamespaceManager, consumerClusterID, rsOptions.RemoteClusterID.GetClusterID())
+	if err != nil {
+		return err
+	}
+
+	resourceSlice, err := rsOptions.Forge(namespace)
+	if err != nil {
+		return err
+	}
+	if err := o.Plan.Add(consumerClusterID, resourceSlice); err != nil {
+		return err
+	}
+
+	if o.CreateVirtualNode {
+		o.Plan.AddNote("The VirtualNode is created by cluster %q once the ResourceSlice is accepted by cluster %q",
+			consumerClusterID, rsOptions.RemoteClusterID.GetClusterID())
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	nwforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+)
+
+// InventoryRole is the role a cluster of the inventory plays in the peerings.
+type InventoryRole string
+
+const (
+	// InventoryRoleConsumer identifies the clusters peering with all the providers of the inventory.
+	InventoryRoleConsumer InventoryRole = "consumer"
+	// InventoryRoleProvider identifies the clusters sharing their resources with all the consumers of the inventory.
+	InventoryRoleProvider InventoryRole = "provider"
+
+	defaultResourceSliceClass = "default"
+)
+
+// Inventory describes the desired peerings among a fleet of clusters.
+// Each cluster with the consumer role peers with every cluster with the provider role (other than itself),
+// unless its providers are explicitly listed.
+type Inventory struct {
+	// Defaults are the peering options applied to all peerings, unless overridden by the provider cluster.
+	Defaults InventoryPeeringOptions `json:"defaults,omitempty"`
+	// Clusters are the clusters of the fleet.
+	Clusters []InventoryCluster `json:"clusters"`
+}
+
+// InventoryCluster describes a cluster of the inventory.
+type InventoryCluster struct {
+	// Name is the name identifying the cluster within the inventory.
+	Name string `json:"name"`
+	// Kubeconfig is the path of the kubeconfig to access the cluster, relative to the inventory file.
+	// Defaults to the standard kubeconfig loading rules.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Context is the kubeconfig context to access the cluster. Defaults to the current context.
+	Context string `json:"context,omitempty"`
+	// LiqoNamespace is the namespace where Liqo is installed. Defaults to the liqo namespace.
+	LiqoNamespace string `json:"liqoNamespace,omitempty"`
+	// Roles are the roles the cluster plays in the peerings. A cluster without roles is not peered with any other.
+	Roles []InventoryRole `json:"roles,omitempty"`
+	// Providers, if set, restricts the providers the cluster peers with as a consumer.
+	Providers []string `json:"providers,omitempty"`
+	// Peering are the options of the peerings towards this cluster (as a provider), overriding the inventory defaults.
+	Peering InventoryPeeringOptions `json:"peering,omitempty"`
+}
+
+// InventoryPeeringOptions are the options of a peering. Unset fields fall back to the defaults of the peer command.
+type InventoryPeeringOptions struct {
+	// NetworkingDisabled disables the networking between the two clusters.
+	NetworkingDisabled *bool `json:"networkingDisabled,omitempty"`
+	// GatewayServerLocation is the location of the gateway server (Consumer or Provider).
+	GatewayServerLocation string `json:"gatewayServerLocation,omitempty"`
+	// GatewayServerServiceType is the type of the service exposing the gateway server.
+	GatewayServerServiceType string `json:"gatewayServerServiceType,omitempty"`
+	// GatewayServerServicePort is the port of the service exposing the gateway server.
+	GatewayServerServicePort int32 `json:"gatewayServerServicePort,omitempty"`
+	// MTU is the MTU of the gateways.
+	MTU int `json:"mtu,omitempty"`
+	// CreateResourceSlice enables the creation of the ResourceSlice towards the provider.
+	CreateResourceSlice *bool `json:"createResourceSlice,omitempty"`
+	// CreateVirtualNode enables the creation of the VirtualNode for the ResourceSlice.
+	CreateVirtualNode *bool `json:"createVirtualNode,omitempty"`
+	// ResourceSliceClass is the class of the ResourceSlice.
+	ResourceSliceClass string `json:"resourceSliceClass,omitempty"`
+	// Resources are the amounts of resources requested through the ResourceSlice (e.g., cpu, memory, pods).
+	Resources map[corev1.ResourceName]string `json:"resources,omitempty"`
+}
+
+// InventoryPeering identifies a peering between two clusters of the inventory, by name.
+type InventoryPeering struct {
+	Consumer string
+	Provider string
+}
+
+// String returns a representation of the peering.
+func (p InventoryPeering) String() string {
+	return fmt.Sprintf("%s -> %s", p.Consumer, p.Provider)
+}
+
+// LoadInventory reads and validates the inventory from the given file.
+func LoadInventory(path string) (*Inventory, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the inventory: %w", err)
+	}
+	inventory, err := ParseInventory(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relative kubeconfig paths are resolved with respect to the directory of the inventory.
+	for i := range inventory.Clusters {
+		if kubeconfig := inventory.Clusters[i].Kubeconfig; kubeconfig != "" && !filepath.IsAbs(kubeconfig) {
+			inventory.Clusters[i].Kubeconfig = filepath.Join(filepath.Dir(path), kubeconfig)
+		}
+	}
+	return inventory, nil
+}
+
+// ParseInventory parses and validates the given inventory.
+func ParseInventory(data []byte) (*Inventory, error) {
+	var inventory Inventory
+	if err := yaml.UnmarshalStrict(data, &inventory); err != nil {
+		return nil, fmt.Errorf("unable to parse the inventory: %w", err)
+	}
+	if err := inventory.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid inventory: %w", err)
+	}
+	return &inventory, nil
+}
+
+// Validate checks that the inventory is consistent.
+func (inv *Inventory) Validate() error {
+	if len(inv.Clusters) == 0 {
+		return fmt.Errorf("no clusters listed")
+	}
+
+	names := sets.New[string]()
+	for i := range inv.Clusters {
+		cluster := &inv.Clusters[i]
+		switch {
+		case cluster.Name == "":
+			return fmt.Errorf("cluster %d has no name", i)
+		case names.Has(cluster.Name):
+			return fmt.Errorf("cluster %q listed more than once", cluster.Name)
+		}
+		names.Insert(cluster.Name)
+
+		for _, role := range cluster.Roles {
+			if role != InventoryRoleConsumer && role != InventoryRoleProvider {
+				return fmt.Errorf("cluster %q has invalid role %q (allowed: %q, %q)",
+					cluster.Name, role, InventoryRoleConsumer, InventoryRoleProvider)
+			}
+		}
+		if len(cluster.Providers) > 0 && !cluster.HasRole(InventoryRoleConsumer) {
+			return fmt.Errorf("cluster %q lists providers, but it does not have the %q role", cluster.Name, InventoryRoleConsumer)
+		}
+	}
+
+	for i := range inv.Clusters {
+		cluster := &inv.Clusters[i]
+		for _, provider := range cluster.Providers {
+			target := inv.Cluster(provider)
+			switch {
+			case target == nil:
+				return fmt.Errorf("cluster %q lists unknown provider %q", cluster.Name, provider)
+			case provider == cluster.Name:
+				return fmt.Errorf("cluster %q cannot peer with itself", cluster.Name)
+			case !target.HasRole(InventoryRoleProvider):
+				return fmt.Errorf("cluster %q lists provider %q, which does not have the %q role", cluster.Name, provider, InventoryRoleProvider)
+			}
+		}
+
+		// Make sure the options can be converted to the ones of the peer command.
+		if err := inv.PeeringOptions(cluster.Name).apply(NewOptions(nil)); err != nil {
+			return fmt.Errorf("cluster %q: %w", cluster.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Cluster returns the cluster with the given name, or nil if not found.
+func (inv *Inventory) Cluster(name string) *InventoryCluster {
+	for i := range inv.Clusters {
+		if inv.Clusters[i].Name == name {
+			return &inv.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// HasRole returns whether the cluster plays the given role.
+func (c *InventoryCluster) HasRole(role InventoryRole) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// DesiredPeerings returns the peerings described by the inventory, ordered by consumer and provider name.
+func (inv *Inventory) DesiredPeerings() []InventoryPeering {
+	var peerings []InventoryPeering
+	for i := range inv.Clusters {
+		consumer := &inv.Clusters[i]
+		if !consumer.HasRole(InventoryRoleConsumer) {
+			continue
+		}
+
+		providers := sets.New(consumer.Providers...)
+		for j := range inv.Clusters {
+			provider := &inv.Clusters[j]
+			if provider.Name == consumer.Name || !provider.HasRole(InventoryRoleProvider) {
+				continue
+			}
+			if providers.Len() > 0 && !providers.Has(provider.Name) {
+				continue
+			}
+			peerings = append(peerings, InventoryPeering{Consumer: consumer.Name, Provider: provider.Name})
+		}
+	}
+
+	sort.Slice(peerings, func(i, j int) bool {
+		if peerings[i].Consumer != peerings[j].Consumer {
+			return peerings[i].Consumer < peerings[j].Consumer
+		}
+		return peerings[i].Provider < peerings[j].Provider
+	})
+	return peerings
+}
+
+// PeeringOptions returns the options of the peerings towards the given provider, merging its options with the defaults.
+func (inv *Inventory) PeeringOptions(provider string) *InventoryPeeringOptions {
+	merged := inv.Defaults
+	cluster := inv.Cluster(provider)
+	if cluster == nil {
+		return &merged
+	}
+
+	override := &cluster.Peering
+	if override.NetworkingDisabled != nil {
+		merged.NetworkingDisabled = override.NetworkingDisabled
+	}
+	if override.GatewayServerLocation != "" {
+		merged.GatewayServerLocation = override.GatewayServerLocation
+	}
+	if override.GatewayServerServiceType != "" {
+		merged.GatewayServerServiceType = override.GatewayServerServiceType
+	}
+	if override.GatewayServerServicePort != 0 {
+		merged.GatewayServerServicePort = override.GatewayServerServicePort
+	}
+	if override.MTU != 0 {
+		merged.MTU = override.MTU
+	}
+	if override.CreateResourceSlice != nil {
+		merged.CreateResourceSlice = override.CreateResourceSlice
+	}
+	if override.CreateVirtualNode != nil {
+		merged.CreateVirtualNode = override.CreateVirtualNode
+	}
+	if override.ResourceSliceClass != "" {
+		merged.ResourceSliceClass = override.ResourceSliceClass
+	}
+	if override.Resources != nil {
+		merged.Resources = override.Resources
+	}
+	return &merged
+}
+
+// apply configures the options of the peer command according to the inventory peering options.
+func (po *InventoryPeeringOptions) apply(opts *Options) error {
+	opts.NetworkingDisabled = po.NetworkingDisabled != nil && *po.NetworkingDisabled
+	if po.GatewayServerLocation != "" {
+		if err := opts.ServerServiceLocation.Set(po.GatewayServerLocation); err != nil {
+			return fmt.Errorf("invalid gateway server location: %w", err)
+		}
+	}
+	if po.GatewayServerServiceType != "" {
+		if err := opts.ServerServiceType.Set(po.GatewayServerServiceType); err != nil {
+			return fmt.Errorf("invalid gateway server service type: %w", err)
+		}
+	}
+
+	opts.ServerServicePort = nwforge.DefaultGwServerPort
+	if po.GatewayServerServicePort != 0 {
+		opts.ServerServicePort = po.GatewayServerServicePort
+	}
+	opts.MTU = nwforge.DefaultMTU
+	if po.MTU != 0 {
+		opts.MTU = po.MTU
+	}
+
+	opts.CreateResourceSlice = po.CreateResourceSlice == nil || *po.CreateResourceSlice
+	opts.CreateVirtualNode = po.CreateVirtualNode == nil || *po.CreateVirtualNode
+	opts.ResourceSliceClass = defaultResourceSliceClass
+	if po.ResourceSliceClass != "" {
+		opts.ResourceSliceClass = po.ResourceSliceClass
+	}
+
+	opts.OtherResources = map[string]string{}
+	for name, quantity := range po.Resources {
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("invalid quantity %q for resource %q: %w", quantity, name, err)
+		}
+		switch name {
+		case corev1.ResourceCPU:
+			opts.CPU = quantity
+		case corev1.ResourceMemory:
+			opts.Memory = quantity
+		case corev1.ResourcePods:
+			opts.Pods = quantity
+		default:
+			opts.OtherResources[string(name)] = quantity
+		}
+	}
+	return nil
+}
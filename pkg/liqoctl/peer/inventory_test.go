@@ -0,0 +1,148 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	nwforge "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+)
+
+var _ = Describe("Inventory", func() {
+	const fleet = `
+defaults:
+  gatewayServerServiceType: NodePort
+  resources:
+    cpu: "2"
+    memory: 4Gi
+clusters:
+- name: hub
+  context: hub
+  roles: [consumer]
+- name: spoke-1
+  context: spoke-1
+  roles: [provider]
+- name: spoke-2
+  context: spoke-2
+  kubeconfig: spoke-2.kubeconfig
+  roles: [consumer, provider]
+  peering:
+    mtu: 1400
+    createVirtualNode: false
+    resources:
+      cpu: "8"
+      nvidia.com/gpu: "1"
+`
+
+	Describe("parsing", func() {
+		It("should parse a valid inventory", func() {
+			inventory, err := ParseInventory([]byte(fleet))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inventory.Clusters).To(HaveLen(3))
+			Expect(inventory.Cluster("spoke-2").Roles).To(ConsistOf(InventoryRoleConsumer, InventoryRoleProvider))
+		})
+
+		It("should resolve the kubeconfig paths relative to the inventory file", func() {
+			dir := GinkgoT().TempDir()
+			path := filepath.Join(dir, "fleet.yaml")
+			Expect(os.WriteFile(path, []byte(fleet), 0o600)).To(Succeed())
+
+			inventory, err := LoadInventory(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inventory.Cluster("spoke-2").Kubeconfig).To(Equal(filepath.Join(dir, "spoke-2.kubeconfig")))
+			Expect(inventory.Cluster("hub").Kubeconfig).To(BeEmpty())
+		})
+
+		DescribeTable("should reject invalid inventories",
+			func(data, message string) {
+				_, err := ParseInventory([]byte(data))
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("no clusters", `clusters: []`, "no clusters listed"),
+			Entry("unknown field", `clusters: [{name: a, foo: bar}]`, "unknown field"),
+			Entry("missing name", `clusters: [{context: a}]`, "has no name"),
+			Entry("duplicated name", `clusters: [{name: a}, {name: a}]`, "listed more than once"),
+			Entry("invalid role", `clusters: [{name: a, roles: [owner]}]`, "invalid role"),
+			Entry("providers without the consumer role", `clusters: [{name: a, providers: [b]}, {name: b, roles: [provider]}]`,
+				"does not have the \"consumer\" role"),
+			Entry("unknown provider", `clusters: [{name: a, roles: [consumer], providers: [b]}]`, "unknown provider"),
+			Entry("provider without the provider role", `clusters: [{name: a, roles: [consumer], providers: [b]}, {name: b}]`,
+				"does not have the \"provider\" role"),
+			Entry("invalid service type", `{defaults: {gatewayServerServiceType: Foo}, clusters: [{name: a}]}`,
+				"invalid gateway server service type"),
+			Entry("invalid quantity", `clusters: [{name: a, peering: {resources: {cpu: lots}}}]`, "invalid quantity"),
+		)
+	})
+
+	Describe("desired peerings", func() {
+		It("should peer every consumer with every provider other than itself", func() {
+			inventory, err := ParseInventory([]byte(fleet))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inventory.DesiredPeerings()).To(Equal([]InventoryPeering{
+				{Consumer: "hub", Provider: "spoke-1"},
+				{Consumer: "hub", Provider: "spoke-2"},
+				{Consumer: "spoke-2", Provider: "spoke-1"},
+			}))
+		})
+
+		It("should restrict the peerings to the listed providers", func() {
+			inventory, err := ParseInventory([]byte(`
+clusters:
+- {name: a, roles: [consumer], providers: [c]}
+- {name: b, roles: [provider]}
+- {name: c, roles: [provider]}
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inventory.DesiredPeerings()).To(Equal([]InventoryPeering{{Consumer: "a", Provider: "c"}}))
+		})
+	})
+
+	Describe("peering options", func() {
+		var inventory *Inventory
+
+		BeforeEach(func() {
+			var err error
+			inventory, err = ParseInventory([]byte(fleet))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should apply the defaults", func() {
+			opts := NewOptions(nil)
+			Expect(inventory.PeeringOptions("spoke-1").apply(opts)).To(Succeed())
+			Expect(opts.ServerServiceType.Value).To(Equal(string(corev1.ServiceTypeNodePort)))
+			Expect(opts.MTU).To(Equal(nwforge.DefaultMTU))
+			Expect(opts.CreateResourceSlice).To(BeTrue())
+			Expect(opts.CreateVirtualNode).To(BeTrue())
+			Expect(opts.CPU).To(Equal("2"))
+			Expect(opts.Memory).To(Equal("4Gi"))
+		})
+
+		It("should override the defaults with the options of the provider", func() {
+			opts := NewOptions(nil)
+			Expect(inventory.PeeringOptions("spoke-2").apply(opts)).To(Succeed())
+			Expect(opts.ServerServiceType.Value).To(Equal(string(corev1.ServiceTypeNodePort)))
+			Expect(opts.MTU).To(Equal(1400))
+			Expect(opts.CreateVirtualNode).To(BeFalse())
+			Expect(opts.CPU).To(Equal("8"))
+			Expect(opts.Memory).To(BeEmpty())
+			Expect(opts.OtherResources).To(HaveKeyWithValue("nvidia.com/gpu", "1"))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	argsutils "github.com/liqotech/liqo/pkg/utils/args"
+)
+
+const (
+	// DryRunNone is the dry-run mode applying the objects.
+	DryRunNone = "none"
+	// DryRunPlan is the dry-run mode collecting the objects without applying them.
+	DryRunPlan = "plan"
+)
+
+// Flags contains the flags enabling the plan mode of a command.
+type Flags struct {
+	DryRun *argsutils.StringEnum
+	Export string
+}
+
+// NewFlags returns a new Flags struct.
+func NewFlags() *Flags {
+	return &Flags{
+		DryRun: argsutils.NewEnum([]string{DryRunNone, DryRunPlan}, DryRunNone),
+	}
+}
+
+// AddFlags registers the flags enabling the plan mode in the given flagset.
+func (f *Flags) AddFlags(flags *pflag.FlagSet) {
+	flags.Var(f.DryRun, "dry-run", fmt.Sprintf("Set to %q to output the objects that would be applied on each cluster, "+
+		"without applying them. Supported values: %s, %s", DryRunPlan, DryRunNone, DryRunPlan))
+	flags.StringVar(&f.Export, "export", "",
+		"Write the objects that would be applied on each cluster to the given directory, without applying them (implies --dry-run=plan)")
+}
+
+// Enabled returns whether the plan mode is enabled.
+func (f *Flags) Enabled() bool {
+	return f.DryRun.Value == DryRunPlan || f.Export != ""
+}
+
+// NewPlan returns a new Plan if the plan mode is enabled, and nil otherwise.
+func (f *Flags) NewPlan(scheme *runtime.Scheme) *Plan {
+	if !f.Enabled() {
+		return nil
+	}
+	return New(scheme)
+}
+
+// Output outputs the plan, either exporting it to the configured directory or printing it to the standard output.
+// It is a no-op if the plan is nil.
+func (f *Flags) Output(p *Plan, printer *output.Printer) error {
+	switch {
+	case p == nil:
+		return nil
+	case f.Export == "":
+		if err := p.Print(os.Stdout); err != nil {
+			return fmt.Errorf("unable to print the plan: %w", err)
+		}
+	default:
+		files, err := p.Export(f.Export)
+		if err != nil {
+			return err
+		}
+		printer.Success.Printfln("%d manifests written to %q", len(files), f.Export)
+	}
+
+	for _, note := range p.Notes() {
+		printer.Info.Println(note)
+	}
+	return nil
+}
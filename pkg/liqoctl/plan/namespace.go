@@ -0,0 +1,43 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+)
+
+// TenantNamespace returns the name of the tenant namespace for the remote cluster in the local cluster.
+// If the namespace does not exist yet, it is added to the plan of the local cluster.
+func (p *Plan) TenantNamespace(ctx context.Context, namespaceManager tenantnamespace.Manager,
+	localClusterID, remoteClusterID liqov1beta1.ClusterID) (string, error) {
+	ns, err := namespaceManager.GetNamespace(ctx, remoteClusterID)
+	switch {
+	case err == nil:
+		return ns.Name, nil
+	case apierrors.IsNotFound(err):
+		ns = namespaceManager.ForgeNamespace(remoteClusterID, nil)
+		if err := p.Add(localClusterID, ns); err != nil {
+			return "", err
+		}
+		return ns.Name, nil
+	default:
+		return "", err
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// Plan collects the objects a command would apply on each cluster, in the order they should be applied.
+type Plan struct {
+	scheme *runtime.Scheme
+
+	clusters []liqov1beta1.ClusterID
+	objects  map[liqov1beta1.ClusterID][]client.Object
+	notes    []string
+}
+
+// New returns a new empty Plan. The scheme is used to set the type information of the collected objects.
+func New(scheme *runtime.Scheme) *Plan {
+	return &Plan{
+		scheme:  scheme,
+		objects: map[liqov1beta1.ClusterID][]client.Object{},
+	}
+}
+
+// Add adds an object to be applied on the given cluster. If the plan already contains the same object
+// for the given cluster, it is replaced while preserving its position.
+func (p *Plan) Add(clusterID liqov1beta1.ClusterID, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, p.scheme)
+	if err != nil {
+		return fmt.Errorf("unable to get the kind of object %q: %w", obj.GetName(), err)
+	}
+
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetManagedFields(nil)
+
+	if _, found := p.objects[clusterID]; !found {
+		p.clusters = append(p.clusters, clusterID)
+	}
+	for i, existing := range p.objects[clusterID] {
+		if sameObject(existing, obj) {
+			p.objects[clusterID][i] = obj
+			return nil
+		}
+	}
+	p.objects[clusterID] = append(p.objects[clusterID], obj)
+	return nil
+}
+
+// AddNote records a step which cannot be planned in advance (e.g., because it depends on the status of the applied objects).
+func (p *Plan) AddNote(format string, args ...interface{}) {
+	p.notes = append(p.notes, fmt.Sprintf(format, args...))
+}
+
+// Clusters returns the clusters the plan contains objects for, in order of appearance.
+func (p *Plan) Clusters() []liqov1beta1.ClusterID {
+	return p.clusters
+}
+
+// Objects returns the objects to be applied on the given cluster.
+func (p *Plan) Objects(clusterID liqov1beta1.ClusterID) []client.Object {
+	return p.objects[clusterID]
+}
+
+// Notes returns the steps which cannot be planned in advance.
+func (p *Plan) Notes() []string {
+	return p.notes
+}
+
+// Print writes the objects of the plan as a stream of YAML documents, grouped by cluster.
+func (p *Plan) Print(w io.Writer) error {
+	printer := printers.YAMLPrinter{}
+	for _, clusterID := range p.clusters {
+		if _, err := fmt.Fprintf(w, "# Objects to be applied on cluster %q\n", clusterID); err != nil {
+			return err
+		}
+		for _, obj := range p.objects[clusterID] {
+			if err := printer.PrintObj(obj, w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Export writes the objects of the plan to the given directory, in a subdirectory for each cluster.
+// Files are prefixed with a sequence number, so that applying them in lexicographic order respects the order of the plan.
+// It returns the paths of the written files.
+func (p *Plan) Export(dir string) ([]string, error) {
+	var files []string
+	printer := printers.YAMLPrinter{}
+	for _, clusterID := range p.clusters {
+		clusterDir := filepath.Join(dir, string(clusterID))
+		if err := os.MkdirAll(clusterDir, 0o750); err != nil {
+			return nil, fmt.Errorf("unable to create directory %q: %w", clusterDir, err)
+		}
+
+		for i, obj := range p.objects[clusterID] {
+			kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+			path := filepath.Join(clusterDir, fmt.Sprintf("%02d-%s-%s.yaml", i+1, kind, obj.GetName()))
+			if err := writeObject(&printer, obj, path); err != nil {
+				return nil, err
+			}
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+func writeObject(printer printers.ResourcePrinter, obj client.Object, path string) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("unable to create file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := printer.PrintObj(obj, f); err != nil {
+		return fmt.Errorf("unable to write file %q: %w", path, err)
+	}
+	return nil
+}
+
+func sameObject(a, b client.Object) bool {
+	return gvkOf(a) == gvkOf(b) && a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
+
+func gvkOf(obj client.Object) schema.GroupVersionKind {
+	return obj.GetObjectKind().GroupVersionKind()
+}
@@ -0,0 +1,144 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+)
+
+var _ = Describe("Plan", func() {
+	const (
+		consumerID liqov1beta1.ClusterID = "consumer"
+		providerID liqov1beta1.ClusterID = "provider"
+	)
+
+	var p *Plan
+
+	BeforeEach(func() {
+		p = New(scheme.Scheme)
+	})
+
+	Describe("adding objects", func() {
+		var tenant *authv1beta1.Tenant
+
+		BeforeEach(func() {
+			tenant = &authv1beta1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "consumer", Namespace: "liqo-tenant-consumer",
+					ResourceVersion: "42", UID: "uid", Generation: 3,
+				},
+				Spec: authv1beta1.TenantSpec{ClusterID: consumerID},
+			}
+			Expect(p.Add(providerID, tenant)).To(Succeed())
+		})
+
+		It("should set the type information and clear the server-populated fields", func() {
+			objects := p.Objects(providerID)
+			Expect(objects).To(HaveLen(1))
+			Expect(objects[0].GetObjectKind().GroupVersionKind()).To(Equal(authv1beta1.TenantGroupVersionResource.GroupVersion().WithKind("Tenant")))
+			Expect(objects[0].GetResourceVersion()).To(BeEmpty())
+			Expect(objects[0].GetUID()).To(BeEmpty())
+			Expect(objects[0].GetGeneration()).To(BeZero())
+		})
+
+		It("should not modify the original object", func() {
+			Expect(tenant.ResourceVersion).To(Equal("42"))
+		})
+
+		It("should replace an object already in the plan", func() {
+			Expect(p.Add(providerID, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "liqo-tenant-consumer"}})).To(Succeed())
+			updated := tenant.DeepCopy()
+			updated.Spec.ProxyURL = ptr.To("http://proxy:8118")
+			Expect(p.Add(providerID, updated)).To(Succeed())
+
+			objects := p.Objects(providerID)
+			Expect(objects).To(HaveLen(2))
+			Expect(objects[0].(*authv1beta1.Tenant).Spec.ProxyURL).To(HaveValue(Equal("http://proxy:8118")))
+		})
+
+		It("should keep the clusters in order of appearance", func() {
+			Expect(p.Add(consumerID, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "liqo-tenant-provider"}})).To(Succeed())
+			Expect(p.Clusters()).To(Equal([]liqov1beta1.ClusterID{providerID, consumerID}))
+		})
+
+		It("should print the objects grouped by cluster", func() {
+			var buf bytes.Buffer
+			Expect(p.Print(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`# Objects to be applied on cluster "provider"`))
+			Expect(buf.String()).To(ContainSubstring("kind: Tenant"))
+		})
+
+		It("should export the objects to a directory for each cluster", func() {
+			Expect(p.Add(providerID, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "liqo-tenant-consumer"}})).To(Succeed())
+
+			dir := GinkgoT().TempDir()
+			files, err := p.Export(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{
+				filepath.Join(dir, "provider", "01-tenant-consumer.yaml"),
+				filepath.Join(dir, "provider", "02-namespace-liqo-tenant-consumer.yaml"),
+			}))
+
+			content, err := os.ReadFile(files[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("kind: Namespace"))
+		})
+	})
+
+	Describe("planning the tenant namespace", func() {
+		var (
+			ctx     context.Context
+			manager tenantnamespace.Manager
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			manager = tenantnamespace.NewManager(fake.NewSimpleClientset(), scheme.Scheme)
+		})
+
+		It("should add the namespace to the plan if it does not exist", func() {
+			name, err := p.TenantNamespace(ctx, manager, consumerID, providerID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).ToNot(BeEmpty())
+			Expect(p.Objects(consumerID)).To(HaveLen(1))
+			Expect(p.Objects(consumerID)[0].GetName()).To(Equal(name))
+		})
+
+		It("should return the existing namespace without adding it to the plan", func() {
+			ns, err := manager.CreateNamespace(ctx, providerID)
+			Expect(err).ToNot(HaveOccurred())
+
+			name, err := p.TenantNamespace(ctx, manager, consumerID, providerID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(Equal(ns.Name))
+			Expect(p.Clusters()).To(BeEmpty())
+		})
+	})
+})
@@ -76,6 +76,8 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.TemplateName, "template-name", forge.DefaultGwClientTemplateName, "Name of the Gateway Client template")
 	cmd.Flags().StringVar(&o.TemplateNamespace, "template-namespace", "", "Namespace of the Gateway Client template")
 	cmd.Flags().IntVar(&o.MTU, "mtu", forge.DefaultMTU, "MTU of Gateway Client")
+	cmd.Flags().Var(&o.Bandwidth, "bandwidth",
+		"Maximum bandwidth (in bits per second, e.g., 100M) of the traffic sent through the tunnel. Leave empty to disable the limit")
 	cmd.Flags().StringSliceVar(&o.Addresses, "addresses", []string{}, "Addresses of Gateway Server")
 	cmd.Flags().Int32Var(&o.Port, "port", 0, "Port of Gateway Server")
 	cmd.Flags().StringVar(&o.Protocol, "protocol", forge.DefaultProtocol, "Gateway Protocol")
@@ -15,6 +15,9 @@
 package gatewayclient
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest"
 	"github.com/liqotech/liqo/pkg/utils/args"
@@ -30,6 +33,7 @@ type Options struct {
 	TemplateName      string
 	TemplateNamespace string
 	MTU               int
+	Bandwidth         args.Quantity
 	Addresses         []string
 	Port              int32
 	Protocol          string
@@ -52,6 +56,11 @@ func (o *Options) APIOptions() *rest.APIOptions {
 }
 
 func (o *Options) getForgeOptions() *forge.GwClientOptions {
+	var bandwidth *resource.Quantity
+	if !o.Bandwidth.Quantity.IsZero() {
+		bandwidth = ptr.To(o.Bandwidth.Quantity)
+	}
+
 	if o.TemplateNamespace == "" {
 		o.TemplateNamespace = o.createOptions.LiqoNamespace
 	}
@@ -63,6 +72,7 @@ func (o *Options) getForgeOptions() *forge.GwClientOptions {
 		TemplateName:      o.TemplateName,
 		TemplateNamespace: o.TemplateNamespace,
 		MTU:               o.MTU,
+		Bandwidth:         bandwidth,
 		Addresses:         o.Addresses,
 		Port:              o.Port,
 		Protocol:          o.Protocol,
@@ -78,6 +78,8 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.TemplateNamespace, "template-namespace", "", "Namespace of the Gateway Server template")
 	cmd.Flags().Var(o.ServiceType, "service-type", fmt.Sprintf("Service type of Gateway Server. Default: %s", forge.DefaultGwServerServiceType))
 	cmd.Flags().IntVar(&o.MTU, "mtu", forge.DefaultMTU, "MTU of Gateway Server")
+	cmd.Flags().Var(&o.Bandwidth, "bandwidth",
+		"Maximum bandwidth (in bits per second, e.g., 100M) of the traffic sent through the tunnel. Leave empty to disable the limit")
 	cmd.Flags().Int32Var(&o.Port, "port", forge.DefaultGwServerPort, "Port of Gateway Server")
 	cmd.Flags().Int32Var(&o.NodePort, "node-port", 0,
 		"Force the NodePort of the Gateway Server. Leave empty to let Kubernetes allocate a random NodePort")
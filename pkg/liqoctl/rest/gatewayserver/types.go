@@ -16,6 +16,7 @@ package gatewayserver
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
@@ -34,6 +35,7 @@ type Options struct {
 	TemplateNamespace string
 	ServiceType       *argsutils.StringEnum
 	MTU               int
+	Bandwidth         argsutils.Quantity
 	Port              int32
 	NodePort          int32
 	LoadBalancerIP    string
@@ -60,6 +62,11 @@ func (o *Options) APIOptions() *rest.APIOptions {
 }
 
 func (o *Options) getForgeOptions() *forge.GwServerOptions {
+	var bandwidth *resource.Quantity
+	if !o.Bandwidth.Quantity.IsZero() {
+		bandwidth = ptr.To(o.Bandwidth.Quantity)
+	}
+
 	if o.TemplateNamespace == "" {
 		o.TemplateNamespace = o.createOptions.LiqoNamespace
 	}
@@ -72,6 +79,7 @@ func (o *Options) getForgeOptions() *forge.GwServerOptions {
 		TemplateNamespace: o.TemplateNamespace,
 		ServiceType:       corev1.ServiceType(o.ServiceType.Value),
 		MTU:               o.MTU,
+		Bandwidth:         bandwidth,
 		Port:              o.Port,
 		NodePort:          ptr.To(o.NodePort),
 		LoadBalancerIP:    ptr.To(o.LoadBalancerIP),
@@ -0,0 +1,182 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/rest"
+	"github.com/liqotech/liqo/pkg/liqoctl/rest/peering-user/userfactory"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	liqoutils "github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+const liqoctlGenerateInvitationHelp = `Generate an invitation token to peer with this cluster.
+
+This command generates a signed and expiring invitation token, which allows the cluster with the given
+cluster ID to declaratively peer with this cluster (acting as provider), without requiring access to both
+clusters at the same time. Under the hood, a peering user with the minimum permissions is created.
+The invitation can be used to establish the peering until it expires, while the credentials of the peering user
+(required to reconfigure the peering) have a separate and longer validity. The credentials are revoked once expired,
+or as soon as the consumer cluster deletes the PeeringRequest referencing the invitation.
+
+The token shall be stored in a Secret (under the "token" key) in the consumer cluster, and referenced
+by a PeeringRequest resource, together with the fingerprint of the public key of this cluster. The consumer
+cluster trusts the token only if it is signed with the key matching the fingerprint: hence, the fingerprint
+shall be shared through a channel other than the one used for the token.
+
+Examples:
+  $ {{ .Executable }} generate invitation --consumer-cluster-id=<cluster-id>
+or
+  $ {{ .Executable }} generate invitation --consumer-cluster-id=<cluster-id> --ttl=2h --credentials-ttl=720h`
+
+// Generate generates an invitation.
+func (o *Options) Generate(ctx context.Context, options *rest.GenerateOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invitation",
+		Short: "Generate an invitation token to peer with this cluster",
+		Long:  liqoctlGenerateInvitationHelp,
+		Args:  cobra.NoArgs,
+
+		PreRun: func(_ *cobra.Command, _ []string) {
+			o.generateOptions = options
+
+			o.namespaceManager = tenantnamespace.NewManager(options.KubeClient, options.CRClient.Scheme())
+		},
+
+		Run: func(_ *cobra.Command, _ []string) {
+			output.ExitOnErr(o.handleGenerate(ctx))
+		},
+	}
+
+	cmd.Flags().Var(&o.clusterID, "consumer-cluster-id", "The cluster ID of the cluster allowed to peer using the invitation")
+	cmd.Flags().DurationVar(&o.ttl, "ttl", DefaultTTL, "The validity of the invitation, to establish the peering")
+	cmd.Flags().DurationVar(&o.credentialsTTL, "credentials-ttl", DefaultCredentialsTTL,
+		"The validity of the credentials carried by the invitation, to reconfigure the peering. It cannot be shorter than the ttl")
+	cmd.Flags().StringVar(&o.tlsCompatibilityMode, "tls-compatibility-mode", "auto",
+		"TLS compatibility mode for peering-user keys: one of auto,true,false. "+
+			"If set to true keys are generated with a widely supported algorithm (RSA) "+
+			"to ensure compatibility with systems that do not yet support Ed25519 (default) as signature algorithm. "+
+			"When auto, liqoctl attempts to detect the system configuration.")
+
+	runtime.Must(cmd.MarkFlagRequired("consumer-cluster-id"))
+
+	return cmd
+}
+
+func (o *Options) handleGenerate(ctx context.Context) error {
+	opts := o.generateOptions
+
+	if o.ttl <= 0 {
+		return fmt.Errorf("invalid value for --ttl: %s (must be positive)", o.ttl)
+	}
+	if o.credentialsTTL < o.ttl {
+		return fmt.Errorf("invalid value for --credentials-ttl: %s (must not be shorter than --ttl)", o.credentialsTTL)
+	}
+
+	clusterID := liqov1beta1.ClusterID(*o.clusterID.ClusterID)
+
+	tlsCompat, err := userfactory.ResolveTLSCompatibilityMode(ctx, opts.CRClient, opts.LiqoNamespace, o.tlsCompatibilityMode)
+	if err != nil {
+		return err
+	}
+
+	providerClusterID, err := liqoutils.GetClusterIDWithControllerClient(ctx, opts.CRClient, opts.LiqoNamespace)
+	if err != nil {
+		wErr := fmt.Errorf("unable to retrieve the cluster ID: %w", err)
+		opts.Printer.Error.Println(wErr)
+		return wErr
+	}
+
+	privateKey, publicKey, err := authentication.GetClusterKeys(ctx, opts.CRClient, opts.LiqoNamespace)
+	if err != nil {
+		wErr := fmt.Errorf("unable to retrieve the cluster authentication keys: %w", err)
+		opts.Printer.Error.Println(wErr)
+		return wErr
+	}
+
+	tenantNs, err := o.namespaceManager.CreateNamespace(ctx, clusterID)
+	if err != nil {
+		wErr := fmt.Errorf("unable to create the tenant namespace: %w", err)
+		opts.Printer.Error.Println(wErr)
+		return wErr
+	}
+
+	spinner := opts.Printer.StartSpinner("Generating an invitation to peer with this cluster")
+	now := time.Now()
+	expiration := metav1.NewTime(now.Add(o.ttl).Truncate(time.Second))
+	credentialsExpiration := metav1.NewTime(now.Add(o.credentialsTTL).Truncate(time.Second))
+	kubeconfig, err := userfactory.GeneratePeerUser(ctx, clusterID, tenantNs.Name, opts.Factory, tlsCompat, o.credentialsTTL)
+	if err != nil {
+		spinner.Fail(err)
+		return err
+	}
+
+	// Record the issued credentials, so that they are revoked once expired or no longer used.
+	desired := invitation.CredentialsSecret(clusterID, tenantNs.Name, credentialsExpiration)
+	credentials := desired.DeepCopy()
+	if _, err := resource.CreateOrUpdate(ctx, opts.CRClient, credentials, func() error {
+		credentials.Labels = desired.Labels
+		credentials.Annotations = desired.Annotations
+		controllerutil.AddFinalizer(credentials, invitation.CredentialsFinalizer)
+		return nil
+	}); err != nil {
+		err = fmt.Errorf("unable to record the invitation credentials: %w", err)
+		spinner.Fail(err)
+		return err
+	}
+
+	token, err := invitation.Sign(&invitation.Invitation{
+		ProviderClusterID: providerClusterID,
+		ConsumerClusterID: clusterID,
+		LiqoNamespace:     opts.LiqoNamespace,
+		TenantNamespace:   tenantNs.Name,
+		Kubeconfig:        []byte(kubeconfig),
+		PublicKey:         publicKey,
+		ExpirationTime:    expiration,
+
+		CredentialsExpirationTime: credentialsExpiration,
+	}, privateKey)
+	if err != nil {
+		spinner.Fail(err)
+		return err
+	}
+	spinner.Success("Invitation generated successfully")
+
+	opts.Printer.Warning.Println("Please take note of this token as it is not stored.")
+	opts.Printer.Warning.Printfln("Note that it can only be used from the cluster with ID %s, before %s",
+		clusterID, expiration.Format(time.RFC3339))
+	opts.Printer.Warning.Printfln("The peering can be reconfigured through the invitation credentials until %s",
+		credentialsExpiration.Format(time.RFC3339))
+	opts.Printer.Info.Println("Store it in a Secret of the consumer cluster, and reference it from a PeeringRequest:")
+	opts.Printer.Info.Printfln("  kubectl create secret generic <name> --from-literal=%s=<token>", invitation.TokenKey)
+	opts.Printer.Info.Printfln("Share the fingerprint of the key of this cluster through a separate channel, "+
+		"and set it in the providerKeyFingerprint field of the PeeringRequest: %s", invitation.Fingerprint(publicKey))
+
+	fmt.Println(token)
+	return nil
+}
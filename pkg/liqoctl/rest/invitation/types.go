@@ -0,0 +1,57 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invitation
+
+import (
+	"time"
+
+	"github.com/liqotech/liqo/pkg/liqoctl/rest"
+	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	"github.com/liqotech/liqo/pkg/utils/args"
+)
+
+const (
+	// DefaultTTL is the default validity of the generated invitations.
+	DefaultTTL = 24 * time.Hour
+	// DefaultCredentialsTTL is the default validity of the credentials carried by the generated invitations.
+	DefaultCredentialsTTL = 365 * 24 * time.Hour
+)
+
+// Options encapsulates the arguments of the invitation command.
+type Options struct {
+	generateOptions  *rest.GenerateOptions
+	namespaceManager tenantnamespace.Manager
+
+	clusterID      args.ClusterIDFlags
+	ttl            time.Duration
+	credentialsTTL time.Duration
+	// tlsCompatibilityMode controls key type selection for the generated peering user.
+	// Accepted values: "auto", "true", "false".
+	tlsCompatibilityMode string
+}
+
+var _ rest.API = &Options{}
+
+// Invitation returns the rest API for the invitation command.
+func Invitation() rest.API {
+	return &Options{}
+}
+
+// APIOptions returns the APIOptions for the invitation API.
+func (o *Options) APIOptions() *rest.APIOptions {
+	return &rest.APIOptions{
+		EnableGenerate: true,
+	}
+}
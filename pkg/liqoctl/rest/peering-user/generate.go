@@ -17,7 +17,6 @@ package peeringuser
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -26,9 +25,7 @@ import (
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest/peering-user/userfactory"
-	"github.com/liqotech/liqo/pkg/liqoctl/utils"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
-	"github.com/liqotech/liqo/pkg/utils/getters"
 )
 
 const liqoctlGeneratePeeringUserHelp = `Generate a new user with the permissions to peer with this cluster.
@@ -78,30 +75,9 @@ func (o *Options) handleGenerate(ctx context.Context) error {
 	opts.Printer.Warning.Println("Please take note of this kubeconfig as it is not stored.")
 	opts.Printer.Warning.Printfln("Note that it can only be used to peer with this cluster from a cluster with ID %s", clusterID)
 
-	// Resolve TLS compatibility mode to a boolean.
-	var tlsCompat bool
-	mode := strings.ToLower(strings.TrimSpace(o.tlsCompatibilityMode))
-	switch mode {
-	case "true":
-		tlsCompat = true
-	case "false":
-		tlsCompat = false
-	case "auto", "":
-		// Detect from controller manager args; default to false if not found.
-		ctrlDeployment, err := getters.GetControllerManagerDeployment(ctx, opts.CRClient, opts.LiqoNamespace)
-		if err == nil {
-			if ctrlContainer, e := utils.GetCtrlManagerContainer(ctrlDeployment); e == nil {
-				if v, e := utils.ExtractValuesFromArgumentList("--tls-compatibility-mode", ctrlContainer.Args); e == nil {
-					if v == "" || strings.EqualFold(v, "true") {
-						tlsCompat = true
-					} else {
-						tlsCompat = false
-					}
-				}
-			}
-		}
-	default:
-		return fmt.Errorf("invalid value for --tls-compatibility-mode: %q (allowed: auto,true,false)", o.tlsCompatibilityMode)
+	tlsCompat, err := userfactory.ResolveTLSCompatibilityMode(ctx, opts.CRClient, opts.LiqoNamespace, o.tlsCompatibilityMode)
+	if err != nil {
+		return err
 	}
 
 	tenantNs, err := o.namespaceManager.CreateNamespace(ctx, clusterID)
@@ -112,7 +88,7 @@ func (o *Options) handleGenerate(ctx context.Context) error {
 	}
 
 	spinner := opts.Printer.StartSpinner("Generating a user for peering with this cluster")
-	kubeconfig, err := userfactory.GeneratePeerUser(ctx, clusterID, tenantNs.Name, opts.Factory, tlsCompat)
+	kubeconfig, err := userfactory.GeneratePeerUser(ctx, clusterID, tenantNs.Name, opts.Factory, tlsCompat, 0)
 	if err != nil {
 		spinner.Fail(err)
 		return err
@@ -24,12 +24,14 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	certv1 "k8s.io/api/certificates/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
@@ -45,13 +47,15 @@ import (
 
 // GeneratePeerUser generates a new user to peer with the local cluster and returns its kubeconfig.
 // When tlsCompatibilityMode is true, RSA keys are generated to improve TLS handshake compatibility.
-// Otherwise, Ed25519 keys are used by default.
+// Otherwise, Ed25519 keys are used by default. When validity is greater than zero, the certificate of the user
+// is requested to expire after the given duration, otherwise the default duration of the signer is used.
 func GeneratePeerUser(
 	ctx context.Context,
 	clusterID liqov1beta1.ClusterID,
 	tenantNsName string,
 	opts *factory.Factory,
 	tlsCompatibilityMode bool,
+	validity time.Duration,
 ) (string, error) {
 	if exists, err := IsExistingPeerUser(ctx, opts.CRClient, clusterID); err != nil {
 		return "", fmt.Errorf("unable to check if the user already exists: %w", err)
@@ -91,7 +95,7 @@ func GeneratePeerUser(
 	}
 
 	// Sign the csr to generate the certificate
-	cert, err := generateSignedCert(ctx, opts.CRClient, opts.KubeClient, csr, clusterID)
+	cert, err := generateSignedCert(ctx, opts.CRClient, opts.KubeClient, csr, clusterID, validity)
 	if err != nil {
 		return "", fmt.Errorf("unable to generate certificate for the user: %w", err)
 	}
@@ -122,9 +126,36 @@ func GeneratePeerUser(
 	return string(kubeconfig), nil
 }
 
+// ResolveTLSCompatibilityMode converts the given TLS compatibility mode (one of auto, true, false) to a boolean.
+// In auto mode, the value is detected from the arguments of the controller manager, defaulting to false if not found.
+func ResolveTLSCompatibilityMode(ctx context.Context, c client.Client, liqoNamespace, mode string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "auto", "":
+		ctrlDeployment, err := getters.GetControllerManagerDeployment(ctx, c, liqoNamespace)
+		if err != nil {
+			return false, nil
+		}
+		ctrlContainer, err := liqoctlutils.GetCtrlManagerContainer(ctrlDeployment)
+		if err != nil {
+			return false, nil
+		}
+		v, err := liqoctlutils.ExtractValuesFromArgumentList("--tls-compatibility-mode", ctrlContainer.Args)
+		if err != nil {
+			return false, nil
+		}
+		return v == "" || strings.EqualFold(v, "true"), nil
+	default:
+		return false, fmt.Errorf("invalid value for --tls-compatibility-mode: %q (allowed: auto,true,false)", mode)
+	}
+}
+
 // GetUserNameFromClusterID returns the username of the peering user for the given clusterID.
 func GetUserNameFromClusterID(clusterID liqov1beta1.ClusterID) string {
-	return fmt.Sprintf("liqo-peer-user-%s", clusterID)
+	return authentication.PeeringUserName(clusterID)
 }
 
 func getAPIServerAddress(ctx context.Context, c client.Client, liqoNamespaceName string) (string, error) {
@@ -157,6 +188,7 @@ func generateSignedCert(
 	clientset kubernetes.Interface,
 	csr []byte,
 	clusterID liqov1beta1.ClusterID,
+	validity time.Duration,
 ) ([]byte, error) {
 	userName := GetUserNameFromClusterID(clusterID)
 	cert := &certv1.CertificateSigningRequest{
@@ -180,6 +212,10 @@ func generateSignedCert(
 		},
 	}
 
+	if validity > 0 {
+		cert.Spec.ExpirationSeconds = ptr.To(int32(validity.Seconds()))
+	}
+
 	cert, err := clientset.CertificatesV1().CertificateSigningRequests().Create(ctx, cert, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
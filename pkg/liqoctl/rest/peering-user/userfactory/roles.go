@@ -20,13 +20,16 @@ import (
 	"fmt"
 
 	certv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/invitation"
 )
 
 var peeringUserLabel = client.ListOptions{
@@ -93,6 +96,28 @@ func RemovePermissions(ctx context.Context, c client.Client, clusterID liqov1bet
 		return fmt.Errorf("unable to delete ClusterRoleBindings: %w", err)
 	}
 
+	// Delete the Secrets recording the credentials issued through invitations, which have just been revoked.
+	// The finalizer is removed first, to prevent a later revocation from affecting a new user with the same name.
+	var credentials corev1.SecretList
+	if err := c.List(ctx, &credentials, client.MatchingLabels{
+		invitation.CredentialsLabelKey: "true",
+		consts.RemoteClusterID:         string(clusterID),
+	}); err != nil {
+		return fmt.Errorf("unable to get the invitation credentials: %w", err)
+	}
+
+	for i := range credentials.Items {
+		secret := &credentials.Items[i]
+		if controllerutil.RemoveFinalizer(secret, invitation.CredentialsFinalizer) {
+			if err := c.Update(ctx, secret); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("unable to update Secret %q: %w", secret.Name, err)
+			}
+		}
+		if err := c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete Secret %q: %w", secret.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -46,7 +46,9 @@ Examples:
   $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
   --cpu 4 --memory 8Gi --pods 30
   $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
-  --cpu 4 --memory 8Gi --pods 30 --resource nvidia.com/gpu=2`
+  --cpu 4 --memory 8Gi --pods 30 --resource nvidia.com/gpu=2
+  $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
+  --cpu 4 --memory 8Gi --max-cpu 16 --max-memory 32Gi`
 
 // Create implements the create command.
 func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobra.Command {
@@ -84,6 +86,12 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.Pods, "pods", "", "The amount of pods requested in the resource slice")
 	cmd.Flags().StringToStringVar(
 		&o.OtherResources, "resource", nil, "Other resources requested in the resource slice (e.g., 'resource=nvidia.com/gpu=2')")
+	cmd.Flags().StringVar(&o.MaxCPU, "max-cpu", "",
+		"Enable the autoscaling of the CPU requested in the resource slice, up to the given amount")
+	cmd.Flags().StringVar(&o.MaxMemory, "max-memory", "",
+		"Enable the autoscaling of the memory requested in the resource slice, up to the given amount")
+	cmd.Flags().StringVar(&o.MaxPods, "max-pods", "",
+		"Enable the autoscaling of the pods requested in the resource slice, up to the given amount")
 	cmd.Flags().BoolVar(&o.DisableVirtualNodeCreation, "no-virtual-node", false,
 		"Prevent the automatic creation of a VirtualNode for the ResourceSlice. Default: false")
 
@@ -115,8 +123,9 @@ func (o *Options) HandleCreate(ctx context.Context) error {
 	resourceSlice := forge.ResourceSlice(opts.Name, namespace)
 	_, err = resource.CreateOrUpdate(ctx, opts.CRClient, resourceSlice, func() error {
 		return forge.MutateResourceSlice(resourceSlice, o.RemoteClusterID.GetClusterID(), &forge.ResourceSliceOptions{
-			Class:     authv1beta1.ResourceSliceClass(o.Class),
-			Resources: o.buildResourceMap(),
+			Class:        authv1beta1.ResourceSliceClass(o.Class),
+			Resources:    o.buildResourceMap(),
+			MaxResources: o.buildMaxResourceMap(),
 		}, !o.DisableVirtualNodeCreation)
 	})
 	if err != nil {
@@ -172,6 +181,14 @@ func (o *Options) buildResourceMap() map[corev1.ResourceName]string {
 	return resources
 }
 
+func (o *Options) buildMaxResourceMap() map[corev1.ResourceName]string {
+	return map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    o.MaxCPU,
+		corev1.ResourceMemory: o.MaxMemory,
+		corev1.ResourcePods:   o.MaxPods,
+	}
+}
+
 // output implements the logic to output the generated ResourceSlice resource.
 func (o *Options) output(ctx context.Context) error {
 	opts := o.CreateOptions
@@ -190,14 +207,24 @@ func (o *Options) output(ctx context.Context) error {
 		return err
 	}
 
-	resourceSlice := forge.ResourceSlice(opts.Name, namespace)
-	err = forge.MutateResourceSlice(resourceSlice, o.RemoteClusterID.GetClusterID(), &forge.ResourceSliceOptions{
-		Class:     authv1beta1.ResourceSliceClass(o.Class),
-		Resources: o.buildResourceMap(),
-	}, !o.DisableVirtualNodeCreation)
+	resourceSlice, err := o.Forge(namespace)
 	if err != nil {
 		return err
 	}
 
 	return printer.PrintObj(resourceSlice, os.Stdout)
 }
+
+// Forge returns the ResourceSlice described by the options, in the given tenant namespace.
+func (o *Options) Forge(namespace string) (*authv1beta1.ResourceSlice, error) {
+	resourceSlice := forge.ResourceSlice(o.CreateOptions.Name, namespace)
+	err := forge.MutateResourceSlice(resourceSlice, o.RemoteClusterID.GetClusterID(), &forge.ResourceSliceOptions{
+		Class:        authv1beta1.ResourceSliceClass(o.Class),
+		Resources:    o.buildResourceMap(),
+		MaxResources: o.buildMaxResourceMap(),
+	}, !o.DisableVirtualNodeCreation)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSlice, nil
+}
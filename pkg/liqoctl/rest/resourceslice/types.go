@@ -33,6 +33,10 @@ type Options struct {
 	Memory         string
 	Pods           string
 	OtherResources map[string]string
+
+	MaxCPU    string
+	MaxMemory string
+	MaxPods   string
 }
 
 var _ rest.API = &Options{}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
@@ -33,6 +34,8 @@ import (
 	"github.com/liqotech/liqo/pkg/utils/getters"
 )
 
+const revocationReason = "revoked through liqoctl unauthenticate"
+
 // Cluster contains the information about a cluster.
 type Cluster struct {
 	local  *factory.Factory
@@ -142,6 +145,36 @@ func (c *Cluster) DeleteTenant(ctx context.Context, consumerClusterID liqov1beta
 	return nil
 }
 
+// RevokeTenant revokes all the identities granted to the consumer cluster, given its cluster id.
+// The tenant is preserved, so that the revocation keeps being enforced until it is explicitly lifted.
+func (c *Cluster) RevokeTenant(ctx context.Context, consumerClusterID liqov1beta1.ClusterID) error {
+	s := c.local.Printer.StartSpinner("Revoking tenant identities")
+	tenantNamespace, err := c.tenantNamespaceManager.GetNamespace(ctx, consumerClusterID)
+	if err != nil {
+		s.Fail("Error while retrieving tenant namespace: ", output.PrettyErr(err))
+		return err
+	}
+
+	tenant, err := getters.GetTenantByClusterID(ctx, c.local.CRClient, consumerClusterID, tenantNamespace.Name)
+	if err != nil {
+		s.Fail("Error while retrieving tenant: ", output.PrettyErr(err))
+		return err
+	}
+
+	original := tenant.DeepCopy()
+	tenant.Spec.RevokedIdentities = []authv1beta1.RevokedIdentity{
+		{Type: authv1beta1.ControlPlaneIdentityType, Reason: revocationReason},
+		{Type: authv1beta1.ResourceSliceIdentityType, Reason: revocationReason},
+	}
+	if err := c.local.CRClient.Patch(ctx, tenant, client.MergeFrom(original)); err != nil {
+		s.Fail("Error while revoking tenant identities: ", output.PrettyErr(err))
+		return err
+	}
+	s.Success("Tenant identities correctly revoked")
+
+	return nil
+}
+
 // DeleteTenantNamespace deletes a tenant namespace given the remote cluster id.
 func (c *Cluster) DeleteTenantNamespace(ctx context.Context, remoteClusterID liqov1beta1.ClusterID, waitForActualDeletion bool) error {
 	s := c.local.Printer.StartSpinner("Deleting tenant namespace")
@@ -28,6 +28,7 @@ type Options struct {
 
 	Timeout time.Duration
 	Wait    bool
+	Revoke  bool
 }
 
 // NewOptions returns a new Options struct.
@@ -41,6 +42,8 @@ func NewOptions(localFactory *factory.Factory) *Options {
 // In the consumer cluster, it deletes the control plane Identity.
 // In the provider cluster, it deletes the Tenant.
 // The execution is prevented if any ResourceSlice or VirtualNode associated with the provider cluster is found.
+// If Revoke is set, the identities granted to the consumer are immediately revoked and the Tenant is preserved
+// to keep enforcing the revocation, regardless of the leftover resources on the consumer cluster.
 func (o *Options) RunUnauthenticate(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
 	defer cancel()
@@ -57,6 +60,10 @@ func (o *Options) RunUnauthenticate(ctx context.Context) error {
 		return err
 	}
 
+	if o.Revoke {
+		return o.revoke(ctx, consumer, provider)
+	}
+
 	// Check if any resourceslice is still present on consumer cluster
 	if err := consumer.CheckLeftoverResourceSlices(ctx, provider.localClusterID); err != nil {
 		return err
@@ -74,3 +81,19 @@ func (o *Options) RunUnauthenticate(ctx context.Context) error {
 
 	return nil
 }
+
+func (o *Options) revoke(ctx context.Context, consumer, provider *Cluster) error {
+	// Revoke the identities on the provider cluster first, as it is the step taking effect on the granted permissions.
+	if err := provider.RevokeTenant(ctx, consumer.localClusterID); err != nil {
+		return err
+	}
+
+	// Delete control plane Identity on consumer cluster
+	if err := consumer.DeleteControlPlaneIdentity(ctx, provider.localClusterID); err != nil {
+		return err
+	}
+
+	o.RemoteFactory.Printer.Info.Println("The Tenant has been preserved to keep enforcing the revocation. " +
+		"Delete it, or authenticate the clusters again, to lift the revocation")
+	return nil
+}
@@ -332,6 +332,58 @@ func (w *Waiter) ForConnectionEstablished(ctx context.Context, conn *networkingv
 	return nil
 }
 
+// ForKeysRotationStep waits until the given step of the keys rotation has been performed on the gateway keys secret.
+func (w *Waiter) ForKeysRotationStep(ctx context.Context, secret *corev1.Secret, step string) error {
+	s := w.Printer.StartSpinner(fmt.Sprintf("Waiting for keys rotation step %q to be performed", step))
+	err := wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		err = w.CRClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		switch step {
+		case consts.RotateKeysPrepare:
+			_, ok := secret.Data[consts.NextPublicKeyField]
+			return ok, nil
+		default:
+			_, ok := secret.Annotations[consts.RotateKeysAnnotation]
+			return !ok, nil
+		}
+	})
+	if err != nil {
+		s.Fail(fmt.Sprintf("Failed waiting for keys rotation step %q to be performed: %s", step, output.PrettyErr(err)))
+		return err
+	}
+	s.Success(fmt.Sprintf("Keys rotation step %q performed", step))
+	return nil
+}
+
+// ForPublicKeyPhase waits until the current generation of the PublicKey has been observed by the gateway,
+// and the PublicKey is in one of the given phases.
+func (w *Waiter) ForPublicKeyPhase(ctx context.Context, pk *networkingv1beta1.PublicKey, phases ...networkingv1beta1.PublicKeyPhase) error {
+	s := w.Printer.StartSpinner("Waiting for PublicKey to be configured")
+	err := wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		err = w.CRClient.Get(ctx, client.ObjectKeyFromObject(pk), pk)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if pk.Status.ObservedGeneration != pk.Generation {
+			return false, nil
+		}
+		for _, phase := range phases {
+			if pk.Status.Phase == phase {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		s.Fail(fmt.Sprintf("Failed waiting for PublicKey to be configured: %s", output.PrettyErr(err)))
+		return err
+	}
+	s.Success(fmt.Sprintf("PublicKey is %s", pk.Status.Phase))
+	return nil
+}
+
 // ForNonce waits until the secret containing the nonce has been created or the timeout expires.
 // If tenantNamespace is empty this function searches in all the namespaces in the cluster.
 func (w *Waiter) ForNonce(ctx context.Context, remoteClusterID liqov1beta1.ClusterID, tenantNamespace string, silent bool) error {
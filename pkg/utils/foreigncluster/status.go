@@ -16,10 +16,10 @@ package foreigncluster
 
 import liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 
-// IsNetworkingEstablished checks if the networking is established.
+// IsNetworkingEstablished checks if the networking is established (even if degraded).
 func IsNetworkingEstablished(foreignCluster *liqov1beta1.ForeignCluster) bool {
 	curPhase := GetStatus(foreignCluster.Status.Modules.Networking.Conditions, liqov1beta1.NetworkConnectionStatusCondition)
-	return curPhase == liqov1beta1.ConditionStatusEstablished
+	return curPhase == liqov1beta1.ConditionStatusEstablished || curPhase == liqov1beta1.ConditionStatusDegraded
 }
 
 // IsNetworkingEstablishedOrDisabled checks if the networking is established or if the liqo networking module is disabled.
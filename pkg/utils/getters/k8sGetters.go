@@ -74,6 +74,16 @@ func ListNamespaceMapsByLabel(ctx context.Context, cl client.Client,
 	return namespaceMapList.Items, nil
 }
 
+// ListExportedServicesByLabel returns the ExportedServices that match the given label selector.
+func ListExportedServicesByLabel(ctx context.Context, cl client.Client,
+	ns string, lSelector labels.Selector) ([]offloadingv1beta1.ExportedService, error) {
+	var exportedServiceList offloadingv1beta1.ExportedServiceList
+	if err := cl.List(ctx, &exportedServiceList, client.MatchingLabelsSelector{Selector: lSelector}, client.InNamespace(ns)); err != nil {
+		return nil, err
+	}
+	return exportedServiceList.Items, nil
+}
+
 // GetServiceByLabel it returns a service instance that matches the given label selector.
 func GetServiceByLabel(ctx context.Context, cl client.Client, ns string, lSelector labels.Selector) (*corev1.Service, error) {
 	list := new(corev1.ServiceList)
@@ -446,6 +456,16 @@ func ListResourceSlicesByClusterID(ctx context.Context, cl client.Client,
 	return resSlices, nil
 }
 
+// ListPeeringAuditEventsByClusterID returns the list of PeeringAuditEvents recording the operations of the given cluster.
+func ListPeeringAuditEventsByClusterID(ctx context.Context, cl client.Client,
+	remoteClusterID liqov1beta1.ClusterID) ([]liqov1beta1.PeeringAuditEvent, error) {
+	var list liqov1beta1.PeeringAuditEventList
+	if err := cl.List(ctx, &list, client.MatchingLabels{consts.RemoteClusterID: string(remoteClusterID)}); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
 // GetKubeconfigSecretFromIdentity returns the Secret referenced in the status of the given Identity.
 func GetKubeconfigSecretFromIdentity(ctx context.Context, cl client.Client, identity *authv1beta1.Identity) (*corev1.Secret, error) {
 	if identity.Status.KubeconfigSecretRef == nil || identity.Status.KubeconfigSecretRef.Name == "" {
@@ -470,6 +490,15 @@ func ListShadowPodsByCreator(ctx context.Context, cl client.Client, creator stri
 	return list, nil
 }
 
+// ListShadowWorkloadsByCreator returns the list of ShadowWorkloads created by the given user.
+func ListShadowWorkloadsByCreator(ctx context.Context, cl client.Client, creator string) (*offloadingv1beta1.ShadowWorkloadList, error) {
+	list := new(offloadingv1beta1.ShadowWorkloadList)
+	if err := cl.List(ctx, list, client.MatchingLabels{consts.CreatorLabelKey: resource.EscapeLabel(creator)}); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 // GetQuotaByUser returns the list of Quotas for the given user.
 func GetQuotaByUser(ctx context.Context, cl client.Client,
 	user string) (*offloadingv1beta1.Quota, error) {
@@ -22,6 +22,20 @@ import (
 
 // GenerateKubeconfig generates a kubeconfig file with the provided user, cluster, server, and certificate data.
 func GenerateKubeconfig(user, cluster, server string, ca, clientCertificate, clientKey []byte, proxyURL, namespace *string) ([]byte, error) {
+	return generateKubeconfig(user, cluster, server, ca, &clientcmdapi.AuthInfo{
+		ClientKeyData:         clientKey,
+		ClientCertificateData: clientCertificate,
+	}, proxyURL, namespace)
+}
+
+// GenerateKubeconfigWithToken generates a kubeconfig file with the provided user, cluster, server, and bearer token.
+func GenerateKubeconfigWithToken(user, cluster, server string, ca []byte, token string, proxyURL, namespace *string) ([]byte, error) {
+	return generateKubeconfig(user, cluster, server, ca, &clientcmdapi.AuthInfo{
+		Token: token,
+	}, proxyURL, namespace)
+}
+
+func generateKubeconfig(user, cluster, server string, ca []byte, authInfo *clientcmdapi.AuthInfo, proxyURL, namespace *string) ([]byte, error) {
 	clusters := make(map[string]*clientcmdapi.Cluster)
 	clusters[cluster] = &clientcmdapi.Cluster{
 		Server:                   server,
@@ -37,10 +51,7 @@ func GenerateKubeconfig(user, cluster, server string, ca, clientCertificate, cli
 	}
 
 	authinfos := make(map[string]*clientcmdapi.AuthInfo)
-	authinfos[user] = &clientcmdapi.AuthInfo{
-		ClientKeyData:         clientKey,
-		ClientCertificateData: clientCertificate,
-	}
+	authinfos[user] = authInfo
 
 	clientConfig := clientcmdapi.Config{
 		Kind:           "Config",
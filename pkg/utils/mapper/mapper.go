@@ -32,6 +32,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
@@ -115,6 +116,11 @@ func addDefaults(dClient *discovery.DiscoveryClient, mapper *meta.DefaultRESTMap
 		return err
 	}
 
+	// Multi-Cluster Services API group
+	if err = addGroup(dClient, mcsv1alpha1.SchemeGroupVersion, mapper, GroupOptional); err != nil {
+		return err
+	}
+
 	mapper.Add(schema.GroupVersionKind{
 		Group:   "",
 		Version: "v1",
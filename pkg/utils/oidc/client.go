@@ -0,0 +1,276 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// GrantTypeClientCredentials is the grant used by the client to obtain its own access token (RFC 6749).
+	GrantTypeClientCredentials = "client_credentials"
+	// GrantTypeTokenExchange is the grant used to exchange the client access token for a token
+	// issued to another subject (RFC 8693).
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// TokenTypeAccessToken identifies an OAuth 2.0 access token (RFC 8693).
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	// TokenTypeIDToken identifies an OpenID Connect ID token (RFC 8693).
+	TokenTypeIDToken = "urn:ietf:params:oauth:token-type:id_token"
+
+	// SubjectTokenParam is the token exchange parameter carrying the token of the requesting client.
+	SubjectTokenParam = "subject_token"
+	// SubjectTokenTypeParam is the token exchange parameter carrying the type of the subject token.
+	SubjectTokenTypeParam = "subject_token_type"
+	// RequestedTokenTypeParam is the token exchange parameter carrying the type of the requested token.
+	RequestedTokenTypeParam = "requested_token_type"
+	// AudienceParam is the token exchange parameter carrying the audience of the token.
+	AudienceParam = "audience"
+	// RequestedSubjectParam is the token exchange parameter carrying the subject the token is issued for.
+	// It is not defined by RFC 8693, but it is the extension used by the providers supporting impersonation
+	// through the token exchange (e.g., Keycloak).
+	RequestedSubjectParam = "requested_subject"
+	// RequestedGroupsParam is the token exchange parameter carrying the groups the subject belongs to.
+	// It is not defined by RFC 8693: the providers not supporting it must be configured to map the
+	// subject to the requested groups.
+	RequestedGroupsParam = "requested_groups"
+)
+
+// Client requests tokens to an OpenID Connect provider on behalf of other subjects. The client first
+// obtains its own access token through the client credentials grant, and then exchanges it for an ID token
+// issued to the requested subject through the token exchange grant (RFC 8693).
+type Client struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	// mu protects the tokenEndpoint, which may be discovered lazily by concurrent requests.
+	mu            sync.Mutex
+	tokenEndpoint string
+}
+
+// TokenRequest describes the identity a token is requested for.
+type TokenRequest struct {
+	Subject  string
+	Groups   []string
+	Audience string
+}
+
+// Token is a token issued by the OpenID Connect provider.
+type Token struct {
+	Value          string
+	IssueTime      time.Time
+	ExpirationTime time.Time
+}
+
+type discoveryDocument struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	IssuedTokenType  string `json:"issued_token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type tokenClaims struct {
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+// NewClient returns a new Client for the given issuer. When tokenEndpoint is empty, it is discovered
+// from the issuer metadata at the first token request. A nil httpClient defaults to http.DefaultClient.
+func NewClient(issuerURL, clientID, clientSecret, tokenEndpoint string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		issuerURL:     strings.TrimSuffix(issuerURL, "/"),
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		httpClient:    httpClient,
+		tokenEndpoint: tokenEndpoint,
+	}
+}
+
+// TokenEndpoint returns the token endpoint of the provider, discovering it if not yet known.
+func (c *Client) TokenEndpoint(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tokenEndpoint != "" {
+		return c.tokenEndpoint, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.issuerURL+discoveryPath, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to retrieve the OIDC discovery document: unexpected status %s", resp.Status)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to decode the OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != c.issuerURL {
+		return "", fmt.Errorf("the OIDC discovery document refers to issuer %q instead of %q", doc.Issuer, c.issuerURL)
+	}
+	if doc.TokenEndpoint == "" {
+		return "", fmt.Errorf("the OIDC discovery document does not advertise a token endpoint")
+	}
+
+	c.tokenEndpoint = doc.TokenEndpoint
+	return c.tokenEndpoint, nil
+}
+
+// IssueToken requests a new token for the given subject, exchanging the client access token for an ID token
+// issued to the subject. The ID token is preferred over the access token when both are returned,
+// since it is the one validated by the Kubernetes API server.
+func (c *Client) IssueToken(ctx context.Context, request *TokenRequest) (*Token, error) {
+	endpoint, err := c.TokenEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", GrantTypeClientCredentials)
+	clientToken, err := c.requestToken(ctx, endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain the client access token: %w", err)
+	}
+	if clientToken.AccessToken == "" {
+		return nil, fmt.Errorf("failed to obtain the client access token: the response does not contain any access token")
+	}
+
+	form = url.Values{}
+	form.Set("grant_type", GrantTypeTokenExchange)
+	form.Set(SubjectTokenParam, clientToken.AccessToken)
+	form.Set(SubjectTokenTypeParam, TokenTypeAccessToken)
+	form.Set(RequestedTokenTypeParam, TokenTypeIDToken)
+	form.Set("scope", "openid")
+	form.Set(RequestedSubjectParam, request.Subject)
+	for _, group := range request.Groups {
+		form.Add(RequestedGroupsParam, group)
+	}
+	if request.Audience != "" {
+		form.Set(AudienceParam, request.Audience)
+	}
+
+	now := time.Now()
+	tr, err := c.requestToken(ctx, endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the token for subject %q: %w", request.Subject, err)
+	}
+
+	token := &Token{Value: tr.IDToken, IssueTime: now}
+	if token.Value == "" && (tr.IssuedTokenType == TokenTypeIDToken || tr.IssuedTokenType == "") {
+		// RFC 8693 returns the issued token in the access_token field, whatever its type.
+		token.Value = tr.AccessToken
+	}
+	if token.Value == "" {
+		return nil, fmt.Errorf("the OIDC token response does not contain any ID token")
+	}
+
+	if tr.ExpiresIn > 0 {
+		token.ExpirationTime = now.Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	// The claims, when available, are more accurate than the expires_in field.
+	if claims, err := parseClaims(token.Value); err == nil {
+		if claims.IssuedAt > 0 {
+			token.IssueTime = time.Unix(claims.IssuedAt, 0)
+		}
+		if claims.ExpiresAt > 0 {
+			token.ExpirationTime = time.Unix(claims.ExpiresAt, 0)
+		}
+	}
+	if token.ExpirationTime.IsZero() {
+		return nil, fmt.Errorf("unable to determine the expiration time of the OIDC token")
+	}
+
+	return token, nil
+}
+
+// requestToken performs a token request to the given endpoint, authenticating with the client credentials.
+func (c *Client) requestToken(ctx context.Context, endpoint string, form url.Values) (*tokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request the OIDC token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OIDC token response: %w", err)
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("failed to decode the OIDC token response (status %s): %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the OIDC provider refused to issue the token (status %s): %s %s",
+			resp.Status, tr.Error, tr.ErrorDescription)
+	}
+	return &tr, nil
+}
+
+// parseClaims extracts the time-related claims from a JWT, without verifying its signature.
+// The token is received directly from the issuer over a trusted connection.
+func parseClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("the token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the token payload: %w", err)
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode the token claims: %w", err)
+	}
+	return &claims, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/liqotech/liqo/pkg/utils/oidc"
+	"github.com/liqotech/liqo/pkg/utils/oidc/fake"
+)
+
+var _ = Describe("OIDC client", func() {
+	var (
+		ctx    context.Context
+		server *fake.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = fake.NewServer("liqo", "secret", time.Hour)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should discover the token endpoint from the issuer", func() {
+		client := oidc.NewClient(server.URL+"/", "liqo", "secret", "", nil)
+		Expect(client.TokenEndpoint(ctx)).To(Equal(server.URL + "/token"))
+	})
+
+	It("should issue a token for the requested subject", func() {
+		client := oidc.NewClient(server.URL, "liqo", "secret", "", nil)
+		token, err := client.IssueToken(ctx, &oidc.TokenRequest{
+			Subject:  "remote-cluster-id",
+			Groups:   []string{"liqo.io"},
+			Audience: "kubernetes",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		issued := server.Issued()
+		Expect(issued).To(HaveLen(1))
+		Expect(issued[0].Subject).To(Equal("remote-cluster-id"))
+		Expect(issued[0].Groups).To(ConsistOf("liqo.io"))
+		Expect(issued[0].Audience).To(Equal("kubernetes"))
+
+		Expect(token.Value).To(Equal(issued[0].Token))
+		Expect(token.ExpirationTime.Sub(token.IssueTime)).To(Equal(time.Hour))
+	})
+
+	It("should issue tokens concurrently, discovering the token endpoint lazily", func() {
+		client := oidc.NewClient(server.URL, "liqo", "secret", "", nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := client.IssueToken(ctx, &oidc.TokenRequest{Subject: "remote-cluster-id"})
+				Expect(err).ToNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+		Expect(server.Issued()).To(HaveLen(5))
+	})
+
+	It("should fail with invalid client credentials", func() {
+		client := oidc.NewClient(server.URL, "liqo", "wrong", "", nil)
+		_, err := client.IssueToken(ctx, &oidc.TokenRequest{Subject: "remote-cluster-id"})
+		Expect(err).To(MatchError(ContainSubstring("invalid_client")))
+		Expect(server.Issued()).To(BeEmpty())
+	})
+
+	It("should use the configured token endpoint without discovery", func() {
+		client := oidc.NewClient("http://unreachable.invalid", "liqo", "secret", server.URL+"/token", nil)
+		_, err := client.IssueToken(ctx, &oidc.TokenRequest{Subject: "remote-cluster-id"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(server.Issued()).To(HaveLen(1))
+	})
+
+	It("should fail when the issuer does not expose the discovery document", func() {
+		client := oidc.NewClient(server.URL+"/dex", "liqo", "secret", "", nil)
+		_, err := client.TokenEndpoint(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,171 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/liqotech/liqo/pkg/utils/oidc"
+)
+
+// IssuedToken records a token issued by the mock provider.
+type IssuedToken struct {
+	Subject  string
+	Groups   []string
+	Audience string
+	Token    string
+}
+
+// Server is a mock OpenID Connect provider, exposing the discovery document and a token endpoint
+// supporting the client credentials and the token exchange grants. The issued ID tokens are unsigned JWTs.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+	TokenTTL     time.Duration
+
+	mu     sync.Mutex
+	issued []IssuedToken
+}
+
+// NewServer starts a new mock provider accepting the given client credentials.
+func NewServer(clientID, clientSecret string, ttl time.Duration) *Server {
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenTTL:     ttl,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Issued returns the tokens issued so far.
+func (s *Server) Issued() []IssuedToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]IssuedToken(nil), s.issued...)
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":         s.URL,
+		"token_endpoint": s.URL + "/token",
+	})
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != s.ClientID || secret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case oidc.GrantTypeClientCredentials:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": s.clientToken(),
+			"token_type":   "bearer",
+			"expires_in":   int64(s.TokenTTL.Seconds()),
+		})
+	case oidc.GrantTypeTokenExchange:
+		s.exchange(w, r)
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+	}
+}
+
+// clientToken returns the opaque access token issued to the client through the client credentials grant.
+func (s *Server) clientToken() string {
+	return "opaque-" + s.ClientID
+}
+
+func (s *Server) exchange(w http.ResponseWriter, r *http.Request) {
+	if r.PostForm.Get(oidc.SubjectTokenParam) != s.clientToken() ||
+		r.PostForm.Get(oidc.SubjectTokenTypeParam) != oidc.TokenTypeAccessToken {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if r.PostForm.Get(oidc.RequestedTokenTypeParam) != oidc.TokenTypeIDToken {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	subject := r.PostForm.Get(oidc.RequestedSubjectParam)
+	if subject == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":    s.URL,
+		"sub":    subject,
+		"aud":    r.PostForm.Get(oidc.AudienceParam),
+		"groups": r.PostForm[oidc.RequestedGroupsParam],
+		"iat":    now.Unix(),
+		"exp":    now.Add(s.TokenTTL).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	token := fmt.Sprintf("%s.%s.",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)),
+		base64.RawURLEncoding.EncodeToString(payload))
+
+	s.mu.Lock()
+	s.issued = append(s.issued, IssuedToken{
+		Subject:  subject,
+		Groups:   r.PostForm[oidc.RequestedGroupsParam],
+		Audience: r.PostForm.Get(oidc.AudienceParam),
+		Token:    token,
+	})
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":      token,
+		"issued_token_type": oidc.TokenTypeIDToken,
+		"token_type":        "N_A",
+		"expires_in":        int64(s.TokenTTL.Seconds()),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -18,6 +18,7 @@ import (
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 )
@@ -58,10 +59,14 @@ func IsPodSpecEqual(previous, updated *corev1.PodSpec) bool {
 	// * spec.initContainers[*].image
 	// * spec.activeDeadlineSeconds
 	// * spec.tolerations (only new entries can be added)
+	// * spec.ephemeralContainers (only new entries can be added, through the corresponding subresource)
+	// * spec.containers[*].resources (through the resize subresource)
 	return AreContainersEqual(previous.Containers, updated.Containers) &&
+		AreContainersResourcesEqual(previous.Containers, updated.Containers) &&
 		AreContainersEqual(previous.InitContainers, updated.InitContainers) &&
 		ptr.Equal(previous.ActiveDeadlineSeconds, updated.ActiveDeadlineSeconds) &&
-		len(previous.Tolerations) == len(updated.Tolerations)
+		len(previous.Tolerations) == len(updated.Tolerations) &&
+		len(previous.EphemeralContainers) == len(updated.EphemeralContainers)
 }
 
 // CheckShadowPodUpdate returns whether updated equals previous, except for the fields that are allowed to be updated.
@@ -72,17 +77,52 @@ func CheckShadowPodUpdate(previous, updated *corev1.PodSpec) bool {
 	// * spec.initContainers[*].image
 	// * spec.activeDeadlineSeconds
 	// * spec.tolerations (only new entries can be added)
+	// * spec.ephemeralContainers (only new entries can be added, through the corresponding subresource)
+	// * spec.containers[*].resources (through the resize subresource)
+	if !AreEphemeralContainersAppended(previous.EphemeralContainers, updated.EphemeralContainers) {
+		return false
+	}
+	// The containers can be neither added nor removed, and the mutable fields are restored matching them by name.
+	if len(updated.Containers) != len(previous.Containers) || len(updated.InitContainers) != len(previous.InitContainers) {
+		return false
+	}
 	for i := range updated.Containers {
-		updated.Containers[i].Image = previous.Containers[i].Image
+		container := containerByName(previous.Containers, updated.Containers[i].Name)
+		if container == nil {
+			return false
+		}
+		updated.Containers[i].Image = container.Image
+		updated.Containers[i].Resources = container.Resources
 	}
 	for i := range updated.InitContainers {
-		updated.InitContainers[i].Image = previous.InitContainers[i].Image
+		container := containerByName(previous.InitContainers, updated.InitContainers[i].Name)
+		if container == nil {
+			return false
+		}
+		updated.InitContainers[i].Image = container.Image
 	}
 	updated.ActiveDeadlineSeconds = previous.ActiveDeadlineSeconds
 	updated.Tolerations = previous.Tolerations
+	updated.EphemeralContainers = previous.EphemeralContainers
 	return reflect.DeepEqual(previous, updated)
 }
 
+// containerByName returns the container with the given name, or nil if not found.
+func containerByName(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+// AreEphemeralContainersAppended returns whether the updated ephemeral containers only append new entries
+// to the previous ones, as the existing ephemeral containers cannot be either modified or removed.
+func AreEphemeralContainersAppended(previous, updated []corev1.EphemeralContainer) bool {
+	return len(updated) >= len(previous) && equality.Semantic.DeepEqual(previous, updated[:len(previous)])
+}
+
 // AreContainersEqual returns whether two container lists are equal according to the
 // fields that can be modified after start-up time (i.e. the image field).
 func AreContainersEqual(previous, updated []corev1.Container) bool {
@@ -106,6 +146,19 @@ outer:
 	return true
 }
 
+// AreContainersResourcesEqual returns whether the resources of the containers with the same name are equal,
+// that is whether the updated containers correspond to an in-place resize of the previous ones.
+func AreContainersResourcesEqual(previous, updated []corev1.Container) bool {
+	for i := range previous {
+		for j := range updated {
+			if previous[i].Name == updated[j].Name && !equality.Semantic.DeepEqual(previous[i].Resources, updated[j].Resources) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // ForgeContainerResources forges the container resource requirements, leaving unset the ones not specified.
 func ForgeContainerResources(cpuRequests, cpuLimits, ramRequests, ramLimits resource.Quantity) corev1.ResourceRequirements {
 	configure := func(rl corev1.ResourceList, key corev1.ResourceName, value resource.Quantity) {
@@ -110,6 +110,124 @@ var _ = Describe("Pod utility functions", func() {
 				updated:  corev1.PodSpec{ActiveDeadlineSeconds: nil},
 				expected: BeFalse(),
 			}),
+			Entry("more ephemeral containers are present", TestCase{
+				previous: corev1.PodSpec{},
+				updated: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "foo"}}}},
+				expected: BeFalse(),
+			}),
+			Entry("the resources of a container are different", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}}}},
+				updated: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")}}}}},
+				expected: BeFalse(),
+			}),
+		)
+	})
+
+	Describe("The CheckShadowPodUpdate function", func() {
+		type TestCase struct {
+			previous corev1.PodSpec
+			updated  corev1.PodSpec
+			expected types.GomegaMatcher
+		}
+
+		debugger := corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}}
+		other := corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "other", Image: "alpine"}}
+
+		DescribeTable("tests table",
+			func(c TestCase) {
+				Expect(pod.CheckShadowPodUpdate(&c.previous, &c.updated)).To(c.expected)
+			},
+			Entry("both specs are empty", TestCase{expected: BeTrue()}),
+			Entry("the image of a container is changed", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}}},
+				updated:  corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "baz"}}},
+				expected: BeTrue(),
+			}),
+			Entry("a non mutable field is changed", TestCase{
+				previous: corev1.PodSpec{Hostname: "foo"},
+				updated:  corev1.PodSpec{Hostname: "bar"},
+				expected: BeFalse(),
+			}),
+			Entry("an ephemeral container is added", TestCase{
+				previous: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{debugger}},
+				updated:  corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{debugger, other}},
+				expected: BeTrue(),
+			}),
+			Entry("an ephemeral container is removed", TestCase{
+				previous: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{debugger, other}},
+				updated:  corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{debugger}},
+				expected: BeFalse(),
+			}),
+			Entry("an existing ephemeral container is modified", TestCase{
+				previous: corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{debugger}},
+				updated:  corev1.PodSpec{EphemeralContainers: []corev1.EphemeralContainer{other}},
+				expected: BeFalse(),
+			}),
+			Entry("the resources of a container are resized", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}}}},
+				updated: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")}}}}},
+				expected: BeTrue(),
+			}),
+			Entry("a container is added", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}}},
+				updated:  corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}, {Name: "baz", Image: "bar"}}},
+				expected: BeFalse(),
+			}),
+			Entry("an init container is added", TestCase{
+				previous: corev1.PodSpec{InitContainers: []corev1.Container{{Name: "foo", Image: "bar"}}},
+				updated:  corev1.PodSpec{InitContainers: []corev1.Container{{Name: "foo", Image: "bar"}, {Name: "baz", Image: "bar"}}},
+				expected: BeFalse(),
+			}),
+			Entry("a container is removed", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}, {Name: "baz", Image: "bar"}}},
+				updated:  corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}}},
+				expected: BeFalse(),
+			}),
+			Entry("a container is renamed", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}}},
+				updated:  corev1.PodSpec{Containers: []corev1.Container{{Name: "baz", Image: "bar"}}},
+				expected: BeFalse(),
+			}),
+			Entry("the containers are reordered", TestCase{
+				previous: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: "bar"}, {Name: "baz", Image: "bar"}}},
+				updated:  corev1.PodSpec{Containers: []corev1.Container{{Name: "baz", Image: "bar"}, {Name: "foo", Image: "bar"}}},
+				expected: BeFalse(),
+			}),
+		)
+	})
+
+	Describe("The AreContainersResourcesEqual function", func() {
+		type TestCase struct {
+			previous []corev1.Container
+			updated  []corev1.Container
+			expected types.GomegaMatcher
+		}
+
+		Container := func(name, cpu string) corev1.Container {
+			return corev1.Container{Name: name, Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}}
+		}
+
+		DescribeTable("tests table",
+			func(c TestCase) {
+				Expect(pod.AreContainersResourcesEqual(c.previous, c.updated)).To(c.expected)
+			},
+			Entry("both lists are nil", TestCase{expected: BeTrue()}),
+			Entry("the resources are equal", TestCase{
+				previous: []corev1.Container{Container("foo", "1"), Container("bar", "2")},
+				updated:  []corev1.Container{Container("bar", "2"), Container("foo", "1000m")},
+				expected: BeTrue(),
+			}),
+			Entry("the resources of a container are different", TestCase{
+				previous: []corev1.Container{Container("foo", "1"), Container("bar", "2")},
+				updated:  []corev1.Container{Container("foo", "1"), Container("bar", "3")},
+				expected: BeFalse(),
+			}),
 		)
 	})
 
@@ -23,12 +23,36 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 )
 
-// EndpointSliceManagedBy -> The manager associated with the reflected EndpointSlices.
-const EndpointSliceManagedBy = "endpointslice.reflection.liqo.io"
+const (
+	// EndpointSliceManagedBy -> The manager associated with the reflected EndpointSlices.
+	EndpointSliceManagedBy = "endpointslice.reflection.liqo.io"
+	// ClusterZonePrefix -> The prefix of the synthetic zones identifying the clusters hosting the reflected endpoints.
+	ClusterZonePrefix = "liqo-"
+)
+
+// EndpointZoneMode defines how the zone of the reflected endpoints is determined.
+type EndpointZoneMode string
+
+const (
+	// EndpointZoneModeReal -> the reflected endpoints carry the zone and the hints reported by the cluster hosting them, verbatim.
+	// Hence, the remote nodes belonging to a zone with the same name prefer these endpoints, even if across the WAN.
+	EndpointZoneModeReal EndpointZoneMode = "real"
+	// EndpointZoneModeCluster -> the reflected endpoints carry the synthetic zone of the cluster hosting them.
+	EndpointZoneModeCluster EndpointZoneMode = "cluster"
+)
+
+// EndpointTopologyOpts contains the options to forge the topology information of the reflected endpoints.
+type EndpointTopologyOpts struct {
+	ZoneMode EndpointZoneMode
+	// PreferSameCluster hints the reflected endpoints only for the synthetic zone of the cluster hosting them,
+	// so that the remote kube-proxy prefers the endpoints natively present in the remote cluster, if any.
+	PreferSameCluster bool
+}
 
 // EndpointTranslator defines the function to translate between local and remote endpoint addresses.
 type EndpointTranslator func([]string) []string
@@ -74,10 +98,32 @@ func EndpointToBeReflected(endpoint *discoveryv1.Endpoint, localNodeClient corev
 	return epNodeClusterID != string(RemoteCluster)
 }
 
+// ClusterZone returns the synthetic zone identifying the given cluster.
+func ClusterZone(clusterID liqov1beta1.ClusterID) string {
+	return ClusterZonePrefix + string(clusterID)
+}
+
+// EndpointClusterID returns the ID of the cluster hosting the given endpoint, from the local point of view:
+// endpoints referring to a virtual node are hosted by the corresponding remote cluster, all others by the local one.
+func EndpointClusterID(endpoint *discoveryv1.Endpoint, localNodeClient corev1listers.NodeLister) liqov1beta1.ClusterID {
+	if endpoint.NodeName == nil {
+		return LocalCluster
+	}
+
+	node, err := localNodeClient.Get(*endpoint.NodeName)
+	if err != nil {
+		return LocalCluster
+	}
+	if clusterID, err := getters.RetrieveRemoteClusterIDFromNode(node); err == nil && clusterID != "" {
+		return liqov1beta1.ClusterID(clusterID)
+	}
+	return LocalCluster
+}
+
 // RemoteShadowEndpointSlice forges the remote shadowendpointslice, given the local endpointslice.
 func RemoteShadowEndpointSlice(local *discoveryv1.EndpointSlice, remote *offloadingv1beta1.ShadowEndpointSlice,
 	localNodeClient corev1listers.NodeLister, targetNamespace string, translator EndpointTranslator,
-	forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowEndpointSlice {
+	topologyOpts *EndpointTopologyOpts, forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowEndpointSlice {
 	if remote == nil {
 		// The remote is nil if not already created.
 		remote = &offloadingv1beta1.ShadowEndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: local.GetName(), Namespace: targetNamespace}}
@@ -88,7 +134,7 @@ func RemoteShadowEndpointSlice(local *discoveryv1.EndpointSlice, remote *offload
 		Spec: offloadingv1beta1.ShadowEndpointSliceSpec{
 			Template: offloadingv1beta1.EndpointSliceTemplate{
 				AddressType: local.AddressType,
-				Endpoints:   RemoteEndpointSliceEndpoints(local.Endpoints, localNodeClient, translator),
+				Endpoints:   RemoteEndpointSliceEndpoints(local.Endpoints, localNodeClient, translator, topologyOpts),
 				Ports:       RemoteEndpointSlicePorts(local.Ports),
 			},
 		},
@@ -107,7 +153,7 @@ func RemoteEndpointSliceObjectMeta(local, remote *metav1.ObjectMeta, forgingOpts
 
 // RemoteEndpointSliceEndpoints forges the endpoints of the reflected endpointslice, given the local ones.
 func RemoteEndpointSliceEndpoints(locals []discoveryv1.Endpoint, localNodeClient corev1listers.NodeLister,
-	translator EndpointTranslator) []discoveryv1.Endpoint {
+	translator EndpointTranslator, topologyOpts *EndpointTopologyOpts) []discoveryv1.Endpoint {
 	var remotes []discoveryv1.Endpoint
 
 	for i := range locals {
@@ -118,6 +164,7 @@ func RemoteEndpointSliceEndpoints(locals []discoveryv1.Endpoint, localNodeClient
 
 		local := locals[i].DeepCopy()
 		conditions := discoveryv1.EndpointConditions{Ready: local.Conditions.Ready}
+		zone, hints := RemoteEndpointTopology(local, EndpointClusterID(local, localNodeClient), topologyOpts)
 
 		remote := discoveryv1.Endpoint{
 			Addresses:  translator(local.Addresses),
@@ -125,8 +172,8 @@ func RemoteEndpointSliceEndpoints(locals []discoveryv1.Endpoint, localNodeClient
 			Hostname:   local.Hostname,
 			TargetRef:  RemoteEndpointTargetRef(local.TargetRef),
 			NodeName:   pointer.String(string(LocalCluster)),
-			Zone:       local.Zone,
-			Hints:      local.Hints,
+			Zone:       zone,
+			Hints:      hints,
 		}
 
 		remotes = append(remotes, remote)
@@ -135,6 +182,31 @@ func RemoteEndpointSliceEndpoints(locals []discoveryv1.Endpoint, localNodeClient
 	return remotes
 }
 
+// RemoteEndpointTopology forges the zone and the hints of the reflected endpoint, given the local one and the ID of the cluster hosting it.
+func RemoteEndpointTopology(local *discoveryv1.Endpoint, clusterID liqov1beta1.ClusterID,
+	topologyOpts *EndpointTopologyOpts) (*string, *discoveryv1.EndpointHints) {
+	clusterZone := ClusterZone(clusterID)
+
+	switch {
+	case topologyOpts.PreferSameCluster:
+		zone := local.Zone
+		if topologyOpts.ZoneMode == EndpointZoneModeCluster {
+			zone = &clusterZone
+		}
+		// No remote node belongs to the synthetic zone, hence the remote kube-proxy falls back
+		// to the reflected endpoints only in case no native endpoint is available for its zone.
+		return zone, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: clusterZone}}}
+	case topologyOpts.ZoneMode == EndpointZoneModeCluster:
+		if local.Hints == nil {
+			return &clusterZone, nil
+		}
+		// The local hints refer to the zones of the local nodes, which are meaningless in the remote cluster.
+		return &clusterZone, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: clusterZone}}}
+	default:
+		return local.Zone, local.Hints
+	}
+}
+
 // RemoteEndpointTargetRef forges the ObjectReference of the reflected endpoint, given the local one.
 func RemoteEndpointTargetRef(ref *corev1.ObjectReference) *corev1.ObjectReference {
 	if ref == nil {
@@ -25,6 +25,7 @@ import (
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/pointer"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/testutil"
@@ -36,6 +37,11 @@ var _ listerscorev1.NodeLister = &FakeNodeLister{}
 
 type FakeNodeLister struct{}
 
+const (
+	OtherVirtualNodeName                       = "other-virtual-node"
+	OtherClusterID       liqov1beta1.ClusterID = "other-cluster-id"
+)
+
 // List lists all Nodes in the indexer.
 func (fnl *FakeNodeLister) List(_ labels.Selector) (ret []*corev1.Node, err error) {
 	return []*corev1.Node{}, nil
@@ -48,8 +54,11 @@ func (fnl *FakeNodeLister) Get(name string) (*corev1.Node, error) {
 			Name:   name,
 			Labels: map[string]string{},
 		}}
-	if name == LiqoNodeName {
+	switch name {
+	case LiqoNodeName:
 		n.Labels[consts.RemoteClusterID] = string(RemoteClusterID)
+	case OtherVirtualNodeName:
+		n.Labels[consts.RemoteClusterID] = string(OtherClusterID)
 	}
 	return n, nil
 }
@@ -84,7 +93,8 @@ var _ = Describe("EndpointSlices Forging", func() {
 			forgingOpts = testutil.FakeForgingOpts()
 
 			JustBeforeEach(func() {
-				output = forge.RemoteShadowEndpointSlice(input, output, &FakeNodeLister{}, "reflected", Translator, forgingOpts)
+				output = forge.RemoteShadowEndpointSlice(input, output, &FakeNodeLister{}, "reflected", Translator,
+					&forge.EndpointTopologyOpts{}, forgingOpts)
 			})
 
 			It("should correctly set the name and namespace", func() {
@@ -119,9 +129,10 @@ var _ = Describe("EndpointSlices Forging", func() {
 
 	Describe("the RemoteEndpointSliceEndpoints function", func() {
 		var (
-			endpoint discoveryv1.Endpoint
-			input    []discoveryv1.Endpoint
-			output   []discoveryv1.Endpoint
+			endpoint     discoveryv1.Endpoint
+			topologyOpts forge.EndpointTopologyOpts
+			input        []discoveryv1.Endpoint
+			output       []discoveryv1.Endpoint
 		)
 
 		BeforeEach(func() {
@@ -136,10 +147,11 @@ var _ = Describe("EndpointSlices Forging", func() {
 				Hints:     &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone"}}},
 				TargetRef: &corev1.ObjectReference{Kind: "Pod"},
 			}
+			topologyOpts = forge.EndpointTopologyOpts{ZoneMode: forge.EndpointZoneModeReal}
 		})
 
 		JustBeforeEach(func() {
-			output = forge.RemoteEndpointSliceEndpoints(input, &FakeNodeLister{}, Translator)
+			output = forge.RemoteEndpointSliceEndpoints(input, &FakeNodeLister{}, Translator, &topologyOpts)
 		})
 
 		When("translating a single endpoint", func() {
@@ -169,6 +181,56 @@ var _ = Describe("EndpointSlices Forging", func() {
 			})
 		})
 
+		When("translating an endpoint without zone", func() {
+			BeforeEach(func() {
+				endpoint.Zone = nil
+				endpoint.Hints = nil
+				input = []discoveryv1.Endpoint{endpoint}
+			})
+			It("should not assign any zone", func() { Expect(output[0].Zone).To(BeNil()) })
+			It("should not add any hint", func() { Expect(output[0].Hints).To(BeNil()) })
+		})
+
+		When("translating an endpoint hosted by a different remote cluster", func() {
+			BeforeEach(func() {
+				endpoint.NodeName = pointer.String(OtherVirtualNodeName)
+				topologyOpts.ZoneMode = forge.EndpointZoneModeCluster
+				input = []discoveryv1.Endpoint{endpoint}
+			})
+			It("should assign the synthetic zone of the cluster hosting the endpoint", func() {
+				Expect(output[0].Zone).To(PointTo(Equal(forge.ClusterZone(OtherClusterID))))
+			})
+		})
+
+		When("the synthetic per-cluster zones are configured", func() {
+			BeforeEach(func() {
+				topologyOpts.ZoneMode = forge.EndpointZoneModeCluster
+				input = []discoveryv1.Endpoint{endpoint}
+			})
+			It("should replace the zone with the synthetic one", func() {
+				Expect(output[0].Zone).To(PointTo(Equal(forge.ClusterZone(LocalClusterID))))
+			})
+			It("should hint the endpoint for the synthetic zone", func() {
+				Expect(output[0].Hints).To(PointTo(Equal(discoveryv1.EndpointHints{
+					ForZones: []discoveryv1.ForZone{{Name: forge.ClusterZone(LocalClusterID)}}})))
+			})
+		})
+
+		When("same-cluster endpoints are preferred", func() {
+			BeforeEach(func() {
+				topologyOpts.PreferSameCluster = true
+				endpoint.Hints = nil
+				input = []discoveryv1.Endpoint{endpoint}
+			})
+			It("should preserve the real zone", func() {
+				Expect(output[0].Zone).To(PointTo(Equal("target-zone")))
+			})
+			It("should hint the endpoint for the synthetic zone only", func() {
+				Expect(output[0].Hints).To(PointTo(Equal(discoveryv1.EndpointHints{
+					ForZones: []discoveryv1.ForZone{{Name: forge.ClusterZone(LocalClusterID)}}})))
+			})
+		})
+
 		When("translating an endpoint referring to the remote cluster", func() {
 			BeforeEach(func() {
 				endpoint.NodeName = pointer.String(forge.LiqoNodeName)
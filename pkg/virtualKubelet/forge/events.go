@@ -67,6 +67,11 @@ func EventFailedReflectionAlreadyExistsMsg() string {
 	return fmt.Sprintf("Error reflecting object to cluster %q: remote object already exists", RemoteCluster)
 }
 
+// EventUnsupportedWorkloadMsg returns the message for the event when the offloading of a workload is aborted, since not supported.
+func EventUnsupportedWorkloadMsg(reason string) string {
+	return fmt.Sprintf("Error reflecting workload to cluster %q: %s", RemoteCluster, reason)
+}
+
 // EventFailedLabelsUpdateMsg returns the message for the event when it is impossible to update the labels of a local object.
 func EventFailedLabelsUpdateMsg(err error) string {
 	return fmt.Sprintf("Error updating local object labels: %v", err)
@@ -0,0 +1,107 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"fmt"
+	"slices"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	policyv1apply "k8s.io/client-go/applyconfigurations/policy/v1"
+
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+)
+
+// RemotePodDisruptionBudget forges the apply patch for the reflected poddisruptionbudget, given the local one.
+// The healthy parameter is the number of healthy local pods matching the budget which are offloaded through the current virtual node,
+// while the allowed parameter is the share of the disruptions allowed by the local budget granted to the current virtual node.
+func RemotePodDisruptionBudget(local *policyv1.PodDisruptionBudget, healthy, allowed int32,
+	targetNamespace string, forgingOpts *ForgingOpts) *policyv1apply.PodDisruptionBudgetApplyConfiguration {
+	annotations := FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected)
+	delete(annotations, liqoconst.RemotePodDisruptionBudgetStatusAnnotationKey)
+
+	return policyv1apply.PodDisruptionBudget(local.GetName(), targetNamespace).
+		WithLabels(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected)).WithLabels(ReflectionLabels()).
+		WithAnnotations(annotations).
+		WithSpec(RemotePodDisruptionBudgetSpec(local, healthy, allowed))
+}
+
+// RemotePodDisruptionBudgetSpec forges the apply patch for the specs of the reflected poddisruptionbudget, given the local one.
+// The remote budget always leverages an absolute minAvailable value, which does not require the provider cluster to retrieve
+// the scale of the controller owning the pods (i.e., the ShadowPod). Specifically, it is computed such that no more
+// disruptions than the share granted to the current virtual node can happen on the healthy pods offloaded to the remote cluster.
+func RemotePodDisruptionBudgetSpec(local *policyv1.PodDisruptionBudget,
+	healthy, allowed int32) *policyv1apply.PodDisruptionBudgetSpecApplyConfiguration {
+	spec := policyv1apply.PodDisruptionBudgetSpec().
+		WithMinAvailable(intstr.FromInt32(max(0, healthy-allowed))).
+		WithSelector(RemotePodDisruptionBudgetSelector(local.Spec.Selector))
+	if local.Spec.UnhealthyPodEvictionPolicy != nil {
+		spec.WithUnhealthyPodEvictionPolicy(*local.Spec.UnhealthyPodEvictionPolicy)
+	}
+	return spec
+}
+
+// DisruptionsAllowedShare returns the share of the disruptions allowed by a local poddisruptionbudget granted to the given
+// virtual node, given the number of healthy pods offloaded through each virtual node. Each remote cluster enforces its budget
+// independently, hence the allowance is split evenly across the virtual nodes hosting healthy pods (with the remainder granted
+// to the first ones in name order), so that the overall disruptions cannot exceed the ones allowed by the local budget.
+func DisruptionsAllowedShare(allowed int32, healthy map[string]int32, node string) int32 {
+	if allowed <= 0 || healthy[node] <= 0 {
+		return 0
+	}
+
+	var nodes []string
+	for name, count := range healthy {
+		if count > 0 {
+			nodes = append(nodes, name)
+		}
+	}
+	slices.Sort(nodes)
+
+	share := allowed / int32(len(nodes))
+	if int32(slices.Index(nodes, node)) < allowed%int32(len(nodes)) {
+		share++
+	}
+	return share
+}
+
+// RemotePodDisruptionBudgetSelector forges the apply patch for the selector of the reflected poddisruptionbudget,
+// restricting the local one to select only the pods offloaded through the current virtual node.
+// A nil selector is preserved, as selecting no pods at all.
+func RemotePodDisruptionBudgetSelector(local *metav1.LabelSelector) *metav1apply.LabelSelectorApplyConfiguration {
+	if local == nil {
+		return nil
+	}
+
+	selector := metav1apply.LabelSelector().
+		WithMatchLabels(local.MatchLabels).
+		WithMatchLabels(map[string]string{LiqoOriginClusterNodeName: LiqoNodeName})
+	for i := range local.MatchExpressions {
+		expr := &local.MatchExpressions[i]
+		selector.WithMatchExpressions(metav1apply.LabelSelectorRequirement().
+			WithKey(expr.Key).WithOperator(expr.Operator).WithValues(expr.Values...))
+	}
+	return selector
+}
+
+// RemotePodDisruptionBudgetStatusSummary returns a human-readable summary of the status of the given remote poddisruptionbudget,
+// to be reported on the corresponding local one.
+func RemotePodDisruptionBudgetStatusSummary(remote *policyv1.PodDisruptionBudget) string {
+	return fmt.Sprintf("%d/%d healthy, %d disruptions allowed",
+		remote.Status.CurrentHealthy, remote.Status.ExpectedPods, remote.Status.DisruptionsAllowed)
+}
@@ -0,0 +1,142 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	policyv1apply "k8s.io/client-go/applyconfigurations/policy/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("PodDisruptionBudgets Forging", func() {
+	Describe("the RemotePodDisruptionBudget function", func() {
+		var (
+			local   *policyv1.PodDisruptionBudget
+			healthy int32
+			output  *policyv1apply.PodDisruptionBudgetApplyConfiguration
+		)
+
+		BeforeEach(func() {
+			local = &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name", Namespace: "original",
+					Labels: map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+					Annotations: map[string]string{
+						"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true",
+						consts.RemotePodDisruptionBudgetStatusAnnotationKey: "1/1 healthy, 0 disruptions allowed",
+					},
+				},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MaxUnavailable: ptr.To(intstr.FromString("25%")),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "foo"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}},
+						},
+					},
+					UnhealthyPodEvictionPolicy: ptr.To(policyv1.AlwaysAllow),
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			}
+			healthy = 3
+		})
+
+		JustBeforeEach(func() {
+			output = forge.RemotePodDisruptionBudget(local, healthy, local.Status.DisruptionsAllowed, "reflected", testutil.FakeForgingOpts())
+		})
+
+		It("should correctly set the name and namespace", func() {
+			Expect(output.Name).To(PointTo(Equal("name")))
+			Expect(output.Namespace).To(PointTo(Equal("reflected")))
+		})
+
+		It("should correctly set the labels", func() {
+			Expect(output.Labels).To(HaveKeyWithValue("foo", "bar"))
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, string(RemoteClusterID)))
+			Expect(output.Labels).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+		})
+
+		It("should correctly set the annotations", func() {
+			Expect(output.Annotations).To(HaveKeyWithValue("bar", "baz"))
+			Expect(output.Annotations).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+			Expect(output.Annotations).ToNot(HaveKey(consts.RemotePodDisruptionBudgetStatusAnnotationKey))
+		})
+
+		It("should restrict the selector to the pods offloaded through the current virtual node", func() {
+			Expect(output.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app", "foo"))
+			Expect(output.Spec.Selector.MatchLabels).To(HaveKeyWithValue(forge.LiqoOriginClusterNodeName, LiqoNodeName))
+			Expect(output.Spec.Selector.MatchExpressions).To(ConsistOf(*metav1apply.LabelSelectorRequirement().
+				WithKey("tier").WithOperator(metav1.LabelSelectorOpIn).WithValues("frontend")))
+		})
+
+		It("should propagate the unhealthy pod eviction policy", func() {
+			Expect(output.Spec.UnhealthyPodEvictionPolicy).To(PointTo(Equal(policyv1.AlwaysAllow)))
+		})
+
+		It("should translate the budget into an absolute minAvailable value", func() {
+			Expect(output.Spec.MaxUnavailable).To(BeNil())
+			Expect(output.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt32(2))))
+		})
+
+		When("the disruptions allowed locally exceed the healthy offloaded pods", func() {
+			BeforeEach(func() { local.Status.DisruptionsAllowed = 5 })
+
+			It("should not set a negative minAvailable value", func() {
+				Expect(output.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt32(0))))
+			})
+		})
+
+		When("the local selector is nil", func() {
+			BeforeEach(func() { local.Spec.Selector = nil })
+
+			It("should preserve the nil selector", func() {
+				Expect(output.Spec.Selector).To(BeNil())
+			})
+		})
+	})
+
+	DescribeTable("the DisruptionsAllowedShare function",
+		func(allowed int32, healthy map[string]int32, node string, expected int32) {
+			Expect(forge.DisruptionsAllowedShare(allowed, healthy, node)).To(BeNumerically("==", expected))
+		},
+		Entry("a single virtual node", int32(2), map[string]int32{"node-a": 3}, "node-a", int32(2)),
+		Entry("an even split", int32(4), map[string]int32{"node-a": 3, "node-b": 3}, "node-b", int32(2)),
+		Entry("the remainder to the first node", int32(1), map[string]int32{"node-a": 3, "node-b": 3}, "node-a", int32(1)),
+		Entry("no remainder to the following nodes", int32(1), map[string]int32{"node-a": 3, "node-b": 3}, "node-b", int32(0)),
+		Entry("the nodes without healthy pods are ignored", int32(1), map[string]int32{"node-a": 0, "node-b": 3}, "node-b", int32(1)),
+		Entry("no healthy pods on the given node", int32(1), map[string]int32{"node-a": 3}, "node-b", int32(0)),
+		Entry("no disruptions allowed", int32(0), map[string]int32{"node-a": 3}, "node-a", int32(0)),
+	)
+
+	Describe("the RemotePodDisruptionBudgetStatusSummary function", func() {
+		It("should return the correct summary", func() {
+			remote := &policyv1.PodDisruptionBudget{Status: policyv1.PodDisruptionBudgetStatus{
+				CurrentHealthy: 2, ExpectedPods: 3, DisruptionsAllowed: 1,
+			}}
+			Expect(forge.RemotePodDisruptionBudgetStatusSummary(remote)).To(Equal("2/3 healthy, 1 disruptions allowed"))
+		})
+	})
+})
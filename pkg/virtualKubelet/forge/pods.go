@@ -17,6 +17,7 @@ package forge
 import (
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	"github.com/virtual-kubelet/virtual-kubelet/node/api/statsv1alpha1"
@@ -85,7 +86,15 @@ type KubernetesServiceIPGetter func() []string
 func LocalPod(local, remote *corev1.Pod, translator PodIPTranslator, restarts int32, mutators ...RemotePodStatusMutator) *corev1.Pod {
 	return &corev1.Pod{
 		ObjectMeta: *local.ObjectMeta.DeepCopy(),
-		Status:     LocalPodStatus(remote.Status.DeepCopy(), translator, restarts, mutators...),
+		Status:     LocalPodStatus(remote.Status.DeepCopy(), translator, restarts, append(mutators, LocalResourceClaimStatusesMutator(local))...),
+	}
+}
+
+// LocalResourceClaimStatusesMutator is a mutator which preserves the statuses of the resource claims of the local pod,
+// since they refer to the claims generated in the local cluster, rather than to the remote ones.
+func LocalResourceClaimStatusesMutator(local *corev1.Pod) RemotePodStatusMutator {
+	return func(remote *corev1.PodStatus) {
+		remote.ResourceClaimStatuses = local.Status.ResourceClaimStatuses
 	}
 }
 
@@ -210,7 +219,12 @@ func RemotePodSpec(creation bool, local, remote *corev1.PodSpec, mutators ...Rem
 	// Do not mutate the pod specifications after it has been created, since it is likely the modification
 	// would be rejected by the API server, as only a very limited set of fields can be mutated.
 	// Additionally, such modification would not be currently propagated by the remote ShadowPod controller.
+	// The only exceptions are ephemeral containers (e.g., added by kubectl debug), which can only be added to already
+	// existing pods, and the container resources (i.e., in-place resize), which are propagated by the remote ShadowPod
+	// controller through the corresponding subresources.
 	if !creation {
+		remote.EphemeralContainers = RemoteEphemeralContainers(local.EphemeralContainers, remote.EphemeralContainers)
+		RemoteContainersResources(local.Containers, remote.Containers)
 		return *remote
 	}
 
@@ -228,6 +242,7 @@ func RemotePodSpec(creation bool, local, remote *corev1.PodSpec, mutators ...Rem
 	remote.Hostname = local.Hostname
 	remote.ImagePullSecrets = local.ImagePullSecrets
 	remote.ReadinessGates = local.ReadinessGates
+	remote.ResourceClaims = local.ResourceClaims
 	remote.RestartPolicy = local.RestartPolicy
 	remote.SecurityContext = local.SecurityContext
 	remote.ServiceAccountName = local.ServiceAccountName
@@ -254,6 +269,29 @@ func RemotePodSpec(creation bool, local, remote *corev1.PodSpec, mutators ...Rem
 	return *remote
 }
 
+// RemoteEphemeralContainers forges the ephemeral containers of the reflected pod, appending to the remote ones
+// the local ones not yet present (identified by name), as existing ephemeral containers cannot be modified or removed.
+func RemoteEphemeralContainers(local, remote []corev1.EphemeralContainer) []corev1.EphemeralContainer {
+	for i := range local {
+		if !slices.ContainsFunc(remote, func(ec corev1.EphemeralContainer) bool { return ec.Name == local[i].Name }) {
+			remote = append(remote, local[i])
+		}
+	}
+	return remote
+}
+
+// RemoteContainersResources mutates the resources of the remote containers, aligning them to the ones of the
+// local containers with the same name (e.g., following an in-place resize of the local pod).
+func RemoteContainersResources(local, remote []corev1.Container) {
+	for i := range remote {
+		for j := range local {
+			if remote[i].Name == local[j].Name {
+				remote[i].Resources = local[j].Resources
+			}
+		}
+	}
+}
+
 // TerminateContainerState modifies the container status to set it in a terminated state, if not already in a terminal state.
 func TerminateContainerState(cs *corev1.ContainerStatus, phase corev1.PodPhase, reason string) {
 	cs.Ready = false
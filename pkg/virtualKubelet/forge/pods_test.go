@@ -252,9 +252,84 @@ var _ = Describe("Pod forging", func() {
 			It("should not update the pod spec", func() {
 				Expect(output.Spec.Pod).To(Equal(corev1.PodSpec{}))
 			})
+
+			When("ephemeral containers have been added to the local pod", func() {
+				BeforeEach(func() {
+					local.Spec.EphemeralContainers = []corev1.EphemeralContainer{
+						{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}, TargetContainerName: "foo"},
+					}
+				})
+
+				It("should propagate the ephemeral containers only", func() {
+					Expect(output.Spec.Pod.EphemeralContainers).To(Equal(local.Spec.EphemeralContainers))
+					Expect(output.Spec.Pod.TerminationGracePeriodSeconds).To(BeNil())
+				})
+			})
+
+			When("the containers of the local pod have been resized", func() {
+				BeforeEach(func() {
+					local.Spec.Containers = []corev1.Container{{Name: "foo", Image: "foo/bar:v0.2", Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")}}}}
+					remote.Spec.Pod.Containers = []corev1.Container{{Name: "foo", Image: "foo/bar:v0.1", Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}}}
+				})
+
+				It("should propagate the container resources only", func() {
+					Expect(output.Spec.Pod.Containers).To(HaveLen(1))
+					Expect(output.Spec.Pod.Containers[0].Image).To(Equal("foo/bar:v0.1"))
+					Expect(output.Spec.Pod.Containers[0].Resources).To(Equal(local.Spec.Containers[0].Resources))
+				})
+			})
 		})
 	})
 
+	Describe("the RemoteEphemeralContainers function", func() {
+		EphemeralContainer := func(name, image string) corev1.EphemeralContainer {
+			return corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: name, Image: image}}
+		}
+
+		DescribeTable("should append the local ephemeral containers not yet present",
+			func(local, remote, expected []corev1.EphemeralContainer) {
+				Expect(forge.RemoteEphemeralContainers(local, remote)).To(Equal(expected))
+			},
+			Entry("no ephemeral containers", nil, nil, nil),
+			Entry("a new local ephemeral container",
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox")}, nil,
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox")}),
+			Entry("a new local ephemeral container, in addition to the existing ones",
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox"), EphemeralContainer("bar", "alpine")},
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox")},
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox"), EphemeralContainer("bar", "alpine")}),
+			Entry("the ephemeral containers are already present, preserving the remote ones",
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "busybox")},
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "mutated")},
+				[]corev1.EphemeralContainer{EphemeralContainer("foo", "mutated")}),
+		)
+	})
+
+	Describe("the RemoteContainersResources function", func() {
+		Container := func(name, cpu string) corev1.Container {
+			return corev1.Container{Name: name, Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}}
+		}
+
+		DescribeTable("should update the resources of the matching remote containers",
+			func(local, remote, expected []corev1.Container) {
+				forge.RemoteContainersResources(local, remote)
+				Expect(remote).To(Equal(expected))
+			},
+			Entry("no containers", nil, nil, nil),
+			Entry("a resized container",
+				[]corev1.Container{Container("foo", "2"), Container("bar", "1")},
+				[]corev1.Container{Container("bar", "1"), Container("foo", "1")},
+				[]corev1.Container{Container("bar", "1"), Container("foo", "2")}),
+			Entry("a local container not present remotely",
+				[]corev1.Container{Container("baz", "2")},
+				[]corev1.Container{Container("foo", "1")},
+				[]corev1.Container{Container("foo", "1")}),
+		)
+	})
+
 	Describe("the APIServerSupportMutator function", func() {
 		const saName = "service-account"
 
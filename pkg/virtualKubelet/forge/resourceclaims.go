@@ -0,0 +1,68 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	resourcev1 "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteResourceClaim forges the reflected resourceclaim, given the local one.
+// The specifications are immutable, hence they are preserved in case the remote object already exists, together
+// with the status (i.e., the allocation) set by the remote cluster, so that it is not cleared by the update.
+func RemoteResourceClaim(local, remote *resourcev1.ResourceClaim, targetNamespace string,
+	forgingOpts *ForgingOpts) *resourcev1.ResourceClaim {
+	if remote == nil {
+		// The remote is nil if not already created.
+		remote = &resourcev1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: local.GetName(), Namespace: targetNamespace}, Spec: local.Spec}
+	}
+
+	return &resourcev1.ResourceClaim{
+		ObjectMeta: RemoteDRAObjectMeta(&local.ObjectMeta, &remote.ObjectMeta, forgingOpts),
+		Spec:       *remote.Spec.DeepCopy(),
+		Status:     *remote.Status.DeepCopy(),
+	}
+}
+
+// RemoteResourceClaimTemplate forges the reflected resourceclaimtemplate, given the local one.
+// The specifications are immutable, hence they are preserved in case the remote object already exists.
+func RemoteResourceClaimTemplate(local, remote *resourcev1.ResourceClaimTemplate, targetNamespace string,
+	forgingOpts *ForgingOpts) *resourcev1.ResourceClaimTemplate {
+	if remote == nil {
+		// The remote is nil if not already created.
+		remote = &resourcev1.ResourceClaimTemplate{ObjectMeta: metav1.ObjectMeta{Name: local.GetName(), Namespace: targetNamespace}, Spec: local.Spec}
+	}
+
+	return &resourcev1.ResourceClaimTemplate{
+		ObjectMeta: RemoteDRAObjectMeta(&local.ObjectMeta, &remote.ObjectMeta, forgingOpts),
+		Spec:       *remote.Spec.DeepCopy(),
+	}
+}
+
+// RemoteDRAObjectMeta forges the objectMeta of the reflected resourceclaims and resourceclaimtemplates, given the local one.
+func RemoteDRAObjectMeta(local, remote *metav1.ObjectMeta, forgingOpts *ForgingOpts) metav1.ObjectMeta {
+	objectMeta := RemoteObjectMeta(local, remote)
+	objectMeta.SetLabels(FilterNotReflected(objectMeta.Labels, forgingOpts.LabelsNotReflected))
+	objectMeta.SetAnnotations(FilterNotReflected(objectMeta.Annotations, forgingOpts.AnnotationsNotReflected))
+	return objectMeta
+}
+
+// IsResourceClaimGenerated returns whether the given resourceclaim has been generated from a resourceclaimtemplate
+// for a given pod. These claims are not reflected, as the remote cluster generates its own ones for the remote pod,
+// starting from the reflected template.
+func IsResourceClaimGenerated(claim *resourcev1.ResourceClaim) bool {
+	owner := metav1.GetControllerOf(claim)
+	return owner != nil && owner.APIVersion == "v1" && owner.Kind == "Pod"
+}
@@ -0,0 +1,163 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	resourcev1 "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("ResourceClaims Forging", func() {
+	spec := func(deviceClassName string) resourcev1.ResourceClaimSpec {
+		return resourcev1.ResourceClaimSpec{
+			Devices: resourcev1.DeviceClaim{
+				Requests: []resourcev1.DeviceRequest{{
+					Name:    "gpu",
+					Exactly: &resourcev1.ExactDeviceRequest{DeviceClassName: deviceClassName},
+				}},
+			},
+		}
+	}
+
+	Describe("the RemoteResourceClaim function", func() {
+		var (
+			local, remote, output *resourcev1.ResourceClaim
+		)
+
+		BeforeEach(func() {
+			local = &resourcev1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name", Namespace: "original",
+					Labels:      map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+					Annotations: map[string]string{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true"},
+				},
+				Spec: spec("local.example.com"),
+			}
+			remote = nil
+		})
+
+		JustBeforeEach(func() {
+			output = forge.RemoteResourceClaim(local, remote, "reflected", testutil.FakeForgingOpts())
+		})
+
+		When("the remote object does not exist", func() {
+			It("should correctly set the name and namespace", func() {
+				Expect(output.GetName()).To(Equal("name"))
+				Expect(output.GetNamespace()).To(Equal("reflected"))
+			})
+
+			It("should correctly set the labels", func() {
+				Expect(output.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+				Expect(output.GetLabels()).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+				Expect(output.GetLabels()).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, string(RemoteClusterID)))
+				Expect(output.GetLabels()).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+			})
+
+			It("should correctly set the annotations", func() {
+				Expect(output.GetAnnotations()).To(HaveKeyWithValue("bar", "baz"))
+				Expect(output.GetAnnotations()).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+			})
+
+			It("should correctly set the spec", func() {
+				Expect(output.Spec).To(Equal(local.Spec))
+			})
+		})
+
+		When("the remote object already exists", func() {
+			BeforeEach(func() {
+				remote = &resourcev1.ResourceClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "reflected", ResourceVersion: "42"},
+					Spec:       spec("remote.example.com"),
+					Status: resourcev1.ResourceClaimStatus{Allocation: &resourcev1.AllocationResult{
+						Devices: resourcev1.DeviceAllocationResult{Results: []resourcev1.DeviceRequestAllocationResult{{Request: "gpu", Device: "gpu-0"}}},
+					}},
+				}
+			})
+
+			It("should preserve the resource version", func() {
+				Expect(output.GetResourceVersion()).To(Equal("42"))
+			})
+
+			It("should preserve the immutable spec", func() {
+				Expect(output.Spec).To(Equal(remote.Spec))
+			})
+
+			It("should preserve the status", func() {
+				Expect(output.Status).To(Equal(remote.Status))
+			})
+		})
+	})
+
+	Describe("the RemoteResourceClaimTemplate function", func() {
+		var (
+			local  *resourcev1.ResourceClaimTemplate
+			output *resourcev1.ResourceClaimTemplate
+		)
+
+		BeforeEach(func() {
+			local = &resourcev1.ResourceClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name", Namespace: "original",
+					Labels: map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+				},
+				Spec: resourcev1.ResourceClaimTemplateSpec{Spec: spec("local.example.com")},
+			}
+		})
+
+		JustBeforeEach(func() {
+			output = forge.RemoteResourceClaimTemplate(local, nil, "reflected", testutil.FakeForgingOpts())
+		})
+
+		It("should correctly set the name and namespace", func() {
+			Expect(output.GetName()).To(Equal("name"))
+			Expect(output.GetNamespace()).To(Equal("reflected"))
+		})
+
+		It("should correctly set the labels", func() {
+			Expect(output.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+			Expect(output.GetLabels()).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+			Expect(output.GetLabels()).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+		})
+
+		It("should correctly set the spec", func() {
+			Expect(output.Spec).To(Equal(local.Spec))
+		})
+	})
+
+	Describe("the IsResourceClaimGenerated function", func() {
+		var claim *resourcev1.ResourceClaim
+
+		BeforeEach(func() {
+			claim = &resourcev1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "original"}}
+		})
+
+		When("the claim is not owned by a pod", func() {
+			It("should return false", func() { Expect(forge.IsResourceClaimGenerated(claim)).To(BeFalse()) })
+		})
+
+		When("the claim is controlled by a pod", func() {
+			BeforeEach(func() {
+				claim.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: "Pod", Name: "pod", Controller: ptr.To(true)}}
+			})
+			It("should return true", func() { Expect(forge.IsResourceClaimGenerated(claim)).To(BeTrue()) })
+		})
+	})
+})
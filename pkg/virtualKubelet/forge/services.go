@@ -27,17 +27,20 @@ const nodePortUnset = 0
 
 // RemoteService forges the apply patch for the reflected service, given the local one.
 func RemoteService(local *corev1.Service, targetNamespace string, enableLoadBalancer bool, remoteRealLoadBalancerClassName string,
-	forgingOpts *ForgingOpts) *corev1apply.ServiceApplyConfiguration {
+	preferSameCluster bool, forgingOpts *ForgingOpts) *corev1apply.ServiceApplyConfiguration {
 	return corev1apply.Service(local.GetName(), targetNamespace).
 		WithLabels(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected)).WithLabels(ReflectionLabels()).
 		WithAnnotations(FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected)).
-		WithSpec(RemoteServiceSpec(local.Spec.DeepCopy(), getForceRemoteNodePort(local), enableLoadBalancer, remoteRealLoadBalancerClassName))
+		WithSpec(RemoteServiceSpec(local.Spec.DeepCopy(), getForceRemoteNodePort(local), enableLoadBalancer, remoteRealLoadBalancerClassName,
+			preferSameCluster))
 }
 
 // RemoteServiceSpec forges the apply patch for the specs of the reflected service, given the local ones.
 // It expects the local object to be a deepcopy, as it is mutated.
+// The traffic distribution is reflected only if preferSameCluster is set, defaulting to PreferClose, to enable the generation
+// of topology hints for the endpoints natively present in the remote cluster (see RemoteEndpointTopology).
 func RemoteServiceSpec(local *corev1.ServiceSpec, forceRemoteNodePort,
-	enableLoadBalancer bool, remoteRealLoadBalancerClassName string) *corev1apply.ServiceSpecApplyConfiguration {
+	enableLoadBalancer bool, remoteRealLoadBalancerClassName string, preferSameCluster bool) *corev1apply.ServiceSpecApplyConfiguration {
 	remote := corev1apply.ServiceSpec().
 		WithType(local.Type).WithSelector(local.Selector).
 		WithPorts(RemoteServicePorts(local.Ports, forceRemoteNodePort)...).
@@ -54,6 +57,14 @@ func RemoteServiceSpec(local *corev1.ServiceSpec, forceRemoteNodePort,
 	remote.PublishNotReadyAddresses = &local.PublishNotReadyAddresses
 	remote.SessionAffinity = &local.SessionAffinity
 
+	if preferSameCluster {
+		remote.TrafficDistribution = local.TrafficDistribution
+		if remote.TrafficDistribution == nil {
+			//nolint:staticcheck // PreferSameZone is not supported by remote clusters older than v1.33.
+			remote.WithTrafficDistribution(corev1.ServiceTrafficDistributionPreferClose)
+		}
+	}
+
 	if local.ClusterIP == corev1.ClusterIPNone {
 		remote.ClusterIP = pointer.String(corev1.ClusterIPNone)
 	}
@@ -48,7 +48,7 @@ var _ = Describe("Services Forging", func() {
 
 			forgingOpts = testutil.FakeForgingOpts()
 
-			JustBeforeEach(func() { output = forge.RemoteService(input, "reflected", false, "", forgingOpts) })
+			JustBeforeEach(func() { output = forge.RemoteService(input, "reflected", false, "", false, forgingOpts) })
 
 			It("should correctly set the name and namespace", func() {
 				Expect(output.Name).To(PointTo(Equal("name")))
@@ -89,6 +89,7 @@ var _ = Describe("Services Forging", func() {
 					LoadBalancerSourceRanges:      []string{"0.0.0.0/0"},
 					PublishNotReadyAddresses:      *pointer.Bool(true),
 					SessionAffinity:               corev1.ServiceAffinityNone,
+					TrafficDistribution:           pointer.String(corev1.ServiceTrafficDistributionPreferSameZone),
 					ClusterIP:                     clusterIP,
 				}
 
@@ -108,7 +109,7 @@ var _ = Describe("Services Forging", func() {
 		}
 
 		DescribeTable("RemoteServiceSpec table", func(c remoteServiceTestcase) {
-			output := forge.RemoteServiceSpec(c.input.DeepCopy(), false, false, "")
+			output := forge.RemoteServiceSpec(c.input.DeepCopy(), false, false, "", false)
 
 			By("should correctly replicate the core fields", func() {
 				Expect(output.Type).To(PointTo(c.expectedServiceType))
@@ -127,6 +128,10 @@ var _ = Describe("Services Forging", func() {
 				Expect(output.ClusterIP).To(c.expectedClusterIP)
 				Expect(output.ExternalName).To(c.externalName)
 			})
+
+			By("should not replicate the traffic distribution", func() {
+				Expect(output.TrafficDistribution).To(BeNil())
+			})
 		}, Entry("NodePort Service", remoteServiceTestcase{
 			input:               getService(corev1.ServiceTypeNodePort, ""),
 			expectedClusterIP:   BeNil(),
@@ -148,6 +153,23 @@ var _ = Describe("Services Forging", func() {
 			expectedServiceType: Equal(corev1.ServiceTypeExternalName),
 			externalName:        PointTo(Equal("external-name")),
 		}))
+
+		When("same-cluster endpoints are preferred", func() {
+			var input *corev1.ServiceSpec
+
+			BeforeEach(func() { input = getService(corev1.ServiceTypeClusterIP, "") })
+
+			It("should default the traffic distribution to PreferClose", func() {
+				input.TrafficDistribution = nil
+				output := forge.RemoteServiceSpec(input, false, false, "", true)
+				Expect(output.TrafficDistribution).To(PointTo(Equal(corev1.ServiceTrafficDistributionPreferClose)))
+			})
+
+			It("should preserve the traffic distribution, if set", func() {
+				output := forge.RemoteServiceSpec(input, false, false, "", true)
+				Expect(output.TrafficDistribution).To(PointTo(Equal(corev1.ServiceTrafficDistributionPreferSameZone)))
+			})
+		})
 	})
 
 	Describe("the RemoteServicePorts function", func() {
@@ -0,0 +1,280 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+)
+
+const (
+	// deploymentRevisionAnnotation is the annotation set by the Deployment controller to track the current revision.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// legacyJobNameLabel is the legacy label set by the Job controller on the pod template.
+	legacyJobNameLabel = "job-name"
+	// legacyControllerUIDLabel is the legacy label set by the Job controller on the pod template.
+	legacyControllerUIDLabel = "controller-uid"
+)
+
+// workloadAnnotationsNotReflected contains the annotations of the local workloads which are not reflected,
+// as either managed by the native controllers or meaningful only locally.
+var workloadAnnotationsNotReflected = []string{
+	deploymentRevisionAnnotation,
+	corev1.LastAppliedConfigAnnotation,
+	liqoconst.RemoteWorkloadStatusAnnotationKey,
+}
+
+// WorkloadOffloadingTarget returns the ID of the remote cluster the whole workload is offloaded to, given the annotations
+// of its pod template (which are inherited by its pods), and whether the workload is offloaded as a whole.
+func WorkloadOffloadingTarget(annotations map[string]string) (liqov1beta1.ClusterID, bool) {
+	value, found := annotations[liqoconst.WorkloadOffloadingAnnotationKey]
+	return liqov1beta1.ClusterID(value), found && value != ""
+}
+
+// IsWorkloadOffloaded returns whether the given pod template is marked to offload the whole workload to the remote cluster.
+func IsWorkloadOffloaded(template *corev1.PodTemplateSpec) bool {
+	target, found := WorkloadOffloadingTarget(template.GetAnnotations())
+	return found && target == RemoteCluster
+}
+
+// RemoteShadowWorkload forges the reflected shadowworkload, given the metadata of the local workload and the forged spec.
+func RemoteShadowWorkload(local metav1.Object, spec *offloadingv1beta1.ShadowWorkloadSpec,
+	remote *offloadingv1beta1.ShadowWorkload, targetNamespace string, forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowWorkload {
+	if remote == nil {
+		// The remote is nil if not already created.
+		remote = &offloadingv1beta1.ShadowWorkload{ObjectMeta: metav1.ObjectMeta{Name: local.GetName(), Namespace: targetNamespace}}
+	}
+
+	localMetaFiltered := metav1.ObjectMeta{
+		Labels: FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected),
+		Annotations: FilterNotReflected(FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected),
+			workloadAnnotationsNotReflected),
+	}
+
+	return &offloadingv1beta1.ShadowWorkload{
+		ObjectMeta: RemoteObjectMeta(&localMetaFiltered, &remote.ObjectMeta),
+		Spec:       *spec,
+	}
+}
+
+// RemoteDeploymentWorkloadSpec forges the spec of the shadowworkload reflecting the given Deployment.
+func RemoteDeploymentWorkloadSpec(local *appsv1.Deployment, forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowWorkloadSpec {
+	return &offloadingv1beta1.ShadowWorkloadSpec{
+		Kind:            offloadingv1beta1.DeploymentWorkloadKind,
+		Replicas:        local.Spec.Replicas,
+		Selector:        local.Spec.Selector.DeepCopy(),
+		MinReadySeconds: local.Spec.MinReadySeconds,
+		Template:        RemoteWorkloadPodTemplate(&local.Spec.Template, forgingOpts),
+		Deployment:      &offloadingv1beta1.ShadowDeploymentSpec{Strategy: *local.Spec.Strategy.DeepCopy()},
+	}
+}
+
+// RemoteStatefulSetWorkloadSpec forges the spec of the shadowworkload reflecting the given StatefulSet.
+// StatefulSets with volume claim templates are not supported, and shall be filtered out by the caller.
+func RemoteStatefulSetWorkloadSpec(local *appsv1.StatefulSet, forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowWorkloadSpec {
+	return &offloadingv1beta1.ShadowWorkloadSpec{
+		Kind:            offloadingv1beta1.StatefulSetWorkloadKind,
+		Replicas:        local.Spec.Replicas,
+		Selector:        local.Spec.Selector.DeepCopy(),
+		MinReadySeconds: local.Spec.MinReadySeconds,
+		Template:        RemoteWorkloadPodTemplate(&local.Spec.Template, forgingOpts),
+		StatefulSet: &offloadingv1beta1.ShadowStatefulSetSpec{
+			ServiceName:         local.Spec.ServiceName,
+			PodManagementPolicy: local.Spec.PodManagementPolicy,
+			UpdateStrategy:      *local.Spec.UpdateStrategy.DeepCopy(),
+		},
+	}
+}
+
+// RemoteJobWorkloadSpec forges the spec of the shadowworkload reflecting the given Job.
+func RemoteJobWorkloadSpec(local *batchv1.Job, forgingOpts *ForgingOpts) *offloadingv1beta1.ShadowWorkloadSpec {
+	template := RemoteWorkloadPodTemplate(&local.Spec.Template, forgingOpts)
+	// Remove the labels added by the local Job controller, as they would not match the remote Job.
+	for _, key := range []string{batchv1.JobNameLabel, batchv1.ControllerUidLabel, legacyJobNameLabel, legacyControllerUIDLabel} {
+		delete(template.Labels, key)
+	}
+
+	return &offloadingv1beta1.ShadowWorkloadSpec{
+		Kind:     offloadingv1beta1.JobWorkloadKind,
+		Template: template,
+		Job: &offloadingv1beta1.ShadowJobSpec{
+			Parallelism:           local.Spec.Parallelism,
+			Completions:           local.Spec.Completions,
+			BackoffLimit:          local.Spec.BackoffLimit,
+			ActiveDeadlineSeconds: local.Spec.ActiveDeadlineSeconds,
+			CompletionMode:        local.Spec.CompletionMode,
+		},
+	}
+}
+
+// RemoteWorkloadPodTemplate forges the pod template of a reflected workload, given the local one.
+// The API server support is not available for the pods of offloaded workloads, unless explicitly
+// configured to use the remote service account through the corresponding annotation.
+func RemoteWorkloadPodTemplate(local *corev1.PodTemplateSpec, forgingOpts *ForgingOpts) corev1.PodTemplateSpec {
+	annotations := FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected)
+	delete(annotations, liqoconst.WorkloadOffloadingAnnotationKey)
+
+	mutators := []RemotePodSpecMutator{
+		ServiceAccountMutator(APIServerSupportDisabled, local.GetAnnotations()),
+		NodeSelectorMutator(forgingOpts.NodeSelector),
+		TolerationsMutator(forgingOpts.Tolerations),
+		AffinityMutator(forgingOpts.Affinity),
+		RuntimeClassNameMutator(&corev1.Pod{ObjectMeta: local.ObjectMeta, Spec: local.Spec}, forgingOpts),
+	}
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      labels.Merge(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected), ReflectionLabels()),
+			Annotations: annotations,
+		},
+		Spec: RemotePodSpec(true, local.Spec.DeepCopy(), &corev1.PodSpec{}, mutators...),
+	}
+}
+
+// RemoteWorkloadStatusSummary returns a human-readable summary of the status of the given shadowworkload,
+// to be reported on the corresponding local workload.
+func RemoteWorkloadStatusSummary(shadow *offloadingv1beta1.ShadowWorkload) string {
+	status := &shadow.Status
+	if shadow.Spec.Kind != offloadingv1beta1.JobWorkloadKind {
+		return fmt.Sprintf("%d/%d ready, %d updated, %d available",
+			status.ReadyReplicas, status.Replicas, status.UpdatedReplicas, status.AvailableReplicas)
+	}
+
+	summary := fmt.Sprintf("%d active, %d succeeded, %d failed", status.Active, status.Succeeded, status.Failed)
+	var terminal []string
+	for i := range status.Conditions {
+		cond := &status.Conditions[i]
+		if (cond.Type == string(batchv1.JobComplete) || cond.Type == string(batchv1.JobFailed)) && cond.Status == corev1.ConditionTrue {
+			terminal = append(terminal, cond.Type)
+		}
+	}
+	if len(terminal) > 0 {
+		summary += fmt.Sprintf(" (%s)", strings.Join(terminal, ", "))
+	}
+	return summary
+}
+
+// IsWorkloadPlaceholder returns whether the given pod is a local placeholder of a workload offloaded as a whole.
+func IsWorkloadPlaceholder(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Spec.SchedulingGates, func(gate corev1.PodSchedulingGate) bool {
+		return gate.Name == liqoconst.WorkloadOffloadingSchedulingGate
+	})
+}
+
+// WorkloadPlaceholdersStatus returns the local placeholder pods whose status needs to be updated to mirror the one
+// of the given shadowworkload, with the status already updated. This way, the native controllers compute the status
+// of the local workload (as well as the disruption controller the one of the budgets) as if the pods were running
+// locally, hence supporting rollouts, job completions and pod disruption budgets. The Deployment and StatefulSet
+// placeholders are marked ready up to the number of remote ready replicas (the newest ones first for Deployments,
+// to let the rollouts progress), while the Job placeholders are terminated as the remote pods succeed or fail.
+func WorkloadPlaceholdersStatus(remote *offloadingv1beta1.ShadowWorkload, placeholders []*corev1.Pod, now metav1.Time) []*corev1.Pod {
+	var active []*corev1.Pod
+	var succeeded, failed int32
+	for _, pod := range placeholders {
+		switch {
+		case pod.Status.Phase == corev1.PodSucceeded:
+			succeeded++
+		case pod.Status.Phase == corev1.PodFailed:
+			failed++
+		case pod.DeletionTimestamp == nil:
+			active = append(active, pod)
+		}
+	}
+
+	newestFirst := remote.Spec.Kind == offloadingv1beta1.DeploymentWorkloadKind
+	slices.SortStableFunc(active, func(a, b *corev1.Pod) int {
+		if cmp := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); cmp != 0 {
+			if newestFirst {
+				return -cmp
+			}
+			return cmp
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var updated []*corev1.Pod
+	if remote.Spec.Kind == offloadingv1beta1.JobWorkloadKind {
+		toSucceed, toFail := remote.Status.Succeeded-succeeded, remote.Status.Failed-failed
+		for _, pod := range active {
+			switch {
+			case toSucceed > 0:
+				updated = append(updated, terminatedPlaceholder(pod, corev1.PodSucceeded))
+				toSucceed--
+			case toFail > 0:
+				updated = append(updated, terminatedPlaceholder(pod, corev1.PodFailed))
+				toFail--
+			}
+		}
+		return updated
+	}
+
+	for i, pod := range active {
+		if pod = readyPlaceholder(pod, int32(i) < remote.Status.ReadyReplicas, now); pod != nil {
+			updated = append(updated, pod)
+		}
+	}
+	return updated
+}
+
+// terminatedPlaceholder returns a copy of the given placeholder, terminated with the given phase.
+func terminatedPlaceholder(pod *corev1.Pod, phase corev1.PodPhase) *corev1.Pod {
+	pod = pod.DeepCopy()
+	pod.Status.Phase = phase
+	pod.Status.Message = "The corresponding pod of the workload offloaded to the remote cluster terminated"
+	return pod
+}
+
+// readyPlaceholder returns a copy of the given placeholder with the readiness conditions set as specified,
+// or nil if they are already up-to-date.
+func readyPlaceholder(pod *corev1.Pod, ready bool, now metav1.Time) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	var current corev1.ConditionStatus
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			current = pod.Status.Conditions[i].Status
+		}
+	}
+	// The placeholders not yet marked ready have no readiness condition, which is equivalent to a false one.
+	if current == status || (current == "" && !ready) {
+		return nil
+	}
+
+	pod = pod.DeepCopy()
+	for _, condition := range []corev1.PodConditionType{corev1.ContainersReady, corev1.PodReady} {
+		idx := slices.IndexFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool { return c.Type == condition })
+		updated := corev1.PodCondition{Type: condition, Status: status, LastTransitionTime: now, Reason: "RemoteWorkloadStatus"}
+		if idx < 0 {
+			pod.Status.Conditions = append(pod.Status.Conditions, updated)
+		} else {
+			pod.Status.Conditions[idx] = updated
+		}
+	}
+	return pod
+}
@@ -0,0 +1,308 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("Workloads forging", func() {
+	var template corev1.PodTemplateSpec
+
+	BeforeEach(func() {
+		template = corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": "foo", testutil.FakeNotReflectedLabelKey: "true"},
+				Annotations: map[string]string{
+					consts.WorkloadOffloadingAnnotationKey: string(RemoteClusterID),
+					testutil.FakeNotReflectedAnnotKey:      "true",
+					"foo":                                  "bar",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "bar", Image: "foo"}},
+				NodeSelector: map[string]string{"liqo.io/type": "virtual-node"},
+			},
+		}
+	})
+
+	Describe("the IsWorkloadOffloaded function", func() {
+		It("should return true if the template targets the remote cluster", func() {
+			Expect(forge.IsWorkloadOffloaded(&template)).To(BeTrue())
+		})
+		It("should return false if the template targets a different cluster", func() {
+			template.Annotations[consts.WorkloadOffloadingAnnotationKey] = "other-cluster-id"
+			Expect(forge.IsWorkloadOffloaded(&template)).To(BeFalse())
+		})
+		It("should return false if the template is not annotated", func() {
+			delete(template.Annotations, consts.WorkloadOffloadingAnnotationKey)
+			Expect(forge.IsWorkloadOffloaded(&template)).To(BeFalse())
+		})
+	})
+
+	Describe("the RemoteWorkloadPodTemplate function", func() {
+		var output corev1.PodTemplateSpec
+
+		JustBeforeEach(func() { output = forge.RemoteWorkloadPodTemplate(&template, testutil.FakeForgingOpts()) })
+
+		It("should filter the labels and annotations not to be reflected", func() {
+			Expect(output.Labels).To(HaveKeyWithValue("app", "foo"))
+			Expect(output.Labels).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+			Expect(output.Annotations).To(HaveKeyWithValue("foo", "bar"))
+			Expect(output.Annotations).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+			Expect(output.Annotations).ToNot(HaveKey(consts.WorkloadOffloadingAnnotationKey))
+		})
+		It("should add the reflection labels", func() {
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, string(RemoteClusterID)))
+		})
+		It("should correctly propagate the containers", func() {
+			Expect(output.Spec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Name": Equal("bar"), "Image": Equal("foo")})))
+		})
+		It("should not propagate the local node selector", func() {
+			Expect(output.Spec.NodeSelector).ToNot(HaveKey("liqo.io/type"))
+		})
+	})
+
+	Describe("the RemoteShadowWorkload function", func() {
+		var (
+			local  *appsv1.Deployment
+			remote *offloadingv1beta1.ShadowWorkload
+			output *offloadingv1beta1.ShadowWorkload
+		)
+
+		BeforeEach(func() {
+			local = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "name", Namespace: "local-namespace",
+					Labels:      map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+					Annotations: map[string]string{"bar": "baz", consts.RemoteWorkloadStatusAnnotationKey: "1/1 ready"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To[int32](2),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+					Template: template,
+				},
+			}
+			remote = nil
+		})
+
+		JustBeforeEach(func() {
+			spec := forge.RemoteDeploymentWorkloadSpec(local, testutil.FakeForgingOpts())
+			output = forge.RemoteShadowWorkload(local, spec, remote, "remote-namespace", testutil.FakeForgingOpts())
+		})
+
+		When("the remote object does not exist", func() {
+			It("should correctly set the object meta", func() {
+				Expect(output.Name).To(Equal("name"))
+				Expect(output.Namespace).To(Equal("remote-namespace"))
+				Expect(output.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(output.Labels).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+				Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+				Expect(output.Annotations).To(HaveKeyWithValue("bar", "baz"))
+				Expect(output.Annotations).ToNot(HaveKey(consts.RemoteWorkloadStatusAnnotationKey))
+			})
+			It("should correctly set the spec", func() {
+				Expect(output.Spec.Kind).To(Equal(offloadingv1beta1.DeploymentWorkloadKind))
+				Expect(output.Spec.Replicas).To(PointTo(BeNumerically("==", 2)))
+				Expect(output.Spec.Selector).To(Equal(local.Spec.Selector))
+				Expect(output.Spec.Deployment).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Strategy": Equal(appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}),
+				})))
+				Expect(output.Spec.StatefulSet).To(BeNil())
+				Expect(output.Spec.Job).To(BeNil())
+			})
+		})
+
+		When("the remote object already exists", func() {
+			BeforeEach(func() {
+				remote = &offloadingv1beta1.ShadowWorkload{ObjectMeta: metav1.ObjectMeta{
+					Name: "name", Namespace: "remote-namespace", ResourceVersion: "42", Labels: map[string]string{"existing": "label"}}}
+			})
+
+			It("should preserve the existing object meta, while replacing the labels", func() {
+				Expect(output.ResourceVersion).To(Equal("42"))
+				Expect(output.Labels).ToNot(HaveKey("existing"))
+				Expect(output.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+		})
+	})
+
+	Describe("the RemoteStatefulSetWorkloadSpec function", func() {
+		It("should correctly forge the spec", func() {
+			local := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](3), ServiceName: "svc",
+				PodManagementPolicy: appsv1.ParallelPodManagement, Template: template,
+			}}
+			output := forge.RemoteStatefulSetWorkloadSpec(local, testutil.FakeForgingOpts())
+			Expect(output.Kind).To(Equal(offloadingv1beta1.StatefulSetWorkloadKind))
+			Expect(output.Replicas).To(PointTo(BeNumerically("==", 3)))
+			Expect(output.StatefulSet).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"ServiceName":         Equal("svc"),
+				"PodManagementPolicy": Equal(appsv1.ParallelPodManagement),
+			})))
+		})
+	})
+
+	Describe("the RemoteJobWorkloadSpec function", func() {
+		It("should correctly forge the spec, removing the labels generated by the local controller", func() {
+			template.Labels[batchv1.JobNameLabel] = "name"
+			template.Labels[batchv1.ControllerUidLabel] = "uid"
+			template.Labels["job-name"] = "name"
+			template.Labels["controller-uid"] = "uid"
+			local := &batchv1.Job{Spec: batchv1.JobSpec{
+				Completions: ptr.To[int32](5), Parallelism: ptr.To[int32](2), BackoffLimit: ptr.To[int32](1),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{batchv1.ControllerUidLabel: "uid"}},
+				Template: template,
+			}}
+			output := forge.RemoteJobWorkloadSpec(local, testutil.FakeForgingOpts())
+			Expect(output.Kind).To(Equal(offloadingv1beta1.JobWorkloadKind))
+			Expect(output.Selector).To(BeNil())
+			Expect(output.Job).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Completions":  PointTo(BeNumerically("==", 5)),
+				"Parallelism":  PointTo(BeNumerically("==", 2)),
+				"BackoffLimit": PointTo(BeNumerically("==", 1)),
+			})))
+			Expect(output.Template.Labels).To(HaveKeyWithValue("app", "foo"))
+			Expect(output.Template.Labels).ToNot(HaveKey(batchv1.JobNameLabel))
+			Expect(output.Template.Labels).ToNot(HaveKey(batchv1.ControllerUidLabel))
+			Expect(output.Template.Labels).ToNot(HaveKey("job-name"))
+			Expect(output.Template.Labels).ToNot(HaveKey("controller-uid"))
+		})
+	})
+
+	DescribeTable("the RemoteWorkloadStatusSummary function",
+		func(kind offloadingv1beta1.WorkloadKind, status offloadingv1beta1.ShadowWorkloadStatus, expected string) {
+			shadow := &offloadingv1beta1.ShadowWorkload{Spec: offloadingv1beta1.ShadowWorkloadSpec{Kind: kind, Replicas: ptr.To[int32](3)}, Status: status}
+			Expect(forge.RemoteWorkloadStatusSummary(shadow)).To(Equal(expected))
+		},
+		Entry("a deployment", offloadingv1beta1.DeploymentWorkloadKind,
+			offloadingv1beta1.ShadowWorkloadStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 1},
+			"2/3 ready, 3 updated, 1 available"),
+		Entry("a running job", offloadingv1beta1.JobWorkloadKind,
+			offloadingv1beta1.ShadowWorkloadStatus{Active: 2, Succeeded: 1},
+			"2 active, 1 succeeded, 0 failed"),
+		Entry("a completed job", offloadingv1beta1.JobWorkloadKind,
+			offloadingv1beta1.ShadowWorkloadStatus{Succeeded: 3, Conditions: []offloadingv1beta1.ShadowWorkloadCondition{
+				{Type: string(batchv1.JobComplete), Status: corev1.ConditionTrue}}},
+			"0 active, 3 succeeded, 0 failed (Complete)"),
+	)
+
+	Describe("the WorkloadPlaceholdersStatus function", func() {
+		var (
+			shadow       *offloadingv1beta1.ShadowWorkload
+			placeholders []*corev1.Pod
+			output       []*corev1.Pod
+		)
+
+		now := metav1.Now()
+		placeholder := func(name string, age time.Duration, phase corev1.PodPhase, ready bool) *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+				Spec:       corev1.PodSpec{SchedulingGates: []corev1.PodSchedulingGate{{Name: consts.WorkloadOffloadingSchedulingGate}}},
+				Status:     corev1.PodStatus{Phase: phase},
+			}
+			if ready {
+				pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			return pod
+		}
+		names := func(pods []*corev1.Pod) []string {
+			var out []string
+			for _, pod := range pods {
+				out = append(out, pod.Name)
+			}
+			return out
+		}
+
+		JustBeforeEach(func() { output = forge.WorkloadPlaceholdersStatus(shadow, placeholders, now) })
+
+		When("the workload is a deployment", func() {
+			BeforeEach(func() {
+				shadow = &offloadingv1beta1.ShadowWorkload{
+					Spec:   offloadingv1beta1.ShadowWorkloadSpec{Kind: offloadingv1beta1.DeploymentWorkloadKind},
+					Status: offloadingv1beta1.ShadowWorkloadStatus{Replicas: 3, ReadyReplicas: 2},
+				}
+				placeholders = []*corev1.Pod{
+					placeholder("old", time.Hour, corev1.PodPending, true),
+					placeholder("new", time.Minute, corev1.PodPending, false),
+					placeholder("newest", time.Second, corev1.PodPending, false),
+				}
+			})
+
+			It("should mark ready the newest placeholders, up to the remote ready replicas", func() {
+				Expect(names(output)).To(ConsistOf("old", "new", "newest"))
+				for _, pod := range output {
+					Expect(pod.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type": Equal(corev1.PodReady), "Status": Equal(map[string]corev1.ConditionStatus{
+							"old": corev1.ConditionFalse, "new": corev1.ConditionTrue, "newest": corev1.ConditionTrue}[pod.Name]),
+					})))
+					Expect(pod.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{"Type": Equal(corev1.ContainersReady)})))
+				}
+			})
+
+			It("should not modify the input placeholders", func() {
+				Expect(placeholders[1].Status.Conditions).To(BeEmpty())
+			})
+
+			When("the placeholders are already aligned", func() {
+				BeforeEach(func() {
+					shadow.Status.ReadyReplicas = 0
+					placeholders = placeholders[1:]
+				})
+
+				It("should return no placeholders to be updated", func() { Expect(output).To(BeEmpty()) })
+			})
+		})
+
+		When("the workload is a job", func() {
+			BeforeEach(func() {
+				shadow = &offloadingv1beta1.ShadowWorkload{
+					Spec:   offloadingv1beta1.ShadowWorkloadSpec{Kind: offloadingv1beta1.JobWorkloadKind},
+					Status: offloadingv1beta1.ShadowWorkloadStatus{Active: 1, Succeeded: 2, Failed: 1},
+				}
+				placeholders = []*corev1.Pod{
+					placeholder("succeeded", time.Hour, corev1.PodSucceeded, false),
+					placeholder("first", time.Minute, corev1.PodPending, false),
+					placeholder("second", time.Second, corev1.PodPending, false),
+					placeholder("third", 0, corev1.PodPending, false),
+				}
+			})
+
+			It("should terminate the oldest placeholders, as the remote pods succeed or fail", func() {
+				Expect(output).To(HaveLen(2))
+				Expect(output[0].Name).To(Equal("first"))
+				Expect(output[0].Status.Phase).To(Equal(corev1.PodSucceeded))
+				Expect(output[1].Name).To(Equal("second"))
+				Expect(output[1].Status.Phase).To(Equal(corev1.PodFailed))
+			})
+		})
+	})
+})
@@ -0,0 +1,138 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liqonodeprovider
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// forgeDeviceResourceSlices forges the ResourceSlices advertising, through the virtual node, the devices offered by the remote
+// cluster via Dynamic Resource Allocation. One slice is generated for each driver, with as many devices as the ones offered
+// by the remote cluster (up to the maximum number of devices per slice) and carrying the attributes shared by the remote
+// devices, so that the consumer scheduler can allocate the claims referencing the corresponding device classes onto the virtual node.
+// Since only the number of devices is advertised, the devices are named after their position (i.e., device-N) rather than
+// after the remote ones: the names are placeholders, as the actual devices are chosen by the remote cluster upon allocation.
+func forgeDeviceResourceSlices(nodeName string, owner *metav1.OwnerReference,
+	deviceClasses []liqov1beta1.DeviceClassType) map[string]*resourcev1.ResourceSlice {
+	slices := map[string]*resourcev1.ResourceSlice{}
+	for i := range deviceClasses {
+		class := &deviceClasses[i]
+		name := fmt.Sprintf("%s-%s", nodeName, class.Driver)
+		if _, found := slices[name]; found {
+			// The device classes exposed by the same driver share the same devices.
+			continue
+		}
+
+		devices := min(class.Devices, resourcev1.ResourceSliceMaxDevices)
+		if devices < class.Devices {
+			klog.Warningf("Advertising only %d out of %d devices of driver %q, as exceeding the maximum number of devices per slice",
+				devices, class.Devices, class.Driver)
+		}
+
+		slice := &resourcev1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{consts.VirtualNodeLabel: nodeName},
+			},
+			Spec: resourcev1.ResourceSliceSpec{
+				Driver:   class.Driver,
+				NodeName: &nodeName,
+				Pool:     resourcev1.ResourcePool{Name: nodeName, ResourceSliceCount: 1},
+				Devices:  make([]resourcev1.Device, devices),
+			},
+		}
+		if owner != nil {
+			slice.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		for j := range slice.Spec.Devices {
+			// The remote device names are not advertised, hence synthetic ones are generated.
+			slice.Spec.Devices[j].Name = fmt.Sprintf("device-%d", j)
+			// The devices carry the attributes of the remote ones, so that they can be selected by the local device classes.
+			if len(class.Attributes) > 0 {
+				slice.Spec.Devices[j].Attributes = make(map[resourcev1.QualifiedName]resourcev1.DeviceAttribute, len(class.Attributes))
+				for name, attribute := range class.Attributes {
+					slice.Spec.Devices[j].Attributes[name] = *attribute.DeepCopy()
+				}
+			}
+		}
+		slices[name] = slice
+	}
+	return slices
+}
+
+// reconcileDeviceResourceSlices ensures the ResourceSlices advertising the devices offered by the remote cluster
+// through the virtual node are in sync with the given device classes.
+func (p *LiqoNodeProvider) reconcileDeviceResourceSlices(ctx context.Context, deviceClasses []liqov1beta1.DeviceClassType) error {
+	client := p.localClient.ResourceV1().ResourceSlices()
+	selector := labels.SelectorFromSet(map[string]string{consts.VirtualNodeLabel: p.nodeName})
+	existing, err := client.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		// The resource.k8s.io API is not available in clusters not supporting Dynamic Resource Allocation.
+		if apierrors.IsNotFound(err) && len(deviceClasses) == 0 {
+			return nil
+		}
+		return fmt.Errorf("failed to list the ResourceSlices of node %q: %w", p.nodeName, err)
+	}
+
+	// The slices are owned by the node, so that they are garbage collected once it is deleted.
+	var owner *metav1.OwnerReference
+	if node, err := p.localClient.CoreV1().Nodes().Get(ctx, p.nodeName, metav1.GetOptions{}); err == nil {
+		owner = metav1.NewControllerRef(node, corev1.SchemeGroupVersion.WithKind("Node"))
+	}
+
+	desired := forgeDeviceResourceSlices(p.nodeName, owner, deviceClasses)
+	for i := range existing.Items {
+		current := &existing.Items[i]
+		target, found := desired[current.GetName()]
+		if found {
+			target.Spec.Pool.Generation = current.Spec.Pool.Generation
+		}
+		switch {
+		case !found:
+			if err := client.Delete(ctx, current.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete ResourceSlice %q: %w", current.GetName(), err)
+			}
+			klog.Infof("ResourceSlice %q of node %q correctly deleted", current.GetName(), p.nodeName)
+		case !equality.Semantic.DeepEqual(current.Spec, target.Spec):
+			target.ResourceVersion = current.ResourceVersion
+			target.Spec.Pool.Generation++
+			if _, err := client.Update(ctx, target, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to update ResourceSlice %q: %w", current.GetName(), err)
+			}
+			klog.Infof("ResourceSlice %q of node %q correctly updated", current.GetName(), p.nodeName)
+		}
+		delete(desired, current.GetName())
+	}
+
+	for name, target := range desired {
+		if _, err := client.Create(ctx, target, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create ResourceSlice %q: %w", name, err)
+		}
+		klog.Infof("ResourceSlice %q of node %q correctly created", name, p.nodeName)
+	}
+	return nil
+}
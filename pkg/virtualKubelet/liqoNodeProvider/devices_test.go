@@ -0,0 +1,87 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liqonodeprovider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/utils/ptr"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("Device ResourceSlices forging", func() {
+	Describe("The forgeDeviceResourceSlices function", func() {
+		var (
+			deviceClasses []liqov1beta1.DeviceClassType
+			slices        map[string]*resourcev1.ResourceSlice
+		)
+
+		JustBeforeEach(func() { slices = forgeDeviceResourceSlices("virtual-node", nil, deviceClasses) })
+
+		When("no device class is offered", func() {
+			BeforeEach(func() { deviceClasses = nil })
+			It("should not forge any slice", func() { Expect(slices).To(BeEmpty()) })
+		})
+
+		When("multiple device classes are offered", func() {
+			BeforeEach(func() {
+				deviceClasses = []liqov1beta1.DeviceClassType{
+					{DeviceClassName: "gpu", Driver: "gpu.example.com", Devices: 4, Attributes: map[resourcev1.QualifiedName]resourcev1.DeviceAttribute{
+						"model": {StringValue: ptr.To("model-a")},
+					}},
+					{DeviceClassName: "gpu-large", Driver: "gpu.example.com", Devices: 4},
+					{DeviceClassName: "fpga", Driver: "fpga.example.com", Devices: 2 * resourcev1.ResourceSliceMaxDevices},
+				}
+			})
+
+			It("should forge one slice for each driver", func() {
+				Expect(slices).To(HaveLen(2))
+				Expect(slices).To(HaveKey("virtual-node-gpu.example.com"))
+				Expect(slices).To(HaveKey("virtual-node-fpga.example.com"))
+			})
+
+			It("should associate the slices with the virtual node", func() {
+				for _, slice := range slices {
+					Expect(slice.Labels).To(HaveKeyWithValue(consts.VirtualNodeLabel, "virtual-node"))
+					Expect(slice.Spec.NodeName).To(PointTo(Equal("virtual-node")))
+					Expect(slice.Spec.Pool.Name).To(Equal("virtual-node"))
+				}
+			})
+
+			It("should advertise the number of devices offered", func() {
+				slice := slices["virtual-node-gpu.example.com"]
+				Expect(slice.Spec.Driver).To(Equal("gpu.example.com"))
+				Expect(slice.Spec.Devices).To(HaveLen(4))
+				Expect(slice.Spec.Devices[0].Name).To(Equal("device-0"))
+			})
+
+			It("should advertise the attributes shared by the remote devices", func() {
+				for _, device := range slices["virtual-node-gpu.example.com"].Spec.Devices {
+					Expect(device.Attributes).To(HaveKeyWithValue(resourcev1.QualifiedName("model"),
+						resourcev1.DeviceAttribute{StringValue: ptr.To("model-a")}))
+				}
+				Expect(slices["virtual-node-fpga.example.com"].Spec.Devices[0].Attributes).To(BeEmpty())
+			})
+
+			It("should cap the number of devices to the maximum allowed per slice", func() {
+				Expect(slices["virtual-node-fpga.example.com"].Spec.Devices).To(HaveLen(resourcev1.ResourceSliceMaxDevices))
+			})
+		})
+	})
+})
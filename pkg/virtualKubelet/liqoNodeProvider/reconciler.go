@@ -106,6 +106,11 @@ func (p *LiqoNodeProvider) updateFromVirtualNode(ctx context.Context,
 		return err
 	}
 
+	if err := p.reconcileDeviceResourceSlices(ctx, virtualNode.Spec.DeviceClasses); err != nil {
+		klog.Error(err)
+		return err
+	}
+
 	p.applyVirtualNodeStatus(virtualNode)
 
 	return p.updateNode()
@@ -67,6 +67,9 @@ type InitConfig struct {
 
 	ReflectorsConfigs map[resources.ResourceReflected]offloadingv1beta1.ReflectorConfig
 
+	EndpointZoneMode  forge.EndpointZoneMode
+	PreferSameCluster bool
+
 	EnableAPIServerSupport          bool
 	EnableStorage                   bool
 	VirtualStorageClassName         string
@@ -151,7 +154,8 @@ func NewLiqoProvider(ctx context.Context, cfg *InitConfig, eb record.EventBroadc
 
 	reflectionManager := manager.New(localClient, remoteClient, localLiqoClient, remoteLiqoClient, cfg.InformerResyncPeriod, eb, &forgingOpts).
 		With(podreflector).
-		With(exposition.NewServiceReflector(ptr.To(cfg.ReflectorsConfigs[resources.Service]), cfg.EnableLoadBalancer, cfg.RemoteRealLoadBalancerClassName)).
+		With(exposition.NewServiceReflector(ptr.To(cfg.ReflectorsConfigs[resources.Service]), cfg.EnableLoadBalancer, cfg.RemoteRealLoadBalancerClassName,
+			cfg.PreferSameCluster)).
 		With(exposition.NewIngressReflector(ptr.To(cfg.ReflectorsConfigs[resources.Ingress]), cfg.EnableIngress, cfg.RemoteRealIngressClassName)).
 		With(configuration.NewConfigMapReflector(ptr.To(cfg.ReflectorsConfigs[resources.ConfigMap]))).
 		With(configuration.NewSecretReflector(apiServerSupport == forge.APIServerSupportLegacy, ptr.To(cfg.ReflectorsConfigs[resources.Secret]))).
@@ -160,10 +164,15 @@ func NewLiqoProvider(ctx context.Context, cfg *InitConfig, eb record.EventBroadc
 		With(storage.NewPersistentVolumeClaimReflector(cfg.VirtualStorageClassName, cfg.RemoteRealStorageClassName,
 			cfg.EnableStorage, ptr.To(cfg.ReflectorsConfigs[resources.PersistentVolumeClaim]))).
 		With(event.NewEventReflector(ptr.To(cfg.ReflectorsConfigs[resources.Event]))).
+		With(workload.NewWorkloadReflector(ptr.To(cfg.ReflectorsConfigs[resources.Workload]))).
+		With(workload.NewPodDisruptionBudgetReflector(ptr.To(cfg.ReflectorsConfigs[resources.PodDisruptionBudget]))).
+		With(workload.NewResourceClaimReflector(ptr.To(cfg.ReflectorsConfigs[resources.ResourceClaim]))).
+		With(workload.NewResourceClaimTemplateReflector(ptr.To(cfg.ReflectorsConfigs[resources.ResourceClaimTemplate]))).
 		WithNamespaceHandler(namespacemap.NewHandler(localLiqoClient, cfg.Namespace, cfg.InformerResyncPeriod))
 
 	if !cfg.DisableIPReflection {
-		reflectionManager.With(exposition.NewEndpointSliceReflector(cfg.LocalPodCIDRs, ptr.To(cfg.ReflectorsConfigs[resources.EndpointSlice])))
+		topologyOpts := &forge.EndpointTopologyOpts{ZoneMode: cfg.EndpointZoneMode, PreferSameCluster: cfg.PreferSameCluster}
+		reflectionManager.With(exposition.NewEndpointSliceReflector(cfg.LocalPodCIDRs, topologyOpts, ptr.To(cfg.ReflectorsConfigs[resources.EndpointSlice])))
 	}
 
 	reflectionManager.Start(ctx)
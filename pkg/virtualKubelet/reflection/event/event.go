@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	netv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -30,6 +31,7 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 	netv1listers "k8s.io/client-go/listers/networking/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/trace"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,6 +66,7 @@ type NamespacedEventReflector struct {
 	localServices       corev1listers.ServiceNamespaceLister
 	localPvcs           corev1listers.PersistentVolumeClaimNamespaceLister
 	localPods           corev1listers.PodNamespaceLister
+	localPDBs           policyv1listers.PodDisruptionBudgetNamespaceLister
 }
 
 // NewEventReflector returns a new EventReflector instance.
@@ -84,6 +87,7 @@ func NewNamespacedEventReflector(opts *options.NamespacedOpts) manager.Namespace
 	localServices := opts.LocalFactory.Core().V1().Services()
 	localPvcs := opts.LocalFactory.Core().V1().PersistentVolumeClaims()
 	localPods := opts.LocalFactory.Core().V1().Pods()
+	localPDBs := opts.LocalFactory.Policy().V1().PodDisruptionBudgets()
 
 	_, err := local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
 	utilruntime.Must(err)
@@ -103,6 +107,7 @@ func NewNamespacedEventReflector(opts *options.NamespacedOpts) manager.Namespace
 		localServices:       localServices.Lister().Services(opts.LocalNamespace),
 		localPvcs:           localPvcs.Lister().PersistentVolumeClaims(opts.LocalNamespace),
 		localPods:           localPods.Lister().Pods(opts.LocalNamespace),
+		localPDBs:           localPDBs.Lister().PodDisruptionBudgets(opts.LocalNamespace),
 	}
 }
 
@@ -242,6 +247,8 @@ func (ner *NamespacedEventReflector) getLocalObject(kind, apiVersion, name strin
 		return ner.localPvcs.Get(name)
 	case gv.Group == corev1.GroupName && gv.Version == corev1.SchemeGroupVersion.Version && kind == "Pod":
 		return ner.localPods.Get(name)
+	case gv.Group == policyv1.GroupName && gv.Version == policyv1.SchemeGroupVersion.Version && kind == "PodDisruptionBudget":
+		return ner.localPDBs.Get(name)
 	case gv.Group == offloadingv1beta1.SchemeGroupVersion.Group && gv.Version == offloadingv1beta1.SchemeGroupVersion.Version && kind == "ShadowPod":
 		// Shadowpod and its corresponding pod have the same name.
 		// We can use shadowpod events as pod events.
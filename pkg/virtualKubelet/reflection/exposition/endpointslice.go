@@ -71,18 +71,21 @@ type NamespacedEndpointSliceReflector struct {
 	localIPs                         ipamv1alpha1listers.IPNamespaceLister
 
 	localPodCIDRs []*net.IPNet
+	topologyOpts  *forge.EndpointTopologyOpts
 
 	translations sync.Map
 }
 
 // NewEndpointSliceReflector returns a new EndpointSliceReflector instance.
-func NewEndpointSliceReflector(localPodCIDRs []string, reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
-	return generic.NewReflector(EndpointSliceReflectorName, NewNamespacedEndpointSliceReflector(localPodCIDRs),
+func NewEndpointSliceReflector(localPodCIDRs []string, topologyOpts *forge.EndpointTopologyOpts,
+	reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(EndpointSliceReflectorName, NewNamespacedEndpointSliceReflector(localPodCIDRs, topologyOpts),
 		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedEndpointSliceReflector returns a function generating NamespacedEndpointSliceReflector instances.
-func NewNamespacedEndpointSliceReflector(localPodCIDRs []string) func(*options.NamespacedOpts) manager.NamespacedReflector {
+func NewNamespacedEndpointSliceReflector(localPodCIDRs []string,
+	topologyOpts *forge.EndpointTopologyOpts) func(*options.NamespacedOpts) manager.NamespacedReflector {
 	return func(opts *options.NamespacedOpts) manager.NamespacedReflector {
 		localNode := opts.LocalFactory.Core().V1().Nodes()
 		localServices := opts.LocalFactory.Core().V1().Services()
@@ -111,6 +114,7 @@ func NewNamespacedEndpointSliceReflector(localPodCIDRs []string) func(*options.N
 			remoteShadowEndpointSlicesClient: opts.RemoteLiqoClient.OffloadingV1beta1().ShadowEndpointSlices(opts.RemoteNamespace),
 			localIPs:                         localIPs.Lister().IPs(opts.LocalNamespace),
 			localPodCIDRs:                    podCIDRs,
+			topologyOpts:                     topologyOpts,
 		}
 
 		// Enqueue all existing remote EndpointSlices in case the local Service has the "skip-reflection" annotation, to ensure they are also deleted.
@@ -271,7 +275,8 @@ func (ner *NamespacedEndpointSliceReflector) Handle(ctx context.Context, name st
 		}
 	}
 
-	target := forge.RemoteShadowEndpointSlice(local, remote, ner.localNodeClient, ner.RemoteNamespace(), translator, ner.ForgingOpts)
+	target := forge.RemoteShadowEndpointSlice(local, remote, ner.localNodeClient, ner.RemoteNamespace(), translator,
+		ner.topologyOpts, ner.ForgingOpts)
 	if terr != nil {
 		klog.Errorf("Reflection of local EndpointSlice %q to %q failed: %v", ner.LocalRef(name), ner.RemoteRef(name), terr)
 		ner.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(terr))
@@ -63,7 +63,7 @@ var _ = Describe("EndpointSlice Reflection Tests", func() {
 				NumWorkers: 1,
 				Type:       root.DefaultReflectorsTypes[resources.EndpointSlice],
 			}
-			Expect(exposition.NewEndpointSliceReflector(localPodCIDRs, &reflectorConfig)).ToNot(BeNil())
+			Expect(exposition.NewEndpointSliceReflector(localPodCIDRs, &forge.EndpointTopologyOpts{}, &reflectorConfig)).ToNot(BeNil())
 		})
 	})
 
@@ -157,7 +157,7 @@ var _ = Describe("EndpointSlice Reflection Tests", func() {
 		JustBeforeEach(func() {
 			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
 			liqoFactory := liqoinformers.NewSharedInformerFactory(liqoClient, 10*time.Hour)
-			reflector = exposition.NewNamespacedEndpointSliceReflector(podCIDRs)(options.NewNamespaced().
+			reflector = exposition.NewNamespacedEndpointSliceReflector(podCIDRs, &forge.EndpointTopologyOpts{})(options.NewNamespaced().
 				WithLocal(LocalNamespace, client, factory).
 				WithLiqoLocal(liqoClient, liqoFactory).
 				WithRemote(RemoteNamespace, client, factory).
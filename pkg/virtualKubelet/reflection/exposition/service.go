@@ -54,19 +54,20 @@ type NamespacedServiceReflector struct {
 
 	enableLoadBalancer              bool
 	remoteRealLoadBalancerClassName string
+	preferSameCluster               bool
 }
 
 // NewServiceReflector returns a new ServiceReflector instance.
 func NewServiceReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig,
-	enableLoadBalancer bool, remoteRealLoadBalancerClassName string) manager.Reflector {
+	enableLoadBalancer bool, remoteRealLoadBalancerClassName string, preferSameCluster bool) manager.Reflector {
 	return generic.NewReflector(ServiceReflectorName,
-		NewNamespacedServiceReflector(enableLoadBalancer, remoteRealLoadBalancerClassName), generic.WithoutFallback(),
+		NewNamespacedServiceReflector(enableLoadBalancer, remoteRealLoadBalancerClassName, preferSameCluster), generic.WithoutFallback(),
 		reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedServiceReflector returns a new NamespacedServiceReflector instance.
-func NewNamespacedServiceReflector(
-	enableLoadBalancer bool, remoteRealLoadBalancerClassName string) func(*options.NamespacedOpts) manager.NamespacedReflector {
+func NewNamespacedServiceReflector(enableLoadBalancer bool, remoteRealLoadBalancerClassName string,
+	preferSameCluster bool) func(*options.NamespacedOpts) manager.NamespacedReflector {
 	return func(opts *options.NamespacedOpts) manager.NamespacedReflector {
 		local := opts.LocalFactory.Core().V1().Services()
 		remote := opts.RemoteFactory.Core().V1().Services()
@@ -83,6 +84,7 @@ func NewNamespacedServiceReflector(
 			remoteServicesClient:            opts.RemoteClient.CoreV1().Services(opts.RemoteNamespace),
 			enableLoadBalancer:              enableLoadBalancer,
 			remoteRealLoadBalancerClassName: remoteRealLoadBalancerClassName,
+			preferSameCluster:               preferSameCluster,
 		}
 	}
 }
@@ -152,7 +154,8 @@ func (nsr *NamespacedServiceReflector) Handle(ctx context.Context, name string)
 	}
 
 	// Forge the mutation to be applied to the remote cluster.
-	mutation := forge.RemoteService(local, nsr.RemoteNamespace(), nsr.enableLoadBalancer, nsr.remoteRealLoadBalancerClassName, nsr.ForgingOpts)
+	mutation := forge.RemoteService(local, nsr.RemoteNamespace(), nsr.enableLoadBalancer, nsr.remoteRealLoadBalancerClassName,
+		nsr.preferSameCluster, nsr.ForgingOpts)
 	tracer.Step("Remote mutation created")
 
 	defer tracer.Step("Enforced the correctness of the remote object")
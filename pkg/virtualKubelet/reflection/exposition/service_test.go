@@ -46,7 +46,7 @@ var _ = Describe("Service Reflection Tests", func() {
 				NumWorkers: 1,
 				Type:       root.DefaultReflectorsTypes[resources.Service],
 			}
-			Expect(exposition.NewServiceReflector(&reflectorConfig, false, "")).ToNot(BeNil())
+			Expect(exposition.NewServiceReflector(&reflectorConfig, false, "", false)).ToNot(BeNil())
 		})
 	})
 
@@ -106,7 +106,7 @@ var _ = Describe("Service Reflection Tests", func() {
 
 		JustBeforeEach(func() {
 			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
-			reflector = exposition.NewNamespacedServiceReflector(false, "")(options.NewNamespaced().
+			reflector = exposition.NewNamespacedServiceReflector(false, "", false)(options.NewNamespaced().
 				WithLocal(LocalNamespace, client, factory).
 				WithRemote(RemoteNamespace, client, factory).
 				WithHandlerFactory(FakeEventHandler).
@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -44,6 +45,8 @@ type NamespacedReflector struct {
 	remote string
 
 	reflectionType offloadingv1beta1.ReflectionType
+	disabled       bool
+	selector       labels.Selector
 
 	ForgingOpts *forge.ForgingOpts
 }
@@ -55,11 +58,60 @@ type ResourceDeleter interface {
 
 // NewNamespacedReflector returns a new NamespacedReflector for the given namespaces.
 func NewNamespacedReflector(opts *options.NamespacedOpts, name string) NamespacedReflector {
-	return NamespacedReflector{
+	reflector := NamespacedReflector{
 		EventRecorder: opts.EventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "liqo-" + strings.ToLower(name) + "-reflection"}),
 		local:         opts.LocalNamespace, remote: opts.RemoteNamespace, ready: opts.Ready,
 		reflectionType: opts.ReflectionType, ForgingOpts: opts.ForgingOpts,
 	}
+
+	if override := namespaceReflectorOverride(opts.Reflection, name); override != nil {
+		reflector.applyOverride(override)
+	}
+	return reflector
+}
+
+// inheritedOverrides maps the reflectors inheriting the reflection configuration from another one to the latter.
+var inheritedOverrides = map[string]string{
+	// The EndpointSlice reflector inherits the reflection policy from the Service reflector.
+	"endpointslice": "service",
+}
+
+// namespaceReflectorOverride returns the override of the reflection configuration associated with the given reflector, if any.
+func namespaceReflectorOverride(reflection *offloadingv1beta1.NamespaceReflection, name string) *offloadingv1beta1.NamespaceReflectorOverride {
+	if reflection == nil {
+		return nil
+	}
+
+	if inherited, found := inheritedOverrides[strings.ToLower(name)]; found {
+		name = inherited
+	}
+
+	for i := range reflection.Resources {
+		if strings.EqualFold(reflection.Resources[i].Resource, name) {
+			return &reflection.Resources[i]
+		}
+	}
+	return nil
+}
+
+// applyOverride customizes the reflection configuration according to the given per-namespace override.
+func (gnr *NamespacedReflector) applyOverride(override *offloadingv1beta1.NamespaceReflectorOverride) {
+	gnr.disabled = override.Disabled
+
+	// The reflection type can be overridden only for the reflectors supporting the allow/deny list policies.
+	if override.Type != "" && gnr.reflectionType != offloadingv1beta1.CustomLiqo {
+		gnr.reflectionType = override.Type
+	}
+
+	if override.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(override.Selector)
+		if err != nil {
+			// Fail safe, preventing the reflection of all objects in case the selector is invalid.
+			klog.Errorf("Invalid reflection selector for local namespace %q: %v", gnr.local, err)
+			selector = labels.Nothing()
+		}
+		gnr.selector = selector
+	}
 }
 
 // Ready returns whether the NamespacedReflector is completely initialized.
@@ -113,6 +165,11 @@ func (gnr *NamespacedReflector) DeleteLocal(ctx context.Context, deleter Resourc
 
 // ShouldSkipReflection returns whether the reflection of the given object should be skipped.
 func (gnr *NamespacedReflector) ShouldSkipReflection(obj metav1.Object) (bool, error) {
+	// The per-namespace overrides take precedence over the reflection policy.
+	if gnr.disabled || (gnr.selector != nil && !gnr.selector.Matches(labels.Set(obj.GetLabels()))) {
+		return true, nil
+	}
+
 	switch gnr.reflectionType {
 	case offloadingv1beta1.AllowList:
 		value, ok := obj.GetAnnotations()[consts.AllowReflectionAnnotationKey]
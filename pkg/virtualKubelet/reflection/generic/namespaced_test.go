@@ -46,19 +46,21 @@ var _ = Describe("NamespacedReflector tests", func() {
 			ready          bool
 			forgingOpts    *forge.ForgingOpts
 			reflectionType offloadingv1beta1.ReflectionType
+			reflection     *offloadingv1beta1.NamespaceReflection
 		)
 
 		BeforeEach(func() {
 			ready = false
 			forgingOpts = &forge.ForgingOpts{}
 			reflectionType = offloadingv1beta1.CustomLiqo
+			reflection = nil
 		})
 
 		JustBeforeEach(func() {
 			opts := options.NamespacedOpts{
 				LocalNamespace: localNamespace, RemoteNamespace: remoteNamespace,
 				Ready: func() bool { return ready }, EventBroadcaster: record.NewBroadcaster(),
-				ReflectionType: reflectionType, ForgingOpts: forgingOpts,
+				ReflectionType: reflectionType, ForgingOpts: forgingOpts, Reflection: reflection,
 			}
 			nsrfl = NewNamespacedReflector(&opts, name)
 		})
@@ -98,6 +100,96 @@ var _ = Describe("NamespacedReflector tests", func() {
 			})
 		})
 
+		Context("the per-namespace reflection overrides", func() {
+			var (
+				obj  metav1.ObjectMeta
+				skip bool
+				err  error
+			)
+
+			BeforeEach(func() {
+				reflectionType = offloadingv1beta1.DenyList
+				obj = metav1.ObjectMeta{Name: name, Namespace: localNamespace, Labels: map[string]string{"team": "foo"}}
+			})
+
+			JustBeforeEach(func() { skip, err = nsrfl.ShouldSkipReflection(&obj) })
+
+			When("no override refers to the reflector", func() {
+				BeforeEach(func() {
+					reflection = &offloadingv1beta1.NamespaceReflection{
+						Resources: []offloadingv1beta1.NamespaceReflectorOverride{{Resource: "other", Disabled: true}},
+					}
+				})
+
+				It("should not alter the reflection type", func() { Expect(nsrfl.GetReflectionType()).To(Equal(offloadingv1beta1.DenyList)) })
+				It("should not skip the reflection", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(skip).To(BeFalse())
+				})
+			})
+
+			When("the reflection is disabled", func() {
+				BeforeEach(func() {
+					reflection = &offloadingv1beta1.NamespaceReflection{
+						Resources: []offloadingv1beta1.NamespaceReflectorOverride{{Resource: name, Disabled: true}},
+					}
+				})
+
+				It("should skip the reflection", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(skip).To(BeTrue())
+				})
+			})
+
+			When("the reflection type is overridden", func() {
+				BeforeEach(func() {
+					reflection = &offloadingv1beta1.NamespaceReflection{
+						Resources: []offloadingv1beta1.NamespaceReflectorOverride{{Resource: name, Type: offloadingv1beta1.AllowList}},
+					}
+				})
+
+				It("should use the overridden reflection type", func() {
+					Expect(nsrfl.GetReflectionType()).To(Equal(offloadingv1beta1.AllowList))
+				})
+				It("should skip the reflection of the objects not marked with the allow annotation", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(skip).To(BeTrue())
+				})
+
+				When("the reflector follows the custom liqo logic", func() {
+					BeforeEach(func() { reflectionType = offloadingv1beta1.CustomLiqo })
+					It("should not alter the reflection type", func() {
+						Expect(nsrfl.GetReflectionType()).To(Equal(offloadingv1beta1.CustomLiqo))
+					})
+				})
+			})
+
+			When("a selector is specified", func() {
+				BeforeEach(func() {
+					reflection = &offloadingv1beta1.NamespaceReflection{
+						Resources: []offloadingv1beta1.NamespaceReflectorOverride{{
+							Resource: name, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}},
+						}},
+					}
+				})
+
+				When("the object matches the selector", func() {
+					It("should not skip the reflection", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(skip).To(BeFalse())
+					})
+				})
+
+				When("the object does not match the selector", func() {
+					BeforeEach(func() { obj.Labels = map[string]string{"team": "bar"} })
+					It("should skip the reflection", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(skip).To(BeTrue())
+					})
+				})
+			})
+		})
+
 		Context("remote resource deletion", func() {
 			var (
 				ctx     context.Context
@@ -17,9 +17,11 @@ package manager
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
@@ -29,8 +31,10 @@ import (
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/trace"
 
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	liqoclient "github.com/liqotech/liqo/pkg/client/clientset/versioned"
 	liqoinformers "github.com/liqotech/liqo/pkg/client/informers/externalversions"
+	"github.com/liqotech/liqo/pkg/consts"
 	traceutils "github.com/liqotech/liqo/pkg/utils/trace"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
@@ -172,13 +176,17 @@ func (m *manager) StartNamespace(local, remote string) {
 	remoteFactory := informers.NewSharedInformerFactoryWithOptions(m.remote, m.resync, informers.WithNamespace(remote))
 	remoteLiqoFactory := liqoinformers.NewSharedInformerFactoryWithOptions(m.remoteLiqo, m.resync, liqoinformers.WithNamespace(remote))
 
+	// Retrieve the per-namespace reflection customizations, and configure the forging options accordingly.
+	reflection := m.namespaceReflection(ctx, local)
+	forgingOpts := m.namespaceForgingOpts(reflection)
+
 	ready := false
 	for _, reflector := range m.reflectors {
 		opts := options.NewNamespaced().
 			WithLocal(local, m.local, localFactory).WithLiqoLocal(m.localLiqo, localLiqoFactory).
 			WithRemote(remote, m.remote, remoteFactory).WithLiqoRemote(m.remoteLiqo, remoteLiqoFactory).
 			WithReadinessFunc(func() bool { return ready }).WithEventBroadcaster(m.eventBroadcaster).
-			WithForgingOpts(&m.forgingOpts)
+			WithForgingOpts(forgingOpts).WithNamespaceReflection(reflection)
 		reflector.StartNamespace(opts)
 	}
 
@@ -212,6 +220,31 @@ func (m *manager) StartNamespace(local, remote string) {
 	}()
 }
 
+// namespaceReflection returns the reflection customizations specified in the NamespaceOffloading of the given namespace, if any.
+func (m *manager) namespaceReflection(ctx context.Context, namespace string) *offloadingv1beta1.NamespaceReflection {
+	nsoff, err := m.localLiqo.OffloadingV1beta1().NamespaceOffloadings(namespace).
+		Get(ctx, consts.DefaultNamespaceOffloadingName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			klog.Warningf("Failed to retrieve the NamespaceOffloading of namespace %q, using the default reflection configuration: %v", namespace, err)
+		}
+		return nil
+	}
+	return nsoff.Spec.Reflection
+}
+
+// namespaceForgingOpts returns the forging options to be used for a given namespace, given its reflection customizations.
+func (m *manager) namespaceForgingOpts(reflection *offloadingv1beta1.NamespaceReflection) *forge.ForgingOpts {
+	if reflection == nil || (len(reflection.LabelsNotReflected) == 0 && len(reflection.AnnotationsNotReflected) == 0) {
+		return &m.forgingOpts
+	}
+
+	forgingOpts := m.forgingOpts
+	forgingOpts.LabelsNotReflected = append(slices.Clone(m.forgingOpts.LabelsNotReflected), reflection.LabelsNotReflected...)
+	forgingOpts.AnnotationsNotReflected = append(slices.Clone(m.forgingOpts.AnnotationsNotReflected), reflection.AnnotationsNotReflected...)
+	return &forgingOpts
+}
+
 // StopNamespace stops the reflection for a given namespace.
 func (m *manager) StopNamespace(local, remote string) {
 	m.Lock()
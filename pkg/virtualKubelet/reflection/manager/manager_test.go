@@ -20,6 +20,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
@@ -27,6 +28,7 @@ import (
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	liqoclient "github.com/liqotech/liqo/pkg/client/clientset/versioned"
 	liqoclientfake "github.com/liqotech/liqo/pkg/client/clientset/versioned/fake"
+	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	reflectionfake "github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic/fake"
 )
@@ -166,6 +168,33 @@ var _ = Describe("Manager tests", func() {
 						Eventually(reflector.NamespaceStarted[localNamespace].Ready).Should(BeTrue())
 					})
 
+					When("the namespace specifies reflection customizations", func() {
+						BeforeEach(func() {
+							forgingOpts.LabelsNotReflected = []string{"global"}
+							localLiqoClient = liqoclientfake.NewSimpleClientset(&offloadingv1beta1.NamespaceOffloading{
+								ObjectMeta: metav1.ObjectMeta{Name: consts.DefaultNamespaceOffloadingName, Namespace: localNamespace},
+								Spec: offloadingv1beta1.NamespaceOffloadingSpec{
+									Reflection: &offloadingv1beta1.NamespaceReflection{
+										Resources:          []offloadingv1beta1.NamespaceReflectorOverride{{Resource: "secret", Disabled: true}},
+										LabelsNotReflected: []string{"local"},
+									},
+								},
+							})
+						})
+
+						It("should propagate the customizations to the reflector", func() {
+							opts := reflector.NamespaceStarted[localNamespace]
+							Expect(opts.Reflection).ToNot(BeNil())
+							Expect(opts.Reflection.Resources).To(ConsistOf(
+								offloadingv1beta1.NamespaceReflectorOverride{Resource: "secret", Disabled: true}))
+						})
+						It("should extend the forging options", func() {
+							opts := reflector.NamespaceStarted[localNamespace]
+							Expect(opts.ForgingOpts.LabelsNotReflected).To(ConsistOf("global", "local"))
+							Expect(mgr.(*manager).forgingOpts.LabelsNotReflected).To(ConsistOf("global"))
+						})
+					})
+
 					Context("the same namespace is stopped", func() {
 						JustBeforeEach(func() { mgr.StopNamespace(localNamespace, remoteNamespace) })
 
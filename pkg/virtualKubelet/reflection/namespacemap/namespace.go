@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -38,6 +39,10 @@ type Handler struct {
 	lister          offloadingv1beta1listers.NamespaceMapNamespaceLister
 	informerFactory liqoinformers.SharedInformerFactory
 
+	// nsoffInformerFactory is the informer factory for the NamespaceOffloadings of all namespaces,
+	// whose changes to the reflection customizations trigger the restart of the corresponding reflection.
+	nsoffInformerFactory liqoinformers.SharedInformerFactory
+
 	namespaceStartStopper manager.NamespaceStartStopper
 }
 
@@ -51,8 +56,9 @@ func NewHandler(localLiqoClient liqoclient.Interface, namespace string, resyncPe
 		liqoinformers.WithTweakListOptions(localLiqoNamespaceMapTweakListOptions))
 
 	return &Handler{
-		informerFactory: localLiqoInformerFactory,
-		lister:          localLiqoInformerFactory.Offloading().V1beta1().NamespaceMaps().Lister().NamespaceMaps(namespace),
+		informerFactory:      localLiqoInformerFactory,
+		lister:               localLiqoInformerFactory.Offloading().V1beta1().NamespaceMaps().Lister().NamespaceMaps(namespace),
+		nsoffInformerFactory: liqoinformers.NewSharedInformerFactory(localLiqoClient, resyncPeriod),
 	}
 }
 
@@ -73,8 +79,14 @@ func (nh *Handler) Start(ctx context.Context, namespaceStartStopper manager.Name
 	_, err := nh.informerFactory.Offloading().V1beta1().NamespaceMaps().Informer().AddEventHandler(eh)
 	utilruntime.Must(err)
 
+	nsoffEh := cache.ResourceEventHandlerFuncs{UpdateFunc: nh.onUpdateNamespaceOffloading}
+	_, err = nh.nsoffInformerFactory.Offloading().V1beta1().NamespaceOffloadings().Informer().AddEventHandler(nsoffEh)
+	utilruntime.Must(err)
+
 	nh.informerFactory.Start(ctx.Done())
+	nh.nsoffInformerFactory.Start(ctx.Done())
 	nh.informerFactory.WaitForCacheSync(ctx.Done())
+	nh.nsoffInformerFactory.WaitForCacheSync(ctx.Done())
 
 	klog.Info("namespaceMap handler started")
 }
@@ -120,6 +132,30 @@ func (nh *Handler) onUpdateNamespaceMap(oldObj, newObj interface{}) {
 	}
 }
 
+func (nh *Handler) onUpdateNamespaceOffloading(oldObj, newObj interface{}) {
+	oldNsOffloading := oldObj.(*offloadingv1beta1.NamespaceOffloading)
+	newNsOffloading := newObj.(*offloadingv1beta1.NamespaceOffloading)
+
+	if equality.Semantic.DeepEqual(oldNsOffloading.Spec.Reflection, newNsOffloading.Spec.Reflection) {
+		return
+	}
+
+	// Restart the reflection of the namespace (if currently active), to apply the new customizations.
+	localNs := newNsOffloading.GetNamespace()
+	nsList, err := nh.lister.List(labels.Everything())
+	utilruntime.Must(err)
+	for _, namespaceMap := range nsList {
+		remoteNamespaceStatus, found := namespaceMap.Status.CurrentMapping[localNs]
+		if !found || remoteNamespaceStatus.Phase != offloadingv1beta1.MappingAccepted {
+			continue
+		}
+
+		klog.Infof("Restarting reflection for local namespace %s, as the reflection customizations changed", localNs)
+		nh.stopNamespace(localNs, remoteNamespaceStatus)
+		nh.startNamespace(localNs, remoteNamespaceStatus)
+	}
+}
+
 func (nh *Handler) checkNamespaceMapUniqueness(_ interface{}) bool {
 	nsList, err := nh.lister.List(labels.SelectorFromSet(labels.Set{
 		liqoconst.RemoteClusterID:             string(forge.RemoteCluster),
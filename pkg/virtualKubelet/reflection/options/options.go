@@ -92,6 +92,7 @@ type NamespacedOpts struct {
 
 	ForgingOpts    *forge.ForgingOpts
 	ReflectionType offloadingv1beta1.ReflectionType
+	Reflection     *offloadingv1beta1.NamespaceReflection
 }
 
 // NewNamespaced returns a new NamespacedOpts object.
@@ -159,6 +160,12 @@ func (ro *NamespacedOpts) WithReflectionType(reflectionType offloadingv1beta1.Re
 	return ro
 }
 
+// WithNamespaceReflection configures the per-namespace reflection customizations of the NamespacedOpts.
+func (ro *NamespacedOpts) WithNamespaceReflection(reflection *offloadingv1beta1.NamespaceReflection) *NamespacedOpts {
+	ro.Reflection = reflection
+	return ro
+}
+
 // EventFilterCreate ignores events of type create.
 func EventFilterCreate(et watch.EventType) bool { return et == watch.Added }
 
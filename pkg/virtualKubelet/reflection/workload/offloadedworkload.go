@@ -0,0 +1,353 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsv1apply "k8s.io/client-go/applyconfigurations/apps/v1"
+	batchv1apply "k8s.io/client-go/applyconfigurations/batch/v1"
+	appsv1clients "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchv1clients "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1clients "k8s.io/client-go/kubernetes/typed/core/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	offloadingv1beta1clients "github.com/liqotech/liqo/pkg/client/clientset/versioned/typed/offloading/v1beta1"
+	offloadingv1beta1listers "github.com/liqotech/liqo/pkg/client/listers/offloading/v1beta1"
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/virtualkubelet"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ manager.NamespacedReflector = (*NamespacedWorkloadReflector)(nil)
+
+const (
+	// WorkloadReflectorName -> The name associated with the Workload reflector.
+	WorkloadReflectorName = "Workload"
+)
+
+// NamespacedWorkloadReflector manages the reflection of the workloads (i.e., Deployments, StatefulSets and Jobs)
+// offloaded as a whole, for a given pair of local and remote namespaces.
+type NamespacedWorkloadReflector struct {
+	generic.NamespacedReflector
+
+	localDeployments      appsv1listers.DeploymentNamespaceLister
+	localStatefulSets     appsv1listers.StatefulSetNamespaceLister
+	localJobs             batchv1listers.JobNamespaceLister
+	localPods             corev1listers.PodNamespaceLister
+	remoteShadowWorkloads offloadingv1beta1listers.ShadowWorkloadNamespaceLister
+
+	localDeploymentsClient      appsv1clients.DeploymentInterface
+	localStatefulSetsClient     appsv1clients.StatefulSetInterface
+	localJobsClient             batchv1clients.JobInterface
+	localPodsClient             corev1clients.PodInterface
+	remoteShadowWorkloadsClient offloadingv1beta1clients.ShadowWorkloadInterface
+}
+
+// NewWorkloadReflector returns a new WorkloadReflector instance.
+func NewWorkloadReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(WorkloadReflectorName, NewNamespacedWorkloadReflector,
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, offloadingv1beta1.CustomLiqo, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedWorkloadReflector returns a new NamespacedWorkloadReflector instance.
+func NewNamespacedWorkloadReflector(opts *options.NamespacedOpts) manager.NamespacedReflector {
+	localDeployments := opts.LocalFactory.Apps().V1().Deployments()
+	localStatefulSets := opts.LocalFactory.Apps().V1().StatefulSets()
+	localJobs := opts.LocalFactory.Batch().V1().Jobs()
+	localPods := opts.LocalFactory.Core().V1().Pods()
+	remoteShadowWorkloads := opts.RemoteLiqoFactory.Offloading().V1beta1().ShadowWorkloads()
+
+	_, err := localDeployments.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = localStatefulSets.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = localJobs.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	// The local placeholder pods trigger the workloads they belong to, to keep their status aligned with the remote one.
+	_, err = localPods.Informer().AddEventHandler(opts.HandlerFactory(placeholderKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = remoteShadowWorkloads.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+
+	return &NamespacedWorkloadReflector{
+		NamespacedReflector: generic.NewNamespacedReflector(opts, WorkloadReflectorName),
+
+		localDeployments:      localDeployments.Lister().Deployments(opts.LocalNamespace),
+		localStatefulSets:     localStatefulSets.Lister().StatefulSets(opts.LocalNamespace),
+		localJobs:             localJobs.Lister().Jobs(opts.LocalNamespace),
+		localPods:             localPods.Lister().Pods(opts.LocalNamespace),
+		remoteShadowWorkloads: remoteShadowWorkloads.Lister().ShadowWorkloads(opts.RemoteNamespace),
+
+		localDeploymentsClient:      opts.LocalClient.AppsV1().Deployments(opts.LocalNamespace),
+		localStatefulSetsClient:     opts.LocalClient.AppsV1().StatefulSets(opts.LocalNamespace),
+		localJobsClient:             opts.LocalClient.BatchV1().Jobs(opts.LocalNamespace),
+		localPodsClient:             opts.LocalClient.CoreV1().Pods(opts.LocalNamespace),
+		remoteShadowWorkloadsClient: opts.RemoteLiqoClient.OffloadingV1beta1().ShadowWorkloads(opts.RemoteNamespace),
+	}
+}
+
+// Handle reconciles the workloads offloaded as a whole.
+func (nwr *NamespacedWorkloadReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local workload %q (remote: %q)", nwr.LocalRef(name), nwr.RemoteRef(name))
+	local, spec := nwr.localWorkload(name)
+	remote, rerr := nwr.remoteShadowWorkloads.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if local != nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local workload %q as remote already exists and is not managed by us", nwr.LocalRef(name))
+			nwr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+
+	// Ensure the remote object is recreated in case the kind of the offloaded workload changed, as the native one cannot be converted.
+	if local != nil && rerr == nil && remote.Spec.Kind != spec.Kind {
+		klog.Infof("Recreating remote ShadowWorkload %q, since the kind of the local workload %q changed from %s to %s",
+			nwr.RemoteRef(name), nwr.LocalRef(name), remote.Spec.Kind, spec.Kind)
+		local = nil
+	}
+	tracer.Step("Performed the sanity checks")
+
+	// The local workload does no longer exist (or it is no longer offloaded). Ensure it is also absent from the remote cluster.
+	if local == nil {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote ShadowWorkload %q, since local workload %q does no longer exist", nwr.RemoteRef(name), nwr.LocalRef(name))
+			return nwr.DeleteRemote(ctx, nwr.remoteShadowWorkloadsClient, WorkloadReflectorName, name, remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local workload %q and remote ShadowWorkload %q both vanished", nwr.LocalRef(name), nwr.RemoteRef(name))
+		return nil
+	}
+
+	// Forge the target object to be enforced in the remote cluster.
+	if kerrors.IsNotFound(rerr) {
+		remote = nil
+	}
+	target := forge.RemoteShadowWorkload(local, spec, remote, nwr.RemoteNamespace(), nwr.ForgingOpts)
+	tracer.Step("Remote object forged")
+
+	if remote == nil {
+		defer tracer.Step("Ensured the presence of the remote object")
+		if _, err := nwr.remoteShadowWorkloadsClient.Create(ctx, target, metav1.CreateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			klog.Errorf("Failed to create remote ShadowWorkload %q (local: %q): %v", nwr.RemoteRef(name), nwr.LocalRef(name), err)
+			nwr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+			return err
+		}
+
+		klog.Infof("Remote ShadowWorkload %q successfully created (local: %q)", nwr.RemoteRef(name), nwr.LocalRef(name))
+		nwr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+		return nil
+	}
+
+	if !equality.Semantic.DeepEqual(remote.Spec, target.Spec) || !equality.Semantic.DeepEqual(remote.GetLabels(), target.GetLabels()) ||
+		!equality.Semantic.DeepEqual(remote.GetAnnotations(), target.GetAnnotations()) {
+		defer tracer.Step("Enforced the correctness of the remote object")
+		if _, err := nwr.remoteShadowWorkloadsClient.Update(ctx, target, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			klog.Errorf("Failed to update remote ShadowWorkload %q (local: %q): %v", nwr.RemoteRef(name), nwr.LocalRef(name), err)
+			if !kerrors.IsConflict(err) {
+				nwr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+			}
+			return err
+		}
+
+		klog.Infof("Remote ShadowWorkload %q successfully updated (local: %q)", nwr.RemoteRef(name), nwr.LocalRef(name))
+		nwr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+		return nil
+	}
+
+	// Report the status aggregated from the remote workload on the local one, as the native controller owns its status.
+	defer tracer.Step("Enforced the status of the local object")
+	if err := nwr.enforceLocalStatus(ctx, local, remote); err != nil {
+		return err
+	}
+	return nwr.enforcePlaceholdersStatus(ctx, local, remote)
+}
+
+// localWorkload returns the local workload with the given name which is marked to be offloaded to the remote cluster, if any,
+// along with the spec of the corresponding ShadowWorkload. Deployments take precedence over StatefulSets, and in turn over Jobs.
+func (nwr *NamespacedWorkloadReflector) localWorkload(name string) (client.Object, *offloadingv1beta1.ShadowWorkloadSpec) {
+	deploy, err := nwr.localDeployments.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(err))
+	if err == nil && forge.IsWorkloadOffloaded(&deploy.Spec.Template) {
+		return deploy, forge.RemoteDeploymentWorkloadSpec(deploy, nwr.ForgingOpts)
+	}
+
+	sts, err := nwr.localStatefulSets.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(err))
+	if err == nil && forge.IsWorkloadOffloaded(&sts.Spec.Template) {
+		if len(sts.Spec.VolumeClaimTemplates) > 0 {
+			klog.Warningf("Skipping reflection of local StatefulSet %q, as volume claim templates are not supported", nwr.LocalRef(name))
+			nwr.Event(sts, corev1.EventTypeWarning, forge.EventFailedReflection,
+				forge.EventUnsupportedWorkloadMsg("StatefulSets with volume claim templates are not supported"))
+			return nil, nil
+		}
+		return sts, forge.RemoteStatefulSetWorkloadSpec(sts, nwr.ForgingOpts)
+	}
+
+	job, err := nwr.localJobs.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(err))
+	if err == nil && forge.IsWorkloadOffloaded(&job.Spec.Template) {
+		return job, forge.RemoteJobWorkloadSpec(job, nwr.ForgingOpts)
+	}
+
+	return nil, nil
+}
+
+// enforceLocalStatus reports the status of the remote ShadowWorkload on the local workload, through the corresponding annotation.
+func (nwr *NamespacedWorkloadReflector) enforceLocalStatus(ctx context.Context, local client.Object, remote *offloadingv1beta1.ShadowWorkload) error {
+	summary := forge.RemoteWorkloadStatusSummary(remote)
+	if local.GetAnnotations()[liqoconst.RemoteWorkloadStatusAnnotationKey] == summary {
+		return nil
+	}
+
+	annotations := map[string]string{liqoconst.RemoteWorkloadStatusAnnotationKey: summary}
+	var err error
+	switch local.(type) {
+	case *appsv1.Deployment:
+		_, err = nwr.localDeploymentsClient.Apply(ctx,
+			appsv1apply.Deployment(local.GetName(), local.GetNamespace()).WithAnnotations(annotations), forge.ApplyOptions())
+	case *appsv1.StatefulSet:
+		_, err = nwr.localStatefulSetsClient.Apply(ctx,
+			appsv1apply.StatefulSet(local.GetName(), local.GetNamespace()).WithAnnotations(annotations), forge.ApplyOptions())
+	case *batchv1.Job:
+		_, err = nwr.localJobsClient.Apply(ctx,
+			batchv1apply.Job(local.GetName(), local.GetNamespace()).WithAnnotations(annotations), forge.ApplyOptions())
+	}
+
+	if err != nil {
+		klog.Errorf("Failed to update the status of local workload %q (remote: %q): %v",
+			nwr.LocalRef(local.GetName()), nwr.RemoteRef(remote.GetName()), err)
+		nwr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedStatusReflectionMsg(err))
+		return err
+	}
+
+	klog.Infof("Status of local workload %q successfully enforced (remote: %q)", nwr.LocalRef(local.GetName()), nwr.RemoteRef(remote.GetName()))
+	return nil
+}
+
+// enforcePlaceholdersStatus mirrors the status of the remote ShadowWorkload onto the local placeholder pods,
+// so that the native controller computes the status of the local workload accordingly.
+func (nwr *NamespacedWorkloadReflector) enforcePlaceholdersStatus(ctx context.Context, local client.Object,
+	remote *offloadingv1beta1.ShadowWorkload) error {
+	var selector *metav1.LabelSelector
+	switch workload := local.(type) {
+	case *appsv1.Deployment:
+		selector = workload.Spec.Selector
+	case *appsv1.StatefulSet:
+		selector = workload.Spec.Selector
+	case *batchv1.Job:
+		selector = workload.Spec.Selector
+	}
+	if selector == nil {
+		return nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil || sel.Empty() {
+		klog.Warningf("Skipping the status reflection on the placeholders of local workload %q, due to an invalid selector", nwr.LocalRef(local.GetName()))
+		return nil
+	}
+
+	pods, err := nwr.localPods.List(sel)
+	utilruntime.Must(err)
+	var placeholders []*corev1.Pod
+	for _, pod := range pods {
+		if forge.IsWorkloadPlaceholder(pod) {
+			placeholders = append(placeholders, pod)
+		}
+	}
+
+	for _, pod := range forge.WorkloadPlaceholdersStatus(remote, placeholders, metav1.Now()) {
+		if _, err := nwr.localPodsClient.UpdateStatus(ctx, pod, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			klog.Errorf("Failed to update the status of placeholder pod %q of local workload %q (remote: %q): %v",
+				nwr.LocalRef(pod.GetName()), nwr.LocalRef(local.GetName()), nwr.RemoteRef(remote.GetName()), err)
+			return err
+		}
+		klog.V(4).Infof("Status of placeholder pod %q of local workload %q successfully enforced",
+			nwr.LocalRef(pod.GetName()), nwr.LocalRef(local.GetName()))
+	}
+	return nil
+}
+
+// placeholderKeyer returns a keyer mapping the local placeholder pods to the workloads they belong to.
+// The Deployment pods are owned by a ReplicaSet, whose name is the one of the Deployment followed by the template hash.
+func placeholderKeyer(namespace string) options.Keyer {
+	return func(metadata metav1.Object) []types.NamespacedName {
+		pod, ok := metadata.(*corev1.Pod)
+		if !ok || !forge.IsWorkloadPlaceholder(pod) {
+			return nil
+		}
+
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil {
+			return nil
+		}
+
+		name := owner.Name
+		if owner.Kind == "ReplicaSet" {
+			name = strings.TrimSuffix(owner.Name, "-"+pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey])
+		}
+		return []types.NamespacedName{{Namespace: namespace, Name: name}}
+	}
+}
+
+// List returns the list of objects to be reflected.
+func (nwr *NamespacedWorkloadReflector) List() ([]interface{}, error) {
+	deployments, err := virtualkubelet.List[virtualkubelet.Lister[*appsv1.Deployment], *appsv1.Deployment](nwr.localDeployments)
+	if err != nil {
+		return nil, err
+	}
+	statefulsets, err := virtualkubelet.List[virtualkubelet.Lister[*appsv1.StatefulSet], *appsv1.StatefulSet](nwr.localStatefulSets)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := virtualkubelet.List[virtualkubelet.Lister[*batchv1.Job], *batchv1.Job](nwr.localJobs)
+	if err != nil {
+		return nil, err
+	}
+	shadows, err := virtualkubelet.List[virtualkubelet.Lister[*offloadingv1beta1.ShadowWorkload], *offloadingv1beta1.ShadowWorkload](
+		nwr.remoteShadowWorkloads)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(append(deployments, statefulsets...), jobs...), shadows...), nil
+}
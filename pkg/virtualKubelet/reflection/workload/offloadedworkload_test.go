@@ -0,0 +1,294 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/trace"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/cmd/virtual-kubelet/root"
+	liqoclient "github.com/liqotech/liqo/pkg/client/clientset/versioned"
+	liqoclientfake "github.com/liqotech/liqo/pkg/client/clientset/versioned/fake"
+	liqoinformers "github.com/liqotech/liqo/pkg/client/informers/externalversions"
+	"github.com/liqotech/liqo/pkg/consts"
+	. "github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/workload"
+)
+
+var _ = Describe("Workload Reflection Tests", func() {
+	Describe("the NewWorkloadReflector function", func() {
+		It("should not return a nil reflector", func() {
+			reflectorConfig := offloadingv1beta1.ReflectorConfig{
+				NumWorkers: 1,
+				Type:       root.DefaultReflectorsTypes[resources.Workload],
+			}
+			Expect(workload.NewWorkloadReflector(&reflectorConfig)).ToNot(BeNil())
+		})
+	})
+
+	Describe("workload handling", func() {
+		const WorkloadName = "name"
+
+		var (
+			client     *fake.Clientset
+			liqoClient liqoclient.Interface
+			reflector  manager.NamespacedReflector
+
+			applied map[string]string
+			err     error
+		)
+
+		template := func(offloaded bool) corev1.PodTemplateSpec {
+			tmpl := corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "bar", Image: "foo"}}},
+			}
+			if offloaded {
+				tmpl.Annotations = map[string]string{consts.WorkloadOffloadingAnnotationKey: RemoteClusterID}
+			}
+			return tmpl
+		}
+
+		deployment := func(offloaded bool) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: WorkloadName, Namespace: LocalNamespace, Labels: map[string]string{"foo": "bar"}},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To[int32](3),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					Template: template(offloaded),
+				},
+			}
+		}
+
+		GetShadowWorkload := func() (*offloadingv1beta1.ShadowWorkload, error) {
+			return liqoClient.OffloadingV1beta1().ShadowWorkloads(RemoteNamespace).Get(ctx, WorkloadName, metav1.GetOptions{})
+		}
+
+		CreateShadowWorkload := func(kind offloadingv1beta1.WorkloadKind, lbls map[string]string) {
+			shadow := &offloadingv1beta1.ShadowWorkload{
+				ObjectMeta: metav1.ObjectMeta{Name: WorkloadName, Namespace: RemoteNamespace, Labels: lbls},
+				Spec:       offloadingv1beta1.ShadowWorkloadSpec{Kind: kind},
+				Status:     offloadingv1beta1.ShadowWorkloadStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2},
+			}
+			_, errc := liqoClient.OffloadingV1beta1().ShadowWorkloads(RemoteNamespace).Create(ctx, shadow, metav1.CreateOptions{})
+			Expect(errc).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			client = fake.NewSimpleClientset()
+			liqoClient = liqoclientfake.NewSimpleClientset()
+			applied = nil
+
+			// Currently, the fake client does not handle SSA, and we need to add a custom reactor for that.
+			client.PrependReactor("patch", "deployments", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+				patch := action.(testing.PatchAction)
+				Expect(patch.GetPatchType()).To(Equal(types.ApplyPatchType))
+				var obj metav1.PartialObjectMetadata
+				Expect(json.Unmarshal(patch.GetPatch(), &obj)).To(Succeed())
+				applied = obj.GetAnnotations()
+				return true, nil, nil
+			})
+		})
+
+		JustBeforeEach(func() {
+			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
+			liqoFactory := liqoinformers.NewSharedInformerFactory(liqoClient, 10*time.Hour)
+
+			reflector = workload.NewNamespacedWorkloadReflector(options.NewNamespaced().
+				WithLocal(LocalNamespace, client, factory).WithLiqoLocal(liqoClient, liqoFactory).
+				WithRemote(RemoteNamespace, client, factory).WithLiqoRemote(liqoClient, liqoFactory).
+				WithHandlerFactory(FakeEventHandler).WithEventBroadcaster(record.NewBroadcaster()).WithForgingOpts(FakeForgingOpts()))
+
+			factory.Start(ctx.Done())
+			liqoFactory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+			liqoFactory.WaitForCacheSync(ctx.Done())
+
+			err = reflector.Handle(trace.ContextWithTrace(ctx, trace.New("Workload")), WorkloadName)
+		})
+
+		When("the local workload does not exist", func() {
+			When("the remote object exists", func() {
+				BeforeEach(func() { CreateShadowWorkload(offloadingv1beta1.DeploymentWorkloadKind, forge.ReflectionLabels()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object", func() {
+					_, err = GetShadowWorkload()
+					Expect(err).To(BeNotFound())
+				})
+			})
+		})
+
+		When("the local workload is not marked for offloading", func() {
+			BeforeEach(func() {
+				_, err = client.AppsV1().Deployments(LocalNamespace).Create(ctx, deployment(false), metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should not create the remote object", func() {
+				_, err = GetShadowWorkload()
+				Expect(err).To(BeNotFound())
+			})
+		})
+
+		When("the local deployment is marked for offloading", func() {
+			BeforeEach(func() {
+				_, err = client.AppsV1().Deployments(LocalNamespace).Create(ctx, deployment(true), metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should create the remote object", func() {
+					shadow, errg := GetShadowWorkload()
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(shadow.Labels).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, LocalClusterID))
+					Expect(shadow.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(shadow.Spec.Kind).To(Equal(offloadingv1beta1.DeploymentWorkloadKind))
+					Expect(shadow.Spec.Replicas).To(PointTo(BeNumerically("==", 3)))
+					Expect(shadow.Spec.Template.Annotations).ToNot(HaveKey(consts.WorkloadOffloadingAnnotationKey))
+				})
+			})
+
+			When("the remote object already exists and is up-to-date", func() {
+				BeforeEach(func() {
+					spec := forge.RemoteDeploymentWorkloadSpec(deployment(true), FakeForgingOpts())
+					shadow := forge.RemoteShadowWorkload(deployment(true), spec, nil, RemoteNamespace, FakeForgingOpts())
+					shadow.Status = offloadingv1beta1.ShadowWorkloadStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2}
+					_, err = liqoClient.OffloadingV1beta1().ShadowWorkloads(RemoteNamespace).Create(ctx, shadow, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should report the remote status on the local object", func() {
+					Expect(applied).To(HaveKeyWithValue(consts.RemoteWorkloadStatusAnnotationKey, "2/3 ready, 3 updated, 2 available"))
+				})
+
+				When("the local placeholder pods exist", func() {
+					BeforeEach(func() {
+						for _, name := range []string{"first", "second", "third"} {
+							pod := &corev1.Pod{
+								ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: LocalNamespace, Labels: map[string]string{"app": "foo"}},
+								Spec:       corev1.PodSpec{SchedulingGates: []corev1.PodSchedulingGate{{Name: consts.WorkloadOffloadingSchedulingGate}}},
+							}
+							_, err = client.CoreV1().Pods(LocalNamespace).Create(ctx, pod, metav1.CreateOptions{})
+							Expect(err).ToNot(HaveOccurred())
+						}
+					})
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("should mark ready as many placeholders as the remote ready replicas", func() {
+						pods, errl := client.CoreV1().Pods(LocalNamespace).List(ctx, metav1.ListOptions{})
+						Expect(errl).ToNot(HaveOccurred())
+						ready := 0
+						for i := range pods.Items {
+							for _, cond := range pods.Items[i].Status.Conditions {
+								if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+									ready++
+								}
+							}
+						}
+						Expect(ready).To(Equal(2))
+					})
+				})
+			})
+
+			When("the remote object has a different kind", func() {
+				BeforeEach(func() { CreateShadowWorkload(offloadingv1beta1.JobWorkloadKind, forge.ReflectionLabels()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object, to be recreated", func() {
+					_, err = GetShadowWorkload()
+					Expect(err).To(BeNotFound())
+				})
+			})
+
+			When("the remote object is not managed by us", func() {
+				BeforeEach(func() { CreateShadowWorkload(offloadingv1beta1.DeploymentWorkloadKind, nil) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not mutate the remote object", func() {
+					shadow, errg := GetShadowWorkload()
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(shadow.Labels).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the local statefulset with volume claim templates is marked for offloading", func() {
+			BeforeEach(func() {
+				sts := &appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: WorkloadName, Namespace: LocalNamespace},
+					Spec: appsv1.StatefulSetSpec{
+						Template:             template(true),
+						VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+					},
+				}
+				_, err = client.AppsV1().StatefulSets(LocalNamespace).Create(ctx, sts, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should not create the remote object", func() {
+				_, err = GetShadowWorkload()
+				Expect(err).To(BeNotFound())
+			})
+		})
+
+		When("the local job is marked for offloading", func() {
+			BeforeEach(func() {
+				job := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{Name: WorkloadName, Namespace: LocalNamespace},
+					Spec:       batchv1.JobSpec{Completions: ptr.To[int32](2), Template: template(true)},
+				}
+				job.Spec.Template.Labels[batchv1.JobNameLabel] = WorkloadName
+				_, err = client.BatchV1().Jobs(LocalNamespace).Create(ctx, job, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should create the remote object", func() {
+				shadow, errg := GetShadowWorkload()
+				Expect(errg).ToNot(HaveOccurred())
+				Expect(shadow.Spec.Kind).To(Equal(offloadingv1beta1.JobWorkloadKind))
+				Expect(shadow.Spec.Job).ToNot(BeNil())
+				Expect(shadow.Spec.Job.Completions).To(PointTo(BeNumerically("==", 2)))
+				Expect(shadow.Spec.Template.Labels).ToNot(HaveKey(batchv1.JobNameLabel))
+			})
+		})
+	})
+})
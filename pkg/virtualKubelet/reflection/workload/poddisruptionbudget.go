@@ -0,0 +1,252 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	policyv1apply "k8s.io/client-go/applyconfigurations/policy/v1"
+	policyv1clients "k8s.io/client-go/kubernetes/typed/policy/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+	podutils "github.com/liqotech/liqo/pkg/utils/pod"
+	"github.com/liqotech/liqo/pkg/utils/virtualkubelet"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ manager.NamespacedReflector = (*NamespacedPodDisruptionBudgetReflector)(nil)
+
+const (
+	// PodDisruptionBudgetReflectorName -> The name associated with the PodDisruptionBudget reflector.
+	PodDisruptionBudgetReflectorName = "PodDisruptionBudget"
+)
+
+// NamespacedPodDisruptionBudgetReflector manages the PodDisruptionBudget reflection for a given pair of local and remote namespaces.
+type NamespacedPodDisruptionBudgetReflector struct {
+	generic.NamespacedReflector
+
+	localPDBs        policyv1listers.PodDisruptionBudgetNamespaceLister
+	remotePDBs       policyv1listers.PodDisruptionBudgetNamespaceLister
+	localPDBsClient  policyv1clients.PodDisruptionBudgetInterface
+	remotePDBsClient policyv1clients.PodDisruptionBudgetInterface
+
+	localPods  corev1listers.PodNamespaceLister
+	localNodes corev1listers.NodeLister
+}
+
+// NewPodDisruptionBudgetReflector returns a new PodDisruptionBudgetReflector instance.
+func NewPodDisruptionBudgetReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(PodDisruptionBudgetReflectorName, NewNamespacedPodDisruptionBudgetReflector,
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedPodDisruptionBudgetReflector returns a new NamespacedPodDisruptionBudgetReflector instance.
+func NewNamespacedPodDisruptionBudgetReflector(opts *options.NamespacedOpts) manager.NamespacedReflector {
+	local := opts.LocalFactory.Policy().V1().PodDisruptionBudgets()
+	remote := opts.RemoteFactory.Policy().V1().PodDisruptionBudgets()
+	localPods := opts.LocalFactory.Core().V1().Pods()
+	localNodes := opts.LocalFactory.Core().V1().Nodes()
+
+	// The local budgets are also triggered by the changes of the status computed by the local disruption controller,
+	// which in turn follows the health of the local pods (hence, reflecting the one of the remote ones).
+	_, err := local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+
+	return &NamespacedPodDisruptionBudgetReflector{
+		NamespacedReflector: generic.NewNamespacedReflector(opts, PodDisruptionBudgetReflectorName),
+		localPDBs:           local.Lister().PodDisruptionBudgets(opts.LocalNamespace),
+		remotePDBs:          remote.Lister().PodDisruptionBudgets(opts.RemoteNamespace),
+		localPDBsClient:     opts.LocalClient.PolicyV1().PodDisruptionBudgets(opts.LocalNamespace),
+		remotePDBsClient:    opts.RemoteClient.PolicyV1().PodDisruptionBudgets(opts.RemoteNamespace),
+		localPods:           localPods.Lister().Pods(opts.LocalNamespace),
+		localNodes:          localNodes.Lister(),
+	}
+}
+
+// Handle reconciles poddisruptionbudget objects.
+func (npr *NamespacedPodDisruptionBudgetReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local PodDisruptionBudget %q (remote: %q)", npr.LocalRef(name), npr.RemoteRef(name))
+	local, lerr := npr.localPDBs.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(lerr))
+	remote, rerr := npr.remotePDBs.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if lerr == nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local PodDisruptionBudget %q as remote already exists and is not managed by us", npr.LocalRef(name))
+			npr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+
+	// Abort the reflection if the local object has the "skip-reflection" annotation.
+	if !kerrors.IsNotFound(lerr) {
+		skipReflection, err := npr.ShouldSkipReflection(local)
+		if err != nil {
+			klog.Errorf("Failed to check whether local PodDisruptionBudget %q should be reflected: %v", npr.LocalRef(name), err)
+			return err
+		}
+		if skipReflection {
+			if npr.GetReflectionType() == offloadingv1beta1.DenyList {
+				klog.Infof("Skipping reflection of local PodDisruptionBudget %q as marked with the skip annotation", npr.LocalRef(name))
+			} else { // AllowList
+				klog.Infof("Skipping reflection of local PodDisruptionBudget %q as not marked with the allow annotation", npr.LocalRef(name))
+			}
+			npr.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionDisabledMsg(npr.GetReflectionType()))
+			if kerrors.IsNotFound(rerr) { // The remote object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			lerr = kerrors.NewNotFound(policyv1.Resource("poddisruptionbudget"), local.GetName())
+		}
+	}
+
+	tracer.Step("Performed the sanity checks")
+
+	// The local poddisruptionbudget does no longer exist. Ensure it is also absent from the remote cluster.
+	if kerrors.IsNotFound(lerr) {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote PodDisruptionBudget %q, since local %q does no longer exist", npr.RemoteRef(name), npr.LocalRef(name))
+			return npr.DeleteRemote(ctx, npr.remotePDBsClient, PodDisruptionBudgetReflectorName, name, remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local PodDisruptionBudget %q and remote PodDisruptionBudget %q both vanished", npr.LocalRef(name), npr.RemoteRef(name))
+		return nil
+	}
+
+	healthy, err := npr.healthyOffloadedPods(local)
+	if err != nil {
+		klog.Errorf("Failed to retrieve the pods selected by local PodDisruptionBudget %q: %v", npr.LocalRef(name), err)
+		return err
+	}
+	allowed := forge.DisruptionsAllowedShare(local.Status.DisruptionsAllowed, healthy, forge.LiqoNodeName)
+
+	// Forge the mutation to be applied to the remote cluster.
+	mutation := forge.RemotePodDisruptionBudget(local, healthy[forge.LiqoNodeName], allowed, npr.RemoteNamespace(), npr.ForgingOpts)
+	tracer.Step("Remote mutation created")
+
+	if _, err := npr.remotePDBsClient.Apply(ctx, mutation, forge.ApplyOptions()); err != nil {
+		klog.Errorf("Failed to enforce remote PodDisruptionBudget %q (local: %q): %v", npr.RemoteRef(name), npr.LocalRef(name), err)
+		npr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+		return err
+	}
+	tracer.Step("Enforced the correctness of the remote object")
+
+	klog.Infof("Remote PodDisruptionBudget %q successfully enforced (local: %q)", npr.RemoteRef(name), npr.LocalRef(name))
+	npr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+
+	// The status of the remote object is available only once it already exists, hence reported as part of the following reconciliation.
+	if kerrors.IsNotFound(rerr) {
+		return nil
+	}
+
+	defer tracer.Step("Enforced the status of the local object")
+	return npr.enforceLocalStatus(ctx, local, remote)
+}
+
+// healthyOffloadedPods returns the number of healthy pods selected by the given local poddisruptionbudget,
+// and which are offloaded through each virtual node (including the current one).
+func (npr *NamespacedPodDisruptionBudgetReflector) healthyOffloadedPods(local *policyv1.PodDisruptionBudget) (map[string]int32, error) {
+	healthy := map[string]int32{}
+	if local.Spec.Selector == nil {
+		return healthy, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(local.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := npr.localPods.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if ready, _ := podutils.IsPodReady(pod); !ready || !npr.isVirtualNode(pod.Spec.NodeName) {
+			continue
+		}
+		healthy[pod.Spec.NodeName]++
+	}
+	return healthy, nil
+}
+
+// isVirtualNode returns whether the node with the given name is a virtual node.
+func (npr *NamespacedPodDisruptionBudgetReflector) isVirtualNode(name string) bool {
+	if name == forge.LiqoNodeName {
+		return true
+	}
+
+	node, err := npr.localNodes.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(err))
+	return err == nil && node.Labels[liqoconst.TypeLabel] == liqoconst.TypeNode
+}
+
+// enforceLocalStatus reports the status of the remote poddisruptionbudget on the local one, through the corresponding annotation.
+// The actual status is not overwritten, as managed by the disruption controller of the local cluster.
+func (npr *NamespacedPodDisruptionBudgetReflector) enforceLocalStatus(ctx context.Context,
+	local, remote *policyv1.PodDisruptionBudget) error {
+	summary := forge.RemotePodDisruptionBudgetStatusSummary(remote)
+	if local.GetAnnotations()[liqoconst.RemotePodDisruptionBudgetStatusAnnotationKey] == summary {
+		return nil
+	}
+
+	mutation := policyv1apply.PodDisruptionBudget(local.GetName(), local.GetNamespace()).
+		WithAnnotations(map[string]string{liqoconst.RemotePodDisruptionBudgetStatusAnnotationKey: summary})
+	if _, err := npr.localPDBsClient.Apply(ctx, mutation, forge.ApplyOptions()); err != nil {
+		klog.Errorf("Failed to update the status of local PodDisruptionBudget %q (remote: %q): %v",
+			npr.LocalRef(local.GetName()), npr.RemoteRef(remote.GetName()), err)
+		npr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedStatusReflectionMsg(err))
+		return err
+	}
+
+	klog.Infof("Status of local PodDisruptionBudget %q successfully enforced (remote: %q)",
+		npr.LocalRef(local.GetName()), npr.RemoteRef(remote.GetName()))
+	return nil
+}
+
+// List returns the list of poddisruptionbudget objects to be reflected.
+func (npr *NamespacedPodDisruptionBudgetReflector) List() ([]interface{}, error) {
+	return virtualkubelet.List[virtualkubelet.Lister[*policyv1.PodDisruptionBudget], *policyv1.PodDisruptionBudget](
+		npr.localPDBs,
+		npr.remotePDBs,
+	)
+}
@@ -0,0 +1,224 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/trace"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/cmd/virtual-kubelet/root"
+	"github.com/liqotech/liqo/pkg/consts"
+	. "github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/workload"
+)
+
+var _ = Describe("PodDisruptionBudget Reflection Tests", func() {
+	Describe("the NewPodDisruptionBudgetReflector function", func() {
+		It("should not return a nil reflector", func() {
+			reflectorConfig := offloadingv1beta1.ReflectorConfig{
+				NumWorkers: 1,
+				Type:       root.DefaultReflectorsTypes[resources.PodDisruptionBudget],
+			}
+			Expect(workload.NewPodDisruptionBudgetReflector(&reflectorConfig)).ToNot(BeNil())
+		})
+	})
+
+	Describe("poddisruptionbudget handling", func() {
+		const PDBName = "name"
+
+		var (
+			client    *fake.Clientset
+			reflector manager.NamespacedReflector
+			err       error
+		)
+
+		GetPDB := func(namespace string) (*policyv1.PodDisruptionBudget, error) {
+			return client.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, PDBName, metav1.GetOptions{})
+		}
+
+		CreatePDB := func(pdb *policyv1.PodDisruptionBudget) {
+			_, errc := client.PolicyV1().PodDisruptionBudgets(pdb.GetNamespace()).Create(ctx, pdb, metav1.CreateOptions{})
+			Expect(errc).ToNot(HaveOccurred())
+		}
+
+		CreatePod := func(name, node string, ready corev1.ConditionStatus) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: LocalNamespace, Labels: map[string]string{"app": "foo"}},
+				Spec:       corev1.PodSpec{NodeName: node},
+				Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+			}
+			_, errc := client.CoreV1().Pods(LocalNamespace).Create(ctx, pod, metav1.CreateOptions{})
+			Expect(errc).ToNot(HaveOccurred())
+		}
+
+		LocalPDB := func() *policyv1.PodDisruptionBudget {
+			return &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: PDBName, Namespace: LocalNamespace, Labels: map[string]string{"foo": "bar"}},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: ptr.To(intstr.FromInt32(2)),
+					Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			}
+		}
+
+		BeforeEach(func() { client = fake.NewClientset() })
+
+		JustBeforeEach(func() {
+			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
+
+			reflector = workload.NewNamespacedPodDisruptionBudgetReflector(options.NewNamespaced().
+				WithLocal(LocalNamespace, client, factory).WithRemote(RemoteNamespace, client, factory).
+				WithReflectionType(root.DefaultReflectorsTypes[resources.PodDisruptionBudget]).
+				WithHandlerFactory(FakeEventHandler).WithEventBroadcaster(record.NewBroadcaster()).WithForgingOpts(FakeForgingOpts()))
+
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			err = reflector.Handle(trace.ContextWithTrace(ctx, trace.New("PodDisruptionBudget")), PDBName)
+		})
+
+		When("the local object does exist", func() {
+			BeforeEach(func() {
+				CreatePDB(LocalPDB())
+				CreatePod("offloaded-ready-1", LiqoNodeName, corev1.ConditionTrue)
+				CreatePod("offloaded-ready-2", LiqoNodeName, corev1.ConditionTrue)
+				CreatePod("offloaded-not-ready", LiqoNodeName, corev1.ConditionFalse)
+				CreatePod("local-ready", "physical-node", corev1.ConditionTrue)
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should create the remote object", func() {
+					remote, errg := GetPDB(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(forge.IsReflected(remote)).To(BeTrue())
+					Expect(remote.Spec.Selector.MatchLabels).To(HaveKeyWithValue("app", "foo"))
+					Expect(remote.Spec.Selector.MatchLabels).To(HaveKeyWithValue(forge.LiqoOriginClusterNodeName, LiqoNodeName))
+				})
+				It("should allow no more disruptions of the healthy offloaded pods than the local budget", func() {
+					remote, errg := GetPDB(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt32(1))))
+				})
+			})
+
+			When("healthy pods are also offloaded through other virtual nodes", func() {
+				BeforeEach(func() {
+					node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "aaa-virtual-node", Labels: map[string]string{consts.TypeLabel: consts.TypeNode}}}
+					_, errc := client.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+					Expect(errc).ToNot(HaveOccurred())
+					CreatePod("other-offloaded-ready", node.Name, corev1.ConditionTrue)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should split the disruptions allowed by the local budget across the virtual nodes", func() {
+					remote, errg := GetPDB(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					// The only disruption allowed is granted to the other virtual node, which comes first in name order.
+					Expect(remote.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt32(2))))
+				})
+			})
+
+			When("the remote object already exists", func() {
+				BeforeEach(func() {
+					remote := LocalPDB()
+					remote.SetNamespace(RemoteNamespace)
+					remote.SetLabels(forge.ReflectionLabels())
+					remote.Status = policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 2, ExpectedPods: 2, DisruptionsAllowed: 1}
+					CreatePDB(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should report the remote status on the local object", func() {
+					local, errg := GetPDB(LocalNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(local.Annotations).To(HaveKeyWithValue(consts.RemotePodDisruptionBudgetStatusAnnotationKey,
+						"2/2 healthy, 1 disruptions allowed"))
+				})
+			})
+
+			When("the remote object already exists, but is not managed by us", func() {
+				BeforeEach(func() {
+					remote := LocalPDB()
+					remote.SetNamespace(RemoteNamespace)
+					CreatePDB(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not mutate the remote object", func() {
+					remote, errg := GetPDB(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Spec.Selector.MatchLabels).ToNot(HaveKey(forge.LiqoOriginClusterNodeName))
+				})
+			})
+
+			When("the local object is marked with the skip annotation", func() {
+				BeforeEach(func() {
+					local, errg := GetPDB(LocalNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					local.SetAnnotations(map[string]string{consts.SkipReflectionAnnotationKey: "true"})
+					_, errg = client.PolicyV1().PodDisruptionBudgets(LocalNamespace).Update(ctx, local, metav1.UpdateOptions{})
+					Expect(errg).ToNot(HaveOccurred())
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not create the remote object", func() {
+					_, err = GetPDB(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+		})
+
+		When("the local object does not exist", func() {
+			When("the remote object does exist", func() {
+				BeforeEach(func() {
+					remote := LocalPDB()
+					remote.SetNamespace(RemoteNamespace)
+					remote.SetLabels(forge.ReflectionLabels())
+					CreatePDB(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object", func() {
+					_, err = GetPDB(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			})
+		})
+	})
+})
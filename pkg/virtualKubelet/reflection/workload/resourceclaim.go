@@ -0,0 +1,169 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	resourcev1clients "k8s.io/client-go/kubernetes/typed/resource/v1"
+	resourcev1listers "k8s.io/client-go/listers/resource/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/virtualkubelet"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ manager.NamespacedReflector = (*NamespacedResourceClaimReflector)(nil)
+
+const (
+	// ResourceClaimReflectorName -> The name associated with the ResourceClaim reflector.
+	ResourceClaimReflectorName = "ResourceClaim"
+)
+
+// NamespacedResourceClaimReflector manages the ResourceClaim reflection for a given pair of local and remote namespaces.
+type NamespacedResourceClaimReflector struct {
+	generic.NamespacedReflector
+
+	localClaims        resourcev1listers.ResourceClaimNamespaceLister
+	remoteClaims       resourcev1listers.ResourceClaimNamespaceLister
+	remoteClaimsClient resourcev1clients.ResourceClaimInterface
+}
+
+// NewResourceClaimReflector returns a new ResourceClaimReflector instance.
+func NewResourceClaimReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(ResourceClaimReflectorName, NewNamespacedResourceClaimReflector,
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedResourceClaimReflector returns a new NamespacedResourceClaimReflector instance.
+func NewNamespacedResourceClaimReflector(opts *options.NamespacedOpts) manager.NamespacedReflector {
+	local := opts.LocalFactory.Resource().V1().ResourceClaims()
+	remote := opts.RemoteFactory.Resource().V1().ResourceClaims()
+
+	_, err := local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+
+	return &NamespacedResourceClaimReflector{
+		NamespacedReflector: generic.NewNamespacedReflector(opts, ResourceClaimReflectorName),
+		localClaims:         local.Lister().ResourceClaims(opts.LocalNamespace),
+		remoteClaims:        remote.Lister().ResourceClaims(opts.RemoteNamespace),
+		remoteClaimsClient:  opts.RemoteClient.ResourceV1().ResourceClaims(opts.RemoteNamespace),
+	}
+}
+
+// Handle reconciles resourceclaim objects.
+func (nrr *NamespacedResourceClaimReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local ResourceClaim %q (remote: %q)", nrr.LocalRef(name), nrr.RemoteRef(name))
+	local, lerr := nrr.localClaims.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(lerr))
+	remote, rerr := nrr.remoteClaims.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if lerr == nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local ResourceClaim %q as remote already exists and is not managed by us", nrr.LocalRef(name))
+			nrr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+
+	// Abort the reflection if the local object has been generated from a template for a given pod, as the remote
+	// cluster generates its own one for the remote pod, starting from the reflected template.
+	if lerr == nil && forge.IsResourceClaimGenerated(local) {
+		klog.V(4).Infof("Skipping reflection of local ResourceClaim %q as generated for a pod", nrr.LocalRef(name))
+		// Pretend the local object does not exist, so that the remote one (if any) gets deleted.
+		lerr = kerrors.NewNotFound(resourcev1.Resource("resourceclaim"), name)
+	}
+	tracer.Step("Performed the sanity checks")
+
+	// The local resourceclaim does no longer exist. Ensure it is also absent from the remote cluster.
+	if kerrors.IsNotFound(lerr) {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote ResourceClaim %q, since local %q does no longer exist", nrr.RemoteRef(name), nrr.LocalRef(name))
+			return nrr.DeleteRemote(ctx, nrr.remoteClaimsClient, ResourceClaimReflectorName, name, remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local ResourceClaim %q and remote ResourceClaim %q both vanished", nrr.LocalRef(name), nrr.RemoteRef(name))
+		return nil
+	}
+
+	// Forge the target object to be enforced in the remote cluster.
+	if kerrors.IsNotFound(rerr) {
+		remote = nil
+	}
+	target := forge.RemoteResourceClaim(local, remote, nrr.RemoteNamespace(), nrr.ForgingOpts)
+	tracer.Step("Remote object forged")
+
+	if remote == nil {
+		defer tracer.Step("Ensured the presence of the remote object")
+		if _, err := nrr.remoteClaimsClient.Create(ctx, target, metav1.CreateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			klog.Errorf("Failed to create remote ResourceClaim %q (local: %q): %v", nrr.RemoteRef(name), nrr.LocalRef(name), err)
+			nrr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+			return err
+		}
+
+		klog.Infof("Remote ResourceClaim %q successfully created (local: %q)", nrr.RemoteRef(name), nrr.LocalRef(name))
+		nrr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+		return nil
+	}
+
+	// The specifications are immutable, hence only the metadata is possibly updated.
+	if equality.Semantic.DeepEqual(remote.GetLabels(), target.GetLabels()) &&
+		equality.Semantic.DeepEqual(remote.GetAnnotations(), target.GetAnnotations()) {
+		klog.V(4).Infof("Skipping remote ResourceClaim %q update, as already synced", nrr.RemoteRef(name))
+		return nil
+	}
+
+	defer tracer.Step("Enforced the correctness of the remote object")
+	if _, err := nrr.remoteClaimsClient.Update(ctx, target, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+		klog.Errorf("Failed to update remote ResourceClaim %q (local: %q): %v", nrr.RemoteRef(name), nrr.LocalRef(name), err)
+		if !kerrors.IsConflict(err) {
+			nrr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+		}
+		return err
+	}
+
+	klog.Infof("Remote ResourceClaim %q successfully updated (local: %q)", nrr.RemoteRef(name), nrr.LocalRef(name))
+	nrr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+	return nil
+}
+
+// List returns the list of resourceclaim objects to be reflected.
+func (nrr *NamespacedResourceClaimReflector) List() ([]interface{}, error) {
+	return virtualkubelet.List[virtualkubelet.Lister[*resourcev1.ResourceClaim], *resourcev1.ResourceClaim](
+		nrr.localClaims,
+		nrr.remoteClaims,
+	)
+}
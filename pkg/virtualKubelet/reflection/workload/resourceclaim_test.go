@@ -0,0 +1,208 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	resourcev1 "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/trace"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/cmd/virtual-kubelet/root"
+	. "github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/workload"
+)
+
+var _ = Describe("ResourceClaim Reflection Tests", func() {
+	Describe("the NewResourceClaimReflector function", func() {
+		It("should not return a nil reflector", func() {
+			reflectorConfig := offloadingv1beta1.ReflectorConfig{
+				NumWorkers: 1,
+				Type:       root.DefaultReflectorsTypes[resources.ResourceClaim],
+			}
+			Expect(workload.NewResourceClaimReflector(&reflectorConfig)).ToNot(BeNil())
+		})
+	})
+
+	Describe("resourceclaim handling", func() {
+		const ClaimName = "name"
+
+		var (
+			client    *fake.Clientset
+			reflector manager.NamespacedReflector
+			err       error
+		)
+
+		GetClaim := func(namespace string) (*resourcev1.ResourceClaim, error) {
+			return client.ResourceV1().ResourceClaims(namespace).Get(ctx, ClaimName, metav1.GetOptions{})
+		}
+
+		CreateClaim := func(claim *resourcev1.ResourceClaim) {
+			_, errc := client.ResourceV1().ResourceClaims(claim.GetNamespace()).Create(ctx, claim, metav1.CreateOptions{})
+			Expect(errc).ToNot(HaveOccurred())
+		}
+
+		ClaimSpec := func(deviceClassName string) resourcev1.ResourceClaimSpec {
+			return resourcev1.ResourceClaimSpec{Devices: resourcev1.DeviceClaim{Requests: []resourcev1.DeviceRequest{{
+				Name: "gpu", Exactly: &resourcev1.ExactDeviceRequest{DeviceClassName: deviceClassName},
+			}}}}
+		}
+
+		LocalClaim := func() *resourcev1.ResourceClaim {
+			return &resourcev1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: ClaimName, Namespace: LocalNamespace, Labels: map[string]string{"foo": "bar"}},
+				Spec:       ClaimSpec("gpu.example.com"),
+			}
+		}
+
+		RemoteClaim := func() *resourcev1.ResourceClaim {
+			remote := LocalClaim()
+			remote.SetNamespace(RemoteNamespace)
+			remote.SetLabels(forge.ReflectionLabels())
+			return remote
+		}
+
+		BeforeEach(func() { client = fake.NewClientset() })
+
+		JustBeforeEach(func() {
+			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
+
+			reflector = workload.NewNamespacedResourceClaimReflector(options.NewNamespaced().
+				WithLocal(LocalNamespace, client, factory).WithRemote(RemoteNamespace, client, factory).
+				WithReflectionType(root.DefaultReflectorsTypes[resources.ResourceClaim]).
+				WithHandlerFactory(FakeEventHandler).WithEventBroadcaster(record.NewBroadcaster()).WithForgingOpts(FakeForgingOpts()))
+
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			err = reflector.Handle(trace.ContextWithTrace(ctx, trace.New("ResourceClaim")), ClaimName)
+		})
+
+		When("the local object does exist", func() {
+			BeforeEach(func() { CreateClaim(LocalClaim()) })
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should create the remote object", func() {
+					remote, errg := GetClaim(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(forge.IsReflected(remote)).To(BeTrue())
+					Expect(remote.Spec).To(Equal(ClaimSpec("gpu.example.com")))
+				})
+			})
+
+			When("the remote object already exists and has been allocated", func() {
+				BeforeEach(func() {
+					remote := RemoteClaim()
+					// The specifications are immutable, hence a mismatching one shall be preserved.
+					remote.Spec = ClaimSpec("remote.example.com")
+					remote.Status.Allocation = &resourcev1.AllocationResult{Devices: resourcev1.DeviceAllocationResult{
+						Results: []resourcev1.DeviceRequestAllocationResult{{Request: "gpu", Driver: "gpu.example.com", Pool: "node", Device: "gpu-0"}},
+					}}
+					CreateClaim(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should update the metadata of the remote object", func() {
+					remote, errg := GetClaim(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(forge.IsReflected(remote)).To(BeTrue())
+				})
+				It("should preserve the spec and the status of the remote object", func() {
+					remote, errg := GetClaim(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Spec).To(Equal(ClaimSpec("remote.example.com")))
+					Expect(remote.Status.Allocation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Devices": MatchFields(IgnoreExtras, Fields{"Results": ConsistOf(MatchFields(IgnoreExtras, Fields{"Device": Equal("gpu-0")}))}),
+					})))
+				})
+			})
+
+			When("the remote object already exists, but is not managed by us", func() {
+				BeforeEach(func() {
+					remote := LocalClaim()
+					remote.SetNamespace(RemoteNamespace)
+					remote.SetLabels(nil)
+					CreateClaim(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not mutate the remote object", func() {
+					remote, errg := GetClaim(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).ToNot(HaveKey("foo"))
+					Expect(forge.IsReflected(remote)).To(BeFalse())
+				})
+			})
+		})
+
+		When("the local object has been generated from a template for a pod", func() {
+			BeforeEach(func() {
+				local := LocalClaim()
+				local.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "Pod", Name: "pod", UID: "uid", Controller: ptr.To(true)}})
+				CreateClaim(local)
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not create the remote object", func() {
+					_, err = GetClaim(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+
+			When("the remote object does exist", func() {
+				BeforeEach(func() { CreateClaim(RemoteClaim()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object", func() {
+					_, err = GetClaim(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+		})
+
+		When("the local object does not exist", func() {
+			When("the remote object does exist", func() {
+				BeforeEach(func() { CreateClaim(RemoteClaim()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object", func() {
+					_, err = GetClaim(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			})
+		})
+	})
+})
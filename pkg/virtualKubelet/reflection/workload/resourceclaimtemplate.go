@@ -0,0 +1,161 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	resourcev1clients "k8s.io/client-go/kubernetes/typed/resource/v1"
+	resourcev1listers "k8s.io/client-go/listers/resource/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/virtualkubelet"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ manager.NamespacedReflector = (*NamespacedResourceClaimTemplateReflector)(nil)
+
+const (
+	// ResourceClaimTemplateReflectorName -> The name associated with the ResourceClaimTemplate reflector.
+	ResourceClaimTemplateReflectorName = "ResourceClaimTemplate"
+)
+
+// NamespacedResourceClaimTemplateReflector manages the ResourceClaimTemplate reflection for a given pair of local and remote namespaces.
+type NamespacedResourceClaimTemplateReflector struct {
+	generic.NamespacedReflector
+
+	localTemplates        resourcev1listers.ResourceClaimTemplateNamespaceLister
+	remoteTemplates       resourcev1listers.ResourceClaimTemplateNamespaceLister
+	remoteTemplatesClient resourcev1clients.ResourceClaimTemplateInterface
+}
+
+// NewResourceClaimTemplateReflector returns a new ResourceClaimTemplateReflector instance.
+func NewResourceClaimTemplateReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(ResourceClaimTemplateReflectorName, NewNamespacedResourceClaimTemplateReflector,
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedResourceClaimTemplateReflector returns a new NamespacedResourceClaimTemplateReflector instance.
+func NewNamespacedResourceClaimTemplateReflector(opts *options.NamespacedOpts) manager.NamespacedReflector {
+	local := opts.LocalFactory.Resource().V1().ResourceClaimTemplates()
+	remote := opts.RemoteFactory.Resource().V1().ResourceClaimTemplates()
+
+	_, err := local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+
+	return &NamespacedResourceClaimTemplateReflector{
+		NamespacedReflector:   generic.NewNamespacedReflector(opts, ResourceClaimTemplateReflectorName),
+		localTemplates:        local.Lister().ResourceClaimTemplates(opts.LocalNamespace),
+		remoteTemplates:       remote.Lister().ResourceClaimTemplates(opts.RemoteNamespace),
+		remoteTemplatesClient: opts.RemoteClient.ResourceV1().ResourceClaimTemplates(opts.RemoteNamespace),
+	}
+}
+
+// Handle reconciles resourceclaimtemplate objects.
+func (ntr *NamespacedResourceClaimTemplateReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local ResourceClaimTemplate %q (remote: %q)", ntr.LocalRef(name), ntr.RemoteRef(name))
+	local, lerr := ntr.localTemplates.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(lerr))
+	remote, rerr := ntr.remoteTemplates.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if lerr == nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local ResourceClaimTemplate %q as remote already exists and is not managed by us", ntr.LocalRef(name))
+			ntr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+	tracer.Step("Performed the sanity checks")
+
+	// The local resourceclaimtemplate does no longer exist. Ensure it is also absent from the remote cluster.
+	if kerrors.IsNotFound(lerr) {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote ResourceClaimTemplate %q, since local %q does no longer exist", ntr.RemoteRef(name), ntr.LocalRef(name))
+			return ntr.DeleteRemote(ctx, ntr.remoteTemplatesClient, ResourceClaimTemplateReflectorName, name, remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local ResourceClaimTemplate %q and remote ResourceClaimTemplate %q both vanished", ntr.LocalRef(name), ntr.RemoteRef(name))
+		return nil
+	}
+
+	// Forge the target object to be enforced in the remote cluster.
+	if kerrors.IsNotFound(rerr) {
+		remote = nil
+	}
+	target := forge.RemoteResourceClaimTemplate(local, remote, ntr.RemoteNamespace(), ntr.ForgingOpts)
+	tracer.Step("Remote object forged")
+
+	if remote == nil {
+		defer tracer.Step("Ensured the presence of the remote object")
+		if _, err := ntr.remoteTemplatesClient.Create(ctx, target, metav1.CreateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			klog.Errorf("Failed to create remote ResourceClaimTemplate %q (local: %q): %v", ntr.RemoteRef(name), ntr.LocalRef(name), err)
+			ntr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+			return err
+		}
+
+		klog.Infof("Remote ResourceClaimTemplate %q successfully created (local: %q)", ntr.RemoteRef(name), ntr.LocalRef(name))
+		ntr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+		return nil
+	}
+
+	// The specifications are immutable, hence only the metadata is possibly updated.
+	if equality.Semantic.DeepEqual(remote.GetLabels(), target.GetLabels()) &&
+		equality.Semantic.DeepEqual(remote.GetAnnotations(), target.GetAnnotations()) {
+		klog.V(4).Infof("Skipping remote ResourceClaimTemplate %q update, as already synced", ntr.RemoteRef(name))
+		return nil
+	}
+
+	defer tracer.Step("Enforced the correctness of the remote object")
+	if _, err := ntr.remoteTemplatesClient.Update(ctx, target, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+		klog.Errorf("Failed to update remote ResourceClaimTemplate %q (local: %q): %v", ntr.RemoteRef(name), ntr.LocalRef(name), err)
+		if !kerrors.IsConflict(err) {
+			ntr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+		}
+		return err
+	}
+
+	klog.Infof("Remote ResourceClaimTemplate %q successfully updated (local: %q)", ntr.RemoteRef(name), ntr.LocalRef(name))
+	ntr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+	return nil
+}
+
+// List returns the list of resourceclaimtemplate objects to be reflected.
+func (ntr *NamespacedResourceClaimTemplateReflector) List() ([]interface{}, error) {
+	return virtualkubelet.List[virtualkubelet.Lister[*resourcev1.ResourceClaimTemplate], *resourcev1.ResourceClaimTemplate](
+		ntr.localTemplates,
+		ntr.remoteTemplates,
+	)
+}
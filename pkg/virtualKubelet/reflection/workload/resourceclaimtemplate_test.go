@@ -0,0 +1,174 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	resourcev1 "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/trace"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/cmd/virtual-kubelet/root"
+	. "github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/workload"
+)
+
+var _ = Describe("ResourceClaimTemplate Reflection Tests", func() {
+	Describe("the NewResourceClaimTemplateReflector function", func() {
+		It("should not return a nil reflector", func() {
+			reflectorConfig := offloadingv1beta1.ReflectorConfig{
+				NumWorkers: 1,
+				Type:       root.DefaultReflectorsTypes[resources.ResourceClaimTemplate],
+			}
+			Expect(workload.NewResourceClaimTemplateReflector(&reflectorConfig)).ToNot(BeNil())
+		})
+	})
+
+	Describe("resourceclaimtemplate handling", func() {
+		const TemplateName = "name"
+
+		var (
+			client    *fake.Clientset
+			reflector manager.NamespacedReflector
+			err       error
+		)
+
+		GetTemplate := func(namespace string) (*resourcev1.ResourceClaimTemplate, error) {
+			return client.ResourceV1().ResourceClaimTemplates(namespace).Get(ctx, TemplateName, metav1.GetOptions{})
+		}
+
+		CreateTemplate := func(template *resourcev1.ResourceClaimTemplate) {
+			_, errc := client.ResourceV1().ResourceClaimTemplates(template.GetNamespace()).Create(ctx, template, metav1.CreateOptions{})
+			Expect(errc).ToNot(HaveOccurred())
+		}
+
+		TemplateSpec := func(deviceClassName string) resourcev1.ResourceClaimTemplateSpec {
+			return resourcev1.ResourceClaimTemplateSpec{Spec: resourcev1.ResourceClaimSpec{Devices: resourcev1.DeviceClaim{
+				Requests: []resourcev1.DeviceRequest{{Name: "gpu", Exactly: &resourcev1.ExactDeviceRequest{DeviceClassName: deviceClassName}}},
+			}}}
+		}
+
+		LocalTemplate := func() *resourcev1.ResourceClaimTemplate {
+			return &resourcev1.ResourceClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: TemplateName, Namespace: LocalNamespace, Labels: map[string]string{"foo": "bar"}},
+				Spec:       TemplateSpec("gpu.example.com"),
+			}
+		}
+
+		RemoteTemplate := func() *resourcev1.ResourceClaimTemplate {
+			remote := LocalTemplate()
+			remote.SetNamespace(RemoteNamespace)
+			remote.SetLabels(forge.ReflectionLabels())
+			return remote
+		}
+
+		BeforeEach(func() { client = fake.NewClientset() })
+
+		JustBeforeEach(func() {
+			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
+
+			reflector = workload.NewNamespacedResourceClaimTemplateReflector(options.NewNamespaced().
+				WithLocal(LocalNamespace, client, factory).WithRemote(RemoteNamespace, client, factory).
+				WithReflectionType(root.DefaultReflectorsTypes[resources.ResourceClaimTemplate]).
+				WithHandlerFactory(FakeEventHandler).WithEventBroadcaster(record.NewBroadcaster()).WithForgingOpts(FakeForgingOpts()))
+
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			err = reflector.Handle(trace.ContextWithTrace(ctx, trace.New("ResourceClaimTemplate")), TemplateName)
+		})
+
+		When("the local object does exist", func() {
+			BeforeEach(func() { CreateTemplate(LocalTemplate()) })
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should create the remote object", func() {
+					remote, errg := GetTemplate(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(forge.IsReflected(remote)).To(BeTrue())
+					Expect(remote.Spec).To(Equal(TemplateSpec("gpu.example.com")))
+				})
+			})
+
+			When("the remote object already exists", func() {
+				BeforeEach(func() {
+					remote := RemoteTemplate()
+					// The specifications are immutable, hence a mismatching one shall be preserved.
+					remote.Spec = TemplateSpec("remote.example.com")
+					CreateTemplate(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should update the metadata of the remote object", func() {
+					remote, errg := GetTemplate(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(forge.IsReflected(remote)).To(BeTrue())
+				})
+				It("should preserve the spec of the remote object", func() {
+					remote, errg := GetTemplate(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Spec).To(Equal(TemplateSpec("remote.example.com")))
+				})
+			})
+
+			When("the remote object already exists, but is not managed by us", func() {
+				BeforeEach(func() {
+					remote := LocalTemplate()
+					remote.SetNamespace(RemoteNamespace)
+					remote.SetLabels(nil)
+					CreateTemplate(remote)
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should not mutate the remote object", func() {
+					remote, errg := GetTemplate(RemoteNamespace)
+					Expect(errg).ToNot(HaveOccurred())
+					Expect(remote.Labels).ToNot(HaveKey("foo"))
+					Expect(forge.IsReflected(remote)).To(BeFalse())
+				})
+			})
+		})
+
+		When("the local object does not exist", func() {
+			When("the remote object does exist", func() {
+				BeforeEach(func() { CreateTemplate(RemoteTemplate()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("should delete the remote object", func() {
+					_, err = GetTemplate(RemoteNamespace)
+					Expect(err).To(BeNotFound())
+				})
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			})
+		})
+	})
+})
@@ -0,0 +1,132 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identityrevocation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorizationv1beta1 "k8s.io/api/authorization/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants,verbs=get;list;watch
+
+const deniedReason = "the credentials of the identity have been revoked"
+
+// Authorizer is an authorization webhook denying the SubjectAccessReviews of the users and the groups
+// corresponding to the identities revoked through the Tenant resources. It has no opinion about any other request.
+type Authorizer struct {
+	client client.Client
+}
+
+// NewAuthorizer returns a new identity revocation Authorizer.
+func NewAuthorizer(cl client.Client) *Authorizer {
+	return &Authorizer{client: cl}
+}
+
+// ServeHTTP implements the http.Handler interface, handling both the v1 and v1beta1 SubjectAccessReviews.
+func (a *Authorizer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var typeMeta metav1.TypeMeta
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &typeMeta); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var response interface{}
+	switch typeMeta.APIVersion {
+	case authorizationv1beta1.SchemeGroupVersion.String():
+		var review authorizationv1beta1.SubjectAccessReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode the SubjectAccessReview: %v", err), http.StatusBadRequest)
+			return
+		}
+		denied, err := a.IsDenied(r.Context(), review.Spec.User, review.Spec.Groups)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		review.Status = authorizationv1beta1.SubjectAccessReviewStatus{Denied: denied}
+		if denied {
+			review.Status.Reason = deniedReason
+		}
+		response = &review
+	default:
+		var review authorizationv1.SubjectAccessReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode the SubjectAccessReview: %v", err), http.StatusBadRequest)
+			return
+		}
+		denied, err := a.IsDenied(r.Context(), review.Spec.User, review.Spec.Groups)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		review.APIVersion = authorizationv1.SchemeGroupVersion.String()
+		review.Kind = "SubjectAccessReview"
+		review.Status = authorizationv1.SubjectAccessReviewStatus{Denied: denied}
+		if denied {
+			review.Status.Reason = deniedReason
+		}
+		response = &review
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		klog.Errorf("Failed to encode the SubjectAccessReview response: %v", err)
+	}
+}
+
+// IsDenied checks whether the given user, or one of the given groups, corresponds to a revoked identity.
+func (a *Authorizer) IsDenied(ctx context.Context, user string, groups []string) (bool, error) {
+	var tenants authv1beta1.TenantList
+	if err := a.client.List(ctx, &tenants); err != nil {
+		klog.Errorf("Failed to list the Tenants: %v", err)
+		return false, fmt.Errorf("failed to list the Tenants: %w", err)
+	}
+
+	for i := range tenants.Items {
+		revokedUsers, revokedGroups := authentication.RevokedUsersAndGroups(&tenants.Items[i])
+		for _, revoked := range revokedUsers {
+			if revoked == user {
+				klog.V(4).Infof("Denying the request of the revoked user %q", user)
+				return true, nil
+			}
+		}
+		for _, revoked := range revokedGroups {
+			for _, group := range groups {
+				if revoked == group {
+					klog.V(4).Infof("Denying the request of user %q, belonging to the revoked group %q", user, group)
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identityrevocation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorizationv1beta1 "k8s.io/api/authorization/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/webhooks/identityrevocation"
+)
+
+var _ = Describe("Identity revocation authorizer", func() {
+	const clusterID liqov1beta1.ClusterID = "consumer"
+
+	var (
+		ctx        context.Context
+		tenant     *authv1beta1.Tenant
+		authorizer *identityrevocation.Authorizer
+	)
+
+	newTenant := func(revoked ...authv1beta1.RevokedIdentity) *authv1beta1.Tenant {
+		return &authv1beta1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "liqo-tenant-consumer"},
+			Spec:       authv1beta1.TenantSpec{ClusterID: clusterID, RevokedIdentities: revoked},
+		}
+	}
+
+	JustBeforeEach(func() {
+		ctx = context.Background()
+		authorizer = identityrevocation.NewAuthorizer(fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build())
+	})
+
+	When("no identity is revoked", func() {
+		BeforeEach(func() { tenant = newTenant() })
+
+		It("should have no opinion", func() {
+			Expect(authorizer.IsDenied(ctx, string(clusterID), []string{"liqo.io"})).To(BeFalse())
+		})
+	})
+
+	When("the control plane identity is revoked", func() {
+		BeforeEach(func() {
+			tenant = newTenant(authv1beta1.RevokedIdentity{Type: authv1beta1.ControlPlaneIdentityType})
+		})
+
+		It("should deny the control plane user", func() {
+			Expect(authorizer.IsDenied(ctx, authentication.CommonNameControlPlaneCSR(clusterID), []string{"liqo.io"})).To(BeTrue())
+		})
+
+		It("should not deny the ResourceSlice users", func() {
+			Expect(authorizer.IsDenied(ctx, authentication.CommonNameResourceSlice("slice", clusterID),
+				[]string{string(clusterID)})).To(BeFalse())
+		})
+
+		It("should not deny the control plane users of other clusters", func() {
+			Expect(authorizer.IsDenied(ctx, "other", []string{"liqo.io"})).To(BeFalse())
+		})
+	})
+
+	When("a single ResourceSlice identity is revoked", func() {
+		BeforeEach(func() {
+			tenant = newTenant(authv1beta1.RevokedIdentity{Type: authv1beta1.ResourceSliceIdentityType, ResourceSliceName: "slice"})
+		})
+
+		It("should deny the user of that ResourceSlice only", func() {
+			Expect(authorizer.IsDenied(ctx, authentication.CommonNameResourceSlice("slice", clusterID),
+				[]string{string(clusterID)})).To(BeTrue())
+			Expect(authorizer.IsDenied(ctx, authentication.CommonNameResourceSlice("other", clusterID),
+				[]string{string(clusterID)})).To(BeFalse())
+		})
+	})
+
+	When("all the ResourceSlice identities are revoked", func() {
+		BeforeEach(func() {
+			tenant = newTenant(authv1beta1.RevokedIdentity{Type: authv1beta1.ResourceSliceIdentityType})
+		})
+
+		It("should deny the whole group of the tenant cluster", func() {
+			Expect(authorizer.IsDenied(ctx, authentication.CommonNameResourceSlice("any", clusterID),
+				[]string{string(clusterID)})).To(BeTrue())
+		})
+
+		It("should serve the v1 and v1beta1 SubjectAccessReviews", func() {
+			user := authentication.CommonNameResourceSlice("any", clusterID)
+
+			body, err := json.Marshal(&authorizationv1.SubjectAccessReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: authorizationv1.SchemeGroupVersion.String(), Kind: "SubjectAccessReview"},
+				Spec:     authorizationv1.SubjectAccessReviewSpec{User: user, Groups: []string{string(clusterID)}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			rec := httptest.NewRecorder()
+			authorizer.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/authorize/identities", bytes.NewReader(body)))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var v1Review authorizationv1.SubjectAccessReview
+			Expect(json.Unmarshal(rec.Body.Bytes(), &v1Review)).To(Succeed())
+			Expect(v1Review.Status.Denied).To(BeTrue())
+			Expect(v1Review.Status.Allowed).To(BeFalse())
+
+			body, err = json.Marshal(&authorizationv1beta1.SubjectAccessReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: authorizationv1beta1.SchemeGroupVersion.String(), Kind: "SubjectAccessReview"},
+				Spec:     authorizationv1beta1.SubjectAccessReviewSpec{User: "other", Groups: []string{"system:authenticated"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			rec = httptest.NewRecorder()
+			authorizer.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/authorize/identities", bytes.NewReader(body)))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var v1beta1Review authorizationv1beta1.SubjectAccessReview
+			Expect(json.Unmarshal(rec.Body.Bytes(), &v1beta1Review)).To(Succeed())
+			Expect(v1beta1Review.APIVersion).To(Equal(authorizationv1beta1.SchemeGroupVersion.String()))
+			Expect(v1beta1Review.Status.Denied).To(BeFalse())
+		})
+	})
+})
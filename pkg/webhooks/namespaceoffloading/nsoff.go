@@ -16,14 +16,17 @@ package nsoffwh
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 )
@@ -77,6 +80,10 @@ func (w *nsoffwh) handleCreate(_ context.Context, _ *admission.Request, nsoff *o
 		return admission.Denied("The RemoteNamespaceName value cannot be empty when using the SelectedName NamespaceMappingStrategy")
 	}
 
+	if err := validateClusterMappings(nsoff); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	return admission.Allowed("")
 }
 
@@ -98,6 +105,14 @@ func (w *nsoffwh) handleUpdate(_ context.Context, req *admission.Request, nsoff
 		return admission.Denied("The RemoteNamespaceName value cannot be modified after creation")
 	}
 
+	if err := validateClusterMappings(nsoff); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	if err := validateClusterMappingsUpdate(old, nsoff); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	if nsoff.Spec.PodOffloadingStrategy != offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType &&
 		old.Spec.PodOffloadingStrategy != nsoff.Spec.PodOffloadingStrategy {
 		const msg = "The PodOffloadingStrategy was mutated to a more restrictive setting: existing pods violating this policy might still be running"
@@ -106,3 +121,43 @@ func (w *nsoffwh) handleUpdate(_ context.Context, req *admission.Request, nsoff
 
 	return admission.Allowed("").WithWarnings(warnings...)
 }
+
+// validateClusterMappings checks that the cluster-specific mappings are well-formed.
+func validateClusterMappings(nsoff *offloadingv1beta1.NamespaceOffloading) error {
+	for i := range nsoff.Spec.ClusterMappings {
+		mapping := &nsoff.Spec.ClusterMappings[i]
+		if mapping.NamespaceMappingStrategy == offloadingv1beta1.SelectedNameMappingStrategyType && mapping.RemoteNamespaceName == "" {
+			return fmt.Errorf("the RemoteNamespaceName value for cluster %q cannot be empty when using the SelectedName NamespaceMappingStrategy",
+				mapping.ClusterID)
+		}
+	}
+	return nil
+}
+
+// validateClusterMappingsUpdate checks that the cluster-specific mappings are only extended with the clusters the namespace
+// is not yet offloaded to, as changing the name of an existing remote namespace would require deleting it with all its content.
+func validateClusterMappingsUpdate(old, nsoff *offloadingv1beta1.NamespaceOffloading) error {
+	mappings := map[liqov1beta1.ClusterID]*offloadingv1beta1.ClusterNamespaceMapping{}
+	for i := range nsoff.Spec.ClusterMappings {
+		mappings[nsoff.Spec.ClusterMappings[i].ClusterID] = &nsoff.Spec.ClusterMappings[i]
+	}
+
+	for i := range old.Spec.ClusterMappings {
+		previous := &old.Spec.ClusterMappings[i]
+		current, found := mappings[previous.ClusterID]
+		if !found {
+			return fmt.Errorf("the ClusterMappings entry for cluster %q cannot be removed after creation", previous.ClusterID)
+		}
+		if !equality.Semantic.DeepEqual(previous, current) {
+			return fmt.Errorf("the ClusterMappings entry for cluster %q cannot be modified after creation", previous.ClusterID)
+		}
+		delete(mappings, previous.ClusterID)
+	}
+
+	for clusterID := range mappings {
+		if _, offloaded := old.Status.RemoteNamespacesNames[string(clusterID)]; offloaded {
+			return fmt.Errorf("a ClusterMappings entry for cluster %q cannot be added, as the namespace is already offloaded to it", clusterID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsoffwh_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	nsoffwh "github.com/liqotech/liqo/pkg/webhooks/namespaceoffloading"
+)
+
+var _ = Describe("NamespaceOffloading webhook", func() {
+	var old, updated *offloadingv1beta1.NamespaceOffloading
+
+	serialize := func(nsoff *offloadingv1beta1.NamespaceOffloading) runtime.RawExtension {
+		data, err := json.Marshal(nsoff)
+		Expect(err).ToNot(HaveOccurred())
+		return runtime.RawExtension{Raw: data}
+	}
+
+	update := func() admission.Response {
+		return nsoffwh.New().Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update, Object: serialize(updated), OldObject: serialize(old),
+		}})
+	}
+
+	BeforeEach(func() {
+		old = &offloadingv1beta1.NamespaceOffloading{
+			ObjectMeta: metav1.ObjectMeta{Name: consts.DefaultNamespaceOffloadingName, Namespace: "namespace"},
+			Spec: offloadingv1beta1.NamespaceOffloadingSpec{
+				NamespaceMappingStrategy: offloadingv1beta1.EnforceSameNameMappingStrategyType,
+				ClusterMappings: []offloadingv1beta1.ClusterNamespaceMapping{{
+					ClusterID: "cluster-1", NamespaceMappingStrategy: offloadingv1beta1.SelectedNameMappingStrategyType, RemoteNamespaceName: "foo",
+				}},
+			},
+			Status: offloadingv1beta1.NamespaceOffloadingStatus{
+				RemoteNamespacesNames: map[string]string{"cluster-1": "foo", "cluster-2": "namespace"},
+			},
+		}
+		updated = old.DeepCopy()
+	})
+
+	It("should allow the updates preserving the cluster mappings", func() {
+		Expect(update().Allowed).To(BeTrue())
+	})
+
+	It("should deny the modification of an existing cluster mapping", func() {
+		updated.Spec.ClusterMappings[0].RemoteNamespaceName = "bar"
+		Expect(update().Allowed).To(BeFalse())
+	})
+
+	It("should deny the removal of an existing cluster mapping", func() {
+		updated.Spec.ClusterMappings = nil
+		Expect(update().Allowed).To(BeFalse())
+	})
+
+	It("should deny the addition of a mapping for a cluster the namespace is already offloaded to", func() {
+		updated.Spec.ClusterMappings = append(updated.Spec.ClusterMappings, offloadingv1beta1.ClusterNamespaceMapping{
+			ClusterID: "cluster-2", NamespaceMappingStrategy: offloadingv1beta1.DefaultNameMappingStrategyType,
+		})
+		Expect(update().Allowed).To(BeFalse())
+	})
+
+	It("should allow the addition of a mapping for a cluster the namespace is not yet offloaded to", func() {
+		updated.Spec.ClusterMappings = append(updated.Spec.ClusterMappings, offloadingv1beta1.ClusterNamespaceMapping{
+			ClusterID: "cluster-3", NamespaceMappingStrategy: offloadingv1beta1.DefaultNameMappingStrategyType,
+		})
+		Expect(update().Allowed).To(BeTrue())
+	})
+})
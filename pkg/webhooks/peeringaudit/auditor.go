@@ -0,0 +1,174 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=core.liqo.io,resources=peeringauditevents,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// operations maps the verbs of the audited requests to the corresponding operations.
+var operations = map[string]liqov1beta1.PeeringAuditOperation{
+	"create":           liqov1beta1.PeeringAuditOperationCreate,
+	"update":           liqov1beta1.PeeringAuditOperationUpdate,
+	"patch":            liqov1beta1.PeeringAuditOperationUpdate,
+	"delete":           liqov1beta1.PeeringAuditOperationDelete,
+	"deletecollection": liqov1beta1.PeeringAuditOperationDelete,
+}
+
+// connectSubresources are the subresources whose requests open a connection to the pods, regardless of the verb.
+var connectSubresources = []string{"exec", "attach", "portforward"}
+
+// Auditor is an audit webhook backend of the API server, recording the operations performed by the consumer clusters
+// in their tenant namespaces and in the namespaces they offload to the local cluster. The API server sends the audit
+// events once the requests are completed, hence only the operations actually admitted and executed are recorded.
+// The records are persisted before acknowledging the events, so that the API server retries sending them on failure.
+// Hence, the sinks are expected to ignore the records already persisted when the same batch is sent again.
+type Auditor struct {
+	client client.Client
+	sinks  []Sink
+}
+
+// NewAuditor returns a new Auditor persisting the records to the given sinks.
+func NewAuditor(cl client.Client, sinks ...Sink) *Auditor {
+	return &Auditor{client: cl, sinks: sinks}
+}
+
+// ServeHTTP implements the http.Handler interface, handling the audit.k8s.io/v1 EventLists sent by the API server.
+func (a *Auditor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var events auditv1.EventList
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the audit events: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	records, err := a.Records(r.Context(), events.Items)
+	if err != nil {
+		klog.Errorf("Failed to audit the peering operations: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, sink := range a.sinks {
+		if err := sink.Write(r.Context(), records); err != nil {
+			klog.Errorf("Failed to write %d peering audit records: %v", len(records), err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Records returns the operations performed by the consumer identities out of the given audit events,
+// aggregating the repeated occurrences of the same operation.
+func (a *Auditor) Records(ctx context.Context, events []auditv1.Event) ([]*Record, error) {
+	var records []*Record
+	aggregated := map[string]*Record{}
+	origins := map[string]string{}
+
+	for i := range events {
+		event := &events[i]
+		operation, found := operation(event)
+		if !found {
+			continue
+		}
+
+		origin, found := origins[event.ObjectRef.Namespace]
+		if !found {
+			var err error
+			if origin, err = a.origin(ctx, event.ObjectRef.Namespace); err != nil {
+				return nil, err
+			}
+			origins[event.ObjectRef.Namespace] = origin
+		}
+
+		// The control plane identity of a consumer is named after its cluster ID, while the other identities belong to the group named after it.
+		if origin == "" || (event.User.Username != origin && !slices.Contains(event.User.Groups, origin)) {
+			continue
+		}
+
+		record := &Record{
+			ClusterID: liqov1beta1.ClusterID(origin),
+			User:      event.User.Username,
+			Operation: operation,
+			Resource: liqov1beta1.PeeringAuditResource{
+				Group:       event.ObjectRef.APIGroup,
+				Resource:    event.ObjectRef.Resource,
+				Subresource: event.ObjectRef.Subresource,
+				Namespace:   event.ObjectRef.Namespace,
+				Name:        event.ObjectRef.Name,
+			},
+			FirstTimestamp: event.RequestReceivedTimestamp.Time,
+			LastTimestamp:  event.RequestReceivedTimestamp.Time,
+			Count:          1,
+			AuditIDs:       []types.UID{event.AuditID},
+		}
+
+		if existing, found := aggregated[record.Key()]; found {
+			existing.merge(record)
+			continue
+		}
+		aggregated[record.Key()] = record
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// operation returns the operation performed by the request the event refers to, and whether it is to be recorded.
+// Only the namespaced requests which completed successfully (including the upgraded connections) are recorded.
+func operation(event *auditv1.Event) (liqov1beta1.PeeringAuditOperation, bool) {
+	if event.Stage != auditv1.StageResponseComplete || event.ObjectRef == nil || event.ObjectRef.Namespace == "" ||
+		event.ResponseStatus == nil || event.ResponseStatus.Code < http.StatusSwitchingProtocols ||
+		event.ResponseStatus.Code >= http.StatusMultipleChoices {
+		return "", false
+	}
+
+	if slices.Contains(connectSubresources, event.ObjectRef.Subresource) {
+		return liqov1beta1.PeeringAuditOperationConnect, true
+	}
+	operation, found := operations[event.Verb]
+	return operation, found
+}
+
+// origin returns the ID of the consumer cluster the given namespace is associated with, if any.
+// Both tenant and offloaded namespaces are labeled with the ID of the consumer cluster.
+func (a *Auditor) origin(ctx context.Context, name string) (string, error) {
+	var namespace corev1.Namespace
+	if err := a.client.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to retrieve namespace %q: %w", name, err)
+	}
+	return namespace.Labels[consts.RemoteClusterID], nil
+}
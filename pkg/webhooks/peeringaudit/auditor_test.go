@@ -0,0 +1,207 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringaudit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/webhooks/peeringaudit"
+)
+
+var _ = Describe("Peering audit tests", func() {
+	const (
+		origin          = "consumer"
+		tenantNamespace = "liqo-tenant-consumer"
+		offloaded       = "offloaded"
+	)
+
+	var (
+		ctx      context.Context
+		cl       client.Client
+		failures int
+	)
+
+	forgeEvent := func(verb, namespace, name string, code int32, user authenticationv1.UserInfo) auditv1.Event {
+		return auditv1.Event{
+			AuditID:                  uuid.NewUUID(),
+			Stage:                    auditv1.StageResponseComplete,
+			Verb:                     verb,
+			User:                     user,
+			ObjectRef:                &auditv1.ObjectReference{APIVersion: "v1", Resource: "services", Namespace: namespace, Name: name},
+			ResponseStatus:           &metav1.Status{Code: code},
+			RequestReceivedTimestamp: metav1.NewMicroTime(time.Now()),
+		}
+	}
+
+	resourceSliceUser := authenticationv1.UserInfo{Username: "rs-consumer", Groups: []string{origin, "system:authenticated"}}
+	controlPlaneUser := authenticationv1.UserInfo{Username: origin, Groups: []string{"liqo.io"}}
+
+	send := func(events ...auditv1.Event) int {
+		body, err := json.Marshal(&auditv1.EventList{Items: events})
+		Expect(err).ToNot(HaveOccurred())
+		recorder := httptest.NewRecorder()
+		peeringaudit.NewAuditor(cl, peeringaudit.NewCRDSink(cl)).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodPost, "/audit/peering", bytes.NewReader(body)))
+		return recorder.Code
+	}
+
+	listEvents := func() []liqov1beta1.PeeringAuditEvent {
+		var events liqov1beta1.PeeringAuditEventList
+		Expect(cl.List(ctx, &events, client.InNamespace(tenantNamespace))).To(Succeed())
+		return events.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		failures = 0
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			testutil.FakeNamespaceWithClusterID(origin, tenantNamespace),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: offloaded, Labels: map[string]string{consts.RemoteClusterID: origin}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if failures > 0 {
+					failures--
+					return http.ErrHandlerTimeout
+				}
+				return cl.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	})
+
+	It("should record the operations performed by the consumer identities", func() {
+		Expect(send(
+			forgeEvent("create", offloaded, "svc", http.StatusCreated, resourceSliceUser),
+			forgeEvent("delete", tenantNamespace, "svc", http.StatusOK, controlPlaneUser),
+		)).To(Equal(http.StatusOK))
+
+		events := listEvents()
+		Expect(events).To(HaveLen(2))
+		for i := range events {
+			Expect(events[i].Spec.ClusterID).To(BeEquivalentTo(origin))
+			Expect(events[i].Labels).To(HaveKeyWithValue(consts.RemoteClusterID, origin))
+			Expect(events[i].Spec.Count).To(BeEquivalentTo(1))
+		}
+		Expect([]liqov1beta1.PeeringAuditOperation{events[0].Spec.Operation, events[1].Spec.Operation}).To(ConsistOf(
+			liqov1beta1.PeeringAuditOperationCreate, liqov1beta1.PeeringAuditOperationDelete))
+	})
+
+	It("should aggregate the repeated operations, also across batches", func() {
+		event := func() auditv1.Event { return forgeEvent("patch", offloaded, "svc", http.StatusOK, resourceSliceUser) }
+		Expect(send(event(), event(), event())).To(Equal(http.StatusOK))
+		Expect(send(event())).To(Equal(http.StatusOK))
+
+		events := listEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Spec.Count).To(BeEquivalentTo(4))
+		Expect(events[0].Spec.Operation).To(Equal(liqov1beta1.PeeringAuditOperationUpdate))
+		Expect(events[0].Spec.User).To(Equal(resourceSliceUser.Username))
+		Expect(events[0].Spec.Resource).To(Equal(liqov1beta1.PeeringAuditResource{
+			Resource: "services", Namespace: offloaded, Name: "svc"}))
+		Expect(events[0].Spec.LastTimestamp.Before(&events[0].Spec.FirstTimestamp)).To(BeFalse())
+	})
+
+	It("should record the connections to the offloaded pods", func() {
+		event := forgeEvent("get", offloaded, "pod", http.StatusSwitchingProtocols, resourceSliceUser)
+		event.ObjectRef.Resource, event.ObjectRef.Subresource = "pods", "exec"
+		Expect(send(event)).To(Equal(http.StatusOK))
+
+		events := listEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Spec.Operation).To(Equal(liqov1beta1.PeeringAuditOperationConnect))
+	})
+
+	It("should ignore the requests which did not complete successfully", func() {
+		forbidden := forgeEvent("create", offloaded, "svc", http.StatusForbidden, resourceSliceUser)
+		started := forgeEvent("create", offloaded, "svc", http.StatusCreated, resourceSliceUser)
+		started.Stage = auditv1.StageRequestReceived
+		Expect(send(forbidden, started)).To(Equal(http.StatusOK))
+		Expect(listEvents()).To(BeEmpty())
+	})
+
+	It("should ignore the operations performed by other users", func() {
+		Expect(send(forgeEvent("create", offloaded, "svc", http.StatusCreated,
+			authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:masters"}}))).To(Equal(http.StatusOK))
+		Expect(listEvents()).To(BeEmpty())
+	})
+
+	It("should ignore the operations in namespaces not associated with a consumer", func() {
+		Expect(send(
+			forgeEvent("create", "other", "svc", http.StatusCreated, resourceSliceUser),
+			forgeEvent("create", "missing", "svc", http.StatusCreated, resourceSliceUser),
+		)).To(Equal(http.StatusOK))
+		Expect(listEvents()).To(BeEmpty())
+	})
+
+	It("should fail when the records cannot be persisted, to have the events sent again", func() {
+		failures = 1
+		event := forgeEvent("create", offloaded, "svc", http.StatusCreated, resourceSliceUser)
+		Expect(send(event)).To(Equal(http.StatusInternalServerError))
+		Expect(listEvents()).To(BeEmpty())
+
+		Expect(send(event)).To(Equal(http.StatusOK))
+		Expect(listEvents()).To(HaveLen(1))
+	})
+	It("should not count twice the records already persisted when a partially failed batch is sent again", func() {
+		update := func() auditv1.Event { return forgeEvent("update", offloaded, "svc", http.StatusOK, resourceSliceUser) }
+		Expect(send(update())).To(Equal(http.StatusOK))
+
+		// The record of the update is merged into the existing event, while the event of the deletion cannot be created.
+		failures = 1
+		batch := []auditv1.Event{update(), forgeEvent("delete", offloaded, "svc", http.StatusOK, resourceSliceUser)}
+		Expect(send(batch...)).To(Equal(http.StatusInternalServerError))
+		Expect(listEvents()).To(HaveLen(1))
+
+		Expect(send(batch...)).To(Equal(http.StatusOK))
+		events := listEvents()
+		Expect(events).To(HaveLen(2))
+		for i := range events {
+			switch events[i].Spec.Operation {
+			case liqov1beta1.PeeringAuditOperationUpdate:
+				Expect(events[i].Spec.Count).To(BeEquivalentTo(2))
+			default:
+				Expect(events[i].Spec.Count).To(BeEquivalentTo(1))
+			}
+		}
+
+		// A further batch of updates is counted as usual.
+		Expect(send(update())).To(Equal(http.StatusOK))
+		var event liqov1beta1.PeeringAuditEvent
+		Expect(cl.Get(ctx, client.ObjectKey{Namespace: tenantNamespace, Name: (&peeringaudit.Record{
+			ClusterID: origin, User: resourceSliceUser.Username, Operation: liqov1beta1.PeeringAuditOperationUpdate,
+			Resource: liqov1beta1.PeeringAuditResource{Resource: "services", Namespace: offloaded, Name: "svc"},
+		}).EventName()}, &event)).To(Succeed())
+		Expect(event.Spec.Count).To(BeEquivalentTo(3))
+	})
+})
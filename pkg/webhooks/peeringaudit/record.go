@@ -0,0 +1,82 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringaudit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// Record is an operation performed by a consumer cluster, possibly aggregating multiple occurrences.
+type Record struct {
+	ClusterID liqov1beta1.ClusterID
+	User      string
+	Operation liqov1beta1.PeeringAuditOperation
+	Resource  liqov1beta1.PeeringAuditResource
+
+	FirstTimestamp time.Time
+	LastTimestamp  time.Time
+	Count          int32
+
+	// AuditIDs are the IDs of the audit events aggregated by the record.
+	AuditIDs []types.UID
+}
+
+// Key returns the identifier of the record, which is the same for the repeated occurrences of an operation.
+func (r *Record) Key() string {
+	return strings.Join([]string{string(r.ClusterID), r.User, string(r.Operation),
+		r.Resource.Group, r.Resource.Resource, r.Resource.Subresource, r.Resource.Namespace, r.Resource.Name}, "/")
+}
+
+// EventName returns the name of the PeeringAuditEvent associated with the record.
+func (r *Record) EventName() string {
+	hash := sha256.Sum256([]byte(r.Key()))
+	return "audit-" + hex.EncodeToString(hash[:])[:16]
+}
+
+// Digest returns the identifier of the occurrences aggregated by the record, which is the same when the API server
+// sends the same batch of audit events again. It is empty if the audit events are not identified.
+func (r *Record) Digest() string {
+	if len(r.AuditIDs) == 0 {
+		return ""
+	}
+
+	ids := make([]string, len(r.AuditIDs))
+	for i := range r.AuditIDs {
+		ids[i] = string(r.AuditIDs[i])
+	}
+	slices.Sort(ids)
+	hash := sha256.Sum256([]byte(strings.Join(ids, "/")))
+	return hex.EncodeToString(hash[:])[:32]
+}
+
+// merge aggregates the occurrences of another record of the same operation.
+func (r *Record) merge(other *Record) {
+	if other.FirstTimestamp.Before(r.FirstTimestamp) {
+		r.FirstTimestamp = other.FirstTimestamp
+	}
+	if other.LastTimestamp.After(r.LastTimestamp) {
+		r.LastTimestamp = other.LastTimestamp
+	}
+	r.Count += other.Count
+	r.AuditIDs = append(r.AuditIDs, other.AuditIDs...)
+}
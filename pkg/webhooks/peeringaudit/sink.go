@@ -0,0 +1,199 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringaudit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// maxAppliedBatches is the number of batches of occurrences recorded by each PeeringAuditEvent, to ignore them if sent again.
+const maxAppliedBatches = 16
+
+const (
+	// CRDSinkName is the name of the sink storing the records as PeeringAuditEvents.
+	CRDSinkName = "crd"
+	// LogSinkName is the name of the sink emitting the records as structured logs, to be collected by external systems.
+	LogSinkName = "log"
+)
+
+// Sink is the destination of the audit records.
+type Sink interface {
+	// Write persists the given records.
+	Write(ctx context.Context, records []*Record) error
+}
+
+// NewSink returns the sink with the given name.
+func NewSink(name string, cl client.Client) (Sink, error) {
+	switch name {
+	case CRDSinkName:
+		return NewCRDSink(cl), nil
+	case LogSinkName:
+		return NewLogSink(), nil
+	default:
+		return nil, fmt.Errorf("unknown peering audit sink %q (supported: %s, %s)", name, CRDSinkName, LogSinkName)
+	}
+}
+
+type crdSink struct {
+	client client.Client
+}
+
+// NewCRDSink returns a sink storing the records as PeeringAuditEvents, in the tenant namespace of the consumer cluster.
+// Each PeeringAuditEvent records the most recently applied batches of occurrences, so that the ones already stored
+// are not counted twice when the API server sends a batch again (e.g., as the storage of other records failed).
+func NewCRDSink(cl client.Client) Sink {
+	return &crdSink{client: cl}
+}
+
+// Write implements the Sink interface.
+func (s *crdSink) Write(ctx context.Context, records []*Record) error {
+	var errs []error
+	for _, record := range records {
+		if err := s.write(ctx, record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to store %d audit records, first error: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+func (s *crdSink) write(ctx context.Context, record *Record) error {
+	namespace, err := s.tenantNamespace(ctx, record.ClusterID)
+	if err != nil {
+		return err
+	}
+	if namespace == "" {
+		klog.V(4).Infof("Skipping audit record of cluster %q, since its tenant namespace does not exist", record.ClusterID)
+		return nil
+	}
+
+	digest := record.Digest()
+	// The event may be concurrently created by another replica, in which case the record is merged into it.
+	return retry.OnError(retry.DefaultRetry, func(err error) bool { return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) },
+		func() error {
+			var event liqov1beta1.PeeringAuditEvent
+			err := s.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: record.EventName()}, &event)
+			switch {
+			case apierrors.IsNotFound(err):
+				return s.client.Create(ctx, forgeEvent(record, namespace, digest))
+			case err != nil:
+				return err
+			}
+
+			if digest != "" && slices.Contains(event.Spec.AppliedBatches, digest) {
+				klog.V(4).Infof("Skipping audit record %q, since already stored", client.ObjectKeyFromObject(&event))
+				return nil
+			}
+
+			stored := fromEvent(&event)
+			stored.merge(record)
+			event.Spec.FirstTimestamp = metav1.NewTime(stored.FirstTimestamp)
+			event.Spec.LastTimestamp = metav1.NewTime(stored.LastTimestamp)
+			event.Spec.Count = stored.Count
+			event.Spec.AppliedBatches = appendBatch(event.Spec.AppliedBatches, digest)
+			return s.client.Update(ctx, &event)
+		})
+}
+
+// tenantNamespace returns the name of the tenant namespace of the given cluster, or an empty string if it does not exist.
+func (s *crdSink) tenantNamespace(ctx context.Context, clusterID liqov1beta1.ClusterID) (string, error) {
+	var namespaces corev1.NamespaceList
+	if err := s.client.List(ctx, &namespaces, client.MatchingLabels{consts.RemoteClusterID: string(clusterID)},
+		client.HasLabels{consts.TenantNamespaceLabel}); err != nil {
+		return "", fmt.Errorf("failed to retrieve the tenant namespace of cluster %q: %w", clusterID, err)
+	}
+	if len(namespaces.Items) == 0 {
+		return "", nil
+	}
+	return namespaces.Items[0].Name, nil
+}
+
+// appendBatch appends the given digest to the applied batches, retaining only the most recent ones.
+func appendBatch(batches []string, digest string) []string {
+	if digest == "" {
+		return batches
+	}
+	batches = append(batches, digest)
+	if len(batches) > maxAppliedBatches {
+		batches = batches[len(batches)-maxAppliedBatches:]
+	}
+	return batches
+}
+
+func forgeEvent(record *Record, namespace, digest string) *liqov1beta1.PeeringAuditEvent {
+	return &liqov1beta1.PeeringAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      record.EventName(),
+			Namespace: namespace,
+			Labels: map[string]string{
+				consts.RemoteClusterID: string(record.ClusterID),
+			},
+		},
+		Spec: liqov1beta1.PeeringAuditEventSpec{
+			ClusterID:      record.ClusterID,
+			User:           record.User,
+			Operation:      record.Operation,
+			Resource:       record.Resource,
+			FirstTimestamp: metav1.NewTime(record.FirstTimestamp),
+			LastTimestamp:  metav1.NewTime(record.LastTimestamp),
+			Count:          record.Count,
+			AppliedBatches: appendBatch(nil, digest),
+		},
+	}
+}
+
+func fromEvent(event *liqov1beta1.PeeringAuditEvent) *Record {
+	return &Record{
+		ClusterID:      event.Spec.ClusterID,
+		User:           event.Spec.User,
+		Operation:      event.Spec.Operation,
+		Resource:       event.Spec.Resource,
+		FirstTimestamp: event.Spec.FirstTimestamp.Time,
+		LastTimestamp:  event.Spec.LastTimestamp.Time,
+		Count:          event.Spec.Count,
+	}
+}
+
+type logSink struct{}
+
+// NewLogSink returns a sink emitting the records as structured logs.
+func NewLogSink() Sink {
+	return &logSink{}
+}
+
+// Write implements the Sink interface.
+func (s *logSink) Write(_ context.Context, records []*Record) error {
+	for _, record := range records {
+		klog.InfoS("Peering audit event", "clusterID", record.ClusterID, "user", record.User, "operation", record.Operation,
+			"group", record.Resource.Group, "resource", record.Resource.Resource, "subresource", record.Resource.Subresource,
+			"namespace", record.Resource.Namespace, "name", record.Resource.Name, "count", record.Count,
+			"firstTimestamp", record.FirstTimestamp, "lastTimestamp", record.LastTimestamp)
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 )
 
 // getVirtualNodeToleration returns a new Toleration for the Liqo's virtual-nodes.
@@ -126,17 +127,43 @@ func fillPodWithTheNewNodeSelector(imposedNodeSelector *corev1.NodeSelector, pod
 	}
 }
 
+// fillPodWithTheWorkloadOffloadingGate adds the workload offloading scheduling gate to the pods belonging to a workload
+// offloaded as a whole, so that they are kept as placeholders while the actual pods are managed by the provider cluster.
+// The pods are gated only if the namespace is offloaded to the target cluster, whose virtual kubelet is in charge of
+// reflecting the workload and releasing the placeholders, as otherwise they would never be released.
+func fillPodWithTheWorkloadOffloadingGate(namespaceOffloading *offloadingv1beta1.NamespaceOffloading, pod *corev1.Pod) {
+	target, found := forge.WorkloadOffloadingTarget(pod.Annotations)
+	if !found {
+		return
+	}
+	if _, offloaded := namespaceOffloading.Status.RemoteNamespacesConditions[string(target)]; !offloaded {
+		klog.Warningf("Pod %q in namespace %q requests the workload offloading to cluster %q, but the namespace is not offloaded there",
+			pod.Name, namespaceOffloading.Namespace, target)
+		return
+	}
+
+	for i := range pod.Spec.SchedulingGates {
+		if pod.Spec.SchedulingGates[i].Name == liqoconst.WorkloadOffloadingSchedulingGate {
+			return
+		}
+	}
+	pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: liqoconst.WorkloadOffloadingSchedulingGate})
+}
+
 // mutatePod checks the NamespaceOffloading CR associated with the Pod's Namespace.
 // The pod is modified in different ways according to the PodOffloadingStrategyType
 // chosen in the CR. Two possible modifications:
 // - The VirtualNodeToleration is added to the Pod Toleration if necessary.
 // - The old Pod NodeSelector is substituted with a new one according to the PodOffloadingStrategyType.
 // No changes are applied to the Pod if the Liqo runtime when the Liqo runtime class is specified.
+// Additionally, the pods belonging to workloads offloaded as a whole are prevented from being scheduled.
 func mutatePod(namespaceOffloading *offloadingv1beta1.NamespaceOffloading, pod *corev1.Pod, liqoRuntimeClassName string) error {
 	// The NamespaceOffloading CR contains information about the PodOffloadingStrategy and
 	// the NodeSelector inserted by the user (ClusterSelector field).
 	klog.V(5).Infof("Chosen strategy: %s", namespaceOffloading.Spec.PodOffloadingStrategy)
 
+	fillPodWithTheWorkloadOffloadingGate(namespaceOffloading, pod)
+
 	// If strategy is equal to LocalPodOffloadingStrategy there is nothing to do
 	if namespaceOffloading.Spec.PodOffloadingStrategy == offloadingv1beta1.LocalPodOffloadingStrategyType {
 		return nil
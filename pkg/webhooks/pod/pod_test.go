@@ -342,4 +342,50 @@ var _ = Describe("Webhook", func() {
 			)
 		})
 	})
+
+	Context("Check the scheduling gate of the pods belonging to offloaded workloads", func() {
+		var (
+			podTest             *corev1.Pod
+			namespaceOffloading offloadingv1beta1.NamespaceOffloading
+		)
+		gate := corev1.PodSchedulingGate{Name: liqoconst.WorkloadOffloadingSchedulingGate}
+
+		BeforeEach(func() {
+			podTest = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "namespace"}}
+			namespaceOffloading = testutils.GetNamespaceOffloading(offloadingv1beta1.LocalPodOffloadingStrategyType)
+			namespaceOffloading.Status.RemoteNamespacesConditions = map[string]offloadingv1beta1.RemoteNamespaceConditions{
+				"remote-cluster-id": {},
+			}
+		})
+
+		It("should not add the gate to pods without the workload offloading annotation", func() {
+			fillPodWithTheWorkloadOffloadingGate(&namespaceOffloading, podTest)
+			Expect(podTest.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("should add the gate to pods with the workload offloading annotation, also in case of local strategy", func() {
+			podTest.Annotations = map[string]string{liqoconst.WorkloadOffloadingAnnotationKey: "remote-cluster-id"}
+			Expect(mutatePod(&namespaceOffloading, podTest, "liqo")).To(Succeed())
+			Expect(podTest.Spec.SchedulingGates).To(ConsistOf(gate))
+		})
+
+		It("should not add the gate if the namespace is not offloaded to the target cluster", func() {
+			podTest.Annotations = map[string]string{liqoconst.WorkloadOffloadingAnnotationKey: "other-cluster-id"}
+			Expect(mutatePod(&namespaceOffloading, podTest, "liqo")).To(Succeed())
+			Expect(podTest.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("should not add the gate if the target cluster is empty", func() {
+			podTest.Annotations = map[string]string{liqoconst.WorkloadOffloadingAnnotationKey: ""}
+			fillPodWithTheWorkloadOffloadingGate(&namespaceOffloading, podTest)
+			Expect(podTest.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("should not add the gate twice", func() {
+			podTest.Annotations = map[string]string{liqoconst.WorkloadOffloadingAnnotationKey: "remote-cluster-id"}
+			podTest.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: "other"}, gate}
+			fillPodWithTheWorkloadOffloadingGate(&namespaceOffloading, podTest)
+			Expect(podTest.Spec.SchedulingGates).To(ConsistOf(corev1.PodSchedulingGate{Name: "other"}, gate))
+		})
+	})
 })
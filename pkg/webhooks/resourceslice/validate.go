@@ -38,6 +38,12 @@ import (
 // cluster-role
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslices,verbs=get;list;watch;
 
+// providerAnnotations are the ResourceSlice annotations that can only be managed by the provider cluster.
+var providerAnnotations = []string{
+	consts.CordonResourceAnnotation,
+	consts.ApprovedGenerationAnnotation,
+}
+
 type rswh struct {
 	decoder admission.Decoder
 }
@@ -85,6 +91,11 @@ func (w *rswhv) handleCreate(ctx context.Context, req *admission.Request) admiss
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// Control plane users can't pre-approve the ResourceSlices they create.
+	if _, found := rs.Annotations[consts.ApprovedGenerationAnnotation]; found && authetication.IsControlPlaneUser(req.UserInfo.Groups) {
+		return admission.Denied(fmt.Sprintf("control plane users can't add the %s annotation", consts.ApprovedGenerationAnnotation))
+	}
+
 	// Always accept replicated ResourceSlices as a VirtualNode will not be created from those.
 	if reflection.IsReplicated(rs) {
 		return admission.Allowed("")
@@ -127,17 +138,19 @@ func (w *rswhv) handleUpdate(_ context.Context, req *admission.Request) admissio
 		return admission.Denied("can't add the remoteClusterID label")
 	}
 
-	// control plane users can't change/delete/add the CordonResourceAnnotation
-	oldCordonAnnotationValue, oldCordonAnnotationFound := rsold.Annotations[consts.CordonResourceAnnotation]
-	newCordonAnnotationValue, newCordonAnnotationFound := rsnew.Annotations[consts.CordonResourceAnnotation]
-
-	switch {
-	case oldCordonAnnotationFound && newCordonAnnotationFound && oldCordonAnnotationValue != newCordonAnnotationValue:
-		return admission.Denied(fmt.Sprintf("control plane users can't change the %s annotation", consts.CordonResourceAnnotation))
-	case oldCordonAnnotationFound && !newCordonAnnotationFound:
-		return admission.Denied(fmt.Sprintf("control plane users can't delete the %s annotation", consts.CordonResourceAnnotation))
-	case !oldCordonAnnotationFound && newCordonAnnotationFound:
-		return admission.Denied(fmt.Sprintf("control plane users can't add the %s annotation", consts.CordonResourceAnnotation))
+	// control plane users can't change/delete/add the annotations reserved to the provider cluster
+	for _, key := range providerAnnotations {
+		oldValue, oldFound := rsold.Annotations[key]
+		newValue, newFound := rsnew.Annotations[key]
+
+		switch {
+		case oldFound && newFound && oldValue != newValue:
+			return admission.Denied(fmt.Sprintf("control plane users can't change the %s annotation", key))
+		case oldFound && !newFound:
+			return admission.Denied(fmt.Sprintf("control plane users can't delete the %s annotation", key))
+		case !oldFound && newFound:
+			return admission.Denied(fmt.Sprintf("control plane users can't add the %s annotation", key))
+		}
 	}
 
 	return admission.Allowed("")
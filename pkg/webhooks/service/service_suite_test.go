@@ -0,0 +1,41 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+var scheme *runtime.Scheme
+
+var _ = BeforeSuite(func() {
+	scheme = runtime.NewScheme()
+	testutil.LogsToGinkgoWriter()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
+})
+
+func TestServiceWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Service Webhook Suite")
+}
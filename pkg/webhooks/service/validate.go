@@ -0,0 +1,92 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenantrbactemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+type svcwhv struct {
+	client  client.Client
+	decoder admission.Decoder
+}
+
+// NewValidator returns a new Service validating webhook.
+func NewValidator(cl client.Client) *webhook.Admission {
+	return &webhook.Admission{Handler: &svcwhv{
+		client:  cl,
+		decoder: admission.NewDecoder(runtime.NewScheme()),
+	}}
+}
+
+// Handle implements the Service validating webhook logic.
+//
+//nolint:gocritic // The signature of this method is imposed by controller runtime.
+func (w *svcwhv) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	var svc corev1.Service
+	if err := w.decoder.DecodeRaw(req.Object, &svc); err != nil {
+		klog.Errorf("Failed decoding Service object: %v", err)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var namespace corev1.Namespace
+	if err := w.client.Get(ctx, types.NamespacedName{Name: req.Namespace}, &namespace); err != nil {
+		klog.Errorf("Failed retrieving namespace %q: %v", req.Namespace, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	// The restrictions apply only to the requests performed by the identities of the consumer the namespace is offloaded from.
+	origin, found := namespace.Labels[consts.RemoteClusterID]
+	if !found || !slices.Contains(req.UserInfo.Groups, origin) {
+		return admission.Allowed("")
+	}
+
+	template, err := authentication.GetTenantRBACTemplate(ctx, w.client, liqov1beta1.ClusterID(origin))
+	if err != nil {
+		klog.Errorf("Failed retrieving the TenantRBACTemplate for cluster %q: %v", origin, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if template != nil && slices.Contains(template.Spec.ForbiddenServiceTypes, svc.Spec.Type) {
+		return admission.Denied(fmt.Sprintf("services of type %s are forbidden for cluster %q by the TenantRBACTemplate %q",
+			svc.Spec.Type, origin, template.Name))
+	}
+
+	return admission.Allowed("")
+}
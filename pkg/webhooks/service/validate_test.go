@@ -0,0 +1,139 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	servicewh "github.com/liqotech/liqo/pkg/webhooks/service"
+)
+
+var _ = Describe("Validating webhook tests", func() {
+	const (
+		origin    = "consumer"
+		namespace = "offloaded"
+	)
+
+	var (
+		ctx       context.Context
+		objects   []client.Object
+		svcType   corev1.ServiceType
+		groups    []string
+		operation admissionv1.Operation
+		response  admission.Response
+	)
+
+	forgeTemplate := func(name string, ids []liqov1beta1.ClusterID, forbidden ...corev1.ServiceType) *authv1beta1.TenantRBACTemplate {
+		return &authv1beta1.TenantRBACTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       authv1beta1.TenantRBACTemplateSpec{ConsumerClusterIDs: ids, ForbiddenServiceTypes: forbidden},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: namespace, Labels: map[string]string{consts.RemoteClusterID: origin}}}}
+		svcType = corev1.ServiceTypeLoadBalancer
+		groups = []string{origin, "system:authenticated"}
+		operation = admissionv1.Create
+	})
+
+	JustBeforeEach(func() {
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		svc := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Type: svcType},
+		}
+		raw, err := json.Marshal(&svc)
+		Expect(err).ToNot(HaveOccurred())
+
+		response = servicewh.NewValidator(cl).Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Namespace: namespace,
+			Object:    runtime.RawExtension{Raw: raw},
+			UserInfo:  authenticationv1.UserInfo{Username: "consumer-user", Groups: groups},
+		}})
+	})
+
+	When("no TenantRBACTemplate exists", func() {
+		It("should allow the service", func() { Expect(response.Allowed).To(BeTrue()) })
+	})
+
+	When("a TenantRBACTemplate forbids LoadBalancer services to all the consumers", func() {
+		BeforeEach(func() {
+			objects = append(objects, forgeTemplate("all", nil, corev1.ServiceTypeLoadBalancer))
+		})
+
+		It("should deny the service", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Message).To(ContainSubstring("LoadBalancer"))
+		})
+
+		When("the service is of an allowed type", func() {
+			BeforeEach(func() { svcType = corev1.ServiceTypeClusterIP })
+			It("should allow the service", func() { Expect(response.Allowed).To(BeTrue()) })
+		})
+
+		When("the request is not performed by an identity of the consumer", func() {
+			BeforeEach(func() { groups = []string{"system:masters"} })
+			It("should allow the service", func() { Expect(response.Allowed).To(BeTrue()) })
+		})
+
+		When("the request is an update", func() {
+			BeforeEach(func() { operation = admissionv1.Update })
+			It("should deny the service", func() { Expect(response.Allowed).To(BeFalse()) })
+		})
+
+		When("a more specific TenantRBACTemplate applies to the consumer", func() {
+			BeforeEach(func() {
+				objects = append(objects, forgeTemplate("specific", []liqov1beta1.ClusterID{origin}, corev1.ServiceTypeNodePort))
+			})
+			It("should enforce the specific template only", func() { Expect(response.Allowed).To(BeTrue()) })
+		})
+	})
+
+	When("a TenantRBACTemplate forbids LoadBalancer services to other consumers", func() {
+		BeforeEach(func() {
+			objects = append(objects, forgeTemplate("other", []liqov1beta1.ClusterID{"other"}, corev1.ServiceTypeLoadBalancer))
+		})
+		It("should allow the service", func() { Expect(response.Allowed).To(BeTrue()) })
+	})
+
+	When("the namespace is not offloaded", func() {
+		BeforeEach(func() {
+			objects = []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+				forgeTemplate("all", nil, corev1.ServiceTypeLoadBalancer),
+			}
+		})
+		It("should allow the service", func() { Expect(response.Allowed).To(BeTrue()) })
+	})
+})
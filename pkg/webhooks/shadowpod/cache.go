@@ -63,8 +63,14 @@ func (spv *Validator) initializeCache(ctx context.Context) (err error) {
 			return err
 		}
 
-		klog.V(5).Infof("Found %d ShadowPods running for user %s", len(shadowPodList.Items), q.Spec.User)
-		pi.alignExistingShadowPods(shadowPodList)
+		shadowWorkloadList, err := getters.ListShadowWorkloadsByCreator(ctx, spv.client, q.Spec.User)
+		if err != nil {
+			return err
+		}
+
+		klog.V(5).Infof("Found %d ShadowPods and %d ShadowWorkloads running for user %s",
+			len(shadowPodList.Items), len(shadowWorkloadList.Items), q.Spec.User)
+		pi.alignExistingShadowPods(shadowPodList, shadowWorkloadList)
 
 		spv.PeeringCache.peeringInfo.Store(q.Spec.User, pi)
 	}
@@ -92,10 +98,16 @@ func (spv *Validator) refreshCache(ctx context.Context) (done bool, err error) {
 				klog.Warning(err)
 				return true
 			}
-			klog.V(5).Infof("Found %d ShadowPods for user %q", len(shadowPodList.Items), pi.userName)
+			shadowWorkloadList, err := getters.ListShadowWorkloadsByCreator(ctx, spv.client, pi.userName)
+			if err != nil {
+				klog.Warning(err)
+				return true
+			}
+			klog.V(5).Infof("Found %d ShadowPods and %d ShadowWorkloads for user %q",
+				len(shadowPodList.Items), len(shadowWorkloadList.Items), pi.userName)
 			// Flush terminating ShadowPods and check the correct alignment between users and cache
 			klog.V(5).Infof("Aligning ShadowPods for user %q", pi.userName)
-			pi.alignTerminatingOrNotExistingShadowPods(shadowPodList)
+			pi.alignTerminatingOrNotExistingShadowPods(shadowPodList, shadowWorkloadList)
 			return true
 		},
 	)
@@ -109,7 +121,8 @@ func (spv *Validator) refreshCache(ctx context.Context) (done bool, err error) {
 	return false, nil
 }
 
-func (pi *peeringInfo) alignTerminatingOrNotExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList) {
+func (pi *peeringInfo) alignTerminatingOrNotExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList,
+	shadowWorkloadList *offloadingv1beta1.ShadowWorkloadList) {
 	pi.mu.Lock()
 	defer pi.mu.Unlock()
 	spMap := make(map[string]struct{})
@@ -122,6 +135,13 @@ func (pi *peeringInfo) alignTerminatingOrNotExistingShadowPods(shadowPodList *of
 		}
 		spMap[nsname.String()] = struct{}{}
 	}
+	for i := range shadowWorkloadList.Items {
+		nsname := types.NamespacedName{Name: shadowWorkloadList.Items[i].Name, Namespace: shadowWorkloadList.Items[i].Namespace}
+		if found := pi.checkAndAddShadowWorkloads(&shadowWorkloadList.Items[i], nsname); !found {
+			klog.Warningf("Warning: ShadowWorkload %s not found in cache, added", nsname.String())
+		}
+		spMap[shadowWorkloadKey(nsname)] = struct{}{}
+	}
 	klog.V(5).Infof("Searching for terminated ShadowPodDescription to be removed from cache")
 	// Alignment of all ShadowPodDescriptions in cache
 	pi.alignTerminatingShadowPodDescriptions(spMap)
@@ -131,10 +151,10 @@ func (pi *peeringInfo) alignTerminatingShadowPodDescriptions(spMap map[string]st
 	for _, shadowPodDescription := range pi.shadowPods {
 		// Check if the ShadowPod is in terminating phase in cache and has been already terminated/deleted from the cluster
 		// if true ShadowPodDescription can be also deleted from the cache
-		if _, stillPresent := spMap[shadowPodDescription.namespacedName.String()]; !stillPresent {
+		if _, stillPresent := spMap[shadowPodDescription.key]; !stillPresent {
 			if !shadowPodDescription.running {
 				pi.removeShadowPod(shadowPodDescription)
-				klog.V(5).Infof("ShadowPodDescription %s removed from cache", shadowPodDescription.namespacedName.String())
+				klog.V(5).Infof("ShadowPodDescription %s removed from cache", shadowPodDescription.key)
 			} else if time.Since(shadowPodDescription.creationTimestamp) > 30*time.Second {
 				pi.terminateShadowPod(shadowPodDescription)
 				pi.removeShadowPod(shadowPodDescription)
@@ -143,7 +163,8 @@ func (pi *peeringInfo) alignTerminatingShadowPodDescriptions(spMap map[string]st
 	}
 }
 
-func (pi *peeringInfo) alignExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList) {
+func (pi *peeringInfo) alignExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList,
+	shadowWorkloadList *offloadingv1beta1.ShadowWorkloadList) {
 	pi.mu.Lock()
 	defer pi.mu.Unlock()
 	for i := range shadowPodList.Items {
@@ -153,6 +174,12 @@ func (pi *peeringInfo) alignExistingShadowPods(shadowPodList *offloadingv1beta1.
 			klog.V(4).Infof("ShadowPod %s added in cache", nsname.String())
 		}
 	}
+	for i := range shadowWorkloadList.Items {
+		nsname := types.NamespacedName{Name: shadowWorkloadList.Items[i].Name, Namespace: shadowWorkloadList.Items[i].Namespace}
+		if found := pi.checkAndAddShadowWorkloads(&shadowWorkloadList.Items[i], nsname); !found {
+			klog.V(4).Infof("ShadowWorkload %s added in cache", nsname.String())
+		}
+	}
 }
 
 func (pi *peeringInfo) checkAndAddShadowPods(shadowPod *offloadingv1beta1.ShadowPod, nsname types.NamespacedName) (found bool) {
@@ -189,8 +216,13 @@ func (spv *Validator) checkAlignmentQuotaPeeringInfo(ctx context.Context) error
 			if err != nil {
 				return err
 			}
-			klog.V(5).Infof("Found %d ShadowPods running for user %s", len(shadowPodList.Items), quota.Spec.User)
-			newPI.(*peeringInfo).alignExistingShadowPods(shadowPodList)
+			shadowWorkloadList, err := getters.ListShadowWorkloadsByCreator(ctx, spv.client, quota.Spec.User)
+			if err != nil {
+				return err
+			}
+			klog.V(5).Infof("Found %d ShadowPods and %d ShadowWorkloads running for user %s",
+				len(shadowPodList.Items), len(shadowWorkloadList.Items), quota.Spec.User)
+			newPI.(*peeringInfo).alignExistingShadowPods(shadowPodList, shadowWorkloadList)
 		}
 	}
 
@@ -82,7 +82,7 @@ var _ = Describe("Webhook Cache", func() {
 
 	Describe("Align existing ShadowPod", func() {
 		JustBeforeEach(func() {
-			peering.alignExistingShadowPods(spList)
+			peering.alignExistingShadowPods(spList, &offloadingv1beta1.ShadowWorkloadList{})
 		})
 
 		When("Some ShadowPods do not exist in a specific peeringInfo", func() {
@@ -103,7 +103,7 @@ var _ = Describe("Webhook Cache", func() {
 
 	Describe("Align terminating or not existing ShadowPods", func() {
 		JustBeforeEach(func() {
-			peering.alignTerminatingOrNotExistingShadowPods(spList)
+			peering.alignTerminatingOrNotExistingShadowPods(spList, &offloadingv1beta1.ShadowWorkloadList{})
 		})
 
 		When("Some ShadowPods do not exist in a specific peeringInfo some others are in terminating", func() {
@@ -12,5 +12,5 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package shadowpod contains the validating webhook logic and the cache of peering information.
+// Package shadowpod contains the validating webhook logic of the shadow pods and shadow workloads, and the cache of peering information.
 package shadowpod
@@ -107,18 +107,18 @@ func (pi *peeringInfo) addUsedResources(resources corev1.ResourceList) {
 }
 
 func (pi *peeringInfo) addShadowPod(spd *Description) {
-	pi.shadowPods[spd.namespacedName.String()] = spd
+	pi.shadowPods[spd.key] = spd
 	pi.addUsedResources(spd.quota)
 }
 
 func (pi *peeringInfo) terminateShadowPod(spd *Description) {
 	spd.terminate()
-	pi.shadowPods[spd.namespacedName.String()] = spd
+	pi.shadowPods[spd.key] = spd
 	pi.subUsedResources(spd.quota)
 }
 
 func (pi *peeringInfo) removeShadowPod(spd *Description) {
-	delete(pi.shadowPods, spd.namespacedName.String())
+	delete(pi.shadowPods, spd.key)
 }
 
 func (pi *peeringInfo) testAndUpdateCreation(ctx context.Context, c client.Client,
@@ -149,6 +149,48 @@ func (pi *peeringInfo) testAndUpdateCreation(ctx context.Context, c client.Clien
 	return nil
 }
 
+// testAndUpdateResize checks whether the in-place resize of the given shadowpod fits the free quota, once released the
+// resources previously accounted for it, and in that case it updates the quota usage accordingly.
+func (pi *peeringInfo) testAndUpdateResize(sp *offloadingv1beta1.ShadowPod,
+	limitsEnforcement offloadingv1beta1.LimitsEnforcement, dryRun bool) error {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+
+	spd, err := pi.getShadowPodDescription(sp)
+	if err != nil {
+		return err
+	}
+
+	newQuota, err := getQuotaFromShadowPod(sp, limitsEnforcement)
+	if err != nil {
+		return err
+	}
+
+	klog.V(5).Infof("ShadowPod resource limits %s (previously %s)", quotaFormatter(*newQuota), quotaFormatter(spd.quota))
+	return pi.testAndUpdateQuota(spd, *newQuota, dryRun)
+}
+
+// testAndUpdateQuota checks whether the new resources of the given description fit the free quota, once released the
+// resources previously accounted for it, and in that case it updates the quota usage accordingly. It shall be called
+// with the lock held.
+func (pi *peeringInfo) testAndUpdateQuota(spd *Description, newQuota corev1.ResourceList, dryRun bool) error {
+	klog.V(5).Infof("Cluster %q free quota %s", pi.userName, quotaFormatter(pi.getFreeQuota()))
+
+	// Temporarily release the resources previously accounted for the description, to check whether the new ones fit.
+	pi.subUsedResources(spd.quota)
+	resized := &Description{quota: newQuota}
+	if err := pi.checkResources(resized); err != nil || dryRun {
+		pi.addUsedResources(spd.quota)
+		return err
+	}
+
+	pi.addUsedResources(newQuota)
+	spd.quota = newQuota
+	klog.V(5).Infof("Cluster %q updated used quota %s", pi.userName, quotaFormatter(pi.usedQuota))
+	klog.V(5).Infof("Cluster %q updated free quota %s", pi.userName, quotaFormatter(pi.getFreeQuota()))
+	return nil
+}
+
 func (pi *peeringInfo) updateDeletion(sp *offloadingv1beta1.ShadowPod, dryRun bool) error {
 	pi.mu.Lock()
 	defer pi.mu.Unlock()
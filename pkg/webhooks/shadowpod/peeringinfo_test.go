@@ -196,6 +196,56 @@ var _ = Describe("Peering Info", func() {
 		})
 	})
 
+	Describe("Test and update resize", func() {
+		var resourceQuotaHalf *corev1.ResourceList
+
+		BeforeEach(func() {
+			resourceQuotaHalf = forgeResourceList(int64(resourceCPU/2), int64(resourceMemory/2))
+			peeringInfo = createPeeringInfo(userName, *resourceQuota)
+			peeringInfo.addShadowPod(createShadowPodDescription(testShadowPodName, testNamespace, testShadowPodUID, *resourceQuotaHalf))
+		})
+
+		JustBeforeEach(func() {
+			err = peeringInfo.testAndUpdateResize(shadowPod, offloadingv1beta1.SoftLimitsEnforcement, dryRun)
+		})
+
+		When("resources are available and dryRun flag is false", func() {
+			BeforeEach(func() { dryRun = false })
+			It("should not return any error and used resources will be updated", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(peeringInfo.usedQuota.Cpu().Value()).To(Equal(int64(resourceCPU)))
+				Expect(peeringInfo.usedQuota.Memory().Value()).To(Equal(int64(resourceMemory)))
+				Expect(peeringInfo.shadowPods[testNamespace+"/"+testShadowPodName].quota).To(Equal(*resourceQuota))
+			})
+		})
+		When("resources are available and dryRun flag is true", func() {
+			BeforeEach(func() { dryRun = true })
+			It("should not return any error and used resources will not be updated", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(peeringInfo.usedQuota.Cpu().Value()).To(Equal(int64(resourceCPU / 2)))
+				Expect(peeringInfo.shadowPods[testNamespace+"/"+testShadowPodName].quota).To(Equal(*resourceQuotaHalf))
+			})
+		})
+		When("resources are not available", func() {
+			BeforeEach(func() {
+				dryRun = false
+				containers = []containerResource{{cpu: int64(resourceCPU * 2), memory: int64(resourceMemory)}}
+				shadowPod = forgeShadowPodWithResourceRequests(containers, nil)
+			})
+			It("should return an error and used resources will not be updated", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(peeringInfo.usedQuota.Cpu().Value()).To(Equal(int64(resourceCPU / 2)))
+				Expect(peeringInfo.shadowPods[testNamespace+"/"+testShadowPodName].quota).To(Equal(*resourceQuotaHalf))
+			})
+		})
+		When("shadow pod description does not exist", func() {
+			BeforeEach(func() { peeringInfo = createPeeringInfo(userName, *resourceQuota) })
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Update deletion", func() {
 		JustBeforeEach(func() {
 			err = peeringInfo.updateDeletion(shadowPod, dryRun)
@@ -0,0 +1,361 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// shadowWorkloadResource is the resource of the shadow workloads, whose requests are served by the same webhooks of the shadow pods.
+const shadowWorkloadResource = "shadowworkloads"
+
+// handleShadowWorkload validates the creation, update and deletion of shadow workloads. The pods of a shadow workload
+// are created by the native controllers of the local cluster, hence the workload is charged against the quota of its
+// creator as a whole, i.e., the resources requested by its pod template multiplied by the number of replicas
+// (including the surge of the rolling updates of Deployments).
+func (spv *Validator) handleShadowWorkload(ctx context.Context, req *admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create:
+		return spv.handleShadowWorkloadCreate(ctx, req)
+	case admissionv1.Delete:
+		return spv.handleShadowWorkloadDelete(req)
+	case admissionv1.Update:
+		return spv.handleShadowWorkloadUpdate(ctx, req)
+	default:
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("unsupported operation %s", req.Operation))
+	}
+}
+
+func (spv *Validator) handleShadowWorkloadCreate(ctx context.Context, req *admission.Request) admission.Response {
+	sw, err := decodeShadowWorkload(spv.decoder, req.Object)
+	if err != nil {
+		klog.Errorf("Failed decoding shadow workload: %v", err)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowWorkload: %w", err))
+	}
+
+	clusterID, found := sw.Labels[forge.LiqoOriginClusterIDKey]
+	if !found {
+		klog.Warningf("Missing origin Cluster ID label on ShadowWorkload %q", sw.Name)
+		return admission.Denied("missing origin Cluster ID label")
+	}
+
+	code, err := spv.validateShadowPodClusterID(ctx, sw.GetNamespace(), clusterID)
+	if err != nil {
+		if code == http.StatusBadRequest {
+			return admission.Errored(code, err)
+		}
+		return admission.Denied(err.Error())
+	}
+
+	if !spv.enableResourceValidation {
+		return admission.Allowed("")
+	}
+
+	creatorName, found := sw.Labels[consts.CreatorLabelKey]
+	if !found {
+		return admission.Denied("missing creator label")
+	}
+
+	quota, err := getters.GetQuotaByUser(ctx, spv.client, creatorName)
+	if err != nil {
+		klog.Warningf("Failed getting quota for user %s: %v", creatorName, err)
+		return admission.Denied("failed getting quota")
+	}
+
+	if quota.Spec.Cordoned != nil && *quota.Spec.Cordoned {
+		klog.Warningf("User %q is cordoned", creatorName)
+		return admission.Denied("user is cordoned")
+	}
+
+	peeringInfo := spv.PeeringCache.getOrCreatePeeringInfo(creatorName, quota.Spec.Resources)
+	if err := peeringInfo.testAndUpdateWorkloadCreation(ctx, spv.client, sw, quota.Spec.LimitsEnforcement, *req.DryRun); err != nil {
+		klog.Warning(err)
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+func (spv *Validator) handleShadowWorkloadUpdate(ctx context.Context, req *admission.Request) admission.Response {
+	sw, err := decodeShadowWorkload(spv.decoder, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowWorkload: %w", err))
+	}
+
+	oldSw, err := decodeShadowWorkload(spv.decoder, req.OldObject)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowWorkload: %w", err))
+	}
+
+	clusterID, found := sw.Labels[forge.LiqoOriginClusterIDKey]
+	if !found {
+		klog.Warningf("Missing origin Cluster ID label on ShadowWorkload %q", sw.Name)
+		return admission.Denied("missing origin Cluster ID label")
+	}
+
+	if oldClusterID := oldSw.Labels[forge.LiqoOriginClusterIDKey]; clusterID != oldClusterID {
+		klog.Warningf("The Cluster ID label of the updated shadowworkload %s is different from the old one %s", clusterID, oldClusterID)
+		return admission.Denied("shadowworkload Cluster ID label is changed")
+	}
+
+	// Scaling the workload (or changing its surge), or changing the resources of its pods, requires to recompute the quota usage.
+	if !spv.enableResourceValidation || (workloadReplicas(sw) == workloadReplicas(oldSw) &&
+		equality.Semantic.DeepEqual(sw.Spec.Template.Spec.Containers, oldSw.Spec.Template.Spec.Containers) &&
+		equality.Semantic.DeepEqual(sw.Spec.Template.Spec.InitContainers, oldSw.Spec.Template.Spec.InitContainers)) {
+		return admission.Allowed("")
+	}
+
+	creatorName, found := sw.Labels[consts.CreatorLabelKey]
+	if !found {
+		return admission.Denied("missing creator label")
+	}
+
+	quota, err := getters.GetQuotaByUser(ctx, spv.client, creatorName)
+	if err != nil {
+		klog.Warningf("Failed getting quota for user %s: %v", creatorName, err)
+		return admission.Denied("failed getting quota")
+	}
+
+	peeringInfo := spv.PeeringCache.getOrCreatePeeringInfo(creatorName, quota.Spec.Resources)
+	if err := peeringInfo.testAndUpdateWorkloadResize(sw, quota.Spec.LimitsEnforcement, *req.DryRun); err != nil {
+		klog.Warning(err)
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+func (spv *Validator) handleShadowWorkloadDelete(req *admission.Request) admission.Response {
+	if !spv.enableResourceValidation {
+		return admission.Allowed("")
+	}
+
+	sw, err := decodeShadowWorkload(spv.decoder, req.OldObject)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowWorkload: %w", err))
+	}
+
+	creatorName, found := sw.Labels[consts.CreatorLabelKey]
+	if !found {
+		klog.Warningf("Missing creator label on ShadowWorkload %q", sw.Name)
+		return admission.Denied("missing creator label")
+	}
+
+	peeringInfo, found := spv.PeeringCache.getPeeringInfo(creatorName)
+	if !found {
+		// The deletion is always allowed, and the next refreshing process will align the cache.
+		klog.Warningf("PeeringInfo not found in cache for user %q", creatorName)
+		return admission.Allowed(fmt.Sprintf("Peering not found in cache for user %q", creatorName))
+	}
+
+	if err := peeringInfo.updateWorkloadDeletion(sw, *req.DryRun); err != nil {
+		klog.Warning(err)
+		return admission.Allowed(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// decodeShadowWorkload decodes a shadow workload from a given runtime object.
+func decodeShadowWorkload(decoder admission.Decoder, obj runtime.RawExtension) (sw *offloadingv1beta1.ShadowWorkload, err error) {
+	sw = &offloadingv1beta1.ShadowWorkload{}
+	err = decoder.DecodeRaw(obj, sw)
+	return
+}
+
+// shadowWorkloadKey returns the key of the description of the given shadow workload in the cache.
+func shadowWorkloadKey(nsname types.NamespacedName) string {
+	return "shadowworkload/" + nsname.String()
+}
+
+// workloadReplicas returns the maximum number of pods the given shadow workload runs at the same time, including the
+// ones a Deployment creates above the desired replicas during a rolling update.
+func workloadReplicas(sw *offloadingv1beta1.ShadowWorkload) int64 {
+	replicas := sw.Spec.Replicas
+	if sw.Spec.Kind == offloadingv1beta1.JobWorkloadKind {
+		replicas = nil
+		if sw.Spec.Job != nil {
+			replicas = sw.Spec.Job.Parallelism
+		}
+	}
+
+	desired := int64(1)
+	if replicas != nil {
+		desired = int64(*replicas)
+	}
+	if sw.Spec.Kind == offloadingv1beta1.DeploymentWorkloadKind {
+		return desired + deploymentSurge(sw, desired)
+	}
+	return desired
+}
+
+// deploymentSurge returns the maximum number of pods a shadow Deployment creates above the desired replicas during
+// a rolling update, applying the same defaults of the native Deployments.
+func deploymentSurge(sw *offloadingv1beta1.ShadowWorkload, replicas int64) int64 {
+	var strategy appsv1.DeploymentStrategy
+	if sw.Spec.Deployment != nil {
+		strategy = sw.Spec.Deployment.Strategy
+	}
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return 0
+	}
+
+	maxSurge := intstr.FromString("25%")
+	if strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxSurge != nil {
+		maxSurge = *strategy.RollingUpdate.MaxSurge
+	}
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(replicas), true)
+	if err != nil || surge < 0 {
+		// The native Deployment is rejected anyway, hence no pod is created.
+		return 0
+	}
+	return int64(surge)
+}
+
+// getQuotaFromShadowWorkload returns the resources requested by the given shadow workload, i.e., the ones requested by
+// its pod template multiplied by the maximum number of pods it runs at the same time.
+func getQuotaFromShadowWorkload(sw *offloadingv1beta1.ShadowWorkload,
+	limitsEnforcement offloadingv1beta1.LimitsEnforcement) (*corev1.ResourceList, error) {
+	if sw.Spec.Template.Spec.Containers == nil {
+		return nil, fmt.Errorf("ShadowWorkload %s has no containers defined", sw.GetName())
+	}
+
+	podQuota, err := getQuotaFromPodSpec(&sw.Spec.Template.Spec, limitsEnforcement)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := workloadReplicas(sw)
+	result := corev1.ResourceList{}
+	for key, podValue := range *podQuota {
+		value := podValue.DeepCopy()
+		value.Mul(replicas)
+		result[key] = value
+	}
+	return &result, nil
+}
+
+func (pi *peeringInfo) testAndUpdateWorkloadCreation(ctx context.Context, c client.Client,
+	sw *offloadingv1beta1.ShadowWorkload, limitsEnforcement offloadingv1beta1.LimitsEnforcement, dryRun bool) error {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+
+	nsname := types.NamespacedName{Name: sw.Name, Namespace: sw.Namespace}
+	swQuota, err := getQuotaFromShadowWorkload(sw, limitsEnforcement)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("ShadowWorkload %s quota %s", nsname.String(), quotaFormatter(*swQuota))
+
+	if swd, found := pi.shadowPods[shadowWorkloadKey(nsname)]; found {
+		if swd.running {
+			return fmt.Errorf("ShadowWorkload %s is already running", sw.GetName())
+		}
+		if err := c.Get(ctx, nsname, &offloadingv1beta1.ShadowWorkload{}); client.IgnoreNotFound(err) != nil {
+			return err
+		} else if err == nil {
+			return fmt.Errorf("ShadowWorkload still exists in the system")
+		}
+		// The cache refreshing has not yet removed the description of the previous shadow workload.
+		pi.removeShadowPod(swd)
+	}
+
+	swd := createDescription(shadowWorkloadKey(nsname), nsname, sw.GetUID(), *swQuota)
+	klog.V(5).Infof("Cluster %q free quota %s", pi.userName, quotaFormatter(pi.getFreeQuota()))
+	if err := pi.checkResources(swd); err != nil {
+		return err
+	}
+	if !dryRun {
+		pi.addShadowPod(swd)
+		klog.V(5).Infof("Cluster %q updated used quota %s", pi.userName, quotaFormatter(pi.usedQuota))
+	}
+	return nil
+}
+
+func (pi *peeringInfo) testAndUpdateWorkloadResize(sw *offloadingv1beta1.ShadowWorkload,
+	limitsEnforcement offloadingv1beta1.LimitsEnforcement, dryRun bool) error {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+
+	swd, err := pi.getShadowWorkloadDescription(sw)
+	if err != nil {
+		return err
+	}
+
+	newQuota, err := getQuotaFromShadowWorkload(sw, limitsEnforcement)
+	if err != nil {
+		return err
+	}
+
+	klog.V(5).Infof("ShadowWorkload resource limits %s (previously %s)", quotaFormatter(*newQuota), quotaFormatter(swd.quota))
+	return pi.testAndUpdateQuota(swd, *newQuota, dryRun)
+}
+
+func (pi *peeringInfo) updateWorkloadDeletion(sw *offloadingv1beta1.ShadowWorkload, dryRun bool) error {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+
+	swd, err := pi.getShadowWorkloadDescription(sw)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		pi.terminateShadowPod(swd)
+		klog.V(5).Infof("Cluster %q updated used quota %s", pi.userName, quotaFormatter(pi.usedQuota))
+	}
+	return nil
+}
+
+func (pi *peeringInfo) getShadowWorkloadDescription(sw *offloadingv1beta1.ShadowWorkload) (*Description, error) {
+	swd, found := pi.shadowPods[shadowWorkloadKey(types.NamespacedName{Name: sw.Name, Namespace: sw.Namespace})]
+	if !found {
+		return nil, fmt.Errorf("ShadowWorkload %s not found (Maybe Cache problem)", sw.GetName())
+	} else if swd.uid != sw.GetUID() {
+		return nil, fmt.Errorf("ShadowWorkload %s: UID mismatch", sw.GetName())
+	}
+	return swd, nil
+}
+
+func (pi *peeringInfo) checkAndAddShadowWorkloads(sw *offloadingv1beta1.ShadowWorkload, nsname types.NamespacedName) (found bool) {
+	_, found = pi.shadowPods[shadowWorkloadKey(nsname)]
+	if !found {
+		// Errors are intentionally ignored here.
+		swQuota, err := getQuotaFromShadowWorkload(sw, offloadingv1beta1.NoLimitsEnforcement)
+		if err != nil {
+			swQuota = &corev1.ResourceList{}
+		}
+		pi.addShadowPod(createDescription(shadowWorkloadKey(nsname), nsname, sw.GetUID(), *swQuota))
+	}
+	return
+}
@@ -0,0 +1,202 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("ShadowWorkload webhooks", func() {
+	const testShadowWorkloadUID types.UID = "test-shadowworkload-uid"
+
+	var (
+		validator *Validator
+		response  admission.Response
+	)
+
+	forgeShadowWorkload := func(origin string, replicas int32, cpu, memory int64) *offloadingv1beta1.ShadowWorkload {
+		return &offloadingv1beta1.ShadowWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-shadowworkload", Namespace: testNamespace, UID: testShadowWorkloadUID,
+				Labels: map[string]string{forge.LiqoOriginClusterIDKey: origin, consts.CreatorLabelKey: userName},
+			},
+			Spec: offloadingv1beta1.ShadowWorkloadSpec{
+				Kind:     offloadingv1beta1.DeploymentWorkloadKind,
+				Replicas: ptr.To(replicas),
+				Deployment: &offloadingv1beta1.ShadowDeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "test-container", Image: "test-image",
+					Resources: corev1.ResourceRequirements{Requests: *forgeResourceList(cpu, memory)},
+				}}}},
+			},
+		}
+	}
+
+	serialize := func(sw *offloadingv1beta1.ShadowWorkload) runtime.RawExtension {
+		data, err := json.Marshal(sw)
+		Expect(err).ToNot(HaveOccurred())
+		return runtime.RawExtension{Raw: data}
+	}
+
+	forgeWorkloadRequest := func(op admissionv1.Operation, newSw, oldSw *offloadingv1beta1.ShadowWorkload) admission.Request {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op, DryRun: ptr.To(false),
+			Resource: metav1.GroupVersionResource{Group: offloadingv1beta1.SchemeGroupVersion.Group, Resource: shadowWorkloadResource},
+			UserInfo: authenticationv1.UserInfo{Username: userName},
+		}}
+		if newSw != nil {
+			req.Object = serialize(newSw)
+		}
+		if oldSw != nil {
+			req.OldObject = serialize(oldSw)
+		}
+		return req
+	}
+
+	usedCPU := func() int64 {
+		pi, found := validator.PeeringCache.getPeeringInfo(userName)
+		Expect(found).To(BeTrue())
+		return pi.usedQuota.Cpu().Value()
+	}
+
+	BeforeEach(func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(testutil.FakeNamespaceWithClusterID(clusterID, testNamespace), quota).Build()
+		validator = NewValidator(fakeClient, true)
+		validator.PeeringCache.ready = true
+	})
+
+	When("a ShadowWorkload is created", func() {
+		It("should deny the ShadowWorkloads with a mismatching origin cluster ID", func() {
+			sw := forgeShadowWorkload(string(clusterIDInvalid), 1, int64(resourceCPU/4), int64(resourceMemory/4))
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeNumerically("==", http.StatusForbidden))
+		})
+
+		It("should charge the requests of all the replicas against the quota", func() {
+			sw := forgeShadowWorkload(string(clusterID), 2, int64(resourceCPU/4), int64(resourceMemory/4))
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU/2))
+		})
+
+		It("should charge the pods created above the replicas during the rolling updates", func() {
+			sw := forgeShadowWorkload(string(clusterID), 2, int64(resourceCPU/4), int64(resourceMemory/4))
+			sw.Spec.Deployment.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: ptr.To(intstr.FromInt32(1))}}
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU*3/4))
+		})
+
+		It("should charge the default surge if the strategy is not specified", func() {
+			sw := forgeShadowWorkload(string(clusterID), 4, int64(resourceCPU/8), int64(resourceMemory/8))
+			sw.Spec.Deployment = nil
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU*5/8))
+		})
+
+		It("should deny the ShadowWorkloads whose surge exceeds the quota", func() {
+			sw := forgeShadowWorkload(string(clusterID), 2, int64(resourceCPU/2), int64(resourceMemory/2))
+			sw.Spec.Deployment = nil
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeNumerically("==", http.StatusForbidden))
+		})
+
+		It("should deny the ShadowWorkloads whose replicas exceed the quota", func() {
+			sw := forgeShadowWorkload(string(clusterID), 3, int64(resourceCPU/2), int64(resourceMemory/2))
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeNumerically("==", http.StatusForbidden))
+		})
+	})
+
+	When("a ShadowWorkload is scaled", func() {
+		var sw *offloadingv1beta1.ShadowWorkload
+
+		BeforeEach(func() {
+			sw = forgeShadowWorkload(string(clusterID), 2, int64(resourceCPU/4), int64(resourceMemory/4))
+			Expect(validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil)).Allowed).To(BeTrue())
+		})
+
+		It("should update the quota usage if the new replicas fit the quota", func() {
+			scaled := sw.DeepCopy()
+			scaled.Spec.Replicas = ptr.To[int32](4)
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Update, scaled, sw))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU))
+		})
+
+		It("should deny the scale up if the new replicas exceed the quota", func() {
+			scaled := sw.DeepCopy()
+			scaled.Spec.Replicas = ptr.To[int32](5)
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Update, scaled, sw))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU/2))
+		})
+
+		It("should recompute the quota usage if the surge changes", func() {
+			surged := sw.DeepCopy()
+			surged.Spec.Deployment.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: ptr.To(intstr.FromString("100%"))}}
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Update, surged, sw))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeNumerically("==", resourceCPU))
+		})
+
+		It("should release the resources once deleted", func() {
+			response = validator.Handle(ctx, forgeWorkloadRequest(admissionv1.Delete, nil, sw))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(usedCPU()).To(BeZero())
+		})
+	})
+
+	When("the mutating webhook handles a ShadowWorkload", func() {
+		It("should only add the creator label", func() {
+			sw := forgeShadowWorkload(string(clusterID), 2, int64(resourceCPU/4), int64(resourceMemory/4))
+			delete(sw.Labels, consts.CreatorLabelKey)
+			response = NewMutator(nil, true).Handle(ctx, forgeWorkloadRequest(admissionv1.Create, sw, nil))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(HaveLen(1))
+			Expect(response.Patches[0].Path).To(Equal("/metadata/labels/liqo.io~1creator-user"))
+			Expect(response.Patches[0].Value).To(Equal(userName))
+		})
+	})
+})
@@ -28,8 +28,10 @@ import (
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 )
 
-// Description is a struct that contains the main informations about a shadow pod.
+// Description is a struct that contains the main informations about a shadow pod, or a shadow workload.
 type Description struct {
+	// key identifies the description in the cache, distinguishing the shadow workloads from the shadow pods.
+	key               string
 	namespacedName    types.NamespacedName
 	uid               types.UID
 	quota             corev1.ResourceList
@@ -38,8 +40,14 @@ type Description struct {
 }
 
 func createShadowPodDescription(name, namespace string, uid types.UID, resources corev1.ResourceList) *Description {
+	nsname := types.NamespacedName{Name: name, Namespace: namespace}
+	return createDescription(nsname.String(), nsname, uid, resources)
+}
+
+func createDescription(key string, nsname types.NamespacedName, uid types.UID, resources corev1.ResourceList) *Description {
 	return &Description{
-		namespacedName:    types.NamespacedName{Name: name, Namespace: namespace},
+		key:               key,
+		namespacedName:    nsname,
 		uid:               uid,
 		quota:             resources,
 		running:           true,
@@ -100,20 +108,24 @@ func checkShadowPodExistence(ctx context.Context, spvclient client.Client, names
 
 func getQuotaFromShadowPod(shadowpod *offloadingv1beta1.ShadowPod,
 	limitsEnforcement offloadingv1beta1.LimitsEnforcement) (*corev1.ResourceList, error) {
-	conResources := corev1.ResourceList{}
-	initConResources := corev1.ResourceList{}
-
 	// At least one container is required
 	if shadowpod.Spec.Pod.Containers == nil {
 		return nil, fmt.Errorf("ShadowPod %s has no containers defined", shadowpod.GetName())
 	}
+	return getQuotaFromPodSpec(&shadowpod.Spec.Pod, limitsEnforcement)
+}
+
+// getQuotaFromPodSpec returns the resources requested by a pod with the given spec.
+func getQuotaFromPodSpec(podSpec *corev1.PodSpec, limitsEnforcement offloadingv1beta1.LimitsEnforcement) (*corev1.ResourceList, error) {
+	conResources := corev1.ResourceList{}
+	initConResources := corev1.ResourceList{}
 
 	// Calculating the sum of the resources of all containers
-	for i := range shadowpod.Spec.Pod.Containers {
+	for i := range podSpec.Containers {
 		// This flags are used to check if each container in range has CPU and Memory requests defined
 		cpuFlag := false
 		memoryFlag := false
-		for key, value := range shadowpod.Spec.Pod.Containers[i].Resources.Requests {
+		for key, value := range podSpec.Containers[i].Resources.Requests {
 			if key == corev1.ResourceCPU {
 				cpuFlag = true
 			}
@@ -128,11 +140,11 @@ func getQuotaFromShadowPod(shadowpod *offloadingv1beta1.ShadowPod,
 			}
 
 			if limitsEnforcement == offloadingv1beta1.HardLimitsEnforcement {
-				req := shadowpod.Spec.Pod.Containers[i].Resources.Requests[key]
-				lim := shadowpod.Spec.Pod.Containers[i].Resources.Limits[key]
+				req := podSpec.Containers[i].Resources.Requests[key]
+				lim := podSpec.Containers[i].Resources.Limits[key]
 				if req.Cmp(lim) != 0 {
 					return nil, fmt.Errorf("%s limits and requests are not equal for container %s",
-						key, shadowpod.Spec.Pod.Containers[i].Name)
+						key, podSpec.Containers[i].Name)
 				}
 			}
 		}
@@ -141,16 +153,16 @@ func getQuotaFromShadowPod(shadowpod *offloadingv1beta1.ShadowPod,
 			continue
 		}
 		if !cpuFlag || !memoryFlag {
-			return nil, fmt.Errorf("CPU and/or memory requests not set for container %s", shadowpod.Spec.Pod.Containers[i].Name)
+			return nil, fmt.Errorf("CPU and/or memory requests not set for container %s", podSpec.Containers[i].Name)
 		}
 	}
 
 	// Calculating the max of each resource type between the init containers
-	for i := range shadowpod.Spec.Pod.InitContainers {
+	for i := range podSpec.InitContainers {
 		// This flags are used to check if each container in range has CPU and Memory requests defined
 		cpuFlag := false
 		memoryFlag := false
-		for key, value := range shadowpod.Spec.Pod.InitContainers[i].Resources.Requests {
+		for key, value := range podSpec.InitContainers[i].Resources.Requests {
 			if key == corev1.ResourceCPU {
 				cpuFlag = true
 			}
@@ -166,11 +178,11 @@ func getQuotaFromShadowPod(shadowpod *offloadingv1beta1.ShadowPod,
 			}
 
 			if limitsEnforcement == offloadingv1beta1.HardLimitsEnforcement {
-				req := shadowpod.Spec.Pod.InitContainers[i].Resources.Requests[key]
-				lim := shadowpod.Spec.Pod.InitContainers[i].Resources.Limits[key]
+				req := podSpec.InitContainers[i].Resources.Requests[key]
+				lim := podSpec.InitContainers[i].Resources.Limits[key]
 				if req.Cmp(lim) != 0 {
 					return nil, fmt.Errorf("%s limits and requests are not equal for container %s",
-						key, shadowpod.Spec.Pod.InitContainers[i].Name)
+						key, podSpec.InitContainers[i].Name)
 				}
 			}
 		}
@@ -180,7 +192,7 @@ func getQuotaFromShadowPod(shadowpod *offloadingv1beta1.ShadowPod,
 		}
 		if !cpuFlag || !memoryFlag {
 			return nil, fmt.Errorf("CPU and/or memory requests not set for initContainer %s",
-				shadowpod.Spec.Pod.InitContainers[i].Name)
+				podSpec.InitContainers[i].Name)
 		}
 	}
 	result := quotav1.Max(conResources, initConResources)
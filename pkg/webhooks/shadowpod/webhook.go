@@ -39,10 +39,11 @@ import (
 
 // cluster-role
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowpods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=shadowworkloads,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=quotas,verbs=get;list;watch
 
-// Validator is the handler used by the Validating Webhook to validate shadow pods.
+// Validator is the handler used by the Validating Webhook to validate shadow pods and shadow workloads.
 type Validator struct {
 	client                   client.Client
 	PeeringCache             *peeringCache
@@ -71,6 +72,10 @@ func (spv *Validator) Handle(ctx context.Context, req admission.Request) admissi
 		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("initialization in progress"))
 	}
 
+	if req.Resource.Resource == shadowWorkloadResource {
+		return spv.handleShadowWorkload(ctx, &req)
+	}
+
 	switch req.Operation {
 	case admissionv1.Create:
 		return spv.HandleCreate(ctx, &req)
@@ -176,11 +181,33 @@ func (spv *Validator) HandleUpdate(ctx context.Context, req *admission.Request)
 		return admission.Denied("shadopow Cluster ID label is changed")
 	}
 
-	if pod.CheckShadowPodUpdate(&shadowpod.Spec.Pod, &oldShadowpod.Spec.Pod) {
+	if !pod.CheckShadowPodUpdate(oldShadowpod.Spec.Pod.DeepCopy(), shadowpod.Spec.Pod.DeepCopy()) {
+		return admission.Denied("")
+	}
+
+	// An in-place resize of the containers requires to recompute the quota usage.
+	if !spv.enableResourceValidation || pod.AreContainersResourcesEqual(oldShadowpod.Spec.Pod.Containers, shadowpod.Spec.Pod.Containers) {
 		return admission.Allowed("")
 	}
 
-	return admission.Denied("")
+	creatorName, found := shadowpod.Labels[consts.CreatorLabelKey]
+	if !found {
+		return admission.Denied("missing creator label")
+	}
+
+	quota, err := getters.GetQuotaByUser(ctx, spv.client, creatorName)
+	if err != nil {
+		klog.Warningf("Failed getting quota for user %s: %v", creatorName, err)
+		return admission.Denied("failed getting quota")
+	}
+
+	peeringInfo := spv.PeeringCache.getOrCreatePeeringInfo(creatorName, quota.Spec.Resources)
+	if err := peeringInfo.testAndUpdateResize(shadowpod, quota.Spec.LimitsEnforcement, *req.DryRun); err != nil {
+		klog.Warning(err)
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
 }
 
 // HandleDelete is the function in charge of handling Deletion requests.
@@ -248,7 +275,7 @@ func decodeShadowPod(decoder admission.Decoder, obj runtime.RawExtension) (shado
 
 var _ webhook.AdmissionHandler = &Mutator{}
 
-// Mutator is the handler used by the Mutating Webhook to mutate shadow pods.
+// Mutator is the handler used by the Mutating Webhook to mutate shadow pods and shadow workloads.
 type Mutator struct {
 	client                   client.Client
 	decoder                  admission.Decoder
@@ -288,10 +315,10 @@ func (spm *Mutator) HandleCreate(req *admission.Request) admission.Response {
 		return admission.Allowed("")
 	}
 
-	sp, err := decodeShadowPod(spm.decoder, req.Object)
+	sp, err := decodeObject(spm.decoder, req, req.Object)
 	if err != nil {
-		klog.Errorf("Failed decoding shadow pod: %v", err)
-		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowPod: %w", err))
+		klog.Errorf("Failed decoding %s: %v", req.Resource.Resource, err)
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of %s: %w", req.Resource.Resource, err))
 	}
 
 	creatorName, err := extractCreatorInfo(&req.UserInfo)
@@ -300,18 +327,7 @@ func (spm *Mutator) HandleCreate(req *admission.Request) admission.Response {
 		return admission.Denied(err.Error())
 	}
 
-	if sp.Labels == nil {
-		sp.Labels = map[string]string{}
-	}
-	sp.Labels[consts.CreatorLabelKey] = creatorName
-
-	marshaledShadowPod, err := json.Marshal(sp)
-	if err != nil {
-		klog.Errorf("Failed marshaling ShadowPod object: %v", err)
-		return admission.Errored(http.StatusInternalServerError, err)
-	}
-
-	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledShadowPod)
+	return setCreatorLabel(req, sp, creatorName)
 }
 
 // HandleUpdate is the function in charge of handling Update requests.
@@ -320,20 +336,20 @@ func (spm *Mutator) HandleUpdate(req *admission.Request) admission.Response {
 		return admission.Allowed("")
 	}
 
-	oldSp, err := decodeShadowPod(spm.decoder, req.OldObject)
+	oldSp, err := decodeObject(spm.decoder, req, req.OldObject)
 	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowPod: %w", err))
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of %s: %w", req.Resource.Resource, err))
 	}
 
-	sp, err := decodeShadowPod(spm.decoder, req.Object)
+	sp, err := decodeObject(spm.decoder, req, req.Object)
 	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of ShadowPod: %w", err))
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed decoding of %s: %w", req.Resource.Resource, err))
 	}
 
-	if oldSp.Labels == nil {
+	if oldSp.GetLabels() == nil {
 		return admission.Denied("missing creator name")
 	}
-	oldCreatorName, ok := oldSp.Labels[consts.CreatorLabelKey]
+	oldCreatorName, ok := oldSp.GetLabels()[consts.CreatorLabelKey]
 	if !ok {
 		return admission.Denied("missing creator name")
 	}
@@ -348,18 +364,34 @@ func (spm *Mutator) HandleUpdate(req *admission.Request) admission.Response {
 		return admission.Denied("creator name cannot be modified")
 	}
 
-	if sp.Labels == nil {
-		sp.Labels = map[string]string{}
+	return setCreatorLabel(req, sp, creatorName)
+}
+
+// setCreatorLabel returns the response patching the object of the given request with the creator label.
+func setCreatorLabel(req *admission.Request, obj client.Object, creatorName string) admission.Response {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
 	}
-	sp.Labels[consts.CreatorLabelKey] = creatorName
+	labels[consts.CreatorLabelKey] = creatorName
+	obj.SetLabels(labels)
 
-	marshaledShadowPod, err := json.Marshal(sp)
+	marshaled, err := json.Marshal(obj)
 	if err != nil {
-		klog.Errorf("Failed marshaling ShadowPod object: %v", err)
+		klog.Errorf("Failed marshaling %s object: %v", req.Resource.Resource, err)
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
-	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledShadowPod)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// decodeObject decodes the given object of the request, which is either a shadow pod or a shadow workload.
+func decodeObject(decoder admission.Decoder, req *admission.Request, raw runtime.RawExtension) (client.Object, error) {
+	var obj client.Object = &offloadingv1beta1.ShadowPod{}
+	if req.Resource.Resource == shadowWorkloadResource {
+		obj = &offloadingv1beta1.ShadowWorkload{}
+	}
+	return obj, decoder.DecodeRaw(raw, obj)
 }
 
 // HandleDelete is the function in charge of handling Deletion requests.
@@ -202,4 +202,49 @@ var _ = Describe("Validating webhook", func() {
 			})
 		})
 	})
+	Describe("Handle update ShadowPod", func() {
+		var oldShadowPod *offloadingv1beta1.ShadowPod
+
+		BeforeEach(func() {
+			containers = []containerResource{{cpu: int64(resourceCPU / 4), memory: int64(resourceMemory / 4)}}
+			oldShadowPod = forgeShadowPodWithResourceRequests(containers, nil)
+			oldShadowPod.Spec.Pod.EphemeralContainers = []corev1.EphemeralContainer{
+				{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}},
+			}
+			fakeNewShadowPod = oldShadowPod.DeepCopy()
+		})
+
+		JustBeforeEach(func() {
+			response = spValidator.Handle(ctx, forgeRequest(admissionv1.Update, fakeNewShadowPod, oldShadowPod))
+		})
+
+		When("an ephemeral container is appended", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod.Spec.Pod.EphemeralContainers = append(fakeNewShadowPod.Spec.Pod.EphemeralContainers,
+					corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-2", Image: "busybox"}})
+			})
+			It("should admit the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+		})
+
+		When("a container is added", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod.Spec.Pod.Containers = append(fakeNewShadowPod.Spec.Pod.Containers,
+					corev1.Container{Name: "sidecar", Image: "busybox"})
+			})
+			It("should deny the request", func() {
+				Expect(response.Allowed).To(BeFalse())
+			})
+		})
+
+		When("an ephemeral container is removed", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod.Spec.Pod.EphemeralContainers = nil
+			})
+			It("should deny the request", func() {
+				Expect(response.Allowed).To(BeFalse())
+			})
+		})
+	})
 })
@@ -0,0 +1,160 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+)
+
+// RevokeVerb is the verb a user must be granted on the Tenants to change the identities revoked for the tenant
+// cluster. It is not granted to the peering users, so that a consumer cannot lift its own revocations.
+const RevokeVerb = "revoke"
+
+// cluster-role
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+type tenantRevocationValidatorWebhook struct {
+	client client.Client
+	tenantDecoder
+}
+
+// NewRevocationValidator returns a new webhook preventing the users not allowed to revoke identities
+// from changing the identities revoked for a Tenant, either by editing it or by deleting it.
+func NewRevocationValidator(cl client.Client) *webhook.Admission {
+	return &webhook.Admission{
+		Handler: &tenantRevocationValidatorWebhook{
+			tenantDecoder: tenantDecoder{
+				decoder: admission.NewDecoder(runtime.NewScheme()),
+			},
+			client: cl,
+		},
+	}
+}
+
+// Handle implements the Tenant revocation validating webhook logic.
+//
+//nolint:gocritic // The signature of this method is imposed by controller runtime.
+func (w *tenantRevocationValidatorWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Update:
+		return w.handleUpdate(ctx, &req)
+	case admissionv1.Delete:
+		return w.handleDelete(ctx, &req)
+	default:
+		return admission.Allowed("")
+	}
+}
+
+func (w *tenantRevocationValidatorWebhook) handleUpdate(ctx context.Context, req *admission.Request) admission.Response {
+	tenant, err := w.DecodeTenant(req.Object)
+	if err != nil {
+		klog.Errorf("Failed decoding Tenant object: %v", err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	oldTenant, err := w.DecodeTenant(req.OldObject)
+	if err != nil {
+		klog.Errorf("Failed decoding old Tenant object: %v", err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if equality.Semantic.DeepEqual(tenant.Spec.RevokedIdentities, oldTenant.Spec.RevokedIdentities) {
+		return admission.Allowed("")
+	}
+	return w.authorize(ctx, req, oldTenant, "change the revoked identities of")
+}
+
+func (w *tenantRevocationValidatorWebhook) handleDelete(ctx context.Context, req *admission.Request) admission.Response {
+	oldTenant, err := w.DecodeTenant(req.OldObject)
+	if err != nil {
+		klog.Errorf("Failed decoding old Tenant object: %v", err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	// Deleting the Tenant would lift the revocations, as the tenant cluster could create a new one.
+	if len(oldTenant.Spec.RevokedIdentities) == 0 {
+		return admission.Allowed("")
+	}
+
+	// The Tenant is deleted along with its tenant namespace.
+	var ns corev1.Namespace
+	if err := w.client.Get(ctx, client.ObjectKey{Name: oldTenant.Namespace}, &ns); err != nil {
+		werr := fmt.Errorf("failed getting the tenant namespace: %v", output.PrettyErr(err))
+		klog.Error(werr)
+		return admission.Errored(http.StatusInternalServerError, werr)
+	}
+	if ns.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+
+	return w.authorize(ctx, req, oldTenant, "delete the revoked")
+}
+
+// authorize allows the request only if the requesting user is granted the revoke verb on the given Tenant.
+func (w *tenantRevocationValidatorWebhook) authorize(ctx context.Context, req *admission.Request,
+	tenant *authv1beta1.Tenant, action string) admission.Response {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extraValues(req.UserInfo.Extra),
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: tenant.Namespace,
+				Verb:      RevokeVerb,
+				Group:     authv1beta1.GroupVersion.Group,
+				Resource:  authv1beta1.TenantResource,
+				Name:      tenant.Name,
+			},
+		},
+	}
+	if err := w.client.Create(ctx, review); err != nil {
+		werr := fmt.Errorf("failed checking the permissions of user %q: %v", req.UserInfo.Username, output.PrettyErr(err))
+		klog.Error(werr)
+		return admission.Errored(http.StatusInternalServerError, werr)
+	}
+
+	if !review.Status.Allowed {
+		return admission.Denied(fmt.Sprintf("user %q is not allowed to %s Tenant %q, as it requires the %q verb",
+			req.UserInfo.Username, action, tenant.Name, RevokeVerb))
+	}
+	return admission.Allowed("")
+}
+
+func extraValues(extra map[string]authenticationv1.ExtraValue) map[string]authorizationv1.ExtraValue {
+	if extra == nil {
+		return nil
+	}
+	values := make(map[string]authorizationv1.ExtraValue, len(extra))
+	for key, value := range extra {
+		values[key] = authorizationv1.ExtraValue(value)
+	}
+	return values
+}
@@ -0,0 +1,147 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	tenantwk "github.com/liqotech/liqo/pkg/webhooks/tenant"
+)
+
+var _ = Describe("Revocation webhook tests", func() {
+	const (
+		clusterID = "fake-cluster"
+		nsName    = "fake-namespace"
+		admin     = "admin"
+		consumer  = "consumer"
+	)
+
+	var (
+		fakeClient client.Client
+		namespace  *corev1.Namespace
+		tenant     *authv1beta1.Tenant
+		reviews    []authorizationv1.SubjectAccessReview
+	)
+
+	revoked := []authv1beta1.RevokedIdentity{{Type: authv1beta1.ControlPlaneIdentityType}}
+
+	request := func(op admissionv1.Operation, user string, oldTenant, newTenant *authv1beta1.Tenant) admission.Request {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			OldObject: tenantToRawExtension(oldTenant),
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+		}}
+		if newTenant != nil {
+			req.Object = tenantToRawExtension(newTenant)
+		}
+		return req
+	}
+
+	JustBeforeEach(func() {
+		reviews = nil
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+						// Only the admin is granted the revoke verb.
+						review.Status.Allowed = review.Spec.User == admin
+						reviews = append(reviews, *review)
+						return nil
+					}
+					return cl.Create(ctx, obj, opts...)
+				},
+			}).Build()
+	})
+
+	BeforeEach(func() {
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+		tenant = generateFakeTenant("fake-tenant", nsName, clusterID)
+		tenant.Spec.RevokedIdentities = revoked
+	})
+
+	When("the revoked identities are changed", func() {
+		It("should deny the users not granted the revoke verb", func() {
+			updated := tenant.DeepCopy()
+			updated.Spec.RevokedIdentities = nil
+
+			res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Update, consumer, tenant, updated))
+			Expect(res.Allowed).To(BeFalse())
+
+			Expect(reviews).To(HaveLen(1))
+			Expect(reviews[0].Spec.ResourceAttributes.Verb).To(Equal(tenantwk.RevokeVerb))
+			Expect(reviews[0].Spec.ResourceAttributes.Resource).To(Equal(authv1beta1.TenantResource))
+			Expect(reviews[0].Spec.ResourceAttributes.Namespace).To(Equal(nsName))
+		})
+
+		It("should allow the users granted the revoke verb", func() {
+			updated := tenant.DeepCopy()
+			updated.Spec.RevokedIdentities = nil
+
+			res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Update, admin, tenant, updated))
+			Expect(res.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the revoked identities are preserved", func() {
+		It("should allow the update without checking the permissions", func() {
+			updated := tenant.DeepCopy()
+			updated.Spec.Signature = []byte("signature")
+
+			res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Update, consumer, tenant, updated))
+			Expect(res.Allowed).To(BeTrue())
+			Expect(reviews).To(BeEmpty())
+		})
+	})
+
+	When("a revoked Tenant is deleted", func() {
+		It("should deny the users not granted the revoke verb", func() {
+			res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Delete, consumer, tenant, nil))
+			Expect(res.Allowed).To(BeFalse())
+		})
+
+		When("the tenant namespace is being deleted", func() {
+			BeforeEach(func() {
+				namespace.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+				namespace.Finalizers = []string{"kubernetes"}
+			})
+
+			It("should allow the deletion", func() {
+				res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Delete, consumer, tenant, nil))
+				Expect(res.Allowed).To(BeTrue())
+			})
+		})
+	})
+
+	When("a Tenant without revoked identities is deleted", func() {
+		It("should allow the deletion", func() {
+			tenant.Spec.RevokedIdentities = nil
+			res := tenantwk.NewRevocationValidator(fakeClient).Handle(context.TODO(), request(admissionv1.Delete, consumer, tenant, nil))
+			Expect(res.Allowed).To(BeTrue())
+		})
+	})
+})